@@ -0,0 +1,344 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"errors"
+	"math"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/transform"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// setTightBBoxes sets the bounding boxes of the normal and down appearance
+// streams of the appearance dictionary `apDict` to the inked bounds of their
+// contents, extended by `margin` on each side. The bounding boxes are never
+// extended beyond the original ones. Appearance streams with no marks are
+// left unchanged.
+func setTightBBoxes(apDict *core.PdfObjectDictionary, margin float64) error {
+	for _, key := range []core.PdfObjectName{"N", "D"} {
+		obj := apDict.Get(key)
+		if stream, ok := core.GetStream(obj); ok {
+			if err := setTightBBox(stream, margin); err != nil {
+				return err
+			}
+			continue
+		}
+		states, ok := core.GetDict(obj)
+		if !ok {
+			continue
+		}
+		for _, state := range states.Keys() {
+			stream, ok := core.GetStream(states.Get(state))
+			if !ok {
+				continue
+			}
+			if err := setTightBBox(stream, margin); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setTightBBox sets the bounding box of the appearance stream `stream` to
+// the inked bounds of its contents, extended by `margin` on each side.
+func setTightBBox(stream *core.PdfObjectStream, margin float64) error {
+	xform, err := model.NewXObjectFormFromStream(stream)
+	if err != nil {
+		return err
+	}
+	arr, ok := core.GetArray(xform.BBox)
+	if !ok {
+		common.Log.Debug("ERROR: appearance stream BBox missing")
+		return errors.New("appearance stream BBox missing")
+	}
+	bbox, err := model.NewPdfRectangle(*arr)
+	if err != nil {
+		return err
+	}
+
+	content, err := xform.GetContentStream()
+	if err != nil {
+		return err
+	}
+	bounds, ok, err := getInkBounds(string(content), xform.Resources)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	llx := math.Max(bbox.Llx, bounds.Llx-margin)
+	lly := math.Max(bbox.Lly, bounds.Lly-margin)
+	urx := math.Min(bbox.Urx, bounds.Urx+margin)
+	ury := math.Min(bbox.Ury, bounds.Ury+margin)
+	if urx <= llx || ury <= lly {
+		return nil
+	}
+	stream.PdfObjectDictionary.Set("BBox", core.MakeArrayFromFloats([]float64{llx, lly, urx, ury}))
+	return nil
+}
+
+// inkTextState represents the text state parameters used for computing the
+// bounds of the text shown by an appearance stream.
+type inkTextState struct {
+	font     *model.PdfFont
+	fontSize float64
+	tc       float64
+	tw       float64
+	th       float64
+	tl       float64
+	rise     float64
+}
+
+// inkState represents the parts of the graphics state, which are not tracked
+// by the content stream processor, used for computing the inked bounds.
+type inkState struct {
+	lineWidth float64
+	text      inkTextState
+}
+
+// inkBounds accumulates the inked bounds of an appearance stream.
+type inkBounds struct {
+	llx, lly, urx, ury float64
+	found              bool
+}
+
+// add extends the bounds with the point `x`,`y` (in user space), widened by
+// `pad` on each side.
+func (b *inkBounds) add(x, y, pad float64) {
+	if !b.found {
+		b.llx, b.lly = math.Inf(1), math.Inf(1)
+		b.urx, b.ury = math.Inf(-1), math.Inf(-1)
+		b.found = true
+	}
+	b.llx, b.urx = math.Min(b.llx, x-pad), math.Max(b.urx, x+pad)
+	b.lly, b.ury = math.Min(b.lly, y-pad), math.Max(b.ury, y+pad)
+}
+
+// getInkBounds returns the bounds of the marks (painted paths and shown text)
+// of the content stream `content`, in the coordinate space of the stream.
+// The fonts used by the text are loaded from `resources`. The returned flag
+// is false if the content stream does not contain any marks.
+func getInkBounds(content string, resources *model.PdfPageResources) (*model.PdfRectangle, bool, error) {
+	ops, err := contentstream.NewContentStreamParser(content).Parse()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var bounds inkBounds
+	var stack []inkState
+	state := inkState{lineWidth: 1, text: inkTextState{th: 1}}
+	fonts := map[core.PdfObjectName]*model.PdfFont{}
+
+	var path [][2]float64
+	var tm, tlm transform.Matrix
+
+	// showText adds the bounds of the glyphs of `data` and advances the text
+	// matrix by their widths.
+	showText := func(data []byte, ctm transform.Matrix) {
+		ts := state.text
+		if ts.font == nil {
+			return
+		}
+		ascent, descent := 1000.0, -250.0
+		if metrics, err := ts.font.GetFontMetrics(); err == nil && metrics.Ascent > 0 {
+			ascent, descent = metrics.Ascent, metrics.Descent
+		}
+		ymin := descent*ts.fontSize/1000 + ts.rise
+		ymax := ascent*ts.fontSize/1000 + ts.rise
+
+		for _, code := range ts.font.BytesToCharcodes(data) {
+			metrics, _ := ts.font.GetCharMetrics(code)
+			w := metrics.Wx * ts.fontSize / 1000 * ts.th
+
+			trm := tm.Mult(ctm)
+			for _, p := range [][2]float64{{0, ymin}, {0, ymax}, {w, ymin}, {w, ymax}} {
+				x, y := trm.Transform(p[0], p[1])
+				bounds.add(x, y, 0)
+			}
+
+			adv := metrics.Wx*ts.fontSize/1000 + ts.tc
+			if code == 32 && !ts.font.IsCID() {
+				adv += ts.tw
+			}
+			tm.Concat(transform.TranslationMatrix(adv*ts.th, 0))
+		}
+	}
+
+	nextLine := func() {
+		tlm.Concat(transform.TranslationMatrix(0, -state.text.tl))
+		tm = tlm
+	}
+
+	processor := contentstream.NewContentStreamProcessor(*ops)
+	processor.AddHandler(contentstream.HandlerConditionEnumAllOperands, "",
+		func(op *contentstream.ContentStreamOperation, gs contentstream.GraphicsState, resources *model.PdfPageResources) error {
+			params, _ := core.GetNumbersAsFloat(op.Params)
+			hasParams := func(n int) bool {
+				return len(params) >= n
+			}
+
+			switch op.Operand {
+			case "q":
+				stack = append(stack, state)
+			case "Q":
+				if len(stack) > 0 {
+					state = stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+				}
+			case "w":
+				if hasParams(1) {
+					state.lineWidth = params[0]
+				}
+
+			// Path construction.
+			case "m", "l":
+				if hasParams(2) {
+					x, y := gs.Transform(params[0], params[1])
+					path = append(path, [2]float64{x, y})
+				}
+			case "c", "v", "y":
+				for i := 0; i+1 < len(params); i += 2 {
+					x, y := gs.Transform(params[i], params[i+1])
+					path = append(path, [2]float64{x, y})
+				}
+			case "re":
+				if hasParams(4) {
+					x, y, w, h := params[0], params[1], params[2], params[3]
+					for _, p := range [][2]float64{{x, y}, {x + w, y}, {x, y + h}, {x + w, y + h}} {
+						px, py := gs.Transform(p[0], p[1])
+						path = append(path, [2]float64{px, py})
+					}
+				}
+
+			// Path painting.
+			case "f", "F", "f*", "S", "s", "B", "B*", "b", "b*":
+				var pad float64
+				if op.Operand != "f" && op.Operand != "F" && op.Operand != "f*" {
+					scale := math.Max(gs.CTM.ScalingFactorX(), gs.CTM.ScalingFactorY())
+					pad = state.lineWidth * scale / 2
+				}
+				for _, p := range path {
+					bounds.add(p[0], p[1], pad)
+				}
+				path = nil
+			case "n":
+				path = nil
+
+			// Text.
+			case "BT":
+				tm = transform.IdentityMatrix()
+				tlm = tm
+			case "Tf":
+				if len(op.Params) < 2 {
+					break
+				}
+				name, ok := core.GetName(op.Params[0])
+				if !ok {
+					break
+				}
+				size, err := core.GetNumberAsFloat(op.Params[1])
+				if err != nil {
+					break
+				}
+				font, ok := fonts[*name]
+				if !ok && resources != nil {
+					if obj, has := resources.GetFontByName(*name); has {
+						font, err = model.NewPdfFontFromPdfObject(obj)
+						if err != nil {
+							common.Log.Debug("ERROR: unable to load font %s: %v", *name, err)
+							font = nil
+						}
+					}
+					fonts[*name] = font
+				}
+				state.text.font = font
+				state.text.fontSize = size
+			case "Tc":
+				if hasParams(1) {
+					state.text.tc = params[0]
+				}
+			case "Tw":
+				if hasParams(1) {
+					state.text.tw = params[0]
+				}
+			case "Tz":
+				if hasParams(1) {
+					state.text.th = params[0] / 100
+				}
+			case "TL":
+				if hasParams(1) {
+					state.text.tl = params[0]
+				}
+			case "Ts":
+				if hasParams(1) {
+					state.text.rise = params[0]
+				}
+			case "Td", "TD":
+				if hasParams(2) {
+					if op.Operand == "TD" {
+						state.text.tl = -params[1]
+					}
+					tlm.Concat(transform.TranslationMatrix(params[0], params[1]))
+					tm = tlm
+				}
+			case "Tm":
+				if hasParams(6) {
+					tlm = transform.NewMatrix(params[0], params[1], params[2], params[3], params[4], params[5])
+					tm = tlm
+				}
+			case "T*":
+				nextLine()
+			case "Tj", "'", "\"":
+				if op.Operand != "Tj" {
+					nextLine()
+				}
+				if len(op.Params) == 0 {
+					break
+				}
+				if op.Operand == "\"" && len(op.Params) == 3 {
+					state.text.tw, _ = core.GetNumberAsFloat(op.Params[0])
+					state.text.tc, _ = core.GetNumberAsFloat(op.Params[1])
+				}
+				if str, ok := core.GetString(op.Params[len(op.Params)-1]); ok {
+					showText(str.Bytes(), gs.CTM)
+				}
+			case "TJ":
+				if len(op.Params) != 1 {
+					break
+				}
+				arr, ok := core.GetArray(op.Params[0])
+				if !ok {
+					break
+				}
+				for _, obj := range arr.Elements() {
+					if str, ok := core.GetString(obj); ok {
+						showText(str.Bytes(), gs.CTM)
+						continue
+					}
+					if val, err := core.GetNumberAsFloat(obj); err == nil {
+						adv := -val / 1000 * state.text.fontSize * state.text.th
+						tm.Concat(transform.TranslationMatrix(adv, 0))
+					}
+				}
+			}
+			return nil
+		})
+
+	if err := processor.Process(resources); err != nil {
+		return nil, false, err
+	}
+	if !bounds.found {
+		return nil, false, nil
+	}
+	return &model.PdfRectangle{Llx: bounds.llx, Lly: bounds.lly, Urx: bounds.urx, Ury: bounds.ury}, true, nil
+}
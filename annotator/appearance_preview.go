@@ -0,0 +1,168 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"errors"
+	"math"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/transform"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// NewFieldAppearancePreview generates the appearance of the widget annotation
+// `wa` of `field`, using the form resources `dr` and the appearance `style`,
+// and returns a writer for a standalone single page document displaying the
+// generated normal appearance. The page has the size of the widget rectangle.
+// For fields with multiple appearance states (e.g. checkboxes), the state
+// selected by the appearance state (AS) of the widget is displayed, or the
+// "on" state if not specified.
+//
+// The preview is meant for the visual inspection of appearance styles: the
+// field, the widget and the resources are not modified, as the appearance is
+// generated using a copy of the resources and it is not set on the widget.
+func NewFieldAppearancePreview(field *model.PdfField, wa *model.PdfAnnotationWidget,
+	dr *model.PdfPageResources, style AppearanceStyle) (*model.PdfWriter, error) {
+	if field == nil || wa == nil {
+		return nil, errors.New("field or widget not specified")
+	}
+
+	rectArr, ok := core.GetArray(wa.Rect)
+	if !ok {
+		return nil, errors.New("invalid widget rectangle")
+	}
+	rect, err := model.NewPdfRectangle(*rectArr)
+	if err != nil {
+		return nil, err
+	}
+	width, height := rect.Width(), rect.Height()
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("invalid widget rectangle")
+	}
+
+	// Generate the appearance using a copy of the form resources.
+	form := model.NewPdfAcroForm()
+	if dr != nil {
+		drDict, ok := core.GetDict(core.DeepCopy(dr.ToPdfObject()))
+		if !ok {
+			return nil, errors.New("invalid form resources")
+		}
+		form.DR, err = model.NewPdfPageResourcesFromDict(drDict)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fa := FieldAppearance{}
+	fa.SetStyle(style)
+	apDict, err := fa.GenerateAppearanceDict(form, field, wa)
+	if err != nil {
+		return nil, err
+	}
+	if apDict == nil {
+		common.Log.Debug("ERROR: appearance generation not supported for field %s", field.PartialName())
+		return nil, errors.New("appearance generation not supported for field")
+	}
+
+	stream, err := getPreviewAppearanceStream(apDict.Get("N"), wa)
+	if err != nil {
+		return nil, err
+	}
+	xform, err := model.NewXObjectFormFromStream(stream)
+	if err != nil {
+		return nil, err
+	}
+
+	page := model.NewPdfPage()
+	page.MediaBox = &model.PdfRectangle{Urx: width, Ury: height}
+	if err := page.Resources.SetXObjectFormByName("Appearance", xform); err != nil {
+		return nil, err
+	}
+
+	// Map the transformed appearance bounding box onto the page, as done by
+	// viewers when drawing the appearance in the widget rectangle.
+	bbox := &model.PdfRectangle{Urx: width, Ury: height}
+	if arr, ok := core.GetArray(xform.BBox); ok {
+		if bbox, err = model.NewPdfRectangle(*arr); err != nil {
+			return nil, err
+		}
+	}
+	matrix := transform.IdentityMatrix()
+	if arr, ok := core.GetArray(xform.Matrix); ok {
+		vals, err := arr.ToFloat64Array()
+		if err != nil || len(vals) != 6 {
+			return nil, errors.New("invalid appearance matrix")
+		}
+		matrix = transform.NewMatrix(vals[0], vals[1], vals[2], vals[3], vals[4], vals[5])
+	}
+
+	llx, lly := math.Inf(1), math.Inf(1)
+	urx, ury := math.Inf(-1), math.Inf(-1)
+	for _, corner := range [][2]float64{
+		{bbox.Llx, bbox.Lly}, {bbox.Llx, bbox.Ury},
+		{bbox.Urx, bbox.Lly}, {bbox.Urx, bbox.Ury},
+	} {
+		x, y := matrix.Transform(corner[0], corner[1])
+		llx, urx = math.Min(llx, x), math.Max(urx, x)
+		lly, ury = math.Min(lly, y), math.Max(ury, y)
+	}
+	sx, sy := 1.0, 1.0
+	if urx > llx {
+		sx = width / (urx - llx)
+	}
+	if ury > lly {
+		sy = height / (ury - lly)
+	}
+
+	cc := contentstream.NewContentCreator()
+	cc.Add_q().
+		Add_cm(sx, 0, 0, sy, -llx*sx, -lly*sy).
+		Add_Do("Appearance").
+		Add_Q()
+	if err := page.SetContentStreams([]string{cc.String()}, style.streamEncoder()); err != nil {
+		return nil, err
+	}
+
+	w := model.NewPdfWriter()
+	if err := w.AddPage(page); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// getPreviewAppearanceStream returns the appearance stream displayed by the
+// preview, given the normal appearance `obj` of widget `wa`.
+func getPreviewAppearanceStream(obj core.PdfObject, wa *model.PdfAnnotationWidget) (*core.PdfObjectStream, error) {
+	if stream, ok := core.GetStream(obj); ok {
+		return stream, nil
+	}
+	states, ok := core.GetDict(obj)
+	if !ok || len(states.Keys()) == 0 {
+		return nil, errors.New("missing normal appearance")
+	}
+
+	// Select the current state, or the first state which is not Off.
+	state := states.Keys()[0]
+	if as, ok := core.GetName(wa.AS); ok && states.Get(*as) != nil {
+		state = *as
+	} else {
+		for _, key := range states.Keys() {
+			if key != "Off" {
+				state = key
+				break
+			}
+		}
+	}
+
+	stream, ok := core.GetStream(states.Get(state))
+	if !ok {
+		return nil, errors.New("invalid appearance state stream")
+	}
+	return stream, nil
+}
@@ -0,0 +1,258 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/codabar"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/code39"
+	"github.com/boombuler/barcode/code93"
+	"github.com/boombuler/barcode/ean"
+	"github.com/boombuler/barcode/qr"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// QRErrorCorrectionLevel represents the error correction level of QR codes,
+// which determines the fraction of the code which can be restored if damaged.
+type QRErrorCorrectionLevel int
+
+const (
+	// QRErrorCorrectionLow recovers 7% of the data.
+	QRErrorCorrectionLow QRErrorCorrectionLevel = iota
+
+	// QRErrorCorrectionMedium recovers 15% of the data.
+	QRErrorCorrectionMedium
+
+	// QRErrorCorrectionQuartile recovers 25% of the data.
+	QRErrorCorrectionQuartile
+
+	// QRErrorCorrectionHigh recovers 30% of the data.
+	QRErrorCorrectionHigh
+)
+
+// BarcodeSymbology represents the symbology of one-dimensional barcodes.
+type BarcodeSymbology int
+
+const (
+	// BarcodeCode128 represents Code 128 barcodes.
+	BarcodeCode128 BarcodeSymbology = iota
+
+	// BarcodeCode39 represents Code 39 barcodes.
+	BarcodeCode39
+
+	// BarcodeCode93 represents Code 93 barcodes.
+	BarcodeCode93
+
+	// BarcodeEAN represents EAN-8 or EAN-13 barcodes, depending on the
+	// length of the content.
+	BarcodeEAN
+
+	// BarcodeCodabar represents Codabar barcodes.
+	BarcodeCodabar
+)
+
+// BarcodeOptions defines the style of generated barcodes and QR codes.
+type BarcodeOptions struct {
+	// Symbology is the symbology of one-dimensional barcodes.
+	// Not used for QR codes.
+	Symbology BarcodeSymbology
+
+	// ErrorCorrection is the error correction level of QR codes.
+	// Not used for one-dimensional barcodes.
+	ErrorCorrection QRErrorCorrectionLevel
+
+	// Color is the color of the bars or modules. Defaults to black.
+	Color model.PdfColor
+
+	// BackgroundColor is the fill color of the barcode area. The
+	// background is not filled if not set.
+	BackgroundColor model.PdfColor
+
+	// QuietZone is the size of the blank margin around the code, in
+	// modules. The margin is only added horizontally for one-dimensional
+	// barcodes.
+	QuietZone int
+}
+
+// NewQRCodeXObject returns a form XObject of size `size`x`size` which draws
+// the QR code encoding `content`, using vector operations. The form can be
+// placed on a page using DrawXObjectForm or used as the appearance of a field
+// widget of the same size.
+func NewQRCodeXObject(content string, size float64, opts *BarcodeOptions) (*model.XObjectForm, error) {
+	var o BarcodeOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	var level qr.ErrorCorrectionLevel
+	switch o.ErrorCorrection {
+	case QRErrorCorrectionLow:
+		level = qr.L
+	case QRErrorCorrectionMedium:
+		level = qr.M
+	case QRErrorCorrectionQuartile:
+		level = qr.Q
+	case QRErrorCorrectionHigh:
+		level = qr.H
+	default:
+		return nil, fmt.Errorf("invalid QR error correction level: %d", o.ErrorCorrection)
+	}
+
+	code, err := qr.Encode(content, level, qr.Auto)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to encode QR code: %v", err)
+		return nil, err
+	}
+	return newBarcodeXObject(code, size, size, o, true)
+}
+
+// NewBarcodeXObject returns a form XObject of size `width`x`height` which
+// draws the one-dimensional barcode encoding `content`, using vector
+// operations. The symbology is specified by the options. The form can be
+// placed on a page using DrawXObjectForm or used as the appearance of a field
+// widget of the same size.
+func NewBarcodeXObject(content string, width, height float64, opts *BarcodeOptions) (*model.XObjectForm, error) {
+	var o BarcodeOptions
+	if opts != nil {
+		o = *opts
+	}
+
+	var code barcode.Barcode
+	var err error
+	switch o.Symbology {
+	case BarcodeCode128:
+		code, err = code128.Encode(content)
+	case BarcodeCode39:
+		code, err = code39.Encode(content, false, true)
+	case BarcodeCode93:
+		code, err = code93.Encode(content, true, true)
+	case BarcodeEAN:
+		code, err = ean.Encode(content)
+	case BarcodeCodabar:
+		code, err = codabar.Encode(content)
+	default:
+		return nil, fmt.Errorf("invalid barcode symbology: %d", o.Symbology)
+	}
+	if err != nil {
+		common.Log.Debug("ERROR: unable to encode barcode: %v", err)
+		return nil, err
+	}
+	return newBarcodeXObject(code, width, height, o, false)
+}
+
+// newBarcodeXObject returns a form XObject which draws the modules of the
+// encoded barcode as rectangles, scaled to `width`x`height`. The quiet zone
+// is only added vertically for two-dimensional codes.
+func newBarcodeXObject(code barcode.Barcode, width, height float64, o BarcodeOptions, is2D bool) (*model.XObjectForm, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.New("invalid barcode size")
+	}
+	if o.Color == nil {
+		o.Color = model.NewPdfColorDeviceGray(0)
+	}
+	if o.QuietZone < 0 {
+		o.QuietZone = 0
+	}
+
+	bounds := code.Bounds()
+	cols, rows := bounds.Dx(), bounds.Dy()
+	if cols == 0 || rows == 0 {
+		return nil, errors.New("empty barcode")
+	}
+
+	quietX, quietY := o.QuietZone, 0
+	if is2D {
+		quietY = o.QuietZone
+	}
+	moduleWidth := width / float64(cols+2*quietX)
+	moduleHeight := height / float64(rows+2*quietY)
+
+	cc := contentstream.NewContentCreator()
+	if o.BackgroundColor != nil {
+		cc.SetNonStrokingColor(o.BackgroundColor).
+			Add_re(0, 0, width, height).
+			Add_f()
+	}
+
+	// Draw horizontal runs of dark modules as single rectangles.
+	cc.SetNonStrokingColor(o.Color)
+	for row := 0; row < rows; row++ {
+		// The image rows are ordered top to bottom.
+		y := height - float64(quietY+row+1)*moduleHeight
+		for col := 0; col < cols; {
+			if !isDarkModule(code.At(bounds.Min.X+col, bounds.Min.Y+row)) {
+				col++
+				continue
+			}
+
+			start := col
+			for col < cols && isDarkModule(code.At(bounds.Min.X+col, bounds.Min.Y+row)) {
+				col++
+			}
+			x := float64(quietX+start) * moduleWidth
+			cc.Add_re(x, y, float64(col-start)*moduleWidth, moduleHeight)
+		}
+	}
+	cc.Add_f()
+
+	xform := model.NewXObjectForm()
+	xform.Resources = model.NewPdfPageResources()
+	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, width, height})
+	if err := xform.SetContentStream(cc.Bytes(), defStreamEncoder()); err != nil {
+		return nil, err
+	}
+	return xform, nil
+}
+
+// isDarkModule checks if the color of a barcode module is dark.
+func isDarkModule(c color.Color) bool {
+	return color.GrayModel.Convert(c).(color.Gray).Y < 128
+}
+
+// DrawXObjectForm draws the form XObject on the page, scaled to fit the
+// rectangle `rect`. The form is added to the page resources.
+func DrawXObjectForm(page *model.PdfPage, xform *model.XObjectForm, rect model.PdfRectangle) error {
+	if page == nil || xform == nil {
+		return errors.New("page and form must be specified")
+	}
+
+	bboxArr, ok := core.GetArray(xform.BBox)
+	if !ok {
+		return errors.New("invalid form BBox")
+	}
+	bbox, err := model.NewPdfRectangle(*bboxArr)
+	if err != nil {
+		return err
+	}
+	if bbox.Width() == 0 || bbox.Height() == 0 {
+		return errors.New("invalid form BBox")
+	}
+
+	if page.Resources == nil {
+		page.Resources = model.NewPdfPageResources()
+	}
+	name := page.Resources.GenerateXObjectName()
+	if err := page.Resources.SetXObjectFormByName(name, xform); err != nil {
+		return err
+	}
+
+	sx, sy := rect.Width()/bbox.Width(), rect.Height()/bbox.Height()
+	cc := contentstream.NewContentCreator()
+	cc.Add_q().
+		Add_cm(sx, 0, 0, sy, rect.Llx-bbox.Llx*sx, rect.Lly-bbox.Lly*sy).
+		Add_Do(name).
+		Add_Q()
+	return appendPageContent(page, cc)
+}
@@ -0,0 +1,100 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"testing"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// barcodeArea returns the total area of the rectangles drawn by the form.
+func barcodeArea(t *testing.T, xform *model.XObjectForm) float64 {
+	apDict := core.MakeDict()
+	apDict.Set("N", xform.ToPdfObject())
+	_, ops := getAppearanceOps(t, apDict)
+
+	var area float64
+	for _, op := range findOps(ops, "re") {
+		params, err := core.GetNumbersAsFloat(op.Params)
+		require.NoError(t, err)
+		area += params[2] * params[3]
+	}
+	return area
+}
+
+// countDarkModules returns the number of dark modules of the barcode.
+func countDarkModules(code barcode.Barcode) int {
+	var count int
+	b := code.Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if isDarkModule(code.At(x, y)) {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func TestQRCodeXObject(t *testing.T) {
+	opts := &BarcodeOptions{ErrorCorrection: QRErrorCorrectionHigh, QuietZone: 4}
+	xform, err := NewQRCodeXObject("https://example.com", 100, opts)
+	require.NoError(t, err)
+
+	// Quiet zone of 4 modules on each side.
+	code, err := qr.Encode("https://example.com", qr.H, qr.Auto)
+	require.NoError(t, err)
+	module := 100.0 / float64(code.Bounds().Dx()+8)
+	dark := countDarkModules(code)
+	require.InDelta(t, float64(dark)*module*module, barcodeArea(t, xform), 1e-6)
+
+	_, err = NewQRCodeXObject("test", 0, nil)
+	require.Error(t, err)
+	_, err = NewQRCodeXObject("test", 100, &BarcodeOptions{ErrorCorrection: 5})
+	require.Error(t, err)
+}
+
+func TestBarcodeXObject(t *testing.T) {
+	xform, err := NewBarcodeXObject("TRACK-12345", 200, 50, &BarcodeOptions{
+		BackgroundColor: model.NewPdfColorDeviceGray(1),
+	})
+	require.NoError(t, err)
+
+	code, err := code128.Encode("TRACK-12345")
+	require.NoError(t, err)
+	module := 200.0 / float64(code.Bounds().Dx())
+	dark := countDarkModules(code) / code.Bounds().Dy()
+	// The background covers the whole area.
+	require.InDelta(t, float64(dark)*module*50+200*50, barcodeArea(t, xform), 1e-6)
+
+	_, err = NewBarcodeXObject("123", 200, 50, &BarcodeOptions{Symbology: BarcodeEAN})
+	require.Error(t, err)
+
+	// Place the barcode on a page.
+	page := model.NewPdfPage()
+	rect := model.PdfRectangle{Llx: 10, Lly: 20, Urx: 110, Ury: 45}
+	require.NoError(t, DrawXObjectForm(page, xform, rect))
+	require.True(t, page.Resources.HasXObjectByName("XObj1"))
+
+	content, err := page.GetAllContentStreams()
+	require.NoError(t, err)
+	ops, err := contentstream.NewContentStreamParser(content).Parse()
+	require.NoError(t, err)
+	cms := findOps(ops, "cm")
+	require.Len(t, cms, 1)
+	params, err := core.GetNumbersAsFloat(cms[0].Params)
+	require.NoError(t, err)
+	require.Equal(t, []float64{0.5, 0, 0, 0.5, 10, 20}, params)
+	require.Len(t, findOps(ops, "Do"), 1)
+}
@@ -7,9 +7,14 @@ package annotator
 
 import (
 	"errors"
+	"fmt"
 	"math"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
 	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
@@ -26,10 +31,22 @@ import (
 // If `OnlyIfMissing` is true, the field appearance is generated only for fields that do not have an
 // appearance stream specified.
 // If `RegenerateTextFields` is true, all text fields are regenerated (even if OnlyIfMissing is true).
+// If `AppendContentStreams` is true, WrapContentStream wraps the page contents by adding content
+// streams to the page Contents array, instead of rewriting the page contents as a single stream.
+// If `SkipFunc` is set, no appearance is generated for the fields for which it returns true.
 type FieldAppearance struct {
 	OnlyIfMissing        bool
 	RegenerateTextFields bool
-	style                *AppearanceStyle
+	AppendContentStreams bool
+
+	// SkipFunc, if set, is called with each field before generating its
+	// appearance. If it returns true, the field is skipped: no appearance is
+	// generated and the appearance dictionary returned for the field is nil
+	// (e.g. for leaving some fields untouched, such as the fields whose
+	// names start with "internal.").
+	SkipFunc func(field *model.PdfField) bool
+
+	style *AppearanceStyle
 }
 
 // AppearanceStyle defines style parameters for appearance stream generation.
@@ -37,9 +54,28 @@ type AppearanceStyle struct {
 	// How much of Rect height to fill when autosizing text.
 	AutoFontSizeFraction float64
 
+	// FieldAutoFontSizeFractions overrides the AutoFontSizeFraction for
+	// specific fields. The map keys represent the names of the fields (which
+	// can be specified by their partial or full names).
+	FieldAutoFontSizeFractions map[string]float64
+
 	// CheckmarkRune is a rune used for check mark in checkboxes (for ZapfDingbats font).
 	CheckmarkRune rune
 
+	// CheckmarkStyle specifies how the check mark of checkboxes is drawn.
+	// By default, the CheckmarkRune glyph of the ZapfDingbats font is used.
+	CheckmarkStyle CheckmarkStyle
+
+	// CheckboxDrawEmptyBox draws the outline of an empty box in the Off
+	// appearance of checkboxes, independently of the BorderSize of the style.
+	// The box is stroked using CheckboxBoxLineWidth (1 if not set) and
+	// CheckboxBoxColor (black if not set). If CheckboxBoxBehindCheck is set,
+	// the box is also drawn behind the check mark of the On appearance.
+	CheckboxDrawEmptyBox   bool
+	CheckboxBoxLineWidth   float64
+	CheckboxBoxColor       model.PdfColor
+	CheckboxBoxBehindCheck bool
+
 	BorderSize  float64
 	BorderColor model.PdfColor
 	FillColor   model.PdfColor
@@ -51,11 +87,179 @@ type AppearanceStyle struct {
 	// Visual guide checking alignment of field contents (debugging).
 	DrawAlignmentReticle bool
 
+	// ReticleColor and ReticleLineWidth are the color and the line width of
+	// the alignment reticle. If not set, the border color and a line width
+	// of 0.2 are used.
+	ReticleColor     model.PdfColor
+	ReticleLineWidth float64
+
+	// ClipToRect clips the contents of text, combobox and list box field
+	// appearances to the annotation rectangle, so that text overflowing the
+	// field (e.g. when using a large font size) is hidden.
+	ClipToRect bool
+
+	// TightBBox sets the bounding box (BBox) of the generated appearance
+	// streams to the inked bounds of their contents (text and marks),
+	// extended by TightBBoxMargin on each side. This reduces overdraw when
+	// the appearances are flattened or reused as stamps. Note that viewers
+	// scale the BBox of widget appearances to the annotation rectangle, so
+	// the option should not be used for appearances displayed as widgets.
+	TightBBox       bool
+	TightBBoxMargin float64
+
+	// Underline and Strikethrough decorate each line of text field contents
+	// with a line drawn below the baseline or through the middle of the
+	// x-height, respectively. The thickness of the lines scales with the
+	// font size. The decorations are also drawn if they are specified by
+	// the text-decoration style of the rich text value (RV) of the field.
+	Underline     bool
+	Strikethrough bool
+
 	// Allow field MK appearance characteristics to override style settings.
 	AllowMK bool
 
 	// Fonts holds appearance styles for fonts.
 	Fonts *AppearanceFontStyle
+
+	// CompressionLevel is the zlib compression level used for encoding the
+	// generated appearance streams. A value of 0 selects the default level.
+	CompressionLevel int
+
+	// Kerning enables the kerning of text field contents, using the kerning
+	// pairs of the field font, where available. Kerning adjustments are
+	// taken into account when measuring text and are applied through the
+	// TJ operator. Disabled by default, as it requires more processing.
+	Kerning bool
+
+	// CharSpacing and WordSpacing specify the character spacing (Tc) and the
+	// word spacing (Tw), in unscaled text space units, used for showing the
+	// contents of text and combobox fields. The spacing is taken into account
+	// when measuring the text for wrapping, autosizing and alignment.
+	CharSpacing float64
+	WordSpacing float64
+
+	// Vertical enables the vertical writing mode for the contents of text
+	// fields (e.g. for CJK text): the glyphs are stacked from top to bottom,
+	// in columns laid out from right to left. When autosizing, the text is
+	// measured against the height of the field.
+	Vertical bool
+
+	// ListboxSelectionColor is the background color of the selected options
+	// of list box fields. If not set, a light blue color is used.
+	ListboxSelectionColor model.PdfColor
+
+	// TextMarginLeft, TextMarginRight, TextMarginTop and TextMarginBottom
+	// specify the insets of the area available for the text of text and
+	// combobox field appearances, which is used for autosizing and aligning
+	// the text. If TextMarginLeft is not set, left aligned text is indented
+	// by 2 points, while centered and right aligned text is aligned to the
+	// full width of the field.
+	TextMarginLeft   float64
+	TextMarginRight  float64
+	TextMarginTop    float64
+	TextMarginBottom float64
+
+	// WrapMode specifies how the lines of multiline text fields, which do
+	// not fit the width of the field, are wrapped. The wrap modes other than
+	// WrapModeDefault also apply to autosized text, in which case the font
+	// size is reduced until the wrapped lines fit the height of the field.
+	WrapMode WrapMode
+
+	// Hyphenate, if set, is used for hyphenating the words crossing the
+	// width of multiline text fields when wrapping. It returns the rune
+	// offsets of the points at which `word` can be hyphenated. The words
+	// are broken at the last point for which the hyphenated text fits.
+	Hyphenate func(word string) []int
+
+	// FormatFunc, if set, is used for transforming the values of text
+	// fields before generating their appearances, e.g. for honoring the
+	// format actions (such as AFDate_FormatEx) of the fields. Only the
+	// appearance text is affected; the field value (V) is left unchanged.
+	// See FormatValue, FormatDate and FormatNumber for built-in formatters.
+	FormatFunc func(field *model.PdfField, value string) string
+
+	// RequiredBorderColor and RequiredBorderSize specify the border drawn,
+	// instead of the normal border, around the appearances of the fields
+	// which have the Required flag set (e.g. for highlighting the required
+	// fields of flattened forms). The required border is only drawn if
+	// RequiredBorderSize is greater than 0. If RequiredBorderColor is not
+	// set, the normal border color is used.
+	RequiredBorderColor model.PdfColor
+	RequiredBorderSize  float64
+
+	// Ellipsis truncates the values of combobox fields which do not fit the
+	// width of the field, when the font size is not autosized, and marks the
+	// truncation with an ellipsis (…). The end of the value is truncated,
+	// unless the field is right aligned, in which case the beginning of the
+	// value is truncated instead.
+	Ellipsis bool
+
+	// EllipsisRune is the rune marking the truncation of the values
+	// shortened by the Ellipsis option. Defaults to '…'. If the font has no
+	// glyph for the rune, three periods (...) are used instead.
+	EllipsisRune rune
+
+	// RenderPasswordMask enables generating appearances for password text
+	// fields, which show the PasswordRune repeated once per character of
+	// the field value. The value itself is never embedded in the appearance.
+	// If not enabled, no appearance is generated for password fields.
+	RenderPasswordMask bool
+
+	// PasswordRune is the rune used for masking the characters of password
+	// fields. If not set, the bullet character (•) is used.
+	PasswordRune rune
+
+	// CombPadding specifies how the values of comb fields, which are shorter
+	// than the number of cells of the field (MaxLen), are padded with the
+	// CombPadRune (0 if not set) before being distributed into the cells.
+	CombPadding CombPadding
+	CombPadRune rune
+
+	// CombAlignNumbersRight right justifies the numeric values (amounts) of
+	// comb fields which do not specify a quadding (Q), so that the digits
+	// are aligned to the last cells of the field.
+	CombAlignNumbersRight bool
+
+	// LineAlignFunc, if set, returns the horizontal alignment of the line
+	// at `lineIndex` (starting from 0) of text field appearances, overriding
+	// the quadding (Q) of the field, e.g. for centering the first line of
+	// the value and left aligning the following lines. If not set, all the
+	// lines are aligned based on the quadding of the field.
+	LineAlignFunc func(lineIndex int) Quadding
+
+	// AppearanceWidth and AppearanceHeight, if greater than 0, override the
+	// width and height of the widget rectangle (Rect) used for laying out
+	// the generated appearances, whose bounding box (BBox) has the specified
+	// size. The Rect is still used for placing the appearances, which are
+	// scaled by viewers to fit it, so that the widgets of different sizes
+	// sharing a field display consistent appearances.
+	AppearanceWidth  float64
+	AppearanceHeight float64
+
+	// PostProcess, if set, is called with the content stream operations of
+	// each appearance stream generated for the fields, before the content
+	// stream is set, e.g. for adding watermarks or adjusting the colors of
+	// the appearances. The operations can be modified or appended to, but
+	// the modifications must keep the content stream valid (e.g. balanced
+	// q/Q and BT/ET operators, and resources referenced by the operators
+	// available in the appearance resources). If it returns an error, the
+	// appearance generation fails with the error. Signature appearances
+	// generated by NewSignatureField are post-processed by the PostProcess
+	// function of SignatureFieldOpts instead.
+	PostProcess func(ops *contentstream.ContentStreamOperations) error
+
+	// matrixRotation is the rotation (in degrees) of the matrix of the
+	// existing appearance of the widget being generated, which is preserved
+	// by the generated appearance.
+	matrixRotation float64
+
+	// mcid is the marked content identifier of the tagged contents of the
+	// generated appearance. If nil, the contents are not tagged.
+	mcid *int64
+
+	// fontInfo, if not nil, is set to the information about the font used
+	// by the generated appearance.
+	fontInfo *AppearanceFontInfo
 }
 
 // AppearanceFontStyle defines font style characteristics for form fields,
@@ -77,6 +281,15 @@ type AppearanceFontStyle struct {
 	// process, even if the default appearance (DA) specify a valid font.
 	// If no fallback font is provided, setting this field has no effect.
 	ForceReplace bool
+
+	// SubsetFonts enables the subsetting of the fallback fonts to the glyphs
+	// used by the generated field appearances, which reduces the size of the
+	// embedded fonts. As the glyphs used across all the filled fields must be
+	// known, the fonts are subset by FieldAppearance.SubsetFonts, which must
+	// be called once all the appearances have been generated, before writing
+	// the output. RegenerateForm subsets the fonts automatically.
+	// NOTE: Only embedded TrueType composite (Type0) fonts are subset.
+	SubsetFonts bool
 }
 
 // AppearanceFont represents a font used for generating the appearance of a
@@ -94,14 +307,140 @@ type AppearanceFont struct {
 	// The default font size is calculated using the available annotation
 	// height and the AutoFontSizeFraction of the AppearanceStyle.
 	Size float64
+
+	// Bold, Italic and BoldItalic are the members of the font family used
+	// for the bold and italic text of rich text values (RV). If not set, the
+	// members of the standard 14 font families are used for standard 14
+	// fonts, while the regular font is used for other fonts.
+	Bold       *model.PdfFont
+	Italic     *model.PdfFont
+	BoldItalic *model.PdfFont
+}
+
+// NewAppearanceFontFromFile returns a new appearance font, named `name` in
+// the AcroForm resources (DR), using the TrueType font file at `path`, which
+// is loaded as a composite (Type0) font, so that the field appearances can
+// show any of the characters of the font. The font is used at the specified
+// `size`, or at the default font size if `size` is 0.
+// e.g.: font, err := NewAppearanceFontFromFile("Corp", "corporate.ttf", 0)
+func NewAppearanceFontFromFile(name, path string, size float64) (*AppearanceFont, error) {
+	if name == "" {
+		return nil, errors.New("appearance font name not specified")
+	}
+	if size < 0 {
+		return nil, fmt.Errorf("invalid appearance font size: %v", size)
+	}
+
+	font, err := model.NewCompositePdfFontFromTTFFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AppearanceFont{
+		Name: name,
+		Font: font,
+		Size: size,
+	}, nil
+}
+
+// AppearanceFontSource represents the source of the font used for generating
+// the appearance of a field.
+type AppearanceFontSource int
+
+const (
+	// AppearanceFontSourceDA represents the font specified by the default
+	// appearance (DA) of the field, found in the AcroForm resources (DR).
+	AppearanceFontSourceDA AppearanceFontSource = iota
+
+	// AppearanceFontSourceFallback represents the global fallback font of
+	// the AppearanceFontStyle.
+	AppearanceFontSourceFallback
+
+	// AppearanceFontSourceFieldFallback represents the field specific
+	// fallback font of the AppearanceFontStyle.
+	AppearanceFontSourceFieldFallback
+
+	// AppearanceFontSourceDefault represents the built-in default font
+	// (Helvetica), used if no other font is available.
+	AppearanceFontSourceDefault
+)
+
+// String returns a string representation of the font source.
+func (s AppearanceFontSource) String() string {
+	switch s {
+	case AppearanceFontSourceDA:
+		return "DA"
+	case AppearanceFontSourceFallback:
+		return "fallback"
+	case AppearanceFontSourceFieldFallback:
+		return "field fallback"
+	case AppearanceFontSourceDefault:
+		return "default"
+	}
+	return fmt.Sprintf("AppearanceFontSource(%d)", int(s))
+}
+
+// AppearanceFontInfo contains information about the font used for generating
+// the appearance of a field.
+type AppearanceFontInfo struct {
+	// Name is the name of the font in the appearance resources.
+	Name string
+
+	// Font is the font used by the appearance.
+	Font *model.PdfFont
+
+	// Source specifies where the font comes from.
+	Source AppearanceFontSource
+
+	// DAFontName is the name of the font requested by the default
+	// appearance (DA) of the field. It is empty if the DA does not specify
+	// a font. If the Source is not AppearanceFontSourceDA, the font is a
+	// substitute for the requested font.
+	DAFontName string
 }
 
-type quadding int
+// CheckmarkStyle represents the style used for drawing the check mark of
+// checkbox field appearances.
+type CheckmarkStyle int
+
+const (
+	// CheckmarkStyleGlyph draws the check mark using the CheckmarkRune glyph
+	// of the ZapfDingbats font.
+	CheckmarkStyleGlyph CheckmarkStyle = iota
+
+	// CheckmarkStyleVectorCheck draws a check mark using path operators.
+	CheckmarkStyleVectorCheck
+
+	// CheckmarkStyleVectorCross draws a cross using path operators.
+	CheckmarkStyleVectorCross
+
+	// CheckmarkStyleVectorCircle draws a filled circle using path operators.
+	CheckmarkStyleVectorCircle
+
+	// CheckmarkStyleVectorSquare draws a filled square using path operators.
+	CheckmarkStyleVectorSquare
+
+	// CheckmarkStyleVectorDiamond draws a filled diamond using path operators.
+	CheckmarkStyleVectorDiamond
+
+	// CheckmarkStyleVectorStar draws a filled five-pointed star using path
+	// operators.
+	CheckmarkStyleVectorStar
+)
+
+// Quadding represents the horizontal alignment of the text of variable text
+// fields, as specified by their Q entry.
+type Quadding int
 
 const (
-	quaddingLeft   quadding = 0
-	quaddingCenter quadding = 1
-	quaddingRight  quadding = 2
+	// QuaddingLeft left aligns the text.
+	QuaddingLeft Quadding = 0
+
+	// QuaddingCenter centers the text.
+	QuaddingCenter Quadding = 1
+
+	// QuaddingRight right justifies the text.
+	QuaddingRight Quadding = 2
 )
 
 // SetStyle applies appearance `style` to `fa`.
@@ -118,6 +457,7 @@ func (fa FieldAppearance) Style() AppearanceStyle {
 	return AppearanceStyle{
 		AutoFontSizeFraction:  0.65,
 		CheckmarkRune:         '✔',
+		PasswordRune:          '•',
 		BorderSize:            0.0,
 		BorderColor:           model.NewPdfColorDeviceGray(0),
 		FillColor:             model.NewPdfColorDeviceGray(1),
@@ -128,21 +468,213 @@ func (fa FieldAppearance) Style() AppearanceStyle {
 	}
 }
 
+// NewAcrobatCompatibleStyle returns an appearance style which sizes text
+// closer to the field appearances generated by Adobe Acrobat. It only differs
+// from the default style (see FieldAppearance.Style) in two settings:
+//   - AutoFontSizeFraction is 0.75 (instead of 0.65), so that auto sized text
+//     fills 75% of the field height.
+//   - MultilineLineHeight is 1.15 (instead of 1.2), so that the lines of
+//     multiline text are set closer together.
+//
+// The other aspects of the layout (e.g. insets, borders and font metrics) are
+// those of the default style and are not adjusted to match Acrobat.
+func NewAcrobatCompatibleStyle() AppearanceStyle {
+	return AppearanceStyle{
+		AutoFontSizeFraction:  0.75,
+		CheckmarkRune:         '✔',
+		PasswordRune:          '•',
+		BorderSize:            0.0,
+		BorderColor:           model.NewPdfColorDeviceGray(0),
+		FillColor:             model.NewPdfColorDeviceGray(1),
+		MultilineLineHeight:   1.15,
+		MultilineVAlignMiddle: false,
+		DrawAlignmentReticle:  false,
+		AllowMK:               true,
+	}
+}
+
 // GenerateAppearanceDict generates an appearance dictionary for widget annotation `wa` for the `field` in `form`.
 // Implements interface model.FieldAppearanceGenerator.
 func (fa FieldAppearance) GenerateAppearanceDict(form *model.PdfAcroForm, field *model.PdfField, wa *model.PdfAnnotationWidget) (*core.PdfObjectDictionary, error) {
 	common.Log.Trace("GenerateAppearanceDict for %v  V: %+v", field.PartialName(), field.V)
-	_, isText := field.GetContext().(*model.PdfFieldText)
+	if fa.skip(field) {
+		return nil, nil
+	}
+	if appDict, ok := fa.keepAppearance(field, wa); ok {
+		return appDict, nil
+	}
+	return genAppearanceDict(form, field, wa, fa.Style())
+}
 
+// skip returns true if the appearance of `field` must not be generated, as
+// specified by the SkipFunc of `fa`.
+func (fa FieldAppearance) skip(field *model.PdfField) bool {
+	if fa.SkipFunc != nil && fa.SkipFunc(field) {
+		common.Log.Trace("Skipped field %v", field.PartialName())
+		return true
+	}
+	return false
+}
+
+// keepAppearance returns the existing appearance dictionary of the widget
+// annotation `wa` of `field`, if it must not be regenerated, based on the
+// OnlyIfMissing and RegenerateTextFields options.
+func (fa FieldAppearance) keepAppearance(field *model.PdfField, wa *model.PdfAnnotationWidget) (*core.PdfObjectDictionary, bool) {
+	_, isText := field.GetContext().(*model.PdfFieldText)
 	appDict, has := core.GetDict(wa.AP)
 	if has && fa.OnlyIfMissing && (!isText || !fa.RegenerateTextFields) {
 		common.Log.Trace("Already populated - ignoring")
-		return appDict, nil
+		return appDict, true
+	}
+	return nil, false
+}
+
+// GenerateAppearanceDictWithFontInfo generates an appearance dictionary for
+// widget annotation `wa` for the `field` in `form`, as GenerateAppearanceDict,
+// and returns information about the font used by the appearance (e.g. for
+// reporting the fields rendered with a substitute font). The returned font
+// information is nil if no appearance is generated or if the appearance does
+// not use a font chosen based on the default appearance (e.g. for check
+// boxes and radio buttons).
+func (fa FieldAppearance) GenerateAppearanceDictWithFontInfo(form *model.PdfAcroForm, field *model.PdfField,
+	wa *model.PdfAnnotationWidget) (*core.PdfObjectDictionary, *AppearanceFontInfo, error) {
+	if fa.skip(field) {
+		return nil, nil, nil
+	}
+	if appDict, ok := fa.keepAppearance(field, wa); ok {
+		return appDict, nil, nil
+	}
+
+	info := &AppearanceFontInfo{}
+	style := fa.Style()
+	style.fontInfo = info
+
+	apDict, err := genAppearanceDict(form, field, wa, style)
+	if err != nil || apDict == nil || info.Font == nil {
+		return apDict, nil, err
+	}
+	return apDict, info, nil
+}
+
+// GenerateTaggedAppearanceDict generates an appearance dictionary for widget
+// annotation `wa` for the `field` in `form`, in which the contents of text and
+// choice fields are marked as tagged content, for accessibility (PDF/UA).
+// The contents are wrapped in a `/Tx <</MCID n>> BDC` marked-content sequence,
+// instead of `/Tx BMC`, and the method returns the marked content identifier
+// (MCID) of the sequence. If `structElem` is not nil, a marked-content
+// reference (MCR) to the normal appearance stream is added to the kids (K)
+// of the structure element. When the normal appearance is a dictionary of
+// appearance states (e.g. for combo boxes), each state stream contains a
+// sequence with the same MCID and an MCR is added for each of them. The caller is responsible for setting the
+// StructParents entry of the appearance stream and the corresponding entry
+// of the structure parent tree.
+// The returned MCID is -1 if no tagged content is generated (e.g. for button
+// fields). OnlyIfMissing is ignored, as existing appearances are not tagged.
+func (fa FieldAppearance) GenerateTaggedAppearanceDict(form *model.PdfAcroForm, field *model.PdfField,
+	wa *model.PdfAnnotationWidget, structElem *core.PdfObjectDictionary) (*core.PdfObjectDictionary, int64, error) {
+	if fa.skip(field) {
+		return nil, -1, nil
+	}
+
+	// MCIDs are unique within a content stream. The generated appearance
+	// stream contains a single marked-content sequence.
+	mcid := int64(0)
+	style := fa.Style()
+	style.mcid = &mcid
+
+	apDict, err := genAppearanceDict(form, field, wa, style)
+	if err != nil || apDict == nil {
+		return apDict, -1, err
+	}
+	switch field.GetContext().(type) {
+	case *model.PdfFieldText, *model.PdfFieldChoice:
+	default:
+		return apDict, -1, nil
+	}
+
+	// The normal appearance is either a single stream or a dictionary of
+	// appearance states.
+	var streams []*core.PdfObjectStream
+	if stream, ok := core.GetStream(apDict.Get("N")); ok {
+		streams = append(streams, stream)
+	} else if states, ok := core.GetDict(apDict.Get("N")); ok {
+		for _, state := range states.Keys() {
+			if stream, ok := core.GetStream(states.Get(state)); ok {
+				streams = append(streams, stream)
+			}
+		}
+	}
+	if len(streams) == 0 {
+		return apDict, -1, nil
+	}
+
+	if structElem != nil {
+		for _, stream := range streams {
+			mcr := core.MakeDict()
+			mcr.Set("Type", core.MakeName("MCR"))
+			if wa.P != nil {
+				mcr.Set("Pg", wa.P)
+			}
+			mcr.Set("Stm", stream)
+			mcr.Set("StmOwn", wa.GetContainingPdfObject())
+			mcr.Set("MCID", core.MakeInteger(mcid))
+			addStructKid(structElem, mcr)
+		}
+	}
+	return apDict, mcid, nil
+}
+
+// addStructKid adds `kid` to the kids (K) of the structure element
+// `structElem`. The K entry can be either a single kid or an array of kids.
+func addStructKid(structElem *core.PdfObjectDictionary, kid core.PdfObject) {
+	switch k := core.TraceToDirectObject(structElem.Get("K")).(type) {
+	case nil, *core.PdfObjectNull:
+		structElem.Set("K", kid)
+	case *core.PdfObjectArray:
+		k.Append(kid)
+	default:
+		structElem.Set("K", core.MakeArray(structElem.Get("K"), kid))
 	}
+}
+
+// genAppearanceDict generates the appearance dictionary of the widget
+// annotation `wa` of `field` using the specified `style`.
+func genAppearanceDict(form *model.PdfAcroForm, field *model.PdfField, wa *model.PdfAnnotationWidget,
+	style AppearanceStyle) (*core.PdfObjectDictionary, error) {
 	if form.DR == nil {
 		form.DR = model.NewPdfPageResources()
 	}
 
+	style.AutoFontSizeFraction = style.fieldAutoFontSizeFraction(field)
+
+	// The rotation of the existing appearance matrix is honored, unless the
+	// widget specifies a rotation in its appearance characteristics (MK),
+	// which takes precedence.
+	mkDict, _ := core.GetDict(wa.MK)
+	matrix, rotation := getAppearanceMatrix(wa)
+	if style.mkRotation(mkDict) == 0 {
+		style.matrixRotation = rotation
+	}
+
+	apDict, err := genFieldAppearance(form, field, wa, style)
+	if err != nil || apDict == nil {
+		return apDict, err
+	}
+	if style.matrixRotation != 0 {
+		setAppearanceMatrix(apDict, matrix)
+	}
+	if style.TightBBox {
+		if err := setTightBBoxes(apDict, style.TightBBoxMargin); err != nil {
+			return nil, err
+		}
+	}
+	return apDict, nil
+}
+
+// genFieldAppearance generates the appearance dictionary of the widget
+// annotation `wa` of `field`, based on the type of the field.
+func genFieldAppearance(form *model.PdfAcroForm, field *model.PdfField, wa *model.PdfAnnotationWidget,
+	style AppearanceStyle) (*core.PdfObjectDictionary, error) {
 	// Generate the appearance.
 	switch t := field.GetContext().(type) {
 	case *model.PdfFieldText:
@@ -150,24 +682,22 @@ func (fa FieldAppearance) GenerateAppearanceDict(form *model.PdfAcroForm, field
 
 		// Handle special cases.
 		switch {
-		case ftxt.Flags().Has(model.FieldFlagPassword):
+		case ftxt.Flags().Has(model.FieldFlagPassword) && !style.RenderPasswordMask:
 			// Should never store password values.
 			return nil, nil
 		case ftxt.Flags().Has(model.FieldFlagFileSelect):
 			// Not supported.
 			return nil, nil
 		case ftxt.Flags().Has(model.FieldFlagComb):
-			// Special handling for comb. Only if max len is set.
-			if ftxt.MaxLen != nil {
-				appDict, err := genFieldTextCombAppearance(wa, ftxt, form.DR, fa.Style())
-				if err != nil {
-					return nil, err
-				}
-				return appDict, nil
+			// Special handling for comb.
+			appDict, err := genFieldTextCombAppearance(wa, ftxt, form.DR, style)
+			if err != nil {
+				return nil, err
 			}
+			return appDict, nil
 		}
 
-		appDict, err := genFieldTextAppearance(wa, ftxt, form.DR, fa.Style())
+		appDict, err := genFieldTextAppearance(wa, ftxt, form.DR, style)
 		if err != nil {
 			return nil, err
 		}
@@ -176,7 +706,21 @@ func (fa FieldAppearance) GenerateAppearanceDict(form *model.PdfAcroForm, field
 	case *model.PdfFieldButton:
 		fbtn := t
 		if fbtn.IsCheckbox() {
-			appDict, err := genFieldCheckboxAppearance(wa, fbtn, form.DR, fa.Style())
+			appDict, err := genFieldCheckboxAppearance(wa, fbtn, form.DR, style)
+			if err != nil {
+				return nil, err
+			}
+			return appDict, nil
+		}
+		if fbtn.IsRadio() {
+			appDict, err := genFieldRadioAppearance(wa, fbtn, style)
+			if err != nil {
+				return nil, err
+			}
+			return appDict, nil
+		}
+		if fbtn.IsPush() {
+			appDict, err := genFieldPushbuttonAppearance(wa, fbtn, form.DR, style)
 			if err != nil {
 				return nil, err
 			}
@@ -188,13 +732,17 @@ func (fa FieldAppearance) GenerateAppearanceDict(form *model.PdfAcroForm, field
 		fch := t
 		switch {
 		case fch.Flags().Has(model.FieldFlagCombo):
-			appDict, err := genFieldComboboxAppearance(form, wa, fch, fa.Style())
+			appDict, err := genFieldComboboxAppearance(form, wa, fch, style)
 			if err != nil {
 				return nil, err
 			}
 			return appDict, nil
 		default:
-			common.Log.Debug("TODO: UNHANDLED choice field with flags: %s", fch.Flags().String())
+			appDict, err := genFieldListboxAppearance(wa, fch, form.DR, style)
+			if err != nil {
+				return nil, err
+			}
+			return appDict, nil
 		}
 
 	default:
@@ -204,10 +752,163 @@ func (fa FieldAppearance) GenerateAppearanceDict(form *model.PdfAcroForm, field
 	return nil, nil
 }
 
+// GenerateFieldAppearances generates the appearance dictionaries for all the
+// widget annotations of `field` and of its descendant fields in `form`, and
+// sets them on the widgets. A field can have several widget annotations (e.g.
+// the same field displayed on multiple pages), each one with its own Rect.
+// The method should be used when all the widgets of a field must be updated,
+// as GenerateAppearanceDict only handles a single widget annotation.
+// The existing appearances of the widgets for which no appearance is
+// generated (e.g. signature fields) are kept.
+func (fa FieldAppearance) GenerateFieldAppearances(form *model.PdfAcroForm, field *model.PdfField) error {
+	if field == nil {
+		return errors.New("field not specified")
+	}
+
+	for _, wa := range field.Annotations {
+		apDict, err := fa.GenerateAppearanceDict(form, field, wa)
+		if err != nil {
+			return err
+		}
+		if apDict == nil {
+			continue
+		}
+
+		wa.AP = apDict
+		wa.ToPdfObject()
+	}
+
+	for _, kid := range field.Kids {
+		if err := fa.GenerateFieldAppearances(form, kid); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FieldAppearanceError represents an error which occurred when generating
+// the appearance of a field.
+type FieldAppearanceError struct {
+	Field *model.PdfField
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *FieldAppearanceError) Error() string {
+	name, err := e.Field.FullName()
+	if err != nil {
+		name = e.Field.PartialName()
+	}
+	return fmt.Sprintf("field %q: %v", name, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *FieldAppearanceError) Unwrap() error {
+	return e.Err
+}
+
+// FieldAppearanceErrors represents the errors which occurred when generating
+// the appearances of multiple fields.
+type FieldAppearanceErrors []*FieldAppearanceError
+
+// Error implements the error interface.
+func (errs FieldAppearanceErrors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d field appearance error(s): %s", len(errs), strings.Join(msgs, "; "))
+}
+
+// RegenerateForm generates the appearance dictionaries of all the widget
+// annotations of the fields of `form` and sets them on the widgets. The
+// OnlyIfMissing and RegenerateTextFields options are respected, as for
+// GenerateAppearanceDict. The generation does not stop on the first failure:
+// the errors of all the fields are collected and returned as
+// FieldAppearanceErrors, while the appearances of the other fields are
+// still updated. The widgets of the fields skipped by SkipFunc, and of the
+// fields for which no appearance is generated (e.g. signature fields), are
+// left unchanged. Once all the appearances are generated, the fallback fonts
+// are subset, if enabled by the font style (see SubsetFonts).
+func (fa FieldAppearance) RegenerateForm(form *model.PdfAcroForm) error {
+	if form == nil {
+		return errors.New("form not specified")
+	}
+
+	var errs FieldAppearanceErrors
+	for _, field := range form.AllFields() {
+		if fa.skip(field) {
+			continue
+		}
+		for _, wa := range field.Annotations {
+			apDict, err := fa.GenerateAppearanceDict(form, field, wa)
+			if err != nil {
+				common.Log.Debug("ERROR: unable to generate field appearance: %v", err)
+				errs = append(errs, &FieldAppearanceError{Field: field, Err: err})
+				continue
+			}
+			if apDict == nil {
+				continue
+			}
+
+			wa.AP = apDict
+			wa.ToPdfObject()
+		}
+	}
+
+	if err := fa.SubsetFonts(); err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// SubsetFonts subsets the fallback fonts of the appearance style of `fa` to
+// the glyphs used by the appearances generated so far, if subsetting is
+// enabled by the SubsetFonts option of the font style. The method should be
+// called once, after all the field appearances have been generated, as the
+// glyphs which are not used at that point are removed from the fonts.
+func (fa FieldAppearance) SubsetFonts() error {
+	fonts := fa.Style().Fonts
+	if fonts == nil || !fonts.SubsetFonts {
+		return nil
+	}
+
+	apFonts := []*AppearanceFont{fonts.Fallback}
+	names := make([]string, 0, len(fonts.FieldFallbacks))
+	for name := range fonts.FieldFallbacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		apFonts = append(apFonts, fonts.FieldFallbacks[name])
+	}
+
+	subset := map[*model.PdfFont]struct{}{}
+	for _, apFont := range apFonts {
+		if apFont == nil || apFont.Font == nil {
+			continue
+		}
+		if _, ok := subset[apFont.Font]; ok {
+			continue
+		}
+		subset[apFont.Font] = struct{}{}
+
+		if err := apFont.Font.SubsetRegistered(); err != nil {
+			common.Log.Debug("ERROR: unable to subset font %s: %v", apFont.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
 // genTextAppearance generates the appearance stream for widget annotation `wa` with text field `ftxt`.
 // It requires access to the form resources DR entry via `dr`.
 func genFieldTextAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldText, dr *model.PdfPageResources, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
-	resources := model.NewPdfPageResources()
+	resources := getAppearanceResources(wa)
 
 	// Get bounding Rect.
 	array, ok := core.GetArray(wa.Rect)
@@ -218,7 +919,7 @@ func genFieldTextAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldT
 	if err != nil {
 		return nil, err
 	}
-	width, height := rect.Width(), rect.Height()
+	width, height := style.rectSize(rect)
 	bboxWidth, bboxHeight := width, height
 
 	mkDict, has := core.GetDict(wa.MK)
@@ -229,6 +930,7 @@ func genFieldTextAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldT
 			return nil, err
 		}
 	}
+	style.applyRequiredBorder(ftxt.PdfField)
 
 	// Get and process the default appearance string (DA) operands.
 	daOps, err := contentstream.NewContentStreamParser(getDA(ftxt.PdfField)).Parse()
@@ -244,18 +946,17 @@ func genFieldTextAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldT
 
 	if style.DrawAlignmentReticle {
 		// Alignment reticle.
-		style2 := style
-		style2.BorderSize = 0.2
-		drawAlignmentReticle(cc, style2, width, height)
+		drawAlignmentReticle(cc, style, width, height)
 	}
 
-	cc.Add_BMC("Tx")
+	style.beginTextContent(cc)
 	cc.Add_q()
 
 	// Apply rotation if present.
 	// Update width and height, as the appearance is generated based on
 	// the bounding of the annotation with no rotation.
 	width, height = style.applyRotation(mkDict, width, height, cc)
+	style.clipToRect(cc, width, height)
 
 	// Graphic state changes.
 	cc.Add_BT()
@@ -285,11 +986,27 @@ func genFieldTextAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldT
 		common.Log.Debug("Error: Unable to get font descriptor")
 	}
 
+	// Render the rich text value of the field, if any, instead of the plain
+	// text value.
+	if richLines := richTextLines(ftxt); richLines != nil && !style.Vertical {
+		decorations := style.addRichText(cc, resources, ftxt, apFont, daOps, richLines, fontsize, autosize, width, height)
+		return makeTextAppearanceDict(cc, resources, bboxWidth, bboxHeight, style, decorations)
+	}
+
 	var text string
 	if str, ok := core.GetString(ftxt.V); ok {
 		text = str.Decoded()
 	}
 
+	// Truncate the text to the maximum length of the field, as displayed
+	// by viewers which enforce it.
+	if maxLen, ok := core.GetIntVal(ftxt.MaxLen); ok && maxLen > 0 && !ftxt.Flags().Has(model.FieldFlagComb) {
+		if runes := []rune(text); len(runes) > maxLen {
+			text = string(runes[:maxLen])
+		}
+	}
+	text = style.fieldText(ftxt, text)
+
 	// If no text, no appearance needed.
 	if len(text) == 0 {
 		return nil, nil
@@ -301,82 +1018,63 @@ func genFieldTextAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldT
 	isMultiline := false
 	if ftxt.Flags().Has(model.FieldFlagMultiline) {
 		isMultiline = true
-		text = strings.Replace(text, "\r\n", "\n", -1)
-		text = strings.Replace(text, "\r", "\n", -1)
-		lines = strings.Split(text, "\n")
+		lines = splitLines(text)
+	}
+
+	boxLeft, boxRight, tx := style.textBox(width)
+	boxBottom, boxTop := style.TextMarginBottom, height-style.TextMarginTop
+
+	if style.Vertical {
+		fontsize = style.addVerticalText(cc, font, encoder, *fontname, lines, fontsize, autosize,
+			boxLeft, boxRight, boxBottom, boxTop)
+		if fontsize <= 0 {
+			return nil, nil
+		}
+		return makeTextAppearanceDict(cc, resources, bboxWidth, bboxHeight, style, nil)
 	}
 
 	maxLinewidth := 0.0
+	maxSpacing := 0.0
 	textlines := 0
+	var decodedLines []string
 	if encoder != nil {
-		l := len(lines)
-		i := 0
-		for i < l {
-			var lastwidth float64
-			lastbreakindex := -1
-			linewidth := 0.0
-			for index, r := range lines[i] {
-				if r == ' ' {
-					lastbreakindex = index
-					lastwidth = linewidth
-				}
-				metrics, has := font.GetRuneMetrics(r)
-				if !has {
-					common.Log.Debug("Font does not have rune metrics for %v - skipping", r)
-					continue
-				}
-				linewidth += metrics.Wx
-
-				if isMultiline && !autosize && fontsize*linewidth/1000.0 > width && lastbreakindex > 0 {
-					part2 := lines[i][lastbreakindex+1:]
-
-					if i < len(lines)-1 {
-						lines[i+1] += part2
-					} else {
-						lines = append(lines, part2)
-						l++
-					}
-					lines[i] = lines[i][0:lastbreakindex]
-					linewidth = lastwidth
-					break
-				}
+		if isMultiline && fontsize > 0 {
+			wrapped, isWrapped := style.wrapLines(font, fontsize, lines, 1000*(boxRight-tx)/fontsize, autosize)
+
+			// Reduce the size of autosized wrapped text until the lines fit
+			// the height of the field.
+			for autosize && isWrapped && fontsize > 1 &&
+				float64(len(wrapped))*style.MultilineLineHeight*fontsize > boxTop-boxBottom {
+				fontsize *= 0.95
+				wrapped, _ = style.wrapLines(font, fontsize, lines, 1000*(boxRight-tx)/fontsize, autosize)
 			}
+			lines = wrapped
+		}
+
+		for i, line := range lines {
+			linewidth := style.textWidth(font, line)
 			if linewidth > maxLinewidth {
 				maxLinewidth = linewidth
 			}
+			if spacing := style.textSpacing(font, line); spacing > maxSpacing {
+				maxSpacing = spacing
+			}
 
-			lines[i] = string(encoder.Encode(lines[i]))
+			decodedLines = append(decodedLines, line)
+			lines[i] = string(encoder.Encode(line))
 			if len(lines[i]) > 0 {
 				textlines++
 			}
-			i++
 		}
 	}
 
-	tx := 2.0
-
 	// Check if text goes out of bounds, if goes out of bounds, then adjust font size until just within bounds.
-	if fontsize == 0 || autosize && maxLinewidth > 0 && tx+maxLinewidth*fontsize/1000.0 > width {
-		// TODO(gunnsth): Add to style options.
-		fontsize = 0.95 * 1000.0 * (width - tx) / maxLinewidth
+	if fontsize == 0 || autosize && maxLinewidth > 0 && tx+maxLinewidth*fontsize/1000.0+maxSpacing > boxRight {
+		fontsize = 0.95 * 1000.0 * fitWidth(boxRight-tx, maxSpacing) / maxLinewidth
 	}
 
-	alignment := quaddingLeft
 	// Account for horizontal alignment (quadding).
-	{
-		if val, has := core.GetIntVal(ftxt.Q); has {
-			switch val {
-			case 0: // Left aligned.
-				alignment = quaddingLeft
-			case 1: // Centered.
-				alignment = quaddingCenter
-			case 2: // Right justified.
-				alignment = quaddingRight
-			default:
-				common.Log.Debug("ERROR: Unsupported quadding: %d - using left alignment", val)
-			}
-		}
-	}
+	alignment := getQuadding(ftxt.PdfField)
 
 	lh := style.MultilineLineHeight
 
@@ -402,8 +1100,8 @@ func genFieldTextAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldT
 	ty := 0.0
 	{
 		textheight := float64(textlines) * lineheight
-		if autosize && ty+textheight > height {
-			fontsize = 0.95 * (height - ty) / float64(textlines)
+		if autosize && textheight > boxTop-boxBottom {
+			fontsize = 0.95 * (boxTop - boxBottom) / float64(textlines)
 			lineheight = fontsize
 			if isMultiline && textlines > 1 {
 				lineheight = lh * fontsize
@@ -412,111 +1110,265 @@ func genFieldTextAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldT
 			textheight = float64(textlines) * lineheight
 		}
 
-		if height > textheight {
+		if boxTop-boxBottom > textheight {
 			if isMultiline {
 				if style.MultilineVAlignMiddle {
-					a := (height - textheight) / 2.0
+					a := boxBottom + (boxTop-boxBottom-textheight)/2.0
 					b := a + textheight - lineheight
 					ty = b
 				} else {
 					// Top.
-					ty = height - lineheight
+					ty = boxTop - lineheight
 					ty -= fontsize * 0.5
 				}
 			} else {
-				ty = (height - capheight) / 2.0
+				ty = centeredBaseline(fdescriptor, fontsize, capheight, boxBottom, boxTop)
 			}
 		}
 	}
 
+	underline, strikethrough := style.textDecorations(ftxt)
+	underlinePos, strikethroughPos, thickness := textDecorationMetrics(font, fontsize)
+	var decorations []*model.PdfRectangle
+
 	cc.Add_Tf(*fontname, fontsize)
+	style.addTextSpacing(cc)
 	cc.Add_Td(tx, ty)
 	tx0 := tx
 	x := tx
+	posX, posY := tx, ty
 	for i, line := range lines {
-		wLine := 0.0
-		for _, r := range line {
-			metrics, has := font.GetRuneMetrics(r)
-			if !has {
-				continue
-			}
-			wLine += metrics.Wx
-		}
-		linewidth := wLine / 1000.0 * fontsize
-		remaining := width - linewidth
+		linewidth := style.spacedTextWidth(font, decodedLines[i], fontsize) / 1000.0 * fontsize
+		remaining := boxRight - boxLeft - linewidth
 
 		var xnew float64
-		switch alignment {
-		case quaddingLeft:
+		switch style.lineAlignment(alignment, i) {
+		case QuaddingLeft:
 			xnew = tx0
-		case quaddingCenter:
-			xnew = remaining / 2
-		case quaddingRight:
-			xnew = remaining
+		case QuaddingCenter:
+			xnew = boxLeft + remaining/2
+		case QuaddingRight:
+			xnew = boxLeft + remaining
 		}
 		tx = xnew - x
-		if tx > 0.0 {
+		if tx != 0.0 {
 			cc.Add_Td(tx, 0)
+			posX += tx
 		}
 		x = xnew
 
-		cc.Add_Tj(*core.MakeString(line))
+		if style.Kerning {
+			addKernedText(cc, font, encoder, decodedLines[i])
+		} else {
+			cc.Add_Tj(*core.MakeString(line))
+		}
+
+		if linewidth > 0 {
+			if underline {
+				decorations = append(decorations, &model.PdfRectangle{
+					Llx: posX, Lly: posY + underlinePos - thickness/2,
+					Urx: posX + linewidth, Ury: posY + underlinePos + thickness/2,
+				})
+			}
+			if strikethrough {
+				decorations = append(decorations, &model.PdfRectangle{
+					Llx: posX, Lly: posY + strikethroughPos - thickness/2,
+					Urx: posX + linewidth, Ury: posY + strikethroughPos + thickness/2,
+				})
+			}
+		}
 
 		if i < len(lines)-1 {
 			cc.Add_Td(0, -lineheight*lh)
+			posY -= lineheight * lh
 		}
 	}
 
+	return makeTextAppearanceDict(cc, resources, bboxWidth, bboxHeight, style, decorations)
+}
+
+// makeTextAppearanceDict closes the text object and the marked content of
+// the text field appearance content `cc` and returns the appearance
+// dictionary of the field. The text `decorations` (underline and
+// strikethrough lines) are filled after the text object, using the
+// current fill color, which is the color of the text.
+func makeTextAppearanceDict(cc *contentstream.ContentCreator, resources *model.PdfPageResources,
+	bboxWidth, bboxHeight float64, style AppearanceStyle, decorations []*model.PdfRectangle) (*core.PdfObjectDictionary, error) {
 	cc.Add_ET()
+	if len(decorations) > 0 {
+		for _, rect := range decorations {
+			cc.Add_re(rect.Llx, rect.Lly, rect.Width(), rect.Height())
+		}
+		cc.Add_f()
+	}
 	cc.Add_Q()
 	cc.Add_EMC()
 
 	xform := model.NewXObjectForm()
 	xform.Resources = resources
 	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
-	xform.SetContentStream(cc.Bytes(), defStreamEncoder())
+	if err := style.setContentStream(xform, cc); err != nil {
+		return nil, err
+	}
 
 	apDict := core.MakeDict()
 	apDict.Set("N", xform.ToPdfObject())
-
 	return apDict, nil
 }
 
-// genFieldTextCombAppearance generates an appearance dictionary for a comb text field where the width is split
-// into equal size boxes.
-func genFieldTextCombAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldText, dr *model.PdfPageResources, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
-	resources := model.NewPdfPageResources()
-
-	// Get bounding Rect.
-	array, ok := core.GetArray(wa.Rect)
-	if !ok {
-		return nil, errors.New("invalid Rect")
-	}
-	rect, err := model.NewPdfRectangle(*array)
-	if err != nil {
-		return nil, err
+// addVerticalText adds the text `lines` to `cc` using vertical writing mode: the glyphs are stacked
+// from top to bottom, using the vertical advance (Wy) of the glyphs, in columns starting from the right
+// side of the text area defined by `boxLeft`, `boxRight`, `boxBottom` and `boxTop`. Each line starts
+// a new column to the left of the previous one, and lines which exceed the height of the area are
+// wrapped into new columns. If `autosize` is true, the font size is reduced so that the longest line
+// fits in the height of the area and all the lines fit in its width.
+// The method returns the font size used, or 0 if there is no text to display.
+func (style AppearanceStyle) addVerticalText(cc *contentstream.ContentCreator, font *model.PdfFont,
+	encoder textencoding.TextEncoder, fontname core.PdfObjectName, lines []string, fontsize float64,
+	autosize bool, boxLeft, boxRight, boxBottom, boxTop float64) float64 {
+	// advance returns the vertical advance of `r` in glyph space units.
+	advance := func(r rune) (float64, bool) {
+		metrics, has := font.GetRuneMetrics(r)
+		if !has {
+			common.Log.Debug("Font does not have rune metrics for %v - skipping", r)
+			return 0, false
+		}
+		wy := math.Abs(metrics.Wy)
+		if wy == 0 {
+			// Default vertical advance of CJK fonts.
+			wy = 1000
+		}
+		return wy, true
 	}
-	width, height := rect.Width(), rect.Height()
-	bboxWidth, bboxHeight := width, height
 
-	mkDict, has := core.GetDict(wa.MK)
-	if has {
-		bsDict, _ := core.GetDict(wa.BS)
-		err := style.applyAppearanceCharacteristics(mkDict, bsDict, nil)
-		if err != nil {
-			return nil, err
+	lh := style.MultilineLineHeight
+	if lh <= 0 {
+		lh = 1
+	}
+	areaWidth, areaHeight := boxRight-boxLeft, boxTop-boxBottom
+
+	if fontsize == 0 || autosize {
+		var maxColumnHeight float64
+		for _, line := range lines {
+			var columnHeight float64
+			for _, r := range line {
+				if wy, ok := advance(r); ok {
+					columnHeight += wy
+				}
+			}
+			maxColumnHeight = math.Max(maxColumnHeight, columnHeight)
+		}
+		if maxColumnHeight == 0 {
+			return 0
 		}
-	}
 
-	maxLen, has := core.GetIntVal(ftxt.MaxLen)
+		fontsize = style.AutoFontSizeFraction * areaWidth
+		if fontsize*maxColumnHeight/1000.0 > areaHeight {
+			fontsize = 0.95 * 1000.0 * areaHeight / maxColumnHeight
+		}
+		if float64(len(lines))*lh*fontsize > areaWidth {
+			fontsize = areaWidth / (float64(len(lines)) * lh)
+		}
+	}
+	if fontsize <= 0 {
+		return 0
+	}
+
+	var fcapheight float64
+	if fdescriptor, err := font.GetFontDescriptor(); err == nil && fdescriptor != nil {
+		fcapheight, _ = fdescriptor.GetCapHeight()
+	}
+	if int(fcapheight) <= 0 {
+		fcapheight = 1000
+	}
+	capheight := fcapheight / 1000.0 * fontsize
+	columnWidth := lh * fontsize
+
+	cc.Add_Tf(fontname, fontsize)
+
+	var x, y, offset float64
+	column := 0
+	for i, line := range lines {
+		if i > 0 {
+			column++
+			offset = 0
+		}
+		for _, r := range line {
+			wy, ok := advance(r)
+			if !ok {
+				continue
+			}
+			glyphHeight := wy * fontsize / 1000.0
+			if offset > 0 && offset+glyphHeight > areaHeight {
+				// Wrap into a new column.
+				column++
+				offset = 0
+			}
+
+			metrics, _ := font.GetRuneMetrics(r)
+			glyphWidth := metrics.Wx * fontsize / 1000.0
+			xnew := boxRight - (float64(column)+0.5)*columnWidth - glyphWidth/2
+			ynew := boxTop - offset - glyphHeight + (glyphHeight-capheight)/2
+			cc.Add_Td(xnew-x, ynew-y)
+			x, y = xnew, ynew
+
+			cc.Add_Tj(*core.MakeStringFromBytes(encoder.Encode(string(r))))
+			offset += glyphHeight
+		}
+	}
+	return fontsize
+}
+
+// genFieldTextCombAppearance generates an appearance dictionary for a comb text field where the width is split
+// into equal size boxes. The number of boxes is specified by the MaxLen entry of the field. Comb fields require
+// MaxLen to be set. If it is not, the number of boxes is derived from the length (in runes) of the field value,
+// so that each character of the value is placed in its own box, and the regular text field appearance is
+// generated if the value is empty.
+func genFieldTextCombAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFieldText, dr *model.PdfPageResources, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
+	var text string
+	if str, ok := core.GetString(ftxt.V); ok {
+		text = str.Decoded()
+	}
+	text = style.fieldText(ftxt, text)
+
+	maxLen, has := core.GetIntVal(ftxt.MaxLen)
 	if !has {
-		return nil, errors.New("maxlen not set")
+		maxLen = utf8.RuneCountInString(text)
+		common.Log.Debug("WARN: comb field MaxLen not set - using the value length (%d) as the number of cells", maxLen)
+		if maxLen == 0 {
+			return genFieldTextAppearance(wa, ftxt, dr, style)
+		}
 	}
 	if maxLen <= 0 {
 		return nil, errors.New("maxLen invalid")
 	}
 
-	boxwidth := float64(width) / float64(maxLen)
+	resources := getAppearanceResources(wa)
+
+	// Get bounding Rect.
+	array, ok := core.GetArray(wa.Rect)
+	if !ok {
+		return nil, errors.New("invalid Rect")
+	}
+	rect, err := model.NewPdfRectangle(*array)
+	if err != nil {
+		return nil, err
+	}
+	width, height := style.rectSize(rect)
+	bboxWidth, bboxHeight := width, height
+
+	mkDict, has := core.GetDict(wa.MK)
+	if has {
+		bsDict, _ := core.GetDict(wa.BS)
+		err := style.applyAppearanceCharacteristics(mkDict, bsDict, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	style.applyRequiredBorder(ftxt.PdfField)
+
+	boxLeft, boxRight, _ := style.textBox(width)
+	boxwidth := (boxRight - boxLeft) / float64(maxLen)
 
 	// Get and process the default appearance string (DA) operands.
 	daOps, err := contentstream.NewContentStreamParser(getDA(ftxt.PdfField)).Parse()
@@ -530,17 +1382,16 @@ func genFieldTextCombAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFi
 	}
 	if style.DrawAlignmentReticle {
 		// Alignment reticle.
-		style2 := style
-		style2.BorderSize = 0.2
-		drawAlignmentReticle(cc, style2, width, height)
+		drawAlignmentReticle(cc, style, width, height)
 	}
-	cc.Add_BMC("Tx")
+	style.beginTextContent(cc)
 	cc.Add_q()
 
 	// Apply rotation if present.
 	// Update width and height, as the appearance is generated based on
 	// the bounding of the annotation with no rotation.
 	width, height = style.applyRotation(mkDict, width, height, cc)
+	style.clipToRect(cc, width, height)
 
 	// Graphic state changes.
 	cc.Add_BT()
@@ -565,16 +1416,19 @@ func genFieldTextCombAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFi
 		encoder = textencoding.NewIdentityTextEncoder("Identity-H")
 	}
 
-	var text string
-	if str, ok := core.GetString(ftxt.V); ok {
-		text = str.Decoded()
+	// Each rune of the text is placed in its own cell, so the text is
+	// truncated to the number of cells.
+	runes := []rune(text)
+	if len(runes) > maxLen {
+		runes = runes[:maxLen]
 	}
+	runes = style.padCombText(runes, maxLen)
 
 	cc.Add_Tf(*fontname, fontsize)
 
 	// Get max glyph height.
 	var maxGlyphWy float64
-	for _, r := range text {
+	for _, r := range runes {
 		metrics, found := font.GetRuneMetrics(r)
 		if !found {
 			common.Log.Debug("ERROR: Rune not found in font: %v - skipping over", r)
@@ -612,40 +1466,43 @@ func genFieldTextCombAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFi
 
 	// Vertical alignment.
 	ty := 0.0
+	boxBottom, boxTop := style.TextMarginBottom, height-style.TextMarginTop
 	lineheight := 1.0 * fontsize * (maxGlyphWy / 1000.0)
 	{
 		textheight := lineheight
 		// If autosize and going out of bounds, reduce to fit.
-		if autosize && ty+textheight > height {
-			fontsize = 0.95 * (height - ty)
+		if autosize && textheight > boxTop-boxBottom {
+			fontsize = 0.95 * (boxTop - boxBottom)
 			lineheight = 1.0 * fontsize
 			textheight = lineheight
 			capheight = fcapheight / 1000.0 * fontsize
 		}
 
-		if height > capheight {
-			ty = (height - capheight) / 2.0
+		if boxTop-boxBottom > capheight {
+			ty = centeredBaseline(fdescriptor, fontsize, capheight, boxBottom, boxTop)
 		}
 	}
-	cc.Add_Td(0, ty)
+	cc.Add_Td(boxLeft, ty)
 
-	if quadding, has := core.GetIntVal(ftxt.Q); has {
-		switch quadding {
-		case 2: // Right justified.
-			if len(text) < maxLen {
-				offset := float64(maxLen-len(text)) * boxwidth
-				cc.Add_Td(offset, 0)
-			}
+	switch style.combQuadding(ftxt, text) {
+	case 2: // Right justified.
+		if len(runes) < maxLen {
+			offset := float64(maxLen-len(runes)) * boxwidth
+			cc.Add_Td(offset, 0)
 		}
 	}
 
-	for i, r := range text {
+	for i, r := range runes {
 		tx := 2.0
 		encoded := string(r)
 		if encoder != nil {
 			metrics, found := font.GetRuneMetrics(r)
 			if !found {
 				common.Log.Debug("ERROR: Rune not found in font: %v - skipping over", r)
+				// Leave the cell of the rune empty.
+				if i != len(runes)-1 {
+					cc.Add_Td(boxwidth, 0)
+				}
 				continue
 			}
 
@@ -660,7 +1517,7 @@ func genFieldTextCombAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFi
 		cc.Add_Td(tx, 0)
 		cc.Add_Tj(*core.MakeString(encoded))
 
-		if i != len(text)-1 {
+		if i != len(runes)-1 {
 			cc.Add_Td(boxwidth-tx, 0)
 		}
 	}
@@ -672,7 +1529,9 @@ func genFieldTextCombAppearance(wa *model.PdfAnnotationWidget, ftxt *model.PdfFi
 	xform := model.NewXObjectForm()
 	xform.Resources = resources
 	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
-	xform.SetContentStream(cc.Bytes(), defStreamEncoder())
+	if err := style.setContentStream(xform, cc); err != nil {
+		return nil, err
+	}
 
 	apDict := core.MakeDict()
 	apDict.Set("N", xform.ToPdfObject())
@@ -692,7 +1551,7 @@ func genFieldCheckboxAppearance(wa *model.PdfAnnotationWidget, fbtn *model.PdfFi
 	if err != nil {
 		return nil, err
 	}
-	width, height := rect.Width(), rect.Height()
+	width, height := style.rectSize(rect)
 	bboxWidth, bboxHeight := width, height
 
 	common.Log.Debug("Checkbox, wa BS: %v", wa.BS)
@@ -710,6 +1569,7 @@ func genFieldCheckboxAppearance(wa *model.PdfAnnotationWidget, fbtn *model.PdfFi
 			return nil, err
 		}
 	}
+	style.applyRequiredBorder(fbtn.PdfField)
 
 	xformOn := model.NewXObjectForm()
 	{
@@ -717,12 +1577,13 @@ func genFieldCheckboxAppearance(wa *model.PdfAnnotationWidget, fbtn *model.PdfFi
 		if style.BorderSize > 0 {
 			drawRect(cc, style, width, height)
 		}
+		if style.CheckboxDrawEmptyBox && style.CheckboxBoxBehindCheck {
+			drawCheckboxBox(cc, style, bboxWidth, bboxHeight)
+		}
 
 		if style.DrawAlignmentReticle {
 			// Alignment reticle.
-			style2 := style
-			style2.BorderSize = 0.2
-			drawAlignmentReticle(cc, style2, width, height)
+			drawAlignmentReticle(cc, style, width, height)
 		}
 
 		// Apply rotation if present.
@@ -732,56 +1593,132 @@ func genFieldCheckboxAppearance(wa *model.PdfAnnotationWidget, fbtn *model.PdfFi
 
 		fontsize := style.AutoFontSizeFraction * height
 
-		checkmetrics, ok := zapfdb.GetRuneMetrics(style.CheckmarkRune)
-		if !ok {
-			return nil, errors.New("glyph not found")
+		if style.CheckmarkStyle != CheckmarkStyleGlyph {
+			drawCheckmark(cc, style.CheckmarkStyle, width, height, fontsize)
+		} else {
+			if err := drawCheckmarkGlyph(cc, zapfdb, style.CheckmarkRune, width, height, fontsize); err != nil {
+				return nil, err
+			}
+
+			xformOn.Resources = model.NewPdfPageResources()
+			xformOn.Resources.SetFontByName("ZaDb", zapfdb.ToPdfObject())
 		}
-		enc := zapfdb.Encoder()
-		checkstr := enc.Encode(string(style.CheckmarkRune))
 
-		checkwidth := checkmetrics.Wx * fontsize / 1000.0
-		// TODO: Get bbox of specific glyph that is chosen.  Choice of specific value will cause slight
-		// deviations for other glyphs, but should be fairly close.
-		fcheckheight := 705.0 // From AFM for code 52.
-		checkheight := fcheckheight / 1000.0 * fontsize
+		xformOn.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
+		if err := style.setContentStream(xformOn, cc); err != nil {
+			return nil, err
+		}
+	}
 
-		tx := 2.0
-		ty := 1.0
-		if checkwidth < width {
-			tx = (width - checkwidth) / 2.0
+	xformOff := model.NewXObjectForm()
+	{
+		cc := contentstream.NewContentCreator()
+		if style.BorderSize > 0 {
+			drawRect(cc, style, width, height)
 		}
-		if checkheight < height {
-			ty = (height - checkheight) / 2.0
+		if style.CheckboxDrawEmptyBox {
+			drawCheckboxBox(cc, style, bboxWidth, bboxHeight)
+		}
+		xformOff.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
+		if err := style.setContentStream(xformOff, cc); err != nil {
+			return nil, err
 		}
+	}
 
-		cc.Add_q().
-			Add_g(0).
-			Add_BT().
-			Add_Tf("ZaDb", fontsize).
-			Add_Td(tx, ty).
-			Add_Tj(*core.MakeStringFromBytes(checkstr)).
-			Add_ET().
-			Add_Q()
+	dchoiceapp := core.MakeDict()
+	dchoiceapp.Set("Off", xformOff.ToPdfObject())
+	dchoiceapp.Set("Yes", xformOn.ToPdfObject())
+
+	appDict := core.MakeDict()
+	appDict.Set("N", dchoiceapp)
+
+	return appDict, nil
+}
+
+// genFieldRadioAppearance generates an appearance dictionary for a widget annotation `wa` referenced by
+// a radio button field `fbtn`. The appearance dictionary contains the Off state, which is blank, and the
+// on state of the widget, which displays a filled circle or the glyph specified by the normal caption (CA)
+// of the widget appearance characteristics (MK).
+// The Off appearance is always generated, including for fields with the NoToggleToOff flag set, as it is
+// displayed when another button of the group is selected.
+func genFieldRadioAppearance(wa *model.PdfAnnotationWidget, fbtn *model.PdfFieldButton, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
+	// Get bounding Rect.
+	array, ok := core.GetArray(wa.Rect)
+	if !ok {
+		return nil, errors.New("invalid Rect")
+	}
+	rect, err := model.NewPdfRectangle(*array)
+	if err != nil {
+		return nil, err
+	}
+	width, height := style.rectSize(rect)
+	bboxWidth, bboxHeight := width, height
+
+	zapfdb, err := model.NewStandard14Font("ZapfDingbats")
+	if err != nil {
+		return nil, err
+	}
+
+	// The caption glyph is only used if specified by the MK dictionary.
+	style.CheckmarkRune = 0
+	mkDict, has := core.GetDict(wa.MK)
+	if has {
+		bsDict, _ := core.GetDict(wa.BS)
+		err := style.applyAppearanceCharacteristics(mkDict, bsDict, zapfdb)
+		if err != nil {
+			return nil, err
+		}
+	}
+	style.applyRequiredBorder(fbtn.PdfField)
+
+	xformOn := model.NewXObjectForm()
+	{
+		cc := contentstream.NewContentCreator()
+		if style.BorderSize > 0 {
+			drawRect(cc, style, width, height)
+		}
+		if style.DrawAlignmentReticle {
+			// Alignment reticle.
+			drawAlignmentReticle(cc, style, width, height)
+		}
+
+		// Apply rotation if present.
+		// Update width and height, as the appearance is generated based on
+		// the bounding of the annotation with no rotation.
+		width, height = style.applyRotation(mkDict, width, height, cc)
+
+		if style.CheckmarkRune != 0 {
+			fontsize := style.AutoFontSizeFraction * height
+			if err := drawCheckmarkGlyph(cc, zapfdb, style.CheckmarkRune, width, height, fontsize); err != nil {
+				return nil, err
+			}
+			xformOn.Resources = model.NewPdfPageResources()
+			xformOn.Resources.SetFontByName("ZaDb", zapfdb.ToPdfObject())
+		} else {
+			drawRadioMarker(cc, width, height, 0.5*math.Min(width, height))
+		}
 
-		xformOn.Resources = model.NewPdfPageResources()
-		xformOn.Resources.SetFontByName("ZaDb", zapfdb.ToPdfObject())
 		xformOn.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
-		xformOn.SetContentStream(cc.Bytes(), defStreamEncoder())
+		if err := style.setContentStream(xformOn, cc); err != nil {
+			return nil, err
+		}
 	}
 
 	xformOff := model.NewXObjectForm()
 	{
 		cc := contentstream.NewContentCreator()
 		if style.BorderSize > 0 {
-			drawRect(cc, style, width, height)
+			drawRect(cc, style, bboxWidth, bboxHeight)
 		}
 		xformOff.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
-		xformOff.SetContentStream(cc.Bytes(), defStreamEncoder())
+		if err := style.setContentStream(xformOff, cc); err != nil {
+			return nil, err
+		}
 	}
 
 	dchoiceapp := core.MakeDict()
 	dchoiceapp.Set("Off", xformOff.ToPdfObject())
-	dchoiceapp.Set("Yes", xformOn.ToPdfObject())
+	dchoiceapp.Set(*core.MakeName(getRadioOnState(wa, fbtn)), xformOn.ToPdfObject())
 
 	appDict := core.MakeDict()
 	appDict.Set("N", dchoiceapp)
@@ -789,6 +1726,241 @@ func genFieldCheckboxAppearance(wa *model.PdfAnnotationWidget, fbtn *model.PdfFi
 	return appDict, nil
 }
 
+// getRadioOnState returns the name of the on appearance state of the widget annotation `wa` of radio
+// button field `fbtn`, i.e. the export value of the widget. The state is determined by the existing normal
+// appearance of the widget. Otherwise, the index of the widget is used if the field has export values
+// specified by its Opt array, which allows buttons of the group to have the same export value. Finally,
+// the current appearance state (AS) of the widget is used, if it is not Off.
+func getRadioOnState(wa *model.PdfAnnotationWidget, fbtn *model.PdfFieldButton) string {
+	if apDict, ok := core.GetDict(wa.AP); ok {
+		if nDict, ok := core.GetDict(apDict.Get("N")); ok {
+			for _, key := range nDict.Keys() {
+				if key != "Off" {
+					return key.String()
+				}
+			}
+		}
+	}
+	if fbtn.Opt != nil {
+		for i, annot := range fbtn.Annotations {
+			if annot == wa {
+				return strconv.Itoa(i)
+			}
+		}
+	}
+	if as, ok := core.GetNameVal(wa.AS); ok && as != "Off" {
+		return as
+	}
+	return "Yes"
+}
+
+// genFieldPushbuttonAppearance generates an appearance dictionary for a widget annotation `wa` referenced by
+// a pushbutton field `fbtn` with form resources (DR) `dr`. The appearance displays the normal caption (CA),
+// using the font of the default appearance (DA) of the field, and the normal icon (I) of the widget
+// appearance characteristics (MK). The caption is placed relative to the icon based on the caption
+// position (TP): below (2), above (3), right (4), left (5) or overlaid (6). The caption is centered in
+// the widget if there is no icon or if TP is 0, while only the icon is displayed if TP is 1.
+func genFieldPushbuttonAppearance(wa *model.PdfAnnotationWidget, fbtn *model.PdfFieldButton, dr *model.PdfPageResources, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
+	resources := getAppearanceResources(wa)
+
+	// Get bounding Rect.
+	array, ok := core.GetArray(wa.Rect)
+	if !ok {
+		return nil, errors.New("invalid Rect")
+	}
+	rect, err := model.NewPdfRectangle(*array)
+	if err != nil {
+		return nil, err
+	}
+	width, height := style.rectSize(rect)
+	bboxWidth, bboxHeight := width, height
+
+	mkDict, has := core.GetDict(wa.MK)
+	if has {
+		bsDict, _ := core.GetDict(wa.BS)
+		err := style.applyAppearanceCharacteristics(mkDict, bsDict, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	style.applyRequiredBorder(fbtn.PdfField)
+
+	// Get the caption, the icon and the caption position.
+	var caption string
+	var icon *model.XObjectForm
+	var position int
+	if mkDict != nil {
+		if ca, ok := core.GetString(mkDict.Get("CA")); ok {
+			caption = ca.Decoded()
+		}
+		if stream, ok := core.GetStream(mkDict.Get("I")); ok {
+			if icon, err = model.NewXObjectFormFromStream(stream); err != nil {
+				return nil, err
+			}
+		}
+		if tp, ok := core.GetIntVal(mkDict.Get("TP")); ok {
+			position = tp
+		}
+	}
+	if icon == nil {
+		position = 0
+	}
+	if position == 1 {
+		caption = ""
+	}
+
+	// Get and process the default appearance string (DA) operands.
+	daOps, err := contentstream.NewContentStreamParser(getDA(fbtn.PdfField)).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	cc := contentstream.NewContentCreator()
+	if style.BorderSize > 0 {
+		drawRect(cc, style, width, height)
+	}
+	if style.DrawAlignmentReticle {
+		// Alignment reticle.
+		drawAlignmentReticle(cc, style, width, height)
+	}
+	cc.Add_q()
+	// Apply rotation if present.
+	// Update width and height, as the appearance is generated based on
+	// the bounding of the annotation with no rotation.
+	width, height = style.applyRotation(mkDict, width, height, cc)
+	style.clipToRect(cc, width, height)
+
+	// The area of the widget available for the caption and the icon.
+	box := &model.PdfRectangle{
+		Llx: style.BorderSize, Lly: style.BorderSize,
+		Urx: width - style.BorderSize, Ury: height - style.BorderSize,
+	}
+	iconBox := *box
+
+	// The caption is laid out first, as it determines the area of the icon.
+	captionContent := contentstream.NewContentCreator()
+	if caption != "" {
+		captionContent.Add_BT()
+		apFont, hasTf, err := style.processDA(fbtn.PdfField, daOps, dr, resources, captionContent)
+		if err != nil {
+			return nil, err
+		}
+		font := apFont.Font
+		fontsize := apFont.Size
+
+		encoder := font.Encoder()
+		if encoder == nil {
+			common.Log.Debug("WARN: font encoder is nil. Assuming identity encoder. Output may be incorrect.")
+			encoder = textencoding.NewIdentityTextEncoder("Identity-H")
+		}
+		textwidth := style.textWidth(font, caption)
+		spacing := style.textSpacing(font, caption)
+
+		// Autosized captions fit the area of the caption, which is half of
+		// the widget if the caption is placed next to the icon.
+		if fontsize == 0 || !hasTf {
+			maxWidth, maxHeight := box.Width(), box.Height()
+			switch position {
+			case 2, 3:
+				maxHeight /= 2
+			case 4, 5:
+				maxWidth /= 2
+			}
+			fontsize = style.AutoFontSizeFraction * maxHeight
+			if textwidth > 0 && textwidth*fontsize/1000.0+spacing > maxWidth {
+				fontsize = 0.95 * 1000.0 * fitWidth(maxWidth, spacing) / textwidth
+			}
+		}
+		captionWidth := textwidth*fontsize/1000.0 + spacing
+
+		// Split the widget area between the caption and the icon.
+		captionBox := *box
+		switch position {
+		case 2:
+			captionBox.Ury = math.Min(box.Lly+fontsize, box.Ury)
+			iconBox.Lly = captionBox.Ury
+		case 3:
+			captionBox.Lly = math.Max(box.Ury-fontsize, box.Lly)
+			iconBox.Ury = captionBox.Lly
+		case 4:
+			captionBox.Llx = math.Max(box.Urx-captionWidth, box.Llx)
+			iconBox.Urx = captionBox.Llx
+		case 5:
+			captionBox.Urx = math.Min(box.Llx+captionWidth, box.Urx)
+			iconBox.Llx = captionBox.Urx
+		}
+
+		// Center the caption in its area.
+		fdescriptor, err := font.GetFontDescriptor()
+		if err != nil {
+			common.Log.Debug("Error: Unable to get font descriptor")
+		}
+		var fcapheight float64
+		if fdescriptor != nil {
+			fcapheight, _ = fdescriptor.GetCapHeight()
+		}
+		if fcapheight <= 0 {
+			fcapheight = 1000
+		}
+		capheight := fcapheight / 1000.0 * fontsize
+		tx := captionBox.Llx + (captionBox.Width()-captionWidth)/2
+		ty := centeredBaseline(fdescriptor, fontsize, capheight, captionBox.Lly, captionBox.Ury)
+
+		captionContent.Add_Tf(*core.MakeName(apFont.Name), fontsize)
+		style.addTextSpacing(captionContent)
+		captionContent.Add_Td(tx, ty)
+		if style.Kerning {
+			addKernedText(captionContent, font, encoder, caption)
+		} else {
+			captionContent.Add_Tj(*core.MakeString(string(encoder.Encode(caption))))
+		}
+		captionContent.Add_ET()
+	}
+
+	// Draw the icon, scaled proportionally to fit its area.
+	if icon != nil && position != 0 {
+		bbox := &model.PdfRectangle{Urx: iconBox.Width(), Ury: iconBox.Height()}
+		if arr, ok := core.GetArray(icon.BBox); ok {
+			if bbox, err = model.NewPdfRectangle(*arr); err != nil {
+				return nil, err
+			}
+		}
+
+		if bbox.Width() > 0 && bbox.Height() > 0 && iconBox.Width() > 0 && iconBox.Height() > 0 {
+			scale := math.Min(iconBox.Width()/bbox.Width(), iconBox.Height()/bbox.Height())
+			x := iconBox.Llx + (iconBox.Width()-bbox.Width()*scale)/2 - bbox.Llx*scale
+			y := iconBox.Lly + (iconBox.Height()-bbox.Height()*scale)/2 - bbox.Lly*scale
+
+			name := resources.GenerateXObjectName()
+			if err := resources.SetXObjectFormByName(name, icon); err != nil {
+				return nil, err
+			}
+			cc.Add_q().
+				Add_cm(scale, 0, 0, scale, x, y).
+				Add_Do(name).
+				Add_Q()
+		}
+	}
+
+	// Draw the caption on top of the icon.
+	for _, op := range *captionContent.Operations() {
+		cc.AddOperand(*op)
+	}
+	cc.Add_Q()
+
+	xform := model.NewXObjectForm()
+	xform.Resources = resources
+	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
+	if err := style.setContentStream(xform, cc); err != nil {
+		return nil, err
+	}
+
+	apDict := core.MakeDict()
+	apDict.Set("N", xform.ToPdfObject())
+
+	return apDict, nil
+}
+
 // genFieldComboboxAppearance generates an appearance dictionary for a widget annotation `wa` referenced by a
 // combobox choice field `fch` with form resources (DR) `dr`.
 func genFieldComboboxAppearance(form *model.PdfAcroForm, wa *model.PdfAnnotationWidget, fch *model.PdfFieldChoice, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
@@ -801,7 +1973,7 @@ func genFieldComboboxAppearance(form *model.PdfAcroForm, wa *model.PdfAnnotation
 	if err != nil {
 		return nil, err
 	}
-	width, height := rect.Width(), rect.Height()
+	width, height := style.rectSize(rect)
 
 	common.Log.Debug("Choice, wa BS: %v", wa.BS)
 
@@ -819,6 +1991,7 @@ func genFieldComboboxAppearance(form *model.PdfAcroForm, wa *model.PdfAnnotation
 			return nil, err
 		}
 	}
+	style.applyRequiredBorder(fch.PdfField)
 
 	// See section 12.7.4.4 "Choice Fields" (pp. 444-446 PDF32000_2008).
 	dchoiceapp := core.MakeDict()
@@ -847,11 +2020,32 @@ func genFieldComboboxAppearance(form *model.PdfAcroForm, wa *model.PdfAnnotation
 		}
 	}
 
-	appDict := core.MakeDict()
-	appDict.Set("N", dchoiceapp)
-
-	return appDict, nil
-}
+	// The value of editable combo boxes can be typed in by the user, in which
+	// case it is not one of the options. Generate its appearance state as well,
+	// so that the value is displayed.
+	if fch.Flags().Has(model.FieldFlagEdit) {
+		var value string
+		if str, ok := core.GetString(fch.V); ok {
+			value = str.Decoded()
+		} else if name, ok := core.GetName(fch.V); ok {
+			value = name.String()
+		}
+
+		if len(value) > 0 && dchoiceapp.Get(*core.MakeName(value)) == nil {
+			xform, err := makeComboboxTextXObjForm(fch.PdfField, width, height, value, style, daOps, form.DR, mkDict)
+			if err != nil {
+				return nil, err
+			}
+
+			dchoiceapp.Set(*core.MakeName(value), xform.ToPdfObject())
+		}
+	}
+
+	appDict := core.MakeDict()
+	appDict.Set("N", dchoiceapp)
+
+	return appDict, nil
+}
 
 // Make a text-based XObj Form.
 func makeComboboxTextXObjForm(field *model.PdfField, width, height float64,
@@ -866,144 +2060,943 @@ func makeComboboxTextXObjForm(field *model.PdfField, width, height float64,
 	}
 	if style.DrawAlignmentReticle {
 		// Alignment reticle.
-		style2 := style
-		style2.BorderSize = 0.2
-		drawAlignmentReticle(cc, style2, width, height)
+		drawAlignmentReticle(cc, style, width, height)
 	}
-	cc.Add_BMC("Tx")
+	style.beginTextContent(cc)
 	cc.Add_q()
+	// Apply rotation if present.
+	// Update width and height, as the appearance is generated based on
+	// the bounding of the annotation with no rotation.
+	width, height = style.applyRotation(mkDict, width, height, cc)
+	style.clipToRect(cc, width, height)
+
 	// Graphic state changes.
 	cc.Add_BT()
 
+	// Process DA operands.
+	apFont, hasTf, err := style.processDA(field, daOps, dr, resources, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	font := apFont.Font
+	fontsize := apFont.Size
+	fontname := core.MakeName(apFont.Name)
+	autosize := fontsize == 0
+	if autosize && hasTf {
+		fontsize = height * style.AutoFontSizeFraction
+	}
+
+	encoder := font.Encoder()
+	if encoder == nil {
+		common.Log.Debug("WARN: font encoder is nil. Assuming identity encoder. Output may be incorrect.")
+		encoder = textencoding.NewIdentityTextEncoder("Identity-H")
+	}
+
+	// If no text, no appearance needed.
+	if len(text) == 0 {
+		return nil, nil
+	}
+
+	boxLeft, boxRight, tx := style.textBox(width)
+	boxBottom, boxTop := style.TextMarginBottom, height-style.TextMarginTop
+	alignment := getQuadding(field)
+
+	linewidth, spacing := 0.0, 0.0
+	if encoder != nil {
+		if style.Ellipsis && !autosize && fontsize > 0 {
+			maxWidth := boxRight - boxLeft
+			if alignment == QuaddingLeft {
+				maxWidth = boxRight - tx
+			}
+			text = style.ellipsize(font, fontsize, text, 1000*maxWidth/fontsize, alignment == QuaddingRight)
+		}
+		linewidth = style.textWidth(font, text)
+		spacing = style.textSpacing(font, text)
+	}
+	decoded := text
+	if encoder != nil {
+		text = string(encoder.Encode(text))
+	}
+
+	// Check if text goes out of bounds, if goes out of bounds, then adjust font size until just within bounds.
+	if fontsize == 0 || autosize && linewidth > 0 && tx+linewidth*fontsize/1000.0+spacing > boxRight {
+		fontsize = 0.95 * 1000.0 * fitWidth(boxRight-tx, spacing) / linewidth
+	}
+
+	lineheight := 1.0 * fontsize
+
+	// Vertical alignment.
+	ty := boxBottom + 2.0
+	{
+		textheight := lineheight
+		if autosize && ty+textheight > boxTop {
+			fontsize = 0.95 * (boxTop - ty)
+			lineheight = 1.0 * fontsize
+			textheight = lineheight
+		}
+
+		if boxTop-boxBottom > textheight {
+			ty = boxBottom + (boxTop-boxBottom-textheight)/2.0
+			ty += 1.50 // TODO(gunnsth): Make configurable/part of style parameter.
+		}
+	}
+
+	// Horizontal alignment.
+	remaining := boxRight - boxLeft - linewidth*fontsize/1000.0 - spacing
+	switch alignment {
+	case QuaddingCenter:
+		tx = boxLeft + remaining/2
+	case QuaddingRight:
+		tx = boxLeft + remaining
+	}
+
+	cc.Add_Tf(*fontname, fontsize)
+	style.addTextSpacing(cc)
+	cc.Add_Td(tx, ty)
+	if style.Kerning {
+		addKernedText(cc, font, encoder, decoded)
+	} else {
+		cc.Add_Tj(*core.MakeString(text))
+	}
+
+	cc.Add_ET()
+	cc.Add_Q()
+	cc.Add_EMC()
+
+	xform := model.NewXObjectForm()
+	xform.Resources = resources
+	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
+	if err := style.setContentStream(xform, cc); err != nil {
+		return nil, err
+	}
+
+	return xform, nil
+}
+
+// genFieldListboxAppearance generates an appearance dictionary for a widget annotation `wa` referenced by
+// a list box choice field `fch` with form resources (DR) `dr`. The options of the field are drawn on
+// separate lines, starting with the top index (TI) option, and the selected options are highlighted.
+func genFieldListboxAppearance(wa *model.PdfAnnotationWidget, fch *model.PdfFieldChoice, dr *model.PdfPageResources, style AppearanceStyle) (*core.PdfObjectDictionary, error) {
+	resources := getAppearanceResources(wa)
+
+	// Get bounding Rect.
+	array, ok := core.GetArray(wa.Rect)
+	if !ok {
+		return nil, errors.New("invalid Rect")
+	}
+	rect, err := model.NewPdfRectangle(*array)
+	if err != nil {
+		return nil, err
+	}
+	width, height := style.rectSize(rect)
+	bboxWidth, bboxHeight := width, height
+
+	mkDict, has := core.GetDict(wa.MK)
+	if has {
+		bsDict, _ := core.GetDict(wa.BS)
+		err := style.applyAppearanceCharacteristics(mkDict, bsDict, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	style.applyRequiredBorder(fch.PdfField)
+
+	// Get and process the default appearance string (DA) operands.
+	daOps, err := contentstream.NewContentStreamParser(getDA(fch.PdfField)).Parse()
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the displayed text and the export value of the options.
+	// See section 12.7.4.4 "Choice Fields" (pp. 444-446 PDF32000_2008).
+	var options, exports []string
+	if fch.Opt != nil {
+		for _, optObj := range fch.Opt.Elements() {
+			exportObj := optObj
+			if optArr, ok := core.GetArray(optObj); ok && optArr.Len() == 2 {
+				exportObj, optObj = optArr.Get(0), optArr.Get(1)
+			}
+
+			var optstr, exportstr string
+			if opt, ok := core.GetString(optObj); ok {
+				optstr = opt.Decoded()
+			} else if opt, ok := core.GetName(optObj); ok {
+				optstr = opt.String()
+			} else {
+				common.Log.Debug("ERROR: Opt not a name/string - %T", optObj)
+				return nil, errors.New("not a name/string")
+			}
+			if export, ok := core.GetString(exportObj); ok {
+				exportstr = export.Decoded()
+			} else {
+				exportstr = optstr
+			}
+
+			options = append(options, optstr)
+			exports = append(exports, exportstr)
+		}
+	}
+
+	// Determine the selected options. Multiple selection list boxes have an
+	// array of values. Use the selection indices (I) if the values are not set.
+	var values []string
+	if str, ok := core.GetString(fch.V); ok {
+		values = append(values, str.Decoded())
+	} else if arr, ok := core.GetArray(fch.V); ok {
+		for _, obj := range arr.Elements() {
+			if str, ok := core.GetString(obj); ok {
+				values = append(values, str.Decoded())
+			}
+		}
+	}
+	selected := map[int]bool{}
+	for i, export := range exports {
+		for _, value := range values {
+			if export == value {
+				selected[i] = true
+			}
+		}
+	}
+	if len(selected) == 0 && fch.I != nil {
+		for _, obj := range fch.I.Elements() {
+			if idx, ok := core.GetIntVal(obj); ok && idx >= 0 && idx < len(options) {
+				selected[idx] = true
+			}
+		}
+	}
+
+	cc := contentstream.NewContentCreator()
+	if style.BorderSize > 0 {
+		drawRect(cc, style, width, height)
+	}
+	if style.DrawAlignmentReticle {
+		// Alignment reticle.
+		drawAlignmentReticle(cc, style, width, height)
+	}
+	style.beginTextContent(cc)
+	cc.Add_q()
+
 	// Apply rotation if present.
 	// Update width and height, as the appearance is generated based on
 	// the bounding of the annotation with no rotation.
 	width, height = style.applyRotation(mkDict, width, height, cc)
+	style.clipToRect(cc, width, height)
 
-	// Process DA operands.
-	apFont, hasTf, err := style.processDA(field, daOps, dr, resources, cc)
+	// Process DA operands. The operands are added in the text object, after
+	// drawing the selection highlight.
+	daCC := contentstream.NewContentCreator()
+	apFont, _, err := style.processDA(fch.PdfField, daOps, dr, resources, daCC)
 	if err != nil {
 		return nil, err
 	}
 
-	font := apFont.Font
-	fontsize := apFont.Size
-	fontname := core.MakeName(apFont.Name)
-	autosize := fontsize == 0
-	if autosize && hasTf {
-		fontsize = height * style.AutoFontSizeFraction
+	font := apFont.Font
+	fontsize := apFont.Size
+	fontname := core.MakeName(apFont.Name)
+	if fontsize == 0 {
+		// Auto sized list boxes use the default font size.
+		fontsize = 12
+	}
+
+	encoder := font.Encoder()
+	if encoder == nil {
+		common.Log.Debug("WARN: font encoder is nil. Assuming identity encoder. Output may be incorrect.")
+		encoder = textencoding.NewIdentityTextEncoder("Identity-H")
+	}
+
+	var fcapheight float64
+	if fdescriptor, err := font.GetFontDescriptor(); err == nil && fdescriptor != nil {
+		fcapheight, err = fdescriptor.GetCapHeight()
+		if err != nil {
+			common.Log.Debug("ERROR: Unable to get font CapHeight: %v", err)
+		}
+	}
+	if int(fcapheight) <= 0 {
+		common.Log.Debug("WARN: CapHeight not available - setting to 1000")
+		fcapheight = 1000
+	}
+	capheight := fcapheight / 1000.0 * fontsize
+
+	// Determine the visible rows, scrolling so that the first selected
+	// option is visible.
+	_, _, tx := style.textBox(width)
+	lineheight := style.MultilineLineHeight * fontsize
+	if lineheight <= 0 {
+		lineheight = fontsize
+	}
+	numRows := int((height - 2*style.BorderSize) / lineheight)
+	if numRows < 1 {
+		numRows = 1
+	}
+
+	start := 0
+	if ti, ok := core.GetIntVal(fch.TI); ok && ti > 0 && ti < len(options) {
+		start = ti
+	}
+	for i := range options {
+		if !selected[i] {
+			continue
+		}
+		if i < start {
+			start = i
+		} else if i >= start+numRows {
+			start = i - numRows + 1
+		}
+		break
+	}
+	end := start + numRows
+	if end > len(options) {
+		end = len(options)
+	}
+
+	// Draw the selection highlight.
+	selectionColor := style.ListboxSelectionColor
+	if selectionColor == nil {
+		selectionColor = model.NewPdfColorDeviceRGB(0.6, 0.757, 0.855)
+	}
+	top := height - style.BorderSize
+	for i := start; i < end; i++ {
+		if !selected[i] {
+			continue
+		}
+		y := top - float64(i-start+1)*lineheight
+		cc.Add_q().
+			SetNonStrokingColor(selectionColor).
+			Add_re(style.BorderSize, y, width-2*style.BorderSize, lineheight).
+			Add_f().
+			Add_Q()
+	}
+
+	// Draw the options.
+	cc.Add_BT()
+	for _, op := range *daCC.Operations() {
+		cc.AddOperand(*op)
+	}
+	cc.Add_Tf(*fontname, fontsize)
+
+	var x, y float64
+	for i := start; i < end; i++ {
+		text := options[i]
+		ynew := top - float64(i-start+1)*lineheight + (lineheight-capheight)/2
+		cc.Add_Td(tx-x, ynew-y)
+		x, y = tx, ynew
+
+		if style.Kerning {
+			addKernedText(cc, font, encoder, text)
+		} else {
+			cc.Add_Tj(*core.MakeStringFromBytes(encoder.Encode(text)))
+		}
+	}
+	cc.Add_ET()
+	cc.Add_Q()
+	cc.Add_EMC()
+
+	xform := model.NewXObjectForm()
+	xform.Resources = resources
+	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
+	if err := style.setContentStream(xform, cc); err != nil {
+		return nil, err
+	}
+
+	apDict := core.MakeDict()
+	apDict.Set("N", xform.ToPdfObject())
+
+	return apDict, nil
+}
+
+// getDA returns the default appearance text (DA) for a given field `ftxt`.
+// If not set for `ftxt` then checks if set by Parent (inherited), otherwise
+// returns "".
+func getDA(field *model.PdfField) string {
+	if field == nil {
+		return ""
+	}
+
+	ftxt, ok := field.GetContext().(*model.PdfFieldText)
+	if !ok {
+		// The DA of other fields, such as pushbuttons, is only available
+		// in the field dictionary.
+		if dict, ok := core.GetDict(field.GetContainingPdfObject()); ok {
+			if da, ok := core.GetString(dict.Get("DA")); ok {
+				return da.Str()
+			}
+		}
+		return getDA(field.Parent)
+	}
+
+	if ftxt.DA != nil {
+		return ftxt.DA.Str()
+	}
+
+	return getDA(ftxt.Parent)
+}
+
+// lineAlignment returns the alignment of the line at `lineIndex` of a text
+// field appearance, given the `alignment` based on the quadding of the
+// field.
+func (style AppearanceStyle) lineAlignment(alignment Quadding, lineIndex int) Quadding {
+	if style.LineAlignFunc != nil {
+		return style.LineAlignFunc(lineIndex)
+	}
+	return alignment
+}
+
+// getQ returns the quadding (Q) entry of `field`. As Q is inheritable, the
+// parents of the field are looked up if the field does not specify it.
+func getQ(field *model.PdfField) core.PdfObject {
+	if field == nil {
+		return nil
+	}
+
+	if ftxt, ok := field.GetContext().(*model.PdfFieldText); ok {
+		if ftxt.Q != nil {
+			return ftxt.Q
+		}
+	} else if dict, ok := core.GetDict(field.GetContainingPdfObject()); ok {
+		if q := dict.Get("Q"); q != nil {
+			return q
+		}
+	}
+
+	return getQ(field.Parent)
+}
+
+// getQuadding returns the horizontal alignment (quadding) of the variable
+// text of `field`, specified by its Q entry, which may be inherited from
+// the parents of the field. Fields with no quadding or with an unsupported
+// quadding are left aligned.
+func getQuadding(field *model.PdfField) Quadding {
+	val, has := core.GetIntVal(getQ(field))
+	if !has {
+		return QuaddingLeft
+	}
+	switch val {
+	case 0: // Left aligned.
+		return QuaddingLeft
+	case 1: // Centered.
+		return QuaddingCenter
+	case 2: // Right justified.
+		return QuaddingRight
+	}
+	common.Log.Debug("ERROR: Unsupported quadding: %d - using left alignment", val)
+	return QuaddingLeft
+}
+
+// getAppearanceResources returns the resources of a regenerated appearance
+// for widget annotation `wa`. The resources of the existing normal appearance
+// of the widget, if any, are used as a base so that resources referenced by
+// the original appearance (e.g. fonts not mentioned in the DA) are preserved.
+func getAppearanceResources(wa *model.PdfAnnotationWidget) *model.PdfPageResources {
+	resources := model.NewPdfPageResources()
+
+	apDict, has := core.GetDict(wa.AP)
+	if !has {
+		return resources
+	}
+	stream, ok := core.GetStream(apDict.Get("N"))
+	if !ok {
+		return resources
+	}
+	resDict, ok := core.GetDict(stream.Get("Resources"))
+	if !ok {
+		return resources
+	}
+
+	existing, err := model.NewPdfPageResourcesFromDict(resDict)
+	if err != nil {
+		common.Log.Debug("ERROR: could not load appearance resources: %v", err)
+		return resources
+	}
+
+	// Copy the font dictionary, as the appearance font is added to it.
+	if fontDict, ok := core.GetDict(existing.Font); ok {
+		existing.Font = core.MakeDict().Merge(fontDict)
+	}
+	return existing
+}
+
+// fieldAutoFontSizeFraction returns the fraction of the annotation height to
+// fill when autosizing the contents of `field`, taking into account the
+// field specific overrides of the style.
+func (style AppearanceStyle) fieldAutoFontSizeFraction(field *model.PdfField) float64 {
+	if style.FieldAutoFontSizeFractions == nil || field == nil {
+		return style.AutoFontSizeFraction
+	}
+	if fraction, ok := style.FieldAutoFontSizeFractions[field.PartialName()]; ok {
+		return fraction
+	}
+	if fullName, err := field.FullName(); err == nil {
+		if fraction, ok := style.FieldAutoFontSizeFractions[fullName]; ok {
+			return fraction
+		}
+	}
+	return style.AutoFontSizeFraction
+}
+
+// centeredBaseline returns the baseline position of a single line of text
+// of the specified font size, vertically centered between `bottom` and
+// `top`. The text is centered on the glyph box spanning from the descent to
+// the ascent of the font descriptor `fd`. If the descriptor metrics are not
+// available, the cap height of the text (`capheight`) is centered instead.
+func centeredBaseline(fd *model.PdfFontDescriptor, fontsize, capheight, bottom, top float64) float64 {
+	if fd != nil {
+		ascent, errA := fd.GetAscent()
+		descent, errD := fd.GetDescent()
+		if errA == nil && errD == nil && ascent > 0 {
+			ascent = ascent / 1000.0 * fontsize
+			descent = -math.Abs(descent) / 1000.0 * fontsize
+			return bottom + (top-bottom-(ascent-descent))/2.0 - descent
+		}
+	}
+	return bottom + (top-bottom-capheight)/2.0
+}
+
+// formatText returns the appearance text of the `field` with the specified
+// `value`, transformed using the format function of the style, if set.
+func (style AppearanceStyle) formatText(field *model.PdfField, value string) string {
+	if style.FormatFunc == nil || value == "" {
+		return value
+	}
+	return style.FormatFunc(field, value)
+}
+
+// fieldText returns the appearance text of the text field `ftxt` with the
+// specified `value`. The value of password fields is replaced by the mask
+// of the value, while the value of other fields is formatted using the
+// FormatFunc of the style.
+func (style AppearanceStyle) fieldText(ftxt *model.PdfFieldText, value string) string {
+	if ftxt.Flags().Has(model.FieldFlagPassword) {
+		return style.passwordMask(value)
+	}
+	return style.formatText(ftxt.PdfField, value)
+}
+
+// passwordMask returns the PasswordRune of the style repeated once per rune
+// of the password `value`.
+func (style AppearanceStyle) passwordMask(value string) string {
+	maskRune := style.PasswordRune
+	if maskRune == 0 {
+		maskRune = '•'
+	}
+	return strings.Repeat(string(maskRune), utf8.RuneCountInString(value))
+}
+
+// textBox returns the left and right edges of the area available for the
+// text of a field appearance of the specified width, based on the text
+// margins of the style, along with the position of left aligned text.
+func (style AppearanceStyle) textBox(width float64) (left, right, tx float64) {
+	left, right = style.TextMarginLeft, width-style.TextMarginRight
+	tx = left
+	if left == 0 {
+		// Default left margin.
+		tx = 2.0
+	}
+	return left, right, tx
+}
+
+// textWidth returns the width of `text` in glyph space units (1/1000 of text
+// space), for the specified font. Kerning adjustments are included if
+// kerning is enabled by the style.
+func (style AppearanceStyle) textWidth(font *model.PdfFont, text string) float64 {
+	width := model.MeasureTextWidth(font, text, 1000)
+	if !style.Kerning {
+		return width
+	}
+
+	runes := []rune(text)
+	for i := 1; i < len(runes); i++ {
+		if kern, ok := font.GetKerning(runes[i-1], runes[i]); ok {
+			width += kern
+		}
+	}
+	return width
+}
+
+// textSpacing returns the additional width of `text`, in unscaled text space
+// units, resulting from the character and word spacing of the style. The
+// word spacing only applies to the spaces of simple fonts.
+func (style AppearanceStyle) textSpacing(font *model.PdfFont, text string) float64 {
+	if style.CharSpacing == 0 && style.WordSpacing == 0 {
+		return 0
+	}
+
+	spacing := style.CharSpacing * float64(utf8.RuneCountInString(text))
+	if !font.IsCID() {
+		spacing += style.WordSpacing * float64(strings.Count(text, " "))
+	}
+	return spacing
+}
+
+// spacedTextWidth returns the width of `text` in glyph space units, including
+// the character and word spacing of the style, for the specified font and
+// font size.
+func (style AppearanceStyle) spacedTextWidth(font *model.PdfFont, text string, fontsize float64) float64 {
+	width := style.textWidth(font, text)
+	if fontsize > 0 {
+		width += 1000 * style.textSpacing(font, text) / fontsize
+	}
+	return width
+}
+
+// fitWidth returns the width available for the glyphs of autosized text, out
+// of the `available` width, when the text spacing takes `spacing` of it. The
+// spacing is ignored if it does not leave any room for the glyphs.
+func fitWidth(available, spacing float64) float64 {
+	if spacing >= available {
+		return available
+	}
+	return available - spacing
+}
+
+// addTextSpacing adds the operators setting the character and word spacing
+// of the style, if any.
+func (style AppearanceStyle) addTextSpacing(cc *contentstream.ContentCreator) {
+	if style.CharSpacing != 0 {
+		cc.Add_Tc(style.CharSpacing)
+	}
+	if style.WordSpacing != 0 {
+		cc.Add_Tw(style.WordSpacing)
+	}
+}
+
+// ellipsize truncates `text` so that it fits the width `maxWidth`, in glyph
+// space units, along with the ellipsis marking the truncation. The text is
+// measured using the metrics of `font` and the text spacing of the style,
+// at the specified `fontsize`. If `fromStart` is true, the beginning
+// of the text is truncated and the ellipsis is prepended, otherwise the end
+// of the text is truncated and the ellipsis is appended. The text is returned
+// unchanged if it fits the width. The ellipsis is the EllipsisRune of the
+// style, or three periods if the font has no glyph for it.
+func (style AppearanceStyle) ellipsize(font *model.PdfFont, fontsize float64, text string, maxWidth float64, fromStart bool) string {
+	if style.spacedTextWidth(font, text, fontsize) <= maxWidth {
+		return text
+	}
+
+	ellipsisRune := style.EllipsisRune
+	if ellipsisRune == 0 {
+		ellipsisRune = '…'
+	}
+	ellipsis := string(ellipsisRune)
+	if !hasRuneGlyph(font, ellipsisRune) {
+		ellipsis = "..."
+	}
+
+	runes := []rune(text)
+	for n := len(runes) - 1; n > 0; n-- {
+		var truncated string
+		if fromStart {
+			truncated = ellipsis + strings.TrimLeft(string(runes[len(runes)-n:]), " ")
+		} else {
+			truncated = strings.TrimRight(string(runes[:n]), " ") + ellipsis
+		}
+		if style.spacedTextWidth(font, truncated, fontsize) <= maxWidth {
+			return truncated
+		}
+	}
+	return ellipsis
+}
+
+// hasRuneGlyph returns true if `font` has a glyph for the rune `r`, which
+// can be encoded by the font encoder. The missing width of the font, which
+// GetRuneMetrics returns for the runes without metrics, is not considered
+// a glyph.
+func hasRuneGlyph(font *model.PdfFont, r rune) bool {
+	if encoder := font.Encoder(); encoder != nil {
+		if _, ok := encoder.RuneToCharcode(r); !ok {
+			return false
+		}
+	}
+	metrics, has := font.GetRuneMetrics(r)
+	return has && metrics.Wx > 0
+}
+
+// addKernedText shows `text` using a TJ operator, which applies the
+// kerning adjustments of the font between the glyphs of the text. If the
+// text contains no kerned pairs, a Tj operator is used instead.
+func addKernedText(cc *contentstream.ContentCreator, font *model.PdfFont, encoder textencoding.TextEncoder, text string) {
+	runes := []rune(text)
+
+	var parts []core.PdfObject
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		kern, ok := font.GetKerning(runes[i-1], runes[i])
+		if !ok || kern == 0 {
+			continue
+		}
+
+		// TJ adjustments are subtracted from the horizontal position.
+		parts = append(parts,
+			core.MakeStringFromBytes(encoder.Encode(string(runes[start:i]))),
+			core.MakeFloat(-kern))
+		start = i
+	}
+
+	encoded := encoder.Encode(string(runes[start:]))
+	if len(parts) == 0 {
+		cc.Add_Tj(*core.MakeStringFromBytes(encoded))
+		return
 	}
+	parts = append(parts, core.MakeStringFromBytes(encoded))
+	cc.Add_TJ(parts...)
+}
 
-	encoder := font.Encoder()
-	if encoder == nil {
-		common.Log.Debug("WARN: font encoder is nil. Assuming identity encoder. Output may be incorrect.")
-		encoder = textencoding.NewIdentityTextEncoder("Identity-H")
+// applyRequiredBorder replaces the border of the style by the required
+// field border, if `field` has the Required flag set and the style specifies
+// a required border.
+func (style *AppearanceStyle) applyRequiredBorder(field *model.PdfField) {
+	if style.RequiredBorderSize <= 0 || !field.Flags().Has(model.FieldFlagRequired) {
+		return
+	}
+	style.BorderSize = style.RequiredBorderSize
+	if style.RequiredBorderColor != nil {
+		style.BorderColor = style.RequiredBorderColor
 	}
+}
 
-	// If no text, no appearance needed.
-	if len(text) == 0 {
-		return nil, nil
+// drawRect draws the annotation Rectangle.
+func drawRect(cc *contentstream.ContentCreator, style AppearanceStyle, width, height float64) {
+	cc.Add_q().
+		Add_re(0, 0, width, height).
+		Add_w(style.BorderSize).
+		SetStrokingColor(style.BorderColor).
+		SetNonStrokingColor(style.FillColor).
+		Add_B().
+		Add_Q()
+}
+
+// drawCheckboxBox strokes the outline of the empty box of checkboxes, using
+// the checkbox box line width and color of the style. The outline is inset
+// by half the line width, so that it is contained in the rectangle defined
+// by `width` and `height`.
+func drawCheckboxBox(cc *contentstream.ContentCreator, style AppearanceStyle, width, height float64) {
+	lineWidth := style.CheckboxBoxLineWidth
+	if lineWidth <= 0 {
+		lineWidth = 1
+	}
+	color := style.CheckboxBoxColor
+	if color == nil {
+		color = model.NewPdfColorDeviceGray(0)
 	}
 
-	tx := 2.0 // Default left margin. // TODO(gunnsth): Add to style options.
+	inset := lineWidth / 2
+	cc.Add_q().
+		Add_w(lineWidth).
+		SetStrokingColor(color).
+		Add_re(inset, inset, math.Max(width-lineWidth, 0), math.Max(height-lineWidth, 0)).
+		Add_S().
+		Add_Q()
+}
 
-	linewidth := 0.0
-	if encoder != nil {
-		for _, r := range text {
-			metrics, has := font.GetRuneMetrics(r)
-			if !has {
-				common.Log.Debug("Font does not have rune metrics for %v - skipping", r)
-				continue
+// drawCheckmark draws a check mark of the specified style using path
+// operators. The mark is centered in the rectangle defined by `width` and
+// `height` and it is contained in a square of side `size`.
+func drawCheckmark(cc *contentstream.ContentCreator, checkStyle CheckmarkStyle, width, height, size float64) {
+	size = math.Min(size, math.Min(width, height))
+	x0, y0 := (width-size)/2, (height-size)/2
+
+	// Returns a point relative to the check mark square.
+	point := func(x, y float64) draw.Point {
+		return draw.NewPoint(x0+x*size, y0+y*size)
+	}
+
+	// Draw the filled marks.
+	var points []draw.Point
+	switch checkStyle {
+	case CheckmarkStyleVectorCircle:
+		drawRadioMarker(cc, width, height, 0.8*size)
+		return
+	case CheckmarkStyleVectorSquare:
+		points = []draw.Point{point(0.15, 0.15), point(0.85, 0.15), point(0.85, 0.85), point(0.15, 0.85)}
+	case CheckmarkStyleVectorDiamond:
+		points = []draw.Point{point(0.5, 0.05), point(0.95, 0.5), point(0.5, 0.95), point(0.05, 0.5)}
+	case CheckmarkStyleVectorStar:
+		// Alternate the outer and inner vertices of the star, starting from
+		// the top vertex.
+		for i := 0; i < 10; i++ {
+			r := 0.5
+			if i%2 == 1 {
+				r *= 0.382
 			}
-			linewidth += metrics.Wx
+			angle := math.Pi/2 + float64(i)*math.Pi/5
+			points = append(points, point(0.5+r*math.Cos(angle), 0.45+r*math.Sin(angle)))
 		}
-
-		text = string(encoder.Encode(text))
+	}
+	if len(points) > 0 {
+		cc.Add_q().Add_g(0)
+		draw.DrawPathWithCreator(draw.Path{Points: points}, cc)
+		cc.Add_h().Add_f().Add_Q()
+		return
 	}
 
-	// Check if text goes out of bounds, if goes out of bounds, then adjust font size until just within bounds.
-	if fontsize == 0 || autosize && linewidth > 0 && tx+linewidth*fontsize/1000.0 > width {
-		// TODO(gunnsth): Add to style options.
-		fontsize = 0.95 * 1000.0 * (width - tx) / linewidth
+	// Use round line caps and joins.
+	cc.Add_q().
+		Add_G(0).
+		Add_w(0.12 * size).
+		AddOperand(contentstream.ContentStreamOperation{
+			Operand: "J", Params: []core.PdfObject{core.MakeInteger(1)},
+		}).
+		AddOperand(contentstream.ContentStreamOperation{
+			Operand: "j", Params: []core.PdfObject{core.MakeInteger(1)},
+		})
+
+	switch checkStyle {
+	case CheckmarkStyleVectorCross:
+		draw.DrawPathWithCreator(draw.Path{Points: []draw.Point{
+			point(0.15, 0.15), point(0.85, 0.85),
+		}}, cc)
+		draw.DrawPathWithCreator(draw.Path{Points: []draw.Point{
+			point(0.15, 0.85), point(0.85, 0.15),
+		}}, cc)
+	default:
+		draw.DrawPathWithCreator(draw.Path{Points: []draw.Point{
+			point(0.1, 0.5), point(0.4, 0.15), point(0.9, 0.85),
+		}}, cc)
 	}
 
-	lineheight := 1.0 * fontsize
+	cc.Add_S().Add_Q()
+}
 
-	// Vertical alignment.
-	ty := 2.0
-	{
-		textheight := lineheight
-		if autosize && ty+textheight > height {
-			fontsize = 0.95 * (height - ty)
-			lineheight = 1.0 * fontsize
-			textheight = lineheight
-		}
+// clipToRect sets the clipping path to the rectangle defined by `width` and
+// `height`, if clipping is enabled by the style, so that the contents drawn
+// afterwards cannot go outside the annotation rectangle. As the clipping path
+// is set in the current coordinate system, the rotation of the appearance
+// must be applied before.
+func (style AppearanceStyle) clipToRect(cc *contentstream.ContentCreator, width, height float64) {
+	if !style.ClipToRect {
+		return
+	}
+	cc.Add_re(0, 0, width, height).Add_W().Add_n()
+}
 
-		if height > textheight {
-			ty = (height - textheight) / 2.0
-			ty += 1.50 // TODO(gunnsth): Make configurable/part of style parameter.
+// textDecorations returns whether the contents of text field `ftxt` should
+// be underlined and struck through, based on the style and on the
+// text-decoration style of the rich text value (RV) of the field.
+func (style AppearanceStyle) textDecorations(ftxt *model.PdfFieldText) (underline, strikethrough bool) {
+	underline, strikethrough = style.Underline, style.Strikethrough
+
+	var rv string
+	switch t := core.TraceToDirectObject(ftxt.RV).(type) {
+	case *core.PdfObjectString:
+		rv = t.Decoded()
+	case *core.PdfObjectStream:
+		data, err := core.DecodeStream(t)
+		if err != nil {
+			common.Log.Debug("ERROR: unable to decode RV stream: %v", err)
+			return underline, strikethrough
 		}
+		rv = string(data)
 	}
 
-	cc.Add_Tf(*fontname, fontsize)
-	cc.Add_Td(tx, ty)
-	cc.Add_Tj(*core.MakeString(text))
-
-	cc.Add_ET()
-	cc.Add_Q()
-	cc.Add_EMC()
-
-	xform := model.NewXObjectForm()
-	xform.Resources = resources
-	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, bboxWidth, bboxHeight})
-	xform.SetContentStream(cc.Bytes(), defStreamEncoder())
-
-	return xform, nil
+	for _, match := range reTextDecoration.FindAllStringSubmatch(strings.ToLower(rv), -1) {
+		underline = underline || strings.Contains(match[1], "underline")
+		strikethrough = strikethrough || strings.Contains(match[1], "line-through")
+	}
+	return underline, strikethrough
 }
 
-// getDA returns the default appearance text (DA) for a given field `ftxt`.
-// If not set for `ftxt` then checks if set by Parent (inherited), otherwise
-// returns "".
-func getDA(field *model.PdfField) string {
-	if field == nil {
-		return ""
+// reTextDecoration matches the values of the text-decoration properties of
+// rich text styles.
+var reTextDecoration = regexp.MustCompile(`text-decoration\s*:\s*([^;"']*)`)
+
+// textDecorationMetrics returns the vertical offsets from the baseline of
+// the underline and strikethrough lines of text drawn using `font` at size
+// `fontsize`, along with the thickness of the lines. The underline is
+// placed at 10% of the font size below the baseline, and the strikethrough
+// in the middle of the x-height of the font. The thickness is derived from
+// the vertical stem width of the font.
+func textDecorationMetrics(font *model.PdfFont, fontsize float64) (underlinePos, strikethroughPos, thickness float64) {
+	xheight, stemV := 500.0, 100.0
+	if fdescriptor, err := font.GetFontDescriptor(); err == nil && fdescriptor != nil {
+		if val, err := core.GetNumberAsFloat(core.TraceToDirectObject(fdescriptor.XHeight)); err == nil && val > 0 {
+			xheight = val
+		}
+		if val, err := core.GetNumberAsFloat(core.TraceToDirectObject(fdescriptor.StemV)); err == nil && val > 0 {
+			stemV = val
+		}
 	}
 
-	ftxt, ok := field.GetContext().(*model.PdfFieldText)
+	underlinePos = -0.1 * fontsize
+	strikethroughPos = xheight / 2 / 1000.0 * fontsize
+	thickness = stemV / 2 / 1000.0 * fontsize
+	return underlinePos, strikethroughPos, thickness
+}
+
+// drawCheckmarkGlyph draws the glyph of rune `r` of the ZapfDingbats font
+// `zapfdb`, centered in the rectangle defined by `width` and `height`.
+// The font must be available as "ZaDb" in the resources of the appearance.
+func drawCheckmarkGlyph(cc *contentstream.ContentCreator, zapfdb *model.PdfFont, r rune, width, height, fontsize float64) error {
+	checkmetrics, ok := zapfdb.GetRuneMetrics(r)
 	if !ok {
-		return getDA(field.Parent)
+		return errors.New("glyph not found")
 	}
+	enc := zapfdb.Encoder()
+	checkstr := enc.Encode(string(r))
 
-	if ftxt.DA != nil {
-		return ftxt.DA.Str()
-	}
+	checkwidth := checkmetrics.Wx * fontsize / 1000.0
+	// TODO: Get bbox of specific glyph that is chosen.  Choice of specific value will cause slight
+	// deviations for other glyphs, but should be fairly close.
+	fcheckheight := 705.0 // From AFM for code 52.
+	checkheight := fcheckheight / 1000.0 * fontsize
 
-	return getDA(ftxt.Parent)
-}
+	tx := 2.0
+	ty := 1.0
+	if checkwidth < width {
+		tx = (width - checkwidth) / 2.0
+	}
+	if checkheight < height {
+		ty = (height - checkheight) / 2.0
+	}
 
-// drawRect draws the annotation Rectangle.
-// TODO(gunnsth): Apply clipping so annotation contents cannot go outside Rect.
-func drawRect(cc *contentstream.ContentCreator, style AppearanceStyle, width, height float64) {
 	cc.Add_q().
-		Add_re(0, 0, width, height).
-		Add_w(style.BorderSize).
-		SetStrokingColor(style.BorderColor).
-		SetNonStrokingColor(style.FillColor).
-		Add_B().
+		Add_g(0).
+		Add_BT().
+		Add_Tf("ZaDb", fontsize).
+		Add_Td(tx, ty).
+		Add_Tj(*core.MakeStringFromBytes(checkstr)).
+		Add_ET().
 		Add_Q()
+	return nil
+}
+
+// drawRadioMarker draws a filled circle of diameter `size`, centered in the
+// rectangle defined by `width` and `height`.
+func drawRadioMarker(cc *contentstream.ContentCreator, width, height, size float64) {
+	r := math.Min(size, math.Min(width, height)) / 2
+	m := r * 0.551784 // Bezier control point distance for circle approximation.
+
+	bpath := draw.NewCubicBezierPath()
+	bpath = bpath.AppendCurve(draw.NewCubicBezierCurve(-r, 0, -r, m, -m, r, 0, r))
+	bpath = bpath.AppendCurve(draw.NewCubicBezierCurve(0, r, m, r, r, m, r, 0))
+	bpath = bpath.AppendCurve(draw.NewCubicBezierCurve(r, 0, r, -m, m, -r, 0, -r))
+	bpath = bpath.AppendCurve(draw.NewCubicBezierCurve(0, -r, -m, -r, -r, -m, -r, 0))
+	bpath = bpath.Offset(width/2, height/2)
+
+	cc.Add_q().Add_g(0)
+	draw.DrawBezierPathWithCreator(bpath, cc)
+	cc.Add_h().Add_f().Add_Q()
 }
 
 // drawAlignmentReticle draws the Rect box with a reticle on top for alignment guidance.
+// Only the lines are stroked, so that the contents underneath remain visible.
 func drawAlignmentReticle(cc *contentstream.ContentCreator, style AppearanceStyle, width, height float64) {
+	lineWidth := style.ReticleLineWidth
+	if lineWidth <= 0 {
+		lineWidth = 0.2
+	}
+	color := style.ReticleColor
+	if color == nil {
+		color = style.BorderColor
+	}
+
 	cc.Add_q().
 		Add_re(0, 0, width, height).
 		Add_re(0, height/2, width, height/2).
 		Add_re(0, 0, width, height).
 		Add_re(width/2, 0, width/2, height).
-		Add_w(style.BorderSize).
-		SetStrokingColor(style.BorderColor).
-		SetNonStrokingColor(style.FillColor).
-		Add_B().
+		Add_w(lineWidth).
+		SetStrokingColor(color).
+		Add_S().
 		Add_Q()
 }
 
@@ -1075,17 +3068,18 @@ func (style *AppearanceStyle) applyAppearanceCharacteristics(mkDict *core.PdfObj
 // applyRotation applies the rotation specified by the MK dictionary,
 // if present. The method returns the width and height of the annotation
 // rectangle with no rotation.
+// The MK rotation (R) must be a multiple of 90 degrees. Other values are
+// snapped to the nearest multiple of 90, as the generated appearance BBox
+// always matches the annotation rectangle.
+//
+// The MK rotation takes precedence over the rotation of the matrix of the
+// existing appearance of the widget. If the widget has no MK rotation, the
+// existing matrix is kept by the generated appearance, so the contents are
+// not rotated, but laid out in the coordinate space of the matrix (see
+// rectSize).
 func (style *AppearanceStyle) applyRotation(mkDict *core.PdfObjectDictionary,
 	width, height float64, cc *contentstream.ContentCreator) (float64, float64) {
-	if !style.AllowMK {
-		return width, height
-	}
-	if mkDict == nil {
-		return width, height
-	}
-
-	// Extract rotation from the MK dictionary.
-	rotation, _ := core.GetNumberAsFloat(mkDict.Get("R"))
+	rotation := style.mkRotation(mkDict)
 	if rotation == 0 {
 		return width, height
 	}
@@ -1106,6 +3100,113 @@ func (style *AppearanceStyle) applyRotation(mkDict *core.PdfObjectDictionary,
 	return bbox.Width, bbox.Height
 }
 
+// rectSize returns the width and height of the annotation rectangle `rect`
+// in the coordinate space of the generated appearance. The AppearanceWidth
+// and AppearanceHeight of the style, if set, override the dimensions of the
+// rectangle. The dimensions are swapped if the appearance keeps an existing
+// matrix rotated by 90 or 270 degrees.
+func (style *AppearanceStyle) rectSize(rect *model.PdfRectangle) (float64, float64) {
+	width, height := rect.Width(), rect.Height()
+	if style.AppearanceWidth > 0 {
+		width = style.AppearanceWidth
+	}
+	if style.AppearanceHeight > 0 {
+		height = style.AppearanceHeight
+	}
+	if math.Mod(style.matrixRotation, 180) != 0 {
+		return height, width
+	}
+	return width, height
+}
+
+// beginTextContent begins the marked-content sequence enclosing the variable
+// text of a field appearance. The sequence is tagged with the MCID of the
+// style, if set.
+func (style *AppearanceStyle) beginTextContent(cc *contentstream.ContentCreator) {
+	if style.mcid == nil {
+		cc.Add_BMC("Tx")
+		return
+	}
+	props := core.MakeDict()
+	props.Set("MCID", core.MakeInteger(*style.mcid))
+	cc.Add_BDC("Tx", props)
+}
+
+// mkRotation returns the rotation specified by the MK dictionary, snapped
+// to the nearest multiple of 90 degrees. Returns 0 if the style does not
+// allow MK overrides or if `mkDict` is nil.
+func (style *AppearanceStyle) mkRotation(mkDict *core.PdfObjectDictionary) float64 {
+	if !style.AllowMK || mkDict == nil {
+		return 0
+	}
+
+	rotation, _ := core.GetNumberAsFloat(mkDict.Get("R"))
+	if math.Mod(rotation, 90) != 0 {
+		snapped := math.Round(rotation/90) * 90
+		common.Log.Debug("WARN: MK rotation %v is not a multiple of 90 - using %v", rotation, snapped)
+		rotation = snapped
+	}
+	return rotation
+}
+
+// getAppearanceMatrix returns the matrix of the existing normal appearance
+// of the widget annotation `wa`, along with its rotation in degrees, snapped
+// to the nearest multiple of 90 and normalized to the [0, 360) range.
+// If the normal appearance has multiple states, the matrix of the first
+// state is used. Returns a nil matrix if the appearance has no matrix.
+func getAppearanceMatrix(wa *model.PdfAnnotationWidget) (*core.PdfObjectArray, float64) {
+	apDict, ok := core.GetDict(wa.AP)
+	if !ok {
+		return nil, 0
+	}
+
+	obj := apDict.Get("N")
+	if states, ok := core.GetDict(obj); ok {
+		obj = nil
+		if keys := states.Keys(); len(keys) > 0 {
+			obj = states.Get(keys[0])
+		}
+	}
+	stream, ok := core.GetStream(obj)
+	if !ok {
+		return nil, 0
+	}
+	matrix, ok := core.GetArray(stream.Get("Matrix"))
+	if !ok {
+		return nil, 0
+	}
+	values, err := matrix.ToFloat64Array()
+	if err != nil || len(values) != 6 {
+		common.Log.Debug("ERROR: invalid appearance matrix: %v", matrix)
+		return nil, 0
+	}
+
+	rotation := math.Round(math.Atan2(values[1], values[0])*180/math.Pi/90) * 90
+	rotation = math.Mod(rotation+360, 360)
+	return matrix, rotation
+}
+
+// setAppearanceMatrix sets the matrix of the normal and down appearance
+// streams of the appearance dictionary `apDict` to `matrix`.
+func setAppearanceMatrix(apDict *core.PdfObjectDictionary, matrix *core.PdfObjectArray) {
+	setMatrix := func(obj core.PdfObject) {
+		if stream, ok := core.GetStream(obj); ok {
+			stream.Set("Matrix", matrix)
+		}
+	}
+
+	for _, key := range []core.PdfObjectName{"N", "D"} {
+		obj := apDict.Get(key)
+		if states, ok := core.GetDict(obj); ok {
+			for _, state := range states.Keys() {
+				setMatrix(states.Get(state))
+			}
+			continue
+		}
+		setMatrix(obj)
+	}
+}
+
 // processDA adds the operands found in the field default appearance stream to
 // the provided content stream creator. It also provides a fallback font, based
 // on the configuration of the AppearanceStyle, if no valid font is specified
@@ -1117,20 +3218,24 @@ func (style *AppearanceStyle) processDA(field *model.PdfField,
 	cc *contentstream.ContentCreator) (*AppearanceFont, bool, error) {
 	// Check for fallback fonts.
 	var fallbackFont *AppearanceFont
+	var fallbackSource AppearanceFontSource
 	var forceReplace bool
 	if style.Fonts != nil {
 		// Use global fallback, if one is specified.
 		if style.Fonts.Fallback != nil {
 			fallbackFont = style.Fonts.Fallback
+			fallbackSource = AppearanceFontSourceFallback
 		}
 
 		// Use field fallback, if one is specified.
 		if fieldFallbacks := style.Fonts.FieldFallbacks; fieldFallbacks != nil {
 			if fbFont, ok := fieldFallbacks[field.PartialName()]; ok {
 				fallbackFont = fbFont
+				fallbackSource = AppearanceFontSourceFieldFallback
 			} else if fullName, err := field.FullName(); err == nil {
 				if fbFont, ok := fieldFallbacks[fullName]; ok {
 					fallbackFont = fbFont
+					fallbackSource = AppearanceFontSourceFieldFallback
 				}
 			}
 		}
@@ -1160,8 +3265,10 @@ func (style *AppearanceStyle) processDA(field *model.PdfField,
 
 	var apFont *AppearanceFont
 	var apFontObj core.PdfObject
+	var source AppearanceFontSource
 	if forceReplace && fallbackFont != nil {
 		apFont = fallbackFont
+		source = fallbackSource
 	} else {
 		// Check if font name was found in the DA stream and search it in the resources.
 		if dr != nil && fontName != "" {
@@ -1169,6 +3276,7 @@ func (style *AppearanceStyle) processDA(field *model.PdfField,
 				if font, err := model.NewPdfFontFromPdfObject(obj); err == nil {
 					apFontObj = obj
 					apFont = &AppearanceFont{Name: fontName, Font: font, Size: fontSize}
+					source = AppearanceFontSourceDA
 				} else {
 					common.Log.Debug("ERROR: could not load appearance font: %v", err)
 				}
@@ -1178,6 +3286,7 @@ func (style *AppearanceStyle) processDA(field *model.PdfField,
 		// Use fallback font, if one was specified.
 		if apFont == nil && fallbackFont != nil {
 			apFont = fallbackFont
+			source = fallbackSource
 		}
 
 		// Use default fallback font (Helvetica).
@@ -1187,6 +3296,15 @@ func (style *AppearanceStyle) processDA(field *model.PdfField,
 				return nil, false, err
 			}
 			apFont = &AppearanceFont{Name: "Helv", Font: font, Size: fontSize}
+			source = AppearanceFontSourceDefault
+		}
+	}
+	if style.fontInfo != nil {
+		*style.fontInfo = AppearanceFontInfo{
+			Name:       apFont.Name,
+			Font:       apFont.Font,
+			Source:     source,
+			DAFontName: fontName,
 		}
 	}
 
@@ -1198,7 +3316,7 @@ func (style *AppearanceStyle) processDA(field *model.PdfField,
 	if dr != nil && !dr.HasFontByName(apFontName) {
 		dr.SetFontByName(apFontName, apFontObj)
 	}
-	if resources != nil && !resources.HasFontByName(apFontName) {
+	if resources != nil {
 		resources.SetFontByName(apFontName, apFontObj)
 	}
 
@@ -1207,6 +3325,7 @@ func (style *AppearanceStyle) processDA(field *model.PdfField,
 
 // WrapContentStream ensures that the entire content stream for a `page` is wrapped within q ... Q operands.
 // Ensures that following operands that are added are not affected by additional operands that are added.
+// The page contents are not modified if already wrapped.
 // Implements interface model.ContentStreamWrapper.
 func (fa FieldAppearance) WrapContentStream(page *model.PdfPage) error {
 	cstream, err := page.GetAllContentStreams()
@@ -1218,10 +3337,24 @@ func (fa FieldAppearance) WrapContentStream(page *model.PdfPage) error {
 	if err != nil {
 		return err
 	}
+
+	prefix, suffix := operands.WrapOperations()
+	if len(prefix) == 0 {
+		// Already wrapped or empty.
+		return nil
+	}
+
+	if fa.AppendContentStreams {
+		if err := page.PrependContentStreamByString(prefix.String()); err != nil {
+			return err
+		}
+		return page.AddContentStreamByString(suffix.String())
+	}
+
 	operands.WrapIfNeeded()
 
 	cstreams := []string{operands.String()}
-	return page.SetContentStreams(cstreams, defStreamEncoder())
+	return page.SetContentStreams(cstreams, fa.Style().streamEncoder())
 }
 
 // defStreamEncoder returns the default stream encoder. Typically FlateEncoder, although RawEncoder
@@ -1230,6 +3363,26 @@ func defStreamEncoder() core.StreamEncoder {
 	return core.NewFlateEncoder()
 }
 
+// setContentStream sets the contents of `cc` as the content stream of the
+// appearance `xform`, after passing the content stream operations to the
+// PostProcess function of the style, if set.
+func (style AppearanceStyle) setContentStream(xform *model.XObjectForm, cc *contentstream.ContentCreator) error {
+	if style.PostProcess != nil {
+		if err := style.PostProcess(cc.Operations()); err != nil {
+			return err
+		}
+	}
+	return xform.SetContentStream(cc.Bytes(), style.streamEncoder())
+}
+
+// streamEncoder returns the stream encoder used for the appearance streams
+// generated using `style`.
+func (style AppearanceStyle) streamEncoder() core.StreamEncoder {
+	return core.NewFlateEncoderWithOptions(&core.FlateEncoderOptions{
+		CompressionLevel: style.CompressionLevel,
+	})
+}
+
 // genFieldSignatureAppearance generates the appearance dictionary for a
 // signature appearance widget.
 func genFieldSignatureAppearance(fields []*SignatureLine, opts *SignatureFieldOpts) (*core.PdfObjectDictionary, error) {
@@ -1277,7 +3430,9 @@ func genFieldSignatureAppearance(fields []*SignatureLine, opts *SignatureFieldOp
 	}
 	spaceWidth := spaceMetrics.Wx
 
-	// Generate lines.
+	// Generate lines. The lines of the signature line texts are rendered
+	// as separate lines and, if the annotation rectangle is specified, the
+	// lines wider than the rectangle are wrapped at spaces.
 	var maxLineWidth float64
 	var lines []string
 
@@ -1286,28 +3441,27 @@ func genFieldSignatureAppearance(fields []*SignatureLine, opts *SignatureFieldOp
 			continue
 		}
 
-		line := field.Text
+		text := field.Text
 		if field.Desc != "" {
-			line = field.Desc + ": " + line
+			text = field.Desc + ": " + text
 		}
-		lines = append(lines, line)
-
-		var lineWidth float64
-		for _, r := range line {
-			metrics, has := font.GetRuneMetrics(r)
-			if !has {
-				continue
+		for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+			if opts.Rect != nil {
+				maxWidth := (opts.Rect[2] - opts.Rect[0]) * 1000 / fontSize
+				lines = append(lines, AppearanceStyle{}.wrapLine(font, fontSize, line, maxWidth, WrapModeWords)...)
+			} else {
+				lines = append(lines, line)
 			}
-
-			lineWidth += metrics.Wx
 		}
+	}
 
-		if lineWidth > maxLineWidth {
-			maxLineWidth = lineWidth
-		}
+	if len(lines) == 0 && opts.DrawPlaceholder {
+		return genSignaturePlaceholderAppearance(font, *fontName, fontSize, opts)
 	}
 
-	maxLineWidth = maxLineWidth * fontSize / 1000.0
+	for _, line := range lines {
+		maxLineWidth = math.Max(maxLineWidth, model.MeasureTextWidth(font, line, fontSize))
+	}
 	height := float64(len(lines)) * lineHeight
 
 	// Calculate annotation rectangle.
@@ -1353,6 +3507,20 @@ func genFieldSignatureAppearance(fields []*SignatureLine, opts *SignatureFieldOp
 		Add_B().
 		Add_Q()
 
+	// Draw background image.
+	resources := model.NewPdfPageResources()
+	if opts.BackgroundImage != nil {
+		// Use an image name distinct from the font name.
+		imgName := core.PdfObjectName("Im1")
+		for i := 2; imgName == *fontName; i++ {
+			imgName = core.PdfObjectName("Im" + strconv.Itoa(i))
+		}
+		if err := resources.SetXObjectImageByName(imgName, opts.BackgroundImage); err != nil {
+			return nil, err
+		}
+		drawSignatureImage(cc, imgName, opts, rect)
+	}
+
 	// Draw signature.
 	cc.Add_q()
 	cc.Translate(rect[0], rect[3]-lineHeight-offsetY)
@@ -1393,15 +3561,189 @@ func genFieldSignatureAppearance(fields []*SignatureLine, opts *SignatureFieldOp
 	cc.Add_Q()
 
 	// Create appearance dictionary.
-	resources := model.NewPdfPageResources()
 	resources.SetFontByName(*fontName, font.ToPdfObject())
 
 	xform := model.NewXObjectForm()
 	xform.Resources = resources
 	xform.BBox = core.MakeArrayFromFloats(rect)
-	xform.SetContentStream(cc.Bytes(), defStreamEncoder())
+	if err := opts.setContentStream(xform, cc); err != nil {
+		return nil, err
+	}
+
+	apDict := core.MakeDict()
+	apDict.Set("N", xform.ToPdfObject())
+	return apDict, nil
+}
+
+// genSignaturePlaceholderAppearance generates the appearance dictionary of
+// an unsigned signature widget, which shows a placeholder box labeled with
+// the placeholder text of `opts`, using the font `font` with resource name
+// `fontName`. If the options do not specify the rectangle of the widget, the
+// box is sized to fit the label.
+func genSignaturePlaceholderAppearance(font *model.PdfFont, fontName core.PdfObjectName, fontSize float64,
+	opts *SignatureFieldOpts) (*core.PdfObjectDictionary, error) {
+	text := opts.PlaceholderText
+	if text == "" {
+		text = "Sign here"
+	}
+	lineWidth := opts.PlaceholderBorderSize
+	if lineWidth <= 0 {
+		lineWidth = 1
+	}
+	borderColor := opts.PlaceholderBorderColor
+	if borderColor == nil {
+		borderColor = model.NewPdfColorDeviceGray(0.5)
+	}
+	textColor := opts.TextColor
+	if textColor == nil {
+		textColor = borderColor
+	}
+
+	// Calculate annotation rectangle.
+	padding := fontSize/2 + lineWidth
+	textWidth := model.MeasureTextWidth(font, text, fontSize)
+	rect := opts.Rect
+	if rect == nil {
+		rect = []float64{0, 0, textWidth + 2*padding, fontSize + 2*padding}
+		opts.Rect = rect
+	}
+	rectWidth := rect[2] - rect[0]
+	rectHeight := rect[3] - rect[1]
+
+	// Fit the label.
+	if opts.AutoSize && textWidth > 0 {
+		if available := rectWidth - 2*padding; textWidth > available && available > 0 {
+			scale := available / textWidth
+			fontSize *= scale
+			textWidth *= scale
+		}
+		fontSize = math.Min(fontSize, math.Max(rectHeight-2*lineWidth, 0))
+	}
+
+	cc := contentstream.NewContentCreator()
+	if opts.FillColor != nil {
+		cc.Add_q().
+			Add_re(rect[0], rect[1], rectWidth, rectHeight).
+			SetNonStrokingColor(opts.FillColor).
+			Add_f().
+			Add_Q()
+	}
+
+	// Draw placeholder box, inset by half the line width.
+	inset := lineWidth / 2
+	llx, lly := rect[0]+inset, rect[1]+inset
+	urx, ury := rect[2]-inset, rect[3]-inset
+	cc.Add_q().
+		Add_w(lineWidth).
+		SetStrokingColor(borderColor)
+	if len(opts.PlaceholderBorderDash) > 0 {
+		cc.Add_d(opts.PlaceholderBorderDash, 0)
+	}
+	cc.Add_re(llx, lly, math.Max(urx-llx, 0), math.Max(ury-lly, 0)).
+		Add_S()
+	if opts.PlaceholderDiagonal {
+		cc.Add_m(llx, lly).
+			Add_l(urx, ury).
+			Add_S()
+	}
+	cc.Add_Q()
+
+	// Draw the label, centered in the box.
+	encoder := font.Encoder()
+	if encoder == nil {
+		common.Log.Debug("WARN: font encoder is nil. Assuming identity encoder. Output may be incorrect.")
+		encoder = textencoding.NewIdentityTextEncoder("Identity-H")
+	}
+	capHeight := fontSize
+	if metrics, err := font.GetFontMetrics(); err == nil && metrics.CapHeight > 0 {
+		capHeight = metrics.CapHeight / 1000 * fontSize
+	}
+	fd, _ := font.GetFontDescriptor()
+	ty := centeredBaseline(fd, fontSize, capHeight, rect[1], rect[3])
+
+	cc.Add_q().
+		Add_BT().
+		SetNonStrokingColor(textColor).
+		Add_Tf(fontName, fontSize).
+		Add_Td(rect[0]+(rectWidth-textWidth)/2, ty).
+		Add_Tj(*core.MakeStringFromBytes(encoder.Encode(text))).
+		Add_ET().
+		Add_Q()
+
+	// Create appearance dictionary.
+	resources := model.NewPdfPageResources()
+	resources.SetFontByName(fontName, font.ToPdfObject())
+
+	xform := model.NewXObjectForm()
+	xform.Resources = resources
+	xform.BBox = core.MakeArrayFromFloats(rect)
+	if err := opts.setContentStream(xform, cc); err != nil {
+		return nil, err
+	}
 
 	apDict := core.MakeDict()
 	apDict.Set("N", xform.ToPdfObject())
 	return apDict, nil
 }
+
+// setContentStream sets the contents of `cc` as the content stream of the
+// signature appearance `xform`, after passing the content stream operations
+// to the PostProcess function of the options, if set.
+func (opts *SignatureFieldOpts) setContentStream(xform *model.XObjectForm, cc *contentstream.ContentCreator) error {
+	if opts.PostProcess != nil {
+		if err := opts.PostProcess(cc.Operations()); err != nil {
+			return err
+		}
+	}
+	return xform.SetContentStream(cc.Bytes(), defStreamEncoder())
+}
+
+// drawSignatureImage draws the background image `imgName` of the signature
+// appearance in the rectangle `rect`, according to the placement options of
+// `opts`. The image is clipped to the rectangle.
+func drawSignatureImage(cc *contentstream.ContentCreator, imgName core.PdfObjectName,
+	opts *SignatureFieldOpts, rect []float64) {
+	img := opts.BackgroundImage
+	if img.Width == nil || img.Height == nil {
+		common.Log.Debug("ERROR: background image dimensions not specified")
+		return
+	}
+	imgWidth, imgHeight := float64(*img.Width), float64(*img.Height)
+	rectWidth, rectHeight := rect[2]-rect[0], rect[3]-rect[1]
+	if imgWidth <= 0 || imgHeight <= 0 || rectWidth <= 0 || rectHeight <= 0 {
+		return
+	}
+
+	scale := opts.BackgroundImageScale
+	if scale <= 0 {
+		scale = 1
+	}
+	if opts.BackgroundImagePlacement == SignatureImageFit {
+		scale *= math.Min(rectWidth/imgWidth, rectHeight/imgHeight)
+	}
+	width, height := imgWidth*scale, imgHeight*scale
+
+	drawImage := func(x, y float64) {
+		cc.Add_q().
+			Add_cm(width, 0, 0, height, x, y).
+			Add_Do(imgName).
+			Add_Q()
+	}
+
+	cc.Add_q().
+		Add_re(rect[0], rect[1], rectWidth, rectHeight).
+		Add_W().
+		Add_n()
+
+	if opts.BackgroundImagePlacement == SignatureImageTile {
+		for y := rect[1]; y < rect[3]; y += height {
+			for x := rect[0]; x < rect[2]; x += width {
+				drawImage(x, y)
+			}
+		}
+	} else {
+		drawImage(rect[0]+(rectWidth-width)/2, rect[1]+(rectHeight-height)/2)
+	}
+
+	cc.Add_Q()
+}
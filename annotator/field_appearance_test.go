@@ -0,0 +1,2082 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	goimage "image"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// newTestTextField returns a new form containing a single text field with
+// the specified value, placed at `rect` on a blank page.
+func newTestTextField(t *testing.T, value string, rect []float64) (*model.PdfAcroForm, *model.PdfFieldText) {
+	page := model.NewPdfPage()
+	field, err := NewTextField(page, "text1", rect, TextFieldOptions{Value: value})
+	require.NoError(t, err)
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{field.PdfField}
+	return form, field
+}
+
+// getAppearanceOps parses the content stream of the normal appearance of
+// the specified appearance dictionary.
+func getAppearanceOps(t *testing.T, apDict *core.PdfObjectDictionary) (*model.XObjectForm, *contentstream.ContentStreamOperations) {
+	require.NotNil(t, apDict)
+	stream, ok := core.GetStream(apDict.Get("N"))
+	require.True(t, ok)
+
+	xform, err := model.NewXObjectFormFromStream(stream)
+	require.NoError(t, err)
+	content, err := xform.GetContentStream()
+	require.NoError(t, err)
+
+	ops, err := contentstream.NewContentStreamParser(string(content)).Parse()
+	require.NoError(t, err)
+	return xform, ops
+}
+
+// findOps returns the content stream operations with the specified operand.
+func findOps(ops *contentstream.ContentStreamOperations, operand string) []*contentstream.ContentStreamOperation {
+	var found []*contentstream.ContentStreamOperation
+	for _, op := range *ops {
+		if op.Operand == operand {
+			found = append(found, op)
+		}
+	}
+	return found
+}
+
+func TestFieldAppearanceRotationSnap(t *testing.T) {
+	form, field := newTestTextField(t, "Rotated", []float64{0, 0, 100, 20})
+
+	mk := core.MakeDict()
+	mk.Set("R", core.MakeInteger(45))
+	widget := field.Annotations[0]
+	widget.MK = mk
+
+	fa := FieldAppearance{}
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, widget)
+	require.NoError(t, err)
+
+	// The BBox must match the annotation rectangle.
+	xform, ops := getAppearanceOps(t, apDict)
+	bbox, ok := core.GetArray(xform.BBox)
+	require.True(t, ok)
+	vals, err := bbox.ToFloat64Array()
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 0, 100, 20}, vals)
+
+	// The rotation of 45 degrees must be snapped to 90 degrees.
+	cms := findOps(ops, "cm")
+	require.NotEmpty(t, cms)
+	params, err := core.GetNumbersAsFloat(cms[0].Params)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{0, 1, -1, 0, 0, 0}, params, 1e-9)
+}
+
+func TestFieldAppearanceMatrixRotation(t *testing.T) {
+	// newRotatedField returns a field with an existing appearance, which is
+	// rotated by 90 degrees using the appearance matrix.
+	newRotatedField := func() (*model.PdfAcroForm, *model.PdfFieldText) {
+		form, field := newTestTextField(t, "Rotated", []float64{0, 0, 100, 20})
+
+		xform := model.NewXObjectForm()
+		xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, 20, 100})
+		xform.Matrix = core.MakeArrayFromFloats([]float64{0, 1, -1, 0, 20, 0})
+		require.NoError(t, xform.SetContentStream([]byte("BT /Helv 12 Tf (Old) Tj ET"), nil))
+
+		apDict := core.MakeDict()
+		apDict.Set("N", xform.ToPdfObject())
+		field.Annotations[0].AP = apDict
+		return form, field
+	}
+
+	getBBox := func(xform *model.XObjectForm) []float64 {
+		bbox, ok := core.GetArray(xform.BBox)
+		require.True(t, ok)
+		vals, err := bbox.ToFloat64Array()
+		require.NoError(t, err)
+		return vals
+	}
+
+	t.Run("Matrix", func(t *testing.T) {
+		form, field := newRotatedField()
+		fa := FieldAppearance{}
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+
+		// The matrix is preserved and the contents are laid out in its
+		// coordinate space, with no additional rotation.
+		xform, ops := getAppearanceOps(t, apDict)
+		matrix, ok := core.GetArray(xform.Matrix)
+		require.True(t, ok)
+		vals, err := matrix.ToFloat64Array()
+		require.NoError(t, err)
+		require.Equal(t, []float64{0, 1, -1, 0, 20, 0}, vals)
+		require.Equal(t, []float64{0, 0, 20, 100}, getBBox(xform))
+		require.Empty(t, findOps(ops, "cm"))
+	})
+
+	t.Run("MKPrecedence", func(t *testing.T) {
+		form, field := newRotatedField()
+		mk := core.MakeDict()
+		mk.Set("R", core.MakeInteger(90))
+		field.Annotations[0].MK = mk
+
+		fa := FieldAppearance{}
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+
+		// The MK rotation is applied to the contents and the existing
+		// matrix is dropped.
+		xform, ops := getAppearanceOps(t, apDict)
+		require.Nil(t, xform.Matrix)
+		require.Equal(t, []float64{0, 0, 100, 20}, getBBox(xform))
+		require.NotEmpty(t, findOps(ops, "cm"))
+	})
+}
+
+func TestRegenerateForm(t *testing.T) {
+	page := model.NewPdfPage()
+	good, err := NewTextField(page, "good", []float64{0, 0, 100, 20}, TextFieldOptions{Value: "Good"})
+	require.NoError(t, err)
+	bad, err := NewTextField(page, "bad", []float64{0, 0, 100, 20}, TextFieldOptions{Value: "Bad"})
+	require.NoError(t, err)
+	bad.Annotations[0].Rect = core.MakeNull()
+	other, err := NewTextField(page, "other", []float64{0, 0, 100, 20}, TextFieldOptions{Value: "Other"})
+	require.NoError(t, err)
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{good.PdfField, bad.PdfField, other.PdfField}
+
+	// The error of the bad field does not prevent the other fields from
+	// being regenerated.
+	err = FieldAppearance{}.RegenerateForm(form)
+	require.Error(t, err)
+	errs, ok := err.(FieldAppearanceErrors)
+	require.True(t, ok)
+	require.Len(t, errs, 1)
+	require.Equal(t, bad.PdfField, errs[0].Field)
+	require.Contains(t, err.Error(), `field "bad"`)
+
+	require.Nil(t, bad.Annotations[0].AP)
+	for _, field := range []*model.PdfFieldText{good, other} {
+		_, ok := core.GetDict(field.Annotations[0].AP)
+		require.True(t, ok)
+	}
+}
+
+func TestRegenerateFormSignature(t *testing.T) {
+	opts := NewSignatureFieldOpts()
+	opts.Rect = []float64{0, 0, 200, 50}
+	field, err := NewSignatureField(model.NewPdfSignature(nil),
+		[]*SignatureLine{NewSignatureLine("Name", "John Doe")}, opts)
+	require.NoError(t, err)
+	field.T = core.MakeString("signature")
+	field.Annotations = append(field.Annotations, field.PdfAnnotationWidget)
+	signatureAP := field.AP
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{field.PdfField}
+
+	// No appearance is generated for signature fields, so the appearance
+	// of the signed field is kept.
+	require.NoError(t, FieldAppearance{}.RegenerateForm(form))
+	require.NotNil(t, signatureAP)
+	require.Same(t, signatureAP, field.AP)
+}
+
+func TestFieldAppearanceSkipFunc(t *testing.T) {
+	page := model.NewPdfPage()
+	internal, err := NewTextField(page, "internal.id", []float64{0, 0, 100, 20}, TextFieldOptions{Value: "1234"})
+	require.NoError(t, err)
+	public, err := NewTextField(page, "name", []float64{0, 0, 100, 20}, TextFieldOptions{Value: "Name"})
+	require.NoError(t, err)
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{internal.PdfField, public.PdfField}
+	internalAP := core.MakeDict()
+	internal.Annotations[0].AP = internalAP
+
+	fa := FieldAppearance{SkipFunc: func(field *model.PdfField) bool {
+		return strings.HasPrefix(field.PartialName(), "internal.")
+	}}
+	apDict, err := fa.GenerateAppearanceDict(form, internal.PdfField, internal.Annotations[0])
+	require.NoError(t, err)
+	require.Nil(t, apDict)
+	apDict, err = fa.GenerateAppearanceDict(form, public.PdfField, public.Annotations[0])
+	require.NoError(t, err)
+	require.NotNil(t, apDict)
+
+	// The widgets of the skipped fields are left unchanged.
+	require.NoError(t, fa.RegenerateForm(form))
+	require.Same(t, internalAP, internal.Annotations[0].AP)
+	_, ok := core.GetDict(public.Annotations[0].AP)
+	require.True(t, ok)
+}
+
+func TestRegenerateFormSubsetFonts(t *testing.T) {
+	font, err := model.NewCompositePdfFontFromTTFFile("../model/testdata/font/OpenSans-Regular.ttf")
+	require.NoError(t, err)
+
+	// getFontFileSize returns the size of the embedded font file.
+	getFontFileSize := func() int {
+		descriptor, err := font.GetFontDescriptor()
+		require.NoError(t, err)
+		stream, ok := core.GetStream(descriptor.FontFile2)
+		require.True(t, ok)
+		data, err := core.DecodeStream(stream)
+		require.NoError(t, err)
+		return len(data)
+	}
+	fullSize := getFontFileSize()
+
+	page := model.NewPdfPage()
+	form := model.NewPdfAcroForm()
+	var fields []*model.PdfField
+	for i, value := range []string{"abc", "cab"} {
+		field, err := NewTextField(page, fmt.Sprintf("text%d", i), []float64{0, 0, 100, 20}, TextFieldOptions{Value: value})
+		require.NoError(t, err)
+		fields = append(fields, field.PdfField)
+	}
+	form.Fields = &fields
+
+	fa := FieldAppearance{}
+	style := fa.Style()
+	style.Fonts = &AppearanceFontStyle{
+		Fallback:    &AppearanceFont{Name: "OpenSans", Font: font, Size: 10},
+		SubsetFonts: true,
+	}
+	fa.SetStyle(style)
+	require.NoError(t, fa.RegenerateForm(form))
+
+	// The font only contains the glyphs used by the fields.
+	require.Less(t, getFontFileSize(), fullSize/2)
+	dict, ok := core.GetDict(font.ToPdfObject())
+	require.True(t, ok)
+	baseFont, ok := core.GetName(dict.Get("BaseFont"))
+	require.True(t, ok)
+	require.Contains(t, baseFont.String(), "+")
+}
+
+func TestNewAppearanceFontFromFile(t *testing.T) {
+	apFont, err := NewAppearanceFontFromFile("OpenSans", "../model/testdata/font/OpenSans-Regular.ttf", 12)
+	require.NoError(t, err)
+	require.Equal(t, "OpenSans", apFont.Name)
+	require.Equal(t, 12.0, apFont.Size)
+	require.Equal(t, "Type0:CIDFontType2", apFont.Font.Subtype())
+
+	// The font is used for the fields with no DA font.
+	form, field := newTestTextField(t, "Value", []float64{0, 0, 100, 20})
+	fa := FieldAppearance{}
+	style := fa.Style()
+	style.Fonts = &AppearanceFontStyle{Fallback: apFont}
+	fa.SetStyle(style)
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+	tfs := findOps(ops, "Tf")
+	require.Len(t, tfs, 1)
+	name, ok := core.GetNameVal(tfs[0].Params[0])
+	require.True(t, ok)
+	require.Equal(t, "OpenSans", name)
+	size, err := core.GetNumberAsFloat(tfs[0].Params[1])
+	require.NoError(t, err)
+	require.Equal(t, 12.0, size)
+
+	_, err = NewAppearanceFontFromFile("", "../model/testdata/font/OpenSans-Regular.ttf", 0)
+	require.Error(t, err)
+	_, err = NewAppearanceFontFromFile("Missing", "missing.ttf", 0)
+	require.Error(t, err)
+}
+
+func TestGenerateAppearanceDictWithFontInfo(t *testing.T) {
+	courier, err := model.NewStandard14Font(model.CourierName)
+	require.NoError(t, err)
+	times, err := model.NewStandard14Font(model.TimesRomanName)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		drFont   bool
+		fonts    *AppearanceFontStyle
+		expected AppearanceFontSource
+		fontName string
+	}{
+		{name: "Default", expected: AppearanceFontSourceDefault, fontName: "Helv"},
+		{name: "DA", drFont: true, expected: AppearanceFontSourceDA, fontName: "Cour"},
+		{
+			name:     "Fallback",
+			fonts:    &AppearanceFontStyle{Fallback: &AppearanceFont{Name: "Times", Font: times}},
+			expected: AppearanceFontSourceFallback,
+			fontName: "Times",
+		},
+		{
+			name: "FieldFallback",
+			fonts: &AppearanceFontStyle{
+				Fallback:       &AppearanceFont{Name: "Times", Font: times},
+				FieldFallbacks: map[string]*AppearanceFont{"text1": {Name: "Courier", Font: courier}},
+			},
+			expected: AppearanceFontSourceFieldFallback,
+			fontName: "Courier",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			form, field := newTestTextField(t, "Text", []float64{0, 0, 100, 20})
+			field.DA = core.MakeString("/Cour 10 Tf 0 g")
+			if tc.drFont {
+				form.DR = model.NewPdfPageResources()
+				form.DR.SetFontByName("Cour", courier.ToPdfObject())
+			}
+
+			fa := FieldAppearance{}
+			style := fa.Style()
+			style.Fonts = tc.fonts
+			fa.SetStyle(style)
+
+			apDict, info, err := fa.GenerateAppearanceDictWithFontInfo(form, field.PdfField, field.Annotations[0])
+			require.NoError(t, err)
+			require.NotNil(t, apDict)
+			require.NotNil(t, info)
+			require.Equal(t, tc.expected, info.Source)
+			require.Equal(t, tc.fontName, info.Name)
+			require.Equal(t, "Cour", info.DAFontName)
+		})
+	}
+
+	// Check boxes do not use the fonts chosen based on the DA.
+	form := model.NewPdfAcroForm()
+	checkbox, err := NewCheckboxField(model.NewPdfPage(), "check1", []float64{0, 0, 20, 20}, CheckboxFieldOptions{Checked: true})
+	require.NoError(t, err)
+	apDict, info, err := FieldAppearance{}.GenerateAppearanceDictWithFontInfo(form, checkbox.PdfField, checkbox.Annotations[0])
+	require.NoError(t, err)
+	require.NotNil(t, apDict)
+	require.Nil(t, info)
+}
+
+func TestFieldAppearanceRegeneratePreservesResources(t *testing.T) {
+	form, field := newTestTextField(t, "Regenerated", []float64{0, 0, 100, 20})
+	field.DA = core.MakeString("/Helv 0 Tf 0 g")
+
+	// Set an existing appearance which uses a font not referenced by the DA.
+	courier, err := model.NewStandard14Font(model.CourierName)
+	require.NoError(t, err)
+
+	xform := model.NewXObjectForm()
+	xform.Resources = model.NewPdfPageResources()
+	xform.Resources.SetFontByName("F9", courier.ToPdfObject())
+	xform.BBox = core.MakeArrayFromFloats([]float64{0, 0, 100, 20})
+	require.NoError(t, xform.SetContentStream([]byte("BT /F9 12 Tf (Old) Tj ET"), nil))
+
+	widget := field.Annotations[0]
+	apDict := core.MakeDict()
+	apDict.Set("N", xform.ToPdfObject())
+	widget.AP = apDict
+
+	fa := FieldAppearance{OnlyIfMissing: true, RegenerateTextFields: true}
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, widget)
+	require.NoError(t, err)
+
+	regenerated, _ := getAppearanceOps(t, apDict)
+	require.NotNil(t, regenerated.Resources)
+	require.True(t, regenerated.Resources.HasFontByName("F9"))
+	require.True(t, regenerated.Resources.HasFontByName("Helv"))
+
+	// The original appearance resources must not be modified.
+	require.False(t, xform.Resources.HasFontByName("Helv"))
+}
+
+func TestGenerateFieldAppearancesMultipleWidgets(t *testing.T) {
+	form, field := newTestTextField(t, "Multiple widgets", []float64{0, 0, 100, 20})
+
+	// Add a second widget with a different rectangle (e.g. on another page).
+	widget := model.NewPdfAnnotationWidget()
+	widget.Rect = core.MakeArrayFromFloats([]float64{50, 50, 250, 80})
+	widget.Parent = field.ToPdfObject()
+	field.Annotations = append(field.Annotations, widget)
+
+	fa := FieldAppearance{}
+	require.NoError(t, fa.GenerateFieldAppearances(form, field.PdfField))
+
+	expected := [][]float64{{0, 0, 100, 20}, {0, 0, 200, 30}}
+	for i, wa := range field.Annotations {
+		apDict, ok := core.GetDict(wa.AP)
+		require.True(t, ok)
+
+		xform, _ := getAppearanceOps(t, apDict)
+		bbox, ok := core.GetArray(xform.BBox)
+		require.True(t, ok)
+		vals, err := bbox.ToFloat64Array()
+		require.NoError(t, err)
+		require.Equal(t, expected[i], vals)
+	}
+}
+
+func TestGenerateFieldAppearancesKeepsAppearance(t *testing.T) {
+	opts := NewSignatureFieldOpts()
+	opts.Rect = []float64{0, 0, 200, 50}
+	field, err := NewSignatureField(model.NewPdfSignature(nil),
+		[]*SignatureLine{NewSignatureLine("Name", "John Doe")}, opts)
+	require.NoError(t, err)
+	field.Annotations = append(field.Annotations, field.PdfAnnotationWidget)
+	signatureAP := field.AP
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{field.PdfField}
+
+	require.NoError(t, FieldAppearance{}.GenerateFieldAppearances(form, field.PdfField))
+	require.NotNil(t, signatureAP)
+	require.Same(t, signatureAP, field.AP)
+}
+
+func TestCheckboxVectorCheckmark(t *testing.T) {
+	page := model.NewPdfPage()
+	field, err := NewCheckboxField(page, "check1", []float64{0, 0, 20, 20}, CheckboxFieldOptions{Checked: true})
+	require.NoError(t, err)
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{field.PdfField}
+
+	for _, checkStyle := range []CheckmarkStyle{
+		CheckmarkStyleVectorCheck, CheckmarkStyleVectorCross, CheckmarkStyleVectorCircle,
+		CheckmarkStyleVectorSquare, CheckmarkStyleVectorDiamond, CheckmarkStyleVectorStar,
+	} {
+		fa := FieldAppearance{}
+		style := fa.Style()
+		style.CheckmarkStyle = checkStyle
+		fa.SetStyle(style)
+
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+
+		nDict, ok := core.GetDict(apDict.Get("N"))
+		require.True(t, ok)
+		onDict := core.MakeDict()
+		onDict.Set("N", nDict.Get("Yes"))
+
+		// The check mark must be drawn as a path, without using a font.
+		xform, ops := getAppearanceOps(t, onDict)
+		require.Empty(t, findOps(ops, "Tj"))
+		switch checkStyle {
+		case CheckmarkStyleVectorCheck, CheckmarkStyleVectorCross:
+			require.NotEmpty(t, findOps(ops, "S"))
+		default:
+			require.NotEmpty(t, findOps(ops, "f"))
+			require.Empty(t, findOps(ops, "S"))
+		}
+		require.True(t, xform.Resources == nil || !xform.Resources.HasFontByName("ZaDb"))
+	}
+}
+
+func TestCheckboxDrawEmptyBox(t *testing.T) {
+	field, err := NewCheckboxField(model.NewPdfPage(), "check1", []float64{0, 0, 20, 20}, CheckboxFieldOptions{Checked: true})
+	require.NoError(t, err)
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{field.PdfField}
+
+	// getStateOps returns the operations of the appearance of `state`.
+	getStateOps := func(style AppearanceStyle, state string) *contentstream.ContentStreamOperations {
+		fa := FieldAppearance{}
+		fa.SetStyle(style)
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+
+		nDict, ok := core.GetDict(apDict.Get("N"))
+		require.True(t, ok)
+		stateDict := core.MakeDict()
+		stateDict.Set("N", nDict.Get(core.PdfObjectName(state)))
+		_, ops := getAppearanceOps(t, stateDict)
+		return ops
+	}
+
+	style := FieldAppearance{}.Style()
+	style.BorderSize = 0
+	require.Empty(t, findOps(getStateOps(style, "Off"), "re"))
+
+	// The box is drawn in the Off state, even without a border.
+	style.CheckboxDrawEmptyBox = true
+	style.CheckboxBoxLineWidth = 2
+	style.CheckboxBoxColor = model.NewPdfColorDeviceRGB(0, 0, 1)
+	ops := getStateOps(style, "Off")
+	res := findOps(ops, "re")
+	require.Len(t, res, 1)
+	vals, err := core.GetNumbersAsFloat(res[0].Params)
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 1, 18, 18}, vals)
+	require.Len(t, findOps(ops, "S"), 1)
+	ws := findOps(ops, "w")
+	require.Len(t, ws, 1)
+	w, err := core.GetNumberAsFloat(ws[0].Params[0])
+	require.NoError(t, err)
+	require.Equal(t, 2.0, w)
+	rgs := findOps(ops, "RG")
+	require.Len(t, rgs, 1)
+	vals, err = core.GetNumbersAsFloat(rgs[0].Params)
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 0, 1}, vals)
+
+	// The box is drawn behind the check mark only if enabled.
+	require.Empty(t, findOps(getStateOps(style, "Yes"), "re"))
+	style.CheckboxBoxBehindCheck = true
+	ops = getStateOps(style, "Yes")
+	require.Len(t, findOps(ops, "re"), 1)
+	require.Len(t, findOps(ops, "Tj"), 1)
+}
+
+func TestWrapContentStream(t *testing.T) {
+	page := model.NewPdfPage()
+	require.NoError(t, page.SetContentStreams([]string{"0 g 0 0 10 10 re f"}, nil))
+
+	// Append the wrapping operators as separate content streams.
+	fa := FieldAppearance{AppendContentStreams: true}
+	require.NoError(t, fa.WrapContentStream(page))
+	cstreams, err := page.GetContentStreams()
+	require.NoError(t, err)
+	require.Len(t, cstreams, 3)
+	require.Equal(t, "0 g 0 0 10 10 re f", cstreams[1])
+
+	// Already wrapped contents must not be modified.
+	contents := page.Contents
+	fa = FieldAppearance{}
+	require.NoError(t, fa.WrapContentStream(page))
+	require.Equal(t, contents, page.Contents)
+
+	// Collapse into a single stream.
+	page = model.NewPdfPage()
+	require.NoError(t, page.SetContentStreams([]string{"0 g", "0 0 10 10 re f"}, nil))
+	require.NoError(t, fa.WrapContentStream(page))
+	cstreams, err = page.GetContentStreams()
+	require.NoError(t, err)
+	require.Len(t, cstreams, 1)
+
+	ops, err := contentstream.NewContentStreamParser(cstreams[0]).Parse()
+	require.NoError(t, err)
+	require.True(t, ops.IsWrapped())
+}
+
+func TestTextFieldKerning(t *testing.T) {
+	form, field := newTestTextField(t, "AVATAR", []float64{0, 0, 200, 20})
+	field.DA = core.MakeString("/Helv 12 Tf 0 g")
+	field.Q = core.MakeInteger(2)
+
+	fa := FieldAppearance{}
+	style := fa.Style()
+	widget := field.Annotations[0]
+
+	// Kerning disabled.
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, widget)
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+	require.Len(t, findOps(ops, "Tj"), 1)
+	require.Empty(t, findOps(ops, "TJ"))
+	unkernedTd := findOps(ops, "Td")
+
+	// Kerning enabled.
+	style.Kerning = true
+	fa.SetStyle(style)
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, widget)
+	require.NoError(t, err)
+	_, ops = getAppearanceOps(t, apDict)
+	require.Empty(t, findOps(ops, "Tj"))
+	tjs := findOps(ops, "TJ")
+	require.Len(t, tjs, 1)
+	require.Len(t, tjs[0].Params, 1)
+	arr, ok := core.GetArray(tjs[0].Params[0])
+	require.True(t, ok)
+
+	var text string
+	var adjustments []float64
+	for _, param := range arr.Elements() {
+		if str, ok := core.GetString(param); ok {
+			text += str.Str()
+			continue
+		}
+		val, err := core.GetNumberAsFloat(param)
+		require.NoError(t, err)
+		adjustments = append(adjustments, val)
+	}
+	require.Equal(t, "AVATAR", text)
+	// Helvetica kerning pairs: AV -70, VA -80, AT -120, TA -120.
+	require.Equal(t, []float64{70, 80, 120, 120}, adjustments)
+
+	// The kerned text is narrower, so it is moved to the right when right
+	// aligned.
+	kernedTd := findOps(ops, "Td")
+	require.Len(t, kernedTd, len(unkernedTd))
+	unkernedX, err := core.GetNumberAsFloat(unkernedTd[1].Params[0])
+	require.NoError(t, err)
+	kernedX, err := core.GetNumberAsFloat(kernedTd[1].Params[0])
+	require.NoError(t, err)
+	require.InDelta(t, 390*12/1000.0, kernedX-unkernedX, 1e-9)
+}
+
+func TestFieldAppearancePreview(t *testing.T) {
+	_, field := newTestTextField(t, "Preview", []float64{50, 50, 150, 70})
+	field.DA = core.MakeString("/Helv 0 Tf 0 g")
+	widget := field.Annotations[0]
+	dr := model.NewPdfPageResources()
+
+	fa := FieldAppearance{}
+	w, err := NewFieldAppearancePreview(field.PdfField, widget, dr, fa.Style())
+	require.NoError(t, err)
+
+	// The field, the widget and the resources are not modified.
+	require.Nil(t, widget.AP)
+	require.False(t, dr.HasFontByName("Helv"))
+
+	var buf bytes.Buffer
+	require.NoError(t, w.Write(&buf))
+	reader, err := model.NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	page, err := reader.GetPage(1)
+	require.NoError(t, err)
+	require.Equal(t, model.PdfRectangle{Urx: 100, Ury: 20}, *page.MediaBox)
+
+	xform, err := page.Resources.GetXObjectFormByName("Appearance")
+	require.NoError(t, err)
+	require.NotNil(t, xform)
+	require.True(t, xform.Resources.HasFontByName("Helv"))
+	content, err := xform.GetContentStream()
+	require.NoError(t, err)
+	require.Contains(t, string(content), "(Preview) Tj")
+
+	// The current state of checkboxes is displayed.
+	page = model.NewPdfPage()
+	checkbox, err := NewCheckboxField(page, "check1", []float64{0, 0, 20, 20}, CheckboxFieldOptions{Checked: true})
+	require.NoError(t, err)
+	w, err = NewFieldAppearancePreview(checkbox.PdfField, checkbox.Annotations[0], nil, fa.Style())
+	require.NoError(t, err)
+
+	buf.Reset()
+	require.NoError(t, w.Write(&buf))
+	reader, err = model.NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	page, err = reader.GetPage(1)
+	require.NoError(t, err)
+	xform, err = page.Resources.GetXObjectFormByName("Appearance")
+	require.NoError(t, err)
+	content, err = xform.GetContentStream()
+	require.NoError(t, err)
+	require.Contains(t, string(content), "Tj")
+}
+
+func TestListboxAppearance(t *testing.T) {
+	field := model.NewPdfField()
+	fch := &model.PdfFieldChoice{PdfField: field}
+	field.SetContext(fch)
+	fch.T = core.MakeString("list1")
+	fch.Opt = core.MakeArray()
+	for _, opt := range []string{"a", "b", "c", "d", "e"} {
+		fch.Opt.Append(core.MakeString(opt))
+	}
+	fch.Opt.Append(core.MakeArray(core.MakeString("export"), core.MakeString("f")))
+	fch.SetFlag(model.FieldFlagMultiSelect)
+
+	widget := model.NewPdfAnnotationWidget()
+	widget.Rect = core.MakeArrayFromFloats([]float64{0, 0, 100, 40})
+	widget.Parent = fch.ToPdfObject()
+	fch.Annotations = append(fch.Annotations, widget)
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{field}
+
+	// getTexts returns the drawn options and the number of highlighted rows.
+	getTexts := func(apDict *core.PdfObjectDictionary) ([]string, int) {
+		_, ops := getAppearanceOps(t, apDict)
+		var texts []string
+		for _, op := range findOps(ops, "Tj") {
+			str, ok := core.GetString(op.Params[0])
+			require.True(t, ok)
+			texts = append(texts, str.Str())
+		}
+		return texts, len(findOps(ops, "f"))
+	}
+
+	// Scroll to the selected option, which is out of view.
+	fch.V = core.MakeString("d")
+	fa := FieldAppearance{}
+	apDict, err := fa.GenerateAppearanceDict(form, field, widget)
+	require.NoError(t, err)
+	texts, highlighted := getTexts(apDict)
+	require.Equal(t, []string{"c", "d"}, texts)
+	require.Equal(t, 1, highlighted)
+
+	// Highlight all the selected options, starting from the top index.
+	fch.V = core.MakeArray(core.MakeString("b"), core.MakeString("c"))
+	fch.TI = core.MakeInteger(1)
+	apDict, err = fa.GenerateAppearanceDict(form, field, widget)
+	require.NoError(t, err)
+	texts, highlighted = getTexts(apDict)
+	require.Equal(t, []string{"b", "c"}, texts)
+	require.Equal(t, 2, highlighted)
+
+	// Options with export values.
+	fch.V = core.MakeString("export")
+	fch.TI = nil
+	style := fa.Style()
+	style.ListboxSelectionColor = model.NewPdfColorDeviceGray(0.5)
+	fa.SetStyle(style)
+	apDict, err = fa.GenerateAppearanceDict(form, field, widget)
+	require.NoError(t, err)
+	texts, highlighted = getTexts(apDict)
+	require.Equal(t, []string{"e", "f"}, texts)
+	require.Equal(t, 1, highlighted)
+	_, ops := getAppearanceOps(t, apDict)
+	require.NotEmpty(t, findOps(ops, "g"))
+}
+
+func TestPushbuttonCaption(t *testing.T) {
+	field := model.NewPdfField()
+	fbtn := &model.PdfFieldButton{PdfField: field}
+	field.SetContext(fbtn)
+	fbtn.T = core.MakeString("button1")
+	fbtn.SetFlag(model.FieldFlagPushbutton)
+	dict, ok := core.GetDict(fbtn.GetContainingPdfObject())
+	require.True(t, ok)
+	dict.Set("DA", core.MakeString("/Helv 12 Tf 0 g"))
+
+	mk := core.MakeDict()
+	mk.Set("CA", core.MakeString("OK"))
+	widget := model.NewPdfAnnotationWidget()
+	widget.Rect = core.MakeArrayFromFloats([]float64{0, 0, 100, 40})
+	widget.Parent = fbtn.ToPdfObject()
+	widget.MK = mk
+	fbtn.Annotations = append(fbtn.Annotations, widget)
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{field}
+
+	// getLayout returns the position of the caption, if any, and the
+	// transformation matrix of the icon, if any.
+	getLayout := func() ([]float64, []float64) {
+		apDict, err := FieldAppearance{}.GenerateAppearanceDict(form, field, widget)
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+
+		var caption, iconMatrix []float64
+		if tds := findOps(ops, "Td"); len(tds) > 0 {
+			require.Len(t, findOps(ops, "Tj"), 1)
+			caption, err = core.GetNumbersAsFloat(tds[0].Params)
+			require.NoError(t, err)
+			tfs := findOps(ops, "Tf")
+			require.Len(t, tfs, 1)
+			size, err := core.GetNumberAsFloat(tfs[0].Params[1])
+			require.NoError(t, err)
+			require.Equal(t, 12.0, size)
+		}
+		if len(findOps(ops, "Do")) > 0 {
+			cms := findOps(ops, "cm")
+			require.NotEmpty(t, cms)
+			iconMatrix, err = core.GetNumbersAsFloat(cms[len(cms)-1].Params)
+			require.NoError(t, err)
+		}
+		return caption, iconMatrix
+	}
+
+	font, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+	captionWidth := FieldAppearance{}.Style().textWidth(font, "OK") * 12 / 1000
+
+	// The caption is centered in the widget if there is no icon.
+	caption, iconMatrix := getLayout()
+	require.InDelta(t, (100-captionWidth)/2, caption[0], 1e-6)
+	require.Greater(t, caption[1], 10.0)
+	require.Nil(t, iconMatrix)
+
+	// The caption is placed below the icon, which is scaled to fit.
+	icon := model.NewXObjectForm()
+	icon.BBox = core.MakeArrayFromFloats([]float64{0, 0, 10, 10})
+	require.NoError(t, icon.SetContentStream([]byte("0 0 10 10 re f"), nil))
+	mk.Set("I", icon.ToPdfObject())
+	mk.Set("TP", core.MakeInteger(2))
+	caption, iconMatrix = getLayout()
+	require.InDelta(t, (100-captionWidth)/2, caption[0], 1e-6)
+	require.Less(t, caption[1], 12.0)
+	require.InDeltaSlice(t, []float64{2.8, 0, 0, 2.8, 36, 12}, iconMatrix, 1e-6)
+
+	// The caption is placed right of the icon.
+	mk.Set("TP", core.MakeInteger(4))
+	caption, iconMatrix = getLayout()
+	require.InDelta(t, 100-captionWidth, caption[0], 1e-6)
+	require.InDelta(t, 4, iconMatrix[0], 1e-6)
+	require.Less(t, iconMatrix[4]+40, caption[0]+1e-6)
+
+	// Only the icon is displayed.
+	mk.Set("TP", core.MakeInteger(1))
+	caption, iconMatrix = getLayout()
+	require.Nil(t, caption)
+	require.InDeltaSlice(t, []float64{4, 0, 0, 4, 30, 0}, iconMatrix, 1e-6)
+}
+
+func TestRadioButtonAppearance(t *testing.T) {
+	field := model.NewPdfField()
+	fbtn := &model.PdfFieldButton{PdfField: field}
+	field.SetContext(fbtn)
+	fbtn.T = core.MakeString("radio1")
+	fbtn.SetFlag(model.FieldFlagRadio | model.FieldFlagNoToggleToOff)
+
+	newWidget := func() *model.PdfAnnotationWidget {
+		widget := model.NewPdfAnnotationWidget()
+		widget.Rect = core.MakeArrayFromFloats([]float64{0, 0, 20, 20})
+		widget.Parent = fbtn.ToPdfObject()
+		fbtn.Annotations = append(fbtn.Annotations, widget)
+		return widget
+	}
+
+	// The state names are taken from the existing appearances.
+	nDict := core.MakeDict()
+	nDict.Set("Off", core.MakeNull())
+	nDict.Set("Choice1", core.MakeNull())
+	apDict := core.MakeDict()
+	apDict.Set("N", nDict)
+	widget1 := newWidget()
+	widget1.AP = apDict
+
+	widget2 := newWidget()
+	widget2.AS = core.MakeName("Choice2")
+
+	// Use the caption glyph specified by the MK dictionary.
+	widget3 := newWidget()
+	mk := core.MakeDict()
+	mk.Set("CA", core.MakeString("l"))
+	widget3.MK = mk
+	widget3.AS = core.MakeName("Choice3")
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{field}
+
+	fa := FieldAppearance{}
+	for i, expected := range []string{"Choice1", "Choice2", "Choice3"} {
+		wa := fbtn.Annotations[i]
+		apDict, err := fa.GenerateAppearanceDict(form, field, wa)
+		require.NoError(t, err)
+		nDict, ok := core.GetDict(apDict.Get("N"))
+		require.True(t, ok)
+		require.ElementsMatch(t, []core.PdfObjectName{"Off", core.PdfObjectName(expected)}, nDict.Keys())
+
+		onDict := core.MakeDict()
+		onDict.Set("N", nDict.Get(core.PdfObjectName(expected)))
+		_, ops := getAppearanceOps(t, onDict)
+		if wa == widget3 {
+			require.Len(t, findOps(ops, "Tj"), 1)
+		} else {
+			require.Empty(t, findOps(ops, "Tj"))
+			require.Len(t, findOps(ops, "c"), 4)
+		}
+
+		offDict := core.MakeDict()
+		offDict.Set("N", nDict.Get("Off"))
+		_, ops = getAppearanceOps(t, offDict)
+		require.Empty(t, findOps(ops, "f"))
+	}
+
+	// Buttons with the same export value use the widget indices as states.
+	fbtn.Opt = core.MakeArray(core.MakeString("Same"), core.MakeString("Same"), core.MakeString("Other"))
+	apDict, err := fa.GenerateAppearanceDict(form, field, widget2)
+	require.NoError(t, err)
+	nDict, ok := core.GetDict(apDict.Get("N"))
+	require.True(t, ok)
+	require.NotNil(t, nDict.Get("1"))
+}
+
+func TestTextFieldMargins(t *testing.T) {
+	form, field := newTestTextField(t, "Margins", []float64{0, 0, 100, 20})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+
+	// getTextPosition returns the position of the text of the appearance.
+	getTextPosition := func(fa FieldAppearance) []float64 {
+		form.DR = nil
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+		tds := findOps(ops, "Td")
+		require.NotEmpty(t, tds)
+		params, err := core.GetNumbersAsFloat(tds[0].Params)
+		require.NoError(t, err)
+		return params
+	}
+
+	// Default margins. The text is centered on the glyph box, spanning from
+	// the descent to the ascent of the font.
+	fa := FieldAppearance{}
+	require.InDeltaSlice(t, []float64{2, (20-9.25)/2 + 2.07}, getTextPosition(fa), 1e-6)
+
+	style := fa.Style()
+	style.TextMarginLeft = 10
+	style.TextMarginTop = 4
+	fa.SetStyle(style)
+	require.InDeltaSlice(t, []float64{10, (16-9.25)/2 + 2.07}, getTextPosition(fa), 1e-6)
+
+	// Right aligned text.
+	field.Q = core.MakeInteger(2)
+	style.TextMarginRight = 5
+	fa.SetStyle(style)
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+	tds := findOps(ops, "Td")
+	require.Len(t, tds, 2)
+	params, err := core.GetNumbersAsFloat(tds[1].Params)
+	require.NoError(t, err)
+
+	font, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+	textWidth := style.textWidth(font, "Margins") / 100
+	require.InDelta(t, 95-textWidth-10, params[0], 1e-6)
+}
+
+func TestTextFieldInheritedQuadding(t *testing.T) {
+	form, field := newTestTextField(t, "Inherited", []float64{0, 0, 100, 20})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+	field.Q = nil
+
+	// getTextX returns the horizontal position of the text of the appearance.
+	getTextX := func() float64 {
+		apDict, err := FieldAppearance{}.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+		var x float64
+		for _, op := range findOps(ops, "Td") {
+			tx, err := core.GetNumberAsFloat(op.Params[0])
+			require.NoError(t, err)
+			x += tx
+		}
+		return x
+	}
+	require.InDelta(t, 2, getTextX(), 1e-6)
+
+	font, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+	textWidth := FieldAppearance{}.Style().textWidth(font, "Inherited") / 100
+
+	// The field is the kid of a non-terminal field, which specifies centered
+	// alignment, nested in a text field specifying right justification.
+	root := model.NewPdfField()
+	root.SetContext(&model.PdfFieldText{PdfField: root, Q: core.MakeInteger(2)})
+	root.T = core.MakeString("root")
+	group := model.NewPdfField()
+	group.T = core.MakeString("group")
+	groupDict, ok := core.GetDict(group.GetContainingPdfObject())
+	require.True(t, ok)
+	groupDict.Set("Q", core.MakeInteger(1))
+
+	group.Parent = root
+	root.Kids = []*model.PdfField{group}
+	field.Parent = group
+	group.Kids = []*model.PdfField{field.PdfField}
+	form.Fields = &[]*model.PdfField{root}
+	require.InDelta(t, (100-textWidth)/2, getTextX(), 1e-6)
+
+	// Without the quadding of the non-terminal field, the quadding of the
+	// root field is used.
+	groupDict.Remove("Q")
+	x := getTextX()
+	require.Greater(t, x, (100-textWidth)/2)
+	field.Q = core.MakeInteger(2)
+	require.InDelta(t, getTextX(), x, 1e-6)
+
+	// The quadding of the field overrides the inherited one.
+	field.Q = core.MakeInteger(0)
+	require.InDelta(t, 2, getTextX(), 1e-6)
+}
+
+func TestCenteredBaseline(t *testing.T) {
+	fd := &model.PdfFontDescriptor{
+		Ascent:  core.MakeFloat(800),
+		Descent: core.MakeFloat(-200),
+	}
+	// The glyph box of the text is 10 units high.
+	require.InDelta(t, 2+5+2, centeredBaseline(fd, 10, 7, 2, 22), 1e-9)
+
+	// The cap height is centered if the metrics are missing.
+	require.InDelta(t, 2+6.5, centeredBaseline(nil, 10, 7, 2, 22), 1e-9)
+	require.InDelta(t, 2+6.5, centeredBaseline(&model.PdfFontDescriptor{}, 10, 7, 2, 22), 1e-9)
+}
+
+func TestAppearanceSizeOverride(t *testing.T) {
+	form, field := newTestTextField(t, "Stamp", []float64{0, 0, 100, 20})
+	field.DA = core.MakeString("/Helv 0 Tf 0 g")
+	widget := model.NewPdfAnnotationWidget()
+	widget.Rect = core.MakeArrayFromFloats([]float64{50, 50, 250, 90})
+	widget.Parent = field.ToPdfObject()
+	field.Annotations = append(field.Annotations, widget)
+
+	// getAppearance returns the bounding box and the content of the
+	// appearance of the widget `wa`.
+	getAppearance := func(fa FieldAppearance, wa *model.PdfAnnotationWidget) ([]float64, string) {
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, wa)
+		require.NoError(t, err)
+		xform, _ := getAppearanceOps(t, apDict)
+		bbox, ok := core.GetArray(xform.BBox)
+		require.True(t, ok)
+		vals, err := bbox.ToFloat64Array()
+		require.NoError(t, err)
+		content, err := xform.GetContentStream()
+		require.NoError(t, err)
+		return vals, string(content)
+	}
+
+	// The appearances are laid out based on the widget rectangles by default.
+	fa := FieldAppearance{}
+	bbox1, content1 := getAppearance(fa, field.Annotations[0])
+	bbox2, content2 := getAppearance(fa, widget)
+	require.Equal(t, []float64{0, 0, 100, 20}, bbox1)
+	require.Equal(t, []float64{0, 0, 200, 40}, bbox2)
+	require.NotEqual(t, content1, content2)
+
+	// The widgets share the same appearance with the size override.
+	style := fa.Style()
+	style.AppearanceWidth = 120
+	style.AppearanceHeight = 30
+	fa.SetStyle(style)
+	bbox1, content1 = getAppearance(fa, field.Annotations[0])
+	bbox2, content2 = getAppearance(fa, widget)
+	require.Equal(t, []float64{0, 0, 120, 30}, bbox1)
+	require.Equal(t, bbox1, bbox2)
+	require.Equal(t, content1, content2)
+
+	// The widget rectangles are left unchanged.
+	rect, ok := core.GetArray(widget.Rect)
+	require.True(t, ok)
+	vals, err := rect.ToFloat64Array()
+	require.NoError(t, err)
+	require.Equal(t, []float64{50, 50, 250, 90}, vals)
+}
+
+func TestAppearancePostProcess(t *testing.T) {
+	form, field := newTestTextField(t, "Value", []float64{0, 0, 100, 20})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+
+	// Replace the text color and append a watermark to the appearances.
+	fa := FieldAppearance{}
+	style := fa.Style()
+	var count int
+	style.PostProcess = func(ops *contentstream.ContentStreamOperations) error {
+		count++
+		for _, op := range *ops {
+			if op.Operand == "g" {
+				op.Operand = "rg"
+				op.Params = []core.PdfObject{core.MakeFloat(1), core.MakeFloat(0), core.MakeFloat(0)}
+			}
+		}
+		cc := contentstream.NewContentCreator()
+		cc.Add_q().Add_re(0, 0, 10, 10).Add_f().Add_Q()
+		*ops = append(*ops, *cc.Operations()...)
+		return nil
+	}
+	fa.SetStyle(style)
+
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	_, ops := getAppearanceOps(t, apDict)
+	require.Empty(t, findOps(ops, "g"))
+	rgs := findOps(ops, "rg")
+	require.Len(t, rgs, 1)
+	vals, err := core.GetNumbersAsFloat(rgs[0].Params)
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 0, 0}, vals)
+	require.Equal(t, "Q", (*ops)[len(*ops)-1].Operand)
+	require.Equal(t, "f", (*ops)[len(*ops)-2].Operand)
+
+	// Both states of checkbox appearances are post-processed.
+	checkbox := model.NewPdfField()
+	fbtn := &model.PdfFieldButton{PdfField: checkbox}
+	checkbox.SetContext(fbtn)
+	fbtn.T = core.MakeString("checkbox1")
+	fbtn.SetFlag(model.FieldFlagNoToggleToOff)
+	widget := model.NewPdfAnnotationWidget()
+	widget.Rect = core.MakeArrayFromFloats([]float64{0, 0, 20, 20})
+	widget.Parent = fbtn.ToPdfObject()
+	fbtn.Annotations = append(fbtn.Annotations, widget)
+	count = 0
+	_, err = fa.GenerateAppearanceDict(form, checkbox, widget)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+
+	// Errors are returned by the appearance generation.
+	style.PostProcess = func(ops *contentstream.ContentStreamOperations) error {
+		return errors.New("post-processing failed")
+	}
+	fa.SetStyle(style)
+	_, err = fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.EqualError(t, err, "post-processing failed")
+}
+
+func TestSignatureAppearancePostProcess(t *testing.T) {
+	// Append a watermark to the signature appearances.
+	var count int
+	opts := NewSignatureFieldOpts()
+	opts.Rect = []float64{10, 10, 210, 60}
+	opts.PostProcess = func(ops *contentstream.ContentStreamOperations) error {
+		count++
+		cc := contentstream.NewContentCreator()
+		cc.Add_q().Add_re(0, 0, 10, 10).Add_f().Add_Q()
+		*ops = append(*ops, *cc.Operations()...)
+		return nil
+	}
+
+	lines := []*SignatureLine{NewSignatureLine("Name", "John Doe")}
+	field, err := NewSignatureField(model.NewPdfSignature(nil), lines, opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	_, ops := getAppearanceOps(t, field.AP.(*core.PdfObjectDictionary))
+	require.Equal(t, "Q", (*ops)[len(*ops)-1].Operand)
+	require.Equal(t, "f", (*ops)[len(*ops)-2].Operand)
+
+	// Placeholder appearances are post-processed too.
+	count = 0
+	opts.DrawPlaceholder = true
+	apDict, err := genFieldSignatureAppearance(nil, opts)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+	_, ops = getAppearanceOps(t, apDict)
+	require.Equal(t, "f", (*ops)[len(*ops)-2].Operand)
+
+	// Errors are returned by the appearance generation.
+	opts.PostProcess = func(ops *contentstream.ContentStreamOperations) error {
+		return errors.New("post-processing failed")
+	}
+	_, err = NewSignatureField(model.NewPdfSignature(nil), lines, opts)
+	require.EqualError(t, err, "post-processing failed")
+	_, err = genFieldSignatureAppearance(nil, opts)
+	require.EqualError(t, err, "post-processing failed")
+}
+
+func TestTextFieldMaxLen(t *testing.T) {
+	form, field := newTestTextField(t, "", []float64{0, 0, 200, 20})
+	field.V = core.MakeEncodedString("Truncated äöü", true)
+	field.MaxLen = core.MakeInteger(11)
+
+	fa := FieldAppearance{}
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+	tjs := findOps(ops, "Tj")
+	require.Len(t, tjs, 1)
+	str, ok := core.GetString(tjs[0].Params[0])
+	require.True(t, ok)
+	require.Equal(t, "Truncated \xe4", str.Str())
+}
+
+func TestTextFieldPasswordMask(t *testing.T) {
+	form, field := newTestTextField(t, "secret", []float64{0, 0, 200, 20})
+	field.SetFlag(model.FieldFlagPassword)
+
+	// No appearance is generated by default.
+	fa := FieldAppearance{}
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	require.Nil(t, apDict)
+
+	style := fa.Style()
+	style.RenderPasswordMask = true
+	fa.SetStyle(style)
+
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	xform, ops := getAppearanceOps(t, apDict)
+	content, err := xform.GetContentStream()
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "secret")
+
+	// The mask contains a bullet for each character.
+	tfs := findOps(ops, "Tf")
+	require.Len(t, tfs, 1)
+	fontName, ok := core.GetName(tfs[0].Params[0])
+	require.True(t, ok)
+	fontObj, ok := xform.Resources.GetFontByName(*fontName)
+	require.True(t, ok)
+	font, err := model.NewPdfFontFromPdfObject(fontObj)
+	require.NoError(t, err)
+
+	tjs := findOps(ops, "Tj")
+	require.Len(t, tjs, 1)
+	str, ok := core.GetString(tjs[0].Params[0])
+	require.True(t, ok)
+	require.Equal(t, "••••••", font.Encoder().Decode(str.Bytes()))
+}
+
+func TestTaggedAppearance(t *testing.T) {
+	form, field := newTestTextField(t, "Tagged", []float64{0, 0, 200, 20})
+	widget := field.Annotations[0]
+
+	structElem := core.MakeDict()
+	structElem.Set("S", core.MakeName("Form"))
+
+	fa := FieldAppearance{}
+	apDict, mcid, err := fa.GenerateTaggedAppearanceDict(form, field.PdfField, widget, structElem)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), mcid)
+
+	// The text is wrapped in a tagged marked-content sequence.
+	_, ops := getAppearanceOps(t, apDict)
+	require.Empty(t, findOps(ops, "BMC"))
+	bdcs := findOps(ops, "BDC")
+	require.Len(t, bdcs, 1)
+	require.Len(t, bdcs[0].Params, 2)
+	props, ok := core.GetDict(bdcs[0].Params[1])
+	require.True(t, ok)
+	val, ok := core.GetIntVal(props.Get("MCID"))
+	require.True(t, ok)
+	require.Equal(t, 0, val)
+
+	// The marked-content reference is added to the structure element.
+	mcr, ok := core.GetDict(structElem.Get("K"))
+	require.True(t, ok)
+	require.Equal(t, apDict.Get("N"), mcr.Get("Stm"))
+	require.Equal(t, widget.GetContainingPdfObject(), mcr.Get("StmOwn"))
+	val, ok = core.GetIntVal(mcr.Get("MCID"))
+	require.True(t, ok)
+	require.Equal(t, 0, val)
+
+	// Button fields have no tagged content.
+	checkbox, err := NewCheckboxField(model.NewPdfPage(), "check1", []float64{0, 0, 20, 20}, CheckboxFieldOptions{Checked: true})
+	require.NoError(t, err)
+	_, mcid, err = fa.GenerateTaggedAppearanceDict(form, checkbox.PdfField, checkbox.Annotations[0], nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), mcid)
+
+	// Combo boxes have an appearance state for each option, each of which is
+	// referenced by the structure element.
+	combo, err := NewComboboxField(model.NewPdfPage(), "combo1", []float64{0, 0, 80, 20},
+		ComboboxFieldOptions{Choices: []string{"First", "Second"}})
+	require.NoError(t, err)
+	structElem = core.MakeDict()
+	apDict, mcid, err = fa.GenerateTaggedAppearanceDict(form, combo.PdfField, combo.Annotations[0], structElem)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), mcid)
+
+	states, ok := core.GetDict(apDict.Get("N"))
+	require.True(t, ok)
+	kids, ok := core.GetArray(structElem.Get("K"))
+	require.True(t, ok)
+	require.Equal(t, len(states.Keys()), kids.Len())
+	for i, state := range states.Keys() {
+		mcr, ok := core.GetDict(kids.Get(i))
+		require.True(t, ok)
+		require.Equal(t, states.Get(state), mcr.Get("Stm"))
+
+		stateDict := core.MakeDict()
+		stateDict.Set("N", states.Get(state))
+		_, ops := getAppearanceOps(t, stateDict)
+		bdcs := findOps(ops, "BDC")
+		require.Len(t, bdcs, 1)
+		props, ok := core.GetDict(bdcs[0].Params[1])
+		require.True(t, ok)
+		val, ok := core.GetIntVal(props.Get("MCID"))
+		require.True(t, ok)
+		require.Equal(t, 0, val)
+	}
+}
+
+func TestComboboxEllipsis(t *testing.T) {
+	const option = "A very long combobox option"
+
+	helvetica, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+
+	// getText returns the text shown by the appearance of the option.
+	getText := func(ellipsis bool, q int64) string {
+		combo, err := NewComboboxField(model.NewPdfPage(), "combo1", []float64{0, 0, 80, 20},
+			ComboboxFieldOptions{Choices: []string{option}})
+		require.NoError(t, err)
+		dict, ok := core.GetDict(combo.ToPdfObject())
+		require.True(t, ok)
+		dict.Set("Q", core.MakeInteger(q))
+
+		form := model.NewPdfAcroForm()
+		form.Fields = &[]*model.PdfField{combo.PdfField}
+
+		fa := FieldAppearance{}
+		style := fa.Style()
+		style.Ellipsis = ellipsis
+		style.Fonts = &AppearanceFontStyle{
+			Fallback: &AppearanceFont{Name: "Helv", Font: helvetica, Size: 12},
+		}
+		fa.SetStyle(style)
+
+		apDict, err := fa.GenerateAppearanceDict(form, combo.PdfField, combo.Annotations[0])
+		require.NoError(t, err)
+		states, ok := core.GetDict(apDict.Get("N"))
+		require.True(t, ok)
+		stateDict := core.MakeDict()
+		stateDict.Set("N", states.Get(*core.MakeName(option)))
+		_, ops := getAppearanceOps(t, stateDict)
+
+		tjs := findOps(ops, "Tj")
+		require.Len(t, tjs, 1)
+		str, ok := core.GetString(tjs[0].Params[0])
+		require.True(t, ok)
+		return helvetica.Encoder().Decode(str.Bytes())
+	}
+
+	require.Equal(t, option, getText(false, 0))
+
+	// The truncated text fits the width of the field.
+	text := getText(true, 0)
+	require.True(t, strings.HasSuffix(text, "…"), text)
+	require.True(t, strings.HasPrefix(option, strings.TrimSuffix(text, "…")), text)
+	require.LessOrEqual(t, FieldAppearance{}.Style().textWidth(helvetica, text)*12/1000, 78.0)
+
+	// Right aligned values are truncated from the start.
+	text = getText(true, 2)
+	require.True(t, strings.HasPrefix(text, "…"), text)
+	require.True(t, strings.HasSuffix(option, strings.TrimPrefix(text, "…")), text)
+}
+
+func TestEllipsisRune(t *testing.T) {
+	const text = "A very long value"
+
+	helvetica, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+	style := FieldAppearance{}.Style()
+	maxWidth := style.textWidth(helvetica, "A very long…")
+
+	require.Equal(t, "A very long…", style.ellipsize(helvetica, 12, text, maxWidth, false))
+
+	style.EllipsisRune = '~'
+	require.Equal(t, "A very long~", style.ellipsize(helvetica, 12, text, maxWidth, false))
+
+	// Fonts without a glyph for the ellipsis rune use three periods.
+	style.EllipsisRune = '⋯'
+	require.False(t, hasRuneGlyph(helvetica, '⋯'))
+	require.Equal(t, "A very long...", style.ellipsize(helvetica, 12, text, maxWidth, false))
+}
+
+func TestComboboxEditableValue(t *testing.T) {
+	// getStates returns the appearance states of the combo box with the
+	// value `value`, mapped to the text shown by them.
+	getStates := func(editable bool, value string) map[string]string {
+		combo, err := NewComboboxField(model.NewPdfPage(), "combo1", []float64{0, 0, 100, 20},
+			ComboboxFieldOptions{Choices: []string{"First", "Second"}})
+		require.NoError(t, err)
+		if editable {
+			combo.SetFlag(combo.Flags().Set(model.FieldFlagEdit))
+		}
+		combo.V = core.MakeString(value)
+
+		form := model.NewPdfAcroForm()
+		form.Fields = &[]*model.PdfField{combo.PdfField}
+
+		apDict, err := FieldAppearance{}.GenerateAppearanceDict(form, combo.PdfField, combo.Annotations[0])
+		require.NoError(t, err)
+		states, ok := core.GetDict(apDict.Get("N"))
+		require.True(t, ok)
+
+		texts := map[string]string{}
+		for _, key := range states.Keys() {
+			stateDict := core.MakeDict()
+			stateDict.Set("N", states.Get(key))
+			_, ops := getAppearanceOps(t, stateDict)
+
+			tjs := findOps(ops, "Tj")
+			require.Len(t, tjs, 1)
+			str, ok := core.GetString(tjs[0].Params[0])
+			require.True(t, ok)
+			texts[key.String()] = str.Str()
+		}
+		return texts
+	}
+
+	// Typed values of editable combo boxes have their own appearance state.
+	expected := map[string]string{"First": "First", "Second": "Second", "Typed value": "Typed value"}
+	require.Equal(t, expected, getStates(true, "Typed value"))
+
+	expected = map[string]string{"First": "First", "Second": "Second"}
+	require.Equal(t, expected, getStates(true, "Second"))
+	require.Equal(t, expected, getStates(false, "Typed value"))
+}
+
+func TestAlignmentReticle(t *testing.T) {
+	// getReticleOps returns the line width and stroking color of the
+	// reticle, along with the painting operators of the appearance.
+	getReticleOps := func(style AppearanceStyle) ([]float64, []float64, []string) {
+		form, field := newTestTextField(t, "Text", []float64{0, 0, 100, 20})
+		fa := FieldAppearance{}
+		fa.SetStyle(style)
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+
+		ws := findOps(ops, "w")
+		require.Len(t, ws, 1)
+		w, err := core.GetNumbersAsFloat(ws[0].Params)
+		require.NoError(t, err)
+
+		var color []float64
+		for _, op := range *ops {
+			if op.Operand == "G" || op.Operand == "RG" {
+				color, err = core.GetNumbersAsFloat(op.Params)
+				require.NoError(t, err)
+			}
+		}
+
+		var painting []string
+		for _, op := range *ops {
+			switch op.Operand {
+			case "S", "f", "B", "b":
+				painting = append(painting, op.Operand)
+			}
+		}
+		return w, color, painting
+	}
+
+	style := FieldAppearance{}.Style()
+	style.DrawAlignmentReticle = true
+	// The reticle doesn't fill the field, so that the contents stay visible.
+	w, color, painting := getReticleOps(style)
+	require.Equal(t, []float64{0.2}, w)
+	require.Equal(t, []float64{0}, color)
+	require.Equal(t, []string{"S"}, painting)
+
+	style.ReticleColor = model.NewPdfColorDeviceRGB(1, 0, 0)
+	style.ReticleLineWidth = 0.5
+	w, color, painting = getReticleOps(style)
+	require.Equal(t, []float64{0.5}, w)
+	require.Equal(t, []float64{1, 0, 0}, color)
+	require.Equal(t, []string{"S"}, painting)
+}
+
+func TestRequiredFieldBorder(t *testing.T) {
+	fa := FieldAppearance{}
+	style := fa.Style()
+	style.RequiredBorderSize = 2
+	style.RequiredBorderColor = model.NewPdfColorDeviceRGB(1, 0, 0)
+	fa.SetStyle(style)
+
+	// getBorderOps returns the line width and stroking color operations of
+	// the appearance of `field`.
+	getBorderOps := func(field *model.PdfField, wa *model.PdfAnnotationWidget) ([]float64, []float64) {
+		apDict, err := fa.GenerateAppearanceDict(model.NewPdfAcroForm(), field, wa)
+		require.NoError(t, err)
+		if n, ok := core.GetDict(apDict.Get("N")); ok {
+			stream := core.MakeDict()
+			stream.Set("N", n.Get(n.Keys()[0]))
+			apDict = stream
+		}
+		_, ops := getAppearanceOps(t, apDict)
+
+		var w, rg []float64
+		if ws := findOps(ops, "w"); len(ws) > 0 {
+			w, err = core.GetNumbersAsFloat(ws[0].Params)
+			require.NoError(t, err)
+		}
+		if rgs := findOps(ops, "RG"); len(rgs) > 0 {
+			rg, err = core.GetNumbersAsFloat(rgs[0].Params)
+			require.NoError(t, err)
+		}
+		return w, rg
+	}
+
+	// The normal border is used for fields which are not required.
+	_, text := newTestTextField(t, "Text", []float64{0, 0, 100, 20})
+	w, rg := getBorderOps(text.PdfField, text.Annotations[0])
+	require.Empty(t, w)
+	require.Empty(t, rg)
+
+	text.SetFlag(model.FieldFlagRequired)
+	w, rg = getBorderOps(text.PdfField, text.Annotations[0])
+	require.Equal(t, []float64{2}, w)
+	require.Equal(t, []float64{1, 0, 0}, rg)
+
+	checkbox, err := NewCheckboxField(model.NewPdfPage(), "check1", []float64{0, 0, 20, 20}, CheckboxFieldOptions{Checked: true})
+	require.NoError(t, err)
+	checkbox.SetFlag(model.FieldFlagRequired)
+	w, rg = getBorderOps(checkbox.PdfField, checkbox.Annotations[0])
+	require.Equal(t, []float64{2}, w)
+	require.Equal(t, []float64{1, 0, 0}, rg)
+}
+
+func TestTextFieldSpacing(t *testing.T) {
+	form, field := newTestTextField(t, "ab c", []float64{0, 0, 100, 20})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+	field.Q = core.MakeInteger(2)
+
+	helvetica, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+
+	fa := FieldAppearance{}
+	style := fa.Style()
+	style.CharSpacing = 1
+	style.WordSpacing = 2
+	fa.SetStyle(style)
+
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+
+	tcs := findOps(ops, "Tc")
+	require.Len(t, tcs, 1)
+	tc, err := core.GetNumberAsFloat(tcs[0].Params[0])
+	require.NoError(t, err)
+	require.Equal(t, 1.0, tc)
+
+	tws := findOps(ops, "Tw")
+	require.Len(t, tws, 1)
+	tw, err := core.GetNumberAsFloat(tws[0].Params[0])
+	require.NoError(t, err)
+	require.Equal(t, 2.0, tw)
+
+	// The right aligned text accounts for the spacing of the 4 characters
+	// and of the space.
+	var x float64
+	for _, op := range findOps(ops, "Td") {
+		tx, err := core.GetNumberAsFloat(op.Params[0])
+		require.NoError(t, err)
+		x += tx
+	}
+	width := style.textWidth(helvetica, "ab c")*10/1000 + 4*1 + 2
+	require.InDelta(t, 100-width, x, 1e-6)
+}
+
+func TestTextFieldCombUnicode(t *testing.T) {
+	// getCells returns the glyphs shown by the comb field appearance and
+	// the indices of the cells containing them.
+	getCells := func(value string, quadding int64) ([]string, []int) {
+		form, field := newTestTextField(t, "", []float64{0, 0, 100, 20})
+		field.V = core.MakeEncodedString(value, true)
+		field.MaxLen = core.MakeInteger(5)
+		field.Q = core.MakeInteger(quadding)
+		field.SetFlag(model.FieldFlagComb)
+
+		fa := FieldAppearance{}
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+
+		var x float64
+		var glyphs []string
+		var cells []int
+		for _, op := range *ops {
+			switch op.Operand {
+			case "Td":
+				tx, err := core.GetNumberAsFloat(op.Params[0])
+				require.NoError(t, err)
+				x += tx
+			case "Tj":
+				str, ok := core.GetString(op.Params[0])
+				require.True(t, ok)
+				glyphs = append(glyphs, str.Str())
+				cells = append(cells, int(x/20))
+			}
+		}
+		return glyphs, cells
+	}
+
+	// The value has fewer runes than bytes.
+	glyphs, cells := getCells("Über", 0)
+	require.Equal(t, []string{"\xdc", "b", "e", "r"}, glyphs)
+	require.Equal(t, []int{0, 1, 2, 3}, cells)
+
+	glyphs, cells = getCells("Über", 2)
+	require.Len(t, glyphs, 4)
+	require.Equal(t, []int{1, 2, 3, 4}, cells)
+
+	// The value is truncated to MaxLen runes.
+	glyphs, cells = getCells("äöüÄÖÜ", 0)
+	require.Equal(t, []string{"\xe4", "\xf6", "\xfc", "\xc4", "\xd6"}, glyphs)
+	require.Equal(t, []int{0, 1, 2, 3, 4}, cells)
+}
+
+func TestTextFieldCombNoMaxLen(t *testing.T) {
+	form, field := newTestTextField(t, "ABCD", []float64{0, 0, 100, 20})
+	field.SetFlag(model.FieldFlagComb)
+
+	// The number of cells is derived from the length of the value.
+	fa := FieldAppearance{}
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+
+	var x float64
+	var cells []int
+	for _, op := range *ops {
+		switch op.Operand {
+		case "Td":
+			tx, err := core.GetNumberAsFloat(op.Params[0])
+			require.NoError(t, err)
+			x += tx
+		case "Tj":
+			cells = append(cells, int(x/25))
+		}
+	}
+	require.Equal(t, []int{0, 1, 2, 3}, cells)
+
+	// The regular text field appearance is generated for empty values.
+	field.V = core.MakeString("")
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	field.SetFlag(0)
+	expected, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	require.Equal(t, expected, apDict)
+}
+
+func TestTextFieldClipToRect(t *testing.T) {
+	form, field := newTestTextField(t, "Clipped", []float64{0, 0, 100, 20})
+	field.DA = core.MakeString("/Helv 40 Tf 0 g")
+	mk := core.MakeDict()
+	mk.Set("R", core.MakeInteger(90))
+	field.Annotations[0].MK = mk
+
+	fa := FieldAppearance{}
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+	require.Empty(t, findOps(ops, "W"))
+
+	// The clipping path is set in the rotated coordinate system, before
+	// drawing the text.
+	style := fa.Style()
+	style.ClipToRect = true
+	fa.SetStyle(style)
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops = getAppearanceOps(t, apDict)
+
+	var operands []string
+	for _, op := range *ops {
+		operands = append(operands, op.Operand)
+	}
+	require.Equal(t, []string{"BMC", "q", "cm", "cm", "re", "W", "n", "BT"}, operands[:8])
+	params, err := core.GetNumbersAsFloat(findOps(ops, "re")[0].Params)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{0, 0, 20, 100}, params, 1e-9)
+}
+
+func TestTextFieldVertical(t *testing.T) {
+	form, field := newTestTextField(t, "ABC", []float64{0, 0, 30, 100})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+	field.SetFlag(model.FieldFlagMultiline)
+
+	fa := FieldAppearance{}
+	style := fa.Style()
+	style.Vertical = true
+	fa.SetStyle(style)
+
+	// getGlyphPositions returns the positions of the drawn glyphs.
+	getGlyphPositions := func() [][2]float64 {
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+
+		var x, y float64
+		var positions [][2]float64
+		for _, op := range *ops {
+			switch op.Operand {
+			case "Td":
+				params, err := core.GetNumbersAsFloat(op.Params)
+				require.NoError(t, err)
+				x, y = x+params[0], y+params[1]
+			case "Tj":
+				positions = append(positions, [2]float64{x, y})
+			}
+		}
+		return positions
+	}
+
+	// The glyphs are stacked from top to bottom.
+	positions := getGlyphPositions()
+	require.Len(t, positions, 3)
+	for i := 1; i < len(positions); i++ {
+		require.InDelta(t, positions[i-1][1]-10, positions[i][1], 1e-6)
+	}
+	require.Greater(t, positions[0][0], 15.0)
+
+	// Lines are laid out in columns from right to left.
+	field.V = core.MakeString("AB\nC")
+	positions = getGlyphPositions()
+	require.Len(t, positions, 3)
+	require.Less(t, positions[2][0], positions[0][0]-10)
+	require.InDelta(t, positions[0][1], positions[2][1], 1e-6)
+
+	// Autosized text is measured against the height of the field.
+	field.DA = core.MakeString("/Helv 0 Tf 0 g")
+	field.V = core.MakeString("ABCDEFGHIJ")
+	positions = getGlyphPositions()
+	require.Len(t, positions, 10)
+	require.Greater(t, positions[9][1], 0.0)
+	require.InDelta(t, positions[0][0], positions[9][0], 3)
+}
+
+func TestTextFieldLineAlignFunc(t *testing.T) {
+	form, field := newTestTextField(t, "Name\nJohn Doe", []float64{0, 0, 200, 60})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+	field.SetFlag(model.FieldFlagMultiline)
+
+	// getLineX returns the horizontal positions of the lines of text.
+	getLineX := func(fa FieldAppearance) []float64 {
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+
+		var x float64
+		var positions []float64
+		for _, op := range *ops {
+			switch op.Operand {
+			case "Td":
+				tx, err := core.GetNumberAsFloat(op.Params[0])
+				require.NoError(t, err)
+				x += tx
+			case "Tj":
+				positions = append(positions, x)
+			}
+		}
+		return positions
+	}
+
+	// All lines are aligned based on the quadding of the field by default.
+	fa := FieldAppearance{}
+	require.InDeltaSlice(t, []float64{2, 2}, getLineX(fa), 1e-6)
+
+	font, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+	style := fa.Style()
+	nameWidth := style.textWidth(font, "Name") / 100
+	valueWidth := style.textWidth(font, "John Doe") / 100
+
+	// Centered label followed by left aligned lines.
+	style.LineAlignFunc = func(lineIndex int) Quadding {
+		if lineIndex == 0 {
+			return QuaddingCenter
+		}
+		return QuaddingLeft
+	}
+	fa.SetStyle(style)
+	require.InDeltaSlice(t, []float64{(200 - nameWidth) / 2, 2}, getLineX(fa), 1e-6)
+
+	// The function overrides the quadding of the field.
+	field.Q = core.MakeInteger(int64(QuaddingCenter))
+	style.LineAlignFunc = func(lineIndex int) Quadding {
+		return []Quadding{QuaddingRight, QuaddingCenter}[lineIndex]
+	}
+	fa.SetStyle(style)
+	require.InDeltaSlice(t, []float64{200 - nameWidth, (200 - valueWidth) / 2}, getLineX(fa), 1e-6)
+}
+
+func TestFieldAutoFontSizeFractions(t *testing.T) {
+	form, field := newTestTextField(t, "Size", []float64{0, 0, 100, 20})
+	field.DA = core.MakeString("/Helv 0 Tf 0 g")
+
+	// getFontSize returns the font size of the generated appearance.
+	getFontSize := func(fa FieldAppearance) float64 {
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+		tfs := findOps(ops, "Tf")
+		require.NotEmpty(t, tfs)
+		size, err := core.GetNumberAsFloat(tfs[len(tfs)-1].Params[1])
+		require.NoError(t, err)
+		return size
+	}
+
+	fa := FieldAppearance{}
+	require.InDelta(t, 13, getFontSize(fa), 1e-6)
+
+	style := fa.Style()
+	style.FieldAutoFontSizeFractions = map[string]float64{"text1": 0.4, "other": 0.9}
+	fa.SetStyle(style)
+	require.InDelta(t, 8, getFontSize(fa), 1e-6)
+
+	style.FieldAutoFontSizeFractions = map[string]float64{"other": 0.9}
+	fa.SetStyle(style)
+	require.InDelta(t, 13, getFontSize(fa), 1e-6)
+}
+
+func TestAcrobatCompatibleStyle(t *testing.T) {
+	style := NewAcrobatCompatibleStyle()
+	require.Equal(t, 0.75, style.AutoFontSizeFraction)
+	require.Equal(t, 1.15, style.MultilineLineHeight)
+
+	// The other settings are those of the default style.
+	expected := FieldAppearance{}.Style()
+	expected.AutoFontSizeFraction = 0.75
+	expected.MultilineLineHeight = 1.15
+	require.Equal(t, expected, style)
+
+	fa := FieldAppearance{}
+	fa.SetStyle(style)
+
+	// Auto sized text fills 75% of the field height.
+	form, field := newTestTextField(t, "Size", []float64{0, 0, 100, 20})
+	field.DA = core.MakeString("/Helv 0 Tf 0 g")
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+	tfs := findOps(ops, "Tf")
+	require.NotEmpty(t, tfs)
+	size, err := core.GetNumberAsFloat(tfs[len(tfs)-1].Params[1])
+	require.NoError(t, err)
+	require.InDelta(t, 15, size, 1e-6)
+
+	// The spacing of multiline text lines is based on the line height of
+	// the style (1.15).
+	form, field = newTestTextField(t, "First\nSecond", []float64{0, 0, 100, 50})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+	field.SetFlag(field.Flags().Set(model.FieldFlagMultiline))
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops = getAppearanceOps(t, apDict)
+	var offsets []float64
+	for _, op := range findOps(ops, "Td") {
+		ty, err := core.GetNumberAsFloat(op.Params[1])
+		require.NoError(t, err)
+		offsets = append(offsets, ty)
+	}
+	require.Len(t, offsets, 2)
+	require.InDelta(t, -10*1.15*1.15, offsets[1], 1e-6)
+}
+
+func TestTextFieldTightBBox(t *testing.T) {
+	form, field := newTestTextField(t, "Tight", []float64{0, 0, 200, 50})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+
+	getBBox := func(apDict *core.PdfObjectDictionary) []float64 {
+		xform, _ := getAppearanceOps(t, apDict)
+		arr, ok := core.GetArray(xform.BBox)
+		require.True(t, ok)
+		bbox, err := arr.ToFloat64Array()
+		require.NoError(t, err)
+		return bbox
+	}
+
+	fa := FieldAppearance{}
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 0, 200, 50}, getBBox(apDict))
+
+	// The bounding box fits the text, which is indented by 2 points.
+	style := fa.Style()
+	style.TightBBox = true
+	fa.SetStyle(style)
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	bbox := getBBox(apDict)
+	require.InDelta(t, 2, bbox[0], 1e-9)
+	require.InDelta(t, 2+22.23, bbox[2], 1e-9)
+	require.Greater(t, bbox[1], 10.0)
+	require.Less(t, bbox[3], 40.0)
+
+	// The margin is not extended beyond the original bounding box.
+	style.TightBBoxMargin = 5
+	fa.SetStyle(style)
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	marginBBox := getBBox(apDict)
+	require.InDelta(t, 0, marginBBox[0], 1e-9)
+	require.InDeltaSlice(t, []float64{bbox[1] - 5, bbox[2] + 5, bbox[3] + 5}, marginBBox[1:], 1e-9)
+}
+
+func TestTextFieldDecorations(t *testing.T) {
+	form, field := newTestTextField(t, "Decorated", []float64{0, 0, 200, 30})
+	field.DA = core.MakeString("/Helv 10 Tf 1 0 0 rg")
+
+	fa := FieldAppearance{}
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+	require.Empty(t, findOps(ops, "f"))
+
+	// The lines are filled after the text, below the baseline and through
+	// the text, spanning the width of the text.
+	style := fa.Style()
+	style.Underline = true
+	style.Strikethrough = true
+	fa.SetStyle(style)
+	form.DR = nil
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops = getAppearanceOps(t, apDict)
+
+	var operands []string
+	for _, op := range *ops {
+		operands = append(operands, op.Operand)
+	}
+	require.Equal(t, []string{"ET", "re", "re", "f", "Q", "EMC"}, operands[len(operands)-6:])
+
+	td, err := core.GetNumbersAsFloat(findOps(ops, "Td")[0].Params)
+	require.NoError(t, err)
+	helvetica, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+	width := 10 * style.textWidth(helvetica, "Decorated") / 1000
+	res := findOps(ops, "re")
+	underline, err := core.GetNumbersAsFloat(res[0].Params)
+	require.NoError(t, err)
+	require.InDelta(t, td[0], underline[0], 1e-9)
+	require.InDelta(t, width, underline[2], 1e-9)
+	require.Less(t, underline[1]+underline[3], td[1])
+	require.Greater(t, underline[3], 0.0)
+
+	strikethrough, err := core.GetNumbersAsFloat(res[1].Params)
+	require.NoError(t, err)
+	require.InDelta(t, width, strikethrough[2], 1e-9)
+	require.Greater(t, strikethrough[1], td[1])
+	require.Less(t, strikethrough[1], td[1]+10*0.718)
+
+	// The decorations can be specified by the rich text value.
+	fa = FieldAppearance{}
+	field.RV = core.MakeString(`<body style="text-decoration: line-through"><p>Decorated</p></body>`)
+	form.DR = nil
+	apDict, err = fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops = getAppearanceOps(t, apDict)
+	require.Len(t, findOps(ops, "re"), 1)
+	require.Len(t, findOps(ops, "f"), 1)
+}
+
+func TestSignatureBackgroundImage(t *testing.T) {
+	img, err := model.ImageHandling.NewImageFromGoImage(goimage.NewRGBA(goimage.Rect(0, 0, 20, 10)))
+	require.NoError(t, err)
+	ximg, err := model.NewXObjectImageFromImage(img, nil, core.NewFlateEncoder())
+	require.NoError(t, err)
+
+	lines := []*SignatureLine{NewSignatureLine("Name", "John Doe")}
+	getImageOps := func(placement SignatureImagePlacement, scale float64) [][]float64 {
+		opts := NewSignatureFieldOpts()
+		opts.Rect = []float64{10, 10, 110, 40}
+		opts.BackgroundImage = ximg
+		opts.BackgroundImagePlacement = placement
+		opts.BackgroundImageScale = scale
+		apDict, err := genFieldSignatureAppearance(lines, opts)
+		require.NoError(t, err)
+		xform, ops := getAppearanceOps(t, apDict)
+		require.True(t, xform.Resources.HasXObjectByName("Im1"))
+		require.Len(t, findOps(ops, "BT"), 1)
+
+		var cms [][]float64
+		for i, op := range *ops {
+			if op.Operand != "Do" {
+				continue
+			}
+			name, ok := core.GetName(op.Params[0])
+			require.True(t, ok)
+			require.Equal(t, "Im1", name.String())
+			params, err := core.GetNumbersAsFloat((*ops)[i-1].Params)
+			require.NoError(t, err)
+			cms = append(cms, params)
+		}
+		return cms
+	}
+
+	// The image is scaled to fit, preserving its aspect ratio.
+	require.Equal(t, [][]float64{{60, 0, 0, 30, 30, 10}}, getImageOps(SignatureImageFit, 0))
+	require.Equal(t, [][]float64{{20, 0, 0, 10, 50, 20}}, getImageOps(SignatureImageCenter, 0))
+	require.Len(t, getImageOps(SignatureImageTile, 0), 5*3)
+	require.Len(t, getImageOps(SignatureImageTile, 2), 3*2)
+}
+
+func TestSignaturePlaceholder(t *testing.T) {
+	// No placeholder is drawn by default.
+	opts := NewSignatureFieldOpts()
+	opts.Rect = []float64{10, 10, 210, 60}
+	apDict, err := genFieldSignatureAppearance(nil, opts)
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+	require.Empty(t, findOps(ops, "Tj"))
+	require.Empty(t, findOps(ops, "S"))
+
+	opts.DrawPlaceholder = true
+	opts.PlaceholderDiagonal = true
+	opts.PlaceholderBorderSize = 2
+	opts.PlaceholderBorderDash = []int64{3, 2}
+	apDict, err = genFieldSignatureAppearance(nil, opts)
+	require.NoError(t, err)
+	xform, ops := getAppearanceOps(t, apDict)
+
+	tjs := findOps(ops, "Tj")
+	require.Len(t, tjs, 1)
+	str, ok := core.GetString(tjs[0].Params[0])
+	require.True(t, ok)
+	require.Equal(t, "Sign here", str.Str())
+	require.Len(t, findOps(ops, "S"), 2)
+	require.Len(t, findOps(ops, "d"), 1)
+	require.Len(t, findOps(ops, "l"), 1)
+
+	// The box is inset by half the line width.
+	var box []float64
+	for _, op := range findOps(ops, "re") {
+		box, err = core.GetNumbersAsFloat(op.Params)
+		require.NoError(t, err)
+	}
+	require.Equal(t, []float64{11, 11, 198, 48}, box)
+	bbox, err := core.GetNumbersAsFloat(xform.BBox.(*core.PdfObjectArray).Elements())
+	require.NoError(t, err)
+	require.Equal(t, opts.Rect, bbox)
+
+	// The placeholder is not drawn for signed fields.
+	lines := []*SignatureLine{NewSignatureLine("Name", "John Doe")}
+	apDict, err = genFieldSignatureAppearance(lines, opts)
+	require.NoError(t, err)
+	_, ops = getAppearanceOps(t, apDict)
+	require.Empty(t, findOps(ops, "Tj"))
+	require.Empty(t, findOps(ops, "d"))
+
+	// The rectangle is sized to fit the label, if not specified.
+	opts = NewSignatureFieldOpts()
+	opts.DrawPlaceholder = true
+	opts.PlaceholderText = "Sign"
+	_, err = genFieldSignatureAppearance(nil, opts)
+	require.NoError(t, err)
+	require.Len(t, opts.Rect, 4)
+	width := model.MeasureTextWidth(opts.Font, "Sign", 10)
+	require.InDelta(t, width+12, opts.Rect[2], 1e-9)
+	require.InDelta(t, 22.0, opts.Rect[3], 1e-9)
+}
+
+func TestSignatureMultilineText(t *testing.T) {
+	// getLines returns the number of rendered lines and the font size.
+	getLines := func(lines []*SignatureLine, rect []float64) (int, float64) {
+		opts := NewSignatureFieldOpts()
+		opts.Rect = rect
+		apDict, err := genFieldSignatureAppearance(lines, opts)
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+
+		tfs := findOps(ops, "Tf")
+		require.NotEmpty(t, tfs)
+		fontSize, err := core.GetNumberAsFloat(tfs[0].Params[1])
+		require.NoError(t, err)
+		return len(findOps(ops, "Td")), fontSize
+	}
+
+	// Newlines separate the lines of the text.
+	lines := []*SignatureLine{
+		NewSignatureLine("Name", "John Doe"),
+		NewSignatureLine("Address", "1 Main Street\nSpringfield"),
+	}
+	n, fontSize := getLines(lines, []float64{0, 0, 200, 100})
+	require.Equal(t, 3, n)
+	require.Equal(t, 10.0, fontSize)
+
+	// Long lines are wrapped to the width of the rectangle.
+	lines = []*SignatureLine{
+		NewSignatureLine("Reason", "I am approving this document on behalf of the company"),
+	}
+	n, fontSize = getLines(lines, []float64{0, 0, 100, 100})
+	require.Greater(t, n, 1)
+	require.Equal(t, 10.0, fontSize)
+
+	// The font size is reduced for the wrapped lines to fit the height.
+	n, fontSize = getLines(lines, []float64{0, 0, 100, 20})
+	require.Greater(t, n, 1)
+	require.Less(t, fontSize, 10.0)
+	require.LessOrEqual(t, float64(n)*fontSize, 20.0)
+}
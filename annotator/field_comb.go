@@ -0,0 +1,86 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// CombPadding represents the padding of the values of comb text fields which
+// are shorter than the number of cells of the field (MaxLen).
+type CombPadding int
+
+const (
+	// CombPaddingNone leaves the cells following (or preceding, for right
+	// justified fields) the value empty.
+	CombPaddingNone CombPadding = iota
+
+	// CombPaddingLeft fills the leading cells of the field with the pad
+	// rune, so that the value occupies the last cells (e.g. 000123).
+	CombPaddingLeft
+
+	// CombPaddingRight fills the trailing cells of the field with the pad
+	// rune, so that the value occupies the first cells (e.g. 123***).
+	CombPaddingRight
+)
+
+// padCombText pads `runes` to `maxLen` runes, based on the comb padding of
+// the style. If CombPadRune is not set, the values are padded with zeros.
+func (style AppearanceStyle) padCombText(runes []rune, maxLen int) []rune {
+	n := maxLen - len(runes)
+	if n <= 0 || style.CombPadding == CombPaddingNone {
+		return runes
+	}
+
+	padRune := style.CombPadRune
+	if padRune == 0 {
+		padRune = '0'
+	}
+	pad := []rune(strings.Repeat(string(padRune), n))
+
+	switch style.CombPadding {
+	case CombPaddingLeft:
+		return append(pad, runes...)
+	case CombPaddingRight:
+		return append(runes, pad...)
+	}
+	return runes
+}
+
+// combQuadding returns the quadding of the comb field `ftxt`, showing the
+// value `text`. Numeric values of fields which do not specify (or inherit)
+// a quadding are right justified if the CombAlignNumbersRight option of the
+// style is set.
+func (style AppearanceStyle) combQuadding(ftxt *model.PdfFieldText, text string) int64 {
+	if quadding, has := core.GetIntVal(getQ(ftxt.PdfField)); has {
+		return int64(quadding)
+	}
+	if style.CombAlignNumbersRight && isCombNumber(text) {
+		return 2
+	}
+	return 0
+}
+
+// isCombNumber returns true if `text` represents an amount, which contains
+// digits, optionally along with signs, parentheses (for negative amounts),
+// decimal and grouping separators and currency symbols.
+func isCombNumber(text string) bool {
+	var hasDigit bool
+	for _, r := range text {
+		switch {
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case strings.ContainsRune("+-().,' ", r), unicode.Is(unicode.Sc, r):
+		default:
+			return false
+		}
+	}
+	return hasDigit
+}
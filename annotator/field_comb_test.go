@@ -0,0 +1,90 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+func TestIsCombNumber(t *testing.T) {
+	for _, text := range []string{"123", "-1,234.50", "(12.00)", "$ 5", "1'000 €"} {
+		require.True(t, isCombNumber(text), text)
+	}
+	for _, text := range []string{"", "ABC", "12a", "-.", "€"} {
+		require.False(t, isCombNumber(text), text)
+	}
+}
+
+func TestTextFieldCombPadding(t *testing.T) {
+	// getCells returns the glyphs shown by the comb field appearance and
+	// the indices of the cells containing them.
+	getCells := func(value string, style AppearanceStyle) ([]string, []int) {
+		form, field := newTestTextField(t, value, []float64{0, 0, 100, 20})
+		field.MaxLen = core.MakeInteger(5)
+		field.Q = nil
+		field.SetFlag(model.FieldFlagComb)
+
+		fa := FieldAppearance{}
+		fa.SetStyle(style)
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+
+		var x float64
+		var glyphs []string
+		var cells []int
+		for _, op := range *ops {
+			switch op.Operand {
+			case "Td":
+				tx, err := core.GetNumberAsFloat(op.Params[0])
+				require.NoError(t, err)
+				x += tx
+			case "Tj":
+				str, ok := core.GetString(op.Params[0])
+				require.True(t, ok)
+				glyphs = append(glyphs, str.Str())
+				cells = append(cells, int(x/20))
+			}
+		}
+		return glyphs, cells
+	}
+
+	style := FieldAppearance{}.Style()
+	glyphs, cells := getCells("123", style)
+	require.Equal(t, []string{"1", "2", "3"}, glyphs)
+	require.Equal(t, []int{0, 1, 2}, cells)
+
+	// Numeric values are right justified by default.
+	style.CombAlignNumbersRight = true
+	glyphs, cells = getCells("123", style)
+	require.Equal(t, []string{"1", "2", "3"}, glyphs)
+	require.Equal(t, []int{2, 3, 4}, cells)
+
+	glyphs, cells = getCells("AB", style)
+	require.Equal(t, []string{"A", "B"}, glyphs)
+	require.Equal(t, []int{0, 1}, cells)
+
+	// The value is padded with zeros to the number of cells.
+	style.CombPadding = CombPaddingLeft
+	glyphs, cells = getCells("123", style)
+	require.Equal(t, []string{"0", "0", "1", "2", "3"}, glyphs)
+	require.Equal(t, []int{0, 1, 2, 3, 4}, cells)
+
+	style.CombPadding = CombPaddingRight
+	style.CombPadRune = '*'
+	glyphs, cells = getCells("123", style)
+	require.Equal(t, []string{"1", "2", "3", "*", "*"}, glyphs)
+	require.Equal(t, []int{0, 1, 2, 3, 4}, cells)
+
+	// Values filling the cells are not padded.
+	glyphs, _ = getCells("123456", style)
+	require.Equal(t, []string{"1", "2", "3", "4", "5"}, glyphs)
+}
@@ -0,0 +1,337 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+var (
+	reAFDateFormatEx = regexp.MustCompile(`AFDate_FormatEx\s*\(\s*("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*')\s*\)`)
+	reAFDateFormat   = regexp.MustCompile(`AFDate_Format\s*\(\s*(\d+)\s*\)`)
+	reAFNumberFormat = regexp.MustCompile(`AFNumber_Format\s*\(\s*(\d+)\s*,\s*(\d+)\s*,\s*(\d+)\s*,\s*(\d+)\s*,\s*("(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*')\s*,\s*(true|false)\s*\)`)
+)
+
+// afDateFormats contains the date formats referenced by index by the
+// AFDate_Format JavaScript function.
+var afDateFormats = []string{
+	"m/d", "m/d/yy", "mm/dd/yy", "mm/yy", "d-mmm", "d-mmm-yy", "dd-mmm-yy",
+	"yy-mm-dd", "mmm-yy", "mmmm-yy", "mmm d, yyyy", "mmmm d, yyyy",
+	"m/d/yy h:MM tt", "m/d/yy HH:MM",
+}
+
+// dateValueLayouts contains the layouts used for parsing the values of date
+// fields.
+var dateValueLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"01/02/2006",
+	"1/2/2006",
+}
+
+// FormatValue formats the `value` of the `field` based on the JavaScript
+// format action of the field. Both the date (AFDate_FormatEx, AFDate_Format)
+// and number (AFNumber_Format) format actions are supported. The value is
+// returned unchanged if the field has no supported format action. The
+// function can be used as the FormatFunc of an AppearanceStyle.
+func FormatValue(field *model.PdfField, value string) string {
+	script := getFormatScript(field)
+	if formatted, ok := formatDateScript(script, value); ok {
+		return formatted
+	}
+	if formatted, ok := formatNumberScript(script, value); ok {
+		return formatted
+	}
+	return value
+}
+
+// FormatDate formats the date `value` of the `field` using the format of the
+// AFDate_FormatEx or AFDate_Format JavaScript format action of the field.
+// The value can be specified as a PDF date or using the ISO 8601 layout
+// (e.g. 2006-01-02 or 2006-01-02T15:04:05Z07:00). The value is returned
+// unchanged if the field has no date format action or if it cannot be parsed.
+func FormatDate(field *model.PdfField, value string) string {
+	if formatted, ok := formatDateScript(getFormatScript(field), value); ok {
+		return formatted
+	}
+	return value
+}
+
+// FormatNumber formats the numeric `value` of the `field` using the number
+// of decimals, the thousands separator style, the negative number style and
+// the currency of the AFNumber_Format JavaScript format action of the field.
+// The value is returned unchanged if the field has no number format action
+// or if it is not a number.
+func FormatNumber(field *model.PdfField, value string) string {
+	if formatted, ok := formatNumberScript(getFormatScript(field), value); ok {
+		return formatted
+	}
+	return value
+}
+
+// getFormatScript returns the JavaScript code of the format action (the F
+// entry of the additional actions) of `field` or of its widget annotations.
+func getFormatScript(field *model.PdfField) string {
+	if field == nil {
+		return ""
+	}
+
+	aaObjs := []core.PdfObject{field.AA}
+	for _, wa := range field.Annotations {
+		aaObjs = append(aaObjs, wa.AA)
+	}
+	for _, obj := range aaObjs {
+		aa, ok := core.GetDict(obj)
+		if !ok {
+			continue
+		}
+		action, ok := core.GetDict(aa.Get("F"))
+		if !ok {
+			continue
+		}
+
+		jsObj := action.Get("JS")
+		if str, ok := core.GetString(jsObj); ok {
+			return str.Decoded()
+		}
+		if stream, ok := core.GetStream(jsObj); ok {
+			data, err := core.DecodeStream(stream)
+			if err == nil {
+				return string(data)
+			}
+		}
+	}
+	return ""
+}
+
+// formatDateScript formats the date `value` based on the AFDate format
+// function call of the JavaScript `script`. The returned flag is false if
+// the script does not contain a date format or the value is not a date.
+func formatDateScript(script, value string) (string, bool) {
+	var format string
+	if match := reAFDateFormatEx.FindStringSubmatch(script); match != nil {
+		format = unquoteJS(match[1])
+	} else if match := reAFDateFormat.FindStringSubmatch(script); match != nil {
+		idx, _ := strconv.Atoi(match[1])
+		if idx >= len(afDateFormats) {
+			return "", false
+		}
+		format = afDateFormats[idx]
+	}
+	if format == "" {
+		return "", false
+	}
+
+	t, ok := parseDateValue(value)
+	if !ok {
+		return "", false
+	}
+	return formatAFDate(t, format), true
+}
+
+// parseDateValue parses the date field `value`.
+func parseDateValue(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "D:") {
+		date, err := model.NewPdfDate(value)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return date.ToGoTime(), true
+	}
+
+	for _, layout := range dateValueLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// formatAFDate formats `t` using the AFDate `format`, which consists of
+// repeated sequences of the d, m, y, H, h, M, s and t characters. All other
+// characters of the format are output as they are.
+func formatAFDate(t time.Time, format string) string {
+	var sb strings.Builder
+	runes := []rune(format)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		n := 1
+		for i+n < len(runes) && runes[i+n] == r {
+			n++
+		}
+
+		switch r {
+		case 'd':
+			switch n {
+			case 1:
+				fmt.Fprintf(&sb, "%d", t.Day())
+			case 2:
+				fmt.Fprintf(&sb, "%02d", t.Day())
+			case 3:
+				sb.WriteString(t.Weekday().String()[:3])
+			default:
+				sb.WriteString(t.Weekday().String())
+			}
+		case 'm':
+			switch n {
+			case 1:
+				fmt.Fprintf(&sb, "%d", t.Month())
+			case 2:
+				fmt.Fprintf(&sb, "%02d", t.Month())
+			case 3:
+				sb.WriteString(t.Month().String()[:3])
+			default:
+				sb.WriteString(t.Month().String())
+			}
+		case 'y':
+			if n <= 2 {
+				fmt.Fprintf(&sb, "%02d", t.Year()%100)
+			} else {
+				fmt.Fprintf(&sb, "%04d", t.Year())
+			}
+		case 'H':
+			writeDatePart(&sb, t.Hour(), n)
+		case 'h':
+			hour := t.Hour() % 12
+			if hour == 0 {
+				hour = 12
+			}
+			writeDatePart(&sb, hour, n)
+		case 'M':
+			writeDatePart(&sb, t.Minute(), n)
+		case 's':
+			writeDatePart(&sb, t.Second(), n)
+		case 't':
+			ampm := "am"
+			if t.Hour() >= 12 {
+				ampm = "pm"
+			}
+			if n == 1 {
+				ampm = ampm[:1]
+			}
+			sb.WriteString(ampm)
+		default:
+			sb.WriteString(string(runes[i : i+n]))
+		}
+		i += n
+	}
+	return sb.String()
+}
+
+// writeDatePart writes the date part `value` to `sb`, padded with a leading
+// zero if the part is specified using two or more characters.
+func writeDatePart(sb *strings.Builder, value, n int) {
+	if n == 1 {
+		fmt.Fprintf(sb, "%d", value)
+		return
+	}
+	fmt.Fprintf(sb, "%02d", value)
+}
+
+// formatNumberScript formats the numeric `value` based on the
+// AFNumber_Format function call of the JavaScript `script`. The returned
+// flag is false if the script does not contain a number format or the value
+// is not a number.
+func formatNumberScript(script, value string) (string, bool) {
+	match := reAFNumberFormat.FindStringSubmatch(script)
+	if match == nil {
+		return "", false
+	}
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return "", false
+	}
+
+	decimals, _ := strconv.Atoi(match[1])
+	sepStyle, _ := strconv.Atoi(match[2])
+	negStyle, _ := strconv.Atoi(match[3])
+	currency := unquoteJS(match[5])
+	prepend := match[6] == "true"
+	return formatAFNumber(number, decimals, sepStyle, negStyle, currency, prepend), true
+}
+
+// formatAFNumber formats `number` using the specified number of `decimals`.
+// The separator style `sepStyle` specifies the thousands and decimal
+// separators: 0 - 1,234.56, 1 - 1234.56, 2 - 1.234,56, 3 - 1234,56 and
+// 4 - 1'234.56. Negative numbers are enclosed in parentheses if the negative
+// style `negStyle` is 2 or 3, otherwise they are prefixed by a minus sign.
+// The `currency` symbol is placed before the number if `prepend` is true,
+// otherwise after it.
+func formatAFNumber(number float64, decimals, sepStyle, negStyle int, currency string, prepend bool) string {
+	thousandsSep, decimalSep := ",", "."
+	switch sepStyle {
+	case 1:
+		thousandsSep = ""
+	case 2:
+		thousandsSep, decimalSep = ".", ","
+	case 3:
+		thousandsSep, decimalSep = "", ","
+	case 4:
+		thousandsSep = "'"
+	}
+
+	digits := strconv.FormatFloat(math.Abs(number), 'f', decimals, 64)
+	intPart, fracPart := digits, ""
+	if idx := strings.IndexByte(digits, '.'); idx >= 0 {
+		intPart, fracPart = digits[:idx], digits[idx+1:]
+	}
+
+	var sb strings.Builder
+	for i, r := range intPart {
+		if i > 0 && (len(intPart)-i)%3 == 0 {
+			sb.WriteString(thousandsSep)
+		}
+		sb.WriteRune(r)
+	}
+	if fracPart != "" {
+		sb.WriteString(decimalSep)
+		sb.WriteString(fracPart)
+	}
+
+	text := sb.String()
+	if prepend {
+		text = currency + text
+	} else {
+		text += currency
+	}
+
+	isNegative := number < 0 && strings.Trim(digits, "0.") != ""
+	if !isNegative {
+		return text
+	}
+	if negStyle == 2 || negStyle == 3 {
+		return "(" + text + ")"
+	}
+	return "-" + text
+}
+
+// unquoteJS returns the value of the quoted JavaScript string literal `s`.
+func unquoteJS(s string) string {
+	if len(s) < 2 {
+		return s
+	}
+	if s[0] == '\'' {
+		s = `"` + strings.ReplaceAll(s[1:len(s)-1], `"`, `\"`) + `"`
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		return unquoted
+	}
+	return s[1 : len(s)-1]
+}
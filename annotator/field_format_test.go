@@ -0,0 +1,102 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// newFormatField returns a text field with a format action running the
+// JavaScript `script`.
+func newFormatField(script string) *model.PdfField {
+	action := core.MakeDict()
+	action.Set("S", core.MakeName("JavaScript"))
+	action.Set("JS", core.MakeEncodedString(script, true))
+
+	aa := core.MakeDict()
+	aa.Set("F", action)
+	return &model.PdfField{AA: aa}
+}
+
+func TestFormatDate(t *testing.T) {
+	testCases := []struct {
+		script   string
+		value    string
+		expected string
+	}{
+		{`AFDate_FormatEx("mm/dd/yyyy");`, "2021-03-07", "03/07/2021"},
+		{`AFDate_FormatEx("d mmmm yy");`, "2021-03-07", "7 March 21"},
+		{`AFDate_FormatEx('dddd, mmm d');`, "2021-03-07", "Sunday, Mar 7"},
+		{`AFDate_FormatEx("h:MM tt");`, "2021-03-07T15:04:05Z", "3:04 pm"},
+		{`AFDate_FormatEx("yyyy-mm-dd HH:MM");`, "D:20210307150405Z", "2021-03-07 15:04"},
+		{`AFDate_Format(2);`, "2021-03-07", "03/07/21"},
+		{`AFDate_FormatEx("mm/dd/yyyy");`, "not a date", "not a date"},
+		{`AFNumber_Format(2, 0, 0, 0, "", true);`, "2021-03-07", "2021-03-07"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, FormatDate(newFormatField(tc.script), tc.value), tc.script)
+	}
+	require.Equal(t, "2021-03-07", FormatDate(nil, "2021-03-07"))
+}
+
+func TestFormatNumber(t *testing.T) {
+	testCases := []struct {
+		script   string
+		value    string
+		expected string
+	}{
+		{`AFNumber_Format(2, 0, 0, 0, "", true);`, "1234567.891", "1,234,567.89"},
+		{`AFNumber_Format(0, 0, 0, 0, "", true);`, "999", "999"},
+		{`AFNumber_Format(2, 1, 0, 0, "", true);`, "1234.5", "1234.50"},
+		{`AFNumber_Format(2, 2, 0, 0, " €", false);`, "1234.5", "1.234,50 €"},
+		{`AFNumber_Format(1, 4, 0, 0, "", true);`, "1234567", "1'234'567.0"},
+		{`AFNumber_Format(0, 0, 0, 0, "\u20AC", true);`, "1000", "€1,000"},
+		{`AFNumber_Format(2, 0, 0, 0, "$", true);`, "-1234.5", "-$1,234.50"},
+		{`AFNumber_Format(2, 0, 2, 0, "$", true);`, "-1234.5", "($1,234.50)"},
+		{`AFNumber_Format(0, 0, 2, 0, "", true);`, "-0.2", "0"},
+		{`AFNumber_Format(2, 0, 0, 0, "", true);`, "n/a", "n/a"},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, FormatNumber(newFormatField(tc.script), tc.value), tc.script)
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	require.Equal(t, "03/07/2021", FormatValue(newFormatField(`AFDate_FormatEx("mm/dd/yyyy");`), "2021-03-07"))
+	require.Equal(t, "1,234.00", FormatValue(newFormatField(`AFNumber_Format(2, 0, 0, 0, "", true);`), "1234"))
+	require.Equal(t, "1234", FormatValue(newFormatField(`AFSpecial_Format(0);`), "1234"))
+}
+
+func TestTextFieldFormatFunc(t *testing.T) {
+	form, field := newTestTextField(t, "1234567.5", []float64{0, 0, 200, 20})
+	field.AA = newFormatField(`AFNumber_Format(2, 0, 0, 0, "", true);`).AA
+
+	fa := FieldAppearance{}
+	style := fa.Style()
+	style.FormatFunc = FormatValue
+	fa.SetStyle(style)
+
+	apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops := getAppearanceOps(t, apDict)
+	tjs := findOps(ops, "Tj")
+	require.Len(t, tjs, 1)
+	str, ok := core.GetString(tjs[0].Params[0])
+	require.True(t, ok)
+	require.Equal(t, "1,234,567.50", str.Str())
+
+	// The field value is left unchanged.
+	value, ok := core.GetString(field.V)
+	require.True(t, ok)
+	require.Equal(t, "1234567.5", value.Decoded())
+}
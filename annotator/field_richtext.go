@@ -0,0 +1,550 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// richTextSpan is a run of text of a rich text value (RV) sharing the same
+// style.
+type richTextSpan struct {
+	text          string
+	color         *model.PdfColorDeviceRGB
+	bold          bool
+	italic        bool
+	underline     bool
+	strikethrough bool
+}
+
+// richTextLine is a line of a rich text value, made of styled spans.
+type richTextLine []richTextSpan
+
+// richTextStyle is the style of the rich text value elements, inherited by
+// their children.
+type richTextStyle struct {
+	color         *model.PdfColorDeviceRGB
+	bold          bool
+	italic        bool
+	underline     bool
+	strikethrough bool
+}
+
+// std14FontFamilies contains the regular, bold, italic and bold italic
+// members of the standard 14 font families.
+var std14FontFamilies = [][4]model.StdFontName{
+	{model.HelveticaName, model.HelveticaBoldName, model.HelveticaObliqueName, model.HelveticaBoldObliqueName},
+	{model.TimesRomanName, model.TimesBoldName, model.TimesItalicName, model.TimesBoldItalicName},
+	{model.CourierName, model.CourierBoldName, model.CourierObliqueName, model.CourierBoldObliqueName},
+}
+
+// richTextLines returns the lines of the rich text value (RV) of the text
+// field `ftxt`. Returns nil if the field does not have the RichText flag set,
+// has no rich text value, is a password field, or if the value cannot be
+// parsed, in which case the plain text value (V) of the field is used.
+func richTextLines(ftxt *model.PdfFieldText) []richTextLine {
+	flags := ftxt.Flags()
+	if !flags.Has(model.FieldFlagRichText) || flags.Has(model.FieldFlagPassword) {
+		return nil
+	}
+
+	var rv string
+	if str, ok := core.GetString(ftxt.RV); ok {
+		rv = str.Decoded()
+	} else if stream, ok := core.GetStream(ftxt.RV); ok {
+		data, err := core.DecodeStream(stream)
+		if err != nil {
+			common.Log.Debug("ERROR: unable to decode rich text value: %v", err)
+			return nil
+		}
+		rv = string(data)
+	}
+	if strings.TrimSpace(rv) == "" {
+		return nil
+	}
+
+	lines, err := parseRichText(rv)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to parse rich text value, using plain text: %v", err)
+		return nil
+	}
+	return lines
+}
+
+// parseRichText parses the XHTML rich text value `rv` into lines of styled
+// spans. Paragraphs (p and div elements) and br elements start new lines.
+// The color, font-weight, font-style and text-decoration properties of the
+// style attributes are honored, along with the b, strong, i, em, u, s,
+// strike and del elements and the color attribute of font elements.
+// Returns an error if the value is not valid XML or contains no text.
+func parseRichText(rv string) ([]richTextLine, error) {
+	decoder := xml.NewDecoder(strings.NewReader(rv))
+	decoder.Strict = false
+	decoder.AutoClose = xml.HTMLAutoClose
+	decoder.Entity = xml.HTMLEntity
+
+	lines := []richTextLine{nil}
+	breakLine := func() {
+		if len(lines[len(lines)-1]) > 0 {
+			lines = append(lines, nil)
+		}
+	}
+
+	stack := []richTextStyle{{}}
+	hasText := false
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			style := stack[len(stack)-1]
+			switch strings.ToLower(t.Name.Local) {
+			case "p", "div":
+				breakLine()
+			case "br":
+				lines = append(lines, nil)
+			case "b", "strong":
+				style.bold = true
+			case "i", "em":
+				style.italic = true
+			case "u":
+				style.underline = true
+			case "s", "strike", "del":
+				style.strikethrough = true
+			}
+			for _, attr := range t.Attr {
+				switch strings.ToLower(attr.Name.Local) {
+				case "style":
+					style = parseRichTextStyle(attr.Value, style)
+				case "color":
+					if color, ok := parseRichTextColor(attr.Value); ok {
+						style.color = color
+					}
+				}
+			}
+			stack = append(stack, style)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+			switch strings.ToLower(t.Name.Local) {
+			case "p", "div":
+				breakLine()
+			}
+		case xml.CharData:
+			text := string(t)
+			// Skip the whitespace used for indenting the markup.
+			if strings.TrimSpace(text) == "" && strings.ContainsAny(text, "\r\n") {
+				continue
+			}
+			text = strings.Replace(text, "\r\n", "\n", -1)
+			text = strings.Replace(text, "\r", "\n", -1)
+
+			style := stack[len(stack)-1]
+			for i, part := range strings.Split(text, "\n") {
+				if i > 0 {
+					lines = append(lines, nil)
+				}
+				if part == "" {
+					continue
+				}
+				lines[len(lines)-1] = append(lines[len(lines)-1], richTextSpan{
+					text:          part,
+					color:         style.color,
+					bold:          style.bold,
+					italic:        style.italic,
+					underline:     style.underline,
+					strikethrough: style.strikethrough,
+				})
+				hasText = true
+			}
+		}
+	}
+	if !hasText {
+		return nil, errors.New("no text")
+	}
+
+	// Remove the empty line following the last paragraph.
+	if len(lines) > 1 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, nil
+}
+
+// parseRichTextStyle returns `style` updated with the properties of the CSS
+// declarations of the style attribute `attr`.
+func parseRichTextStyle(attr string, style richTextStyle) richTextStyle {
+	for _, decl := range strings.Split(attr, ";") {
+		parts := strings.SplitN(decl, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.ToLower(strings.TrimSpace(parts[1]))
+		switch strings.ToLower(strings.TrimSpace(parts[0])) {
+		case "color":
+			if color, ok := parseRichTextColor(value); ok {
+				style.color = color
+			}
+		case "font-weight":
+			if weight, err := strconv.Atoi(value); err == nil {
+				style.bold = weight >= 600
+			} else {
+				style.bold = value == "bold" || value == "bolder"
+			}
+		case "font-style":
+			style.italic = value == "italic" || value == "oblique"
+		case "text-decoration", "text-decoration-line":
+			style.underline = strings.Contains(value, "underline")
+			style.strikethrough = strings.Contains(value, "line-through")
+		}
+	}
+	return style
+}
+
+// parseRichTextColor parses the CSS color `value`, specified as #rrggbb,
+// #rgb or rgb(r, g, b).
+func parseRichTextColor(value string) (*model.PdfColorDeviceRGB, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) != 6 {
+			return nil, false
+		}
+		rgb, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return nil, false
+		}
+		return model.NewPdfColorDeviceRGB(
+			float64(rgb>>16&0xff)/255, float64(rgb>>8&0xff)/255, float64(rgb&0xff)/255), true
+	}
+
+	if strings.HasPrefix(value, "rgb(") && strings.HasSuffix(value, ")") {
+		parts := strings.Split(value[4:len(value)-1], ",")
+		if len(parts) != 3 {
+			return nil, false
+		}
+		var rgb [3]float64
+		for i, part := range parts {
+			c, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+			if err != nil {
+				return nil, false
+			}
+			rgb[i] = math.Max(0, math.Min(c, 255)) / 255
+		}
+		return model.NewPdfColorDeviceRGB(rgb[0], rgb[1], rgb[2]), true
+	}
+	return nil, false
+}
+
+// richTextFont returns the member of the font family of `apFont` matching the
+// `bold` and `italic` styles of rich text, along with the name of the font in
+// the appearance resources. The family members are the Bold, Italic and
+// BoldItalic fonts of `apFont`, or the members of the standard 14 font family
+// of the font. The font of `apFont` is returned if the member is not
+// available.
+func richTextFont(apFont *AppearanceFont, bold, italic bool) (string, *model.PdfFont) {
+	if !bold && !italic {
+		return apFont.Name, apFont.Font
+	}
+
+	var font *model.PdfFont
+	var suffix string
+	switch {
+	case bold && italic:
+		font, suffix = apFont.BoldItalic, "BI"
+	case bold:
+		font, suffix = apFont.Bold, "B"
+	default:
+		font, suffix = apFont.Italic, "I"
+	}
+	if font != nil {
+		return apFont.Name + suffix, font
+	}
+
+	// Look up the standard 14 font family of the font.
+	basefont := model.StdFontName(apFont.Font.BaseFont())
+	for _, family := range std14FontFamilies {
+		for i, name := range family {
+			if name != basefont {
+				continue
+			}
+			if bold {
+				i |= 1
+			}
+			if italic {
+				i |= 2
+			}
+			if font, err := model.NewStandard14Font(family[i]); err == nil {
+				return apFont.Name + suffix, font
+			}
+		}
+	}
+	return apFont.Name, apFont.Font
+}
+
+// richTextPiece is a part of a rich text span, laid out on a single line.
+type richTextPiece struct {
+	span     *richTextSpan
+	text     string
+	fontName string
+	font     *model.PdfFont
+}
+
+// addRichText adds the rich text `lines` of the text field `ftxt` to the
+// field appearance content `cc`, within a text object, using the family of
+// the appearance font `apFont` at the specified `fontsize`, or an autosized
+// font size. The fonts used by the text are added to `resources`. The
+// default appearance operands `daOps` are used for restoring the color of
+// the text following colored spans. The text is laid out in the area of the
+// appearance of the specified `width` and `height`, based on the text
+// margins, the multiline flag and the quadding of the field. The lines of
+// multiline fields are wrapped based on the wrap mode of the style, unless
+// autosized. Returns the underline and strikethrough lines of the spans,
+// which are drawn after the text object.
+func (style AppearanceStyle) addRichText(cc *contentstream.ContentCreator, resources *model.PdfPageResources,
+	ftxt *model.PdfFieldText, apFont *AppearanceFont, daOps *contentstream.ContentStreamOperations,
+	lines []richTextLine, fontsize float64, autosize bool, width, height float64) []*model.PdfRectangle {
+	isMultiline := ftxt.Flags().Has(model.FieldFlagMultiline)
+	if !isMultiline && len(lines) > 1 {
+		// Single line fields show the lines separated by spaces.
+		var joined richTextLine
+		for i, line := range lines {
+			if i > 0 && len(line) > 0 {
+				joined = append(joined, richTextSpan{text: " ", color: line[0].color})
+			}
+			joined = append(joined, line...)
+		}
+		lines = []richTextLine{joined}
+	}
+
+	boxLeft, boxRight, tx := style.textBox(width)
+	boxBottom, boxTop := style.TextMarginBottom, height-style.TextMarginTop
+
+	// lineWidth returns the width of `pieces` in glyph space units.
+	lineWidth := func(pieces []richTextPiece, fontsize float64) float64 {
+		var w float64
+		for _, piece := range pieces {
+			w += style.spacedTextWidth(piece.font, piece.text, fontsize)
+		}
+		return w
+	}
+
+	mode := style.WrapMode
+	if mode == WrapModeDefault {
+		mode = WrapModeWords
+	}
+	wrap := isMultiline && !autosize && fontsize > 0 && mode != WrapModeNone
+
+	// Lay out the lines into pieces, made of the parts of the spans shown on
+	// each wrapped line, using the fonts of the spans.
+	fontNames := map[string]*model.PdfFont{}
+	var pieceLines [][]richTextPiece
+	for _, line := range lines {
+		spanPieces := make([]richTextPiece, len(line))
+		var runes []rune
+		var runeSpans []int
+		for i := range line {
+			name, font := richTextFont(apFont, line[i].bold, line[i].italic)
+			fontNames[name] = font
+			spanPieces[i] = richTextPiece{span: &line[i], fontName: name, font: font}
+			for _, r := range line[i].text {
+				runes = append(runes, r)
+				runeSpans = append(runeSpans, i)
+			}
+		}
+
+		// pieces returns the pieces of the runes [start, end) of the line,
+		// followed by a hyphen if `hyphen` is set.
+		pieces := func(start, end int, hyphen bool) []richTextPiece {
+			var pieces []richTextPiece
+			for i := start; i < end; i++ {
+				piece := spanPieces[runeSpans[i]]
+				if n := len(pieces); n == 0 || pieces[n-1].span != piece.span {
+					pieces = append(pieces, piece)
+				}
+				pieces[len(pieces)-1].text += string(runes[i])
+			}
+			if n := len(pieces); hyphen && n > 0 {
+				pieces[n-1].text += "-"
+			}
+			return pieces
+		}
+
+		ranges := []lineRange{{start: 0, end: len(runes)}}
+		if wrap {
+			width := func(start, end int, hyphen bool) float64 {
+				return lineWidth(pieces(start, end, hyphen), fontsize)
+			}
+			ranges = style.breakLine(runes, 1000*(boxRight-tx)/fontsize, mode, width)
+		}
+		for _, r := range ranges {
+			linePieces := pieces(r.start, r.end, r.hyphen)
+			// Trim the trailing spaces of the line.
+			if n := len(linePieces); n > 0 {
+				linePieces[n-1].text = strings.TrimRight(linePieces[n-1].text, " ")
+			}
+			pieceLines = append(pieceLines, linePieces)
+		}
+	}
+	for name, font := range fontNames {
+		if name != apFont.Name && resources != nil {
+			resources.SetFontByName(*core.MakeName(name), font.ToPdfObject())
+		}
+	}
+
+	maxLinewidth := 0.0
+	for _, pieces := range pieceLines {
+		if w := lineWidth(pieces, fontsize); w > maxLinewidth {
+			maxLinewidth = w
+		}
+	}
+	if fontsize == 0 || autosize && maxLinewidth > 0 && tx+maxLinewidth*fontsize/1000.0 > boxRight {
+		if maxLinewidth > 0 {
+			fontsize = 0.95 * 1000.0 * (boxRight - tx) / maxLinewidth
+		}
+	}
+
+	lh := style.MultilineLineHeight
+	numLines := float64(len(pieceLines))
+	lineheight := fontsize
+	if isMultiline && numLines > 1 {
+		lineheight = lh * fontsize
+	}
+	if autosize && numLines*lineheight > boxTop-boxBottom {
+		fontsize = 0.95 * (boxTop - boxBottom) / numLines
+		lineheight = fontsize
+		if isMultiline && numLines > 1 {
+			lineheight = lh * fontsize
+		}
+	}
+	if fontsize <= 0 {
+		return nil
+	}
+
+	// Vertical alignment.
+	fdescriptor, _ := apFont.Font.GetFontDescriptor()
+	capheight := 1000.0
+	if fdescriptor != nil {
+		if c, err := fdescriptor.GetCapHeight(); err == nil && c > 0 {
+			capheight = c
+		}
+	}
+	capheight = capheight / 1000.0 * fontsize
+
+	ty := 0.0
+	textheight := numLines * lineheight
+	if boxTop-boxBottom > textheight {
+		if isMultiline {
+			if style.MultilineVAlignMiddle {
+				ty = boxBottom + (boxTop-boxBottom-textheight)/2.0 + textheight - lineheight
+			} else {
+				ty = boxTop - lineheight - fontsize*0.5
+			}
+		} else {
+			ty = centeredBaseline(fdescriptor, fontsize, capheight, boxBottom, boxTop)
+		}
+	}
+
+	// The color operands of the default appearance, which set the color of
+	// the spans with no color.
+	var daColorOps []*contentstream.ContentStreamOperation
+	if daOps != nil {
+		for _, op := range *daOps {
+			switch op.Operand {
+			case "g", "rg", "k", "cs", "sc", "scn":
+				daColorOps = append(daColorOps, op)
+			}
+		}
+	}
+
+	alignment := getQuadding(ftxt.PdfField)
+	var curFont string
+	var curColor *model.PdfColorDeviceRGB
+	var decorations []*model.PdfRectangle
+	x, y := 0.0, 0.0
+	for i, pieces := range pieceLines {
+		remaining := boxRight - boxLeft - lineWidth(pieces, fontsize)/1000.0*fontsize
+		xnew := tx
+		switch style.lineAlignment(alignment, i) {
+		case QuaddingCenter:
+			xnew = boxLeft + remaining/2
+		case QuaddingRight:
+			xnew = boxLeft + remaining
+		}
+		cc.Add_Td(xnew-x, ty-y)
+		x, y = xnew, ty
+		ty -= lineheight
+		pieceX := x
+
+		for _, piece := range pieces {
+			if piece.fontName != curFont {
+				cc.Add_Tf(*core.MakeName(piece.fontName), fontsize)
+				if curFont == "" {
+					style.addTextSpacing(cc)
+				}
+				curFont = piece.fontName
+			}
+			if piece.span.color != curColor {
+				if piece.span.color != nil {
+					cc.Add_rg(piece.span.color.R(), piece.span.color.G(), piece.span.color.B())
+				} else {
+					for _, op := range daColorOps {
+						cc.AddOperand(*op)
+					}
+				}
+				curColor = piece.span.color
+			}
+
+			encoder := piece.font.Encoder()
+			if encoder == nil {
+				common.Log.Debug("WARN: font encoder is nil. Skipping rich text")
+				continue
+			}
+			if style.Kerning {
+				addKernedText(cc, piece.font, encoder, piece.text)
+			} else {
+				cc.Add_Tj(*core.MakeStringFromBytes(encoder.Encode(piece.text)))
+			}
+
+			// Underline and strike through the piece.
+			pieceWidth := style.spacedTextWidth(piece.font, piece.text, fontsize) / 1000.0 * fontsize
+			underlinePos, strikethroughPos, thickness := textDecorationMetrics(piece.font, fontsize)
+			if pieceWidth > 0 && (style.Underline || piece.span.underline) {
+				decorations = append(decorations, &model.PdfRectangle{
+					Llx: pieceX, Lly: y + underlinePos - thickness/2,
+					Urx: pieceX + pieceWidth, Ury: y + underlinePos + thickness/2,
+				})
+			}
+			if pieceWidth > 0 && (style.Strikethrough || piece.span.strikethrough) {
+				decorations = append(decorations, &model.PdfRectangle{
+					Llx: pieceX, Lly: y + strikethroughPos - thickness/2,
+					Urx: pieceX + pieceWidth, Ury: y + strikethroughPos + thickness/2,
+				})
+			}
+			pieceX += pieceWidth
+		}
+	}
+	return decorations
+}
@@ -0,0 +1,177 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+func TestParseRichText(t *testing.T) {
+	rv := `<?xml version="1.0"?>
+<body xmlns="http://www.w3.org/1999/xhtml" xfa:APIVersion="Acrobat:11.0.0">
+<p>Plain <span style="color:#FF0000;font-weight:bold">red bold</span></p>
+<p><i>italic</i><br/><span style="font-style:italic;font-weight:700;color:rgb(0,0,255)">both</span> &amp; more</p>
+</body>`
+	lines, err := parseRichText(rv)
+	require.NoError(t, err)
+
+	red := model.NewPdfColorDeviceRGB(1, 0, 0)
+	blue := model.NewPdfColorDeviceRGB(0, 0, 1)
+	expected := []richTextLine{
+		{{text: "Plain "}, {text: "red bold", color: red, bold: true}},
+		{{text: "italic", italic: true}},
+		{{text: "both", color: blue, bold: true, italic: true}, {text: " & more"}},
+	}
+	require.Equal(t, expected, lines)
+
+	// Text decorations.
+	lines, err = parseRichText(`<body><p><u>under</u> <span style="text-decoration:line-through">struck</span></p></body>`)
+	require.NoError(t, err)
+	require.Equal(t, []richTextLine{
+		{{text: "under", underline: true}, {text: " "}, {text: "struck", strikethrough: true}},
+	}, lines)
+
+	_, err = parseRichText(`<body><p>`)
+	require.Error(t, err)
+	_, err = parseRichText(`<body><p></p></body>`)
+	require.Error(t, err)
+}
+
+func TestTextFieldRichText(t *testing.T) {
+	form, field := newTestTextField(t, "Hello World", []float64{0, 0, 200, 20})
+	field.DA = core.MakeString("/Helv 12 Tf 0 g")
+	field.SetFlag(model.FieldFlagRichText)
+	field.RV = core.MakeString(`<body><p>Hello <span style="color:#ff0000;font-weight:bold">World</span></p></body>`)
+
+	apDict, err := FieldAppearance{}.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	xform, ops := getAppearanceOps(t, apDict)
+
+	// The bold span uses the bold member of the Helvetica family.
+	var fonts []string
+	for _, op := range findOps(ops, "Tf") {
+		name, ok := core.GetNameVal(op.Params[0])
+		require.True(t, ok)
+		fonts = append(fonts, name)
+	}
+	require.Equal(t, []string{"Helv", "HelvB"}, fonts)
+	fontObj, ok := xform.Resources.GetFontByName("HelvB")
+	require.True(t, ok)
+	font, err := model.NewPdfFontFromPdfObject(fontObj)
+	require.NoError(t, err)
+	require.Equal(t, "Helvetica-Bold", font.BaseFont())
+
+	var texts []string
+	for _, op := range findOps(ops, "Tj") {
+		str, ok := core.GetString(op.Params[0])
+		require.True(t, ok)
+		texts = append(texts, str.Str())
+	}
+	require.Equal(t, []string{"Hello ", "World"}, texts)
+
+	rgs := findOps(ops, "rg")
+	require.Len(t, rgs, 1)
+	vals, err := core.GetNumbersAsFloat(rgs[0].Params)
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 0, 0}, vals)
+
+	// Invalid rich text values fall back to the plain text value.
+	field.RV = core.MakeString(`<body><p>Hello <span`)
+	apDict, err = FieldAppearance{}.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+	require.NoError(t, err)
+	_, ops = getAppearanceOps(t, apDict)
+	tjs := findOps(ops, "Tj")
+	require.Len(t, tjs, 1)
+	str, ok := core.GetString(tjs[0].Params[0])
+	require.True(t, ok)
+	require.Equal(t, "Hello World", str.Str())
+}
+
+func TestTextFieldRichTextDecorations(t *testing.T) {
+	form, field := newTestTextField(t, "Hello World", []float64{0, 0, 200, 20})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+	field.SetFlag(model.FieldFlagRichText)
+	field.RV = core.MakeString(`<body><p>Hello <span style="text-decoration:underline">World</span></p></body>`)
+
+	// getDecorations returns the rectangles of the decoration lines.
+	getDecorations := func(fa FieldAppearance) [][]float64 {
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+		var rects [][]float64
+		for _, op := range findOps(ops, "re") {
+			vals, err := core.GetNumbersAsFloat(op.Params)
+			require.NoError(t, err)
+			rects = append(rects, vals)
+		}
+		return rects
+	}
+
+	font, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+	style := FieldAppearance{}.Style()
+	helloWidth := style.textWidth(font, "Hello ") / 100
+	worldWidth := style.textWidth(font, "World") / 100
+
+	// Only the underlined span is underlined.
+	fa := FieldAppearance{}
+	rects := getDecorations(fa)
+	require.Len(t, rects, 1)
+	require.InDelta(t, 2+helloWidth, rects[0][0], 1e-6)
+	require.InDelta(t, worldWidth, rects[0][2], 1e-6)
+
+	// The decorations of the style apply to all the spans.
+	style.Strikethrough = true
+	fa.SetStyle(style)
+	rects = getDecorations(fa)
+	require.Len(t, rects, 3)
+	require.InDelta(t, 2, rects[0][0], 1e-6)
+	require.InDelta(t, helloWidth, rects[0][2], 1e-6)
+}
+
+func TestTextFieldRichTextWrapChars(t *testing.T) {
+	form, field := newTestTextField(t, "", []float64{0, 0, 50, 100})
+	field.DA = core.MakeString("/Helv 10 Tf 0 g")
+	field.SetFlag(model.FieldFlagRichText | model.FieldFlagMultiline)
+	field.RV = core.MakeString(`<body><p>AAAAA<b>BBBBBBBB</b></p></body>`)
+
+	// getLines returns the text shown on each line.
+	getLines := func(fa FieldAppearance) []string {
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+		var lines []string
+		for _, op := range *ops {
+			switch op.Operand {
+			case "Td":
+				lines = append(lines, "")
+			case "Tj":
+				str, ok := core.GetString(op.Params[0])
+				require.True(t, ok)
+				lines[len(lines)-1] += str.Str()
+			}
+		}
+		return lines
+	}
+
+	// The word wider than the field overflows it by default.
+	fa := FieldAppearance{}
+	require.Equal(t, []string{"AAAAABBBBBBBB"}, getLines(fa))
+
+	// The word is broken between characters, across the spans.
+	style := fa.Style()
+	style.WrapMode = WrapModeChars
+	fa.SetStyle(style)
+	lines := getLines(fa)
+	require.Greater(t, len(lines), 1)
+	require.Equal(t, "AAAAABBBBBBBB", strings.Join(lines, ""))
+}
@@ -0,0 +1,199 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// WrapMode represents the mode used for wrapping the contents of multiline
+// text fields which do not fit the width of the field.
+type WrapMode int
+
+const (
+	// WrapModeDefault wraps the lines at spaces, only if the font size of
+	// the field is not autosized. Autosized text is shrunk to fit the field.
+	WrapModeDefault WrapMode = iota
+
+	// WrapModeNone disables wrapping. Lines which do not fit the width of the
+	// field overflow it, unless the text is autosized.
+	WrapModeNone
+
+	// WrapModeWords wraps the lines at spaces. Words which are wider than the
+	// field overflow it, unless they can be hyphenated.
+	WrapModeWords
+
+	// WrapModeChars wraps the lines at spaces and breaks the words which are
+	// wider than the field between characters (e.g. long URLs or IBANs).
+	WrapModeChars
+)
+
+// splitLines splits `text` into lines at the line breaks, which are either
+// CR, LF or CRLF sequences.
+func splitLines(text string) []string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	text = strings.Replace(text, "\r", "\n", -1)
+	return strings.Split(text, "\n")
+}
+
+// wrapLines wraps the multiline text field `lines` to the width `maxWidth`,
+// in glyph space units, based on the wrap mode of the style. The lines are
+// measured using `font` at the specified `fontsize`, which determines the
+// width of the text spacing of the style. The returned flag is true if the
+// lines are wrapped, regardless of whether any of them was broken.
+func (style AppearanceStyle) wrapLines(font *model.PdfFont, fontsize float64, lines []string, maxWidth float64, autosize bool) ([]string, bool) {
+	mode := style.WrapMode
+	if mode == WrapModeDefault {
+		if autosize {
+			return lines, false
+		}
+		mode = WrapModeWords
+	}
+	if mode == WrapModeNone || maxWidth <= 0 {
+		return lines, false
+	}
+
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, style.wrapLine(font, fontsize, line, maxWidth, mode)...)
+	}
+	return wrapped, true
+}
+
+// wrapLine breaks `line` into lines which fit the width `maxWidth`, in glyph
+// space units, at the specified `fontsize`, using the specified wrap mode. Lines are broken at spaces, by
+// hyphenating the words using the Hyphenate function of the style, if set,
+// or between characters in the WrapModeChars mode.
+func (style AppearanceStyle) wrapLine(font *model.PdfFont, fontsize float64, line string, maxWidth float64, mode WrapMode) []string {
+	runes := []rune(line)
+	text := func(start, end int, hyphen bool) string {
+		if hyphen {
+			return string(runes[start:end]) + "-"
+		}
+		return string(runes[start:end])
+	}
+	width := func(start, end int, hyphen bool) float64 {
+		return style.spacedTextWidth(font, text(start, end, hyphen), fontsize)
+	}
+
+	var wrapped []string
+	for _, r := range style.breakLine(runes, maxWidth, mode, width) {
+		wrapped = append(wrapped, text(r.start, r.end, r.hyphen))
+	}
+	return wrapped
+}
+
+// lineRange is a line of wrapped text, made of the runes [start, end) of the
+// wrapped line, followed by a hyphen if `hyphen` is set.
+type lineRange struct {
+	start, end int
+	hyphen     bool
+}
+
+// breakLine breaks the runes of `line` into lines which fit the width
+// `maxWidth`, using the specified wrap mode, as described by wrapLine. The
+// lines are measured using `width`, which returns the width of the runes
+// [start, end) of the line, followed by a hyphen if `hyphen` is set. This
+// allows measuring lines made of runs of different fonts (e.g. rich text).
+func (style AppearanceStyle) breakLine(line []rune, maxWidth float64, mode WrapMode,
+	width func(start, end int, hyphen bool) float64) []lineRange {
+	var wrapped []lineRange
+	pos := 0
+	for pos < len(line) && width(pos, len(line), false) > maxWidth {
+		// Number of runes fitting the width.
+		n := 0
+		for pos+n < len(line) && width(pos, pos+n+1, false) <= maxWidth {
+			n++
+		}
+
+		// Last space at which the line can be broken.
+		brk := -1
+		for i := pos + n; i > pos; i-- {
+			if i < len(line) && line[i] == ' ' {
+				brk = i
+				break
+			}
+		}
+
+		// Hyphenate the word crossing the width of the field.
+		wordStart := pos
+		if brk >= 0 {
+			wordStart = brk + 1
+		}
+		if p := style.hyphenationPoint(line, pos, wordStart, maxWidth, width); p > 0 {
+			wrapped = append(wrapped, lineRange{start: pos, end: wordStart + p, hyphen: true})
+			pos = wordStart + p
+			continue
+		}
+
+		if brk >= 0 {
+			end := brk
+			for end > pos && line[end-1] == ' ' {
+				end--
+			}
+			wrapped = append(wrapped, lineRange{start: pos, end: end})
+			pos = brk + 1
+			continue
+		}
+
+		// The first word of the line is wider than the field.
+		if mode == WrapModeChars {
+			if n == 0 {
+				n = 1
+			}
+			wrapped = append(wrapped, lineRange{start: pos, end: pos + n})
+			pos += n
+			continue
+		}
+
+		end := wordEnd(line, pos)
+		if end == len(line) {
+			break
+		}
+		wrapped = append(wrapped, lineRange{start: pos, end: end})
+		pos = end + 1
+	}
+	return append(wrapped, lineRange{start: pos, end: len(line)})
+}
+
+// hyphenationPoint returns the offset (in runes) of the last hyphenation
+// point of the word starting at index `start` of `line`, for which the
+// hyphenated text, starting at index `lineStart`, fits the width `maxWidth`
+// as measured by `width`. Returns 0 if the style has no hyphenation
+// function or if the word cannot be hyphenated to fit the width.
+func (style AppearanceStyle) hyphenationPoint(line []rune, lineStart, start int, maxWidth float64,
+	width func(start, end int, hyphen bool) float64) int {
+	if style.Hyphenate == nil || start >= len(line) {
+		return 0
+	}
+	word := line[start:wordEnd(line, start)]
+
+	points := style.Hyphenate(string(word))
+	sort.Sort(sort.Reverse(sort.IntSlice(points)))
+	for _, p := range points {
+		if p <= 0 || p >= len(word) {
+			continue
+		}
+		if width(lineStart, start+p, true) <= maxWidth {
+			return p
+		}
+	}
+	return 0
+}
+
+// wordEnd returns the index of the first space of `runes` following the
+// index `start`, or the length of `runes` if there is none.
+func wordEnd(runes []rune, start int) int {
+	for i := start; i < len(runes); i++ {
+		if runes[i] == ' ' {
+			return i
+		}
+	}
+	return len(runes)
+}
@@ -0,0 +1,123 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+func TestWrapLines(t *testing.T) {
+	// The glyphs of the Courier font are 600 units wide, so that 5 glyphs
+	// fit the width used by the test cases.
+	courier, err := model.NewStandard14Font(model.CourierName)
+	require.NoError(t, err)
+
+	// hyphenate allows hyphenating words every 4 runes.
+	hyphenate := func(word string) []int {
+		var points []int
+		for i := 4; i < len([]rune(word)); i += 4 {
+			points = append(points, i)
+		}
+		return points
+	}
+
+	testCases := []struct {
+		name      string
+		mode      WrapMode
+		hyphenate func(string) []int
+		autosize  bool
+		lines     []string
+		expected  []string
+	}{
+		{
+			name:     "Default",
+			lines:    []string{"aaa bbbbbbbbbb cc", "d"},
+			expected: []string{"aaa", "bbbbbbbbbb", "cc", "d"},
+		},
+		{
+			name:     "DefaultAutosize",
+			autosize: true,
+			lines:    []string{"aaa bbbbbbbbbb cc"},
+			expected: []string{"aaa bbbbbbbbbb cc"},
+		},
+		{
+			name:     "None",
+			mode:     WrapModeNone,
+			lines:    []string{"aaa bbbbbbbbbb cc"},
+			expected: []string{"aaa bbbbbbbbbb cc"},
+		},
+		{
+			name:     "Words",
+			mode:     WrapModeWords,
+			autosize: true,
+			lines:    []string{"aa bb cc dd", "eeeeeeeee"},
+			expected: []string{"aa bb", "cc dd", "eeeeeeeee"},
+		},
+		{
+			name:     "Chars",
+			mode:     WrapModeChars,
+			lines:    []string{"aaa bbbbbbbbbb cc"},
+			expected: []string{"aaa", "bbbbb", "bbbbb", "cc"},
+		},
+		{
+			name:      "Hyphenate",
+			mode:      WrapModeWords,
+			hyphenate: hyphenate,
+			lines:     []string{"an hyphenation"},
+			expected:  []string{"an", "hyph-", "enat-", "ion"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			style := AppearanceStyle{WrapMode: tc.mode, Hyphenate: tc.hyphenate}
+			lines, _ := style.wrapLines(courier, 10, tc.lines, 3000, tc.autosize)
+			require.Equal(t, tc.expected, lines)
+		})
+	}
+}
+
+func TestTextFieldWrapMode(t *testing.T) {
+	const url = "https://example.com/a/very/long/path/to/the/resource"
+	form, field := newTestTextField(t, url, []float64{0, 0, 100, 60})
+	field.DA = core.MakeString("/Helv 0 Tf 0 g")
+	field.SetFlag(model.FieldFlagMultiline)
+
+	// getLines returns the lines of text shown by the field appearance.
+	getLines := func(mode WrapMode) []string {
+		fa := FieldAppearance{}
+		style := fa.Style()
+		style.WrapMode = mode
+		fa.SetStyle(style)
+
+		apDict, err := fa.GenerateAppearanceDict(form, field.PdfField, field.Annotations[0])
+		require.NoError(t, err)
+		_, ops := getAppearanceOps(t, apDict)
+
+		var lines []string
+		for _, op := range findOps(ops, "Tj") {
+			str, ok := core.GetString(op.Params[0])
+			require.True(t, ok)
+			lines = append(lines, str.Str())
+		}
+		return lines
+	}
+
+	// Autosized text is not wrapped by default.
+	require.Equal(t, []string{url}, getLines(WrapModeDefault))
+	require.Equal(t, []string{url}, getLines(WrapModeWords))
+
+	// The unbroken text is wrapped between characters.
+	lines := getLines(WrapModeChars)
+	require.Greater(t, len(lines), 1)
+	require.Equal(t, url, strings.Join(lines, ""))
+}
@@ -207,6 +207,8 @@ func NewComboboxField(page *model.PdfPage, name string, rect []float64, opt Comb
 }
 
 // SignatureLine represents a line of information in the signature field appearance.
+// The Text can contain newlines, which separate the rendered lines. The lines
+// wider than the signature annotation rectangle are wrapped at spaces.
 type SignatureLine struct {
 	Desc string
 	Text string
@@ -251,8 +253,63 @@ type SignatureFieldOpts struct {
 
 	// BorderColor represents the border color of the appearance annotation area.
 	BorderColor model.PdfColor
+
+	// BackgroundImage is an image (e.g. a logo) drawn behind the text
+	// content of the appearance, over the fill color.
+	BackgroundImage *model.XObjectImage
+
+	// BackgroundImagePlacement specifies how the background image is
+	// placed in the appearance annotation area.
+	BackgroundImagePlacement SignatureImagePlacement
+
+	// BackgroundImageScale scales the size of the background image. For the
+	// centered and tiled placements, the image size is given by its
+	// dimensions in pixels, interpreted as points. A value of 0 is
+	// equivalent to 1.
+	BackgroundImageScale float64
+
+	// DrawPlaceholder draws a placeholder in the appearance of unsigned
+	// signature fields, which have no signature lines, so that the area to
+	// be signed is visible (e.g. before flattening the form). The placeholder
+	// is a box labeled with the PlaceholderText ("Sign here" if not set),
+	// crossed by a diagonal line if PlaceholderDiagonal is set.
+	DrawPlaceholder     bool
+	PlaceholderText     string
+	PlaceholderDiagonal bool
+
+	// PlaceholderBorderSize, PlaceholderBorderColor and PlaceholderBorderDash
+	// specify the line width (1 if not set), the color (gray if not set) and
+	// the dash pattern (solid if not set) of the placeholder box and of its
+	// diagonal line.
+	PlaceholderBorderSize  float64
+	PlaceholderBorderColor model.PdfColor
+	PlaceholderBorderDash  []int64
+
+	// PostProcess, if set, is called with the content stream operations of
+	// the generated signature appearance (or placeholder appearance) before
+	// the content stream is set. See AppearanceStyle.PostProcess.
+	PostProcess func(ops *contentstream.ContentStreamOperations) error
 }
 
+// SignatureImagePlacement represents the placement of the background image
+// of signature appearances.
+type SignatureImagePlacement int
+
+const (
+	// SignatureImageFit scales the image to fit the annotation area,
+	// preserving its aspect ratio, and centers it.
+	SignatureImageFit SignatureImagePlacement = iota
+
+	// SignatureImageCenter centers the image in the annotation area,
+	// without fitting it. The parts of the image outside of the area are
+	// clipped.
+	SignatureImageCenter
+
+	// SignatureImageTile repeats the image, starting from the bottom left
+	// corner of the annotation area, in order to cover it.
+	SignatureImageTile
+)
+
 // NewSignatureFieldOpts returns a new initialized instance of options
 // used to generate a signature appearance.
 func NewSignatureFieldOpts() *SignatureFieldOpts {
@@ -0,0 +1,32 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// FillAndFlatten fills the form of `reader` with the values provided by
+// `provider` (e.g. *fjson.FieldData) and flattens the form fields, drawing
+// their appearances, generated using `style`, into the page contents.
+// The field values are set before generating the appearances, so that the
+// appearances of all fields reflect the filled values, including the text
+// fields which already had appearances. Annotations which are not related
+// to form fields are kept. The fallback fonts of the style are subset once
+// all the appearances are generated, if enabled by the font style.
+// Use FieldAppearance{}.Style() for the default appearance style.
+func FillAndFlatten(reader *model.PdfReader, provider model.FieldValueProvider, style AppearanceStyle) error {
+	if err := reader.AcroForm.Fill(provider); err != nil {
+		return err
+	}
+
+	fa := FieldAppearance{RegenerateTextFields: true}
+	fa.SetStyle(style)
+	if err := reader.FlattenFields(false, fa); err != nil {
+		return err
+	}
+	return fa.SubsetFonts()
+}
@@ -15,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
 	"github.com/bcmmbaga/unipdf-agpl/v3/fdf"
 	"github.com/bcmmbaga/unipdf-agpl/v3/fjson"
 	"github.com/bcmmbaga/unipdf-agpl/v3/model"
@@ -191,3 +193,81 @@ func testWriteFilledForm(t *testing.T, pdfPath string, provider model.FieldValue
 
 	require.NoError(t, writer.Write(outputFile))
 }
+
+func TestFillAndFlatten(t *testing.T) {
+	inputFile, err := os.Open("../fjson/testdata/basicform.pdf")
+	require.NoError(t, err)
+	defer inputFile.Close()
+
+	reader, err := model.NewPdfReader(inputFile)
+	require.NoError(t, err)
+
+	data, err := fjson.LoadFromJSON(strings.NewReader(`[{"name": "full_name", "value": "John Doe"}]`))
+	require.NoError(t, err)
+	require.NoError(t, FillAndFlatten(reader, data, FieldAppearance{}.Style()))
+	require.Nil(t, reader.AcroForm)
+
+	// The filled value is drawn in the page contents, through the XObject
+	// forms of the flattened field appearances.
+	page := reader.PageList[0]
+	annotations, err := page.GetAnnotations()
+	require.NoError(t, err)
+	for _, annot := range annotations {
+		_, isWidget := annot.GetContext().(*model.PdfAnnotationWidget)
+		require.False(t, isWidget)
+	}
+
+	contents, err := page.GetAllContentStreams()
+	require.NoError(t, err)
+	ops, err := contentstream.NewContentStreamParser(contents).Parse()
+	require.NoError(t, err)
+
+	var texts []string
+	for _, op := range *ops {
+		if op.Operand != "Do" {
+			continue
+		}
+		name, ok := core.GetName(op.Params[0])
+		require.True(t, ok)
+		xform, err := page.Resources.GetXObjectFormByName(*name)
+		require.NoError(t, err)
+		if xform == nil {
+			continue
+		}
+		content, err := xform.GetContentStream()
+		require.NoError(t, err)
+		xops, err := contentstream.NewContentStreamParser(string(content)).Parse()
+		require.NoError(t, err)
+		for _, op := range findOps(xops, "Tj") {
+			str, ok := core.GetString(op.Params[0])
+			require.True(t, ok)
+			texts = append(texts, str.Str())
+		}
+	}
+	require.Contains(t, texts, "John Doe")
+}
+
+func TestFillAndFlattenSubsetFonts(t *testing.T) {
+	inputFile, err := os.Open("../fjson/testdata/basicform.pdf")
+	require.NoError(t, err)
+	defer inputFile.Close()
+
+	reader, err := model.NewPdfReader(inputFile)
+	require.NoError(t, err)
+
+	apFont, err := NewAppearanceFontFromFile("OpenSans", "../model/testdata/font/OpenSans-Regular.ttf", 10)
+	require.NoError(t, err)
+	style := FieldAppearance{}.Style()
+	style.Fonts = &AppearanceFontStyle{Fallback: apFont, ForceReplace: true, SubsetFonts: true}
+
+	data, err := fjson.LoadFromJSON(strings.NewReader(`[{"name": "full_name", "value": "John Doe"}]`))
+	require.NoError(t, err)
+	require.NoError(t, FillAndFlatten(reader, data, style))
+
+	// The fallback font is subset to the glyphs of the filled values.
+	dict, ok := core.GetDict(apFont.Font.ToPdfObject())
+	require.True(t, ok)
+	baseFont, ok := core.GetName(dict.Get("BaseFont"))
+	require.True(t, ok)
+	require.Contains(t, baseFont.String(), "+")
+}
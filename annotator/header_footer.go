@@ -0,0 +1,190 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/transform"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// HeaderFooterOptions defines the running headers and footers added to the
+// pages of a document by StampHeaderFooter.
+// The header and footer texts can contain the following tokens, which are
+// replaced for each page:
+//   - {page}: the number of the page, starting at 1.
+//   - {pages}: the number of pages of the document.
+//   - {date}: the date, formatted using DateFormat.
+type HeaderFooterOptions struct {
+	// Header is the text drawn at the top of each page. Ignored if empty.
+	Header string
+
+	// Footer is the text drawn at the bottom of each page. Ignored if empty.
+	Footer string
+
+	// HeaderAlignment and FooterAlignment are the horizontal alignments of
+	// the header and footer texts.
+	HeaderAlignment TextAlignment
+	FooterAlignment TextAlignment
+
+	// Margins are the distances between the edges of the visible area of
+	// the pages and the header and footer texts. Defaults to 36 (0.5 inches).
+	MarginLeft   float64
+	MarginRight  float64
+	MarginTop    float64
+	MarginBottom float64
+
+	// Style defines the font, size and color of the header and footer
+	// texts. The Alignment field of the style is not used.
+	Style TextBlockOptions
+
+	// Date is the date used for the {date} token. Defaults to the current
+	// date.
+	Date time.Time
+
+	// DateFormat is the layout used for formatting the {date} token, as
+	// accepted by time.Time.Format. Defaults to "2006-01-02".
+	DateFormat string
+}
+
+// StampHeaderFooter draws the header and footer described by `opts` on each
+// page of the document loaded by `reader` and adds the pages to the writer.
+// The header and footer are positioned relative to the visible area of each
+// page (the CropBox, or the MediaBox if not set) and are drawn upright when
+// the page is displayed, taking the page rotation into account.
+func StampHeaderFooter(reader *model.PdfReader, w *model.PdfWriter, opts *HeaderFooterOptions) error {
+	if reader == nil || w == nil {
+		return errors.New("reader and writer must be specified")
+	}
+	if opts == nil {
+		return errors.New("header and footer options not specified")
+	}
+
+	// Apply default options.
+	o := *opts
+	style, err := o.Style.withDefaults()
+	if err != nil {
+		return err
+	}
+	for _, margin := range []*float64{&o.MarginLeft, &o.MarginRight, &o.MarginTop, &o.MarginBottom} {
+		if *margin <= 0 {
+			*margin = 36
+		}
+	}
+	if o.Date.IsZero() {
+		o.Date = time.Now()
+	}
+	if o.DateFormat == "" {
+		o.DateFormat = "2006-01-02"
+	}
+
+	numPages, err := reader.GetNumPages()
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i <= numPages; i++ {
+		page, err := reader.GetPage(i)
+		if err != nil {
+			return err
+		}
+
+		replacer := strings.NewReplacer(
+			"{page}", strconv.Itoa(i),
+			"{pages}", strconv.Itoa(numPages),
+			"{date}", o.Date.Format(o.DateFormat),
+		)
+		if err := stampPageHeaderFooter(page, replacer, o, style); err != nil {
+			return err
+		}
+
+		if err := w.AddPage(page); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// stampPageHeaderFooter draws the header and footer on the specified page.
+// The token values of the page are substituted using `replacer`.
+func stampPageHeaderFooter(page *model.PdfPage, replacer *strings.Replacer,
+	o HeaderFooterOptions, style TextBlockOptions) error {
+	box, err := page.GetMediaBox()
+	if err != nil {
+		return err
+	}
+	if page.CropBox != nil {
+		box = page.CropBox
+	}
+
+	// The header and footer are laid out in the coordinate system of the
+	// displayed page, with the origin at its lower left corner, and mapped
+	// to the page coordinates using `ctm`.
+	var rotate int64
+	if page.Rotate != nil {
+		rotate = (*page.Rotate%360 + 360) % 360
+	}
+	width, height := box.Width(), box.Height()
+	var ctm transform.Matrix
+	switch rotate {
+	case 90:
+		ctm = transform.NewMatrix(0, 1, -1, 0, box.Llx+width, box.Lly)
+		width, height = height, width
+	case 180:
+		ctm = transform.NewMatrix(-1, 0, 0, -1, box.Llx+width, box.Lly+height)
+	case 270:
+		ctm = transform.NewMatrix(0, -1, 1, 0, box.Llx, box.Lly+height)
+		width, height = height, width
+	default:
+		ctm = transform.NewMatrix(1, 0, 0, 1, box.Llx, box.Lly)
+	}
+
+	// blockRect returns the rectangle of the text block containing `text`,
+	// starting at the `y` coordinate and growing upwards, if `up` is true,
+	// or downwards otherwise.
+	blockRect := func(text string, y float64, up bool) model.PdfRectangle {
+		blockWidth := width - o.MarginLeft - o.MarginRight
+		appStyle := AppearanceStyle{Kerning: style.Kerning, WrapMode: WrapModeChars}
+		lines, _ := appStyle.wrapLines(style.Font, style.FontSize, splitLines(text), 1000.0*blockWidth/style.FontSize, false)
+		blockHeight := float64(len(lines)-1)*style.LineHeight*style.FontSize + style.FontSize
+
+		rect := model.PdfRectangle{Llx: o.MarginLeft, Urx: width - o.MarginRight}
+		if up {
+			rect.Lly, rect.Ury = y, y+blockHeight
+		} else {
+			rect.Lly, rect.Ury = y-blockHeight, y
+		}
+		return rect
+	}
+
+	if o.Header != "" {
+		text := replacer.Replace(o.Header)
+		headerStyle := style
+		headerStyle.Alignment = o.HeaderAlignment
+
+		rect := blockRect(text, height-o.MarginTop, false)
+		if err := drawTextBlock(page, rect, text, headerStyle, ctm); err != nil {
+			return err
+		}
+	}
+	if o.Footer != "" {
+		text := replacer.Replace(o.Footer)
+		footerStyle := style
+		footerStyle.Alignment = o.FooterAlignment
+
+		rect := blockRect(text, o.MarginBottom, true)
+		if err := drawTextBlock(page, rect, text, footerStyle, ctm); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+func TestStampHeaderFooter(t *testing.T) {
+	// Create a document with pages of different sizes and rotations.
+	w := model.NewPdfWriter()
+	portrait := model.NewPdfPage()
+	portrait.MediaBox = &model.PdfRectangle{Urx: 612, Ury: 792}
+	require.NoError(t, w.AddPage(portrait))
+
+	rotated := model.NewPdfPage()
+	rotated.MediaBox = &model.PdfRectangle{Urx: 400, Ury: 600}
+	rotate := int64(90)
+	rotated.Rotate = &rotate
+	require.NoError(t, w.AddPage(rotated))
+
+	var buf bytes.Buffer
+	require.NoError(t, w.Write(&buf))
+	reader, err := model.NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	font, err := model.NewStandard14Font(model.CourierName)
+	require.NoError(t, err)
+
+	out := model.NewPdfWriter()
+	opts := &HeaderFooterOptions{
+		Header:          "Report {date}",
+		Footer:          "Page {page} of {pages}",
+		FooterAlignment: TextAlignmentCenter,
+		Style:           TextBlockOptions{Font: font, FontSize: 10},
+		Date:            time.Date(2020, 5, 17, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, StampHeaderFooter(reader, &out, opts))
+
+	buf.Reset()
+	require.NoError(t, out.Write(&buf))
+	reader, err = model.NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	// getOps returns the text and the text positioning operations of the
+	// specified page.
+	getOps := func(pageNum int) ([]string, []*contentstream.ContentStreamOperation, []*contentstream.ContentStreamOperation) {
+		page, err := reader.GetPage(pageNum)
+		require.NoError(t, err)
+		content, err := page.GetAllContentStreams()
+		require.NoError(t, err)
+		ops, err := contentstream.NewContentStreamParser(content).Parse()
+		require.NoError(t, err)
+
+		var text []string
+		for _, op := range findOps(ops, "Tj") {
+			str, ok := core.GetString(op.Params[0])
+			require.True(t, ok)
+			text = append(text, str.Str())
+		}
+		return text, findOps(ops, "Td"), findOps(ops, "cm")
+	}
+
+	// Portrait page: no transformation needed.
+	text, tds, cms := getOps(1)
+	require.Equal(t, []string{"Report 2020-05-17", "Page 1 of 2"}, text)
+	require.Empty(t, cms)
+	require.Len(t, tds, 2)
+	params, err := core.GetNumbersAsFloat(tds[0].Params)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{36, 792 - 36 - 6.29}, params, 1e-9)
+	// Footer centered: 11 Courier glyphs are 66 units wide.
+	params, err = core.GetNumbersAsFloat(tds[1].Params)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{(612 - 66) / 2, 36 + 10 - 6.29}, params, 1e-9)
+
+	// Rotated page: laid out on a 600x400 displayed page.
+	text, tds, cms = getOps(2)
+	require.Equal(t, []string{"Report 2020-05-17", "Page 2 of 2"}, text)
+	require.Len(t, cms, 2)
+	params, err = core.GetNumbersAsFloat(cms[0].Params)
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, 1, -1, 0, 400, 0}, params)
+	params, err = core.GetNumbersAsFloat(tds[0].Params)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{36, 400 - 36 - 6.29}, params, 1e-9)
+	params, err = core.GetNumbersAsFloat(tds[1].Params)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{(600 - 66) / 2, 36 + 10 - 6.29}, params, 1e-9)
+}
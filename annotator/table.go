@@ -0,0 +1,321 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// TableCellStyle defines the appearance of a table cell.
+type TableCellStyle struct {
+	// Alignment is the horizontal alignment of the cell text.
+	Alignment TextAlignment
+
+	// Padding is the distance between the cell borders and the cell text.
+	Padding float64
+
+	// BorderWidth is the width of the cell borders. Borders are not drawn
+	// if the width is 0.
+	BorderWidth float64
+
+	// BorderColor is the color of the cell borders. Defaults to black.
+	BorderColor model.PdfColor
+
+	// BackgroundColor is the fill color of the cell. The cell is not filled
+	// if not set.
+	BackgroundColor model.PdfColor
+}
+
+// TableOptions defines the layout and the style of the tables drawn using
+// DrawTable.
+type TableOptions struct {
+	// ColumnWidths contains the widths of the table columns.
+	ColumnWidths []float64
+
+	// Text defines the font, size, color and line height of the cell text.
+	// The Alignment field is not used, as it is specified by the cell style.
+	Text TextBlockOptions
+
+	// CellStyle is the style of the table cells.
+	CellStyle TableCellStyle
+
+	// CellStyleFunc, if set, returns the style of the cell at the specified
+	// row and column, overriding CellStyle.
+	CellStyleFunc func(row, col int) TableCellStyle
+
+	// HeaderRows is the number of rows at the top of the table which are
+	// repeated at the top of each new page.
+	HeaderRows int
+
+	// MarginTop is the distance between the top edge of the new pages and
+	// the table. Defaults to 36 (0.5 inches).
+	MarginTop float64
+
+	// MarginBottom is the minimum distance between the bottom edge of the
+	// pages and the table. Defaults to 36 (0.5 inches).
+	MarginBottom float64
+
+	// NewPage is called when the rows of the table do not fit on the current
+	// page. It must return the page the table continues on. If not set,
+	// an error is returned when the table does not fit on the page.
+	NewPage func() (*model.PdfPage, error)
+}
+
+// TableResult contains the outcome of drawing a table.
+type TableResult struct {
+	// Pages contains the pages the table was drawn on, starting with the
+	// initial page.
+	Pages []*model.PdfPage
+
+	// Height is the height of the table on the last page.
+	Height float64
+}
+
+// DrawTable draws a table containing the specified rows of text on the page,
+// with the top left corner of the table placed at (`x`, `y`). The cell text
+// is wrapped to the width of the columns and the height of each row is
+// adjusted to fit its cells. When the table reaches the bottom margin of the
+// page, it continues on the page returned by the NewPage option.
+func DrawTable(page *model.PdfPage, x, y float64, rows [][]string, opts *TableOptions) (*TableResult, error) {
+	if page == nil {
+		return nil, errors.New("page not specified")
+	}
+	if opts == nil || len(opts.ColumnWidths) == 0 {
+		return nil, errors.New("table column widths not specified")
+	}
+	for _, w := range opts.ColumnWidths {
+		if w <= 0 {
+			return nil, fmt.Errorf("invalid table column width: %f", w)
+		}
+	}
+
+	// Apply default options.
+	o := *opts
+	text, err := o.Text.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	o.Text = text
+	if o.MarginTop <= 0 {
+		o.MarginTop = 36
+	}
+	if o.MarginBottom <= 0 {
+		o.MarginBottom = 36
+	}
+	if o.HeaderRows > len(rows) {
+		o.HeaderRows = len(rows)
+	}
+
+	t := &tableLayout{opts: o, x: x}
+	if err := t.startPage(page, y); err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		height := t.rowHeight(i, row)
+		if t.y-height < t.minY && t.numRows > t.numRepeated {
+			if err := t.breakPage(); err != nil {
+				return nil, err
+			}
+
+			// Repeat the header rows on the new page.
+			if i >= t.opts.HeaderRows {
+				for j := 0; j < t.opts.HeaderRows; j++ {
+					if err := t.drawRow(j, rows[j], t.rowHeight(j, rows[j])); err != nil {
+						return nil, err
+					}
+				}
+				t.numRepeated = t.opts.HeaderRows
+			}
+		}
+		if t.y-height < t.minY {
+			return nil, fmt.Errorf("table row %d does not fit on the page", i)
+		}
+		if err := t.drawRow(i, row, height); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.finishPage(); err != nil {
+		return nil, err
+	}
+	return &TableResult{Pages: t.pages, Height: t.top - t.y}, nil
+}
+
+// tableLayout holds the state of a table being drawn.
+type tableLayout struct {
+	opts TableOptions
+	x    float64
+
+	pages []*model.PdfPage
+	cc    *contentstream.ContentCreator
+
+	// The number of rows drawn on the current page, including the number
+	// of repeated header rows.
+	numRows     int
+	numRepeated int
+
+	// The top of the table on the current page, the top of the next row and
+	// the lowest position rows can reach.
+	top  float64
+	y    float64
+	minY float64
+}
+
+// startPage starts drawing the table on `page` at the `y` coordinate.
+func (t *tableLayout) startPage(page *model.PdfPage, y float64) error {
+	box, err := page.GetMediaBox()
+	if err != nil {
+		return err
+	}
+
+	t.pages = append(t.pages, page)
+	t.cc = contentstream.NewContentCreator()
+	t.numRows, t.numRepeated = 0, 0
+	t.top, t.y = y, y
+	t.minY = box.Lly + t.opts.MarginBottom
+	return nil
+}
+
+// finishPage appends the drawing operations to the current page.
+func (t *tableLayout) finishPage() error {
+	page := t.pages[len(t.pages)-1]
+	if t.numRows == 0 {
+		return nil
+	}
+	return appendPageContent(page, t.cc)
+}
+
+// breakPage finishes the current page and continues the table on a new page.
+func (t *tableLayout) breakPage() error {
+	if t.opts.NewPage == nil {
+		return errors.New("table does not fit on the page")
+	}
+	if err := t.finishPage(); err != nil {
+		return err
+	}
+
+	page, err := t.opts.NewPage()
+	if err != nil {
+		return err
+	}
+	if page == nil {
+		return errors.New("new table page not specified")
+	}
+	box, err := page.GetMediaBox()
+	if err != nil {
+		return err
+	}
+	return t.startPage(page, box.Ury-t.opts.MarginTop)
+}
+
+// cellStyle returns the style of the cell at the specified row and column.
+func (t *tableLayout) cellStyle(row, col int) TableCellStyle {
+	style := t.opts.CellStyle
+	if t.opts.CellStyleFunc != nil {
+		style = t.opts.CellStyleFunc(row, col)
+	}
+	if style.BorderColor == nil {
+		style.BorderColor = model.NewPdfColorDeviceGray(0)
+	}
+	return style
+}
+
+// cellLines returns the wrapped text lines of the cell at the specified row
+// and column.
+func (t *tableLayout) cellLines(row, col int, text string) []string {
+	style := t.cellStyle(row, col)
+	width := t.opts.ColumnWidths[col] - 2*style.Padding
+	appStyle := AppearanceStyle{Kerning: t.opts.Text.Kerning, WrapMode: WrapModeChars}
+	lines, _ := appStyle.wrapLines(t.opts.Text.Font, t.opts.Text.FontSize, splitLines(text), 1000.0*width/t.opts.Text.FontSize, false)
+	return lines
+}
+
+// rowHeight returns the height required by the cells of the specified row.
+func (t *tableLayout) rowHeight(row int, cells []string) float64 {
+	var height float64
+	for col := range t.opts.ColumnWidths {
+		var text string
+		if col < len(cells) {
+			text = cells[col]
+		}
+		lines := t.cellLines(row, col, text)
+		style := t.cellStyle(row, col)
+
+		o := t.opts.Text
+		h := float64(len(lines)-1)*o.LineHeight*o.FontSize + o.FontSize + 2*style.Padding
+		if h > height {
+			height = h
+		}
+	}
+	return height
+}
+
+// drawRow draws the cells of the specified row at the current position and
+// moves the position below the row.
+func (t *tableLayout) drawRow(row int, cells []string, height float64) error {
+	page := t.pages[len(t.pages)-1]
+	fontName, err := addPageFont(page, t.opts.Text.Font)
+	if err != nil {
+		return err
+	}
+
+	x := t.x
+	for col, width := range t.opts.ColumnWidths {
+		var text string
+		if col < len(cells) {
+			text = cells[col]
+		}
+		style := t.cellStyle(row, col)
+		cell := model.PdfRectangle{Llx: x, Lly: t.y - height, Urx: x + width, Ury: t.y}
+
+		cc := t.cc
+		if style.BackgroundColor != nil {
+			cc.Add_q().
+				SetNonStrokingColor(style.BackgroundColor).
+				Add_re(cell.Llx, cell.Lly, width, height).
+				Add_f().
+				Add_Q()
+		}
+
+		textRect := model.PdfRectangle{
+			Llx: cell.Llx + style.Padding,
+			Lly: cell.Lly + style.Padding,
+			Urx: cell.Urx - style.Padding,
+			Ury: cell.Ury - style.Padding,
+		}
+		if textRect.Width() > 0 && textRect.Height() > 0 && text != "" {
+			o := t.opts.Text
+			o.Alignment = style.Alignment
+
+			cc.Add_q().
+				Add_re(textRect.Llx, textRect.Lly, textRect.Width(), textRect.Height()).
+				Add_W().
+				Add_n()
+			addTextLines(cc, fontName, textRect, t.cellLines(row, col, text), o)
+			cc.Add_Q()
+		}
+
+		if style.BorderWidth > 0 {
+			cc.Add_q().
+				Add_w(style.BorderWidth).
+				SetStrokingColor(style.BorderColor).
+				Add_re(cell.Llx, cell.Lly, width, height).
+				Add_S().
+				Add_Q()
+		}
+
+		x += width
+	}
+
+	t.numRows++
+	t.y -= height
+	return nil
+}
@@ -0,0 +1,98 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+func TestDrawTable(t *testing.T) {
+	newPage := func() *model.PdfPage {
+		page := model.NewPdfPage()
+		page.MediaBox = &model.PdfRectangle{Urx: 300, Ury: 150}
+		return page
+	}
+
+	font, err := model.NewStandard14Font(model.CourierName)
+	require.NoError(t, err)
+
+	rows := [][]string{
+		{"Name", "Amount"},
+		{"First", "10"},
+		{"Second item", "20"},
+		{"Third", "30"},
+		{"Fourth", "40"},
+	}
+
+	var newPages []*model.PdfPage
+	opts := &TableOptions{
+		ColumnWidths: []float64{62, 62},
+		Text:         TextBlockOptions{Font: font},
+		CellStyleFunc: func(row, col int) TableCellStyle {
+			style := TableCellStyle{Padding: 2, BorderWidth: 1}
+			if row == 0 {
+				style.BackgroundColor = model.NewPdfColorDeviceGray(0.8)
+			}
+			if col == 1 {
+				style.Alignment = TextAlignmentRight
+			}
+			return style
+		},
+		HeaderRows: 1,
+		NewPage: func() (*model.PdfPage, error) {
+			page := newPage()
+			newPages = append(newPages, page)
+			return page, nil
+		},
+	}
+
+	// The rows are 14 units high ("Second item" is wrapped on 2 lines: 24).
+	// The first page fits 114-36=78 units: 4 rows.
+	page := newPage()
+	result, err := DrawTable(page, 10, 114, rows, opts)
+	require.NoError(t, err)
+	require.Len(t, newPages, 1)
+	require.Equal(t, []*model.PdfPage{page, newPages[0]}, result.Pages)
+	require.InDelta(t, 28, result.Height, 1e-9)
+
+	// getText returns the text drawn on the page.
+	getText := func(page *model.PdfPage) ([]string, *contentstream.ContentStreamOperations) {
+		content, err := page.GetAllContentStreams()
+		require.NoError(t, err)
+		ops, err := contentstream.NewContentStreamParser(content).Parse()
+		require.NoError(t, err)
+
+		var text []string
+		for _, op := range findOps(ops, "Tj") {
+			str, ok := core.GetString(op.Params[0])
+			require.True(t, ok)
+			text = append(text, str.Str())
+		}
+		return text, ops
+	}
+
+	text, ops := getText(page)
+	require.Equal(t, []string{"Name", "Amount", "First", "10", "Second", "item", "20", "Third", "30"}, text)
+	require.Len(t, findOps(ops, "S"), 8)
+	require.Len(t, findOps(ops, "f"), 2)
+
+	// The header row is repeated on the new page.
+	text, _ = getText(newPages[0])
+	require.Equal(t, []string{"Name", "Amount", "Fourth", "40"}, text)
+
+	// Rows which do not fit on a page.
+	opts.NewPage = nil
+	_, err = DrawTable(newPage(), 10, 114, rows, opts)
+	require.Error(t, err)
+	_, err = DrawTable(newPage(), 10, 114, rows, &TableOptions{})
+	require.Error(t, err)
+}
@@ -0,0 +1,224 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/textencoding"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/transform"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+// TextAlignment represents the horizontal alignment of the lines of a text
+// block.
+type TextAlignment int
+
+const (
+	// TextAlignmentLeft aligns the lines of text to the left edge of the block.
+	TextAlignmentLeft TextAlignment = iota
+
+	// TextAlignmentCenter centers the lines of text horizontally.
+	TextAlignmentCenter
+
+	// TextAlignmentRight aligns the lines of text to the right edge of the block.
+	TextAlignmentRight
+)
+
+// TextBlockOptions defines the style of the text blocks drawn using
+// DrawTextBlock.
+type TextBlockOptions struct {
+	// Font is the font used for drawing the text. Defaults to Helvetica.
+	Font *model.PdfFont
+
+	// FontSize is the size of the font. Defaults to 10.
+	FontSize float64
+
+	// Color is the color of the text. Defaults to black.
+	Color model.PdfColor
+
+	// Alignment is the horizontal alignment of the lines of text.
+	Alignment TextAlignment
+
+	// LineHeight is the distance between the baselines of consecutive lines,
+	// as a multiple of the font size. Defaults to 1.
+	LineHeight float64
+
+	// Kerning enables the kerning of the text, using the kerning pairs of
+	// the font, where available.
+	Kerning bool
+}
+
+// DrawTextBlock draws `text` inside the rectangle `rect` of the specified
+// page, using the style defined by `opts`. The text is wrapped to the width
+// of the rectangle, starting from its top edge. Explicit line breaks are
+// preserved. Lines which do not fit vertically are not drawn and the text is
+// clipped to the rectangle.
+// The font is added to the page resources if needed and the drawing
+// operations are appended to the content streams of the page.
+func DrawTextBlock(page *model.PdfPage, rect model.PdfRectangle, text string, opts *TextBlockOptions) error {
+	var o TextBlockOptions
+	if opts != nil {
+		o = *opts
+	}
+	o, err := o.withDefaults()
+	if err != nil {
+		return err
+	}
+	return drawTextBlock(page, rect, text, o, transform.IdentityMatrix())
+}
+
+// withDefaults returns a copy of the options, with the default values set
+// for the unspecified fields.
+func (o TextBlockOptions) withDefaults() (TextBlockOptions, error) {
+	if o.Font == nil {
+		font, err := model.NewStandard14Font(model.HelveticaName)
+		if err != nil {
+			return o, err
+		}
+		o.Font = font
+	}
+	if o.FontSize <= 0 {
+		o.FontSize = 10
+	}
+	if o.Color == nil {
+		o.Color = model.NewPdfColorDeviceGray(0)
+	}
+	if o.LineHeight <= 0 {
+		o.LineHeight = 1
+	}
+	return o, nil
+}
+
+// drawTextBlock draws a text block, as described by DrawTextBlock. The
+// coordinates of `rect` are transformed by the `ctm` matrix. All the options
+// in `o` must be set.
+func drawTextBlock(page *model.PdfPage, rect model.PdfRectangle, text string, o TextBlockOptions, ctm transform.Matrix) error {
+	if page == nil {
+		return errors.New("page not specified")
+	}
+	width, height := rect.Width(), rect.Height()
+	if width <= 0 || height <= 0 {
+		return errors.New("invalid text block rectangle")
+	}
+
+	// Words which do not fit on a line by themselves are broken between
+	// characters.
+	style := AppearanceStyle{Kerning: o.Kerning, WrapMode: WrapModeChars}
+	lines, _ := style.wrapLines(o.Font, o.FontSize, splitLines(text), 1000.0*width/o.FontSize, false)
+
+	fontName, err := addPageFont(page, o.Font)
+	if err != nil {
+		return err
+	}
+
+	cc := contentstream.NewContentCreator()
+	cc.Add_q()
+	if ctm != transform.IdentityMatrix() {
+		cc.Add_cm(ctm[0], ctm[1], ctm[3], ctm[4], ctm[6], ctm[7])
+	}
+	cc.Add_re(rect.Llx, rect.Lly, width, height).
+		Add_W().
+		Add_n()
+	addTextLines(cc, fontName, rect, lines, o)
+	cc.Add_Q()
+
+	return appendPageContent(page, cc)
+}
+
+// addTextLines adds the operations drawing the text `lines` inside `rect` to
+// the content creator, starting from the top edge of the rectangle. Lines
+// which do not fit vertically are not drawn.
+func addTextLines(cc *contentstream.ContentCreator, fontName core.PdfObjectName,
+	rect model.PdfRectangle, lines []string, o TextBlockOptions) {
+	font := o.Font
+	encoder := font.Encoder()
+	if encoder == nil {
+		common.Log.Debug("WARN: font encoder is nil. Assuming identity encoder. Output may be incorrect.")
+		encoder = textencoding.NewIdentityTextEncoder("Identity-H")
+	}
+	style := AppearanceStyle{Kerning: o.Kerning}
+	width := rect.Width()
+
+	// Position the baseline of the first line using the ascent of the font.
+	ascent := o.FontSize
+	if metrics, err := font.GetFontMetrics(); err == nil && metrics.Ascent > 0 {
+		ascent = metrics.Ascent / 1000.0 * o.FontSize
+	}
+	lineheight := o.LineHeight * o.FontSize
+
+	cc.Add_BT()
+	cc.SetNonStrokingColor(o.Color)
+	cc.Add_Tf(fontName, o.FontSize)
+
+	var x, y float64
+	for i, line := range lines {
+		ty := rect.Ury - ascent - float64(i)*lineheight
+		if ty < rect.Lly {
+			common.Log.Debug("Text block overflow: %d of %d lines drawn", i, len(lines))
+			break
+		}
+
+		tx := rect.Llx
+		switch o.Alignment {
+		case TextAlignmentCenter:
+			tx += (width - style.textWidth(font, line)/1000.0*o.FontSize) / 2
+		case TextAlignmentRight:
+			tx += width - style.textWidth(font, line)/1000.0*o.FontSize
+		}
+
+		cc.Add_Td(tx-x, ty-y)
+		x, y = tx, ty
+
+		if o.Kerning {
+			addKernedText(cc, font, encoder, line)
+		} else {
+			cc.Add_Tj(*core.MakeStringFromBytes(encoder.Encode(line)))
+		}
+	}
+
+	cc.Add_ET()
+}
+
+// appendPageContent appends the operations of the content creator to the
+// content streams of the page. The existing content is wrapped if needed, so
+// that the appended operations are not affected by its graphics state
+// changes.
+func appendPageContent(page *model.PdfPage, cc *contentstream.ContentCreator) error {
+	fa := FieldAppearance{AppendContentStreams: true}
+	if err := fa.WrapContentStream(page); err != nil {
+		return err
+	}
+	return page.AddContentStreamByString(string(cc.Bytes()))
+}
+
+// addPageFont adds `font` to the font resources of the page, if not already
+// present, and returns its resource name.
+func addPageFont(page *model.PdfPage, font *model.PdfFont) (core.PdfObjectName, error) {
+	fontObj := font.ToPdfObject()
+	if page.Resources == nil {
+		page.Resources = model.NewPdfPageResources()
+	}
+	if fontDict, ok := core.GetDict(page.Resources.Font); ok {
+		for _, key := range fontDict.Keys() {
+			if fontDict.Get(key) == fontObj {
+				return key, nil
+			}
+		}
+	}
+
+	i := 1
+	name := core.PdfObjectName(fmt.Sprintf("F%d", i))
+	for page.Resources.HasFontByName(name) {
+		i++
+		name = core.PdfObjectName(fmt.Sprintf("F%d", i))
+	}
+	return name, page.AddFont(name, fontObj)
+}
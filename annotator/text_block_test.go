@@ -0,0 +1,87 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package annotator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+)
+
+func TestWrapText(t *testing.T) {
+	font, err := model.NewStandard14Font(model.CourierName)
+	require.NoError(t, err)
+	style := AppearanceStyle{WrapMode: WrapModeChars}
+
+	// Courier glyphs are 600 units wide: 10 characters per line.
+	text := "The quick brown fox\r\n\njumps over_the_lazy_dog"
+	lines, _ := style.wrapLines(font, 10, splitLines(text), 6000, false)
+	require.Equal(t, []string{
+		"The quick",
+		"brown fox",
+		"",
+		"jumps",
+		"over_the_l",
+		"azy_dog",
+	}, lines)
+}
+
+func TestDrawTextBlock(t *testing.T) {
+	page := model.NewPdfPage()
+	require.NoError(t, page.AddContentStreamByString("q 1 0 0 1 10 10 cm"))
+
+	font, err := model.NewStandard14Font(model.CourierName)
+	require.NoError(t, err)
+
+	// Draw two blocks with the same font.
+	rect := model.PdfRectangle{Llx: 100, Lly: 500, Urx: 160, Ury: 530}
+	opts := &TextBlockOptions{
+		Font:       font,
+		Alignment:  TextAlignmentRight,
+		LineHeight: 1.5,
+		Color:      model.NewPdfColorDeviceRGB(1, 0, 0),
+	}
+	require.NoError(t, DrawTextBlock(page, rect, "Lorem ipsum dolor sit amet", opts))
+	require.NoError(t, DrawTextBlock(page, rect, "Second", opts))
+
+	// The font must be added to the page resources once.
+	fontDict, ok := core.GetDict(page.Resources.Font)
+	require.True(t, ok)
+	require.Equal(t, []core.PdfObjectName{"F1"}, fontDict.Keys())
+
+	// The existing content must be wrapped.
+	content, err := page.GetAllContentStreams()
+	require.NoError(t, err)
+	ops, err := contentstream.NewContentStreamParser(content).Parse()
+	require.NoError(t, err)
+	require.True(t, ops.IsWrapped())
+
+	// Only the lines which fit vertically are drawn (30/(1.5*10) = 2 lines).
+	var text []string
+	for _, op := range findOps(ops, "Tj") {
+		str, ok := core.GetString(op.Params[0])
+		require.True(t, ok)
+		text = append(text, str.Str())
+	}
+	require.Equal(t, []string{"Lorem", "ipsum", "Second"}, text)
+
+	// The lines are right aligned: 5 Courier glyphs are 30 units wide.
+	tds := findOps(ops, "Td")
+	require.Len(t, tds, 3)
+	params, err := core.GetNumbersAsFloat(tds[0].Params)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{130, 530 - 6.29}, params, 1e-9)
+	params, err = core.GetNumbersAsFloat(tds[1].Params)
+	require.NoError(t, err)
+	require.InDeltaSlice(t, []float64{0, -15}, params, 1e-9)
+
+	require.Len(t, findOps(ops, "rg"), 2)
+	require.Error(t, DrawTextBlock(page, model.PdfRectangle{}, "Empty", opts))
+}
@@ -22,8 +22,10 @@ type ContentStreamOperation struct {
 // ContentStreamOperations is a slice of ContentStreamOperations.
 type ContentStreamOperations []*ContentStreamOperation
 
-// Check if the content stream operations are fully wrapped (within q ... Q)
-func (ops *ContentStreamOperations) isWrapped() bool {
+// IsWrapped checks if the content stream operations are fully wrapped
+// within q ... Q, i.e. all the operations are executed within a saved
+// graphics state which is restored at the end.
+func (ops *ContentStreamOperations) IsWrapped() bool {
 	if len(*ops) < 2 {
 		return false
 	}
@@ -45,34 +47,57 @@ func (ops *ContentStreamOperations) isWrapped() bool {
 	return depth == 0
 }
 
-// WrapIfNeeded wraps the entire contents within q ... Q.  If unbalanced, then adds extra Qs at the end.
-// Only does if needed. Ensures that when adding new content, one start with all states
-// in the default condition.
-func (ops *ContentStreamOperations) WrapIfNeeded() *ContentStreamOperations {
-	if len(*ops) == 0 {
-		// No need to wrap if empty.
-		return ops
-	}
-	if ops.isWrapped() {
-		return ops
+// WrapOperations returns the operations which need to be added before
+// (`prefix`) and after (`suffix`) the content stream operations in order to
+// wrap them within q ... Q. Unbalanced q operators are closed by additional
+// Q operators in the suffix and unbalanced Q operators are matched by
+// additional q operators in the prefix. Returns empty operation lists if
+// no wrapping is needed.
+func (ops *ContentStreamOperations) WrapOperations() (prefix, suffix ContentStreamOperations) {
+	if len(*ops) == 0 || ops.IsWrapped() {
+		return nil, nil
 	}
 
-	*ops = append([]*ContentStreamOperation{{Operand: "q"}}, *ops...)
-
-	depth := 0
+	depth, minDepth := 0, 0
 	for _, op := range *ops {
 		if op.Operand == "q" {
 			depth++
 		} else if op.Operand == "Q" {
 			depth--
+			if depth < minDepth {
+				minDepth = depth
+			}
 		}
 	}
 
-	for depth > 0 {
-		*ops = append(*ops, &ContentStreamOperation{Operand: "Q"})
-		depth--
+	// Wrap in q ... Q and add a q for each unmatched Q.
+	numPrefix := 1 - minDepth
+	for i := 0; i < numPrefix; i++ {
+		prefix = append(prefix, &ContentStreamOperation{Operand: "q"})
+	}
+	for depth += numPrefix; depth > 0; depth-- {
+		suffix = append(suffix, &ContentStreamOperation{Operand: "Q"})
 	}
 
+	return prefix, suffix
+}
+
+// WrapIfNeeded wraps the entire contents within q ... Q.  If unbalanced, then adds extra Qs at the end.
+// Only does if needed. Ensures that when adding new content, one start with all states
+// in the default condition. The operation is idempotent: calling it on already wrapped
+// operations has no effect.
+func (ops *ContentStreamOperations) WrapIfNeeded() *ContentStreamOperations {
+	prefix, suffix := ops.WrapOperations()
+	if len(prefix) == 0 {
+		return ops
+	}
+
+	wrapped := make(ContentStreamOperations, 0, len(prefix)+len(*ops)+len(suffix))
+	wrapped = append(wrapped, prefix...)
+	wrapped = append(wrapped, *ops...)
+	wrapped = append(wrapped, suffix...)
+	*ops = wrapped
+
 	return ops
 }
 
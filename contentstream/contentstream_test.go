@@ -23,3 +23,44 @@ func TestOperandTJSpacing(t *testing.T) {
 	}
 
 }
+
+func TestWrapIfNeeded(t *testing.T) {
+	testcases := []struct {
+		content  string
+		expected string
+	}{
+		{"", ""},
+		{"q 1 0 0 1 0 0 cm Q", "q 1 0 0 1 0 0 cm Q"},
+		{"q 0 g Q q 1 g Q", "q 0 g Q q 1 g Q"},
+		{"0 g", "q 0 g Q"},
+		{"q 0 g", "q q 0 g Q Q"},
+		{"0 g Q 1 g", "q q 0 g Q 1 g Q"},
+		{"q 0 g Q 1 g", "q q 0 g Q 1 g Q"},
+	}
+
+	for _, tcase := range testcases {
+		ops, err := NewContentStreamParser(tcase.content).Parse()
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+
+		ops.WrapIfNeeded()
+		expected, err := NewContentStreamParser(tcase.expected).Parse()
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if ops.String() != expected.String() {
+			t.Fatalf("%q: %q != %q", tcase.content, ops.String(), expected.String())
+		}
+		if len(*ops) > 0 && !ops.IsWrapped() {
+			t.Fatalf("%q: not wrapped", tcase.content)
+		}
+
+		// Wrapping must be idempotent.
+		wrapped := ops.String()
+		ops.WrapIfNeeded()
+		if ops.String() != wrapped {
+			t.Fatalf("%q: wrapped twice: %q", tcase.content, ops.String())
+		}
+	}
+}
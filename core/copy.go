@@ -0,0 +1,145 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package core
+
+import (
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+)
+
+// ObjectCopier creates deep copies of PDF objects, which can be used in other
+// documents without sharing any objects with the source document. This allows
+// modifying and writing the copies without affecting the source objects.
+//
+// The references to the objects of the source document are resolved and
+// replaced with copies of the referenced indirect objects. The copied objects
+// are cached, so that objects referenced multiple times within the copied
+// structures are copied only once and remain shared in the copy, even across
+// multiple calls to Copy. Cyclic structures are supported.
+type ObjectCopier struct {
+	copies map[PdfObject]PdfObject
+
+	// nextObjectNumber is the object number assigned to the next copied
+	// indirect object, if positive.
+	nextObjectNumber int64
+}
+
+// NewObjectCopier returns a new object copier. If `nextObjectNumber` is
+// positive, the copied indirect objects and streams are numbered
+// sequentially, starting from `nextObjectNumber`, so that they can be added
+// to a document whose objects are numbered below it. Otherwise, the copied
+// indirect objects are not numbered (the object number is 0) and they are
+// numbered by the writer of the destination document.
+func NewObjectCopier(nextObjectNumber int64) *ObjectCopier {
+	return &ObjectCopier{
+		copies:           map[PdfObject]PdfObject{},
+		nextObjectNumber: nextObjectNumber,
+	}
+}
+
+// NextObjectNumber returns the object number which would be assigned to the
+// next copied indirect object, or 0 if the copied objects are not numbered.
+func (c *ObjectCopier) NextObjectNumber() int64 {
+	return c.nextObjectNumber
+}
+
+// Copy returns a deep copy of `obj`.
+// References which cannot be resolved, as they are not associated with a
+// parser, are copied as they are.
+func (c *ObjectCopier) Copy(obj PdfObject) PdfObject {
+	if ref, ok := obj.(*PdfObjectReference); ok {
+		if ref.parser == nil {
+			refCopy := *ref
+			return &refCopy
+		}
+		obj = ref.Resolve()
+	}
+	if obj == nil {
+		return nil
+	}
+	if objCopy, ok := c.copies[obj]; ok {
+		return objCopy
+	}
+
+	switch t := obj.(type) {
+	case *PdfIndirectObject:
+		ind := &PdfIndirectObject{}
+		c.number(&ind.PdfObjectReference)
+		c.copies[obj] = ind
+		ind.PdfObject = c.Copy(t.PdfObject)
+		return ind
+	case *PdfObjectStream:
+		stream := &PdfObjectStream{
+			PdfObjectDictionary: MakeDict(),
+			Stream:              append([]byte(nil), t.Stream...),
+		}
+		c.number(&stream.PdfObjectReference)
+		c.copies[obj] = stream
+		if t.PdfObjectDictionary != nil {
+			for _, key := range t.PdfObjectDictionary.Keys() {
+				stream.PdfObjectDictionary.Set(key, c.Copy(t.PdfObjectDictionary.Get(key)))
+			}
+		}
+		return stream
+	case *PdfObjectStreams:
+		streams := &PdfObjectStreams{}
+		c.number(&streams.PdfObjectReference)
+		c.copies[obj] = streams
+		for _, val := range t.Elements() {
+			streams.Append(c.Copy(val))
+		}
+		return streams
+	case *PdfObjectDictionary:
+		dict := MakeDict()
+		c.copies[obj] = dict
+		for _, key := range t.Keys() {
+			dict.Set(key, c.Copy(t.Get(key)))
+		}
+		return dict
+	case *PdfObjectArray:
+		arr := MakeArray()
+		c.copies[obj] = arr
+		for _, val := range t.Elements() {
+			arr.Append(c.Copy(val))
+		}
+		return arr
+	case *PdfObjectString:
+		str := *t
+		return &str
+	case *PdfObjectName:
+		name := *t
+		return &name
+	case *PdfObjectInteger:
+		val := *t
+		return &val
+	case *PdfObjectFloat:
+		val := *t
+		return &val
+	case *PdfObjectBool:
+		val := *t
+		return &val
+	case *PdfObjectNull:
+		return MakeNull()
+	}
+
+	common.Log.Debug("ERROR: unable to copy object of type %T", obj)
+	return MakeNull()
+}
+
+// number assigns the next object number to `ref`, if the copied objects are
+// numbered.
+func (c *ObjectCopier) number(ref *PdfObjectReference) {
+	if c.nextObjectNumber <= 0 {
+		return
+	}
+	ref.ObjectNumber = c.nextObjectNumber
+	c.nextObjectNumber++
+}
+
+// DeepCopy returns a deep copy of `obj`, as described by ObjectCopier.
+// The copied indirect objects are not numbered.
+func DeepCopy(obj PdfObject) PdfObject {
+	return NewObjectCopier(0).Copy(obj)
+}
@@ -0,0 +1,84 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeepCopy(t *testing.T) {
+	// Shared indirect object and a cyclic reference.
+	shared := MakeIndirectObject(MakeDict())
+	shared.ObjectNumber = 5
+	root := MakeDict()
+	root.Set("A", shared)
+	root.Set("B", MakeArray(shared, MakeInteger(1), MakeString("text"), MakeFloat(1.5)))
+	root.Set("Name", MakeName("Value"))
+	shared.PdfObject.(*PdfObjectDictionary).Set("Root", root)
+
+	stream, err := MakeStream([]byte("content"), nil)
+	require.NoError(t, err)
+	root.Set("S", stream)
+
+	rootCopy, ok := DeepCopy(root).(*PdfObjectDictionary)
+	require.True(t, ok)
+	require.Equal(t, "<</A 0 0 R/B [0 0 R 1 (text) 1.5]/Name /Value/S 0 0 R>>", rootCopy.WriteString())
+
+	sharedCopy, ok := GetIndirect(rootCopy.Get("A"))
+	require.True(t, ok)
+	require.True(t, sharedCopy != shared)
+	require.Zero(t, sharedCopy.ObjectNumber)
+
+	// The shared structure is preserved.
+	arr, ok := GetArray(rootCopy.Get("B"))
+	require.True(t, ok)
+	require.True(t, arr.Get(0) == sharedCopy)
+	require.True(t, sharedCopy.PdfObject.(*PdfObjectDictionary).Get("Root") == rootCopy)
+
+	// Modifying the copy does not affect the source.
+	streamCopy, ok := GetStream(rootCopy.Get("S"))
+	require.True(t, ok)
+	streamCopy.Stream[0] = 'C'
+	rootCopy.Set("Name", MakeName("Other"))
+	*arr.Get(1).(*PdfObjectInteger) = 2
+	require.Equal(t, "content", string(stream.Stream))
+	require.Equal(t, "Value", root.Get("Name").String())
+	require.Equal(t, "1", root.Get("B").(*PdfObjectArray).Get(1).String())
+}
+
+func TestObjectCopier(t *testing.T) {
+	// Resolve references using the object cache of the source parser.
+	obj := MakeIndirectObject(MakeDict())
+	obj.ObjectNumber = 3
+	parser := &PdfParser{ObjCache: objectCache{3: obj}}
+	ref := &PdfObjectReference{parser: parser, ObjectNumber: 3}
+
+	copier := NewObjectCopier(10)
+	arr, ok := copier.Copy(MakeArray(ref, ref)).(*PdfObjectArray)
+	require.True(t, ok)
+	ind, ok := arr.Get(0).(*PdfIndirectObject)
+	require.True(t, ok)
+	require.True(t, ind != obj)
+	require.True(t, arr.Get(1) == ind)
+	require.Equal(t, int64(10), ind.ObjectNumber)
+
+	// Copied objects are cached across calls.
+	require.True(t, copier.Copy(obj) == ind)
+
+	stream, err := MakeStream(nil, nil)
+	require.NoError(t, err)
+	streamCopy, ok := copier.Copy(stream).(*PdfObjectStream)
+	require.True(t, ok)
+	require.Equal(t, int64(11), streamCopy.ObjectNumber)
+	require.Equal(t, int64(12), copier.NextObjectNumber())
+
+	// References without a parser are kept.
+	refCopy, ok := copier.Copy(&PdfObjectReference{ObjectNumber: 7}).(*PdfObjectReference)
+	require.True(t, ok)
+	require.Equal(t, int64(7), refCopy.ObjectNumber)
+}
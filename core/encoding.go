@@ -76,6 +76,35 @@ type FlateEncoder struct {
 	// For predictors
 	Columns int
 	Colors  int
+
+	// CompressionLevel is the zlib compression level used for encoding,
+	// ranging from zlib.BestSpeed (1) to zlib.BestCompression (9).
+	// A value of 0 selects zlib.DefaultCompression.
+	CompressionLevel int
+}
+
+// FlateEncoderOptions contains the parameters of a FlateEncoder.
+type FlateEncoderOptions struct {
+	// CompressionLevel is the zlib compression level, ranging from
+	// zlib.BestSpeed (1) to zlib.BestCompression (9).
+	// A value of 0 selects zlib.DefaultCompression.
+	CompressionLevel int
+
+	// Predictor is the predictor applied to the data before compression.
+	// Supported values are 1 (no prediction) and 10-15 (PNG predictors).
+	// A value of 0 disables prediction.
+	Predictor int
+
+	// Columns is the number of samples per row. Only used by predictors.
+	Columns int
+
+	// Colors is the number of color components per sample. Only used by
+	// predictors. Defaults to 1.
+	Colors int
+
+	// BitsPerComponent is the number of bits used to represent each color
+	// component. Only used by predictors. Defaults to 8.
+	BitsPerComponent int
 }
 
 // NewFlateEncoder makes a new flate encoder with default parameters, predictor 1 and bits per component 8.
@@ -91,6 +120,35 @@ func NewFlateEncoder() *FlateEncoder {
 	encoder.Colors = 1
 	encoder.Columns = 1
 
+	encoder.CompressionLevel = zlib.DefaultCompression
+
+	return encoder
+}
+
+// NewFlateEncoderWithOptions makes a new flate encoder using the specified
+// options. Unset options use the default values of NewFlateEncoder.
+func NewFlateEncoderWithOptions(opts *FlateEncoderOptions) *FlateEncoder {
+	encoder := NewFlateEncoder()
+	if opts == nil {
+		return encoder
+	}
+
+	if opts.CompressionLevel != 0 {
+		encoder.CompressionLevel = opts.CompressionLevel
+	}
+	if opts.Predictor > 1 {
+		encoder.Predictor = opts.Predictor
+		if opts.Columns > 0 {
+			encoder.Columns = opts.Columns
+		}
+		if opts.Colors > 0 {
+			encoder.Colors = opts.Colors
+		}
+		if opts.BitsPerComponent > 0 {
+			encoder.BitsPerComponent = opts.BitsPerComponent
+		}
+	}
+
 	return encoder
 }
 
@@ -189,7 +247,9 @@ func newFlateEncoderFromStream(streamObj *PdfObjectStream, decodeParams *PdfObje
 				common.Log.Debug("Error: DecodeParms array length != 1 (%d)", arr.Len())
 				return nil, errors.New("range check error")
 			}
-			obj = TraceToDirectObject(arr.Get(0))
+			if dp, ok := GetDict(arr.Get(0)); ok {
+				decodeParams = dp
+			}
 		case *PdfObjectDictionary:
 			decodeParams = t
 		case *PdfObjectNull, nil:
@@ -291,134 +351,18 @@ const (
 
 // Apply predictor to decoded `outData` to get final output data.
 func (enc *FlateEncoder) postDecodePredict(outData []byte) ([]byte, error) {
-	if enc.Predictor > 1 {
-		if enc.Predictor == 2 { // TIFF encoding.
-			// TODO(gunnsth): Needs test cases.
-			common.Log.Trace("Tiff encoding")
-			common.Log.Trace("Colors: %d", enc.Colors)
-
-			rowLength := int(enc.Columns) * enc.Colors
-			if rowLength < 1 {
-				// No data. Return empty set.
-				return []byte{}, nil
-			}
-			rows := len(outData) / rowLength
-			if len(outData)%rowLength != 0 {
-				common.Log.Debug("ERROR: TIFF encoding: Invalid row length...")
-				return nil, fmt.Errorf("invalid row length (%d/%d)", len(outData), rowLength)
-			}
-			if rowLength%enc.Colors != 0 {
-				return nil, fmt.Errorf("invalid row length (%d) for colors %d", rowLength, enc.Colors)
-			}
-			if rowLength > len(outData) {
-				common.Log.Debug("Row length cannot be longer than data length (%d/%d)", rowLength, len(outData))
-				return nil, errors.New("range check error")
-			}
-			common.Log.Trace("inp outData (%d): % x", len(outData), outData)
-
-			pOutBuffer := bytes.NewBuffer(nil)
-
-			// 0-255  -255 255 ; 0-255=-255;
-			for i := 0; i < rows; i++ {
-				rowData := outData[rowLength*i : rowLength*(i+1)]
-				// Predicts the same as the sample to the left.
-				// Interleaved by colors.
-				for j := enc.Colors; j < rowLength; j++ {
-					rowData[j] += rowData[j-enc.Colors]
-				}
-				pOutBuffer.Write(rowData)
-			}
-			pOutData := pOutBuffer.Bytes()
-			common.Log.Trace("POutData (%d): % x", len(pOutData), pOutData)
-			return pOutData, nil
-		} else if enc.Predictor >= 10 && enc.Predictor <= 15 {
-			common.Log.Trace("PNG Encoding")
-			// Columns represents the number of samples per row; Each sample can contain multiple color
-			// components.
-			rowLength := int(enc.Columns*enc.Colors + 1) // 1 byte to specify predictor algorithms per row.
-			rows := len(outData) / rowLength
-			if len(outData)%rowLength != 0 {
-				return nil, fmt.Errorf("invalid row length (%d/%d)", len(outData), rowLength)
-			}
-			if rowLength > len(outData) {
-				common.Log.Debug("Row length cannot be longer than data length (%d/%d)", rowLength, len(outData))
-				return nil, errors.New("range check error")
-			}
-
-			pOutBuffer := bytes.NewBuffer(nil)
-
-			common.Log.Trace("Predictor columns: %d", enc.Columns)
-			common.Log.Trace("Length: %d / %d = %d rows", len(outData), rowLength, rows)
-			prevRowData := make([]byte, rowLength)
-			for i := 0; i < rowLength; i++ {
-				prevRowData[i] = 0
-			}
-
-			bytesPerPixel := enc.Colors // Assuming BPC = 8.
-
-			for i := 0; i < rows; i++ {
-				rowData := outData[rowLength*i : rowLength*(i+1)]
-
-				fb := rowData[0]
-				switch fb {
-				case pfNone:
-				case pfSub:
-					for j := 1 + bytesPerPixel; j < rowLength; j++ {
-						rowData[j] += rowData[j-bytesPerPixel]
-					}
-				case pfUp:
-					// Up: Predicts the same as the sample above
-					for j := 1; j < rowLength; j++ {
-						rowData[j] += prevRowData[j]
-					}
-				case pfAvg:
-					// Avg: Predicts the same as the average of the sample to the left and above.
-					for j := 1; j < bytesPerPixel+1; j++ {
-						rowData[j] += prevRowData[j] / 2
-					}
-					for j := bytesPerPixel + 1; j < rowLength; j++ {
-						rowData[j] += byte((int(rowData[j-bytesPerPixel]) + int(prevRowData[j])) / 2)
-					}
-				case pfPaeth:
-					// Paeth: a nonlinear function of the sample to the left (a), sample above (b)
-					// and the upper left (c).
-					for j := 1; j < rowLength; j++ {
-						var a, b, c byte
-						b = prevRowData[j] // above.
-						if j >= bytesPerPixel+1 {
-							a = rowData[j-bytesPerPixel]
-							c = prevRowData[j-bytesPerPixel]
-						}
-						rowData[j] += paeth(a, b, c)
-					}
-				default:
-					common.Log.Debug("ERROR: Invalid filter byte (%d) @row %d", fb, i)
-					return nil, fmt.Errorf("invalid filter byte (%d)", fb)
-				}
-
-				copy(prevRowData, rowData)
-				pOutBuffer.Write(rowData[1:])
-			}
-			pOutData := pOutBuffer.Bytes()
-			return pOutData, nil
-		} else {
-			common.Log.Debug("ERROR: Unsupported predictor (%d)", enc.Predictor)
-			return nil, fmt.Errorf("unsupported predictor (%d)", enc.Predictor)
-		}
-	}
-
-	return outData, nil
+	return decodePredictor(outData, predictorParams{
+		Predictor:        enc.Predictor,
+		Columns:          enc.Columns,
+		Colors:           enc.Colors,
+		BitsPerComponent: enc.BitsPerComponent,
+	})
 }
 
 // DecodeStream decodes a FlateEncoded stream object and give back decoded bytes.
 func (enc *FlateEncoder) DecodeStream(streamObj *PdfObjectStream) ([]byte, error) {
-	// TODO: Handle more filter bytes and support more values of BitsPerComponent.
-
 	common.Log.Trace("FlateDecode stream")
 	common.Log.Trace("Predictor: %d", enc.Predictor)
-	if enc.BitsPerComponent != 8 {
-		return nil, fmt.Errorf("invalid BitsPerComponent=%d (only 8 supported)", enc.BitsPerComponent)
-	}
 
 	outData, err := enc.DecodeBytes(streamObj.Stream)
 	if err != nil {
@@ -430,49 +374,119 @@ func (enc *FlateEncoder) DecodeStream(streamObj *PdfObjectStream) ([]byte, error
 
 // EncodeBytes encodes a bytes array and return the encoded value based on the encoder parameters.
 func (enc *FlateEncoder) EncodeBytes(data []byte) ([]byte, error) {
-	if enc.Predictor != 1 && enc.Predictor != 11 {
-		common.Log.Debug("Encoding error: FlateEncoder Predictor = 1, 11 only supported")
+	if enc.Predictor != 1 && (enc.Predictor < 10 || enc.Predictor > 15) {
+		common.Log.Debug("Encoding error: FlateEncoder Predictor = 1, 10-15 only supported")
 		return nil, ErrUnsupportedEncodingParameters
 	}
 
-	if enc.Predictor == 11 {
-		// The length of each output row in number of samples.
-		// N.B. Each output row has one extra sample as compared to the input to indicate the
-		// predictor type.
-		rowLength := int(enc.Columns)
-		rows := len(data) / rowLength
-		if len(data)%rowLength != 0 {
-			common.Log.Error("Invalid row length")
-			return nil, errors.New("invalid row length")
+	if enc.Predictor >= 10 {
+		predicted, err := enc.preEncodePredict(data)
+		if err != nil {
+			return nil, err
 		}
+		data = predicted
+	}
+
+	level := enc.CompressionLevel
+	if level == 0 {
+		level = zlib.DefaultCompression
+	}
+
+	var b bytes.Buffer
+	w, err := zlib.NewWriterLevel(&b, level)
+	if err != nil {
+		common.Log.Debug("Encoding error: invalid compression level %d", level)
+		return nil, err
+	}
+	w.Write(data)
+	w.Close()
 
-		pOutBuffer := bytes.NewBuffer(nil)
+	return b.Bytes(), nil
+}
 
-		tmpData := make([]byte, rowLength)
+// preEncodePredict applies the PNG predictor of the encoder to `data`.
+// Each output row is prefixed with the filter type used for the row.
+// Predictors 10-14 use the same filter type for all the rows, while
+// predictor 15 (optimum) selects the filter type of each row separately.
+func (enc *FlateEncoder) preEncodePredict(data []byte) ([]byte, error) {
+	if enc.BitsPerComponent <= 0 || enc.Colors <= 0 || enc.Columns <= 0 {
+		return nil, ErrUnsupportedEncodingParameters
+	}
 
-		for i := 0; i < rows; i++ {
-			rowData := data[rowLength*i : rowLength*(i+1)]
+	// The length of each input row in bytes.
+	rowLength := (enc.Columns*enc.Colors*enc.BitsPerComponent + 7) / 8
+	if len(data)%rowLength != 0 {
+		common.Log.Debug("ERROR: invalid row length (%d/%d)", len(data), rowLength)
+		return nil, errors.New("invalid row length")
+	}
+	rows := len(data) / rowLength
 
-			// PNG SUB method.
-			// Sub: Predicts the same as the sample to the left.
-			tmpData[0] = rowData[0]
-			for j := 1; j < rowLength; j++ {
-				tmpData[j] = byte(int(rowData[j]-rowData[j-1]) % 256)
-			}
+	bytesPerPixel := (enc.Colors*enc.BitsPerComponent + 7) / 8
+
+	pOutBuffer := bytes.NewBuffer(make([]byte, 0, len(data)+rows))
+	prevRowData := make([]byte, rowLength)
+	tmpData := make([]byte, rowLength)
+	bestData := make([]byte, rowLength)
+
+	for i := 0; i < rows; i++ {
+		rowData := data[rowLength*i : rowLength*(i+1)]
 
-			pOutBuffer.WriteByte(1) // sub method
+		if enc.Predictor == 15 {
+			// Optimum: pick the filter which minimizes the sum of absolute
+			// differences (heuristic recommended by the PNG specification).
+			bestFilter, bestSum := byte(pfNone), -1
+			for filter := byte(pfNone); filter <= pfPaeth; filter++ {
+				pngFilterRow(filter, rowData, prevRowData, tmpData, bytesPerPixel)
+
+				sum := 0
+				for _, b := range tmpData {
+					sum += abs(int(int8(b)))
+				}
+				if bestSum < 0 || sum < bestSum {
+					bestFilter, bestSum = filter, sum
+					copy(bestData, tmpData)
+				}
+			}
+			pOutBuffer.WriteByte(bestFilter)
+			pOutBuffer.Write(bestData)
+		} else {
+			filter := byte(enc.Predictor - 10)
+			pngFilterRow(filter, rowData, prevRowData, tmpData, bytesPerPixel)
+			pOutBuffer.WriteByte(filter)
 			pOutBuffer.Write(tmpData)
 		}
 
-		data = pOutBuffer.Bytes()
+		copy(prevRowData, rowData)
 	}
 
-	var b bytes.Buffer
-	w := zlib.NewWriter(&b)
-	w.Write(data)
-	w.Close()
+	return pOutBuffer.Bytes(), nil
+}
 
-	return b.Bytes(), nil
+// pngFilterRow applies the PNG `filter` type to `rowData` and writes the
+// output to `out`. The `prevRowData` slice contains the previous (unfiltered)
+// row, or zeros for the first row.
+func pngFilterRow(filter byte, rowData, prevRowData, out []byte, bytesPerPixel int) {
+	for j := range rowData {
+		var a, b, c byte
+		if j >= bytesPerPixel {
+			a = rowData[j-bytesPerPixel]
+			c = prevRowData[j-bytesPerPixel]
+		}
+		b = prevRowData[j]
+
+		switch filter {
+		case pfSub:
+			out[j] = rowData[j] - a
+		case pfUp:
+			out[j] = rowData[j] - b
+		case pfAvg:
+			out[j] = rowData[j] - byte((int(a)+int(b))/2)
+		case pfPaeth:
+			out[j] = rowData[j] - paeth(a, b, c)
+		default:
+			out[j] = rowData[j]
+		}
+	}
 }
 
 // LZWEncoder provides LZW encoding/decoding functionality.
@@ -511,8 +525,12 @@ func (enc *LZWEncoder) GetFilterName() string {
 // MakeDecodeParams makes a new instance of an encoding dictionary based on
 // the current encoder settings.
 func (enc *LZWEncoder) MakeDecodeParams() PdfObject {
+	if enc.Predictor <= 1 && enc.EarlyChange == 1 {
+		return nil
+	}
+
+	decodeParams := MakeDict()
 	if enc.Predictor > 1 {
-		decodeParams := MakeDict()
 		decodeParams.Set("Predictor", MakeInteger(int64(enc.Predictor)))
 
 		// Only add if not default option.
@@ -525,9 +543,12 @@ func (enc *LZWEncoder) MakeDecodeParams() PdfObject {
 		if enc.Colors != 1 {
 			decodeParams.Set("Colors", MakeInteger(int64(enc.Colors)))
 		}
-		return decodeParams
 	}
-	return nil
+	// EarlyChange defaults to 1.
+	if enc.EarlyChange != 1 {
+		decodeParams.Set("EarlyChange", MakeInteger(int64(enc.EarlyChange)))
+	}
+	return decodeParams
 }
 
 // MakeStreamDict makes a new instance of an encoding dictionary for a stream object.
@@ -542,8 +563,6 @@ func (enc *LZWEncoder) MakeStreamDict() *PdfObjectDictionary {
 		dict.Set("DecodeParms", decodeParams)
 	}
 
-	dict.Set("EarlyChange", MakeInteger(int64(enc.EarlyChange)))
-
 	return dict
 }
 
@@ -611,7 +630,12 @@ func newLZWEncoderFromStream(streamObj *PdfObjectStream, decodeParams *PdfObject
 	// implementations use a different mechanisms. Essentially this chooses
 	// which LZW implementation to use.
 	// The default is 1 (one code early)
+	// The entry belongs to the DecodeParms dictionary, but is also accepted
+	// in the stream dictionary.
 	obj := encDict.Get("EarlyChange")
+	if decodeParams != nil && decodeParams.Get("EarlyChange") != nil {
+		obj = decodeParams.Get("EarlyChange")
+	}
 	if obj != nil {
 		earlyChange, ok := obj.(*PdfObjectInteger)
 		if !ok {
@@ -710,11 +734,6 @@ func (enc *LZWEncoder) DecodeBytes(encoded []byte) ([]byte, error) {
 // DecodeStream decodes a LZW encoded stream and returns the result as a
 // slice of bytes.
 func (enc *LZWEncoder) DecodeStream(streamObj *PdfObjectStream) ([]byte, error) {
-	// Revamp this support to handle TIFF predictor (2).
-	// Also handle more filter bytes and check
-	// BitsPerComponent.  Default value is 8, currently we are only
-	// supporting that one.
-
 	common.Log.Trace("LZW Decoding")
 	common.Log.Trace("Predictor: %d", enc.Predictor)
 
@@ -726,112 +745,12 @@ func (enc *LZWEncoder) DecodeStream(streamObj *PdfObjectStream) ([]byte, error)
 	common.Log.Trace(" IN: (%d) % x", len(streamObj.Stream), streamObj.Stream)
 	common.Log.Trace("OUT: (%d) % x", len(outData), outData)
 
-	if enc.Predictor > 1 {
-		if enc.Predictor == 2 { // TIFF encoding: Needs some tests.
-			common.Log.Trace("Tiff encoding")
-
-			rowLength := int(enc.Columns) * enc.Colors
-			if rowLength < 1 {
-				// No data. Return empty set.
-				return []byte{}, nil
-			}
-
-			rows := len(outData) / rowLength
-			if len(outData)%rowLength != 0 {
-				common.Log.Debug("ERROR: TIFF encoding: Invalid row length...")
-				return nil, fmt.Errorf("invalid row length (%d/%d)", len(outData), rowLength)
-			}
-
-			if rowLength%enc.Colors != 0 {
-				return nil, fmt.Errorf("invalid row length (%d) for colors %d", rowLength, enc.Colors)
-			}
-
-			if rowLength > len(outData) {
-				common.Log.Debug("Row length cannot be longer than data length (%d/%d)", rowLength, len(outData))
-				return nil, errors.New("range check error")
-			}
-			common.Log.Trace("inp outData (%d): % x", len(outData), outData)
-
-			pOutBuffer := bytes.NewBuffer(nil)
-
-			// 0-255  -255 255 ; 0-255=-255;
-			for i := 0; i < rows; i++ {
-				rowData := outData[rowLength*i : rowLength*(i+1)]
-				// Predicts the same as the sample to the left.
-				// Interleaved by colors.
-				for j := enc.Colors; j < rowLength; j++ {
-					rowData[j] = byte(int(rowData[j]+rowData[j-enc.Colors]) % 256)
-				}
-				// GH: Appears that this is not working as expected...
-
-				pOutBuffer.Write(rowData)
-			}
-			pOutData := pOutBuffer.Bytes()
-			common.Log.Trace("POutData (%d): % x", len(pOutData), pOutData)
-			return pOutData, nil
-		} else if enc.Predictor >= 10 && enc.Predictor <= 15 {
-			common.Log.Trace("PNG Encoding")
-			// Columns represents the number of samples per row; Each sample can contain multiple color
-			// components.
-			rowLength := int(enc.Columns*enc.Colors + 1) // 1 byte to specify predictor algorithms per row.
-			if rowLength < 1 {
-				// No data. Return empty set.
-				return []byte{}, nil
-			}
-			rows := len(outData) / rowLength
-			if len(outData)%rowLength != 0 {
-				return nil, fmt.Errorf("invalid row length (%d/%d)", len(outData), rowLength)
-			}
-			if rowLength > len(outData) {
-				common.Log.Debug("Row length cannot be longer than data length (%d/%d)", rowLength, len(outData))
-				return nil, errors.New("range check error")
-			}
-
-			pOutBuffer := bytes.NewBuffer(nil)
-
-			common.Log.Trace("Predictor columns: %d", enc.Columns)
-			common.Log.Trace("Length: %d / %d = %d rows", len(outData), rowLength, rows)
-			prevRowData := make([]byte, rowLength)
-			for i := 0; i < rowLength; i++ {
-				prevRowData[i] = 0
-			}
-
-			for i := 0; i < rows; i++ {
-				rowData := outData[rowLength*i : rowLength*(i+1)]
-
-				fb := rowData[0]
-				switch fb {
-				case 0:
-					// No prediction. (No operation).
-				case 1:
-					// Sub: Predicts the same as the sample to the left.
-					for j := 2; j < rowLength; j++ {
-						rowData[j] = byte(int(rowData[j]+rowData[j-1]) % 256)
-					}
-				case 2:
-					// Up: Predicts the same as the sample above
-					for j := 1; j < rowLength; j++ {
-						rowData[j] = byte(int(rowData[j]+prevRowData[j]) % 256)
-					}
-				default:
-					common.Log.Debug("ERROR: Invalid filter byte (%d)", fb)
-					return nil, fmt.Errorf("invalid filter byte (%d)", fb)
-				}
-
-				for i := 0; i < rowLength; i++ {
-					prevRowData[i] = rowData[i]
-				}
-				pOutBuffer.Write(rowData[1:])
-			}
-			pOutData := pOutBuffer.Bytes()
-			return pOutData, nil
-		} else {
-			common.Log.Debug("ERROR: Unsupported predictor (%d)", enc.Predictor)
-			return nil, fmt.Errorf("unsupported predictor (%d)", enc.Predictor)
-		}
-	}
-
-	return outData, nil
+	return decodePredictor(outData, predictorParams{
+		Predictor:        enc.Predictor,
+		Columns:          enc.Columns,
+		Colors:           enc.Colors,
+		BitsPerComponent: enc.BitsPerComponent,
+	})
 }
 
 // EncodeBytes implements support for LZW encoding.  Currently not supporting predictors (raw compressed data only).
@@ -2148,7 +2067,7 @@ func newMultiEncoderFromStream(streamObj *PdfObjectStream) (*MultiEncoder, error
 			common.Log.Trace("Multi encoder: %#v", mencoder)
 		} else {
 			common.Log.Error("Unsupported filter %s", *name)
-			return nil, fmt.Errorf("invalid filter in multi filter array")
+			return nil, fmt.Errorf("%w: invalid filter in multi filter array (%s)", ErrUnsupportedFilter, *name)
 		}
 	}
 
@@ -16,6 +16,7 @@ import (
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/bitmap"
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/decoder"
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/document"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/encoder/classer"
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/errors"
 )
 
@@ -26,8 +27,9 @@ const (
 	// JB2Generic is the JBIG2 compression type that uses generic region see 6.2.
 	JB2Generic JBIG2CompressionType = iota
 	// JB2SymbolCorrelation is the JBIG2 compression type that uses symbol dictionary and text region encoding procedure
-	// with the correlation classification.
-	// NOT IMPLEMENTED YET.
+	// with the correlation classification. The similar components are classified using the 'Threshold'
+	// settings value. If the 'Lossless' setting is set, the differences between the page and the classified
+	// symbols are additionally encoded, so that the decoded page is exactly the same as the input image.
 	JB2SymbolCorrelation
 	// JB2SymbolRankHaus is the JBIG2 compression type that uses symbol dictionary and text region encoding procedure
 	// with the rank hausdorff classification. RankHausMode uses the rank Hausdorff method that classifies the input images.
@@ -76,6 +78,9 @@ type JBIG2Encoder struct {
 	IsChocolateData bool
 	// DefaultPageSettings are the settings parameters used by the jbig2 encoder.
 	DefaultPageSettings JBIG2EncoderSettings
+
+	// globalsData is the encoded global segments data stored by the EncodeGenericImage.
+	globalsData []byte
 }
 
 // NewJBIG2Encoder creates a new JBIG2Encoder.
@@ -111,11 +116,13 @@ func (enc *JBIG2Encoder) AddPageImage(img *JBIG2Image, settings *JBIG2EncoderSet
 
 	switch settings.Compression {
 	case JB2Generic:
-		if err = enc.d.AddGenericPage(b, settings.DuplicatedLinesRemoval); err != nil {
+		if err = enc.d.AddGenericPageTemplate(b, settings.GenericTemplate, settings.UseMMR, settings.DuplicatedLinesRemoval); err != nil {
 			return errors.Wrap(err, processName, "")
 		}
 	case JB2SymbolCorrelation:
-		return errors.Error(processName, "symbol correlation encoding not implemented yet")
+		if err = enc.addSymbolPage(b, settings); err != nil {
+			return errors.Wrap(err, processName, "")
+		}
 	case JB2SymbolRankHaus:
 		return errors.Error(processName, "symbol rank haus encoding not implemented yet")
 	default:
@@ -124,6 +131,28 @@ func (enc *JBIG2Encoder) AddPageImage(img *JBIG2Image, settings *JBIG2EncoderSet
 	return nil
 }
 
+// addSymbolPage adds the page bitmap 'b' classified using the correlation method into the symbols
+// with respect to the 'settings' Threshold. The classer is initialized with the settings of the first page.
+func (enc *JBIG2Encoder) addSymbolPage(b *bitmap.Bitmap, settings *JBIG2EncoderSettings) (err error) {
+	const processName = "addSymbolPage"
+	if enc.d.Classer == nil {
+		classerSettings := classer.Settings{Thresh: settings.Threshold}
+		classerSettings.SetDefault()
+		if enc.d.Classer, err = classer.Init(classerSettings); err != nil {
+			return errors.Wrap(err, processName, "")
+		}
+	}
+	if settings.Lossless {
+		err = enc.d.AddLosslessClassifiedPage(b, classer.Correlation)
+	} else {
+		err = enc.d.AddClassifiedPage(b, classer.Correlation)
+	}
+	if err != nil {
+		return errors.Wrap(err, processName, "")
+	}
+	return nil
+}
+
 // DecodeBytes decodes a slice of JBIG2 encoded bytes and returns the results.
 func (enc *JBIG2Encoder) DecodeBytes(encoded []byte) ([]byte, error) {
 	parameters := decoder.Parameters{UnpaddedData: true}
@@ -192,11 +221,13 @@ func (enc *JBIG2Encoder) EncodeBytes(data []byte) ([]byte, error) {
 
 	switch settings.Compression {
 	case JB2Generic:
-		if err = enc.d.AddGenericPage(b, settings.DuplicatedLinesRemoval); err != nil {
+		if err = enc.d.AddGenericPageTemplate(b, settings.GenericTemplate, settings.UseMMR, settings.DuplicatedLinesRemoval); err != nil {
 			return nil, errors.Wrap(err, processName, "")
 		}
 	case JB2SymbolCorrelation:
-		return nil, errors.Error(processName, "symbol correlation encoding not implemented yet")
+		if err = enc.addSymbolPage(b, &settings); err != nil {
+			return nil, errors.Wrap(err, processName, "")
+		}
 	case JB2SymbolRankHaus:
 		return nil, errors.Error(processName, "symbol rank haus encoding not implemented yet")
 	default:
@@ -219,6 +250,56 @@ func (enc *JBIG2Encoder) EncodeJBIG2Image(img *JBIG2Image) ([]byte, error) {
 	return enc.Encode()
 }
 
+// EncodeGenericImage encodes the 1-bpp image 'img' as a single page JBIG2 generic region stream.
+// The generic region is encoded using the DefaultPageSettings GenericTemplate, UseMMR and DuplicatedLinesRemoval
+// settings. Returns the encoded page 'data' and the encoded 'globals' segments data, which are stored
+// in the PDF documents in the separate stream referred by the JBIG2Globals decode parameter.
+// The globals are nil if the encoded document doesn't contain any global segments.
+// The globals are also used by the MakeDecodeParams and MakeStreamDict methods, so that the encoder
+// can be used as the stream filter for the encoded data.
+func (enc *JBIG2Encoder) EncodeGenericImage(img *JBIG2Image) (data, globals []byte, err error) {
+	const processName = "JBIG2Encoder.EncodeGenericImage"
+	if enc.DefaultPageSettings.Compression != JB2Generic {
+		return nil, nil, errors.Error(processName, "generic compression required")
+	}
+	if data, globals, err = enc.encodeWithGlobals(img); err != nil {
+		return nil, nil, errors.Wrap(err, processName, "")
+	}
+	return data, globals, nil
+}
+
+// EncodeSymbolImage encodes the 1-bpp image 'img' as a single page JBIG2 stream, where the page components
+// are classified into the symbols stored in the global symbol dictionary and referenced by the page text region.
+// The classification uses the DefaultPageSettings Threshold and Lossless settings.
+// Returns the encoded page 'data' and the encoded 'globals' segments data containing the symbol dictionary,
+// which are stored in the PDF documents in the separate stream referred by the JBIG2Globals decode parameter.
+func (enc *JBIG2Encoder) EncodeSymbolImage(img *JBIG2Image) (data, globals []byte, err error) {
+	const processName = "JBIG2Encoder.EncodeSymbolImage"
+	if enc.DefaultPageSettings.Compression != JB2SymbolCorrelation {
+		return nil, nil, errors.Error(processName, "symbol correlation compression required")
+	}
+	if data, globals, err = enc.encodeWithGlobals(img); err != nil {
+		return nil, nil, errors.Wrap(err, processName, "")
+	}
+	return data, globals, nil
+}
+
+// encodeWithGlobals encodes the image 'img' page using DefaultPageSettings and returns the encoded
+// page 'data' and the 'globals' segments data separately.
+func (enc *JBIG2Encoder) encodeWithGlobals(img *JBIG2Image) (data, globals []byte, err error) {
+	const processName = "encodeWithGlobals"
+	settings := enc.DefaultPageSettings
+	if err = enc.AddPageImage(img, &settings); err != nil {
+		return nil, nil, errors.Wrap(err, processName, "")
+	}
+	enc.d.FullHeaders = settings.FileMode
+	if data, globals, err = enc.d.EncodeWithGlobals(); err != nil {
+		return nil, nil, errors.Wrap(err, processName, "")
+	}
+	enc.globalsData = globals
+	return data, globals, nil
+}
+
 // Encode encodes previously prepare jbig2 document and stores it as the byte slice.
 func (enc *JBIG2Encoder) Encode() (data []byte, err error) {
 	const processName = "JBIG2Document.Encode"
@@ -240,14 +321,27 @@ func (enc *JBIG2Encoder) GetFilterName() string {
 }
 
 // MakeDecodeParams makes a new instance of an encoding dictionary based on the current encoder settings.
+// If the encoder contains the encoded global segments (see EncodeGenericImage and EncodeSymbolImage), the JBIG2Globals stream is set.
 func (enc *JBIG2Encoder) MakeDecodeParams() PdfObject {
-	return MakeDict()
+	decodeParams := MakeDict()
+	if len(enc.globalsData) > 0 {
+		globals, err := MakeStream(enc.globalsData, nil)
+		if err != nil {
+			common.Log.Debug("ERROR: unable to create JBIG2Globals stream: %v", err)
+			return decodeParams
+		}
+		decodeParams.Set("JBIG2Globals", globals)
+	}
+	return decodeParams
 }
 
 // MakeStreamDict makes a new instance of an encoding dictionary for a stream object.
 func (enc *JBIG2Encoder) MakeStreamDict() *PdfObjectDictionary {
 	dict := MakeDict()
 	dict.Set("Filter", MakeName(enc.GetFilterName()))
+	if decodeParams, ok := GetDict(enc.MakeDecodeParams()); ok && len(decodeParams.Keys()) > 0 {
+		dict.Set("DecodeParms", decodeParams)
+	}
 	return dict
 }
 
@@ -445,7 +539,7 @@ func bwToJBIG2Image(i *image.Gray) *JBIG2Image {
 }
 
 // JBIG2EncoderSettings contains the parameters and settings used by the JBIG2Encoder.
-// Current version works only on JB2Generic compression.
+// Current version works on JB2Generic and JB2SymbolCorrelation compression.
 type JBIG2EncoderSettings struct {
 	// FileMode defines if the jbig2 encoder should return full jbig2 file instead of
 	// shortened pdf mode. This adds the file header to the jbig2 definition.
@@ -465,9 +559,21 @@ type JBIG2EncoderSettings struct {
 	// non Generic compression.
 	// User only for JB2SymbolCorrelation and JB2SymbolRankHaus methods.
 	// Best results in range [0.7 - 0.98] - the less the better the compression would be
-	// but the more lossy.
-	// Default value: 0.95
+	// but the more lossy. For JB2SymbolCorrelation the value must be in range [0.4 - 0.98].
+	// Default value: 0.9
 	Threshold float64
+	// Lossless defines if the differences between the page and the classified symbols are encoded,
+	// so that the decoded page is exactly the same as the input image.
+	// Used only for JB2SymbolCorrelation compression.
+	Lossless bool
+	// GenericTemplate is the generic region template (0-3) used by the arithmetic generic region encoding.
+	// Lower templates use larger pixel contexts, which usually results in a better compression.
+	// Used only for JB2Generic compression.
+	GenericTemplate int
+	// UseMMR defines if the generic region is encoded using the MMR (CCITT Group 4) coding instead of the
+	// arithmetic coding. The DuplicatedLinesRemoval and GenericTemplate are not used with the MMR coding.
+	// Used only for JB2Generic compression.
+	UseMMR bool
 }
 
 // Validate validates the page settings for the JBIG2 encoder.
@@ -485,7 +591,16 @@ func (s JBIG2EncoderSettings) Validate() error {
 	if s.DefaultPixelValue != 0 && s.DefaultPixelValue != 1 {
 		return errors.Errorf(processName, "default pixel value: '%d' must be a value for the bit: {0,1}", s.DefaultPixelValue)
 	}
-	if s.Compression != JB2Generic {
+	if s.GenericTemplate < 0 || s.GenericTemplate > 3 {
+		return errors.Errorf(processName, "generic template: '%d' must be in range [0, 3]", s.GenericTemplate)
+	}
+	switch s.Compression {
+	case JB2Generic:
+	case JB2SymbolCorrelation:
+		if s.Threshold != 0 && (s.Threshold < 0.4 || s.Threshold > 0.98) {
+			return errors.Errorf(processName, "provided symbol correlation threshold value: '%v' must be in range [0.4, 0.98]", s.Threshold)
+		}
+	default:
 		return errors.Errorf(processName, "provided compression is not implemented yet")
 	}
 	return nil
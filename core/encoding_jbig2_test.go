@@ -50,3 +50,182 @@ func TestImageToJBIG2Image(t *testing.T) {
 		assert.Equal(t, jb2.Data, bm.Data)
 	})
 }
+
+// TestJBIG2EncodeGenericImage tests the generic region encoding of the 1-bpp images using different settings.
+func TestJBIG2EncodeGenericImage(t *testing.T) {
+	// prepare the test bitmap containing some shapes and duplicated rows.
+	const width, height = 67, 40
+	bm := bitmap.New(width, height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			inFrame := x < 2 || x >= width-2 || y < 2 || y >= height-2
+			inCircle := (x-30)*(x-30)+(y-20)*(y-20) < 100
+			inStripe := y >= 25 && y < 30 && x%5 < 2
+			if inFrame || inCircle || inStripe {
+				require.NoError(t, bm.SetPixel(x, y, 1))
+			}
+		}
+	}
+	img := &JBIG2Image{Width: width, Height: height, Data: bm.Data, HasPadding: true}
+	expected, err := bm.GetUnpaddedData()
+	require.NoError(t, err)
+	// the decoded data uses the PDF convention, where the bit '1' is white.
+	for i := range expected {
+		expected[i] = ^expected[i]
+	}
+
+	testCases := []struct {
+		name     string
+		settings JBIG2EncoderSettings
+	}{
+		{"Template0", JBIG2EncoderSettings{}},
+		{"Template0TPGDON", JBIG2EncoderSettings{DuplicatedLinesRemoval: true}},
+		{"Template1", JBIG2EncoderSettings{GenericTemplate: 1}},
+		{"Template2TPGDON", JBIG2EncoderSettings{GenericTemplate: 2, DuplicatedLinesRemoval: true}},
+		{"Template3", JBIG2EncoderSettings{GenericTemplate: 3}},
+		{"MMR", JBIG2EncoderSettings{UseMMR: true}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			enc := NewJBIG2Encoder()
+			enc.DefaultPageSettings = tc.settings
+			data, globals, err := enc.EncodeGenericImage(img)
+			require.NoError(t, err)
+			require.Nil(t, globals)
+
+			dec := NewJBIG2Encoder()
+			decoded, err := dec.DecodeBytes(data)
+			require.NoError(t, err)
+			assert.Equal(t, expected, decoded)
+
+			dict := enc.MakeStreamDict()
+			name, ok := GetName(dict.Get("Filter"))
+			require.True(t, ok)
+			assert.Equal(t, StreamEncodingFilterNameJBIG2, name.String())
+		})
+	}
+
+	enc := NewJBIG2Encoder()
+	enc.DefaultPageSettings.GenericTemplate = 4
+	_, _, err = enc.EncodeGenericImage(img)
+	require.Error(t, err)
+}
+
+// TestJBIG2DecodeImages tests the round trip of the golang image through the jbig2 encoding and the
+// DecodeImages and ToGoImage conversions, which keep the black and white pixels and the image size.
+func TestJBIG2DecodeImages(t *testing.T) {
+	const width, height = 67, 40
+	g := image.NewGray(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			inFrame := x < 2 || x >= width-2 || y < 2 || y >= height-2
+			inCircle := (x-30)*(x-30)+(y-20)*(y-20) < 100
+			if inFrame || inCircle {
+				g.SetGray(x, y, color.Gray{})
+			} else {
+				g.SetGray(x, y, color.Gray{Y: 255})
+			}
+		}
+	}
+	jimg, err := GoImageToJBIG2(g, 0.5)
+	require.NoError(t, err)
+
+	comparePixels := func(t *testing.T, img image.Image) {
+		require.Equal(t, g.Bounds(), img.Bounds())
+		for y := 0; y < height; y++ {
+			for x := 0; x < width; x++ {
+				expected := g.GrayAt(x, y)
+				actual := color.GrayModel.Convert(img.At(x, y)).(color.Gray)
+				require.Equal(t, expected, actual, "pixel: %d, %d", x, y)
+			}
+		}
+	}
+
+	t.Run("DecodeImages", func(t *testing.T) {
+		enc := NewJBIG2Encoder()
+		data, err := enc.EncodeJBIG2Image(jimg)
+		require.NoError(t, err)
+
+		images, err := NewJBIG2Encoder().DecodeImages(data)
+		require.NoError(t, err)
+		require.Len(t, images, 1)
+		comparePixels(t, images[0])
+	})
+
+	t.Run("ToGoImage", func(t *testing.T) {
+		img, err := jimg.ToGoImage()
+		require.NoError(t, err)
+		comparePixels(t, img)
+	})
+}
+
+// TestJBIG2EncodeSymbolImage tests the symbol dictionary and text region encoding of the JBIG2Encoder.
+func TestJBIG2EncodeSymbolImage(t *testing.T) {
+	// prepare the test bitmap containing the rows of repeated glyphs, where some of them slightly differ.
+	glyphs := [][]string{
+		{"XXXXXX", "XX  XX", "XX  XX", "XXXXXX", "XX  XX", "XX  XX", "XX  XX"},
+		{" XXXXX", "XX    ", "XX    ", "XX    ", "XX    ", "XX    ", " XXXXX"},
+		{"XXXXXX", "XX  XX", "XX  XX", "XXXXXX", "XX  XX", "XX  XX", "XX XXX"},
+	}
+	const width, height = 240, 60
+	bm := bitmap.New(width, height)
+	for row := 0; row < 3; row++ {
+		for i := 0; i < 20; i++ {
+			for y, line := range glyphs[(i+row)%len(glyphs)] {
+				for x, c := range line {
+					if c == 'X' {
+						require.NoError(t, bm.SetPixel(5+i*11+x, 5+row*18+y, 1))
+					}
+				}
+			}
+		}
+	}
+	img := &JBIG2Image{Width: width, Height: height, Data: bm.Data, HasPadding: true}
+	// the decoded data uses the PDF convention, where the bit '1' is white.
+	expected := make([]byte, len(bm.Data))
+	for i := range expected {
+		expected[i] = ^bm.Data[i]
+	}
+
+	decode := func(t *testing.T, data, globals []byte) []byte {
+		dec := NewJBIG2Encoder()
+		var err error
+		dec.Globals, err = dec.DecodeGlobals(globals)
+		require.NoError(t, err)
+		decoded, err := dec.DecodeBytes(data)
+		require.NoError(t, err)
+		return decoded
+	}
+
+	t.Run("Lossless", func(t *testing.T) {
+		enc := NewJBIG2Encoder()
+		enc.DefaultPageSettings = JBIG2EncoderSettings{Compression: JB2SymbolCorrelation, Lossless: true}
+		data, globals, err := enc.EncodeSymbolImage(img)
+		require.NoError(t, err)
+		require.NotEmpty(t, globals)
+		assert.Equal(t, expected, decode(t, data, globals))
+
+		dict := enc.MakeStreamDict()
+		decodeParms, ok := GetDict(dict.Get("DecodeParms"))
+		require.True(t, ok)
+		_, ok = GetStream(decodeParms.Get("JBIG2Globals"))
+		assert.True(t, ok)
+	})
+
+	t.Run("Lossy", func(t *testing.T) {
+		enc := NewJBIG2Encoder()
+		enc.DefaultPageSettings = JBIG2EncoderSettings{Compression: JB2SymbolCorrelation}
+		data, globals, err := enc.EncodeSymbolImage(img)
+		require.NoError(t, err)
+		decoded := decode(t, data, globals)
+		require.Len(t, decoded, len(expected))
+		assert.NotEqual(t, expected, decoded)
+	})
+
+	t.Run("InvalidThreshold", func(t *testing.T) {
+		enc := NewJBIG2Encoder()
+		enc.DefaultPageSettings = JBIG2EncoderSettings{Compression: JB2SymbolCorrelation, Threshold: 0.99}
+		_, _, err := enc.EncodeSymbolImage(img)
+		require.Error(t, err)
+	})
+}
@@ -6,9 +6,12 @@
 package core
 
 import (
+	"compress/zlib"
 	"encoding/base64"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
 )
 
@@ -43,6 +46,53 @@ func TestFlateEncodingPredictor1(t *testing.T) {
 	}
 }
 
+// Test flate encoding round trips with compression levels and PNG predictors.
+func TestFlateEncodingOptionsRoundTrip(t *testing.T) {
+	// 6x4 RGB gradient image.
+	const columns, colors, rows = 6, 3, 4
+	var rawData []byte
+	for y := 0; y < rows; y++ {
+		for x := 0; x < columns; x++ {
+			rawData = append(rawData, byte(10*x+y), byte(20*y), byte(255-5*x*y))
+		}
+	}
+
+	for _, level := range []int{0, zlib.BestSpeed, zlib.BestCompression} {
+		for predictor := 10; predictor <= 15; predictor++ {
+			encoder := NewFlateEncoderWithOptions(&FlateEncoderOptions{
+				CompressionLevel: level,
+				Predictor:        predictor,
+				Columns:          columns,
+				Colors:           colors,
+			})
+
+			encoded, err := encoder.EncodeBytes(rawData)
+			require.NoError(t, err)
+
+			// Decode using the parameters written in the stream dictionary.
+			stream := &PdfObjectStream{Stream: encoded}
+			stream.PdfObjectDictionary = encoder.MakeStreamDict()
+			params, ok := GetDict(stream.Get("DecodeParms"))
+			require.True(t, ok)
+			require.Equal(t, MakeInteger(int64(predictor)).String(), params.Get("Predictor").String())
+
+			decoded, err := DecodeStream(stream)
+			require.NoError(t, err)
+			require.Equal(t, rawData, decoded, "level %d predictor %d", level, predictor)
+		}
+	}
+
+	// Invalid row lengths must be reported.
+	encoder := NewFlateEncoderWithOptions(&FlateEncoderOptions{Predictor: 12, Columns: 5, Colors: 3})
+	_, err := encoder.EncodeBytes(rawData)
+	require.Error(t, err)
+
+	// Unsupported predictors.
+	encoder = NewFlateEncoderWithOptions(&FlateEncoderOptions{Predictor: 2, Columns: columns})
+	_, err = encoder.EncodeBytes(rawData)
+	require.Equal(t, ErrUnsupportedEncodingParameters, err)
+}
+
 // Test post decoding predictors.
 func TestPostDecodingPredictors(t *testing.T) {
 
@@ -165,6 +215,136 @@ func TestPostDecodingPredictors(t *testing.T) {
 	}
 }
 
+// Test decoding Flate and LZW streams with TIFF (2) and PNG (12) predictors.
+func TestDecodeStreamPredictors(t *testing.T) {
+	testcases := []struct {
+		Name             string
+		Predictor        int
+		BitsPerComponent int
+		Colors           int
+		Columns          int
+		Input            []byte
+		Expected         []byte
+	}{
+		{
+			Name:             "TIFF BPC=8 Colors=3",
+			Predictor:        2,
+			BitsPerComponent: 8,
+			Colors:           3,
+			Columns:          2,
+			Input: []byte{
+				10, 20, 30, 1, 2, 255,
+				0, 0, 0, 5, 5, 5,
+			},
+			Expected: []byte{
+				10, 20, 30, 11, 22, 29,
+				0, 0, 0, 5, 5, 5,
+			},
+		},
+		{
+			Name:             "TIFF BPC=16 Colors=1",
+			Predictor:        2,
+			BitsPerComponent: 16,
+			Colors:           1,
+			Columns:          3,
+			Input:            []byte{0x01, 0x00, 0x00, 0x01, 0xff, 0xff},
+			Expected:         []byte{0x01, 0x00, 0x01, 0x01, 0x01, 0x00},
+		},
+		{
+			Name:             "TIFF BPC=4 Colors=1",
+			Predictor:        2,
+			BitsPerComponent: 4,
+			Colors:           1,
+			Columns:          4,
+			Input:            []byte{0x12, 0x3f},
+			Expected:         []byte{0x13, 0x65},
+		},
+		{
+			Name:             "TIFF BPC=1 Colors=1",
+			Predictor:        2,
+			BitsPerComponent: 1,
+			Colors:           1,
+			Columns:          8,
+			Input:            []byte{0x81},
+			Expected:         []byte{0xfe},
+		},
+		{
+			Name:             "PNG Up BPC=8 Colors=1",
+			Predictor:        12,
+			BitsPerComponent: 8,
+			Colors:           1,
+			Columns:          3,
+			Input: []byte{
+				pfUp, 1, 2, 3,
+				pfUp, 1, 1, 1,
+			},
+			Expected: []byte{
+				1, 2, 3,
+				2, 3, 4,
+			},
+		},
+		{
+			Name:             "PNG BPC=16 Colors=1",
+			Predictor:        12,
+			BitsPerComponent: 16,
+			Colors:           1,
+			Columns:          2,
+			Input: []byte{
+				pfSub, 0x00, 0x05, 0x00, 0x03,
+				pfUp, 0x00, 0x01, 0x01, 0x01,
+			},
+			Expected: []byte{
+				0x00, 0x05, 0x00, 0x08,
+				0x00, 0x06, 0x01, 0x09,
+			},
+		},
+		{
+			Name:             "PNG BPC=4 Colors=1",
+			Predictor:        12,
+			BitsPerComponent: 4,
+			Colors:           1,
+			Columns:          4,
+			Input: []byte{
+				pfSub, 0x12, 0x11,
+				pfUp, 0x11, 0x11,
+			},
+			Expected: []byte{
+				0x12, 0x23,
+				0x23, 0x34,
+			},
+		},
+	}
+
+	flateEncoder := NewFlateEncoder()
+	lzwEncoder := NewLZWEncoder()
+	lzwEncoder.EarlyChange = 0
+
+	for _, tcase := range testcases {
+		for _, encoder := range []StreamEncoder{flateEncoder, lzwEncoder} {
+			encoded, err := encoder.EncodeBytes(tcase.Input)
+			require.NoError(t, err)
+
+			params := MakeDict()
+			params.Set("Predictor", MakeInteger(int64(tcase.Predictor)))
+			params.Set("BitsPerComponent", MakeInteger(int64(tcase.BitsPerComponent)))
+			params.Set("Colors", MakeInteger(int64(tcase.Colors)))
+			params.Set("Columns", MakeInteger(int64(tcase.Columns)))
+			if encoder == lzwEncoder {
+				params.Set("EarlyChange", MakeInteger(0))
+			}
+
+			stream := &PdfObjectStream{Stream: encoded}
+			stream.PdfObjectDictionary = MakeDict()
+			stream.Set("Filter", MakeName(encoder.GetFilterName()))
+			stream.Set("DecodeParms", params)
+
+			decoded, err := DecodeStream(stream)
+			require.NoError(t, err, "%s %s", tcase.Name, encoder.GetFilterName())
+			require.Equal(t, tcase.Expected, decoded, "%s %s", tcase.Name, encoder.GetFilterName())
+		}
+	}
+}
+
 // Test LZW encoding.
 func TestLZWEncoding(t *testing.T) {
 	rawStream := []byte("this is a dummy text with some \x01\x02\x03 binary data")
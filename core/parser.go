@@ -17,6 +17,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
 	"github.com/bcmmbaga/unipdf-agpl/v3/core/security"
@@ -56,6 +57,44 @@ type PdfParser struct {
 	// the length reference (if not object) prior to reading the actual stream.  This has risks of endless looping.
 	// Tracking is necessary to avoid recursive loops.
 	streamLengthReferenceLookupInProgress map[int64]bool
+
+	// When enabled, the streams encoded with unsupported filters are passed
+	// through undecoded, instead of failing the decoding. The undecoded
+	// streams are guarded by undecodedMu, as streams may be decoded
+	// concurrently (e.g. when compressing streams in parallel).
+	passthroughUnsupportedFilters bool
+	undecodedMu                   sync.Mutex
+	undecoded                     []*PdfObjectStream
+	undecodedMap                  map[*PdfObjectStream]struct{}
+}
+
+// SetPassthroughUnsupportedFilters sets whether the streams loaded by the
+// parser which are encoded with unsupported filters are passed through
+// undecoded. When enabled, decoding such streams with DecodeStream returns
+// their raw (encoded) data instead of an error, so that the rest of the
+// document can still be processed. The stream objects are not modified, so
+// they are written unchanged. The streams which were left undecoded can be
+// retrieved using GetUndecodedStreams.
+func (parser *PdfParser) SetPassthroughUnsupportedFilters(enable bool) {
+	parser.undecodedMu.Lock()
+	defer parser.undecodedMu.Unlock()
+
+	parser.passthroughUnsupportedFilters = enable
+	if enable && parser.undecodedMap == nil {
+		parser.undecodedMap = map[*PdfObjectStream]struct{}{}
+	}
+}
+
+// GetUndecodedStreams returns the streams which were left undecoded as they
+// are encoded with unsupported filters, in the order they were encountered.
+// Streams are only passed through when enabled using
+// SetPassthroughUnsupportedFilters. The returned slice is a copy, which is
+// not affected by the streams decoded afterwards.
+func (parser *PdfParser) GetUndecodedStreams() []*PdfObjectStream {
+	parser.undecodedMu.Lock()
+	defer parser.undecodedMu.Unlock()
+
+	return append([]*PdfObjectStream(nil), parser.undecoded...)
 }
 
 // Version represents a version of a PDF standard.
@@ -0,0 +1,189 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+)
+
+// predictorParams represents the predictor related entries of the
+// DecodeParms dictionary of the Flate and LZW filters.
+type predictorParams struct {
+	Predictor        int
+	Columns          int
+	Colors           int
+	BitsPerComponent int
+}
+
+// rowLength returns the number of bytes of each (unpredicted) row of samples.
+func (p predictorParams) rowLength() int {
+	return (p.Columns*p.Colors*p.BitsPerComponent + 7) / 8
+}
+
+// bytesPerPixel returns the number of bytes per sample, rounded up to 1.
+// This is the distance used by the PNG filters for the left sample.
+func (p predictorParams) bytesPerPixel() int {
+	return (p.Colors*p.BitsPerComponent + 7) / 8
+}
+
+// validate checks if the predictor parameters are supported.
+func (p predictorParams) validate() error {
+	switch p.BitsPerComponent {
+	case 1, 2, 4, 8, 16:
+	default:
+		common.Log.Debug("ERROR: Unsupported BitsPerComponent (%d)", p.BitsPerComponent)
+		return fmt.Errorf("invalid BitsPerComponent=%d", p.BitsPerComponent)
+	}
+	if p.Colors < 1 {
+		return fmt.Errorf("invalid Colors=%d", p.Colors)
+	}
+	if p.Columns < 1 {
+		return fmt.Errorf("invalid Columns=%d", p.Columns)
+	}
+	return nil
+}
+
+// decodePredictor reverses the predictor described by `p` on the decoded
+// stream data `data` and returns the resulting sample data.
+// Supports TIFF predictor 2 and PNG predictors 10-15 with any valid
+// combination of Colors and BitsPerComponent. The contents of `data` are
+// modified in the process.
+func decodePredictor(data []byte, p predictorParams) ([]byte, error) {
+	if p.Predictor <= 1 {
+		return data, nil
+	}
+	if err := p.validate(); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.Predictor == 2:
+		return decodeTIFFPredictor(data, p)
+	case p.Predictor >= 10 && p.Predictor <= 15:
+		return decodePNGPredictor(data, p)
+	}
+
+	common.Log.Debug("ERROR: Unsupported predictor (%d)", p.Predictor)
+	return nil, fmt.Errorf("unsupported predictor (%d)", p.Predictor)
+}
+
+// decodeTIFFPredictor reverses the TIFF horizontal differencing predictor
+// (Predictor 2). Each sample component is predicted by the corresponding
+// component of the sample to its left.
+func decodeTIFFPredictor(data []byte, p predictorParams) ([]byte, error) {
+	rowLength := p.rowLength()
+	if len(data)%rowLength != 0 {
+		common.Log.Debug("ERROR: TIFF encoding: Invalid row length (%d/%d)", len(data), rowLength)
+		return nil, fmt.Errorf("invalid row length (%d/%d)", len(data), rowLength)
+	}
+	rows := len(data) / rowLength
+
+	for i := 0; i < rows; i++ {
+		rowData := data[rowLength*i : rowLength*(i+1)]
+
+		switch p.BitsPerComponent {
+		case 8:
+			for j := p.Colors; j < rowLength; j++ {
+				rowData[j] += rowData[j-p.Colors]
+			}
+		case 16:
+			for j := 2 * p.Colors; j+1 < rowLength; j += 2 {
+				k := j - 2*p.Colors
+				val := uint16(rowData[j])<<8 | uint16(rowData[j+1])
+				val += uint16(rowData[k])<<8 | uint16(rowData[k+1])
+				rowData[j], rowData[j+1] = byte(val>>8), byte(val)
+			}
+		default:
+			// Sub-byte components (1, 2 or 4 bits).
+			bpc := uint(p.BitsPerComponent)
+			mask := byte(1<<bpc - 1)
+			numComponents := p.Columns * p.Colors
+			prev := make([]byte, p.Colors)
+			for j := 0; j < numComponents; j++ {
+				pos := uint(j) * bpc
+				shift := 8 - bpc - pos%8
+				idx := pos / 8
+
+				c := j % p.Colors
+				val := (rowData[idx]>>shift + prev[c]) & mask
+				prev[c] = val
+
+				rowData[idx] = rowData[idx]&^(mask<<shift) | val<<shift
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// decodePNGPredictor reverses the PNG predictors (Predictor 10-15). Each
+// row starts with a byte indicating the PNG filter type used for the row.
+func decodePNGPredictor(data []byte, p predictorParams) ([]byte, error) {
+	// 1 byte to specify the filter type of each row.
+	rowLength := p.rowLength() + 1
+	if len(data)%rowLength != 0 {
+		return nil, fmt.Errorf("invalid row length (%d/%d)", len(data), rowLength)
+	}
+	if rowLength > len(data) {
+		common.Log.Debug("Row length cannot be longer than data length (%d/%d)", rowLength, len(data))
+		return nil, errors.New("range check error")
+	}
+	rows := len(data) / rowLength
+	bytesPerPixel := p.bytesPerPixel()
+
+	out := make([]byte, 0, rows*(rowLength-1))
+	prevRowData := make([]byte, rowLength-1)
+
+	for i := 0; i < rows; i++ {
+		fb := data[rowLength*i]
+		rowData := data[rowLength*i+1 : rowLength*(i+1)]
+
+		switch fb {
+		case pfNone:
+		case pfSub:
+			// Sub: Predicts the same as the sample to the left.
+			for j := bytesPerPixel; j < len(rowData); j++ {
+				rowData[j] += rowData[j-bytesPerPixel]
+			}
+		case pfUp:
+			// Up: Predicts the same as the sample above.
+			for j := range rowData {
+				rowData[j] += prevRowData[j]
+			}
+		case pfAvg:
+			// Avg: Predicts the same as the average of the sample to the left and above.
+			for j := range rowData {
+				var a byte
+				if j >= bytesPerPixel {
+					a = rowData[j-bytesPerPixel]
+				}
+				rowData[j] += byte((int(a) + int(prevRowData[j])) / 2)
+			}
+		case pfPaeth:
+			// Paeth: a nonlinear function of the sample to the left (a), sample above (b)
+			// and the upper left (c).
+			for j := range rowData {
+				var a, c byte
+				if j >= bytesPerPixel {
+					a = rowData[j-bytesPerPixel]
+					c = prevRowData[j-bytesPerPixel]
+				}
+				rowData[j] += paeth(a, prevRowData[j], c)
+			}
+		default:
+			common.Log.Debug("ERROR: Invalid filter byte (%d) @row %d", fb, i)
+			return nil, fmt.Errorf("invalid filter byte (%d)", fb)
+		}
+
+		copy(prevRowData, rowData)
+		out = append(out, rowData...)
+	}
+
+	return out, nil
+}
@@ -6,6 +6,7 @@
 package core
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
@@ -76,7 +77,7 @@ func NewEncoderFromStream(streamObj *PdfObjectStream) (StreamEncoder, error) {
 		return NewJPXEncoder(), nil
 	}
 	common.Log.Debug("ERROR: Unsupported encoding method!")
-	return nil, fmt.Errorf("unsupported encoding method (%s)", *method)
+	return nil, fmt.Errorf("%w: unsupported encoding method (%s)", ErrUnsupportedFilter, *method)
 }
 
 // DecodeStream decodes the stream data and returns the decoded data.
@@ -86,6 +87,9 @@ func DecodeStream(streamObj *PdfObjectStream) ([]byte, error) {
 
 	encoder, err := NewEncoderFromStream(streamObj)
 	if err != nil {
+		if data, ok := passthroughUndecoded(streamObj, err); ok {
+			return data, nil
+		}
 		common.Log.Debug("ERROR: Stream decoding failed: %v", err)
 		return nil, err
 	}
@@ -93,6 +97,9 @@ func DecodeStream(streamObj *PdfObjectStream) ([]byte, error) {
 
 	decoded, err := encoder.DecodeStream(streamObj)
 	if err != nil {
+		if data, ok := passthroughUndecoded(streamObj, err); ok {
+			return data, nil
+		}
 		common.Log.Debug("ERROR: Stream decoding failed: %v", err)
 		return nil, err
 	}
@@ -100,6 +107,33 @@ func DecodeStream(streamObj *PdfObjectStream) ([]byte, error) {
 	return decoded, nil
 }
 
+// passthroughUndecoded checks if the stream decoding error `err` is caused by
+// an unsupported filter and if the parser the stream was loaded by is set to
+// pass such streams through. If so, the stream is marked as undecoded and its
+// raw data is returned. It is safe to call concurrently for the streams of
+// the same parser.
+func passthroughUndecoded(streamObj *PdfObjectStream, err error) ([]byte, bool) {
+	parser := streamObj.PdfObjectReference.parser
+	if parser == nil {
+		return nil, false
+	}
+	if !errors.Is(err, ErrUnsupportedFilter) && !errors.Is(err, ErrNoJPXDecode) {
+		return nil, false
+	}
+
+	parser.undecodedMu.Lock()
+	defer parser.undecodedMu.Unlock()
+	if !parser.passthroughUnsupportedFilters {
+		return nil, false
+	}
+	if _, ok := parser.undecodedMap[streamObj]; !ok {
+		common.Log.Debug("Stream %d left undecoded: %v", streamObj.ObjectNumber, err)
+		parser.undecodedMap[streamObj] = struct{}{}
+		parser.undecoded = append(parser.undecoded, streamObj)
+	}
+	return streamObj.Stream, true
+}
+
 // EncodeStream encodes the stream data using the encoded specified by the stream's dictionary.
 func EncodeStream(streamObj *PdfObjectStream) error {
 	common.Log.Trace("Encode stream")
@@ -8,7 +8,9 @@ package core
 import (
 	"bytes"
 	"compress/zlib"
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
@@ -175,3 +177,79 @@ endobj`
 	}
 
 }
+
+func TestPassthroughUnsupportedFilters(t *testing.T) {
+	parser := &PdfParser{}
+	newStream := func(filter PdfObject) *PdfObjectStream {
+		stream := &PdfObjectStream{
+			PdfObjectReference:  PdfObjectReference{parser: parser, ObjectNumber: 1},
+			PdfObjectDictionary: MakeDict(),
+			Stream:              []byte("raw"),
+		}
+		stream.Set("Filter", filter)
+		return stream
+	}
+
+	single := newStream(MakeName("UnknownDecode"))
+	multi := newStream(MakeArray(MakeName("ASCIIHexDecode"), MakeName("UnknownDecode")))
+	for _, stream := range []*PdfObjectStream{single, multi} {
+		_, err := DecodeStream(stream)
+		if !errors.Is(err, ErrUnsupportedFilter) {
+			t.Fatalf("Expected unsupported filter error, got %v", err)
+		}
+	}
+	if len(parser.GetUndecodedStreams()) != 0 {
+		t.Fatalf("Unexpected undecoded streams")
+	}
+
+	parser.SetPassthroughUnsupportedFilters(true)
+	for _, stream := range []*PdfObjectStream{single, multi, single} {
+		decoded, err := DecodeStream(stream)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if string(decoded) != "raw" {
+			t.Fatalf("Unexpected decoded data: %q", decoded)
+		}
+	}
+	undecoded := parser.GetUndecodedStreams()
+	if len(undecoded) != 2 || undecoded[0] != single || undecoded[1] != multi {
+		t.Fatalf("Unexpected undecoded streams: %v", undecoded)
+	}
+}
+
+// Test decoding the streams of a parser passing unsupported filters through
+// concurrently (run with -race).
+func TestPassthroughUnsupportedFiltersConcurrent(t *testing.T) {
+	parser := &PdfParser{}
+	parser.SetPassthroughUnsupportedFilters(true)
+
+	var streams []*PdfObjectStream
+	for i := 0; i < 20; i++ {
+		stream := &PdfObjectStream{
+			PdfObjectReference:  PdfObjectReference{parser: parser, ObjectNumber: int64(i + 1)},
+			PdfObjectDictionary: MakeDict(),
+			Stream:              []byte("raw"),
+		}
+		stream.Set("Filter", MakeName("UnknownDecode"))
+		streams = append(streams, stream)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, stream := range streams {
+				if _, err := DecodeStream(stream); err != nil {
+					t.Errorf("Error: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if undecoded := parser.GetUndecodedStreams(); len(undecoded) != len(streams) {
+		t.Fatalf("len(undecoded) != %d (got %d)", len(streams), len(undecoded))
+	}
+}
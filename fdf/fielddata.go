@@ -18,6 +18,9 @@ import (
 type Data struct {
 	root   *core.PdfObjectDictionary
 	fields *core.PdfObjectArray
+
+	// parser resolves the references to the indirect objects of the file.
+	parser *fdfParser
 }
 
 // Load loads FDF form data from `r`.
@@ -40,6 +43,7 @@ func Load(r io.ReadSeeker) (*Data, error) {
 	return &Data{
 		fields: fields,
 		root:   fdfDict,
+		parser: p,
 	}, nil
 }
 
@@ -55,25 +59,46 @@ func LoadFromPath(fdfPath string) (*Data, error) {
 }
 
 // FieldDictionaries returns a map of field names to field dictionaries.
+// The names of the descendant fields (Kids) are the fully qualified names of
+// the fields, i.e. the partial names of the fields and of their ancestors,
+// joined by dots.
 func (fdf *Data) FieldDictionaries() (map[string]*core.PdfObjectDictionary, error) {
 	fieldDataMap := map[string]*core.PdfObjectDictionary{}
+	addFieldDictionaries(fieldDataMap, fdf.fields, "")
+	return fieldDataMap, nil
+}
 
-	for i := 0; i < fdf.fields.Len(); i++ {
-		fieldDict, has := core.GetDict(fdf.fields.Get(i))
-		if has {
-			// Key value field data.
-			t, _ := core.GetString(fieldDict.Get("T"))
-			if t != nil {
-				fieldDataMap[t.Str()] = fieldDict
-			}
+// addFieldDictionaries adds the field dictionaries of `fields` and of their
+// descendants to `fieldDataMap`. The names of the fields are prefixed with
+// the fully qualified name of their parent `parentName`.
+func addFieldDictionaries(fieldDataMap map[string]*core.PdfObjectDictionary, fields *core.PdfObjectArray, parentName string) {
+	for i := 0; i < fields.Len(); i++ {
+		fieldDict, has := core.GetDict(fields.Get(i))
+		if !has {
+			continue
 		}
-	}
 
-	return fieldDataMap, nil
+		// Key value field data.
+		t, _ := core.GetString(fieldDict.Get("T"))
+		if t == nil {
+			continue
+		}
+		name := t.Str()
+		if parentName != "" {
+			name = parentName + "." + name
+		}
+		fieldDataMap[name] = fieldDict
+
+		if kids, has := core.GetArray(fieldDict.Get("Kids")); has {
+			addFieldDictionaries(fieldDataMap, kids, name)
+		}
+	}
 }
 
 // FieldValues implements interface model.FieldValueProvider.
-// Returns a map of field names to values (PdfObjects).
+// Returns a map of field names to values (PdfObjects). Intermediate fields
+// in the hierarchy of fields, which have descendant fields but no value,
+// are not included.
 func (fdf *Data) FieldValues() (map[string]core.PdfObject, error) {
 	fieldDictMap, err := fdf.FieldDictionaries()
 	if err != nil {
@@ -89,9 +114,35 @@ func (fdf *Data) FieldValues() (map[string]core.PdfObject, error) {
 	fieldValMap := map[string]core.PdfObject{}
 	for _, fieldName := range keys {
 		fieldDict := fieldDictMap[fieldName]
-		val := core.TraceToDirectObject(fieldDict.Get("V"))
+		val := fdf.trace(fieldDict.Get("V"))
+		if val == nil && fieldDict.Get("Kids") != nil {
+			continue
+		}
 		fieldValMap[fieldName] = val
 	}
 
 	return fieldValMap, nil
 }
+
+// trace resolves `obj` to a direct object, looking up the referenced
+// indirect objects of the file. The elements of arrays are resolved too
+// (e.g. the selected values of multi-select fields).
+func (fdf *Data) trace(obj core.PdfObject) core.PdfObject {
+	if fdf.parser != nil {
+		obj = fdf.parser.trace(obj)
+	}
+	obj = core.TraceToDirectObject(obj)
+
+	arr, ok := obj.(*core.PdfObjectArray)
+	if !ok {
+		return obj
+	}
+	resolved := core.MakeArray()
+	for _, elem := range arr.Elements() {
+		if fdf.parser != nil {
+			elem = fdf.parser.trace(elem)
+		}
+		resolved.Append(core.TraceToDirectObject(elem))
+	}
+	return resolved
+}
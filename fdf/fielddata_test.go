@@ -45,3 +45,51 @@ func TestFDFDataLoading(t *testing.T) {
 		}
 	}
 }
+
+const fdfHierarchical = `
+%FDF-1.2
+1 0 obj
+<</FDF<</Fields[<</T(address)/Kids[<</T(city)/V(Paris)>><</T(zip)/V(75001)>>]>><</T(agree)/V/Yes>>]>>>>
+endobj
+trailer
+<</Root 1 0 R>>
+%%EOF
+`
+
+func TestFDFHierarchicalFields(t *testing.T) {
+	fdfData, err := Load(bytes.NewReader([]byte(fdfHierarchical)))
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	fieldDicts, err := fdfData.FieldDictionaries()
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if len(fieldDicts) != 4 {
+		t.Fatalf("len(fieldDicts) != 4 (got %d)", len(fieldDicts))
+	}
+
+	fvalMap, err := fdfData.FieldValues()
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	expectedVals := map[string]string{
+		"address.city": "Paris",
+		"address.zip":  "75001",
+		"agree":        "Yes",
+	}
+	if len(fvalMap) != len(expectedVals) {
+		t.Fatalf("len(fvalMap) != %d (got %d)", len(expectedVals), len(fvalMap))
+	}
+	for name, exp := range expectedVals {
+		val, has := fvalMap[name]
+		if !has {
+			t.Fatalf("%s missing from map", name)
+		}
+		if val.String() != exp {
+			t.Fatalf("val.String() != %s (got %s)", exp, val.String())
+		}
+	}
+}
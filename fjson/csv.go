@@ -0,0 +1,160 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fjson
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+)
+
+// LoadFromCSV loads form field data from the CSV data read from `r`. The
+// data must contain a header row with the full names of the fields, followed
+// by a data row containing the values of the fields. Only the first data row
+// is loaded. Use LoadFromCSVRows for loading the field data of multiple forms
+// and LoadFromCSVNameValue for the two column name/value layout.
+//
+// The selected values of multi-select fields with more than one selected
+// value are stored in a single cell, as a JSON array of strings, as written
+// by FieldData.CSV.
+func LoadFromCSV(r io.Reader) (*FieldData, error) {
+	records, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(records) < 2 {
+		return nil, errors.New("CSV data row missing")
+	}
+	return newCSVFieldData(records[0], records[1]), nil
+}
+
+// LoadFromCSVNameValue loads form field data from the CSV data read from `r`,
+// using a two column layout: a header row (e.g. "name,value"), which is
+// skipped, followed by one row for each field, containing the full name and
+// the value of the field. Values are stored as described by LoadFromCSV.
+func LoadFromCSVNameValue(r io.Reader) (*FieldData, error) {
+	records, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var fdata FieldData
+	for _, record := range records[1:] {
+		if len(record) < 2 {
+			return nil, errors.New("invalid CSV name/value row")
+		}
+		fdata.values = append(fdata.values, newCSVFieldValue(record[0], record[1]))
+	}
+	return &fdata, nil
+}
+
+// LoadFromCSVRows loads form field data for multiple forms from the CSV data
+// read from `r`. The data must contain a header row with the full names of
+// the fields, followed by a data row for each form. The returned field data
+// can be used for filling forms in batch (see model.PdfReader.FillBatch).
+func LoadFromCSVRows(r io.Reader) ([]*FieldData, error) {
+	records, err := readCSV(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*FieldData
+	for _, record := range records[1:] {
+		rows = append(rows, newCSVFieldData(records[0], record))
+	}
+	return rows, nil
+}
+
+// LoadFromCSVFile loads form field data from a CSV file.
+func LoadFromCSVFile(filePath string) (*FieldData, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadFromCSV(f)
+}
+
+// CSV returns the field data as a string in CSV format, consisting of a
+// header row containing the full names of the fields, followed by a data
+// row containing the values of the fields. The selected values of
+// multi-select fields with more than one selected value are written in a
+// single cell, as a JSON array of strings.
+func (fd FieldData) CSV() (string, error) {
+	var names, values []string
+	for _, fval := range fd.values {
+		value := fval.Value
+		if len(fval.Values) > 1 {
+			data, err := json.Marshal(fval.Values)
+			if err != nil {
+				return "", err
+			}
+			value = string(data)
+		}
+		names = append(names, fval.Name)
+		values = append(values, value)
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll([][]string{names, values}); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// readCSV reads the records of the CSV data read from `r`. Rows may have
+// different numbers of fields. Returns an error if the data is empty.
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("empty CSV data")
+	}
+	return records, nil
+}
+
+// newCSVFieldData returns the field data of the CSV data row `record`,
+// given the field names of the header row `header`. Missing values are
+// loaded as empty values.
+func newCSVFieldData(header, record []string) *FieldData {
+	var fdata FieldData
+	for i, name := range header {
+		var value string
+		if i < len(record) {
+			value = record[i]
+		}
+		fdata.values = append(fdata.values, newCSVFieldValue(name, value))
+	}
+	return &fdata
+}
+
+// newCSVFieldValue returns the value of the field `name` stored in the CSV
+// cell `cell`. Cells containing a JSON array of more than one string hold
+// the selected values of multi-select fields.
+func newCSVFieldValue(name, cell string) fieldValue {
+	fval := fieldValue{Name: name, Value: cell}
+	if !strings.HasPrefix(cell, "[") {
+		return fval
+	}
+
+	var values []string
+	if err := json.Unmarshal([]byte(cell), &values); err != nil || len(values) < 2 {
+		return fval
+	}
+	fval.Value = values[0]
+	fval.Values = values
+	return fval
+}
@@ -4,4 +4,8 @@
  */
 
 // Package fjson provides support for loading PDF form field data from JSON data/files.
+// Form field data can also be imported from Forms Data Format (FDF) files, and
+// imported from or exported to XML Forms Data Format (XFDF) and CSV files.
+// The loaded field data can be used to fill and flatten forms in one step
+// using annotator.FillAndFlatten.
 package fjson
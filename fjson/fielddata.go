@@ -6,11 +6,15 @@
 package fjson
 
 import (
+	"bytes"
 	"encoding/json"
 	"io"
 	"os"
+	"sort"
 
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
 	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/fdf"
 	"github.com/bcmmbaga/unipdf-agpl/v3/model"
 )
 
@@ -20,12 +24,98 @@ type FieldData struct {
 }
 
 // fieldValue represents a field name and value for a PDF form field.
+// In JSON format, the value of multi-select fields with more than one
+// selected value is represented as an array of values.
 type fieldValue struct {
 	Name  string `json:"name"`
+	Type  string `json:"type,omitempty"`
 	Value string `json:"value"`
 
 	// Options lists allowed values if present.
 	Options []string `json:"options,omitempty"`
+
+	// Rect and Page specify the rectangle ([x1, y1, x2, y2]) of the first
+	// widget annotation of the field and the number of the page it is
+	// displayed on (starting from 1). The location of the field is
+	// informational and it is not used when filling forms.
+	Rect []float64 `json:"rect,omitempty"`
+	Page int       `json:"page,omitempty"`
+
+	// Values lists the selected values of multi-select fields, when more
+	// than one value is selected. Value holds the first selected value.
+	Values []string `json:"-"`
+
+	// RichText holds the rich text (XHTML) value of the field, if any.
+	// Value holds the plain text of the rich text value.
+	RichText string `json:"-"`
+}
+
+// jsonFieldValue represents the JSON format of fieldValue, in which the
+// value is either a string or an array of strings.
+type jsonFieldValue struct {
+	Name    string          `json:"name"`
+	Type    string          `json:"type,omitempty"`
+	Value   json.RawMessage `json:"value"`
+	Options []string        `json:"options,omitempty"`
+	Rect    []float64       `json:"rect,omitempty"`
+	Page    int             `json:"page,omitempty"`
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (fv fieldValue) MarshalJSON() ([]byte, error) {
+	var value interface{} = fv.Value
+	if len(fv.Values) > 1 {
+		value = fv.Values
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(jsonFieldValue{
+		Name:    fv.Name,
+		Type:    fv.Type,
+		Value:   data,
+		Options: fv.Options,
+		Rect:    fv.Rect,
+		Page:    fv.Page,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (fv *fieldValue) UnmarshalJSON(data []byte) error {
+	var jfv jsonFieldValue
+	if err := json.Unmarshal(data, &jfv); err != nil {
+		return err
+	}
+	*fv = fieldValue{
+		Name:    jfv.Name,
+		Type:    jfv.Type,
+		Options: jfv.Options,
+		Rect:    jfv.Rect,
+		Page:    jfv.Page,
+	}
+
+	value := bytes.TrimSpace(jfv.Value)
+	switch {
+	case len(value) == 0 || bytes.Equal(value, []byte("null")):
+	case value[0] == '[':
+		var values []string
+		if err := json.Unmarshal(value, &values); err != nil {
+			return err
+		}
+		if len(values) > 0 {
+			fv.Value = values[0]
+		}
+		if len(values) > 1 {
+			fv.Values = values
+		}
+	default:
+		if err := json.Unmarshal(value, &fv.Value); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // LoadFromJSON loads JSON form data from `r`.
@@ -49,6 +139,72 @@ func LoadFromJSONFile(filePath string) (*FieldData, error) {
 	return LoadFromJSON(f)
 }
 
+// LoadFromFDF loads form field data from the Forms Data Format (FDF) data
+// read from `rs`. The names of hierarchical fields are the fully qualified
+// names of the fields (e.g. "address.city"). Text values are loaded as is,
+// while name values (e.g. the states of checkboxes) are loaded without the
+// leading slash. All the values of fields with multiple values (e.g.
+// multi-select list boxes) are loaded. Fields with values of other types
+// are skipped.
+func LoadFromFDF(rs io.ReadSeeker) (*FieldData, error) {
+	fdfData, err := fdf.Load(rs)
+	if err != nil {
+		return nil, err
+	}
+	fvalMap, err := fdfData.FieldValues()
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for name := range fvalMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var fdata FieldData
+	for _, name := range names {
+		fval := fieldValue{Name: name}
+		switch t := fvalMap[name].(type) {
+		case *core.PdfObjectString:
+			fval.Value = t.Decoded()
+		case *core.PdfObjectName:
+			fval.Value = t.String()
+		case *core.PdfObjectArray:
+			// Multiple selected values.
+			var values []string
+			for _, obj := range t.Elements() {
+				if str, ok := core.GetString(obj); ok {
+					values = append(values, str.Decoded())
+				}
+			}
+			if len(values) > 0 {
+				fval.Value = values[0]
+			}
+			if len(values) > 1 {
+				fval.Values = values
+			}
+		case nil, *core.PdfObjectNull:
+		default:
+			common.Log.Debug("WARN: unsupported value type for field %s: %T - skipping", name, t)
+			continue
+		}
+		fdata.values = append(fdata.values, fval)
+	}
+	return &fdata, nil
+}
+
+// LoadFromFDFFile loads form field data from an FDF file.
+func LoadFromFDFFile(filePath string) (*FieldData, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadFromFDF(f)
+}
+
 // LoadFromPDF loads form field data from a PDF.
 func LoadFromPDF(rs io.ReadSeeker) (*FieldData, error) {
 	pdfReader, err := model.NewPdfReader(rs)
@@ -60,21 +216,74 @@ func LoadFromPDF(rs io.ReadSeeker) (*FieldData, error) {
 		return nil, nil
 	}
 
+	// Map the widget annotations to the numbers of the pages they are
+	// displayed on.
+	annotPages := map[core.PdfObject]int{}
+	for i, page := range pdfReader.PageList {
+		annotations, err := page.GetAnnotations()
+		if err != nil {
+			return nil, err
+		}
+		for _, annot := range annotations {
+			annotPages[annot.GetContainingPdfObject()] = i + 1
+		}
+	}
+
 	var fieldvals []fieldValue
 	fields := pdfReader.AcroForm.AllFields()
 	for _, f := range fields {
-		var options []string
-		optMap := make(map[string]struct{})
-
 		name, err := f.FullName()
 		if err != nil {
 			return nil, err
 		}
 
+		// Get the location of the field.
+		var rect []float64
+		var page int
+		if len(f.Annotations) > 0 {
+			wa := f.Annotations[0]
+			if arr, ok := core.GetArray(wa.Rect); ok {
+				if r, err := model.NewPdfRectangle(*arr); err == nil {
+					rect = []float64{r.Llx, r.Lly, r.Urx, r.Ury}
+				}
+			}
+			page = annotPages[wa.GetContainingPdfObject()]
+		}
+
+		typ := fieldType(f)
+		options := fieldOptions(f)
+		if arr, ok := core.GetArray(f.V); ok {
+			// Multiple selected values.
+			var values []string
+			for _, obj := range arr.Elements() {
+				if str, ok := core.GetString(obj); ok {
+					values = append(values, str.Decoded())
+				}
+			}
+			fval := fieldValue{
+				Name:    name,
+				Type:    typ,
+				Options: options,
+				Rect:    rect,
+				Page:    page,
+			}
+			if len(values) > 0 {
+				fval.Value = values[0]
+			}
+			if len(values) > 1 {
+				fval.Values = values
+			}
+			fieldvals = append(fieldvals, fval)
+			continue
+		}
 		if t, ok := f.V.(*core.PdfObjectString); ok {
 			fieldvals = append(fieldvals, fieldValue{
-				Name:  name,
-				Value: t.Decoded(),
+				Name:    name,
+				Type:    typ,
+				Value:   t.Decoded(),
+				Options: options,
+				Rect:    rect,
+				Page:    page,
 			})
 			continue
 		}
@@ -85,34 +294,15 @@ func LoadFromPDF(rs io.ReadSeeker) (*FieldData, error) {
 			if found {
 				val = state.String()
 			}
-
-			// Options are the keys in the N/D dictionaries in the AP appearance dict.
-			apDict, has := core.GetDict(wa.AP)
-			if !has {
-				continue
-			}
-			nDict, _ := core.GetDict(apDict.Get("N"))
-			for _, key := range nDict.Keys() {
-				keystr := key.String()
-				if _, has := optMap[keystr]; !has {
-					options = append(options, keystr)
-					optMap[keystr] = struct{}{}
-				}
-			}
-			dDict, _ := core.GetDict(apDict.Get("D"))
-			for _, key := range dDict.Keys() {
-				keystr := key.String()
-				if _, has := optMap[keystr]; !has {
-					options = append(options, keystr)
-					optMap[keystr] = struct{}{}
-				}
-			}
 		}
 
 		fval := fieldValue{
 			Name:    name,
+			Type:    typ,
 			Value:   val,
 			Options: options,
+			Rect:    rect,
+			Page:    page,
 		}
 		fieldvals = append(fieldvals, fval)
 	}
@@ -124,6 +314,58 @@ func LoadFromPDF(rs io.ReadSeeker) (*FieldData, error) {
 	return &fdata, nil
 }
 
+// fieldOptions returns the options of field `f`, which are the keys in the
+// N/D dictionaries in the AP appearance dictionaries of its widgets.
+func fieldOptions(f *model.PdfField) []string {
+	var options []string
+	optMap := make(map[string]struct{})
+	for _, wa := range f.Annotations {
+		apDict, has := core.GetDict(wa.AP)
+		if !has {
+			continue
+		}
+		for _, state := range []string{"N", "D"} {
+			stateDict, _ := core.GetDict(apDict.Get(core.PdfObjectName(state)))
+			for _, key := range stateDict.Keys() {
+				keystr := key.String()
+				if _, has := optMap[keystr]; !has {
+					options = append(options, keystr)
+					optMap[keystr] = struct{}{}
+				}
+			}
+		}
+	}
+	return options
+}
+
+// fieldType returns the type of field `f`, which is one of "text",
+// "checkbox", "radio", "pushbutton", "combo", "list" or "signature".
+// Returns an empty string for fields without a type (e.g. intermediate
+// fields in the hierarchy of fields).
+func fieldType(f *model.PdfField) string {
+	switch t := f.GetContext().(type) {
+	case *model.PdfFieldText:
+		return "text"
+	case *model.PdfFieldButton:
+		switch {
+		case t.IsRadio():
+			return "radio"
+		case t.IsPush():
+			return "pushbutton"
+		default:
+			return "checkbox"
+		}
+	case *model.PdfFieldChoice:
+		if f.Flags().Has(model.FieldFlagCombo) {
+			return "combo"
+		}
+		return "list"
+	case *model.PdfFieldSignature:
+		return "signature"
+	}
+	return ""
+}
+
 // LoadFromPDFFile loads form field data from a PDF file.
 func LoadFromPDFFile(filePath string) (*FieldData, error) {
 	f, err := os.Open(filePath)
@@ -145,9 +387,168 @@ func (fd FieldData) JSON() (string, error) {
 func (fd *FieldData) FieldValues() (map[string]core.PdfObject, error) {
 	fvalMap := make(map[string]core.PdfObject)
 	for _, fval := range fd.values {
+		if len(fval.Values) > 1 {
+			arr := core.MakeArray()
+			for _, val := range fval.Values {
+				arr.Append(core.MakeString(val))
+			}
+			fvalMap[fval.Name] = arr
+			continue
+		}
 		if len(fval.Value) > 0 {
 			fvalMap[fval.Name] = core.MakeString(fval.Value)
 		}
 	}
 	return fvalMap, nil
 }
+
+// Names returns the names of the fields, in the order of the field data.
+// For field data loaded from PDF files, the order is the order of the fields
+// in the form.
+func (fd *FieldData) Names() []string {
+	names := make([]string, 0, len(fd.values))
+	for _, fval := range fd.values {
+		names = append(names, fval.Name)
+	}
+	return names
+}
+
+// Get returns the value of the field named `name` and true, or false if the
+// field is not present in the field data. For multi-select fields with more
+// than one selected value, the first selected value is returned.
+func (fd *FieldData) Get(name string) (string, bool) {
+	for _, fval := range fd.values {
+		if fval.Name == name {
+			return fval.Value, true
+		}
+	}
+	return "", false
+}
+
+// Set sets the value of the field named `name` to `value`. The value replaces
+// all the selected values of multi-select fields, as well as the rich text
+// value of the field. The field is appended to the field data if not present.
+func (fd *FieldData) Set(name, value string) {
+	for i := range fd.values {
+		if fval := &fd.values[i]; fval.Name == name {
+			fval.Value, fval.Values, fval.RichText = value, nil, ""
+			return
+		}
+	}
+	fd.values = append(fd.values, fieldValue{Name: name, Value: value})
+}
+
+// Merge overlays the values of the fields of `other` onto the field data.
+// The fields are matched by their names. The non-empty values of `other`
+// replace the values of the matching fields, including the selected values
+// of multi-select fields and the rich text values, so that the values are
+// never partially merged. Empty values of `other` are ignored and do not
+// clear the values of the field data.
+// The type, options and location of the matching fields are kept, unless
+// they are not set in the field data, in which case they are taken from
+// `other`. The fields of `other` with non-empty values which are not present
+// in the field data are appended to it, in the order of `other`.
+func (fd *FieldData) Merge(other *FieldData) {
+	if other == nil {
+		return
+	}
+
+	indices := make(map[string]int, len(fd.values))
+	for i, fval := range fd.values {
+		indices[fval.Name] = i
+	}
+
+	for _, oval := range other.values {
+		if oval.isEmpty() {
+			continue
+		}
+
+		i, ok := indices[oval.Name]
+		if !ok {
+			indices[oval.Name] = len(fd.values)
+			fd.values = append(fd.values, oval)
+			continue
+		}
+
+		fval := &fd.values[i]
+		fval.Value = oval.Value
+		fval.Values = oval.Values
+		fval.RichText = oval.RichText
+		if fval.Type == "" {
+			fval.Type = oval.Type
+		}
+		if len(fval.Options) == 0 {
+			fval.Options = oval.Options
+		}
+		if len(fval.Rect) == 0 {
+			fval.Rect, fval.Page = oval.Rect, oval.Page
+		}
+	}
+}
+
+// Diff returns the fields of `other` whose values differ from the values of
+// the fields with the same names in the field data, in the order of `other`.
+// The fields of `other` which are not present in the field data are included
+// if they have a non-empty value. The selected values of multi-select fields
+// are compared regardless of their order. The rich text values are compared
+// as well, while the types, options and locations of the fields are not.
+// Fields cleared in `other` (with an empty value) are included, but note
+// that empty values are not used when filling forms (see FieldValues).
+// The returned field data can be used to fill only the changed values.
+func (fd *FieldData) Diff(other *FieldData) *FieldData {
+	diff := &FieldData{}
+	if other == nil {
+		return diff
+	}
+
+	values := make(map[string]fieldValue, len(fd.values))
+	for _, fval := range fd.values {
+		values[fval.Name] = fval
+	}
+
+	for _, oval := range other.values {
+		fval, ok := values[oval.Name]
+		if !ok && oval.isEmpty() || ok && fval.equalValue(oval) {
+			continue
+		}
+		diff.values = append(diff.values, oval)
+	}
+	return diff
+}
+
+// isEmpty returns true if the field has no value.
+func (fv fieldValue) isEmpty() bool {
+	return fv.Value == "" && len(fv.Values) == 0 && fv.RichText == ""
+}
+
+// selectedValues returns the sorted selected values of the field.
+func (fv fieldValue) selectedValues() []string {
+	var values []string
+	switch {
+	case len(fv.Values) > 0:
+		values = append(values, fv.Values...)
+	case fv.Value != "":
+		values = append(values, fv.Value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// equalValue returns true if the fields `fv` and `other` have the same
+// values, regardless of the order of the selected values.
+func (fv fieldValue) equalValue(other fieldValue) bool {
+	if fv.RichText != other.RichText {
+		return false
+	}
+
+	values, otherValues := fv.selectedValues(), other.selectedValues()
+	if len(values) != len(otherValues) {
+		return false
+	}
+	for i := range values {
+		if values[i] != otherValues[i] {
+			return false
+		}
+	}
+	return true
+}
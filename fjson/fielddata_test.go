@@ -8,11 +8,13 @@ package fjson
 import (
 	"bytes"
 	"encoding/json"
+	"io/ioutil"
 	"os"
 	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
 	"github.com/bcmmbaga/unipdf-agpl/v3/model"
 	"github.com/stretchr/testify/require"
 )
@@ -155,9 +157,12 @@ func TestJSONExtractAndFill(t *testing.T) {
 
 	// Unmarshal and set template test field data.
 	var fields []*struct {
-		Name    string   `json:"name"`
-		Value   string   `json:"value"`
-		Options []string `json:"options"`
+		Name    string    `json:"name"`
+		Type    string    `json:"type"`
+		Value   string    `json:"value"`
+		Options []string  `json:"options"`
+		Rect    []float64 `json:"rect"`
+		Page    int       `json:"page"`
 	}
 	err = json.Unmarshal([]byte(jsonDataExp), &fields)
 	require.NoError(t, err)
@@ -259,3 +264,492 @@ func TestJSONFillAndExtract(t *testing.T) {
 	// Check field data for equality.
 	require.Equal(t, jsonDataExp, jsonData)
 }
+
+// batchRows returns `n` form data rows for the basic form test file.
+func batchRows(t testing.TB, n int) []*FieldData {
+	var rows []*FieldData
+	for i := 0; i < n; i++ {
+		data := `[{"name": "full_name", "value": "Name ` + strconv.Itoa(i) + `"}]`
+		if i%2 == 1 {
+			data = `[{"name": "city", "value": "City ` + strconv.Itoa(i) + `"}]`
+		}
+		fdata, err := LoadFromJSON(strings.NewReader(data))
+		require.NoError(t, err)
+		rows = append(rows, fdata)
+	}
+	return rows
+}
+
+// rowIterator returns a function which iterates over the specified rows.
+func rowIterator(rows []*FieldData) func() (model.FieldValueProvider, error) {
+	i := 0
+	return func() (model.FieldValueProvider, error) {
+		if i >= len(rows) {
+			return nil, nil
+		}
+		i++
+		return rows[i-1], nil
+	}
+}
+
+func TestFillBatch(t *testing.T) {
+	f, err := os.Open(`./testdata/basicform.pdf`)
+	require.NoError(t, err)
+	defer f.Close()
+
+	reader, err := model.NewPdfReader(f)
+	require.NoError(t, err)
+
+	var outputs [][]byte
+	err = reader.FillBatch(rowIterator(batchRows(t, 2)), nil, func(i int, w *model.PdfWriter) error {
+		var buf bytes.Buffer
+		if err := w.Write(&buf); err != nil {
+			return err
+		}
+		outputs = append(outputs, buf.Bytes())
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, outputs, 2)
+
+	getValues := func(data []byte) map[string]string {
+		fdata, err := LoadFromPDF(bytes.NewReader(data))
+		require.NoError(t, err)
+
+		values := map[string]string{}
+		for _, fval := range fdata.values {
+			values[fval.Name] = fval.Value
+		}
+		return values
+	}
+
+	// The values of a row must not leak into the next row.
+	values := getValues(outputs[0])
+	require.Equal(t, "Name 0", values["full_name"])
+	require.Equal(t, "", values["city"])
+
+	values = getValues(outputs[1])
+	require.Equal(t, "", values["full_name"])
+	require.Equal(t, "City 1", values["city"])
+}
+
+func BenchmarkFillBatch(b *testing.B) {
+	rows := batchRows(b, 20)
+	for n := 0; n < b.N; n++ {
+		f, err := os.Open(`./testdata/basicform.pdf`)
+		require.NoError(b, err)
+
+		reader, err := model.NewPdfReader(f)
+		require.NoError(b, err)
+
+		err = reader.FillBatch(rowIterator(rows), nil, func(i int, w *model.PdfWriter) error {
+			return w.Write(ioutil.Discard)
+		})
+		require.NoError(b, err)
+		f.Close()
+	}
+}
+
+func BenchmarkFillNaive(b *testing.B) {
+	rows := batchRows(b, 20)
+	for n := 0; n < b.N; n++ {
+		for _, row := range rows {
+			f, err := os.Open(`./testdata/basicform.pdf`)
+			require.NoError(b, err)
+
+			reader, err := model.NewPdfReader(f)
+			require.NoError(b, err)
+			require.NoError(b, reader.AcroForm.Fill(row))
+
+			writer := model.NewPdfWriter()
+			for _, page := range reader.PageList {
+				require.NoError(b, writer.AddPage(page))
+			}
+			require.NoError(b, writer.SetForms(reader.AcroForm))
+			require.NoError(b, writer.Write(ioutil.Discard))
+			f.Close()
+		}
+	}
+}
+
+// Tests loading FDF form data, filling in a form and loading the PDF form data.
+func TestFDFFillPDFForm(t *testing.T) {
+	fdfData := `%FDF-1.2
+1 0 obj
+<</FDF<</Fields[<</T(full_name)/V(J\363nas \336orgr\355msson)>><</T(city)/V(Reykjav\355k)>><</T(male)/V/Yes>>]>>>>
+endobj
+trailer
+<</Root 1 0 R>>
+%%EOF
+`
+	fdata, err := LoadFromFDF(strings.NewReader(fdfData))
+	require.NoError(t, err)
+	require.Len(t, fdata.values, 3)
+
+	f, err := os.Open(`./testdata/basicform.pdf`)
+	require.NoError(t, err)
+	defer f.Close()
+	pdfReader, err := model.NewPdfReader(f)
+	require.NoError(t, err)
+	require.NoError(t, pdfReader.AcroForm.Fill(fdata))
+
+	pdfWriter := model.NewPdfWriter()
+	for _, page := range pdfReader.PageList {
+		require.NoError(t, pdfWriter.AddPage(page))
+	}
+	require.NoError(t, pdfWriter.SetForms(pdfReader.AcroForm))
+	var buf bytes.Buffer
+	require.NoError(t, pdfWriter.Write(&buf))
+
+	filled, err := LoadFromPDF(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	values := map[string]string{}
+	for _, fval := range filled.values {
+		values[fval.Name] = fval.Value
+	}
+	require.Equal(t, "Jónas Þorgrímsson", values["full_name"])
+	require.Equal(t, "Reykjavík", values["city"])
+	require.Equal(t, "Yes", values["male"])
+	require.Equal(t, "Off", values["female"])
+}
+
+// Tests loading FDF values stored in indirect objects and multiple values.
+func TestLoadFromFDFIndirectValues(t *testing.T) {
+	fdfData := `%FDF-1.2
+1 0 obj
+<</FDF<</Fields[<</T(name)/V 2 0 R>><</T(colors)/V[(red) 3 0 R]>><</T(size)/V[(L)]>>]>>>>
+endobj
+2 0 obj
+(Jane)
+endobj
+3 0 obj
+(green)
+endobj
+trailer
+<</Root 1 0 R>>
+%%EOF
+`
+	fdata, err := LoadFromFDF(strings.NewReader(fdfData))
+	require.NoError(t, err)
+	require.Equal(t, []fieldValue{
+		{Name: "colors", Value: "red", Values: []string{"red", "green"}},
+		{Name: "name", Value: "Jane"},
+		{Name: "size", Value: "L"},
+	}, fdata.values)
+}
+
+func TestXFDFImportExport(t *testing.T) {
+	xfdfData := `<?xml version="1.0" encoding="UTF-8"?>
+<xfdf xmlns="http://ns.adobe.com/xfdf/" xml:space="preserve">
+	<fields>
+		<field name="address">
+			<field name="city"><value>Paris</value></field>
+			<field name="zip"><value>75001</value></field>
+		</field>
+		<field name="colors"><value>Red</value><value>Blue</value></field>
+		<field name="comment">
+			<value-richtext><body xmlns="http://www.w3.org/1999/xhtml"><p>Hello <b>world</b></p><p>Bye</p></body></value-richtext>
+		</field>
+		<field name="agree"><value>Yes</value></field>
+	</fields>
+</xfdf>`
+
+	fdata, err := LoadFromXFDF(strings.NewReader(xfdfData))
+	require.NoError(t, err)
+
+	fvalMap, err := fdata.FieldValues()
+	require.NoError(t, err)
+	require.Len(t, fvalMap, 5)
+	require.Equal(t, "Paris", fvalMap["address.city"].String())
+	require.Equal(t, "75001", fvalMap["address.zip"].String())
+	require.Equal(t, "Yes", fvalMap["agree"].String())
+	require.Equal(t, "Hello world\nBye", fvalMap["comment"].String())
+	colors, ok := core.GetArray(fvalMap["colors"])
+	require.True(t, ok)
+	require.Equal(t, 2, colors.Len())
+	require.Equal(t, "Blue", colors.Get(1).String())
+
+	// Export and reload.
+	data, err := fdata.XFDF()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(data, `<?xml version="1.0" encoding="UTF-8"?>`))
+	require.Contains(t, data, `<xfdf xmlns="http://ns.adobe.com/xfdf/" xml:space="preserve">`)
+
+	fdata2, err := LoadFromXFDF(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, fdata.values, fdata2.values)
+}
+
+func TestFieldType(t *testing.T) {
+	newField := func(ctx func(*model.PdfField) model.PdfModel, flags model.FieldFlag) *model.PdfField {
+		field := model.NewPdfField()
+		field.SetContext(ctx(field))
+		field.SetFlag(flags)
+		return field
+	}
+	text := func(f *model.PdfField) model.PdfModel { return &model.PdfFieldText{PdfField: f} }
+	button := func(f *model.PdfField) model.PdfModel { return &model.PdfFieldButton{PdfField: f} }
+	choice := func(f *model.PdfField) model.PdfModel { return &model.PdfFieldChoice{PdfField: f} }
+	signature := func(f *model.PdfField) model.PdfModel { return &model.PdfFieldSignature{PdfField: f} }
+
+	require.Equal(t, "text", fieldType(newField(text, 0)))
+	require.Equal(t, "checkbox", fieldType(newField(button, 0)))
+	require.Equal(t, "radio", fieldType(newField(button, model.FieldFlagRadio)))
+	require.Equal(t, "pushbutton", fieldType(newField(button, model.FieldFlagPushbutton)))
+	require.Equal(t, "combo", fieldType(newField(choice, model.FieldFlagCombo)))
+	require.Equal(t, "list", fieldType(newField(choice, 0)))
+	require.Equal(t, "signature", fieldType(newField(signature, 0)))
+	require.Equal(t, "", fieldType(model.NewPdfField()))
+
+	// The type is tolerated on import.
+	fdata, err := LoadFromJSON(strings.NewReader(`[{"name": "a", "type": "text", "value": "b"}]`))
+	require.NoError(t, err)
+	fvalMap, err := fdata.FieldValues()
+	require.NoError(t, err)
+	require.Equal(t, "b", fvalMap["a"].String())
+}
+
+func TestMultiSelectFillAndExtract(t *testing.T) {
+	// Create a form with a multi-select list box.
+	page := model.NewPdfPage()
+	page.MediaBox = &model.PdfRectangle{Urx: 200, Ury: 200}
+
+	field := model.NewPdfField()
+	choice := &model.PdfFieldChoice{PdfField: field}
+	field.SetContext(choice)
+	field.T = core.MakeString("colors")
+	field.SetFlag(model.FieldFlagMultiSelect)
+	choice.Opt = core.MakeArray(core.MakeString("Red"), core.MakeString("Green"), core.MakeString("Blue"))
+
+	widget := model.NewPdfAnnotationWidget()
+	widget.Rect = core.MakeArrayFromFloats([]float64{10, 10, 100, 60})
+	widget.Parent = field.ToPdfObject()
+	stateDict := core.MakeDict()
+	for _, state := range []core.PdfObjectName{"Red", "Blue"} {
+		stream, err := core.MakeStream(nil, nil)
+		require.NoError(t, err)
+		stateDict.Set(state, stream)
+	}
+	apDict := core.MakeDict()
+	apDict.Set("N", stateDict)
+	widget.AP = apDict
+	field.Annotations = append(field.Annotations, widget)
+	page.AddAnnotation(widget.PdfAnnotation)
+
+	form := model.NewPdfAcroForm()
+	form.Fields = &[]*model.PdfField{field}
+
+	// Fill the list box with multiple values.
+	fdata, err := LoadFromJSON(strings.NewReader(`[{"name": "colors", "value": ["Blue", "Red"]}]`))
+	require.NoError(t, err)
+	require.NoError(t, form.Fill(fdata))
+
+	writer := model.NewPdfWriter()
+	require.NoError(t, writer.AddPage(page))
+	require.NoError(t, writer.SetForms(form))
+	var buf bytes.Buffer
+	require.NoError(t, writer.Write(&buf))
+
+	reader, err := model.NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	filled, ok := reader.AcroForm.AllFields()[0].GetContext().(*model.PdfFieldChoice)
+	require.True(t, ok)
+	indices, err := filled.I.ToIntegerArray()
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 2}, indices)
+
+	// The values are extracted as an array.
+	fdata, err = LoadFromPDF(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	data, err := fdata.JSON()
+	require.NoError(t, err)
+
+	var fields []struct {
+		Name    string   `json:"name"`
+		Value   []string `json:"value"`
+		Options []string `json:"options"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(data), &fields))
+	require.Len(t, fields, 1)
+	require.Equal(t, []string{"Blue", "Red"}, fields[0].Value)
+
+	// The options are extracted as for single values.
+	require.Equal(t, []string{"Red", "Blue"}, fields[0].Options)
+
+	// Single values are extracted as strings.
+	fdata, err = LoadFromJSON(strings.NewReader(`[{"name": "colors", "value": ["Green"]}]`))
+	require.NoError(t, err)
+	data, err = fdata.JSON()
+	require.NoError(t, err)
+	require.Contains(t, data, `"value": "Green"`)
+}
+
+func TestFieldDataGetSet(t *testing.T) {
+	fdata, err := LoadFromPDFFile(`./testdata/basicform.pdf`)
+	require.NoError(t, err)
+
+	// The names are in the order of the form fields.
+	names := fdata.Names()
+	require.Len(t, names, 9)
+	require.Equal(t, "full_name", names[0])
+	require.Equal(t, "female", names[7])
+
+	value, ok := fdata.Get("female")
+	require.True(t, ok)
+	require.Equal(t, "Off", value)
+	_, ok = fdata.Get("missing")
+	require.False(t, ok)
+
+	// Setting the values keeps the order of the fields.
+	fdata.Set("full_name", "John Doe")
+	fdata.Set("nickname", "JD")
+	value, ok = fdata.Get("full_name")
+	require.True(t, ok)
+	require.Equal(t, "John Doe", value)
+	require.Equal(t, append(names, "nickname"), fdata.Names())
+
+	// Multi-select values are replaced by the single value.
+	fdata, err = LoadFromJSON(strings.NewReader(`[{"name": "colors", "value": ["Blue", "Red"]}]`))
+	require.NoError(t, err)
+	value, _ = fdata.Get("colors")
+	require.Equal(t, "Blue", value)
+	fdata.Set("colors", "Green")
+	fvals, err := fdata.FieldValues()
+	require.NoError(t, err)
+	require.Equal(t, map[string]core.PdfObject{"colors": core.MakeString("Green")}, fvals)
+}
+
+func TestFieldDataMergeDiff(t *testing.T) {
+	load := func(data string) *FieldData {
+		fdata, err := LoadFromJSON(strings.NewReader(data))
+		require.NoError(t, err)
+		return fdata
+	}
+	values := func(fdata *FieldData) map[string]core.PdfObject {
+		fvals, err := fdata.FieldValues()
+		require.NoError(t, err)
+		return fvals
+	}
+
+	current := load(`[
+		{"name": "name", "type": "text", "value": "John"},
+		{"name": "city", "type": "text", "value": "Paris"},
+		{"name": "country", "type": "choice", "value": "FR", "options": ["FR", "DE"]},
+		{"name": "colors", "value": ["Red", "Blue"]}
+	]`)
+	update := load(`[
+		{"name": "name", "value": "Jane"},
+		{"name": "city", "value": ""},
+		{"name": "country", "value": "DE"},
+		{"name": "colors", "value": ["Blue", "Red"]},
+		{"name": "zip", "value": "75001"},
+		{"name": "phone", "value": ""}
+	]`)
+
+	// Changed, cleared and new values differ, unlike the values selected in
+	// another order.
+	diff := current.Diff(update)
+	var names []string
+	for _, fval := range diff.values {
+		names = append(names, fval.Name)
+	}
+	require.Equal(t, []string{"name", "city", "country", "zip"}, names)
+	require.Empty(t, current.Diff(current).values)
+
+	// Empty values do not clear the values, and new fields are appended.
+	current.Merge(update)
+	require.Equal(t, map[string]core.PdfObject{
+		"name":    core.MakeString("Jane"),
+		"city":    core.MakeString("Paris"),
+		"country": core.MakeString("DE"),
+		"colors":  core.MakeArray(core.MakeString("Blue"), core.MakeString("Red")),
+		"zip":     core.MakeString("75001"),
+	}, values(current))
+	require.Len(t, current.values, 5)
+
+	// The types and options of the fields are kept.
+	country := current.values[2]
+	require.Equal(t, "choice", country.Type)
+	require.Equal(t, []string{"FR", "DE"}, country.Options)
+
+	// Multi-select values are replaced as a whole.
+	current.Merge(load(`[{"name": "colors", "value": "Green"}]`))
+	require.Equal(t, core.MakeString("Green"), values(current)["colors"])
+}
+
+func TestCSVImportExport(t *testing.T) {
+	// Header and data row layout.
+	fdata, err := LoadFromCSV(strings.NewReader("full_name,city,male\n\"Doe, Jane\",Reykjavík,Yes\n"))
+	require.NoError(t, err)
+	fvalMap, err := fdata.FieldValues()
+	require.NoError(t, err)
+	require.Len(t, fvalMap, 3)
+	require.Equal(t, "Doe, Jane", fvalMap["full_name"].String())
+	require.Equal(t, "Reykjavík", fvalMap["city"].String())
+
+	data, err := fdata.CSV()
+	require.NoError(t, err)
+	require.Equal(t, "full_name,city,male\n\"Doe, Jane\",Reykjavík,Yes\n", data)
+
+	// Name/value layout.
+	fdata2, err := LoadFromCSVNameValue(strings.NewReader("Name,Value\nfull_name,\"Doe, Jane\"\ncity,Reykjavík\nmale,Yes\n"))
+	require.NoError(t, err)
+	require.Equal(t, fdata.values, fdata2.values)
+
+	// Fields named "name" and "value" use the header and data row layout.
+	fdata2, err = LoadFromCSV(strings.NewReader("name,value\nJane,42\n"))
+	require.NoError(t, err)
+	require.Equal(t, []fieldValue{{Name: "name", Value: "Jane"}, {Name: "value", Value: "42"}}, fdata2.values)
+
+	// Multi-select values are stored in a single cell.
+	fdata2 = &FieldData{values: []fieldValue{
+		{Name: "colors", Value: "red", Values: []string{"red", "green"}},
+		{Name: "list", Value: "[not json"},
+	}}
+	data, err = fdata2.CSV()
+	require.NoError(t, err)
+	require.Equal(t, "colors,list\n\"[\"\"red\"\",\"\"green\"\"]\",[not json\n", data)
+	fdata3, err := LoadFromCSV(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Equal(t, fdata2.values, fdata3.values)
+
+	_, err = LoadFromCSV(strings.NewReader(""))
+	require.Error(t, err)
+	_, err = LoadFromCSV(strings.NewReader("full_name,city\n"))
+	require.Error(t, err)
+
+	// Batch filling, one form for each data row.
+	rows, err := LoadFromCSVRows(strings.NewReader("full_name,city\nName 0,\n,City 1\n"))
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	f, err := os.Open(`./testdata/basicform.pdf`)
+	require.NoError(t, err)
+	defer f.Close()
+	reader, err := model.NewPdfReader(f)
+	require.NoError(t, err)
+
+	var values []map[string]string
+	err = reader.FillBatch(rowIterator(rows), nil, func(i int, w *model.PdfWriter) error {
+		var buf bytes.Buffer
+		if err := w.Write(&buf); err != nil {
+			return err
+		}
+		fdata, err := LoadFromPDF(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			return err
+		}
+		rowValues := map[string]string{}
+		for _, fval := range fdata.values {
+			rowValues[fval.Name] = fval.Value
+		}
+		values = append(values, rowValues)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	require.Equal(t, "Name 0", values[0]["full_name"])
+	require.Equal(t, "", values[0]["city"])
+	require.Equal(t, "", values[1]["full_name"])
+	require.Equal(t, "City 1", values[1]["city"])
+}
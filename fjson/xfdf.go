@@ -0,0 +1,160 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package fjson
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+)
+
+// xfdfNamespace is the XML namespace of XFDF documents.
+const xfdfNamespace = "http://ns.adobe.com/xfdf/"
+
+// xfdfDocument represents the root element of an XFDF document.
+type xfdfDocument struct {
+	XMLName xml.Name     `xml:"xfdf"`
+	Xmlns   string       `xml:"xmlns,attr,omitempty"`
+	Space   string       `xml:"xml:space,attr,omitempty"`
+	Fields  []*xfdfField `xml:"fields>field"`
+}
+
+// xfdfField represents a field element of an XFDF document. Fields which
+// are part of a hierarchy of fields contain their descendant fields.
+type xfdfField struct {
+	Name     string        `xml:"name,attr"`
+	Values   []string      `xml:"value"`
+	RichText *xfdfRichText `xml:"value-richtext"`
+	Fields   []*xfdfField  `xml:"field"`
+}
+
+// xfdfRichText represents the rich text value of an XFDF field.
+type xfdfRichText struct {
+	Content string `xml:",innerxml"`
+}
+
+// LoadFromXFDF loads form field data from the XML Forms Data Format (XFDF)
+// data read from `r`. The names of nested fields are the fully qualified
+// names of the fields (e.g. "address.city"). All the values of fields with
+// multiple values (e.g. multi-select list boxes) are loaded. For rich text
+// values, the plain text of the rich text is used as the field value.
+func LoadFromXFDF(r io.Reader) (*FieldData, error) {
+	var doc xfdfDocument
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	var fdata FieldData
+	var addFields func(fields []*xfdfField, parentName string)
+	addFields = func(fields []*xfdfField, parentName string) {
+		for _, field := range fields {
+			name := field.Name
+			if parentName != "" {
+				name = parentName + "." + name
+			}
+
+			if len(field.Values) > 0 || field.RichText != nil {
+				fval := fieldValue{Name: name}
+				if len(field.Values) > 0 {
+					fval.Value = field.Values[0]
+				}
+				if len(field.Values) > 1 {
+					fval.Values = field.Values
+				}
+				if field.RichText != nil {
+					fval.RichText = strings.TrimSpace(field.RichText.Content)
+					fval.Value = richTextToPlainText(fval.RichText)
+				}
+				fdata.values = append(fdata.values, fval)
+			}
+
+			addFields(field.Fields, name)
+		}
+	}
+	addFields(doc.Fields, "")
+
+	return &fdata, nil
+}
+
+// LoadFromXFDFFile loads form field data from an XFDF file.
+func LoadFromXFDFFile(filePath string) (*FieldData, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return LoadFromXFDF(f)
+}
+
+// XFDF returns the field data as a string in XFDF format. Fields with fully
+// qualified names containing dots are nested in the fields of their
+// ancestors.
+func (fd FieldData) XFDF() (string, error) {
+	doc := xfdfDocument{Xmlns: xfdfNamespace, Space: "preserve"}
+
+	// Returns the child field of `fields` with the specified partial name,
+	// creating it if it does not exist.
+	getField := func(fields *[]*xfdfField, name string) *xfdfField {
+		for _, field := range *fields {
+			if field.Name == name {
+				return field
+			}
+		}
+		field := &xfdfField{Name: name}
+		*fields = append(*fields, field)
+		return field
+	}
+
+	for _, fval := range fd.values {
+		fields := &doc.Fields
+		var field *xfdfField
+		for _, name := range strings.Split(fval.Name, ".") {
+			field = getField(fields, name)
+			fields = &field.Fields
+		}
+
+		switch {
+		case fval.RichText != "":
+			field.RichText = &xfdfRichText{Content: fval.RichText}
+		case len(fval.Values) > 0:
+			field.Values = fval.Values
+		default:
+			field.Values = []string{fval.Value}
+		}
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		return "", err
+	}
+	return xml.Header + string(data), nil
+}
+
+// richTextToPlainText returns the plain text of the rich text (XHTML)
+// `richText`. The text of each paragraph is placed on a separate line.
+func richTextToPlainText(richText string) string {
+	var buf bytes.Buffer
+	decoder := xml.NewDecoder(strings.NewReader(richText))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if t.Name.Local == "p" && buf.Len() > 0 {
+				buf.WriteByte('\n')
+			}
+		case xml.CharData:
+			buf.Write(t)
+		}
+	}
+	return strings.TrimSpace(buf.String())
+}
@@ -268,6 +268,16 @@ func (b *Bitmap) GetPixel(x, y int) bool {
 	return false
 }
 
+// getPixelBit gets the bit of the pixel at the coordinates 'x', 'y'. The pixels out of the
+// data range are not set.
+func (b *Bitmap) getPixelBit(x, y int) bool {
+	i := b.GetByteIndex(x, y)
+	if i >= len(b.Data) {
+		return false
+	}
+	return b.Data[i]&(0x80>>uint(x&0x07)) != 0
+}
+
 // GetUnpaddedData gets the data without row stride padding.
 // The unpadded data contains bitmap.Height * bitmap.Width bits with
 // optional last byte padding.
@@ -459,16 +469,41 @@ func (b *Bitmap) ThresholdPixelSum(thresh int, tab8 []int) (above bool, err erro
 	return above, nil
 }
 
+// ToGray converts the bitmap into the grayscale image of the same dimensions, where the pixels
+// with the bit set are black and the other pixels are white, as in the decoded jbig2 bitmaps.
+// The padding bits of the rows, for the widths that are not a multiple of 8, are ignored.
+func (b *Bitmap) ToGray() *image.Gray {
+	img := image.NewGray(image.Rect(0, 0, b.Width, b.Height))
+	for y := 0; y < b.Height; y++ {
+		pix := img.Pix[y*img.Stride : y*img.Stride+b.Width]
+		for x := range pix {
+			pix[x] = 0xff
+			if b.getPixelBit(x, y) {
+				pix[x] = 0x00
+			}
+		}
+	}
+	return img
+}
+
 // ToImage gets the bitmap data and store in the image.Image.
+// The image is the grayscale image returned by the ToGray method.
 func (b *Bitmap) ToImage() image.Image {
-	img := image.NewGray(image.Rect(0, 0, b.Width-1, b.Height-1))
-	for x := 0; x < b.Width; x++ {
-		for y := 0; y < b.Height; y++ {
-			c := color.Black
-			if b.GetPixel(x, y) {
-				c = color.White
+	return b.ToGray()
+}
+
+// ToPaletted converts the bitmap into the bitonal image of the same dimensions, with the white
+// and black palette colors at index 0 and 1 respectively, so that the image color indexes match
+// the bitmap bits. The padding bits of the rows, for the widths that are not a multiple of 8,
+// are ignored.
+func (b *Bitmap) ToPaletted() *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, b.Width, b.Height), color.Palette{color.White, color.Black})
+	for y := 0; y < b.Height; y++ {
+		pix := img.Pix[y*img.Stride : y*img.Stride+b.Width]
+		for x := range pix {
+			if b.getPixelBit(x, y) {
+				pix[x] = 1
 			}
-			img.Set(x, y, c)
 		}
 	}
 	return img
@@ -7,6 +7,8 @@ package bitmap
 
 import (
 	"fmt"
+	"image"
+	"image/color"
 	"math/rand"
 	"testing"
 
@@ -1175,3 +1177,49 @@ func TestNewUnpadded(t *testing.T) {
 		}
 	})
 }
+
+// TestBitmapToImage tests the conversion of the bitmaps with the widths not aligned to the byte
+// into the images.
+func TestBitmapToImage(t *testing.T) {
+	bm := New(10, 3)
+	require.NoError(t, bm.SetPixel(0, 0, 1))
+	require.NoError(t, bm.SetPixel(9, 0, 1))
+	require.NoError(t, bm.SetPixel(8, 2, 1))
+	// set the padding bits of the rows, which must not be a part of the images.
+	for y := 0; y < bm.Height; y++ {
+		bm.Data[y*bm.RowStride+1] |= 0x3f
+	}
+
+	isBlack := func(x, y int) bool {
+		return (x == 0 && y == 0) || (x == 9 && y == 0) || (x == 8 && y == 2)
+	}
+
+	t.Run("Gray", func(t *testing.T) {
+		img := bm.ToGray()
+		require.Equal(t, image.Rect(0, 0, 10, 3), img.Bounds())
+		for y := 0; y < bm.Height; y++ {
+			for x := 0; x < bm.Width; x++ {
+				expected := uint8(0xff)
+				if isBlack(x, y) {
+					expected = 0
+				}
+				assert.Equal(t, expected, img.GrayAt(x, y).Y, "x: %d, y: %d", x, y)
+			}
+		}
+		assert.Equal(t, img, bm.ToImage())
+	})
+
+	t.Run("Paletted", func(t *testing.T) {
+		img := bm.ToPaletted()
+		require.Equal(t, image.Rect(0, 0, 10, 3), img.Bounds())
+		for y := 0; y < bm.Height; y++ {
+			for x := 0; x < bm.Width; x++ {
+				expected := color.Color(color.White)
+				if isBlack(x, y) {
+					expected = color.Black
+				}
+				assert.Equal(t, expected, img.At(x, y), "x: %d, y: %d", x, y)
+			}
+		}
+	})
+}
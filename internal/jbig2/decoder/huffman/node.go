@@ -97,10 +97,15 @@ func (i *InternalNode) Decode(r reader.StreamReader) (int64, error) {
 		return 0, err
 	}
 
-	if b == 0 {
-		return i.zero.Decode(r)
+	child := i.zero
+	if b != 0 {
+		child = i.one
 	}
-	return i.one.Decode(r)
+	// the codes of incomplete tables might not be defined.
+	if child == nil {
+		return 0, fmt.Errorf("huffman code not defined at depth %d", i.depth+1)
+	}
+	return child.Decode(r)
 }
 
 // String implements the Stringer interface.
@@ -110,13 +115,20 @@ func (i *InternalNode) String() string {
 	b.WriteString("\n")
 	i.pad(b)
 	b.WriteString("0: ")
-	b.WriteString(i.zero.String() + "\n")
+	b.WriteString(nodeString(i.zero) + "\n")
 	i.pad(b)
 	b.WriteString("1: ")
-	b.WriteString(i.one.String() + "\n")
+	b.WriteString(nodeString(i.one) + "\n")
 	return b.String()
 }
 
+func nodeString(n Node) string {
+	if n == nil {
+		return "nil"
+	}
+	return n.String()
+}
+
 func (i *InternalNode) append(c *Code) (err error) {
 	// ignore unused codes
 	if c.prefixLength == 0 {
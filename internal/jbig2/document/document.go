@@ -96,6 +96,17 @@ func InitEncodeDocument(fullHeaders bool) *Document {
 // AddGenericPage creates the jbig2 page based on the provided bitmap. The data provided
 func (d *Document) AddGenericPage(bm *bitmap.Bitmap, duplicateLineRemoval bool) (err error) {
 	const processName = "Document.AddGenericPage"
+	if err = d.AddGenericPageTemplate(bm, 0, false, duplicateLineRemoval); err != nil {
+		return errors.Wrap(err, processName, "")
+	}
+	return nil
+}
+
+// AddGenericPageTemplate creates the jbig2 page based on the provided bitmap, encoded as a generic region
+// using the generic region 'template' (0-3). If 'useMMR' is true, the generic region is encoded using
+// the MMR coding instead, and the 'template' and 'duplicateLineRemoval' arguments are not used.
+func (d *Document) AddGenericPageTemplate(bm *bitmap.Bitmap, template int, useMMR, duplicateLineRemoval bool) (err error) {
+	const processName = "Document.AddGenericPageTemplate"
 	// check if this is PDFMode and there is already a page
 	if !d.FullHeaders && d.NumberOfPages != 0 {
 		return errors.Error(processName, "document already contains page. FileMode disallows adding more than one page")
@@ -115,7 +126,12 @@ func (d *Document) AddGenericPage(bm *bitmap.Bitmap, duplicateLineRemoval bool)
 	// add page information segment.
 	page.AddPageInformationSegment()
 	// add the generic region for given bitmap
-	if err = page.AddGenericRegion(bm, 0, 0, 0, segments.TImmediateGenericRegion, duplicateLineRemoval); err != nil {
+	if useMMR {
+		err = page.AddGenericRegionMMR(bm, 0, 0)
+	} else {
+		err = page.AddGenericRegion(bm, 0, 0, template, segments.TImmediateGenericRegion, duplicateLineRemoval)
+	}
+	if err != nil {
 		return errors.Wrap(err, processName, "")
 	}
 	if d.FullHeaders {
@@ -128,6 +144,25 @@ func (d *Document) AddGenericPage(bm *bitmap.Bitmap, duplicateLineRemoval bool)
 // AddClassifiedPage adds the bitmap page with a classification 'method'.
 func (d *Document) AddClassifiedPage(bm *bitmap.Bitmap, method classer.Method) (err error) {
 	const processName = "Document.AddClassifiedPage"
+	if err = d.addClassifiedPage(bm, method, false); err != nil {
+		return errors.Wrap(err, processName, "")
+	}
+	return nil
+}
+
+// AddLosslessClassifiedPage adds the bitmap page with a classification 'method'. The differences between
+// the page bitmap and the symbols placed by the text region are encoded in an additional generic region,
+// so that the decoded page is exactly the same as provided bitmap.
+func (d *Document) AddLosslessClassifiedPage(bm *bitmap.Bitmap, method classer.Method) (err error) {
+	const processName = "Document.AddLosslessClassifiedPage"
+	if err = d.addClassifiedPage(bm, method, true); err != nil {
+		return errors.Wrap(err, processName, "")
+	}
+	return nil
+}
+
+func (d *Document) addClassifiedPage(bm *bitmap.Bitmap, method classer.Method, lossless bool) (err error) {
+	const processName = "addClassifiedPage"
 	// check if this is PDFMode and there is already a page
 	if !d.FullHeaders && d.NumberOfPages != 0 {
 		return errors.Error(processName, "document already contains page. FileMode disallows adding more than one page")
@@ -147,6 +182,7 @@ func (d *Document) AddClassifiedPage(bm *bitmap.Bitmap, method classer.Method) (
 		FinalHeight: bm.Height,
 		FinalWidth:  bm.Width,
 		PageNumber:  pageNumber,
+		IsLossless:  lossless,
 	}
 	d.Pages[pageNumber] = p
 	switch method {
@@ -194,6 +230,7 @@ func (d *Document) completeClassifiedPages() (err error) {
 			globalSymbols = append(globalSymbols, i)
 		}
 	}
+	d.globalSymbolsNumber = len(globalSymbols)
 	// build page components map
 	var (
 		page *Page
@@ -296,9 +333,53 @@ func (d *Document) produceClassifiedPage(page *Page, globalSD *segments.Header)
 		d.Classer.PtaLL, d.Classer.UndilatedTemplates, d.Classer.ClassIDs, nil,
 		log2up(numSyms), len(d.pageComponents[page.PageNumber]))
 
+	if !page.IsLossless {
+		return nil
+	}
+	// encode the differences between the page and the symbols placed by the text region.
+	residue, err := d.classifiedPageResidue(page, comps)
+	if err != nil {
+		return errors.Wrap(err, processName, "")
+	}
+	if residue.Zero() {
+		return nil
+	}
+	if err = page.addResidueRegion(residue); err != nil {
+		return errors.Wrap(err, processName, "")
+	}
 	return nil
 }
 
+// classifiedPageResidue gets the XOR of the 'page' bitmap and the bitmap composed of the
+// symbols placed at the lower left corners of the page components 'comps'.
+func (d *Document) classifiedPageResidue(page *Page, comps []int) (*bitmap.Bitmap, error) {
+	const processName = "classifiedPageResidue"
+	composed := bitmap.New(page.FinalWidth, page.FinalHeight)
+	for _, comp := range comps {
+		classID, err := d.Classer.ClassIDs.Get(comp)
+		if err != nil {
+			return nil, errors.Wrapf(err, processName, "no such classID: %d", comp)
+		}
+		symbol, err := d.Classer.UndilatedTemplates.GetBitmap(classID)
+		if err != nil {
+			return nil, errors.Wrapf(err, processName, "no such symbol: %d", classID)
+		}
+		x, y, err := d.Classer.PtaLL.GetGeometry(comp)
+		if err != nil {
+			return nil, errors.Wrapf(err, processName, "no lower left corner for component: %d", comp)
+		}
+		// the text region places the symbols with their bottom row at the 'y' coordinate.
+		if err = bitmap.Blit(symbol, composed, int(x), int(y)-symbol.Height+1, bitmap.CmbOpOr); err != nil {
+			return nil, errors.Wrap(err, processName, "")
+		}
+	}
+	residue := page.Bitmap.Copy()
+	if err := bitmap.Blit(composed, residue, 0, 0, bitmap.CmbOpXor); err != nil {
+		return nil, errors.Wrap(err, processName, "")
+	}
+	return residue, nil
+}
+
 func log2up(v int) int {
 	r := 0
 	isPow2 := (v & (v - 1)) == 0
@@ -440,11 +521,31 @@ func (d *Document) completeSymbols() (err error) {
 // Encode encodes the given document and stores into 'w' writer.
 func (d *Document) Encode() (data []byte, err error) {
 	const processName = "Document.Encode"
+	if data, _, err = d.encode(false); err != nil {
+		return nil, errors.Wrap(err, processName, "")
+	}
+	return data, nil
+}
+
+// EncodeWithGlobals encodes the jbig2 document pages and it's global segments separately.
+// The returned 'globals' contains the encoded global segments, which in the PDF documents are stored
+// in the stream referred by the 'JBIG2Globals' decode parameter. The 'globals' are nil if the
+// document doesn't contain global segments.
+func (d *Document) EncodeWithGlobals() (data, globals []byte, err error) {
+	const processName = "Document.EncodeWithGlobals"
+	if data, globals, err = d.encode(true); err != nil {
+		return nil, nil, errors.Wrap(err, processName, "")
+	}
+	return data, globals, nil
+}
+
+func (d *Document) encode(separateGlobals bool) (data, globals []byte, err error) {
+	const processName = "Document.encode"
 	var n, temp int
 	// if the full headers flag is on, encode file header
 	if d.FullHeaders {
 		if n, err = d.encodeFileHeader(d.w); err != nil {
-			return nil, errors.Wrap(err, processName, "")
+			return nil, nil, errors.Wrap(err, processName, "")
 		}
 	}
 	var (
@@ -454,28 +555,39 @@ func (d *Document) Encode() (data []byte, err error) {
 	)
 	// complete classified pages
 	if err = d.completeClassifiedPages(); err != nil {
-		return nil, errors.Wrap(err, processName, "")
+		return nil, nil, errors.Wrap(err, processName, "")
 	}
 	// produce classified pages
 	if err = d.produceClassifiedPages(); err != nil {
-		return nil, errors.Wrap(err, processName, "")
+		return nil, nil, errors.Wrap(err, processName, "")
 	}
 
-	if d.GlobalSegments != nil {
-		for _, seg = range d.GlobalSegments.Segments {
-			if err = d.encodeSegment(seg, &n); err != nil {
-				return nil, errors.Wrap(err, processName, "")
+	if d.GlobalSegments != nil && len(d.GlobalSegments.Segments) > 0 {
+		if separateGlobals {
+			gw := writer.BufferedMSB()
+			var gn int
+			for _, seg = range d.GlobalSegments.Segments {
+				if err = d.encodeSegment(gw, seg, &gn); err != nil {
+					return nil, nil, errors.Wrap(err, processName, "")
+				}
+			}
+			globals = gw.Data()
+		} else {
+			for _, seg = range d.GlobalSegments.Segments {
+				if err = d.encodeSegment(d.w, seg, &n); err != nil {
+					return nil, nil, errors.Wrap(err, processName, "")
+				}
 			}
 		}
 	}
 
 	for i := 1; i <= int(d.NumberOfPages); i++ {
 		if page, ok = d.Pages[i]; !ok {
-			return nil, errors.Errorf(processName, "page: '%d' not found", i)
+			return nil, nil, errors.Errorf(processName, "page: '%d' not found", i)
 		}
 		for _, seg = range page.Segments {
-			if err = d.encodeSegment(seg, &n); err != nil {
-				return nil, errors.Wrap(err, processName, "")
+			if err = d.encodeSegment(d.w, seg, &n); err != nil {
+				return nil, nil, errors.Wrap(err, processName, "")
 			}
 		}
 	}
@@ -483,7 +595,7 @@ func (d *Document) Encode() (data []byte, err error) {
 	// with full headers the End Of File header must be encoded
 	if d.FullHeaders {
 		if temp, err = d.encodeEOFHeader(d.w); err != nil {
-			return nil, errors.Wrap(err, processName, "")
+			return nil, nil, errors.Wrap(err, processName, "")
 		}
 		n += temp
 	}
@@ -493,14 +605,14 @@ func (d *Document) Encode() (data []byte, err error) {
 	if len(data) != n {
 		common.Log.Debug("Bytes written (n): '%d' is not equal to the length of the data encoded: '%d'", n, len(data))
 	}
-	return data, nil
+	return data, globals, nil
 }
 
-func (d *Document) encodeSegment(seg *segments.Header, n *int) error {
+func (d *Document) encodeSegment(w writer.BinaryWriter, seg *segments.Header, n *int) error {
 	const processName = "encodeSegment"
 	seg.SegmentNumber = d.nextSegmentNumber()
 
-	temp, err := seg.Encode(d.w)
+	temp, err := seg.Encode(w)
 	if err != nil {
 		return errors.Wrapf(err, processName, "segment: '%d'", seg.SegmentNumber)
 	}
@@ -788,12 +900,14 @@ func decodeWithGlobals(input reader.StreamReader, globals *Globals) (*Document,
 		InputStream:          input,
 		OrganizationType:     segments.OSequential,
 		NumberOfPagesUnknown: true,
-		GlobalSegments:       globals,
+		GlobalSegments:       &Globals{},
 		fileHeaderLength:     9,
 	}
 
-	if d.GlobalSegments == nil {
-		d.GlobalSegments = &Globals{}
+	// the globals might be shared by multiple documents (e.g. the images of a PDF document referring to
+	// the same JBIG2Globals stream), thus the global segments found in the document are added to a copy.
+	if globals != nil {
+		d.GlobalSegments.Segments = append(d.GlobalSegments.Segments, globals.Segments...)
 	}
 
 	// mapData map the data stream
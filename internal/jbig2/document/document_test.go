@@ -7,6 +7,7 @@ package document
 
 import (
 	"fmt"
+	"image"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,7 +17,9 @@ import (
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/bitmap"
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/document/segments"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/encoder/classer"
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/reader"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/writer"
 )
 
 // TestDecodeDocument test the DecodeDocument function.
@@ -812,3 +815,227 @@ func checkPatternDictionary(t *testing.T, dict []*bitmap.Bitmap) {
 		assert.True(t, toCompare.Equals(s), fmt.Sprintf("i: %d, %v, %v", i, s.String(), toCompare.String()))
 	}
 }
+
+// TestEncodeClassifiedDocument tests the symbol dictionary and text region encoding of the classified pages.
+func TestEncodeClassifiedDocument(t *testing.T) {
+	glyphs := [][]string{
+		{"XXXXXX", "XX  XX", "XX  XX", "XXXXXX", "XX  XX", "XX  XX", "XX  XX"},
+		{"XXXXX ", "XX  XX", "XX  XX", "XXXXX ", "XX  XX", "XX  XX", "XXXXX "},
+		{" XXXXX", "XX    ", "XX    ", "XX    ", "XX    ", "XX    ", " XXXXX"},
+		// the slightly different version of the first glyph.
+		{"XXXXXX", "XX  XX", "XX  XX", "XXXXXX", "XX  XX", "XX  XX", "XX XXX"},
+	}
+	// textBitmap creates the page with three rows of glyphs.
+	textBitmap := func(t *testing.T, glyphsNumber int) *bitmap.Bitmap {
+		bm := bitmap.New(240, 60)
+		for row := 0; row < 3; row++ {
+			for i := 0; i < 20; i++ {
+				glyph := glyphs[(i+row)%glyphsNumber]
+				for y, line := range glyph {
+					for x, c := range line {
+						if c == 'X' {
+							require.NoError(t, bm.SetPixel(5+i*11+x, 5+row*18+y, 1))
+						}
+					}
+				}
+			}
+		}
+		return bm
+	}
+	// decodePage decodes the first page bitmap of the jbig2 encoded 'data'.
+	decodePage := func(t *testing.T, data []byte) (*Page, *bitmap.Bitmap) {
+		decoded, err := DecodeDocument(reader.New(data), nil)
+		require.NoError(t, err)
+
+		pager, err := decoded.GetPage(1)
+		require.NoError(t, err)
+		p, ok := pager.(*Page)
+		require.True(t, ok)
+
+		bm, err := p.GetBitmap()
+		require.NoError(t, err)
+		return p, bm
+	}
+
+	t.Run("Exact", func(t *testing.T) {
+		sbm := textBitmap(t, 3)
+
+		d := InitEncodeDocument(false)
+		require.NoError(t, d.AddClassifiedPage(sbm.Copy(), classer.Correlation))
+		// all the components should be classified into three classes.
+		assert.Equal(t, 60, d.Classer.ClassIDs.Size())
+		assert.Equal(t, 3, d.Classer.UndilatedTemplates.Size())
+
+		data, err := d.Encode()
+		require.NoError(t, err)
+		assert.True(t, len(data) < len(sbm.Data), "encoded: %d, original: %d", len(data), len(sbm.Data))
+
+		p, bm := decodePage(t, data)
+		// page information and text region.
+		assert.Len(t, p.Segments, 2)
+		assert.Equal(t, sbm.Data, bm.Data)
+	})
+
+	t.Run("Lossless", func(t *testing.T) {
+		sbm := textBitmap(t, 4)
+
+		d := InitEncodeDocument(false)
+		require.NoError(t, d.AddLosslessClassifiedPage(sbm.Copy(), classer.Correlation))
+		// the slightly different glyph should be classified as the first one.
+		assert.Equal(t, 3, d.Classer.UndilatedTemplates.Size())
+
+		data, err := d.Encode()
+		require.NoError(t, err)
+
+		p, bm := decodePage(t, data)
+		// page information, text region and the residue generic region.
+		assert.Len(t, p.Segments, 3)
+		assert.Equal(t, sbm.Data, bm.Data)
+	})
+
+	t.Run("Lossy", func(t *testing.T) {
+		sbm := textBitmap(t, 4)
+
+		d := InitEncodeDocument(false)
+		require.NoError(t, d.AddClassifiedPage(sbm.Copy(), classer.Correlation))
+
+		data, err := d.Encode()
+		require.NoError(t, err)
+
+		_, bm := decodePage(t, data)
+		// only the pixels of the slightly different glyphs are lost.
+		assert.NotEqual(t, sbm.Data, bm.Data)
+		require.NoError(t, bitmap.Blit(sbm, bm, 0, 0, bitmap.CmbOpXor))
+		assert.Equal(t, 15, bm.CountPixels())
+	})
+}
+
+// TestDecodeSharedGlobals tests decoding the pages of a document, which refer to the
+// global segments provided separately, for both segment organization types.
+func TestDecodeSharedGlobals(t *testing.T) {
+	glyphs := [][]string{
+		{"XXXXXX", "XX  XX", "XX  XX", "XXXXXX", "XX  XX", "XX  XX", "XX  XX"},
+		{"XXXXX ", "XX  XX", "XX  XX", "XXXXX ", "XX  XX", "XX  XX", "XXXXX "},
+		{" XXXXX", "XX    ", "XX    ", "XX    ", "XX    ", "XX    ", " XXXXX"},
+	}
+	// textBitmap creates the page with a row of glyphs, starting with the glyph 'first'.
+	textBitmap := func(t *testing.T, first int) *bitmap.Bitmap {
+		bm := bitmap.New(240, 20)
+		for i := 0; i < 20; i++ {
+			for y, line := range glyphs[(i+first)%len(glyphs)] {
+				for x, c := range line {
+					if c == 'X' {
+						require.NoError(t, bm.SetPixel(5+i*11+x, 5+y, 1))
+					}
+				}
+			}
+		}
+		return bm
+	}
+	pages := []*bitmap.Bitmap{textBitmap(t, 0), textBitmap(t, 1)}
+
+	d := InitEncodeDocument(true)
+	for _, bm := range pages {
+		require.NoError(t, d.AddClassifiedPage(bm.Copy(), classer.Correlation))
+	}
+	data, globalsData, err := d.EncodeWithGlobals()
+	require.NoError(t, err)
+	require.NotEmpty(t, globalsData)
+
+	globalsDoc, err := DecodeDocument(reader.New(globalsData), nil)
+	require.NoError(t, err)
+	globals := globalsDoc.GlobalSegments
+	numGlobals := len(globals.Segments)
+	require.NotZero(t, numGlobals)
+
+	// decodePages decodes all the pages of the 'data' document using the shared globals.
+	decodePages := func(t *testing.T, data []byte) *Document {
+		decoded, err := DecodeDocument(reader.New(data), globals)
+		require.NoError(t, err)
+		for i, expected := range pages {
+			pager, err := decoded.GetPage(i + 1)
+			require.NoError(t, err)
+			bm, err := pager.GetBitmap()
+			require.NoError(t, err)
+			assert.Equal(t, expected.Data, bm.Data, "page: %d", i+1)
+		}
+		// the shared globals must not be changed by decoding the document.
+		assert.Len(t, globals.Segments, numGlobals)
+		return decoded
+	}
+
+	t.Run("Sequential", func(t *testing.T) {
+		decoded := decodePages(t, data)
+		assert.Equal(t, segments.OSequential, decoded.OrganizationType)
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		// reorganize the sequential document, so that all the segment headers
+		// precede the segment data.
+		sequential, err := DecodeDocument(reader.New(data), globals)
+		require.NoError(t, err)
+
+		var headers, segmentData []byte
+		var segmentNumber uint32
+		for i := 1; i <= len(pages); i++ {
+			page := sequential.Pages[i]
+			for _, seg := range page.Segments {
+				start := int64(seg.SegmentDataStartOffset)
+				end := start + int64(seg.SegmentDataLength)
+				headers = append(headers, data[start-seg.HeaderLength:start]...)
+				segmentData = append(segmentData, data[start:end]...)
+				if seg.SegmentNumber >= segmentNumber {
+					segmentNumber = seg.SegmentNumber + 1
+				}
+			}
+		}
+		w := writer.BufferedMSB()
+		_, err = (&segments.Header{SegmentNumber: segmentNumber, Type: segments.TEndOfFile}).Encode(w)
+		require.NoError(t, err)
+		headers = append(headers, w.Data()...)
+
+		random := append([]byte{}, data[:13]...)
+		// clear the sequential organization flag of the file header.
+		random[8] &^= 0x01
+		random = append(random, headers...)
+		random = append(random, segmentData...)
+
+		decoded := decodePages(t, random)
+		assert.Equal(t, segments.ORandom, decoded.OrganizationType)
+	})
+}
+
+// TestPageGetImage tests the decoding of the page with the width not aligned to the byte
+// into the image.
+func TestPageGetImage(t *testing.T) {
+	bm := bitmap.New(13, 4)
+	for i := 0; i < 4; i++ {
+		require.NoError(t, bm.SetPixel(i*4, i, 1))
+	}
+	require.NoError(t, bm.SetPixel(12, 0, 1))
+
+	d := InitEncodeDocument(true)
+	require.NoError(t, d.AddGenericPage(bm.Copy(), false))
+	data, err := d.Encode()
+	require.NoError(t, err)
+
+	decoded, err := DecodeDocument(reader.New(data), nil)
+	require.NoError(t, err)
+	pager, err := decoded.GetPage(1)
+	require.NoError(t, err)
+	page, ok := pager.(*Page)
+	require.True(t, ok)
+
+	img, err := page.GetImage()
+	require.NoError(t, err)
+	require.Equal(t, image.Rect(0, 0, 13, 4), img.Bounds())
+	for y := 0; y < bm.Height; y++ {
+		for x := 0; x < bm.Width; x++ {
+			expected := uint8(0xff)
+			if bm.GetPixel(x, y) {
+				expected = 0
+			}
+			assert.Equal(t, expected, img.GrayAt(x, y).Y, "x: %d, y: %d", x, y)
+		}
+	}
+}
@@ -34,16 +34,12 @@ func (g *Globals) GetSegment(segmentNumber int) (*segments.Header, error) {
 		return nil, errors.Error(processName, "globals are empty")
 	}
 
-	var segment *segments.Header
-	for _, segment = range g.Segments {
+	for _, segment := range g.Segments {
 		if segment.SegmentNumber == uint32(segmentNumber) {
-			break
+			return segment, nil
 		}
 	}
-	if segment == nil {
-		return nil, errors.Error(processName, "segment not found")
-	}
-	return segment, nil
+	return nil, errors.Errorf(processName, "segment: '%d' not found", segmentNumber)
 }
 
 // GetSegmentByIndex gets segments header by 'index' in the Globals.
@@ -7,6 +7,7 @@ package document
 
 import (
 	"fmt"
+	"image"
 	"math"
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
@@ -90,6 +91,60 @@ func (p *Page) AddGenericRegion(bm *bitmap.Bitmap, xloc, yloc, template int, tp
 	return nil
 }
 
+// AddGenericRegionMMR adds the MMR encoded generic region to the page context.
+// 'bm' 					- bitmap containing data to encode
+// 'xloc' 					- x location of the generic region
+// 'yloc'					- y location of the generic region
+func (p *Page) AddGenericRegionMMR(bm *bitmap.Bitmap, xloc, yloc int) error {
+	const processName = "Page.AddGenericRegionMMR"
+	genReg := &segments.GenericRegion{}
+	if err := genReg.InitEncodeMMR(bm, xloc, yloc); err != nil {
+		return errors.Wrap(err, processName, "")
+	}
+	p.Segments = append(p.Segments, &segments.Header{
+		Type:            segments.TImmediateGenericRegion,
+		PageAssociation: p.PageNumber,
+		SegmentData:     genReg,
+	})
+	return nil
+}
+
+// addResidueRegion adds the generic region with the 'residue' bitmap combined with the page using the XOR
+// combination operator. The region is placed before the end of page segment, if it exists.
+func (p *Page) addResidueRegion(residue *bitmap.Bitmap) error {
+	const processName = "Page.addResidueRegion"
+	header := p.getPageInformationSegment()
+	if header == nil {
+		return errors.Error(processName, "page information segment not found")
+	}
+	pageInfo, ok := header.SegmentData.(*segments.PageInformationSegment)
+	if !ok {
+		return errors.Error(processName, "page information segment not found")
+	}
+	// the residue region overrides the page default combination operator.
+	pageInfo.SetCombinationOperatorOverrideAllowed(true)
+
+	genReg := &segments.GenericRegion{}
+	if err := genReg.InitEncode(residue, 0, 0, 0, false); err != nil {
+		return errors.Wrap(err, processName, "")
+	}
+	genReg.RegionSegment.CombinaionOperator = bitmap.CmbOpXor
+
+	header = &segments.Header{
+		Type:            segments.TImmediateGenericRegion,
+		PageAssociation: p.PageNumber,
+		SegmentData:     genReg,
+	}
+	index := len(p.Segments)
+	if index > 0 && p.Segments[index-1].Type == segments.TEndOfPage {
+		index--
+	}
+	p.Segments = append(p.Segments, nil)
+	copy(p.Segments[index+1:], p.Segments[index:])
+	p.Segments[index] = header
+	return nil
+}
+
 // AddPageInformationSegment adds the page information segment to the page segments.
 func (p *Page) AddPageInformationSegment() {
 	// prepare page info segment data
@@ -186,6 +241,18 @@ func (p *Page) GetBitmap() (bm *bitmap.Bitmap, err error) {
 	return p.Bitmap, nil
 }
 
+// GetImage decodes the page bitmap and converts it into the grayscale image, where the black
+// pixels of the page are black and the white pixels are white. The image has the page bitmap
+// dimensions, regardless of the row stride of the bitmap data.
+func (p *Page) GetImage() (*image.Gray, error) {
+	const processName = "GetImage"
+	bm, err := p.GetBitmap()
+	if err != nil {
+		return nil, errors.Wrap(err, processName, "")
+	}
+	return bm.ToGray(), nil
+}
+
 // GetHeight gets the page height.
 func (p *Page) GetHeight() (int, error) {
 	return p.getHeight()
@@ -298,6 +365,10 @@ func (p *Page) createNormalPage(i *segments.PageInformationSegment) error {
 				return errors.Errorf(processName, "invalid jbig2 segment type - not a Regioner: %T", s)
 			}
 
+			if err = p.setPageRegionReference(s, int(r.GetRegionInfo().YLocation)); err != nil {
+				return errors.Wrap(err, processName, "")
+			}
+
 			regionBitmap, err := r.GetRegionBitmap()
 			if err != nil {
 				return errors.Wrap(err, processName, "")
@@ -333,6 +404,10 @@ func (p *Page) createStripedPage(i *segments.PageInformationSegment) error {
 			r := sd.(segments.Regioner)
 			regionInfo := r.GetRegionInfo()
 			op := p.getCombinationOperator(i, regionInfo.CombinaionOperator)
+			if err = p.setPageRegionReference(sd, startLine); err != nil {
+				return errors.Wrap(err, processName, "")
+			}
+
 			regionBitmap, err := r.GetRegionBitmap()
 			if err != nil {
 				return errors.Wrap(err, processName, "")
@@ -377,6 +452,30 @@ func (p *Page) collectPageStripes() (stripes []segments.Segmenter, err error) {
 	return stripes, nil
 }
 
+// setPageRegionReference sets the reference bitmap of the generic refinement
+// region segment 's' which doesn't refer to any other region. Such region
+// refines the page bitmap region at its location - 7.4.7.5.
+func (p *Page) setPageRegionReference(s segments.Segmenter, y int) error {
+	const processName = "setPageRegionReference"
+	g, ok := s.(*segments.GenericRefinementRegion)
+	if !ok || g.ReferenceBitmap != nil || !g.RefersToPageRegion() {
+		return nil
+	}
+
+	x := int(g.RegionInfo.XLocation)
+	roi := image.Rect(x, y, x+int(g.RegionInfo.BitmapWidth), y+int(g.RegionInfo.BitmapHeight))
+	if !roi.In(image.Rect(0, 0, p.Bitmap.Width, p.Bitmap.Height)) {
+		return errors.Errorf(processName, "refinement region: %v is out of the page bounds", roi)
+	}
+
+	reference, err := bitmap.Extract(roi, p.Bitmap)
+	if err != nil {
+		return errors.Wrap(err, processName, "")
+	}
+	g.ReferenceBitmap = reference
+	return nil
+}
+
 func (p *Page) clearSegmentData() {
 	for i := range p.Segments {
 		p.Segments[i].CleanSegmentData()
@@ -59,6 +59,8 @@ func (g *GenericRefinementRegion) Init(header *Header, r reader.StreamReader) er
 	g.h = header
 	g.r = r
 	g.RegionInfo = NewRegionSegment(r)
+	g.t0 = &template0{}
+	g.t1 = &template1{}
 	return g.parseHeader()
 }
 
@@ -135,7 +137,7 @@ func (g *GenericRefinementRegion) GetRegionBitmap() (*bitmap.Bitmap, error) {
 			}
 		} else {
 			// 6.3.5.6 - 3 d)
-			err = g.decodeTypicalPredictedLine(y, g.RegionBitmap.Width, g.RegionBitmap.RowStride, g.ReferenceBitmap.RowStride, paddedWidth, deltaRefStride)
+			err = g.decodeTypicalPredictedLine(y, g.RegionBitmap.Width)
 			if err != nil {
 				return nil, err
 			}
@@ -151,6 +153,13 @@ func (g *GenericRefinementRegion) GetRegionInfo() *RegionSegment {
 	return g.RegionInfo
 }
 
+// RefersToPageRegion checks if the region doesn't refer to any other region
+// segment. Such region refines the page bitmap region at its location, which
+// needs to be set as the ReferenceBitmap prior to decoding - 7.4.7.5.
+func (g *GenericRefinementRegion) RefersToPageRegion() bool {
+	return g.h == nil || len(g.h.RTSegments) == 0
+}
+
 func (g *GenericRefinementRegion) decodeSLTP() (int, error) {
 	g.Template.setIndex(g.cx)
 	return g.arithDecode.DecodeBit(g.cx)
@@ -159,6 +168,7 @@ func (g *GenericRefinementRegion) decodeSLTP() (int, error) {
 func (g *GenericRefinementRegion) getGrReference() (*bitmap.Bitmap, error) {
 	segments := g.h.RTSegments
 	if len(segments) == 0 {
+		// 7.4.7.5 - the page region reference should be set by the page.
 		return nil, errors.New("Referenced Segment not exists")
 	}
 
@@ -203,321 +213,76 @@ func (g *GenericRefinementRegion) decodeOptimized(
 	return err
 }
 
-func (g *GenericRefinementRegion) decodeTypicalPredictedLine(
-	lineNumber, width, rowStride, refRowStride, paddedWidth, deltaRefStride int,
-) error {
-	// Offset of the reference bitmap with respect to the bitmap being decoded
+// decodeTypicalPredictedLine decodes the typically predicted line 'lineNumber' of
+// the region bitmap - 6.3.5.6 - 3 d). The pixels whose reference bitmap
+// neighbourhood has a single value (TPGRPIX) are set to that value, the remaining
+// pixels are decoded using the template context.
+func (g *GenericRefinementRegion) decodeTypicalPredictedLine(lineNumber, width int) error {
 	currentLine := lineNumber - int(g.ReferenceDY)
-	refByteIndex := g.ReferenceBitmap.GetByteIndex(0, currentLine)
-	byteIndex := g.RegionBitmap.GetByteIndex(0, lineNumber)
-	var err error
-
-	switch g.TemplateID {
-	case 0:
-		err = g.decodeTypicalPredictedLineTemplate0(lineNumber, width, rowStride, refRowStride, paddedWidth, deltaRefStride, byteIndex, currentLine, refByteIndex)
-	case 1:
-		err = g.decodeTypicalPredictedLineTemplate1(lineNumber, width, rowStride, refRowStride, paddedWidth, deltaRefStride, byteIndex, currentLine, refByteIndex)
-	}
-	return err
-}
-
-func (g *GenericRefinementRegion) decodeTypicalPredictedLineTemplate0(
-	lineNumber, width, rowStride, refRowStride, paddedWidth,
-	deltaRefStride, byteIndex, currentLine, refByteIndex int,
-) error {
-	var (
-		context, overridenContext, previousLine, previousReferenceLine,
-		currentReferenceLine, nextReferenceLine int
-		temp byte
-		err  error
-	)
-
-	if lineNumber > 0 {
-		temp, err = g.RegionBitmap.GetByte(byteIndex - rowStride)
-		if err != nil {
-			return err
-		}
-		previousLine = int(temp)
-	}
-
-	if currentLine > 0 && currentLine <= g.ReferenceBitmap.Height {
-		temp, err = g.ReferenceBitmap.GetByte(refByteIndex - refRowStride + deltaRefStride)
-		if err != nil {
-			return err
-		}
-		previousReferenceLine = int(temp) << 4
-	}
-
-	if currentLine >= 0 && currentLine < g.ReferenceBitmap.Height {
-		temp, err = g.ReferenceBitmap.GetByte(refByteIndex + deltaRefStride)
-		if err != nil {
-			return err
-		}
-		currentReferenceLine = int(temp) << 1
-	}
-
-	if currentLine > -2 && currentLine < g.ReferenceBitmap.Height-1 {
-		temp, err = g.ReferenceBitmap.GetByte(refByteIndex + refRowStride + deltaRefStride)
-		if err != nil {
-			return err
-		}
-		nextReferenceLine = int(temp)
-	}
-
-	context = ((previousLine >> 5) & 0x6) | ((nextReferenceLine >> 2) & 0x30) | (currentReferenceLine & 0x180) | (previousReferenceLine & 0xc00)
-	var nextByte int
-
-	for x := 0; x < paddedWidth; x = nextByte {
-		var result int
-		nextByte = x + 8
-		var minorWidth int
-
-		if minorWidth = width - x; minorWidth > 8 {
-			minorWidth = 8
-		}
-
-		readNextByte := nextByte < width
-		refReadNextByte := nextByte < g.ReferenceBitmap.Width
-		yOffset := deltaRefStride + 1
-
-		if lineNumber > 0 {
-			temp = 0
-			if readNextByte {
-				temp, err = g.RegionBitmap.GetByte(byteIndex - rowStride + 1)
-				if err != nil {
-					return err
-				}
-			}
-			previousLine = (previousLine << 8) | int(temp)
-		}
-
-		if currentLine > 0 && currentLine <= g.ReferenceBitmap.Height {
-			var tempVal int
-			if refReadNextByte {
-				temp, err = g.ReferenceBitmap.GetByte(refByteIndex - refRowStride + yOffset)
-				if err != nil {
-					return err
-				}
-				tempVal = int(temp) << 4
-			}
-			previousReferenceLine = (previousReferenceLine << 8) | tempVal
-		}
+	for x := 0; x < width; x++ {
+		bit, isPredicted := g.typicalPredictedValue(x-int(g.ReferenceDX), currentLine)
+		if !isPredicted {
+			g.cx.SetIndex(int32(g.pixelContext(x, lineNumber)))
 
-		if currentLine >= 0 && currentLine < g.ReferenceBitmap.Height {
-			var tempVal int
-			if refReadNextByte {
-				temp, err = g.ReferenceBitmap.GetByte(refByteIndex + yOffset)
-				if err != nil {
-					return err
-				}
-				tempVal = int(temp) << 1
+			var err error
+			bit, err = g.arithDecode.DecodeBit(g.cx)
+			if err != nil {
+				return err
 			}
-			currentReferenceLine = (currentReferenceLine << 8) | tempVal
 		}
 
-		if currentLine > -2 && currentLine < (g.ReferenceBitmap.Height-1) {
-			temp = 0
-			if refReadNextByte {
-				temp, err = g.ReferenceBitmap.GetByte(refByteIndex + refRowStride + yOffset)
-				if err != nil {
-					return err
-				}
-			}
-			nextReferenceLine = (nextReferenceLine << 8) | int(temp)
+		if bit == 0 {
+			continue
 		}
-
-		for minorX := 0; minorX < minorWidth; minorX++ {
-			var bit int
-			isPixelTypicalPredicted := false
-			bitmapValue := (context >> 4) & 0x1ff
-
-			if bitmapValue == 0x1ff {
-				isPixelTypicalPredicted = true
-				bit = 1
-			} else if bitmapValue == 0x00 {
-				isPixelTypicalPredicted = true
-			}
-
-			if !isPixelTypicalPredicted {
-				if g.override {
-					overridenContext = g.overrideAtTemplate0(context, x+minorX, lineNumber, result, minorX)
-					g.cx.SetIndex(int32(overridenContext))
-				} else {
-					g.cx.SetIndex(int32(context))
-				}
-
-				bit, err = g.arithDecode.DecodeBit(g.cx)
-				if err != nil {
-					return err
-				}
-			}
-
-			toShift := uint(7 - minorX)
-			result |= int(bit << toShift)
-			context = ((context & 0xdb6) << 1) | bit | (previousLine>>toShift+5)&0x002 |
-				((nextReferenceLine>>toShift + 2) & 0x010) |
-				((currentReferenceLine >> toShift) & 0x080) |
-				((previousReferenceLine >> toShift) & 0x400)
-		}
-
-		err = g.RegionBitmap.SetByte(byteIndex, byte(result))
-		if err != nil {
+		if err := g.RegionBitmap.SetPixel(x, lineNumber, 1); err != nil {
 			return err
 		}
-
-		byteIndex++
-		refByteIndex++
 	}
 	return nil
 }
 
-func (g *GenericRefinementRegion) decodeTypicalPredictedLineTemplate1(
-	lineNumber, width, rowStride, refRowStride, paddedWidth,
-	deltaRefStride, byteIndex, currentLine, refByteIndex int,
-) (err error) {
-	var (
-		context, grReferenceValue               int
-		previousLine, previousReferenceLine     int
-		currentReferenceLine, nextReferenceLine int
-		temp                                    byte
-	)
-
-	if lineNumber > 0 {
-		temp, err = g.RegionBitmap.GetByte(byteIndex - rowStride)
-		if err != nil {
-			return
-		}
-		previousLine = int(temp)
-	}
-
-	if currentLine > 0 && currentLine <= g.ReferenceBitmap.Height {
-		temp, err = g.ReferenceBitmap.GetByte(refByteIndex - refRowStride + deltaRefStride)
-		if err != nil {
-			return
-		}
-		previousReferenceLine = int(temp) << 2
-	}
-
-	if currentLine >= 0 && currentLine < g.ReferenceBitmap.Height {
-		temp, err = g.ReferenceBitmap.GetByte(refByteIndex + deltaRefStride)
-		if err != nil {
-			return
-		}
-		currentReferenceLine = int(temp)
-	}
-
-	if currentLine > -2 && currentLine < g.ReferenceBitmap.Height-1 {
-		temp, err = g.ReferenceBitmap.GetByte(refByteIndex + refRowStride + deltaRefStride)
-		if err != nil {
-			return
-		}
-		nextReferenceLine = int(temp)
-	}
-
-	context = ((previousLine >> 5) & 0x6) | ((nextReferenceLine >> 2) & 0x30) |
-		(currentReferenceLine & 0xc0) | (previousReferenceLine & 0x200)
-	grReferenceValue = ((nextReferenceLine >> 2) & 0x70) | (currentReferenceLine & 0xc0) |
-		(previousReferenceLine & 0x700)
-	var nextByte int
-
-	for x := 0; x < paddedWidth; x = nextByte {
-		var (
-			minorWidth int
-			result     int
-		)
-		nextByte = x + 8
-
-		if minorWidth = width - x; minorWidth > 8 {
-			minorWidth = 8
-		}
-
-		readNextByte := nextByte < width
-		refReadNextByte := nextByte < g.ReferenceBitmap.Width
-		yOffset := deltaRefStride + 1
-
-		if lineNumber > 0 {
-			temp = 0
-			if readNextByte {
-				temp, err = g.RegionBitmap.GetByte(byteIndex - rowStride + 1)
-				if err != nil {
-					return
-				}
-			}
-			previousLine = (previousLine << 8) | int(temp)
-		}
-
-		if currentLine > 0 && currentLine <= g.ReferenceBitmap.Height {
-			var tempVal int
-			if refReadNextByte {
-				temp, err = g.ReferenceBitmap.GetByte(refByteIndex - refRowStride + yOffset)
-				if err != nil {
-					return
-				}
-				tempVal = int(temp) << 2
-			}
-			previousReferenceLine = (previousReferenceLine << 8) | tempVal
-		}
-
-		if currentLine >= 0 && currentLine < g.ReferenceBitmap.Height {
-			temp = 0
-			if refReadNextByte {
-				temp, err = g.ReferenceBitmap.GetByte(refByteIndex + yOffset)
-				if err != nil {
-					return
-				}
+// typicalPredictedValue gets the value of the reference bitmap pixels in the 3x3
+// neighbourhood of the pixel at 'rx', 'ry'. The returned flag is false if the
+// pixels of the neighbourhood does not share a single value.
+func (g *GenericRefinementRegion) typicalPredictedValue(rx, ry int) (int, bool) {
+	value := g.getPixel(g.ReferenceBitmap, rx-1, ry-1)
+	for y := ry - 1; y <= ry+1; y++ {
+		for x := rx - 1; x <= rx+1; x++ {
+			if g.getPixel(g.ReferenceBitmap, x, y) != value {
+				return 0, false
 			}
-			currentReferenceLine = (currentReferenceLine << 8) | int(temp)
 		}
-
-		if currentLine > -2 && currentLine < (g.ReferenceBitmap.Height-1) {
-			temp = 0
-			if refReadNextByte {
-				temp, err = g.ReferenceBitmap.GetByte(refByteIndex + refRowStride + yOffset)
-				if err != nil {
-					return
-				}
-			}
-			nextReferenceLine = (nextReferenceLine << 8) | int(temp)
-		}
-
-		for minorX := 0; minorX < minorWidth; minorX++ {
-			var bit int
-			bitmapValue := (grReferenceValue >> 4) & 0x1ff
-
-			switch bitmapValue {
-			case 0x1ff:
-				bit = 1
-			case 0x00:
-				bit = 0
-			default:
-				g.cx.SetIndex(int32(context))
-				bit, err = g.arithDecode.DecodeBit(g.cx)
-				if err != nil {
-					return
-				}
-			}
-
-			toShift := uint(7 - minorX)
-			result |= int(bit << toShift)
-			context = ((context & 0x0d6) << 1) | bit | (previousLine>>toShift+5)&0x002 |
-				((nextReferenceLine>>toShift + 2) & 0x010) |
-				((currentReferenceLine >> toShift) & 0x040) |
-				((previousReferenceLine >> toShift) & 0x200)
-			grReferenceValue = ((grReferenceValue & 0xdb) << 1) |
-				((nextReferenceLine>>toShift + 2) & 0x010) |
-				((currentReferenceLine >> toShift) & 0x080) |
-				((previousReferenceLine >> toShift) & 0x400)
-		}
-
-		err = g.RegionBitmap.SetByte(byteIndex, byte(result))
-		if err != nil {
-			return
-		}
-
-		byteIndex++
-		refByteIndex++
 	}
-	return nil
+	return value, true
+}
+
+// pixelContext gets the context of the region bitmap pixel at 'x', 'y' for the
+// current template - Figures 12 and 13. The context bits are ordered the same way
+// as the ones formed by the templater, so that both share the decoder statistics.
+func (g *GenericRefinementRegion) pixelContext(x, y int) int {
+	rx, ry := x-int(g.ReferenceDX), y-int(g.ReferenceDY)
+	ref := func(dx, dy int) int {
+		return g.getPixel(g.ReferenceBitmap, rx+dx, ry+dy)
+	}
+	reg := func(dx, dy int) int {
+		return g.getPixel(g.RegionBitmap, x+dx, y+dy)
+	}
+
+	if g.TemplateID == 1 {
+		return ref(0, -1)<<9 |
+			ref(-1, 0)<<8 | ref(0, 0)<<7 | ref(1, 0)<<6 |
+			ref(0, 1)<<5 | ref(1, 1)<<4 |
+			reg(-1, -1)<<3 | reg(0, -1)<<2 | reg(1, -1)<<1 |
+			reg(-1, 0)
+	}
+
+	// the nominal positions of the adaptive template pixels are (-1,-1) for both
+	// the region and the reference bitmap.
+	return ref(int(g.GrAtX[1]), int(g.GrAtY[1]))<<12 | ref(0, -1)<<11 | ref(1, -1)<<10 |
+		ref(-1, 0)<<9 | ref(0, 0)<<8 | ref(1, 0)<<7 |
+		ref(-1, 1)<<6 | ref(0, 1)<<5 | ref(1, 1)<<4 |
+		reg(int(g.GrAtX[0]), int(g.GrAtY[0]))<<3 | reg(0, -1)<<2 | reg(1, -1)<<1 |
+		reg(-1, 0)
 }
 
 func (g *GenericRefinementRegion) decodeTemplate(
@@ -799,12 +564,11 @@ func (g *GenericRefinementRegion) overrideAtTemplate0(context, x, y, result, min
 	}
 
 	if g.grAtOverride[1] {
+		// the second AT pixel is located in the reference bitmap.
 		context &= 0xefff
-		if g.GrAtY[1] == 0 && int(g.GrAtX[1]) >= -minorX {
-			context |= (result >> uint(7-(minorX+int(g.GrAtX[1]))) & 0x1) << 12
-		} else {
-			context |= g.getPixel(g.ReferenceBitmap, x+int(g.GrAtX[1]), y+int(g.GrAtY[1]))
-		}
+		rx := x - int(g.ReferenceDX) + int(g.GrAtX[1])
+		ry := y - int(g.ReferenceDY) + int(g.GrAtY[1])
+		context |= g.getPixel(g.ReferenceBitmap, rx, ry) << 12
 	}
 	return context
 }
@@ -907,6 +671,12 @@ func (g *GenericRefinementRegion) setParameters(
 	}
 
 	g.TemplateID = grTemplate
+	switch g.TemplateID {
+	case 0:
+		g.Template = g.t0
+	case 1:
+		g.Template = g.t1
+	}
 	g.RegionInfo.BitmapWidth = regionWidth
 	g.RegionInfo.BitmapHeight = regionHeight
 	g.ReferenceBitmap = grReference
@@ -930,15 +700,18 @@ func (g *GenericRefinementRegion) updateOverride() error {
 	}
 
 	g.grAtOverride = make([]bool, len(g.GrAtX))
+	g.override = false
 
 	switch g.TemplateID {
 	case 0:
-		if g.GrAtX[0] != -1 && g.GrAtY[0] != -1 {
+		// the AT pixel overrides the context if any of its coordinates differs
+		// from the nominal position (-1,-1).
+		if g.GrAtX[0] != -1 || g.GrAtY[0] != -1 {
 			g.grAtOverride[0] = true
 			g.override = true
 		}
 
-		if g.GrAtX[1] != -1 && g.GrAtY[1] != -1 {
+		if g.GrAtX[1] != -1 || g.GrAtY[1] != -1 {
 			g.grAtOverride[1] = true
 			g.override = true
 		}
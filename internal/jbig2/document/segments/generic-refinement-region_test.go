@@ -6,10 +6,139 @@
 package segments
 
 import (
+	"bytes"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/bitmap"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/encoder/arithmetic"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/reader"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/writer"
 )
 
-// TestDecodeGenericRefinementRegion tests the generic refinement regions within the test.
+// TestDecodeGenericRefinementRegion tests the decode process of the jbig2 Generic Refinement Region.
 func TestDecodeGenericRefinementRegion(t *testing.T) {
-	// NOTE(kucjac): no encoded data with generic refinement region found yet.
+	// the reference bitmap is a frame with a diagonal line.
+	reference := bitmap.New(21, 13)
+	for y := 0; y < reference.Height; y++ {
+		for x := 0; x < reference.Width; x++ {
+			if x == 0 || y == 0 || x == reference.Width-1 || y == reference.Height-1 || x == y {
+				require.NoError(t, reference.SetPixel(x, y, 1))
+			}
+		}
+	}
+
+	// the target bitmap differs from the reference by some pixels.
+	target := reference.Copy()
+	for _, p := range [][2]int{{3, 1}, {4, 4}, {10, 6}, {17, 9}, {20, 12}, {0, 5}} {
+		var bit byte
+		if !target.GetPixel(p[0], p[1]) {
+			bit = 1
+		}
+		require.NoError(t, target.SetPixel(p[0], p[1], bit))
+	}
+
+	testCases := []struct {
+		name   string
+		oy     int
+		refDY  int32
+		refBm  *bitmap.Bitmap
+		target *bitmap.Bitmap
+	}{
+		{name: "Template0", refBm: reference, target: target},
+		{name: "Unchanged", refBm: reference, target: reference},
+		{name: "OffsetY", oy: -1, refDY: 1, refBm: reference, target: target},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			data := encodeRefinementRegion(t, tc.refBm, tc.target, tc.oy)
+
+			r := reader.New(data)
+			h := &Header{}
+			g := newGenericRefinementRegion(r, h)
+			require.NoError(t, g.Init(h, r))
+
+			assert.Equal(t, int8(0), g.TemplateID)
+			assert.False(t, g.IsTPGROn)
+			assert.Equal(t, bitmap.CmbOpReplace, g.RegionInfo.CombinaionOperator)
+			assert.True(t, g.RefersToPageRegion())
+
+			g.ReferenceBitmap = tc.refBm
+			g.ReferenceDY = tc.refDY
+
+			bm, err := g.GetRegionBitmap()
+			require.NoError(t, err)
+			assert.True(t, bm.Equals(tc.target), "decoded refinement region doesn't match the target")
+		})
+	}
+
+	t.Run("MissingReference", func(t *testing.T) {
+		data := encodeRefinementRegion(t, reference, target, 0)
+		r := reader.New(data)
+		h := &Header{}
+		g := newGenericRefinementRegion(r, h)
+		require.NoError(t, g.Init(h, r))
+
+		_, err := g.GetRegionBitmap()
+		assert.Error(t, err)
+	})
+}
+
+// TestGenericRefinementRegionOverride tests the adaptive template pixels override.
+func TestGenericRefinementRegionOverride(t *testing.T) {
+	testCases := []struct {
+		grAtX, grAtY []int8
+		template     int8
+		override     bool
+		grAtOverride []bool
+	}{
+		{grAtX: []int8{-1, -1}, grAtY: []int8{-1, -1}, override: false, grAtOverride: []bool{false, false}},
+		{grAtX: []int8{-2, -1}, grAtY: []int8{-1, -1}, override: true, grAtOverride: []bool{true, false}},
+		{grAtX: []int8{-1, -1}, grAtY: []int8{-1, 0}, override: true, grAtOverride: []bool{false, true}},
+		{grAtX: []int8{0, 1}, grAtY: []int8{-1, 1}, override: true, grAtOverride: []bool{true, true}},
+		{grAtX: []int8{-1, -1}, grAtY: []int8{-1, -1}, template: 1, override: false, grAtOverride: []bool{false, false}},
+	}
+
+	g := newGenericRefinementRegion(nil, nil)
+	for _, tc := range testCases {
+		g.GrAtX, g.GrAtY, g.TemplateID = tc.grAtX, tc.grAtY, tc.template
+		require.NoError(t, g.updateOverride())
+		assert.Equal(t, tc.override, g.override)
+		if tc.template == 0 {
+			assert.Equal(t, tc.grAtOverride, g.grAtOverride)
+		}
+	}
+}
+
+// encodeRefinementRegion encodes the generic refinement region segment data of the 'target' bitmap
+// refined from the 'reference', using the template 0 with nominal adaptive template pixels.
+func encodeRefinementRegion(t *testing.T, reference, target *bitmap.Bitmap, oy int) []byte {
+	t.Helper()
+	w := writer.BufferedMSB()
+
+	region := &RegionSegment{
+		BitmapWidth:        uint32(target.Width),
+		BitmapHeight:       uint32(target.Height),
+		CombinaionOperator: bitmap.CmbOpReplace,
+	}
+	_, err := region.Encode(w)
+	require.NoError(t, err)
+
+	// flags: TPGRON - 0, GRTEMPLATE - 0, followed by the nominal AT pixels.
+	_, err = w.Write([]byte{0x00, 0xff, 0xff, 0xff, 0xff})
+	require.NoError(t, err)
+
+	e := arithmetic.New()
+	require.NoError(t, e.Refine(reference, target, 0, oy))
+	e.Final()
+
+	buf := &bytes.Buffer{}
+	_, err = e.WriteTo(buf)
+	require.NoError(t, err)
+	_, err = w.Write(buf.Bytes())
+	require.NoError(t, err)
+	return w.Data()
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
 
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/ccittfax"
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/bitmap"
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/decoder/arithmetic"
 	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/decoder/mmr"
@@ -52,6 +53,10 @@ type GenericRegion struct {
 	// Bitmap is the decoded generic region image.
 	Bitmap *bitmap.Bitmap
 
+	// skip is the optional bitmap of the pixels which are not decoded and have the value 0,
+	// used by the halftone regions - 6.2.5.7 3 d).
+	skip *bitmap.Bitmap
+
 	arithDecoder *arithmetic.Decoder
 	cx           *arithmetic.DecoderStats
 	mmrDecoder   *mmr.Decoder
@@ -117,9 +122,17 @@ func (g *GenericRegion) Encode(w writer.BinaryWriter) (n int, err error) {
 	}
 	n += i
 
+	if g.UseMMR {
+		// encode the region using MMR coding.
+		if i, err = g.encodeMMR(w); err != nil {
+			return n, errors.Wrap(err, processName, "")
+		}
+		return n + i, nil
+	}
+
 	// encode the region using arithmetic encoder
 	ctx := enc.New()
-	if err = ctx.EncodeBitmap(g.Bitmap, g.IsTPGDon); err != nil {
+	if err = ctx.EncodeGenericBitmap(g.Bitmap, int(g.GBTemplate), g.GBAtX, g.GBAtY, g.IsTPGDon); err != nil {
 		return n, errors.Wrap(err, processName, "")
 	}
 	ctx.Final()
@@ -250,6 +263,19 @@ func (g *GenericRegion) InitEncode(bm *bitmap.Bitmap, xLoc, yLoc, template int,
 	return nil
 }
 
+// InitEncodeMMR initializes the generic region for the provided bitmap 'bm' and it's 'xLoc', 'yLoc' locations,
+// to be encoded using the MMR coding.
+func (g *GenericRegion) InitEncodeMMR(bm *bitmap.Bitmap, xLoc, yLoc int) error {
+	const processName = "GenericRegion.InitEncodeMMR"
+	if err := g.InitEncode(bm, xLoc, yLoc, 0, false); err != nil {
+		return errors.Wrap(err, processName, "")
+	}
+	// MMR encoded regions doesn't use the adaptive template pixels.
+	g.GBAtX, g.GBAtY = nil, nil
+	g.UseMMR = true
+	return nil
+}
+
 // Size returns the byte size of the generic region.
 func (g *GenericRegion) Size() int {
 	// region size + flags + 2 * gb pixel size
@@ -373,6 +399,15 @@ func (g *GenericRegion) copyLineAbove(line int) error {
 	return nil
 }
 
+// decodePixel decodes the value of the pixel at the coordinates 'x', 'y' of the region bitmap.
+// The pixels set in the 'skip' bitmap are not decoded and have the value 0.
+func (g *GenericRegion) decodePixel(x, y int) (int, error) {
+	if g.skip != nil && g.skip.GetPixel(x, y) {
+		return 0, nil
+	}
+	return g.arithDecoder.DecodeBit(g.cx)
+}
+
 func (g *GenericRegion) decodeSLTP() (int, error) {
 	switch g.GBTemplate {
 	case 0:
@@ -486,7 +521,7 @@ func (g *GenericRegion) decodeTemplate0a(line, width, paddedWidth int, byteIndex
 			}
 
 			var bit int
-			bit, err = g.arithDecoder.DecodeBit(g.cx)
+			bit, err = g.decodePixel(x+minorX, line)
 			if err != nil {
 				return errors.Wrap(err, processName, "")
 			}
@@ -580,7 +615,7 @@ func (g *GenericRegion) decodeTemplate0b(line, width, paddedWidth int, byteIndex
 			}
 
 			var bit int
-			bit, err = g.arithDecoder.DecodeBit(g.cx)
+			bit, err = g.decodePixel(x+minorX, line)
 			if err != nil {
 				return errors.Wrap(err, processName, "")
 			}
@@ -673,7 +708,7 @@ func (g *GenericRegion) decodeTemplate1(line, width, paddedWidth int, byteIndex,
 				g.cx.SetIndex(int32(context))
 			}
 
-			bit, err = g.arithDecoder.DecodeBit(g.cx)
+			bit, err = g.decodePixel(x+minorX, line)
 			if err != nil {
 				return errors.Wrap(err, processName, "")
 			}
@@ -768,7 +803,7 @@ func (g *GenericRegion) decodeTemplate2(lineNumber, width, paddedWidth int, byte
 				g.cx.SetIndex(int32(context))
 			}
 
-			bit, err = g.arithDecoder.DecodeBit(g.cx)
+			bit, err = g.decodePixel(x+minorX, lineNumber)
 			if err != nil {
 				return errors.Wrap(err, processName, "")
 			}
@@ -840,7 +875,7 @@ func (g *GenericRegion) decodeTemplate3(line, width, paddedWidth int, byteIndex,
 				g.cx.SetIndex(int32(context))
 			}
 
-			bit, err = g.arithDecoder.DecodeBit(g.cx)
+			bit, err = g.decodePixel(x+minorX, line)
 			if err != nil {
 				return errors.Wrap(err, processName, "")
 			}
@@ -1202,7 +1237,7 @@ func (g *GenericRegion) setParametersMMR(
 	dataOffset, dataLength int64,
 	gbh, gbw uint32,
 	gbTemplate byte,
-	isTPGDon, useSkip bool,
+	isTPGDon bool, skip *bitmap.Bitmap,
 	gbAtX, gbAtY []int8,
 ) {
 	g.DataOffset = dataOffset
@@ -1213,9 +1248,35 @@ func (g *GenericRegion) setParametersMMR(
 	g.GBTemplate = gbTemplate
 	g.IsMMREncoded = isMMREncoded
 	g.IsTPGDon = isTPGDon
+	g.skip = skip
 	g.GBAtX = gbAtX
 	g.GBAtY = gbAtY
+}
 
+// encodeMMR encodes the region bitmap using the MMR coding, see 6.2.6.
+func (g *GenericRegion) encodeMMR(w writer.BinaryWriter) (n int, err error) {
+	const processName = "encodeMMR"
+	pixels := make([][]byte, g.Bitmap.Height)
+	for y := range pixels {
+		row := make([]byte, g.Bitmap.Width)
+		for x := range row {
+			// the ccitt encoder uses value 1 for the white pixels.
+			if !g.Bitmap.GetPixel(x, y) {
+				row[x] = 1
+			}
+		}
+		pixels[y] = row
+	}
+	encoder := &ccittfax.Encoder{
+		K:          -1,
+		Columns:    g.Bitmap.Width,
+		Rows:       g.Bitmap.Height,
+		EndOfBlock: true,
+	}
+	if n, err = w.Write(encoder.Encode(pixels)); err != nil {
+		return n, errors.Wrap(err, processName, "")
+	}
+	return n, nil
 }
 
 func (g *GenericRegion) writeGBAtPixels(w writer.BinaryWriter) (n int, err error) {
@@ -6,8 +6,10 @@
 package segments
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/bits"
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
 
@@ -82,17 +84,28 @@ func (h *HalftoneRegion) GetRegionBitmap() (*bitmap.Bitmap, error) {
 		}
 	}
 
+	if len(h.Patterns) == 0 {
+		return nil, errors.New("halftone region doesn't refer to any pattern")
+	}
+
 	if h.HDefaultPixel == 1 {
 		h.HalftoneRegionBitmap.SetDefaultPixel()
 	}
 
+	// 2)
+	var skip *bitmap.Bitmap
+	if h.HSkipEnabled {
+		if skip, err = h.computeSkip(); err != nil {
+			return nil, err
+		}
+	}
+
 	// 3)
-	bitsPerValueF := math.Ceil(math.Log(float64(len(h.Patterns))) / math.Log(2))
-	bitsPerValue := int(bitsPerValueF)
+	bitsPerValue := bits.Len(uint(len(h.Patterns) - 1))
 
 	// 4)
 	var grayScaleValues [][]int
-	grayScaleValues, err = h.grayScaleDecoding(bitsPerValue)
+	grayScaleValues, err = h.grayScaleDecoding(bitsPerValue, skip)
 	if err != nil {
 		return nil, err
 	}
@@ -186,6 +199,9 @@ func (h *HalftoneRegion) computeGrayScalePlanes(grayScalePlanes []*bitmap.Bitmap
 	for i := 0; i < len(grayScaleValues); i++ {
 		grayScaleValues[i] = make([]int, h.HGridWidth)
 	}
+	if bitsPerValue == 0 {
+		return grayScaleValues, nil
+	}
 
 	for y := 0; y < int(h.HGridHeight); y++ {
 		for x := 0; x < int(h.HGridWidth); x += 8 {
@@ -226,15 +242,72 @@ func (h *HalftoneRegion) computeSegmentDataStructure() error {
 	return nil
 }
 
+// computeSkip computes the bitmap of the grid cells whose patterns lie entirely outside of
+// the halftone region, as described in 6.6.5.1.
+func (h *HalftoneRegion) computeSkip() (*bitmap.Bitmap, error) {
+	patternWidth, patternHeight := h.Patterns[0].Width, h.Patterns[0].Height
+	regionWidth, regionHeight := int(h.RegionSegment.BitmapWidth), int(h.RegionSegment.BitmapHeight)
+
+	skip := bitmap.New(int(h.HGridWidth), int(h.HGridHeight))
+	for m := 0; m < int(h.HGridHeight); m++ {
+		for n := 0; n < int(h.HGridWidth); n++ {
+			x, y := h.computeX(m, n), h.computeY(m, n)
+			if x+patternWidth <= 0 || x >= regionWidth || y+patternHeight <= 0 || y >= regionHeight {
+				if err := skip.SetPixel(n, m, 1); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+	return skip, nil
+}
+
+// computeX computes the horizontal location of the pattern in the grid cell 'm', 'n'.
+// The grid offset and vector are the fixed point values with 8 fractional bits.
 func (h *HalftoneRegion) computeX(m, n int) int {
-	return h.shiftAndFill(int(h.HGridX) + m*int(h.HRegionY) + n*int(h.HRegionX))
+	return (int(h.HGridX) + m*int(h.HRegionY) + n*int(h.HRegionX)) >> 8
 }
 
+// computeY computes the vertical location of the pattern in the grid cell 'm', 'n'.
 func (h *HalftoneRegion) computeY(m, n int) int {
-	return h.shiftAndFill(int(h.HGridY) + m*int(h.HRegionX) - n*int(h.HRegionY))
+	return (int(h.HGridY) + m*int(h.HRegionX) - n*int(h.HRegionY)) >> 8
+}
+
+// drawPattern draws the 'pattern' into the region bitmap at the location 'x', 'y', using
+// the halftone combination operator. The parts of the pattern outside of the region are clipped.
+func (h *HalftoneRegion) drawPattern(pattern *bitmap.Bitmap, x, y int) error {
+	region := h.HalftoneRegionBitmap
+	for py := 0; py < pattern.Height; py++ {
+		ry := y + py
+		if ry < 0 || ry >= region.Height {
+			continue
+		}
+		for px := 0; px < pattern.Width; px++ {
+			rx := x + px
+			if rx < 0 || rx >= region.Width {
+				continue
+			}
+			var oldBit, newBit byte
+			if region.GetPixel(rx, ry) {
+				oldBit = 1
+			}
+			if pattern.GetPixel(px, py) {
+				newBit = 1
+			}
+			if err := region.SetPixel(rx, ry, bitmap.CombineBytes(oldBit, newBit, h.CombinationOperator)&1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
-func (h *HalftoneRegion) grayScaleDecoding(bitsPerValue int) ([][]int, error) {
+func (h *HalftoneRegion) grayScaleDecoding(bitsPerValue int, skip *bitmap.Bitmap) ([][]int, error) {
+	if bitsPerValue == 0 {
+		// a single pattern is used for all the grid cells.
+		return h.computeGrayScalePlanes(nil, 0)
+	}
+
 	var (
 		gbAtX []int8
 		gbAtY []int8
@@ -262,7 +335,7 @@ func (h *HalftoneRegion) grayScaleDecoding(bitsPerValue int) ([][]int, error) {
 
 	// 1)
 	genericRegion := NewGenericRegion(h.r)
-	genericRegion.setParametersMMR(h.IsMMREncoded, h.DataOffset, h.DataLength, h.HGridHeight, h.HGridWidth, h.HTemplate, false, h.HSkipEnabled, gbAtX, gbAtY)
+	genericRegion.setParametersMMR(h.IsMMREncoded, h.DataOffset, h.DataLength, h.HGridHeight, h.HGridWidth, h.HTemplate, false, skip, gbAtX, gbAtY)
 
 	// 2)
 	j := bitsPerValue - 1
@@ -349,13 +422,13 @@ func (h *HalftoneRegion) parseHeader() error {
 	if err != nil {
 		return err
 	}
-	h.HGridX = int32(temp & math.MaxInt32)
+	h.HGridX = int32(uint32(temp))
 
 	temp, err = h.r.ReadBits(32)
 	if err != nil {
 		return err
 	}
-	h.HGridY = int32(temp & math.MaxInt32)
+	h.HGridY = int32(uint32(temp))
 
 	temp, err = h.r.ReadBits(16)
 	if err != nil {
@@ -376,46 +449,18 @@ func (h *HalftoneRegion) parseHeader() error {
 }
 
 // renderPattern draws the pattern into the region bitmap, as described in 6.6.5.2.
-func (h *HalftoneRegion) renderPattern(grayScaleValues [][]int) (err error) {
-	var x, y int
-
+func (h *HalftoneRegion) renderPattern(grayScaleValues [][]int) error {
 	for m := 0; m < int(h.HGridHeight); m++ {
 		for n := 0; n < int(h.HGridWidth); n++ {
-			x = h.computeX(m, n)
-			y = h.computeY(m, n)
-			patternBitmap := h.Patterns[grayScaleValues[m][n]]
-
-			if err = bitmap.Blit(
-				patternBitmap, h.HalftoneRegionBitmap,
-				x+int(h.HGridX), y+int(h.HGridY), h.CombinationOperator,
-			); err != nil {
+			value := grayScaleValues[m][n]
+			if value >= len(h.Patterns) {
+				return fmt.Errorf("gray-scale value: %d out of the patterns range: %d", value, len(h.Patterns))
+			}
+
+			if err := h.drawPattern(h.Patterns[value], h.computeX(m, n), h.computeY(m, n)); err != nil {
 				return err
 			}
 		}
 	}
 	return nil
 }
-
-func findMSB(n int) int {
-	if n == 0 {
-		return 0
-	}
-	n |= n >> 1
-	n |= n >> 2
-	n |= n >> 4
-	n |= n >> 8
-	n |= n >> 16
-	return (n + 1) >> 1
-}
-
-func (h *HalftoneRegion) shiftAndFill(value int) int {
-	value >>= 8
-	if value < 0 {
-		bitPosition := int(math.Log(float64(findMSB(value))) / math.Log(2))
-		l := 31 - bitPosition
-		for i := 1; i < l; i++ {
-			value |= 1 << uint(31-i)
-		}
-	}
-	return value
-}
@@ -0,0 +1,43 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package segments
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/bitmap"
+)
+
+// TestHalftoneRegionSkip tests the computation of the halftone grid cells skipped by the gray-scale
+// image decoding - 6.6.5.1.
+func TestHalftoneRegionSkip(t *testing.T) {
+	h := &HalftoneRegion{
+		RegionSegment: &RegionSegment{BitmapWidth: 10, BitmapHeight: 8},
+		HGridWidth:    5,
+		HGridHeight:   4,
+		// the grid starts at (-6, -4) and has the cells of size 4x4.
+		HGridX:   -6 << 8,
+		HGridY:   -4 << 8,
+		HRegionX: 4 << 8,
+		Patterns: []*bitmap.Bitmap{bitmap.New(4, 4), bitmap.New(4, 4)},
+	}
+
+	skip, err := h.computeSkip()
+	require.NoError(t, err)
+	require.Equal(t, 5, skip.Width)
+	require.Equal(t, 4, skip.Height)
+
+	for m := 0; m < 4; m++ {
+		for n := 0; n < 5; n++ {
+			// cells located at x: -6, -2, 2, 6, 10 and y: -4, 0, 4, 8.
+			expected := n == 0 || n == 4 || m == 0 || m == 3
+			assert.Equal(t, expected, skip.GetPixel(n, m), "m: %d, n: %d", m, n)
+		}
+	}
+}
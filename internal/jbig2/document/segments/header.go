@@ -266,11 +266,15 @@ func (h *Header) parse(
 		return errors.Wrap(err, processName, "")
 	}
 
-	if h.Type != TEndOfFile {
-		// 7.2.7 Segment data length (Contains the length of the data).
-		if err = h.readSegmentDataLength(r); err != nil {
+	// 7.2.7 Segment data length (Contains the length of the data).
+	// The field is present in all the segment headers, including the end of file segment header,
+	// which is required to compute the data offsets of the segments in the random-access organization.
+	// Some producers omit the field of the end of file segment header at the end of the stream.
+	if err = h.readSegmentDataLength(r); err != nil {
+		if h.Type != TEndOfFile || err != io.EOF {
 			return errors.Wrap(err, processName, "")
 		}
+		h.SegmentDataLength = 0
 	}
 	h.readDataStartOffset(r, organizationType)
 	h.readHeaderLength(r, offset)
@@ -114,6 +114,12 @@ func (p *PageInformationSegment) CombinationOperatorOverrideAllowed() bool {
 	return p.combinaitonOperatorOverrideAllowed
 }
 
+// SetCombinationOperatorOverrideAllowed sets the flag that allows the page regions
+// to override the page default combination operator.
+func (p *PageInformationSegment) SetCombinationOperatorOverrideAllowed(allowed bool) {
+	p.combinaitonOperatorOverrideAllowed = allowed
+}
+
 // DefaultPixelValue returns page segment default pixel.
 func (p *PageInformationSegment) DefaultPixelValue() uint8 {
 	return p.defaultPixelValue
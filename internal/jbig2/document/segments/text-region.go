@@ -995,6 +995,7 @@ func (t *TextRegion) encodeSymbols(w writer.BinaryWriter) (n int, err error) {
 		if err = encodeCtx.EncodeInteger(encoder.IADT, deltaT); err != nil {
 			return n, errors.Wrap(err, processName, "")
 		}
+		stripeT = stripeY
 
 		// deltaS is the difference in the 'x' value between the symbols.
 		var currentS int
@@ -1037,6 +1038,12 @@ func (t *TextRegion) encodeSymbols(w writer.BinaryWriter) (n int, err error) {
 			if err = encodeCtx.EncodeIAID(t.symBits, symbolID); err != nil {
 				return n, errors.Wrap(err, processName, "")
 			}
+			// the next symbol 's' coordinate is relative to the right edge of this symbol.
+			symbolBitmap, err := t.symbols.GetBitmap(assigned)
+			if err != nil {
+				return n, errors.Wrap(err, processName, "")
+			}
+			currentS += symbolBitmap.Width - 1
 		}
 
 		// terminate the strip with the OOB
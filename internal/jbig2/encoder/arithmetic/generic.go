@@ -0,0 +1,131 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package arithmetic
+
+import (
+	"bytes"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/bitmap"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/errors"
+)
+
+// tpgdContexts are the contexts used for encoding the SLTP bit of the generic region templates, see 6.2.5.7.
+var tpgdContexts = [4]uint32{0x9b25, 0x0795, 0x00e5, 0x0195}
+
+// genericTemplates are the pixel offsets of the generic region templates, see 6.2.5.3.
+// The pixels are ordered by their context bit position, starting from the least significant one.
+// The adaptive template pixels are marked with the 'atPixel' offset, in the order of their occurrence.
+var genericTemplates = [4][][2]int{
+	{
+		{-1, 0}, {-2, 0}, {-3, 0}, {-4, 0}, atPixel,
+		{2, -1}, {1, -1}, {0, -1}, {-1, -1}, {-2, -1}, atPixel, atPixel,
+		{1, -2}, {0, -2}, {-1, -2}, atPixel,
+	},
+	{
+		{-1, 0}, {-2, 0}, {-3, 0}, atPixel,
+		{2, -1}, {1, -1}, {0, -1}, {-1, -1}, {-2, -1},
+		{2, -2}, {1, -2}, {0, -2}, {-1, -2},
+	},
+	{
+		{-1, 0}, {-2, 0}, atPixel,
+		{1, -1}, {0, -1}, {-1, -1}, {-2, -1},
+		{1, -2}, {0, -2}, {-1, -2},
+	},
+	{
+		{-1, 0}, {-2, 0}, {-3, 0}, {-4, 0}, atPixel,
+		{1, -1}, {0, -1}, {-1, -1}, {-2, -1}, {-3, -1},
+	},
+}
+
+// atPixel marks the adaptive template pixel positions in the 'genericTemplates'.
+var atPixel = [2]int{0, 0}
+
+// EncodeGenericBitmap encodes the bitmap 'bm' using the generic region 'template' (0-3) with the adaptive
+// template pixels located at 'atX', 'atY' offsets. The template 0 requires four adaptive template pixels,
+// the other templates require one. If the 'duplicateLineRemoval' is true, the rows equal to the previous
+// ones are not encoded (TPGDON).
+func (e *Encoder) EncodeGenericBitmap(bm *bitmap.Bitmap, template int, atX, atY []int8, duplicateLineRemoval bool) error {
+	const processName = "Encoder.EncodeGenericBitmap"
+	if template < 0 || template > 3 {
+		return errors.Errorf(processName, "provided template: '%d' not in valid range {0,1,2,3}", template)
+	}
+	atNumber := 1
+	if template == 0 {
+		atNumber = 4
+	}
+	if len(atX) != atNumber || len(atY) != atNumber {
+		return errors.Errorf(processName, "template: '%d' requires %d adaptive template pixels", template, atNumber)
+	}
+	if template == 0 && isNominalTemplate0(atX, atY) {
+		// use the optimized template 0 encoding.
+		return e.EncodeBitmap(bm, duplicateLineRemoval)
+	}
+	common.Log.Trace("Encode Generic Bitmap [%dx%d], template: %d", bm.Width, bm.Height, template)
+
+	// resolve the adaptive template pixels positions.
+	offsets := make([][2]int, len(genericTemplates[template]))
+	var atIndex int
+	for i, offset := range genericTemplates[template] {
+		if offset == atPixel {
+			offset = [2]int{int(atX[atIndex]), int(atY[atIndex])}
+			atIndex++
+		}
+		offsets[i] = offset
+	}
+
+	pixel := func(x, y int) uint32 {
+		if x < 0 || x >= bm.Width || y < 0 {
+			return 0
+		}
+		return uint32(bm.Data[y*bm.RowStride+x>>3]>>uint(7-x&7)) & 1
+	}
+
+	var ltp uint8
+	for y := 0; y < bm.Height; y++ {
+		if duplicateLineRemoval {
+			sltp := ltp
+			if y > 0 {
+				thisLine := bm.Data[y*bm.RowStride : (y+1)*bm.RowStride]
+				lastLine := bm.Data[(y-1)*bm.RowStride : y*bm.RowStride]
+				if bytes.Equal(thisLine, lastLine) {
+					sltp, ltp = ltp^1, 1
+				} else {
+					ltp = 0
+				}
+			}
+			if err := e.encodeBit(e.context, tpgdContexts[template], sltp); err != nil {
+				return errors.Wrap(err, processName, "")
+			}
+			if ltp != 0 {
+				continue
+			}
+		}
+
+		for x := 0; x < bm.Width; x++ {
+			var ctx uint32
+			for i, offset := range offsets {
+				ctx |= pixel(x+offset[0], y+offset[1]) << uint(i)
+			}
+			if err := e.encodeBit(e.context, ctx, uint8(pixel(x, y))); err != nil {
+				return errors.Wrap(err, processName, "")
+			}
+		}
+	}
+	return nil
+}
+
+// isNominalTemplate0 checks if the adaptive template pixels 'atX', 'atY' are located at the nominal template 0 positions.
+func isNominalTemplate0(atX, atY []int8) bool {
+	nominalX, nominalY := [4]int8{3, -3, 2, -2}, [4]int8{-1, -1, -2, -2}
+	for i := range nominalX {
+		if atX[i] != nominalX[i] || atY[i] != nominalY[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -146,8 +146,8 @@ func (c *Classer) ComputeLLCorners() (err error) {
 			return errors.Wrap(err, processName, "Undilated Templates")
 		}
 		h = bm.Height
-		// Add the global LL corner point.
-		c.PtaLL.AddPoint(x1, y1+float32(h)) // )-1-2*float32(JbAddedPixels)
+		// Add the global LL corner point - the bottom row of the template.
+		c.PtaLL.AddPoint(x1, y1+float32(h)-1)
 	}
 	return nil
 }
@@ -46,7 +46,8 @@ func finalAlignmentPositioning(s *bitmap.Bitmap, x, y, iDelX, iDelY int, t *bitm
 	}
 
 	w, h := t.Width, t.Height
-	bx, by := x-iDelX-JbAddedPixels, y-iDelY-JbAddedPixels
+	// the templates are stored without the added border pixels.
+	bx, by := x-iDelX, y-iDelY
 
 	common.Log.Trace("x: '%d', y: '%d', w: '%d', h: '%d', bx: '%d', by: '%d'", x, y, w, h, bx, by)
 	box, err := bitmap.Rect(bx, by, w, h)
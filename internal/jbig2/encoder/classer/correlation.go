@@ -98,7 +98,6 @@ func (c *Classer) classifyCorrelation(boxa *bitmap.Boxes, pixas *bitmap.Bitmaps,
 		area, area1, area2 int
 		threshold          float64
 		x1, y1, x2, y2     float32
-		ct1, ct2           bitmap.Point
 		found              bool
 		findContext        *similarTemplatesFinder
 		i                  int
@@ -115,8 +114,8 @@ func (c *Classer) classifyCorrelation(boxa *bitmap.Boxes, pixas *bitmap.Bitmaps,
 
 		found = false
 		nt := len(c.UndilatedTemplates.Values)
-		findContext = initSimilarTemplatesFinder(c, bm1)
-		for iclass := findContext.Next(); iclass > -1; {
+		findContext = initSimilarTemplatesFinder(c, pixas.Values[i])
+		for iclass := findContext.Next(); iclass > -1; iclass = findContext.Next() {
 			// get the template
 			if bm2, err = c.UndilatedTemplates.GetBitmap(iclass); err != nil {
 				return errors.Wrap(err, processName, "unidlated[iclass] = bm2")
@@ -138,7 +137,9 @@ func (c *Classer) classifyCorrelation(boxa *bitmap.Boxes, pixas *bitmap.Bitmaps,
 				threshold = c.Settings.Thresh
 			}
 
-			overThreshold, err := bitmap.CorrelationScoreThresholded(bm1, bm2, area1, area2, ct1.X-ct2.X, ct1.Y-ct2.Y, MaxDiffWidth, MaxDiffHeight, sumtab, pixRowCts[i], float32(threshold))
+			// the templates are stored without the border - compare them with the unbordered component.
+			// The centroid differences and the rows below the border are the same for both bitmaps.
+			overThreshold, err := bitmap.CorrelationScoreThresholded(pixas.Values[i], bm2, area1, area2, x1-x2, y1-y2, MaxDiffWidth, MaxDiffHeight, sumtab, pixRowCts[i][JbAddedPixels:], float32(threshold))
 			if err != nil {
 				return errors.Wrap(err, processName, "")
 			}
@@ -149,12 +150,12 @@ func (c *Classer) classifyCorrelation(boxa *bitmap.Boxes, pixas *bitmap.Bitmaps,
 					score, testScore float64
 					count, testCount int
 				)
-				score, err = bitmap.CorrelationScore(bm1, bm2, area1, area2, x1-x2, y1-y2, MaxDiffWidth, MaxDiffHeight, sumtab)
+				score, err = bitmap.CorrelationScore(pixas.Values[i], bm2, area1, area2, x1-x2, y1-y2, MaxDiffWidth, MaxDiffHeight, sumtab)
 				if err != nil {
 					return errors.Wrap(err, processName, "debugCorrelationScore")
 				}
 
-				testScore, err = bitmap.CorrelationScoreSimple(bm1, bm2, area1, area2, x1-x2, y1-y2, MaxDiffWidth, MaxDiffHeight, sumtab)
+				testScore, err = bitmap.CorrelationScoreSimple(pixas.Values[i], bm2, area1, area2, x1-x2, y1-y2, MaxDiffWidth, MaxDiffHeight, sumtab)
 				if err != nil {
 					return errors.Wrap(err, processName, "debugCorrelationScore")
 				}
@@ -92,8 +92,8 @@ func (c *Classer) classifyRankHouseOne(boxa *bitmap.Boxes, pixa, bms1, bms2 *bit
 		nt := len(c.UndilatedTemplates.Values)
 
 		found = false
-		findContext := initSimilarTemplatesFinder(c, bm1)
-		for iClass = findContext.Next(); iClass > -1; {
+		findContext := initSimilarTemplatesFinder(c, pixa.Values[i])
+		for iClass = findContext.Next(); iClass > -1; iClass = findContext.Next() {
 			bm3, err = c.UndilatedTemplates.GetBitmap(iClass)
 			if err != nil {
 				return errors.Wrap(err, processName, "bm3")
@@ -201,8 +201,8 @@ func (c *Classer) classifyRankHouseNonOne(boxa *bitmap.Boxes, pixa, bms1, bms2 *
 
 		nt := len(c.UndilatedTemplates.Values)
 		found = false
-		findContext := initSimilarTemplatesFinder(c, bm1)
-		for iClass = findContext.Next(); iClass > -1; {
+		findContext := initSimilarTemplatesFinder(c, pixa.Values[i])
+		for iClass = findContext.Next(); iClass > -1; iClass = findContext.Next() {
 			if bm3, err = c.UndilatedTemplates.GetBitmap(iClass); err != nil {
 				return errors.Wrap(err, processName, "pixat.[iClass]")
 			}
@@ -26,7 +26,8 @@ type similarTemplatesFinder struct {
 	N int
 }
 
-// initSimilarTemplatesFinder initializes the templatesState context.
+// initSimilarTemplatesFinder initializes the templatesState context for the
+// component bitmap 'bms' without the added border pixels.
 func initSimilarTemplatesFinder(c *Classer, bms *bitmap.Bitmap) *similarTemplatesFinder {
 	return &similarTemplatesFinder{
 		Width:   bms.Width,
@@ -41,6 +42,7 @@ func (f *similarTemplatesFinder) Next() int {
 		desireDH, desireDW, size, templ int
 		ok                              bool
 		bmT                             *bitmap.Bitmap
+		instances                       *bitmap.Bitmaps
 		err                             error
 	)
 
@@ -66,12 +68,17 @@ func (f *similarTemplatesFinder) Next() int {
 		size = len(f.CurrentNumbers)
 		for ; f.N < size; f.N++ {
 			templ = f.CurrentNumbers[f.N]
-			bmT, err = f.Classer.DilatedTemplates.GetBitmap(templ)
-			if err != nil {
-				common.Log.Debug("FindNextTemplate: template not found: ")
-				return 0
+			// the first instance of each class is the template source bitmap without the border.
+			if instances, err = f.Classer.ClassInstances.GetBitmaps(templ); err != nil {
+				common.Log.Debug("FindNextTemplate: template not found: %v", err)
+				return -1
 			}
-			if bmT.Width-2*JbAddedPixels == desireDW && bmT.Height-2*JbAddedPixels == desireDH {
+			if bmT, err = instances.GetBitmap(0); err != nil {
+				common.Log.Debug("FindNextTemplate: template not found: %v", err)
+				return -1
+			}
+			if bmT.Width == desireDW && bmT.Height == desireDH {
+				f.N++
 				return templ
 			}
 		}
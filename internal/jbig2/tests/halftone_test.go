@@ -0,0 +1,259 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/ccittfax"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/bitmap"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/document"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/encoder/arithmetic"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/reader"
+)
+
+// halftoneSample is the sample jbig2 document with a single page, composed of the halftone region
+// which uses the patterns of the pattern dictionary segment.
+type halftoneSample struct {
+	pageWidth, pageHeight int
+
+	// pattern dictionary.
+	patternSize int
+	grayMax     int
+
+	// halftone region.
+	regionX, regionY          int
+	regionWidth, regionHeight int
+	gridWidth, gridHeight     int
+	// grid offset and vector are the fixed point values with 8 fractional bits.
+	gridX, gridY        int32
+	vectorX, vectorY    uint16
+	isMMR               bool
+	grayScaleValue      func(m, n int) int
+	combinationOperator bitmap.CombinationOperator
+}
+
+// pattern gets the 'gray' pattern, which has the 'gray' pixels set in the dispersed dot order.
+func (s *halftoneSample) pattern(gray int) *bitmap.Bitmap {
+	order := [4][4]int{{0, 8, 2, 10}, {12, 4, 14, 6}, {3, 11, 1, 9}, {15, 7, 13, 5}}
+	bm := bitmap.New(s.patternSize, s.patternSize)
+	for y := 0; y < bm.Height; y++ {
+		for x := 0; x < bm.Width; x++ {
+			if order[y%4][x%4]*s.grayMax < gray*16 {
+				bm.SetPixel(x, y, 1)
+			}
+		}
+	}
+	return bm
+}
+
+// expected renders the page of the sample, as described in 6.6.5.2.
+func (s *halftoneSample) expected() *bitmap.Bitmap {
+	region := bitmap.New(s.regionWidth, s.regionHeight)
+	for m := 0; m < s.gridHeight; m++ {
+		for n := 0; n < s.gridWidth; n++ {
+			x := (int(s.gridX) + m*int(s.vectorY) + n*int(s.vectorX)) >> 8
+			y := (int(s.gridY) + m*int(s.vectorX) - n*int(s.vectorY)) >> 8
+			p := s.pattern(s.grayScaleValue(m, n))
+			for py := 0; py < p.Height; py++ {
+				for px := 0; px < p.Width; px++ {
+					if x+px < 0 || x+px >= region.Width || y+py < 0 || y+py >= region.Height {
+						continue
+					}
+					var oldBit, newBit byte
+					if region.GetPixel(x+px, y+py) {
+						oldBit = 1
+					}
+					if p.GetPixel(px, py) {
+						newBit = 1
+					}
+					region.SetPixel(x+px, y+py, bitmap.CombineBytes(oldBit, newBit, s.combinationOperator)&1)
+				}
+			}
+		}
+	}
+
+	page := bitmap.New(s.pageWidth, s.pageHeight)
+	for y := 0; y < region.Height; y++ {
+		for x := 0; x < region.Width; x++ {
+			if region.GetPixel(x, y) {
+				page.SetPixel(s.regionX+x, s.regionY+y, 1)
+			}
+		}
+	}
+	return page
+}
+
+// encode encodes the sample into the jbig2 document with the sequential organization.
+func (s *halftoneSample) encode(t *testing.T) []byte {
+	buf := &bytes.Buffer{}
+	// file header, sequential organization with one page - 7.4.2.
+	buf.Write([]byte{0x97, 0x4A, 0x42, 0x32, 0x0D, 0x0A, 0x1A, 0x0A, 0x01})
+	write(buf, uint32(1))
+
+	// writeSegment writes the segment with the short header formats - 7.2.
+	segmentNumber := uint32(0)
+	writeSegment := func(segmentType byte, data []byte, referredTo ...byte) {
+		write(buf, segmentNumber)
+		buf.WriteByte(segmentType)
+		buf.WriteByte(byte(len(referredTo)) << 5)
+		buf.Write(referredTo)
+		buf.WriteByte(1)
+		write(buf, uint32(len(data)))
+		buf.Write(data)
+		segmentNumber++
+	}
+
+	// page information segment - 7.4.8.
+	data := &bytes.Buffer{}
+	write(data, uint32(s.pageWidth), uint32(s.pageHeight), uint32(0), uint32(0), byte(0), uint16(0))
+	writeSegment(48, data.Bytes())
+
+	// pattern dictionary segment with the MMR encoded collective bitmap - 7.4.4.
+	collective := bitmap.New((s.grayMax+1)*s.patternSize, s.patternSize)
+	for gray := 0; gray <= s.grayMax; gray++ {
+		p := s.pattern(gray)
+		for y := 0; y < p.Height; y++ {
+			for x := 0; x < p.Width; x++ {
+				if p.GetPixel(x, y) {
+					collective.SetPixel(gray*s.patternSize+x, y, 1)
+				}
+			}
+		}
+	}
+	data.Reset()
+	write(data, byte(1), byte(s.patternSize), byte(s.patternSize), uint32(s.grayMax))
+	data.Write(encodeMMR(collective))
+	writeSegment(16, data.Bytes())
+
+	// immediate halftone region segment - 7.4.5.
+	data.Reset()
+	write(data, uint32(s.regionWidth), uint32(s.regionHeight), uint32(s.regionX), uint32(s.regionY), byte(0))
+	flags := byte(s.combinationOperator) << 4
+	if s.isMMR {
+		flags |= 1
+	}
+	write(data, flags, uint32(s.gridWidth), uint32(s.gridHeight), s.gridX, s.gridY, s.vectorX, s.vectorY)
+
+	// the gray-scale image bit planes are gray coded and start with the most significant one - C.5.
+	var bitsPerValue int
+	for 1<<uint(bitsPerValue) < s.grayMax+1 {
+		bitsPerValue++
+	}
+	enc := arithmetic.New()
+	for j := bitsPerValue - 1; j >= 0; j-- {
+		plane := bitmap.New(s.gridWidth, s.gridHeight)
+		for m := 0; m < s.gridHeight; m++ {
+			for n := 0; n < s.gridWidth; n++ {
+				value := s.grayScaleValue(m, n)
+				if ((value^(value>>1))>>uint(j))&1 == 1 {
+					plane.SetPixel(n, m, 1)
+				}
+			}
+		}
+		if s.isMMR {
+			data.Write(encodeMMR(plane))
+			continue
+		}
+		require.NoError(t, enc.EncodeBitmap(plane, false))
+	}
+	if !s.isMMR {
+		enc.Final()
+		_, err := enc.WriteTo(data)
+		require.NoError(t, err)
+	}
+	writeSegment(22, data.Bytes(), 1)
+
+	// end of page and end of file segments.
+	writeSegment(49, nil)
+	writeSegment(51, nil)
+	return buf.Bytes()
+}
+
+func write(buf *bytes.Buffer, values ...interface{}) {
+	for _, v := range values {
+		binary.Write(buf, binary.BigEndian, v)
+	}
+}
+
+func encodeMMR(bm *bitmap.Bitmap) []byte {
+	pixels := make([][]byte, bm.Height)
+	for y := range pixels {
+		pixels[y] = make([]byte, bm.Width)
+		for x := range pixels[y] {
+			// the ccitt encoder uses value 1 for the white pixels.
+			if !bm.GetPixel(x, y) {
+				pixels[y][x] = 1
+			}
+		}
+	}
+	encoder := &ccittfax.Encoder{K: -1, Columns: bm.Width, Rows: bm.Height, EndOfBlock: true}
+	return encoder.Encode(pixels)
+}
+
+// TestDecodeHalftone tests the decoding of the sample documents with the halftone regions, whose grids
+// are placed partially outside of the region and rotated. The decoded pages are compared with the pages
+// rendered from the sample patterns and with the md5 hashes stored in the 'testdata/goldens/halftone_golden.json'.
+// The hashes are updated when the 'jbig2-update-goldens' flag is provided.
+func TestDecodeHalftone(t *testing.T) {
+	samples := map[string]*halftoneSample{
+		"MMR": {
+			pageWidth: 40, pageHeight: 30,
+			patternSize: 4, grayMax: 4,
+			regionX: 3, regionY: 2, regionWidth: 30, regionHeight: 22,
+			gridWidth: 9, gridHeight: 8,
+			gridX: -384, gridY: -320, vectorX: 0x380, vectorY: 0x100,
+			isMMR:          true,
+			grayScaleValue: func(m, n int) int { return (m + 2*n) % 5 },
+		},
+		"Generic": {
+			pageWidth: 45, pageHeight: 37,
+			patternSize: 6, grayMax: 15,
+			regionX: 0, regionY: 5, regionWidth: 41, regionHeight: 29,
+			gridWidth: 12, gridHeight: 10,
+			gridX: -200, gridY: 100, vectorX: 0x400, vectorY: 0x80,
+			grayScaleValue:      func(m, n int) int { return (3*m + n) % 16 },
+			combinationOperator: bitmap.CmbOpXor,
+		},
+		"SinglePattern": {
+			pageWidth: 20, pageHeight: 20,
+			patternSize: 4, grayMax: 0,
+			regionX: 2, regionY: 2, regionWidth: 16, regionHeight: 16,
+			gridWidth: 4, gridHeight: 4,
+			vectorX:        0x400,
+			isMMR:          true,
+			grayScaleValue: func(m, n int) int { return 0 },
+		},
+	}
+
+	var results []goldenValuePair
+	for _, name := range []string{"MMR", "Generic", "SinglePattern"} {
+		sample := samples[name]
+		t.Run(name, func(t *testing.T) {
+			d, err := document.DecodeDocument(reader.New(sample.encode(t)), nil)
+			require.NoError(t, err)
+
+			page, err := d.GetPage(1)
+			require.NoError(t, err)
+
+			bm, err := page.GetBitmap()
+			require.NoError(t, err)
+
+			expected := sample.expected()
+			assert.True(t, expected.Equals(bm), "expected:\n%s\ndecoded:\n%s", expected, bm)
+
+			hash := md5.Sum(bm.Data)
+			results = append(results, goldenValuePair{Filename: "halftone_" + name, Hash: hash[:]})
+		})
+	}
+	checkGoldenValuePairs(t, "testdata", "halftone", results...)
+}
@@ -0,0 +1,154 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package tests
+
+import (
+	"bytes"
+	"crypto/md5"
+	"image"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/bitmap"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/decoder"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/document"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/document/segments"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/encoder/arithmetic"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/reader"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/writer"
+)
+
+// TestDecodeRefinementRegion decodes the jbig2 streams containing an immediate generic refinement region,
+// which refines the page region, and compares the result with the expected page bitmap.
+// If the environment variable 'UNIDOC_JBIG2_TESTDATA' is provided, the md5 hashes of the decoded
+// pages are checked against the 'refinement' golden file. Use the 'jbig2-update-goldens' flag
+// in order to update the golden file hashes.
+func TestDecodeRefinementRegion(t *testing.T) {
+	page := bitmap.New(64, 40)
+	for y := 0; y < page.Height; y++ {
+		for x := 0; x < page.Width; x++ {
+			if (x/4+y/4)%2 == 0 || x == y {
+				require.NoError(t, page.SetPixel(x, y, 1))
+			}
+		}
+	}
+
+	testCases := []struct {
+		name string
+		roi  image.Rectangle
+	}{
+		{name: "FullPage", roi: image.Rect(0, 0, 64, 40)},
+		{name: "PageRegion", roi: image.Rect(11, 5, 48, 30)},
+	}
+
+	var gvp []goldenValuePair
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reference, err := bitmap.Extract(tc.roi, page)
+			require.NoError(t, err)
+
+			// the refined region differs from the page region by the crossing line.
+			target := reference.Copy()
+			for x := 0; x < target.Width; x++ {
+				require.NoError(t, target.SetPixel(x, target.Height/2, 1))
+			}
+
+			expected := page.Copy()
+			require.NoError(t, bitmap.Blit(target, expected, tc.roi.Min.X, tc.roi.Min.Y, bitmap.CmbOpReplace))
+
+			encoded := encodeRefinedPage(t, page, reference, target, tc.roi)
+			decoded, err := jbig2.DecodeBytes(encoded, decoder.Parameters{UnpaddedData: true})
+			require.NoError(t, err)
+
+			// the expected page is decoded from the generic region stream, so that both share
+			// the same color interpretation.
+			d := document.InitEncodeDocument(false)
+			require.NoError(t, d.AddGenericPage(expected, false))
+			encodedExpected, err := d.Encode()
+			require.NoError(t, err)
+
+			decodedExpected, err := jbig2.DecodeBytes(encodedExpected, decoder.Parameters{UnpaddedData: true})
+			require.NoError(t, err)
+			assert.Equal(t, decodedExpected, decoded)
+
+			h := md5.Sum(decoded)
+			gvp = append(gvp, goldenValuePair{Filename: tc.name, Hash: h[:]})
+		})
+	}
+
+	dirName := os.Getenv(EnvJBIG2Directory)
+	if dirName == "" {
+		return
+	}
+	checkGoldenValuePairs(t, dirName, "refinement", gvp...)
+}
+
+// refinementSegment is the encoded generic refinement region segment data.
+type refinementSegment []byte
+
+// Init implements segments.Segmenter interface.
+func (r refinementSegment) Init(*segments.Header, reader.StreamReader) error {
+	return nil
+}
+
+// Encode implements segments.SegmentEncoder interface.
+func (r refinementSegment) Encode(w writer.BinaryWriter) (int, error) {
+	return w.Write(r)
+}
+
+// encodeRefinedPage encodes the jbig2 stream with the 'page' bitmap as a generic region, followed by
+// the immediate lossless generic refinement region with no referred segments, that refines
+// the 'reference' page region at 'roi' into the 'target' bitmap.
+func encodeRefinedPage(t *testing.T, page, reference, target *bitmap.Bitmap, roi image.Rectangle) []byte {
+	t.Helper()
+	d := document.InitEncodeDocument(false)
+	require.NoError(t, d.AddGenericPage(page, false))
+
+	p := d.Pages[1]
+	pageInfo, ok := p.Segments[0].SegmentData.(*segments.PageInformationSegment)
+	require.True(t, ok)
+	// the refinement region replaces the page region.
+	pageInfo.SetCombinationOperatorOverrideAllowed(true)
+
+	w := writer.BufferedMSB()
+	region := &segments.RegionSegment{
+		BitmapWidth:        uint32(roi.Dx()),
+		BitmapHeight:       uint32(roi.Dy()),
+		XLocation:          uint32(roi.Min.X),
+		YLocation:          uint32(roi.Min.Y),
+		CombinaionOperator: bitmap.CmbOpReplace,
+	}
+	_, err := region.Encode(w)
+	require.NoError(t, err)
+
+	// flags: TPGRON - 0, GRTEMPLATE - 0, followed by the nominal AT pixels.
+	_, err = w.Write([]byte{0x00, 0xff, 0xff, 0xff, 0xff})
+	require.NoError(t, err)
+
+	e := arithmetic.New()
+	require.NoError(t, e.Refine(reference, target, 0, 0))
+	e.Final()
+
+	buf := &bytes.Buffer{}
+	_, err = e.WriteTo(buf)
+	require.NoError(t, err)
+	_, err = w.Write(buf.Bytes())
+	require.NoError(t, err)
+
+	p.Segments = append(p.Segments, &segments.Header{
+		Type:            segments.TImmediateLosslessGenericRefinementRegion,
+		PageAssociation: p.PageNumber,
+		SegmentData:     refinementSegment(w.Data()),
+	})
+
+	encoded, err := d.Encode()
+	require.NoError(t, err)
+	return encoded
+}
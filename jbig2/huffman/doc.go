@@ -0,0 +1,10 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+// Package huffman provides access to the JBIG2 Huffman code tables. It contains the standard
+// tables B.1 - B.15 defined in the Annex B of the JBIG2 specification and allows to build custom
+// code tables from the table line specifications. The tables decode the values from the bit
+// streams read by the Reader.
+package huffman
@@ -0,0 +1,123 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package huffman
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/decoder/huffman"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/jbig2/reader"
+)
+
+// StandardTablesNumber is the number of the standard JBIG2 Huffman tables (B.1 - B.15).
+const StandardTablesNumber = 15
+
+// OOB is the value decoded for the out-of-band code of a table.
+const OOB = int64(math.MaxInt64)
+
+// TableNumberError is the error returned for the standard table numbers out of the [1, 15] range.
+type TableNumberError struct {
+	// Number is the provided table number.
+	Number int
+}
+
+// Error implements the error interface.
+func (e *TableNumberError) Error() string {
+	return fmt.Sprintf("invalid standard huffman table number %d: must be in range [1, %d]", e.Number, StandardTablesNumber)
+}
+
+// Line is the specification of a single code table line (see B.2 of the JBIG2 specification).
+type Line struct {
+	// PrefixLength is the length of the code prefix. Lines with zero prefix length are unused.
+	PrefixLength int32
+	// RangeLength is the number of the bits read after the prefix. The value -1 defines
+	// the out-of-band line.
+	RangeLength int32
+	// RangeLow is the lowest value of the line range.
+	RangeLow int32
+	// LowerRange defines if the line is the lower range line, decoding the values below
+	// the RangeLow value.
+	LowerRange bool
+}
+
+// Table is the JBIG2 Huffman code table.
+type Table struct {
+	t huffman.Tabler
+}
+
+// StandardTable gets the standard table B.'number' where the 'number' is in range [1, 15].
+// Returns a *TableNumberError if the 'number' is out of range.
+func StandardTable(number int) (*Table, error) {
+	if number < 1 || number > StandardTablesNumber {
+		return nil, &TableNumberError{Number: number}
+	}
+	t, err := huffman.GetStandardTable(number)
+	if err != nil {
+		return nil, err
+	}
+	return &Table{t: t}, nil
+}
+
+// NewTable creates a custom code table from the table 'lines' specification. The prefix codes
+// are assigned to the lines the same way as for the standard tables (see B.3).
+func NewTable(lines []Line) (*Table, error) {
+	if len(lines) == 0 {
+		return nil, errors.New("no huffman table lines provided")
+	}
+	codes := make([]*huffman.Code, len(lines))
+	for i, line := range lines {
+		if line.PrefixLength < 0 || line.PrefixLength > 32 {
+			return nil, fmt.Errorf("invalid prefix length %d of the line %d", line.PrefixLength, i)
+		}
+		if line.RangeLength < -1 || line.RangeLength > 32 {
+			return nil, fmt.Errorf("invalid range length %d of the line %d", line.RangeLength, i)
+		}
+		codes[i] = huffman.NewCode(line.PrefixLength, line.RangeLength, line.RangeLow, line.LowerRange)
+	}
+	t, err := huffman.NewFixedSizeTable(codes)
+	if err != nil {
+		return nil, err
+	}
+	return &Table{t: t}, nil
+}
+
+// Decode decodes the next value from the reader 'r'. Returns the OOB value if the out-of-band
+// code was read.
+func (t *Table) Decode(r *Reader) (int64, error) {
+	return t.t.Decode(r.r)
+}
+
+// String implements the fmt.Stringer interface.
+func (t *Table) String() string {
+	return t.t.String()
+}
+
+// Reader is the MSB first bit reader of the Huffman encoded data.
+type Reader struct {
+	r *reader.Reader
+}
+
+// NewReader creates a new reader of the 'data'.
+func NewReader(data []byte) *Reader {
+	return &Reader{r: reader.New(data)}
+}
+
+// ReadBit reads the next bit.
+func (r *Reader) ReadBit() (int, error) {
+	return r.r.ReadBit()
+}
+
+// ReadBits reads the next 'n' bits, where 'n' is at most 64.
+func (r *Reader) ReadBits(n byte) (uint64, error) {
+	return r.r.ReadBits(n)
+}
+
+// Align skips the remaining bits of the current byte. Returns the number of skipped bits.
+func (r *Reader) Align() byte {
+	return r.r.Align()
+}
@@ -0,0 +1,97 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package huffman
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStandardTable tests the decoding with the standard tables.
+func TestStandardTable(t *testing.T) {
+	t.Run("B1", func(t *testing.T) {
+		table, err := StandardTable(1)
+		require.NoError(t, err)
+
+		// '0' + 4 bits: 5, '10' + 8 bits: 16 + 4.
+		r := NewReader([]byte{0x2C, 0x08})
+		v, err := table.Decode(r)
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), v)
+
+		v, err = table.Decode(r)
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), v)
+	})
+
+	t.Run("B2OOB", func(t *testing.T) {
+		table, err := StandardTable(2)
+		require.NoError(t, err)
+
+		v, err := table.Decode(NewReader([]byte{0xFC}))
+		require.NoError(t, err)
+		assert.Equal(t, OOB, v)
+	})
+
+	t.Run("AllTables", func(t *testing.T) {
+		for i := 1; i <= StandardTablesNumber; i++ {
+			_, err := StandardTable(i)
+			require.NoError(t, err, "table: %d", i)
+		}
+	})
+
+	t.Run("OutOfRange", func(t *testing.T) {
+		for _, number := range []int{-1, 0, StandardTablesNumber + 1} {
+			_, err := StandardTable(number)
+			require.Error(t, err)
+
+			var numberErr *TableNumberError
+			require.True(t, errors.As(err, &numberErr))
+			assert.Equal(t, number, numberErr.Number)
+		}
+	})
+}
+
+// TestNewTable tests the decoding with the custom tables.
+func TestNewTable(t *testing.T) {
+	table, err := NewTable([]Line{
+		{PrefixLength: 1, RangeLength: 0, RangeLow: 7},
+		{PrefixLength: 2, RangeLength: 0, RangeLow: 8},
+		{PrefixLength: 2, RangeLength: -1},
+	})
+	require.NoError(t, err)
+
+	// '0': 7, '10': 8, '11': OOB.
+	r := NewReader([]byte{0x58})
+	for _, expected := range []int64{7, 8, OOB} {
+		v, err := table.Decode(r)
+		require.NoError(t, err)
+		assert.Equal(t, expected, v)
+	}
+
+	t.Run("Incomplete", func(t *testing.T) {
+		table, err := NewTable([]Line{{PrefixLength: 1, RangeLength: 0, RangeLow: 7}})
+		require.NoError(t, err)
+
+		_, err = table.Decode(NewReader([]byte{0x80}))
+		require.Error(t, err)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewTable(nil)
+		require.Error(t, err)
+
+		_, err = NewTable([]Line{{PrefixLength: 1, RangeLength: 33}})
+		require.Error(t, err)
+
+		// the same code assigned twice.
+		_, err = NewTable([]Line{{PrefixLength: 1}, {PrefixLength: 1}, {PrefixLength: 1}})
+		require.Error(t, err)
+	})
+}
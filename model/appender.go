@@ -353,8 +353,19 @@ func (a *PdfAppender) MergePageWith(pageNum int, page *PdfPage) error {
 		return err
 	}
 
-	for _, a := range page.annotations {
-		srcPage.annotations = append(srcPage.annotations, a)
+	// Load the annotations of both pages, so that the annotations of the
+	// source page are kept, followed by the merged annotations in their order.
+	srcAnnotations, err := srcPage.GetAnnotations()
+	if err != nil {
+		return err
+	}
+	annotations, err := page.GetAnnotations()
+	if err != nil {
+		return err
+	}
+	srcPage.annotations = append(append([]*PdfAnnotation{}, srcAnnotations...), annotations...)
+	if srcPage.Tabs == nil {
+		srcPage.Tabs = page.Tabs
 	}
 
 	if srcPage.Resources == nil {
@@ -0,0 +1,198 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// PageLayout specifies the page layout used when the document is opened
+// (section 7.7.2 "Document Catalog" p. 73 PDF32000_2008).
+type PageLayout string
+
+// Page layouts.
+const (
+	PageLayoutSinglePage     PageLayout = "SinglePage"     // Display one page at a time.
+	PageLayoutOneColumn      PageLayout = "OneColumn"      // Display the pages in one column.
+	PageLayoutTwoColumnLeft  PageLayout = "TwoColumnLeft"  // Two columns, odd-numbered pages on the left.
+	PageLayoutTwoColumnRight PageLayout = "TwoColumnRight" // Two columns, odd-numbered pages on the right.
+	PageLayoutTwoPageLeft    PageLayout = "TwoPageLeft"    // Two pages at a time, odd-numbered pages on the left.
+	PageLayoutTwoPageRight   PageLayout = "TwoPageRight"   // Two pages at a time, odd-numbered pages on the right.
+)
+
+// IsValid checks if the page layout is one of the values defined by the
+// PDF specification.
+func (l PageLayout) IsValid() bool {
+	switch l {
+	case PageLayoutSinglePage, PageLayoutOneColumn, PageLayoutTwoColumnLeft,
+		PageLayoutTwoColumnRight, PageLayoutTwoPageLeft, PageLayoutTwoPageRight:
+		return true
+	}
+	return false
+}
+
+// PageMode specifies how the document is displayed when opened
+// (section 7.7.2 "Document Catalog" p. 73 PDF32000_2008).
+type PageMode string
+
+// Page modes.
+const (
+	PageModeUseNone        PageMode = "UseNone"        // Neither outlines nor thumbnails visible.
+	PageModeUseOutlines    PageMode = "UseOutlines"    // Document outline visible.
+	PageModeUseThumbs      PageMode = "UseThumbs"      // Thumbnail images visible.
+	PageModeFullScreen     PageMode = "FullScreen"     // Full-screen mode.
+	PageModeUseOC          PageMode = "UseOC"          // Optional content group panel visible.
+	PageModeUseAttachments PageMode = "UseAttachments" // Attachments panel visible.
+)
+
+// IsValid checks if the page mode is one of the values defined by the PDF
+// specification.
+func (m PageMode) IsValid() bool {
+	switch m {
+	case PageModeUseNone, PageModeUseOutlines, PageModeUseThumbs,
+		PageModeFullScreen, PageModeUseOC, PageModeUseAttachments:
+		return true
+	}
+	return false
+}
+
+// GetCatalog returns the document catalog dictionary. Changes made to the
+// returned dictionary affect the reader.
+func (r *PdfReader) GetCatalog() *core.PdfObjectDictionary {
+	return r.catalog
+}
+
+// GetPageLayout returns the page layout used when the document is opened.
+// Returns PageLayoutSinglePage (the default value) if the entry is not
+// specified or is invalid.
+func (r *PdfReader) GetPageLayout() PageLayout {
+	name, ok := core.GetNameVal(r.catalog.Get("PageLayout"))
+	if !ok {
+		return PageLayoutSinglePage
+	}
+
+	layout := PageLayout(name)
+	if !layout.IsValid() {
+		common.Log.Debug("ERROR: invalid PageLayout (%s) - using default", name)
+		return PageLayoutSinglePage
+	}
+	return layout
+}
+
+// GetPageMode returns the page mode used when the document is opened.
+// Returns PageModeUseNone (the default value) if the entry is not specified
+// or is invalid.
+func (r *PdfReader) GetPageMode() PageMode {
+	name, ok := core.GetNameVal(r.catalog.Get("PageMode"))
+	if !ok {
+		return PageModeUseNone
+	}
+
+	mode := PageMode(name)
+	if !mode.IsValid() {
+		common.Log.Debug("ERROR: invalid PageMode (%s) - using default", name)
+		return PageModeUseNone
+	}
+	return mode
+}
+
+// GetCatalogEntry returns the value of the entry with the specified key from
+// the document catalog, or nil if the entry does not exist.
+func (w *PdfWriter) GetCatalogEntry(key string) core.PdfObject {
+	return w.catalog.Get(core.PdfObjectName(key))
+}
+
+// SetCatalogEntry sets the entry with the specified key in the document
+// catalog. The objects referenced by `value` are added to the output file.
+// Setting a nil value removes the entry.
+// The Type, Pages and Version entries are managed by the writer and cannot
+// be set.
+func (w *PdfWriter) SetCatalogEntry(key string, value core.PdfObject) error {
+	switch key {
+	case "Type", "Pages", "Version":
+		return fmt.Errorf("catalog entry %s cannot be set", key)
+	case "":
+		return errors.New("empty catalog entry key")
+	}
+
+	if value == nil {
+		w.catalog.Remove(core.PdfObjectName(key))
+		return nil
+	}
+
+	common.Log.Trace("Setting catalog %s...", key)
+	w.catalog.Set(core.PdfObjectName(key), value)
+	return w.addObjects(value)
+}
+
+// SetPageLayout sets the page layout used when the document is opened.
+func (w *PdfWriter) SetPageLayout(layout PageLayout) error {
+	if !layout.IsValid() {
+		return fmt.Errorf("invalid page layout: %s", layout)
+	}
+
+	w.catalog.Set("PageLayout", core.MakeName(string(layout)))
+	return nil
+}
+
+// SetPageMode sets the page mode used when the document is opened.
+func (w *PdfWriter) SetPageMode(mode PageMode) error {
+	if !mode.IsValid() {
+		return fmt.Errorf("invalid page mode: %s", mode)
+	}
+
+	w.catalog.Set("PageMode", core.MakeName(string(mode)))
+	return nil
+}
+
+// SetOpenAction sets the action performed when the document is opened,
+// e.g. a PdfActionGoTo or a PdfActionJavaScript. A nil action removes the
+// entry.
+func (w *PdfWriter) SetOpenAction(action PdfModel) error {
+	if action == nil {
+		return w.SetCatalogEntry("OpenAction", nil)
+	}
+	return w.SetCatalogEntry("OpenAction", action.ToPdfObject())
+}
+
+// SetOpenDestination makes the document open at the specified destination,
+// by setting a GoTo action as the document open action. If the PageObj of
+// `dest` is not set, the Page field is used as the zero-based index of the
+// destination page. The destination page must be added to the writer
+// before calling this method.
+func (w *PdfWriter) SetOpenDestination(dest OutlineDest) error {
+	pagesDict, ok := core.GetDict(w.pages.PdfObject)
+	if !ok {
+		return errors.New("invalid Pages obj (not a dict)")
+	}
+	kids, ok := core.GetArray(pagesDict.Get("Kids"))
+	if !ok {
+		return errors.New("invalid Pages Kids obj (not an array)")
+	}
+
+	if dest.PageObj == nil {
+		if dest.Page < 0 || int(dest.Page) >= kids.Len() {
+			return fmt.Errorf("destination page index out of range: %d", dest.Page)
+		}
+		pageObj, ok := core.GetIndirect(kids.Get(int(dest.Page)))
+		if !ok {
+			return errors.New("invalid page object")
+		}
+		dest.PageObj = pageObj
+	} else if pageDict, ok := core.GetDict(dest.PageObj.PdfObject); !ok {
+		return errors.New("invalid destination page object")
+	} else if _, added := w.pagesMap[pageDict]; !added {
+		return errors.New("destination page not added to the writer")
+	}
+
+	action := NewPdfActionGoTo()
+	action.D = dest.ToPdfObject()
+	return w.SetOpenAction(action)
+}
@@ -0,0 +1,172 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// writeAndReload writes the document of `w` and loads it back.
+func writeAndReload(t *testing.T, w *PdfWriter) *PdfReader {
+	var buf bytes.Buffer
+	require.NoError(t, w.Write(&buf))
+
+	reader, err := NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	return reader
+}
+
+func TestCatalogEntries(t *testing.T) {
+	w := NewPdfWriter()
+	require.NoError(t, w.AddPage(NewPdfPage()))
+
+	// Defaults.
+	reader := writeAndReload(t, &w)
+	require.Equal(t, PageLayoutSinglePage, reader.GetPageLayout())
+	require.Equal(t, PageModeUseNone, reader.GetPageMode())
+
+	w = NewPdfWriter()
+	require.NoError(t, w.AddPage(NewPdfPage()))
+	require.NoError(t, w.SetPageLayout(PageLayoutTwoColumnLeft))
+	require.NoError(t, w.SetPageMode(PageModeUseOutlines))
+	require.Error(t, w.SetPageLayout("Invalid"))
+	require.Error(t, w.SetPageMode("Invalid"))
+
+	// Custom entries, including indirect objects.
+	lang := core.MakeString("en-US")
+	markInfo := core.MakeIndirectObject(core.MakeDict())
+	markInfo.PdfObject.(*core.PdfObjectDictionary).Set("Marked", core.MakeBool(true))
+	require.NoError(t, w.SetCatalogEntry("Lang", lang))
+	require.NoError(t, w.SetCatalogEntry("MarkInfo", markInfo))
+	require.Equal(t, lang, w.GetCatalogEntry("Lang"))
+	require.Error(t, w.SetCatalogEntry("Pages", core.MakeDict()))
+
+	// Removing entries.
+	require.NoError(t, w.SetCatalogEntry("Custom", core.MakeInteger(1)))
+	require.NoError(t, w.SetCatalogEntry("Custom", nil))
+	require.Nil(t, w.GetCatalogEntry("Custom"))
+
+	reader = writeAndReload(t, &w)
+	require.Equal(t, PageLayoutTwoColumnLeft, reader.GetPageLayout())
+	require.Equal(t, PageModeUseOutlines, reader.GetPageMode())
+
+	catalog := reader.GetCatalog()
+	require.NotNil(t, catalog)
+	langVal, ok := core.GetStringVal(catalog.Get("Lang"))
+	require.True(t, ok)
+	require.Equal(t, "en-US", langVal)
+
+	markInfoDict, ok := core.GetDict(catalog.Get("MarkInfo"))
+	require.True(t, ok)
+	marked, ok := core.GetBoolVal(markInfoDict.Get("Marked"))
+	require.True(t, ok)
+	require.True(t, marked)
+	require.Nil(t, catalog.Get("Custom"))
+}
+
+func TestViewerPreferences(t *testing.T) {
+	w := NewPdfWriter()
+	require.NoError(t, w.AddPage(NewPdfPage()))
+
+	// Invalid enumeration values.
+	require.Error(t, w.SetViewerPreferences(&ViewerPreferences{PrintScaling: "Fit"}))
+	require.Error(t, w.SetViewerPreferences(&ViewerPreferences{Duplex: "Duplex"}))
+	require.Error(t, w.SetViewerPreferences(&ViewerPreferences{NonFullScreenPageMode: PageModeFullScreen}))
+	require.Error(t, w.SetViewerPreferences(&ViewerPreferences{ViewArea: "PageBox"}))
+	require.Error(t, w.SetViewerPreferences(&ViewerPreferences{PrintPageRange: []int{1, 2, 3}}))
+
+	pickTray := false
+	vp := &ViewerPreferences{
+		HideToolbar:           true,
+		FitWindow:             true,
+		DisplayDocTitle:       true,
+		NonFullScreenPageMode: PageModeUseOutlines,
+		Direction:             DirectionR2L,
+		PrintArea:             "CropBox",
+		PrintScaling:          PrintScalingNone,
+		Duplex:                DuplexFlipLongEdge,
+		PickTrayByPDFSize:     &pickTray,
+		PrintPageRange:        []int{1, 1, 3, 4},
+		NumCopies:             2,
+	}
+	require.NoError(t, w.SetViewerPreferences(vp))
+
+	reader := writeAndReload(t, &w)
+	loaded, err := reader.GetViewerPreferences()
+	require.NoError(t, err)
+	require.Equal(t, vp, loaded)
+
+	// Invalid entries are ignored when reading.
+	d := core.MakeDict()
+	d.Set("HideMenubar", core.MakeBool(true))
+	d.Set("PrintScaling", core.MakeName("Fit"))
+	d.Set("Duplex", core.MakeName("Simplex"))
+	loaded, err = newViewerPreferencesFromPdfObject(d)
+	require.NoError(t, err)
+	require.Equal(t, &ViewerPreferences{HideMenubar: true, Duplex: DuplexSimplex}, loaded)
+
+	// Missing viewer preferences.
+	w = NewPdfWriter()
+	require.NoError(t, w.AddPage(NewPdfPage()))
+	loaded, err = writeAndReload(t, &w).GetViewerPreferences()
+	require.NoError(t, err)
+	require.Nil(t, loaded)
+}
+
+func TestOpenDestination(t *testing.T) {
+	newWriter := func() (*PdfWriter, []*PdfPage) {
+		w := NewPdfWriter()
+		pages := []*PdfPage{NewPdfPage(), NewPdfPage(), NewPdfPage()}
+		for _, page := range pages {
+			require.NoError(t, w.AddPage(page))
+		}
+		return &w, pages
+	}
+
+	checkOpenDest := func(reader *PdfReader, pageIdx int, expected []float64) {
+		action, ok := core.GetDict(reader.GetCatalog().Get("OpenAction"))
+		require.True(t, ok)
+		actionType, _ := core.GetNameVal(action.Get("S"))
+		require.Equal(t, "GoTo", actionType)
+
+		destArr, ok := core.GetArray(action.Get("D"))
+		require.True(t, ok)
+		pageObj, ok := core.GetIndirect(destArr.Get(0))
+		require.True(t, ok)
+		_, pageNum, err := reader.PageFromIndirectObject(pageObj)
+		require.NoError(t, err)
+		require.Equal(t, pageIdx+1, pageNum)
+
+		params, err := core.GetNumbersAsFloat(destArr.Elements()[2:])
+		require.NoError(t, err)
+		require.Equal(t, expected, params)
+	}
+
+	// Invalid destinations.
+	w, _ := newWriter()
+	require.Error(t, w.SetOpenDestination(NewOutlineDestFit(3)))
+	dest := NewOutlineDestFit(0)
+	dest.PageObj = NewPdfPage().GetPageAsIndirectObject()
+	require.Error(t, w.SetOpenDestination(dest))
+
+	// Page index with XYZ destination.
+	dest = NewOutlineDest(1, 10, 700)
+	dest.Zoom = 1.5
+	require.NoError(t, w.SetOpenDestination(dest))
+	checkOpenDest(writeAndReload(t, w), 1, []float64{10, 700, 1.5})
+
+	// Page object with Fit destination.
+	w, pages := newWriter()
+	dest = NewOutlineDestFit(0)
+	dest.PageObj = pages[2].GetPageAsIndirectObject()
+	require.NoError(t, w.SetOpenDestination(dest))
+	checkOpenDest(writeAndReload(t, w), 2, nil)
+}
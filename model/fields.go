@@ -426,6 +426,8 @@ func (ch *PdfFieldChoice) ToPdfObject() core.PdfObject {
 	}
 	if ch.I != nil {
 		d.Set("I", ch.I)
+	} else {
+		d.Remove("I")
 	}
 
 	return container
@@ -347,6 +347,7 @@ func newPdfFontFromPdfObject(fontObj core.PdfObject, allowType0 bool) (*PdfFont,
 
 			simplefont.charWidths = std.charWidths
 			simplefont.fontMetrics = std.fontMetrics
+			simplefont.fontKerning = std.fontKerning
 		} else {
 			simplefont, err = newSimpleFontFromPdfObject(d, base, nil)
 			if err != nil {
@@ -644,6 +645,162 @@ func (font *PdfFont) GetCharMetrics(code textencoding.CharCode) (CharMetrics, bo
 	return nometrics, false
 }
 
+// FontMetrics represents the global metrics of a font, as specified by the
+// font descriptor. All the values are expressed in glyph space units
+// (1/1000 of text space units).
+type FontMetrics struct {
+	Ascent      float64
+	Descent     float64
+	CapHeight   float64
+	XHeight     float64
+	ItalicAngle float64
+	BBox        PdfRectangle
+}
+
+// GetFontMetrics returns the global metrics of the font. For the Standard 14
+// fonts, the metrics are loaded from the built-in AFM data. For other fonts,
+// the metrics are loaded from the font descriptor. Metrics which are not
+// specified are set to 0.
+func (font *PdfFont) GetFontMetrics() (FontMetrics, error) {
+	var metrics FontMetrics
+
+	desc, err := font.GetFontDescriptor()
+	if err != nil {
+		return metrics, err
+	}
+	if desc == nil {
+		return metrics, errors.New("font descriptor not found")
+	}
+
+	getVal := func(obj core.PdfObject) float64 {
+		val, _ := core.GetNumberAsFloat(core.TraceToDirectObject(obj))
+		return val
+	}
+	metrics.Ascent = getVal(desc.Ascent)
+	metrics.Descent = getVal(desc.Descent)
+	metrics.CapHeight = getVal(desc.CapHeight)
+	metrics.XHeight = getVal(desc.XHeight)
+	metrics.ItalicAngle = getVal(desc.ItalicAngle)
+
+	if arr, ok := core.GetArray(desc.FontBBox); ok {
+		bbox, err := NewPdfRectangle(*arr)
+		if err != nil {
+			common.Log.Debug("ERROR: invalid FontBBox: %v", err)
+		} else {
+			metrics.BBox = *bbox
+		}
+	}
+
+	return metrics, nil
+}
+
+// GetRuneMetricsTable returns the metrics of all the runes the font has
+// metrics for. For the Standard 14 fonts, the metrics are loaded from the
+// built-in AFM data. For other simple fonts, the metrics are derived from the
+// font widths and encoding. Returns nil for composite fonts.
+// The returned map can be safely modified by the caller.
+func (font *PdfFont) GetRuneMetricsTable() map[rune]CharMetrics {
+	t, ok := font.context.(*pdfFontSimple)
+	if !ok {
+		common.Log.Debug("GetRuneMetricsTable not implemented for font type=%T", font.context)
+		return nil
+	}
+
+	if t.fontMetrics != nil {
+		table := make(map[rune]CharMetrics, len(t.fontMetrics))
+		for r, metrics := range t.fontMetrics {
+			table[r] = metrics
+		}
+		return table
+	}
+
+	encoder := font.Encoder()
+	if encoder == nil {
+		return nil
+	}
+	table := make(map[rune]CharMetrics, len(t.charWidths))
+	for code, width := range t.charWidths {
+		if r, ok := encoder.CharcodeToRune(code); ok {
+			table[r] = CharMetrics{Wx: width}
+		}
+	}
+	return table
+}
+
+// GetKerning returns the kerning adjustment between the glyphs of the `left`
+// and `right` runes, in glyph space units (1/1000 of text space). A negative
+// value moves the glyphs closer together.
+// Kerning information is currently available for the Standard 14 fonts only.
+// Returns false if the font does not specify a kerning adjustment for the pair.
+func (font *PdfFont) GetKerning(left, right rune) (float64, bool) {
+	t, ok := font.context.(*pdfFontSimple)
+	if !ok || t.fontKerning == nil {
+		return 0, false
+	}
+	return t.fontKerning.GetKerning(left, right)
+}
+
+// MeasureTextWidth returns the width of `text`, in points, when shown using
+// `font` at the specified font `size`. Runes for which the font has no metrics
+// are skipped. The widths of the runes of composite fonts loaded from PDF
+// files are looked up by character code, as mapped by the ToUnicode CMap or
+// the encoder of the font, so that the glyph widths (W) of embedded fonts are
+// taken into account.
+func MeasureTextWidth(font *PdfFont, text string, size float64) float64 {
+	if font == nil || text == "" {
+		return 0
+	}
+
+	var encoders []textencoding.TextEncoder
+	if font.IsCID() {
+		if toUnicode := font.baseFields().toUnicodeCmap; toUnicode != nil {
+			encoders = append(encoders, textencoding.NewCMapEncoder("", nil, toUnicode))
+		}
+		if encoder := font.Encoder(); encoder != nil {
+			encoders = append(encoders, encoder)
+		}
+	}
+
+	var width float64
+	for _, r := range text {
+		metrics, ok := font.measureRune(r, encoders)
+		if !ok {
+			common.Log.Debug("Font does not have rune metrics for %v - skipping", r)
+			continue
+		}
+		width += metrics.Wx
+	}
+	return width * size / 1000
+}
+
+// measureRune returns the metrics of rune `r`. The rune is mapped to a
+// character code using `encoders`, unless the font has metrics for the rune
+// itself, as is the case for the composite fonts created from font files.
+func (font *PdfFont) measureRune(r rune, encoders []textencoding.TextEncoder) (CharMetrics, bool) {
+	if len(encoders) > 0 && !font.hasRuneWidth(r) {
+		for _, encoder := range encoders {
+			if code, ok := encoder.RuneToCharcode(r); ok {
+				return font.GetCharMetrics(code)
+			}
+		}
+	}
+	return font.GetRuneMetrics(r)
+}
+
+// hasRuneWidth returns true if the descendant of the composite font has a
+// width for rune `r`.
+func (font *PdfFont) hasRuneWidth(r rune) bool {
+	t, ok := font.context.(*pdfFontType0)
+	if !ok || t.DescendantFont == nil {
+		return false
+	}
+	if cidFont, ok := t.DescendantFont.context.(*pdfCIDFontType2); ok {
+		_, has := cidFont.runeToWidthMap[r]
+		return has
+	}
+	return false
+}
+
 // actualFont returns the Font in font.context
 func (font PdfFont) actualFont() pdfFont {
 	if font.context == nil {
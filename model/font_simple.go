@@ -63,6 +63,9 @@ type pdfFontSimple struct {
 
 	// Standard 14 fonts metrics
 	fontMetrics map[rune]fonts.CharMetrics
+
+	// Standard 14 fonts kerning pairs
+	fontKerning fonts.Kerner
 }
 
 // pdfCIDFontType0FromSkeleton returns a pdfFontSimple with its common fields initalized.
@@ -541,6 +544,7 @@ func stdFontToSimpleFont(f fonts.StdFont) pdfFontSimple {
 			basefont: f.Name(),
 		},
 		fontMetrics: f.GetMetricsTable(),
+		fontKerning: f,
 		std14Descriptor: &PdfFontDescriptor{
 			FontName:    core.MakeName(string(l.Name)),
 			FontFamily:  core.MakeName(l.Family),
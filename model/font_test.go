@@ -885,3 +885,104 @@ func TestNewFontFromFile(t *testing.T) {
 		t.Fatalf("Failed to load font from file. err=%v", err)
 	}
 }
+
+func TestStandard14FontMetrics(t *testing.T) {
+	font, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+
+	metrics, err := font.GetFontMetrics()
+	require.NoError(t, err)
+	require.Equal(t, model.FontMetrics{
+		Ascent:    718,
+		Descent:   -207,
+		CapHeight: 718,
+		XHeight:   523,
+		BBox:      model.PdfRectangle{Llx: -166, Lly: -225, Urx: 1000, Ury: 931},
+	}, metrics)
+
+	table := font.GetRuneMetricsTable()
+	require.NotEmpty(t, table)
+	require.Equal(t, 556.0, table['a'].Wx)
+	for _, r := range "Hello world" {
+		m, ok := font.GetRuneMetrics(r)
+		require.True(t, ok)
+		require.Equal(t, m, table[r])
+	}
+
+	// The table must be a copy.
+	delete(table, 'a')
+	_, ok := font.GetRuneMetrics('a')
+	require.True(t, ok)
+	require.NotEmpty(t, font.GetRuneMetricsTable()['a'])
+}
+
+func TestStandard14FontKerning(t *testing.T) {
+	tests := []struct {
+		name  model.StdFontName
+		pair  string
+		kern  float64
+		found bool
+	}{
+		{model.HelveticaName, "AV", -70, true},
+		{model.HelveticaObliqueName, "VA", -80, true},
+		{model.HelveticaBoldName, "AT", -90, true},
+		{model.TimesRomanName, "AV", -135, true},
+		{model.HelveticaName, "ab", 0, false},
+		{model.CourierName, "AV", 0, false},
+	}
+
+	for _, tc := range tests {
+		font, err := model.NewStandard14Font(tc.name)
+		require.NoError(t, err)
+
+		pair := []rune(tc.pair)
+		kern, ok := font.GetKerning(pair[0], pair[1])
+		require.Equal(t, tc.found, ok, "%s %s", tc.name, tc.pair)
+		require.Equal(t, tc.kern, kern, "%s %s", tc.name, tc.pair)
+	}
+}
+
+func TestMeasureTextWidth(t *testing.T) {
+	helvetica, err := model.NewStandard14Font(model.HelveticaName)
+	require.NoError(t, err)
+
+	var expected float64
+	for _, r := range "Hello" {
+		m, ok := helvetica.GetRuneMetrics(r)
+		require.True(t, ok)
+		expected += m.Wx
+	}
+	require.InDelta(t, expected*12/1000, model.MeasureTextWidth(helvetica, "Hello", 12), 1e-9)
+	require.Zero(t, model.MeasureTextWidth(helvetica, "", 12))
+	require.Zero(t, model.MeasureTextWidth(nil, "Hello", 12))
+
+	// The widths of composite fonts loaded from PDF files are looked up by
+	// character code, instead of using the default width (DW).
+	parser := core.NewParserFromString(`<< /Type /Font
+		/Subtype /Type0
+		/Encoding /Identity-H
+		/BaseFont /Test
+		/DescendantFonts [<<
+			/Type /Font
+			/Subtype /CIDFontType2
+			/BaseFont /Test
+			/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >>
+			/W [65 [500 600]]
+			/DW 1000
+			>>]
+		>>`)
+	obj, err := parser.ParseDict()
+	require.NoError(t, err)
+	font, err := model.NewPdfFontFromPdfObject(obj)
+	require.NoError(t, err)
+	require.InDelta(t, 11.0, model.MeasureTextWidth(font, "AB", 10), 1e-9)
+	require.InDelta(t, 10.0, model.MeasureTextWidth(font, "C", 10), 1e-9)
+
+	// The widths of composite fonts created from font files are looked up
+	// by rune.
+	ttf, err := model.NewCompositePdfFontFromTTFFile("testdata/font/OpenSans-Regular.ttf")
+	require.NoError(t, err)
+	m, ok := ttf.GetRuneMetrics('W')
+	require.True(t, ok)
+	require.InDelta(t, m.Wx*10/1000, model.MeasureTextWidth(ttf, "W", 10), 1e-9)
+}
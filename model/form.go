@@ -6,7 +6,10 @@
 package model
 
 import (
+	"errors"
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
 	"github.com/bcmmbaga/unipdf-agpl/v3/core"
@@ -251,9 +254,16 @@ func (form *PdfAcroForm) FillWithAppearance(provider FieldValueProvider, appGen
 	return form.fill(provider, appGen)
 }
 
-// fill populates `form` with values provided by `provider`. If `appGen` is
-// not nil, field appearances are also generated.
-func (form *PdfAcroForm) fill(provider FieldValueProvider, appGen FieldAppearanceGenerator) error {
+// FillWithValidation populates `form` with values provided by `provider`,
+// after validating them. The values of choice fields must be among the
+// options (Opt) of the fields, unless the fields are editable combo boxes,
+// the values of checkbox and radio button fields must be among the
+// appearance states of their widgets, and the values of text fields must
+// not exceed their maximum length (MaxLen). If a value is not valid, a
+// *FieldValueError is returned and the form is not modified.
+// If not nil, `appGen` is used to generate the appearance dictionaries of
+// the field annotations.
+func (form *PdfAcroForm) FillWithValidation(provider FieldValueProvider, appGen FieldAppearanceGenerator) error {
 	if form == nil {
 		return nil
 	}
@@ -263,17 +273,299 @@ func (form *PdfAcroForm) fill(provider FieldValueProvider, appGen FieldAppearanc
 	}
 
 	for _, field := range form.AllFields() {
-		// Try finding the field in the provider field map using its partial
-		// name. If not found, try finding it by its full name.
-		partialName := field.PartialName()
-		valObj, found := objMap[partialName]
+		valObj, found := lookupFieldValue(objMap, field)
 		if !found {
-			if fullName, err := field.FullName(); err == nil {
-				valObj, found = objMap[fullName]
+			continue
+		}
+		if err := validateFieldValue(field, valObj); err != nil {
+			return err
+		}
+	}
+
+	return form.fill(fieldValueMap(objMap), appGen)
+}
+
+// FillWithPartialNames populates `form` with values provided by `provider`,
+// like FillWithAppearance, but the field names of the provider may also be
+// partial names, matching the trailing components of the full names of the
+// fields. For example, "address.city" matches the field "person.address.city"
+// but not "person.myaddress.city". A name equal to the full name of a field
+// always matches that field only. If several names match the same field,
+// the most specific one is used: the full name of the field, if provided,
+// or else the longest partial name. If a name matches more than one field, a
+// *FieldNameError is returned and the form is not modified.
+// If not nil, `appGen` is used to generate the appearance dictionaries of
+// the field annotations.
+func (form *PdfAcroForm) FillWithPartialNames(provider FieldValueProvider, appGen FieldAppearanceGenerator) error {
+	if form == nil {
+		return nil
+	}
+	objMap, err := provider.FieldValues()
+	if err != nil {
+		return err
+	}
+
+	var fullNames []string
+	isFullName := map[string]bool{}
+	for _, field := range form.AllFields() {
+		fullName, err := field.FullName()
+		if err != nil || isFullName[fullName] {
+			continue
+		}
+		fullNames = append(fullNames, fullName)
+		isFullName[fullName] = true
+	}
+
+	// Process the names in order, so that the result does not depend on the
+	// iteration order of the map.
+	names := make([]string, 0, len(objMap))
+	for name := range objMap {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	resolved := fieldValueMap{}
+	resolvedBy := map[string]string{}
+	resolve := func(fullName, name string) {
+		// A full name is longer than all the partial names matching it.
+		if prev, ok := resolvedBy[fullName]; ok && len(prev) > len(name) {
+			common.Log.Debug("WARN: field %s matched by %s and %s - using %s", fullName, prev, name, prev)
+			return
+		}
+		resolved[fullName] = objMap[name]
+		resolvedBy[fullName] = name
+	}
+	for _, name := range names {
+		if isFullName[name] {
+			resolve(name, name)
+			continue
+		}
+
+		var matches []string
+		for _, fullName := range fullNames {
+			if strings.HasSuffix(fullName, "."+name) {
+				matches = append(matches, fullName)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			common.Log.Debug("WARN: no form field matches the provided name %s. Skipping.", name)
+		case 1:
+			resolve(matches[0], name)
+		default:
+			sort.Strings(matches)
+			return &FieldNameError{Name: name, Matches: matches}
+		}
+	}
+
+	return form.fillWithLookup(resolved, appGen, lookupFullName)
+}
+
+// FieldNameError represents a field name which matches more than one form
+// field when filling a form by partial names.
+type FieldNameError struct {
+	// Name is the provided field name.
+	Name string
+
+	// Matches lists the full names of the matching fields.
+	Matches []string
+}
+
+// Error implements the error interface.
+func (e *FieldNameError) Error() string {
+	return fmt.Sprintf("ambiguous field name %q: matches fields %q", e.Name, e.Matches)
+}
+
+// FieldValueError represents an invalid value provided for a form field.
+type FieldValueError struct {
+	// Field is the full name of the field.
+	Field string
+
+	// Value is the provided value.
+	Value string
+
+	// Allowed lists the allowed values of choice and button fields.
+	Allowed []string
+
+	// MaxLen is the maximum length of text fields.
+	MaxLen int
+}
+
+// Error implements the error interface.
+func (e *FieldValueError) Error() string {
+	if e.Allowed != nil {
+		return fmt.Sprintf("invalid value %q for field %q: allowed values are %q", e.Value, e.Field, e.Allowed)
+	}
+	return fmt.Sprintf("invalid value %q for field %q: exceeds the maximum length of %d characters",
+		e.Value, e.Field, e.MaxLen)
+}
+
+// fieldValueMap is a FieldValueProvider for already loaded field values.
+type fieldValueMap map[string]core.PdfObject
+
+// FieldValues implements interface FieldValueProvider.
+func (m fieldValueMap) FieldValues() (map[string]core.PdfObject, error) {
+	return m, nil
+}
+
+// lookupFieldValue returns the value of `field` from the field value map
+// `objMap`. The field is looked up by its partial name first and then by
+// its full name.
+func lookupFieldValue(objMap map[string]core.PdfObject, field *PdfField) (core.PdfObject, bool) {
+	valObj, found := objMap[field.PartialName()]
+	if !found {
+		if fullName, err := field.FullName(); err == nil {
+			valObj, found = objMap[fullName]
+		}
+	}
+	return valObj, found
+}
+
+// lookupFullName returns the value of `field` from the field value map
+// `objMap`, looked up by the full name of the field only.
+func lookupFullName(objMap map[string]core.PdfObject, field *PdfField) (core.PdfObject, bool) {
+	fullName, err := field.FullName()
+	if err != nil {
+		return nil, false
+	}
+	valObj, found := objMap[fullName]
+	return valObj, found
+}
+
+// validateFieldValue checks if `val` is a valid value for field `f`.
+// Returns a *FieldValueError if it is not.
+func validateFieldValue(f *PdfField, val core.PdfObject) error {
+	// Get the string values.
+	var values []string
+	switch t := core.TraceToDirectObject(val).(type) {
+	case *core.PdfObjectString:
+		values = append(values, t.Decoded())
+	case *core.PdfObjectName:
+		values = append(values, t.String())
+	case *core.PdfObjectArray:
+		for _, obj := range t.Elements() {
+			if str, ok := core.GetString(obj); ok {
+				values = append(values, str.Decoded())
+			} else if name, ok := core.GetName(obj); ok {
+				values = append(values, name.String())
+			}
+		}
+	}
+
+	newError := func(value string) *FieldValueError {
+		name, err := f.FullName()
+		if err != nil {
+			name = f.PartialName()
+		}
+		return &FieldValueError{Field: name, Value: value}
+	}
+
+	// Returns an error for the first value of `values` not in `allowed`.
+	checkAllowed := func(allowed []string) error {
+		allowedMap := map[string]struct{}{}
+		for _, a := range allowed {
+			allowedMap[a] = struct{}{}
+		}
+		for _, value := range values {
+			if value == "" {
+				continue
+			}
+			if _, ok := allowedMap[value]; !ok {
+				err := newError(value)
+				err.Allowed = allowed
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch t := f.GetContext().(type) {
+	case *PdfFieldText:
+		maxLen, ok := core.GetIntVal(t.MaxLen)
+		if !ok || maxLen <= 0 {
+			return nil
+		}
+		for _, value := range values {
+			if len([]rune(value)) > maxLen {
+				err := newError(value)
+				err.MaxLen = maxLen
+				return err
+			}
+		}
+	case *PdfFieldChoice:
+		if t.Opt == nil || f.Flags().Has(FieldFlagCombo) && f.Flags().Has(FieldFlagEdit) {
+			return nil
+		}
+		allowed := []string{}
+		for _, optObj := range t.Opt.Elements() {
+			// Options are either strings or [export value, display text] pairs.
+			if arr, ok := core.GetArray(optObj); ok && arr.Len() > 0 {
+				optObj = arr.Get(0)
+			}
+			if opt, ok := core.GetString(optObj); ok {
+				allowed = append(allowed, opt.Decoded())
+			}
+		}
+		return checkAllowed(allowed)
+	case *PdfFieldButton:
+		if t.IsPush() {
+			return nil
+		}
+		// The allowed values are the appearance states of the widgets.
+		allowed := []string{}
+		seen := map[string]struct{}{}
+		for _, wa := range f.Annotations {
+			apDict, ok := core.GetDict(wa.AP)
+			if !ok {
+				continue
+			}
+			nDict, ok := core.GetDict(apDict.Get("N"))
+			if !ok {
+				continue
+			}
+			for _, key := range nDict.Keys() {
+				if _, ok := seen[string(key)]; !ok {
+					seen[string(key)] = struct{}{}
+					allowed = append(allowed, string(key))
+				}
 			}
 		}
+		if len(allowed) == 0 {
+			return nil
+		}
+		if _, ok := seen["Off"]; !ok {
+			allowed = append(allowed, "Off")
+		}
+		return checkAllowed(allowed)
+	}
+	return nil
+}
+
+// fill populates `form` with values provided by `provider`. If `appGen` is
+// not nil, field appearances are also generated.
+func (form *PdfAcroForm) fill(provider FieldValueProvider, appGen FieldAppearanceGenerator) error {
+	if form == nil {
+		return nil
+	}
+	objMap, err := provider.FieldValues()
+	if err != nil {
+		return err
+	}
+
+	// Try finding the fields in the provider field map using their partial
+	// names. If not found, try finding them by their full names.
+	return form.fillWithLookup(objMap, appGen, lookupFieldValue)
+}
+
+// fillWithLookup populates `form` with the values of `objMap`, which are
+// matched to the fields of the form using `lookup`. If `appGen` is not nil,
+// field appearances are also generated.
+func (form *PdfAcroForm) fillWithLookup(objMap map[string]core.PdfObject, appGen FieldAppearanceGenerator,
+	lookup func(objMap map[string]core.PdfObject, field *PdfField) (core.PdfObject, bool)) error {
+	for _, field := range form.AllFields() {
+		valObj, found := lookup(objMap, field)
 		if !found {
-			common.Log.Debug("WARN: form field %s not found in the provider. Skipping.", partialName)
+			common.Log.Debug("WARN: form field %s not found in the provider. Skipping.", field.PartialName())
 			continue
 		}
 
@@ -286,16 +578,102 @@ func (form *PdfAcroForm) fill(provider FieldValueProvider, appGen FieldAppearanc
 		if appGen == nil {
 			continue
 		}
+		if err := form.generateFieldAppearances(field, appGen); err != nil {
+			return err
+		}
+	}
 
-		for _, annot := range field.Annotations {
-			// appGen generates the appearance based on the form/field/annotation and other settings
-			// depending on the implementation (for example may only generate appearance if none set).
-			apDict, err := appGen.GenerateAppearanceDict(form, field, annot)
-			if err != nil {
-				return err
+	return nil
+}
+
+// generateFieldAppearances generates the appearance dictionaries of the
+// widget annotations of `field` using `appGen`.
+func (form *PdfAcroForm) generateFieldAppearances(field *PdfField, appGen FieldAppearanceGenerator) error {
+	for _, annot := range field.Annotations {
+		// appGen generates the appearance based on the form/field/annotation and other settings
+		// depending on the implementation (for example may only generate appearance if none set).
+		apDict, err := appGen.GenerateAppearanceDict(form, field, annot)
+		if err != nil {
+			return err
+		}
+
+		annot.AP = apDict
+		annot.ToPdfObject()
+	}
+	return nil
+}
+
+// Reset resets the fields of `form` to their default values (DV), similarly
+// to the reset form action of PDF viewers. Fields with no default value are
+// cleared. If not nil, `filter` selects the fields to be reset.
+// The appearance states of checkbox and radio button widgets are updated
+// based on the default values. The appearance dictionaries of other widget
+// types are removed and NeedAppearances is set, so that viewers regenerate
+// them. Use ResetWithAppearance to regenerate the appearances instead.
+func (form *PdfAcroForm) Reset(filter func(field *PdfField) bool) error {
+	return form.reset(filter, nil)
+}
+
+// ResetWithAppearance resets the fields of `form` to their default values
+// (DV). If not nil, `filter` selects the fields to be reset. If not nil,
+// `appGen` is used to regenerate the appearance dictionaries of the widget
+// annotations of the reset fields.
+func (form *PdfAcroForm) ResetWithAppearance(filter func(field *PdfField) bool, appGen FieldAppearanceGenerator) error {
+	return form.reset(filter, appGen)
+}
+
+// reset resets the fields of `form` selected by `filter` to their default
+// values. If `appGen` is not nil, field appearances are also regenerated.
+func (form *PdfAcroForm) reset(filter func(field *PdfField) bool, appGen FieldAppearanceGenerator) error {
+	if form == nil {
+		return nil
+	}
+
+	for _, field := range form.AllFields() {
+		if filter != nil && !filter(field) {
+			continue
+		}
+
+		// Get default value, which is inheritable.
+		var dv core.PdfObject
+		if _, err := field.inherit(func(node *PdfField) bool {
+			dv = node.DV
+			return dv != nil
+		}); err != nil {
+			return err
+		}
+
+		field.V = dv
+		if dv == nil {
+			if d, ok := core.GetDict(field.GetContainingPdfObject()); ok {
+				d.Remove("V")
 			}
+		}
 
-			annot.AP = apDict
+		_, isButton := field.GetContext().(*PdfFieldButton)
+		switch ctx := field.GetContext().(type) {
+		case *PdfFieldButton:
+			resetFieldAnnotAS(field)
+		case *PdfFieldChoice:
+			// The selection indices must match the reset value.
+			ctx.I = nil
+			if dv != nil {
+				ctx.I = choiceOptionIndices(ctx, dv)
+			}
+		}
+
+		for _, annot := range field.Annotations {
+			switch {
+			case appGen != nil:
+				apDict, err := appGen.GenerateAppearanceDict(form, field, annot)
+				if err != nil {
+					return err
+				}
+				annot.AP = apDict
+			case !isButton:
+				annot.AP = nil
+				form.NeedAppearances = core.MakeBool(true)
+			}
 			annot.ToPdfObject()
 		}
 	}
@@ -303,9 +681,27 @@ func (form *PdfAcroForm) fill(provider FieldValueProvider, appGen FieldAppearanc
 	return nil
 }
 
+// resetFieldAnnotAS sets the appearance state of the widget annotations of
+// button field `f` based on the field value. The Off state is used for the
+// widgets which do not have an appearance for the value.
+func resetFieldAnnotAS(f *PdfField) {
+	value, _ := core.GetNameVal(f.V)
+	for _, wa := range f.Annotations {
+		state := "Off"
+		if value != "" {
+			if apDict, ok := core.GetDict(wa.AP); ok {
+				if nDict, ok := core.GetDict(apDict.Get("N")); ok && nDict.Get(core.PdfObjectName(value)) != nil {
+					state = value
+				}
+			}
+		}
+		wa.AS = core.MakeName(state)
+	}
+}
+
 // fillFieldValue populates form field `f` with value represented by `v`.
 func fillFieldValue(f *PdfField, val core.PdfObject) error {
-	switch f.GetContext().(type) {
+	switch ctx := f.GetContext().(type) {
 	case *PdfFieldText:
 		switch t := val.(type) {
 		case *core.PdfObjectName:
@@ -336,17 +732,36 @@ func fillFieldValue(f *PdfField, val core.PdfObject) error {
 		}
 	case *PdfFieldChoice:
 		// See section 12.7.4.4 "Choice Fields" (pp. 444-446 PDF32000_2008).
-		switch val.(type) {
+		switch t := val.(type) {
 		case *core.PdfObjectName:
 			if len(val.String()) > 0 {
 				f.V = core.MakeString(val.String())
 				setFieldAnnotAS(f, val)
+				ctx.I = choiceOptionIndices(ctx, f.V)
 			}
 		case *core.PdfObjectString:
 			if len(val.String()) > 0 {
 				f.V = val
 				setFieldAnnotAS(f, core.MakeName(val.String()))
+				ctx.I = choiceOptionIndices(ctx, f.V)
+			}
+		case *core.PdfObjectArray:
+			// Multiple selected values. Only multi-select choice fields can
+			// have more than one value selected.
+			arr := t
+			if arr.Len() == 0 {
+				break
+			}
+			if arr.Len() > 1 && !f.Flags().Has(FieldFlagMultiSelect) {
+				common.Log.Debug("WARN: multiple values for single selection field %s - using first value", f.PartialName())
+				arr = core.MakeArray(arr.Get(0))
 			}
+			if arr.Len() == 1 {
+				f.V = arr.Get(0)
+			} else {
+				f.V = arr
+			}
+			ctx.I = choiceOptionIndices(ctx, arr)
 		default:
 			common.Log.Debug("ERROR: UNEXPECTED %s -> %v", f.PartialName(), val)
 			f.V = val
@@ -358,6 +773,48 @@ func fillFieldValue(f *PdfField, val core.PdfObject) error {
 	return nil
 }
 
+// choiceOptionIndices returns the sorted indices of the options of the
+// choice field `ch` matching the values of `values`, as stored in the I entry
+// of multi-select fields. Options are matched by their export values.
+// `values` is either a single string or name, or an array of them.
+// Returns nil if the field has no options or none of them matches.
+func choiceOptionIndices(ch *PdfFieldChoice, values core.PdfObject) *core.PdfObjectArray {
+	if ch.Opt == nil {
+		return nil
+	}
+	elements := []core.PdfObject{values}
+	if arr, ok := core.GetArray(values); ok {
+		elements = arr.Elements()
+	}
+	selected := map[string]struct{}{}
+	for _, obj := range elements {
+		if str, ok := core.GetString(obj); ok {
+			selected[str.Decoded()] = struct{}{}
+		} else if name, ok := core.GetName(obj); ok {
+			selected[name.String()] = struct{}{}
+		}
+	}
+
+	var indices []int64
+	for i, optObj := range ch.Opt.Elements() {
+		// Options are either strings or [export value, display text] pairs.
+		if arr, ok := core.GetArray(optObj); ok && arr.Len() > 0 {
+			optObj = arr.Get(0)
+		}
+		opt, ok := core.GetString(optObj)
+		if !ok {
+			continue
+		}
+		if _, ok := selected[opt.Decoded()]; ok {
+			indices = append(indices, int64(i))
+		}
+	}
+	if len(indices) == 0 {
+		return nil
+	}
+	return core.MakeArrayFromIntegers64(indices)
+}
+
 // setFieldAnnotAS sets the appearance stream of the field annotations to `val`.
 func setFieldAnnotAS(f *PdfField, val core.PdfObject) {
 	for _, wa := range f.Annotations {
@@ -365,3 +822,175 @@ func setFieldAnnotAS(f *PdfField, val core.PdfObject) {
 		wa.ToPdfObject()
 	}
 }
+
+// FillBatch fills the form of the document loaded by `r` once for each
+// field value provider returned by `next`, which makes it suitable for
+// generating many documents from the same form template (e.g. mail merge).
+// The `next` function must return a nil provider when there are no more
+// providers left. For each provider, `write` is called with the index of the
+// provider and a writer containing the filled document. The writer must be
+// used (e.g. `w.Write(out)`) before `write` returns, as the form fields are
+// reset to their original state after each fill.
+// If not nil, `appGen` is used to generate the appearance dictionaries of the
+// filled fields. Only the fields present in a provider are updated, and the
+// appearances are only generated for the fields whose values differ from the
+// values of the template. The template document is parsed only once, which is
+// significantly faster than loading the template for each provider.
+func (r *PdfReader) FillBatch(next func() (FieldValueProvider, error),
+	appGen FieldAppearanceGenerator, write func(i int, w *PdfWriter) error) error {
+	if r.AcroForm == nil {
+		return errors.New("document does not contain a form")
+	}
+	if next == nil || write == nil {
+		return errors.New("fill batch callbacks not specified")
+	}
+
+	// Save the original state of the form and of its fields.
+	form := r.AcroForm
+	needAppearances := form.NeedAppearances
+	var states []*fieldState
+	for _, field := range form.AllFields() {
+		states = append(states, newFieldState(field))
+	}
+
+	for i := 0; ; i++ {
+		provider, err := next()
+		if err != nil {
+			return err
+		}
+		if provider == nil {
+			break
+		}
+
+		objMap, err := provider.FieldValues()
+		if err != nil {
+			return err
+		}
+		for _, state := range states {
+			valObj, found := lookupFieldValue(objMap, state.field)
+			if !found {
+				continue
+			}
+			if err := fillFieldValue(state.field, valObj); err != nil {
+				return err
+			}
+
+			// The fields left unchanged keep the appearances of the template.
+			if appGen == nil || !state.changed() {
+				continue
+			}
+			if err := form.generateFieldAppearances(state.field, appGen); err != nil {
+				return err
+			}
+		}
+
+		writer := NewPdfWriter()
+		for _, page := range r.PageList {
+			if err := writer.AddPage(page); err != nil {
+				return err
+			}
+		}
+		if err := writer.SetForms(form); err != nil {
+			return err
+		}
+		if err := write(i, &writer); err != nil {
+			return err
+		}
+
+		// Reset the form and the fields for the next provider.
+		form.NeedAppearances = needAppearances
+		if needAppearances == nil {
+			if d, ok := core.GetDict(form.container); ok {
+				d.Remove("NeedAppearances")
+			}
+		}
+		for _, state := range states {
+			state.restore()
+		}
+	}
+
+	return nil
+}
+
+// fieldState holds the state of a form field which is altered when the
+// field is filled.
+type fieldState struct {
+	field *PdfField
+	v     core.PdfObject
+	as    []core.PdfObject
+	ap    []core.PdfObject
+
+	// i holds the selected indices of choice fields.
+	i *core.PdfObjectArray
+}
+
+// newFieldState saves the state of the specified field.
+func newFieldState(field *PdfField) *fieldState {
+	state := &fieldState{field: field, v: field.V}
+	if ch, ok := field.GetContext().(*PdfFieldChoice); ok {
+		state.i = ch.I
+	}
+	for _, wa := range field.Annotations {
+		state.as = append(state.as, wa.AS)
+		state.ap = append(state.ap, wa.AP)
+	}
+	return state
+}
+
+// restore resets the field to the saved state.
+func (s *fieldState) restore() {
+	field := s.field
+	field.V = s.v
+	if field.V == nil {
+		// Remove the value set by the fill process from the field dictionary.
+		if d, ok := core.GetDict(field.GetContainingPdfObject()); ok {
+			d.Remove("V")
+		}
+	}
+	if ch, ok := field.GetContext().(*PdfFieldChoice); ok {
+		ch.I = s.i
+		if ch.I == nil {
+			if d, ok := core.GetDict(field.GetContainingPdfObject()); ok {
+				d.Remove("I")
+			}
+		}
+	}
+
+	for i, wa := range field.Annotations {
+		if i >= len(s.as) {
+			break
+		}
+		wa.AS = s.as[i]
+		wa.AP = s.ap[i]
+		wa.ToPdfObject()
+	}
+}
+
+// changed returns true if the value or the appearance states of the field
+// differ from the saved state.
+func (s *fieldState) changed() bool {
+	if !equalFieldObjects(s.field.V, s.v) {
+		return true
+	}
+	for i, wa := range s.field.Annotations {
+		if i >= len(s.as) || !equalFieldObjects(wa.AS, s.as[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalFieldObjects returns true if the field values `a` and `b` are equal.
+// Strings are compared by their decoded text, regardless of their encoding,
+// and other objects by their string representation.
+func equalFieldObjects(a, b core.PdfObject) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	strA, okA := a.(*core.PdfObjectString)
+	strB, okB := b.(*core.PdfObjectString)
+	if okA && okB {
+		return strA.Decoded() == strB.Decoded()
+	}
+	return a.WriteString() == b.WriteString()
+}
@@ -209,3 +209,328 @@ func TestAcroFormNeedsRepair(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, needsRepair, true)
 }
+
+func TestAcroFormReset(t *testing.T) {
+	newTextField := func(name string, v, dv core.PdfObject) *PdfField {
+		field := NewPdfField()
+		ftxt := &PdfFieldText{PdfField: field}
+		field.SetContext(ftxt)
+		field.T = core.MakeString(name)
+		field.V = v
+		field.DV = dv
+
+		widget := NewPdfAnnotationWidget()
+		widget.AP = core.MakeDict()
+		field.Annotations = append(field.Annotations, widget)
+		return field
+	}
+
+	// Text fields.
+	withDV := newTextField("withDV", core.MakeString("filled"), core.MakeString("default"))
+	noDV := newTextField("noDV", core.MakeString("filled"), nil)
+	skipped := newTextField("skipped", core.MakeString("filled"), nil)
+
+	// Checkbox field.
+	checkbox := NewPdfField()
+	checkbox.SetContext(&PdfFieldButton{PdfField: checkbox})
+	checkbox.T = core.MakeString("checkbox")
+	checkbox.V = core.MakeName("Yes")
+	checkbox.DV = core.MakeName("Off")
+
+	nDict := core.MakeDict()
+	nDict.Set("Yes", core.MakeNull())
+	nDict.Set("Off", core.MakeNull())
+	apDict := core.MakeDict()
+	apDict.Set("N", nDict)
+
+	widget := NewPdfAnnotationWidget()
+	widget.AP = apDict
+	widget.AS = core.MakeName("Yes")
+	checkbox.Annotations = append(checkbox.Annotations, widget)
+
+	// List box fields.
+	newChoiceField := func(name string, dv core.PdfObject) *PdfField {
+		field := NewPdfField()
+		field.SetContext(&PdfFieldChoice{
+			PdfField: field,
+			Opt:      core.MakeArray(core.MakeString("a"), core.MakeString("b")),
+			I:        core.MakeArray(core.MakeInteger(0), core.MakeInteger(1)),
+		})
+		field.T = core.MakeString(name)
+		field.V = core.MakeArray(core.MakeString("a"), core.MakeString("b"))
+		field.DV = dv
+		return field
+	}
+	choiceDV := newChoiceField("choiceDV", core.MakeString("b"))
+	choiceNoDV := newChoiceField("choiceNoDV", nil)
+
+	form := NewPdfAcroForm()
+	form.Fields = &[]*PdfField{withDV, noDV, skipped, checkbox, choiceDV, choiceNoDV}
+	form.ToPdfObject()
+
+	err := form.Reset(func(field *PdfField) bool {
+		return field.PartialName() != "skipped"
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "default", withDV.V.(*core.PdfObjectString).Decoded())
+	require.Nil(t, withDV.Annotations[0].AP)
+
+	require.Nil(t, noDV.V)
+	d, ok := core.GetDict(noDV.GetContainingPdfObject())
+	require.True(t, ok)
+	require.Nil(t, d.Get("V"))
+
+	require.Equal(t, "filled", skipped.V.(*core.PdfObjectString).Decoded())
+	require.NotNil(t, skipped.Annotations[0].AP)
+
+	require.Equal(t, "Off", checkbox.V.String())
+	require.Equal(t, "Off", widget.AS.String())
+	require.NotNil(t, widget.AP)
+
+	indices, err := choiceDV.GetContext().(*PdfFieldChoice).I.ToIntegerArray()
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, indices)
+	require.Nil(t, choiceNoDV.GetContext().(*PdfFieldChoice).I)
+	d, ok = core.GetDict(choiceNoDV.GetContext().ToPdfObject())
+	require.True(t, ok)
+	require.Nil(t, d.Get("I"))
+
+	require.NotNil(t, form.NeedAppearances)
+	require.True(t, bool(*form.NeedAppearances))
+}
+
+func TestAcroFormFillWithValidation(t *testing.T) {
+	newField := func(name string, ctx func(*PdfField) PdfModel) *PdfField {
+		field := NewPdfField()
+		field.SetContext(ctx(field))
+		field.T = core.MakeString(name)
+		return field
+	}
+
+	text := newField("text", func(f *PdfField) PdfModel {
+		return &PdfFieldText{PdfField: f, MaxLen: core.MakeInteger(5)}
+	})
+	choice := newField("choice", func(f *PdfField) PdfModel {
+		return &PdfFieldChoice{PdfField: f, Opt: core.MakeArray(
+			core.MakeString("a"),
+			core.MakeArray(core.MakeString("b"), core.MakeString("Option B")),
+		)}
+	})
+	choice.SetFlag(FieldFlagMultiSelect)
+
+	checkbox := newField("checkbox", func(f *PdfField) PdfModel {
+		return &PdfFieldButton{PdfField: f}
+	})
+	nDict := core.MakeDict()
+	nDict.Set("Yes", core.MakeNull())
+	apDict := core.MakeDict()
+	apDict.Set("N", nDict)
+	widget := NewPdfAnnotationWidget()
+	widget.AP = apDict
+	checkbox.Annotations = append(checkbox.Annotations, widget)
+
+	form := NewPdfAcroForm()
+	form.Fields = &[]*PdfField{text, choice, checkbox}
+
+	fill := func(values map[string]core.PdfObject) error {
+		return form.FillWithValidation(fieldValueMap(values), nil)
+	}
+
+	// Invalid values.
+	err := fill(map[string]core.PdfObject{"text": core.MakeString("too long")})
+	require.Equal(t, &FieldValueError{Field: "text", Value: "too long", MaxLen: 5}, err)
+	require.Contains(t, err.Error(), "maximum length of 5")
+
+	err = fill(map[string]core.PdfObject{
+		"text":   core.MakeString("ok"),
+		"choice": core.MakeArray(core.MakeString("a"), core.MakeString("c")),
+	})
+	require.Equal(t, &FieldValueError{Field: "choice", Value: "c", Allowed: []string{"a", "b"}}, err)
+	require.Equal(t, `invalid value "c" for field "choice": allowed values are ["a" "b"]`, err.Error())
+
+	err = fill(map[string]core.PdfObject{"checkbox": core.MakeName("On")})
+	require.Equal(t, &FieldValueError{Field: "checkbox", Value: "On", Allowed: []string{"Yes", "Off"}}, err)
+
+	// The form is not modified by invalid values.
+	require.Nil(t, text.V)
+	require.Nil(t, choice.V)
+
+	// Valid values.
+	err = fill(map[string]core.PdfObject{
+		"text":     core.MakeString("ok"),
+		"choice":   core.MakeArray(core.MakeString("a"), core.MakeString("b")),
+		"checkbox": core.MakeName("Yes"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "ok", text.V.(*core.PdfObjectString).Decoded())
+	require.Equal(t, "Yes", checkbox.V.String())
+	indices, err := choice.GetContext().(*PdfFieldChoice).I.ToIntegerArray()
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1}, indices)
+
+	// Single values replace the selection indices.
+	err = fill(map[string]core.PdfObject{"choice": core.MakeString("b")})
+	require.NoError(t, err)
+	indices, err = choice.GetContext().(*PdfFieldChoice).I.ToIntegerArray()
+	require.NoError(t, err)
+	require.Equal(t, []int{1}, indices)
+}
+
+// countingAppearanceGenerator counts the appearances generated for each field.
+type countingAppearanceGenerator map[string]int
+
+func (g countingAppearanceGenerator) WrapContentStream(page *PdfPage) error {
+	return nil
+}
+
+func (g countingAppearanceGenerator) GenerateAppearanceDict(form *PdfAcroForm, field *PdfField,
+	wa *PdfAnnotationWidget) (*core.PdfObjectDictionary, error) {
+	g[field.PartialName()]++
+	return core.MakeDict(), nil
+}
+
+func TestFillBatchChoice(t *testing.T) {
+	newField := func(name string, ctx func(*PdfField) PdfModel) *PdfField {
+		field := NewPdfField()
+		field.SetContext(ctx(field))
+		field.T = core.MakeString(name)
+		field.Annotations = append(field.Annotations, NewPdfAnnotationWidget())
+		return field
+	}
+	text := newField("text", func(f *PdfField) PdfModel {
+		return &PdfFieldText{PdfField: f}
+	})
+	text.V = core.MakeString("template")
+	choice := newField("choice", func(f *PdfField) PdfModel {
+		return &PdfFieldChoice{PdfField: f, Opt: core.MakeArray(
+			core.MakeString("a"), core.MakeString("b"), core.MakeString("c"),
+		)}
+	})
+	choice.SetFlag(FieldFlagMultiSelect)
+	ch := choice.GetContext().(*PdfFieldChoice)
+
+	form := NewPdfAcroForm()
+	form.Fields = &[]*PdfField{text, choice}
+	reader := &PdfReader{AcroForm: form}
+
+	rows := []FieldValueProvider{
+		fieldValueMap{"text": core.MakeString("template"), "choice": core.MakeString("a")},
+		fieldValueMap{"choice": core.MakeArray(core.MakeString("b"), core.MakeString("c"))},
+		fieldValueMap{},
+	}
+	next := func() (FieldValueProvider, error) {
+		if len(rows) == 0 {
+			return nil, nil
+		}
+		row := rows[0]
+		rows = rows[1:]
+		return row, nil
+	}
+
+	appGen := countingAppearanceGenerator{}
+	var indices [][]int
+	err := reader.FillBatch(next, appGen, func(i int, w *PdfWriter) error {
+		form.NeedAppearances = core.MakeBool(true)
+		d, ok := core.GetDict(ch.ToPdfObject())
+		require.True(t, ok)
+		arr, ok := core.GetArray(d.Get("I"))
+		if !ok {
+			indices = append(indices, nil)
+			return nil
+		}
+		vals, err := arr.ToIntegerArray()
+		require.NoError(t, err)
+		indices = append(indices, vals)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// The selection of a row does not leak into the next rows.
+	require.Equal(t, [][]int{{0}, {1, 2}, nil}, indices)
+	require.Nil(t, ch.I)
+	require.Nil(t, form.NeedAppearances)
+
+	// The appearance of the text field, filled with the template value, is
+	// not regenerated.
+	require.Equal(t, countingAppearanceGenerator{"choice": 2}, appGen)
+}
+
+func TestAcroFormFillWithPartialNames(t *testing.T) {
+	newField := func(name string, parent *PdfField) *PdfField {
+		field := NewPdfField()
+		field.T = core.MakeString(name)
+		if parent != nil {
+			field.Parent = parent
+			parent.Kids = append(parent.Kids, field)
+		}
+		return field
+	}
+	newText := func(name string, parent *PdfField) *PdfField {
+		field := newField(name, parent)
+		field.SetContext(&PdfFieldText{PdfField: field})
+		return field
+	}
+
+	// person.address.city, person.myaddress.city, company.address.city and
+	// company.name.
+	person := newField("person", nil)
+	personCity := newText("city", newField("address", person))
+	personMyCity := newText("city", newField("myaddress", person))
+	company := newField("company", nil)
+	companyCity := newText("city", newField("address", company))
+	companyName := newText("name", company)
+
+	form := NewPdfAcroForm()
+	form.Fields = &[]*PdfField{person, company}
+
+	fill := func(values map[string]core.PdfObject) error {
+		return form.FillWithPartialNames(fieldValueMap(values), nil)
+	}
+	value := func(field *PdfField) string {
+		if field.V == nil {
+			return ""
+		}
+		return field.V.(*core.PdfObjectString).Decoded()
+	}
+
+	// Ambiguous names.
+	err := fill(map[string]core.PdfObject{"address.city": core.MakeString("Paris")})
+	require.Equal(t, &FieldNameError{
+		Name:    "address.city",
+		Matches: []string{"company.address.city", "person.address.city"},
+	}, err)
+	require.Equal(t, `ambiguous field name "address.city": matches fields ["company.address.city" "person.address.city"]`,
+		err.Error())
+
+	err = fill(map[string]core.PdfObject{"city": core.MakeString("Paris")})
+	require.Len(t, err.(*FieldNameError).Matches, 3)
+	require.Nil(t, personCity.V)
+
+	// Partial and full names.
+	err = fill(map[string]core.PdfObject{
+		"person.address.city": core.MakeString("Paris"),
+		"myaddress.city":      core.MakeString("Lyon"),
+		"name":                core.MakeString("ACME"),
+		"ress.city":           core.MakeString("ignored"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Paris", value(personCity))
+	require.Equal(t, "Lyon", value(personMyCity))
+	require.Equal(t, "", value(companyCity))
+	require.Equal(t, "ACME", value(companyName))
+
+	// The full name of a field wins over the partial names matching it,
+	// regardless of the iteration order of the values.
+	for i := 0; i < 20; i++ {
+		err = fill(map[string]core.PdfObject{
+			"company.name":          core.MakeString("Full"),
+			"name":                  core.MakeString("Partial"),
+			"person.myaddress.city": core.MakeString("Metz"),
+			"myaddress.city":        core.MakeString("Lille"),
+		})
+		require.NoError(t, err)
+		require.Equal(t, "Full", value(companyName))
+		require.Equal(t, "Metz", value(personMyCity))
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// XFADatasetsPacket is the name of the XFA packet containing the XML data of
+// the form.
+const XFADatasetsPacket = "datasets"
+
+// HasXFA returns true if the form contains an XFA (XML Forms Architecture)
+// form description, i.e. the document is a hybrid XFA/AcroForm document.
+// The XFA entry of the form is preserved when the document is written.
+func (form *PdfAcroForm) HasXFA() bool {
+	return form != nil && form.XFA != nil && !core.IsNullObject(core.ResolveReference(form.XFA))
+}
+
+// GetXFAPacketNames returns the names of the packets of the XFA form, in
+// the order in which they are specified. Returns nil if the form has no
+// XFA, or if the XFA is specified as a single stream.
+func (form *PdfAcroForm) GetXFAPacketNames() []string {
+	if !form.HasXFA() {
+		return nil
+	}
+	arr, ok := core.GetArray(form.XFA)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for i := 0; i+1 < arr.Len(); i += 2 {
+		name, ok := core.GetString(arr.Get(i))
+		if !ok {
+			continue
+		}
+		names = append(names, name.Decoded())
+	}
+	return names
+}
+
+// GetXFAPacket returns the decoded XML data of the XFA packet `name`
+// (e.g. "template" or "datasets"). If the XFA is specified as a single
+// stream, the whole XFA data is returned, regardless of `name`.
+// Returns nil if the form has no XFA, or if the packet is not found.
+func (form *PdfAcroForm) GetXFAPacket(name string) ([]byte, error) {
+	if !form.HasXFA() {
+		return nil, nil
+	}
+	if stream, ok := core.GetStream(form.XFA); ok {
+		return core.DecodeStream(stream)
+	}
+
+	arr, ok := core.GetArray(form.XFA)
+	if !ok {
+		common.Log.Debug("ERROR: XFA invalid (got %T)", form.XFA)
+		return nil, errors.New("invalid XFA entry")
+	}
+	idx := xfaPacketIndex(arr, name)
+	if idx < 0 {
+		return nil, nil
+	}
+	stream, ok := core.GetStream(arr.Get(idx + 1))
+	if !ok {
+		common.Log.Debug("ERROR: XFA packet %s is not a stream", name)
+		return nil, errors.New("invalid XFA packet")
+	}
+	return core.DecodeStream(stream)
+}
+
+// SetXFAPacket sets the XML data of the XFA packet `name` to `data`. If the
+// packet does not exist, it is added before the closing "postamble" packet,
+// or at the end of the packets if there is no postamble.
+// The XFA of the form must be specified as an array of packets.
+func (form *PdfAcroForm) SetXFAPacket(name string, data []byte) error {
+	if !form.HasXFA() {
+		return errors.New("form has no XFA")
+	}
+	arr, ok := core.GetArray(form.XFA)
+	if !ok {
+		common.Log.Debug("ERROR: unable to set XFA packet %s: XFA is not an array (got %T)", name, form.XFA)
+		return errors.New("XFA is not an array of packets")
+	}
+
+	stream, err := core.MakeStream(data, core.NewFlateEncoder())
+	if err != nil {
+		return err
+	}
+
+	if idx := xfaPacketIndex(arr, name); idx >= 0 {
+		return arr.Set(idx+1, stream)
+	}
+
+	elements := arr.Elements()
+	idx := xfaPacketIndex(arr, "postamble")
+	if idx < 0 {
+		idx = len(elements)
+	}
+	packets := append([]core.PdfObject{}, elements[:idx]...)
+	packets = append(packets, core.MakeString(name), stream)
+	packets = append(packets, elements[idx:]...)
+	arr.Clear()
+	arr.Append(packets...)
+	return nil
+}
+
+// GetXFADatasets returns the XML data of the datasets packet of the XFA
+// form, which contains the data of the form fields.
+// Returns nil if the form has no XFA datasets.
+func (form *PdfAcroForm) GetXFADatasets() ([]byte, error) {
+	return form.GetXFAPacket(XFADatasetsPacket)
+}
+
+// SetXFADatasets sets the XML data of the datasets packet of the XFA form.
+func (form *PdfAcroForm) SetXFADatasets(data []byte) error {
+	return form.SetXFAPacket(XFADatasetsPacket, data)
+}
+
+// xfaPacketIndex returns the index of the name of the packet `name` in the
+// XFA packet array `arr`, or -1 if not found.
+func xfaPacketIndex(arr *core.PdfObjectArray, name string) int {
+	for i := 0; i+1 < arr.Len(); i += 2 {
+		if str, ok := core.GetString(arr.Get(i)); ok && str.Decoded() == name {
+			return i
+		}
+	}
+	return -1
+}
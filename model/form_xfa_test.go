@@ -0,0 +1,85 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+func TestFormXFA(t *testing.T) {
+	xfa := core.MakeArray()
+	for _, packet := range []string{"preamble", "template", "datasets", "postamble"} {
+		stream, err := core.MakeStream([]byte("<"+packet+"/>"), core.NewFlateEncoder())
+		require.NoError(t, err)
+		xfa.Append(core.MakeString(packet), stream)
+	}
+
+	field := NewPdfField()
+	field.SetContext(&PdfFieldText{PdfField: field})
+	field.T = core.MakeString("name")
+	form := NewPdfAcroForm()
+	form.Fields = &[]*PdfField{field}
+	form.XFA = xfa
+
+	page := NewPdfPage()
+	page.MediaBox = &PdfRectangle{Urx: 200, Ury: 200}
+	w := NewPdfWriter()
+	require.NoError(t, w.AddPage(page))
+	require.NoError(t, w.SetForms(form))
+	reader := writeAndReload(t, &w)
+
+	// The XFA is preserved.
+	form = reader.AcroForm
+	require.True(t, form.HasXFA())
+	require.Equal(t, []string{"preamble", "template", "datasets", "postamble"}, form.GetXFAPacketNames())
+	data, err := form.GetXFADatasets()
+	require.NoError(t, err)
+	require.Equal(t, "<datasets/>", string(data))
+	data, err = form.GetXFAPacket("config")
+	require.NoError(t, err)
+	require.Nil(t, data)
+
+	// Update the datasets and add a packet through an incremental update.
+	require.NoError(t, form.SetXFADatasets([]byte("<datasets><name>value</name></datasets>")))
+	require.NoError(t, form.SetXFAPacket("config", []byte("<config/>")))
+	appender, err := NewPdfAppender(reader)
+	require.NoError(t, err)
+	appender.ReplaceAcroForm(form)
+
+	var buf bytes.Buffer
+	require.NoError(t, appender.Write(&buf))
+	reader, err = NewPdfReaderLazy(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+
+	form = reader.AcroForm
+	require.Equal(t, []string{"preamble", "template", "datasets", "config", "postamble"}, form.GetXFAPacketNames())
+	data, err = form.GetXFADatasets()
+	require.NoError(t, err)
+	require.Equal(t, "<datasets><name>value</name></datasets>", string(data))
+	data, err = form.GetXFAPacket("template")
+	require.NoError(t, err)
+	require.Equal(t, "<template/>", string(data))
+
+	// Single stream XFA.
+	stream, err := core.MakeStream([]byte("<xdp/>"), nil)
+	require.NoError(t, err)
+	form = NewPdfAcroForm()
+	form.XFA = stream
+	data, err = form.GetXFADatasets()
+	require.NoError(t, err)
+	require.Equal(t, "<xdp/>", string(data))
+	require.Nil(t, form.GetXFAPacketNames())
+	require.Error(t, form.SetXFADatasets(nil))
+
+	form = NewPdfAcroForm()
+	require.False(t, form.HasXFA())
+	require.Error(t, form.SetXFADatasets(nil))
+}
@@ -18,6 +18,13 @@ type Font interface {
 	GetRuneMetrics(r rune) (CharMetrics, bool)
 }
 
+// Kerner is implemented by fonts which provide kerning information.
+type Kerner interface {
+	// GetKerning returns the kerning adjustment between the glyphs of the
+	// `left` and `right` runes, in glyph space units.
+	GetKerning(left, right rune) (float64, bool)
+}
+
 // CharMetrics represents width and height metrics of a glyph.
 type CharMetrics struct {
 	Wx float64
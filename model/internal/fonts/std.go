@@ -68,12 +68,14 @@ func RegisterStdFont(name StdFontName, fnc func() StdFont, aliases ...StdFontNam
 }
 
 var _ Font = StdFont{}
+var _ Kerner = StdFont{}
 
 // StdFont represents one of the built-in fonts and it is assumed that every reader has access to it.
 type StdFont struct {
 	desc    Descriptor
 	metrics map[rune]CharMetrics
 	encoder textencoding.TextEncoder
+	kerning *kernTable
 }
 
 // NewStdFont returns a new instance of the font with a default encoder set (StandardEncoding).
@@ -118,6 +120,18 @@ func (font StdFont) GetMetricsTable() map[rune]CharMetrics {
 	return font.metrics
 }
 
+// GetKerning returns the kerning adjustment between the glyphs of the `left`
+// and `right` runes, in glyph space units (1/1000 of text space). A negative
+// value moves the glyphs closer together. Returns false if the font does not
+// specify a kerning adjustment for the pair.
+func (font StdFont) GetKerning(left, right rune) (float64, bool) {
+	if font.kerning == nil {
+		return 0, false
+	}
+	kern, ok := font.kerning.table()[KernPair{Left: left, Right: right}]
+	return kern, ok
+}
+
 // Descriptor returns a font descriptor.
 func (font StdFont) Descriptor() Descriptor {
 	return font.desc
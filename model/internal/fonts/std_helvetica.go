@@ -49,7 +49,9 @@ func newFontHelvetica() StdFont {
 		StemV:       88,
 		StemH:       76,
 	}
-	return NewStdFont(desc, helveticaCharMetrics)
+	font := NewStdFont(desc, helveticaCharMetrics)
+	font.kerning = helveticaKerning
+	return font
 }
 
 // newFontHelveticaBold returns a new instance of the font with a default encoder set
@@ -70,7 +72,9 @@ func newFontHelveticaBold() StdFont {
 		StemV:       140,
 		StemH:       118,
 	}
-	return NewStdFont(desc, helveticaBoldCharMetrics)
+	font := NewStdFont(desc, helveticaBoldCharMetrics)
+	font.kerning = helveticaBoldKerning
+	return font
 }
 
 // newFontHelveticaOblique returns a new instance of the font with a default encoder set (WinAnsiEncoding).
@@ -90,7 +94,9 @@ func newFontHelveticaOblique() StdFont {
 		StemV:       88,
 		StemH:       76,
 	}
-	return NewStdFont(desc, helveticaObliqueCharMetrics)
+	font := NewStdFont(desc, helveticaObliqueCharMetrics)
+	font.kerning = helveticaKerning
+	return font
 }
 
 // newFontHelveticaBoldOblique returns a new instance of the font with a default encoder set (WinAnsiEncoding).
@@ -110,7 +116,9 @@ func newFontHelveticaBoldOblique() StdFont {
 		StemV:       140,
 		StemH:       118,
 	}
-	return NewStdFont(desc, helveticaBoldObliqueCharMetrics)
+	font := NewStdFont(desc, helveticaBoldObliqueCharMetrics)
+	font.kerning = helveticaBoldKerning
+	return font
 }
 
 var helveticaOnce sync.Once
@@ -0,0 +1,2413 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+/*
+ * The embedded kerning pairs specified in this file are distributed under the terms listed in
+ * ./testdata/afms/MustRead.html.
+ */
+
+package fonts
+
+import "sync"
+
+// KernPair represents a pair of adjacent runes, for which a kerning
+// adjustment may be specified.
+type KernPair struct {
+	Left  rune
+	Right rune
+}
+
+// kernTable is a lazily unpacked table of kerning pairs.
+type kernTable struct {
+	once   sync.Once
+	packed []int16
+	pairs  map[KernPair]float64
+}
+
+// newKernTable returns a new kerning table for the specified packed
+// (left, right, kerning) triples. The left and right values are indices
+// into type1CommonRunes.
+func newKernTable(packed []int16) *kernTable {
+	return &kernTable{packed: packed}
+}
+
+// table returns the unpacked kerning pairs of the table.
+func (t *kernTable) table() map[KernPair]float64 {
+	t.once.Do(func() {
+		t.pairs = make(map[KernPair]float64, len(t.packed)/3)
+		for i := 0; i+2 < len(t.packed); i += 3 {
+			pair := KernPair{
+				Left:  type1CommonRunes[t.packed[i]],
+				Right: type1CommonRunes[t.packed[i+1]],
+			}
+			t.pairs[pair] = float64(t.packed[i+2])
+		}
+	})
+	return t.pairs
+}
+
+// The kerning tables of the standard fonts. The oblique variants of the
+// Helvetica fonts share the kerning pairs of the upright variants.
+var (
+	helveticaKerning       = newKernTable(helveticaKernPairs)
+	helveticaBoldKerning   = newKernTable(helveticaBoldKernPairs)
+	timesRomanKerning      = newKernTable(timesRomanKernPairs)
+	timesBoldKerning       = newKernTable(timesBoldKernPairs)
+	timesItalicKerning     = newKernTable(timesItalicKernPairs)
+	timesBoldItalicKerning = newKernTable(timesBoldItalicKernPairs)
+)
+
+// helveticaKernPairs are the kerning pairs loaded from afms/Helvetica.afm, stored as
+// (left, right, kerning) triples where left and right are indices into
+// type1CommonRunes.
+// See afms/MustRead.html for license information.
+var helveticaKernPairs = []int16{
+	0, 12, -30, 0, 13, -30, 0, 14, -30, 0, 15, -30, 0, 32, -30, 0, 33, -30,
+	0, 34, -30, 0, 58, -30, 0, 60, -30, 0, 61, -30, 0, 62, -30, 0, 63, -30,
+	0, 64, -30, 0, 65, -30, 0, 66, -30, 0, 67, -30, 0, 69, -30, 0, 79, -120,
+	0, 80, -120, 0, 81, -120, 0, 83, -50, 0, 84, -50, 0, 85, -50, 0, 86, -50,
+	0, 87, -50, 0, 88, -50, 0, 89, -50, 0, 90, -50, 0, 91, -50, 0, 92, -70,
+	0, 93, -50, 0, 95, -100, 0, 96, -100, 0, 97, -100, 0, 293, -30, 0, 294, -30,
+	0, 295, -30, 0, 296, -30, 0, 297, -30, 0, 298, -30, 0, 299, -30, 0, 301, -30,
+	0, 302, -30, 0, 303, -40, 0, 304, -40, 0, 306, -40, 0, 307, -40, 0, 308, -40,
+	2, 12, -30, 2, 13, -30, 2, 14, -30, 2, 15, -30, 2, 32, -30, 2, 33, -30,
+	2, 34, -30, 2, 58, -30, 2, 60, -30, 2, 61, -30, 2, 62, -30, 2, 63, -30,
+	2, 64, -30, 2, 65, -30, 2, 66, -30, 2, 67, -30, 2, 69, -30, 2, 79, -120,
+	2, 80, -120, 2, 81, -120, 2, 83, -50, 2, 84, -50, 2, 85, -50, 2, 86, -50,
+	2, 87, -50, 2, 88, -50, 2, 89, -50, 2, 90, -50, 2, 91, -50, 2, 92, -70,
+	2, 93, -50, 2, 95, -100, 2, 96, -100, 2, 97, -100, 2, 293, -30, 2, 294, -30,
+	2, 295, -30, 2, 296, -30, 2, 297, -30, 2, 298, -30, 2, 299, -30, 2, 301, -30,
+	2, 302, -30, 2, 303, -40, 2, 304, -40, 2, 306, -40, 2, 307, -40, 2, 308, -40,
+	3, 12, -30, 3, 13, -30, 3, 14, -30, 3, 15, -30, 3, 32, -30, 3, 33, -30,
+	3, 34, -30, 3, 58, -30, 3, 60, -30, 3, 61, -30, 3, 62, -30, 3, 63, -30,
+	3, 64, -30, 3, 65, -30, 3, 66, -30, 3, 67, -30, 3, 69, -30, 3, 79, -120,
+	3, 80, -120, 3, 81, -120, 3, 83, -50, 3, 84, -50, 3, 85, -50, 3, 86, -50,
+	3, 87, -50, 3, 88, -50, 3, 89, -50, 3, 90, -50, 3, 91, -50, 3, 92, -70,
+	3, 93, -50, 3, 95, -100, 3, 96, -100, 3, 97, -100, 3, 293, -30, 3, 294, -30,
+	3, 295, -30, 3, 296, -30, 3, 297, -30, 3, 298, -30, 3, 299, -30, 3, 301, -30,
+	3, 302, -30, 3, 303, -40, 3, 304, -40, 3, 306, -40, 3, 307, -40, 3, 308, -40,
+	4, 12, -30, 4, 13, -30, 4, 14, -30, 4, 15, -30, 4, 32, -30, 4, 33, -30,
+	4, 34, -30, 4, 58, -30, 4, 60, -30, 4, 61, -30, 4, 62, -30, 4, 63, -30,
+	4, 64, -30, 4, 65, -30, 4, 66, -30, 4, 67, -30, 4, 69, -30, 4, 79, -120,
+	4, 80, -120, 4, 81, -120, 4, 83, -50, 4, 84, -50, 4, 85, -50, 4, 86, -50,
+	4, 87, -50, 4, 88, -50, 4, 89, -50, 4, 90, -50, 4, 91, -50, 4, 92, -70,
+	4, 93, -50, 4, 95, -100, 4, 96, -100, 4, 97, -100, 4, 293, -30, 4, 294, -30,
+	4, 295, -30, 4, 296, -30, 4, 297, -30, 4, 298, -30, 4, 299, -30, 4, 301, -30,
+	4, 302, -30, 4, 303, -40, 4, 304, -40, 4, 306, -40, 4, 307, -40, 4, 308, -40,
+	5, 12, -30, 5, 13, -30, 5, 14, -30, 5, 15, -30, 5, 32, -30, 5, 33, -30,
+	5, 34, -30, 5, 58, -30, 5, 60, -30, 5, 61, -30, 5, 62, -30, 5, 63, -30,
+	5, 64, -30, 5, 65, -30, 5, 66, -30, 5, 67, -30, 5, 69, -30, 5, 79, -120,
+	5, 80, -120, 5, 81, -120, 5, 83, -50, 5, 84, -50, 5, 85, -50, 5, 86, -50,
+	5, 87, -50, 5, 88, -50, 5, 89, -50, 5, 90, -50, 5, 91, -50, 5, 92, -70,
+	5, 93, -50, 5, 95, -100, 5, 96, -100, 5, 97, -100, 5, 293, -30, 5, 294, -30,
+	5, 295, -30, 5, 296, -30, 5, 297, -30, 5, 298, -30, 5, 299, -30, 5, 301, -30,
+	5, 302, -30, 5, 303, -40, 5, 304, -40, 5, 306, -40, 5, 307, -40, 5, 308, -40,
+	6, 12, -30, 6, 13, -30, 6, 14, -30, 6, 15, -30, 6, 32, -30, 6, 33, -30,
+	6, 34, -30, 6, 58, -30, 6, 60, -30, 6, 61, -30, 6, 62, -30, 6, 63, -30,
+	6, 64, -30, 6, 65, -30, 6, 66, -30, 6, 67, -30, 6, 69, -30, 6, 79, -120,
+	6, 80, -120, 6, 81, -120, 6, 83, -50, 6, 84, -50, 6, 85, -50, 6, 86, -50,
+	6, 87, -50, 6, 88, -50, 6, 89, -50, 6, 90, -50, 6, 91, -50, 6, 92, -70,
+	6, 93, -50, 6, 95, -100, 6, 96, -100, 6, 97, -100, 6, 293, -30, 6, 294, -30,
+	6, 295, -30, 6, 296, -30, 6, 297, -30, 6, 298, -30, 6, 299, -30, 6, 301, -30,
+	6, 302, -30, 6, 303, -40, 6, 304, -40, 6, 306, -40, 6, 307, -40, 6, 308, -40,
+	7, 12, -30, 7, 13, -30, 7, 14, -30, 7, 15, -30, 7, 32, -30, 7, 33, -30,
+	7, 34, -30, 7, 58, -30, 7, 60, -30, 7, 61, -30, 7, 62, -30, 7, 63, -30,
+	7, 64, -30, 7, 65, -30, 7, 66, -30, 7, 67, -30, 7, 69, -30, 7, 79, -120,
+	7, 80, -120, 7, 81, -120, 7, 83, -50, 7, 84, -50, 7, 85, -50, 7, 86, -50,
+	7, 87, -50, 7, 88, -50, 7, 89, -50, 7, 90, -50, 7, 91, -50, 7, 92, -70,
+	7, 93, -50, 7, 95, -100, 7, 96, -100, 7, 97, -100, 7, 293, -30, 7, 294, -30,
+	7, 295, -30, 7, 296, -30, 7, 297, -30, 7, 298, -30, 7, 299, -30, 7, 301, -30,
+	7, 302, -30, 7, 303, -40, 7, 304, -40, 7, 306, -40, 7, 307, -40, 7, 308, -40,
+	8, 12, -30, 8, 13, -30, 8, 14, -30, 8, 15, -30, 8, 32, -30, 8, 33, -30,
+	8, 34, -30, 8, 58, -30, 8, 60, -30, 8, 61, -30, 8, 62, -30, 8, 63, -30,
+	8, 64, -30, 8, 65, -30, 8, 66, -30, 8, 67, -30, 8, 69, -30, 8, 79, -120,
+	8, 80, -120, 8, 81, -120, 8, 83, -50, 8, 84, -50, 8, 85, -50, 8, 86, -50,
+	8, 87, -50, 8, 88, -50, 8, 89, -50, 8, 90, -50, 8, 91, -50, 8, 92, -70,
+	8, 93, -50, 8, 95, -100, 8, 96, -100, 8, 97, -100, 8, 293, -30, 8, 294, -30,
+	8, 295, -30, 8, 296, -30, 8, 297, -30, 8, 298, -30, 8, 299, -30, 8, 301, -30,
+	8, 302, -30, 8, 303, -40, 8, 304, -40, 8, 306, -40, 8, 307, -40, 8, 308, -40,
+	9, 12, -30, 9, 13, -30, 9, 14, -30, 9, 15, -30, 9, 32, -30, 9, 33, -30,
+	9, 34, -30, 9, 58, -30, 9, 60, -30, 9, 61, -30, 9, 62, -30, 9, 63, -30,
+	9, 64, -30, 9, 65, -30, 9, 66, -30, 9, 67, -30, 9, 69, -30, 9, 79, -120,
+	9, 80, -120, 9, 81, -120, 9, 83, -50, 9, 84, -50, 9, 85, -50, 9, 86, -50,
+	9, 87, -50, 9, 88, -50, 9, 89, -50, 9, 90, -50, 9, 91, -50, 9, 92, -70,
+	9, 93, -50, 9, 95, -100, 9, 96, -100, 9, 97, -100, 9, 293, -30, 9, 294, -30,
+	9, 295, -30, 9, 296, -30, 9, 297, -30, 9, 298, -30, 9, 299, -30, 9, 301, -30,
+	9, 302, -30, 9, 303, -40, 9, 304, -40, 9, 306, -40, 9, 307, -40, 9, 308, -40,
+	10, 12, -30, 10, 13, -30, 10, 14, -30, 10, 15, -30, 10, 32, -30, 10, 33, -30,
+	10, 34, -30, 10, 58, -30, 10, 60, -30, 10, 61, -30, 10, 62, -30, 10, 63, -30,
+	10, 64, -30, 10, 65, -30, 10, 66, -30, 10, 67, -30, 10, 69, -30, 10, 79, -120,
+	10, 80, -120, 10, 81, -120, 10, 83, -50, 10, 84, -50, 10, 85, -50, 10, 86, -50,
+	10, 87, -50, 10, 88, -50, 10, 89, -50, 10, 90, -50, 10, 91, -50, 10, 92, -70,
+	10, 93, -50, 10, 95, -100, 10, 96, -100, 10, 97, -100, 10, 293, -30, 10, 294, -30,
+	10, 295, -30, 10, 296, -30, 10, 297, -30, 10, 298, -30, 10, 299, -30, 10, 301, -30,
+	10, 302, -30, 10, 303, -40, 10, 304, -40, 10, 306, -40, 10, 307, -40, 10, 308, -40,
+	11, 83, -10, 11, 84, -10, 11, 85, -10, 11, 86, -10, 11, 87, -10, 11, 88, -10,
+	11, 89, -10, 11, 90, -10, 11, 91, -10, 11, 138, -20, 11, 246, -20, 12, 138, -30,
+	12, 246, -30, 13, 138, -30, 13, 246, -30, 14, 138, -30, 14, 246, -30, 15, 138, -30,
+	15, 246, -30, 16, 0, -40, 16, 2, -40, 16, 3, -40, 16, 4, -40, 16, 5, -40,
+	16, 6, -40, 16, 7, -40, 16, 8, -40, 16, 9, -40, 16, 10, -40, 16, 92, -70,
+	16, 93, -40, 16, 95, -90, 16, 96, -90, 16, 97, -90, 16, 138, -70, 16, 246, -70,
+	17, 0, -40, 17, 2, -40, 17, 3, -40, 17, 4, -40, 17, 5, -40, 17, 6, -40,
+	17, 7, -40, 17, 8, -40, 17, 9, -40, 17, 10, -40, 17, 92, -70, 17, 93, -40,
+	17, 95, -90, 17, 96, -90, 17, 97, -90, 17, 138, -70, 17, 246, -70, 18, 0, -40,
+	18, 2, -40, 18, 3, -40, 18, 4, -40, 18, 5, -40, 18, 6, -40, 18, 7, -40,
+	18, 8, -40, 18, 9, -40, 18, 10, -40, 18, 92, -70, 18, 93, -40, 18, 95, -90,
+	18, 96, -90, 18, 97, -90, 18, 138, -70, 18, 246, -70, 31, 0, -80, 31, 2, -80,
+	31, 3, -80, 31, 4, -80, 31, 5, -80, 31, 6, -80, 31, 7, -80, 31, 8, -80,
+	31, 9, -80, 31, 10, -80, 31, 102, -50, 31, 103, -50, 31, 104, -50, 31, 105, -50,
+	31, 107, -50, 31, 109, -50, 31, 110, -50, 31, 112, -50, 31, 113, -50, 31, 118, -50,
+	31, 138, -150, 31, 153, -30, 31, 154, -30, 31, 155, -30, 31, 156, -30, 31, 157, -30,
+	31, 158, -30, 31, 159, -30, 31, 162, -30, 31, 165, -30, 31, 223, -30, 31, 224, -30,
+	31, 225, -30, 31, 226, -30, 31, 229, -30, 31, 230, -30, 31, 231, -30, 31, 238, -30,
+	31, 239, -30, 31, 246, -150, 31, 262, -45, 31, 263, -45, 31, 265, -45, 31, 266, -45,
+	44, 0, -20, 44, 2, -20, 44, 3, -20, 44, 4, -20, 44, 5, -20, 44, 6, -20,
+	44, 7, -20, 44, 8, -20, 44, 9, -20, 44, 10, -20, 44, 102, -20, 44, 103, -20,
+	44, 104, -20, 44, 105, -20, 44, 107, -20, 44, 109, -20, 44, 110, -20, 44, 112, -20,
+	44, 113, -20, 44, 118, -20, 44, 138, -30, 44, 246, -30, 44, 293, -20, 44, 294, -20,
+	44, 295, -20, 44, 296, -20, 44, 297, -20, 44, 298, -20, 44, 299, -20, 44, 301, -20,
+	44, 302, -20, 45, 58, -50, 45, 60, -50, 45, 61, -50, 45, 62, -50, 45, 63, -50,
+	45, 64, -50, 45, 65, -50, 45, 66, -50, 45, 67, -50, 45, 153, -40, 45, 154, -40,
+	45, 155, -40, 45, 156, -40, 45, 157, -40, 45, 158, -40, 45, 159, -40, 45, 162, -40,
+	45, 165, -40, 45, 223, -40, 45, 224, -40, 45, 225, -40, 45, 226, -40, 45, 229, -40,
+	45, 230, -40, 45, 231, -40, 45, 238, -40, 45, 239, -40, 45, 293, -30, 45, 294, -30,
+	45, 295, -30, 45, 296, -30, 45, 297, -30, 45, 298, -30, 45, 299, -30, 45, 301, -30,
+	45, 302, -30, 45, 306, -50, 45, 307, -50, 45, 308, -50, 46, 58, -50, 46, 60, -50,
+	46, 61, -50, 46, 62, -50, 46, 63, -50, 46, 64, -50, 46, 65, -50, 46, 66, -50,
+	46, 67, -50, 46, 153, -40, 46, 154, -40, 46, 155, -40, 46, 156, -40, 46, 157, -40,
+	46, 158, -40, 46, 159, -40, 46, 162, -40, 46, 165, -40, 46, 223, -40, 46, 224, -40,
+	46, 225, -40, 46, 226, -40, 46, 229, -40, 46, 230, -40, 46, 231, -40, 46, 238, -40,
+	46, 239, -40, 46, 293, -30, 46, 294, -30, 46, 295, -30, 46, 296, -30, 46, 297, -30,
+	46, 298, -30, 46, 299, -30, 46, 301, -30, 46, 302, -30, 46, 306, -50, 46, 307, -50,
+	46, 308, -50, 47, 79, -110, 47, 80, -110, 47, 81, -110, 47, 92, -110, 47, 93, -70,
+	47, 95, -140, 47, 96, -140, 47, 97, -140, 47, 257, -140, 47, 259, -160, 47, 306, -30,
+	47, 307, -30, 47, 308, -30, 48, 79, -110, 48, 80, -110, 48, 81, -110, 48, 92, -110,
+	48, 93, -70, 48, 95, -140, 48, 96, -140, 48, 97, -140, 48, 257, -140, 48, 259, -160,
+	48, 306, -30, 48, 307, -30, 48, 308, -30, 49, 79, -110, 49, 80, -110, 49, 81, -110,
+	49, 92, -110, 49, 93, -70, 49, 95, -140, 49, 96, -140, 49, 97, -140, 49, 257, -140,
+	49, 259, -160, 49, 306, -30, 49, 307, -30, 49, 308, -30, 50, 79, -110, 50, 80, -110,
+	50, 81, -110, 50, 92, -110, 50, 93, -70, 50, 95, -140, 50, 96, -140, 50, 97, -140,
+	50, 257, -140, 50, 259, -160, 50, 306, -30, 50, 307, -30, 50, 308, -30, 51, 79, -110,
+	51, 80, -110, 51, 81, -110, 51, 92, -110, 51, 93, -70, 51, 95, -140, 51, 96, -140,
+	51, 97, -140, 51, 257, -140, 51, 259, -160, 51, 306, -30, 51, 307, -30, 51, 308, -30,
+	58, 0, -20, 58, 2, -20, 58, 3, -20, 58, 4, -20, 58, 5, -20, 58, 6, -20,
+	58, 7, -20, 58, 8, -20, 58, 9, -20, 58, 10, -20, 58, 79, -40, 58, 80, -40,
+	58, 81, -40, 58, 92, -50, 58, 93, -30, 58, 94, -60, 58, 95, -70, 58, 96, -70,
+	58, 97, -70, 58, 138, -40, 58, 246, -40, 60, 0, -20, 60, 2, -20, 60, 3, -20,
+	60, 4, -20, 60, 5, -20, 60, 6, -20, 60, 7, -20, 60, 8, -20, 60, 9, -20,
+	60, 10, -20, 60, 79, -40, 60, 80, -40, 60, 81, -40, 60, 92, -50, 60, 93, -30,
+	60, 94, -60, 60, 95, -70, 60, 96, -70, 60, 97, -70, 60, 138, -40, 60, 246, -40,
+	61, 0, -20, 61, 2, -20, 61, 3, -20, 61, 4, -20, 61, 5, -20, 61, 6, -20,
+	61, 7, -20, 61, 8, -20, 61, 9, -20, 61, 10, -20, 61, 79, -40, 61, 80, -40,
+	61, 81, -40, 61, 92, -50, 61, 93, -30, 61, 94, -60, 61, 95, -70, 61, 96, -70,
+	61, 97, -70, 61, 138, -40, 61, 246, -40, 62, 0, -20, 62, 2, -20, 62, 3, -20,
+	62, 4, -20, 62, 5, -20, 62, 6, -20, 62, 7, -20, 62, 8, -20, 62, 9, -20,
+	62, 10, -20, 62, 79, -40, 62, 80, -40, 62, 81, -40, 62, 92, -50, 62, 93, -30,
+	62, 94, -60, 62, 95, -70, 62, 96, -70, 62, 97, -70, 62, 138, -40, 62, 246, -40,
+	63, 0, -20, 63, 2, -20, 63, 3, -20, 63, 4, -20, 63, 5, -20, 63, 6, -20,
+	63, 7, -20, 63, 8, -20, 63, 9, -20, 63, 10, -20, 63, 79, -40, 63, 80, -40,
+	63, 81, -40, 63, 92, -50, 63, 93, -30, 63, 94, -60, 63, 95, -70, 63, 96, -70,
+	63, 97, -70, 63, 138, -40, 63, 246, -40, 64, 0, -20, 64, 2, -20, 64, 3, -20,
+	64, 4, -20, 64, 5, -20, 64, 6, -20, 64, 7, -20, 64, 8, -20, 64, 9, -20,
+	64, 10, -20, 64, 79, -40, 64, 80, -40, 64, 81, -40, 64, 92, -50, 64, 93, -30,
+	64, 94, -60, 64, 95, -70, 64, 96, -70, 64, 97, -70, 64, 138, -40, 64, 246, -40,
+	65, 0, -20, 65, 2, -20, 65, 3, -20, 65, 4, -20, 65, 5, -20, 65, 6, -20,
+	65, 7, -20, 65, 8, -20, 65, 9, -20, 65, 10, -20, 65, 79, -40, 65, 80, -40,
+	65, 81, -40, 65, 92, -50, 65, 93, -30, 65, 94, -60, 65, 95, -70, 65, 96, -70,
+	65, 97, -70, 65, 138, -40, 65, 246, -40, 66, 0, -20, 66, 2, -20, 66, 3, -20,
+	66, 4, -20, 66, 5, -20, 66, 6, -20, 66, 7, -20, 66, 8, -20, 66, 9, -20,
+	66, 10, -20, 66, 79, -40, 66, 80, -40, 66, 81, -40, 66, 92, -50, 66, 93, -30,
+	66, 94, -60, 66, 95, -70, 66, 96, -70, 66, 97, -70, 66, 138, -40, 66, 246, -40,
+	67, 0, -20, 67, 2, -20, 67, 3, -20, 67, 4, -20, 67, 5, -20, 67, 6, -20,
+	67, 7, -20, 67, 8, -20, 67, 9, -20, 67, 10, -20, 67, 79, -40, 67, 80, -40,
+	67, 81, -40, 67, 92, -50, 67, 93, -30, 67, 94, -60, 67, 95, -70, 67, 96, -70,
+	67, 97, -70, 67, 138, -40, 67, 246, -40, 68, 0, -120, 68, 2, -120, 68, 3, -120,
+	68, 4, -120, 68, 5, -120, 68, 6, -120, 68, 7, -120, 68, 8, -120, 68, 9, -120,
+	68, 10, -120, 68, 102, -40, 68, 103, -40, 68, 104, -40, 68, 105, -40, 68, 107, -40,
+	68, 109, -40, 68, 110, -40, 68, 112, -40, 68, 113, -40, 68, 118, -40, 68, 138, -180,
+	68, 153, -50, 68, 154, -50, 68, 155, -50, 68, 156, -50, 68, 157, -50, 68, 158, -50,
+	68, 159, -50, 68, 162, -50, 68, 165, -50, 68, 223, -50, 68, 224, -50, 68, 225, -50,
+	68, 226, -50, 68, 229, -50, 68, 230, -50, 68, 231, -50, 68, 238, -50, 68, 239, -50,
+	68, 246, -180, 69, 83, -10, 69, 84, -10, 69, 85, -10, 69, 86, -10, 69, 87, -10,
+	69, 88, -10, 69, 89, -10, 69, 90, -10, 69, 91, -10, 70, 58, -20, 70, 60, -20,
+	70, 61, -20, 70, 62, -20, 70, 63, -20, 70, 64, -20, 70, 65, -20, 70, 66, -20,
+	70, 67, -20, 70, 79, -30, 70, 80, -30, 70, 81, -30, 70, 83, -40, 70, 84, -40,
+	70, 85, -40, 70, 86, -40, 70, 87, -40, 70, 88, -40, 70, 89, -40, 70, 90, -40,
+	70, 91, -40, 70, 92, -50, 70, 93, -30, 70, 95, -50, 70, 96, -50, 70, 97, -50,
+	71, 58, -20, 71, 60, -20, 71, 61, -20, 71, 62, -20, 71, 63, -20, 71, 64, -20,
+	71, 65, -20, 71, 66, -20, 71, 67, -20, 71, 79, -30, 71, 80, -30, 71, 81, -30,
+	71, 83, -40, 71, 84, -40, 71, 85, -40, 71, 86, -40, 71, 87, -40, 71, 88, -40,
+	71, 89, -40, 71, 90, -40, 71, 91, -40, 71, 92, -50, 71, 93, -30, 71, 95, -50,
+	71, 96, -50, 71, 97, -50, 72, 58, -20, 72, 60, -20, 72, 61, -20, 72, 62, -20,
+	72, 63, -20, 72, 64, -20, 72, 65, -20, 72, 66, -20, 72, 67, -20, 72, 79, -30,
+	72, 80, -30, 72, 81, -30, 72, 83, -40, 72, 84, -40, 72, 85, -40, 72, 86, -40,
+	72, 87, -40, 72, 88, -40, 72, 89, -40, 72, 90, -40, 72, 91, -40, 72, 92, -50,
+	72, 93, -30, 72, 95, -50, 72, 96, -50, 72, 97, -50, 73, 58, -20, 73, 60, -20,
+	73, 61, -20, 73, 62, -20, 73, 63, -20, 73, 64, -20, 73, 65, -20, 73, 66, -20,
+	73, 67, -20, 73, 79, -30, 73, 80, -30, 73, 81, -30, 73, 83, -40, 73, 84, -40,
+	73, 85, -40, 73, 86, -40, 73, 87, -40, 73, 88, -40, 73, 89, -40, 73, 90, -40,
+	73, 91, -40, 73, 92, -50, 73, 93, -30, 73, 95, -50, 73, 96, -50, 73, 97, -50,
+	74, 138, -20, 74, 246, -20, 75, 138, -20, 75, 246, -20, 76, 138, -20, 76, 246, -20,
+	77, 138, -20, 77, 246, -20, 78, 138, -20, 78, 246, -20, 79, 0, -120, 79, 2, -120,
+	79, 3, -120, 79, 4, -120, 79, 5, -120, 79, 6, -120, 79, 7, -120, 79, 8, -120,
+	79, 9, -120, 79, 10, -120, 79, 58, -40, 79, 60, -40, 79, 61, -40, 79, 62, -40,
+	79, 63, -40, 79, 64, -40, 79, 65, -40, 79, 66, -40, 79, 67, -40, 79, 102, -120,
+	79, 103, -120, 79, 104, -60, 79, 105, -120, 79, 107, -120, 79, 109, -120, 79, 110, -60,
+	79, 112, -120, 79, 113, -120, 79, 118, -60, 79, 137, -20, 79, 138, -120, 79, 153, -120,
+	79, 154, -120, 79, 155, -120, 79, 156, -120, 79, 157, -120, 79, 158, -120, 79, 159, -60,
+	79, 162, -60, 79, 165, -120, 79, 190, -140, 79, 223, -120, 79, 224, -120, 79, 225, -120,
+	79, 226, -120, 79, 229, -120, 79, 230, -120, 79, 231, -60, 79, 238, -120, 79, 239, -60,
+	79, 246, -120, 79, 262, -120, 79, 263, -120, 79, 265, -120, 79, 266, -120, 79, 275, -20,
+	79, 293, -120, 79, 294, -120, 79, 295, -120, 79, 296, -120, 79, 297, -120, 79, 298, -120,
+	79, 299, -60, 79, 301, -120, 79, 302, -120, 79, 304, -120, 79, 306, -120, 79, 307, -120,
+	79, 308, -60, 80, 0, -120, 80, 2, -120, 80, 3, -120, 80, 4, -120, 80, 5, -120,
+	80, 6, -120, 80, 7, -120, 80, 8, -120, 80, 9, -120, 80, 10, -120, 80, 58, -40,
+	80, 60, -40, 80, 61, -40, 80, 62, -40, 80, 63, -40, 80, 64, -40, 80, 65, -40,
+	80, 66, -40, 80, 67, -40, 80, 102, -120, 80, 103, -120, 80, 104, -60, 80, 105, -120,
+	80, 107, -120, 80, 109, -120, 80, 110, -60, 80, 112, -120, 80, 113, -120, 80, 118, -60,
+	80, 137, -20, 80, 138, -120, 80, 153, -120, 80, 154, -120, 80, 155, -120, 80, 156, -120,
+	80, 157, -120, 80, 158, -120, 80, 159, -60, 80, 162, -60, 80, 165, -120, 80, 190, -140,
+	80, 223, -120, 80, 224, -120, 80, 225, -120, 80, 226, -120, 80, 229, -120, 80, 230, -120,
+	80, 231, -60, 80, 238, -120, 80, 239, -60, 80, 246, -120, 80, 262, -120, 80, 263, -120,
+	80, 265, -120, 80, 266, -120, 80, 275, -20, 80, 293, -120, 80, 294, -120, 80, 295, -120,
+	80, 296, -120, 80, 297, -120, 80, 298, -120, 80, 299, -60, 80, 301, -120, 80, 302, -120,
+	80, 304, -120, 80, 306, -120, 80, 307, -120, 80, 308, -60, 81, 0, -120, 81, 2, -120,
+	81, 3, -120, 81, 4, -120, 81, 5, -120, 81, 6, -120, 81, 7, -120, 81, 8, -120,
+	81, 9, -120, 81, 10, -120, 81, 58, -40, 81, 60, -40, 81, 61, -40, 81, 62, -40,
+	81, 63, -40, 81, 64, -40, 81, 65, -40, 81, 66, -40, 81, 67, -40, 81, 102, -120,
+	81, 103, -120, 81, 104, -60, 81, 105, -120, 81, 107, -120, 81, 109, -120, 81, 110, -60,
+	81, 112, -120, 81, 113, -120, 81, 118, -60, 81, 137, -20, 81, 138, -120, 81, 153, -120,
+	81, 154, -120, 81, 155, -120, 81, 156, -120, 81, 157, -120, 81, 158, -120, 81, 159, -60,
+	81, 162, -60, 81, 165, -120, 81, 190, -140, 81, 223, -120, 81, 224, -120, 81, 225, -120,
+	81, 226, -120, 81, 229, -120, 81, 230, -120, 81, 231, -60, 81, 238, -120, 81, 239, -60,
+	81, 246, -120, 81, 262, -120, 81, 263, -120, 81, 265, -120, 81, 266, -120, 81, 275, -20,
+	81, 293, -120, 81, 294, -120, 81, 295, -120, 81, 296, -120, 81, 297, -120, 81, 298, -120,
+	81, 299, -60, 81, 301, -120, 81, 302, -120, 81, 304, -120, 81, 306, -120, 81, 307, -120,
+	81, 308, -60, 83, 0, -40, 83, 2, -40, 83, 3, -40, 83, 4, -40, 83, 5, -40,
+	83, 6, -40, 83, 7, -40, 83, 8, -40, 83, 9, -40, 83, 10, -40, 83, 138, -40,
+	83, 246, -40, 84, 0, -40, 84, 2, -40, 84, 3, -40, 84, 4, -40, 84, 5, -40,
+	84, 6, -40, 84, 7, -40, 84, 8, -40, 84, 9, -40, 84, 10, -40, 84, 138, -40,
+	84, 246, -40, 85, 0, -40, 85, 2, -40, 85, 3, -40, 85, 4, -40, 85, 5, -40,
+	85, 6, -40, 85, 7, -40, 85, 8, -40, 85, 9, -40, 85, 10, -40, 85, 138, -40,
+	85, 246, -40, 86, 0, -40, 86, 2, -40, 86, 3, -40, 86, 4, -40, 86, 5, -40,
+	86, 6, -40, 86, 7, -40, 86, 8, -40, 86, 9, -40, 86, 10, -40, 86, 138, -40,
+	86, 246, -40, 87, 0, -40, 87, 2, -40, 87, 3, -40, 87, 4, -40, 87, 5, -40,
+	87, 6, -40, 87, 7, -40, 87, 8, -40, 87, 9, -40, 87, 10, -40, 87, 138, -40,
+	87, 246, -40, 88, 0, -40, 88, 2, -40, 88, 3, -40, 88, 4, -40, 88, 5, -40,
+	88, 6, -40, 88, 7, -40, 88, 8, -40, 88, 9, -40, 88, 10, -40, 88, 138, -40,
+	88, 246, -40, 89, 0, -40, 89, 2, -40, 89, 3, -40, 89, 4, -40, 89, 5, -40,
+	89, 6, -40, 89, 7, -40, 89, 8, -40, 89, 9, -40, 89, 10, -40, 89, 138, -40,
+	89, 246, -40, 90, 0, -40, 90, 2, -40, 90, 3, -40, 90, 4, -40, 90, 5, -40,
+	90, 6, -40, 90, 7, -40, 90, 8, -40, 90, 9, -40, 90, 10, -40, 90, 138, -40,
+	90, 246, -40, 91, 0, -40, 91, 2, -40, 91, 3, -40, 91, 4, -40, 91, 5, -40,
+	91, 6, -40, 91, 7, -40, 91, 8, -40, 91, 9, -40, 91, 10, -40, 91, 138, -40,
+	91, 246, -40, 92, 0, -80, 92, 2, -80, 92, 3, -80, 92, 4, -80, 92, 5, -80,
+	92, 6, -80, 92, 7, -80, 92, 8, -80, 92, 9, -80, 92, 10, -80, 92, 32, -40,
+	92, 33, -40, 92, 34, -40, 92, 58, -40, 92, 60, -40, 92, 61, -40, 92, 62, -40,
+	92, 63, -40, 92, 64, -40, 92, 65, -40, 92, 66, -40, 92, 67, -40, 92, 102, -70,
+	92, 103, -70, 92, 104, -70, 92, 105, -70, 92, 107, -70, 92, 109, -70, 92, 110, -70,
+	92, 112, -70, 92, 113, -70, 92, 118, -70, 92, 137, -40, 92, 138, -125, 92, 153, -80,
+	92, 154, -80, 92, 155, -80, 92, 156, -80, 92, 157, -80, 92, 158, -80, 92, 159, -80,
+	92, 162, -80, 92, 165, -80, 92, 190, -80, 92, 223, -80, 92, 224, -80, 92, 225, -80,
+	92, 226, -80, 92, 229, -80, 92, 230, -80, 92, 231, -80, 92, 238, -80, 92, 239, -80,
+	92, 246, -125, 92, 275, -40, 92, 293, -70, 92, 294, -70, 92, 295, -70, 92, 296, -70,
+	92, 297, -70, 92, 298, -70, 92, 299, -70, 92, 301, -70, 92, 302, -70, 93, 0, -50,
+	93, 2, -50, 93, 3, -50, 93, 4, -50, 93, 5, -50, 93, 6, -50, 93, 7, -50,
+	93, 8, -50, 93, 9, -50, 93, 10, -50, 93, 58, -20, 93, 60, -20, 93, 61, -20,
+	93, 62, -20, 93, 63, -20, 93, 64, -20, 93, 65, -20, 93, 66, -20, 93, 67, -20,
+	93, 102, -40, 93, 103, -40, 93, 104, -40, 93, 105, -40, 93, 107, -40, 93, 109, -40,
+	93, 110, -40, 93, 112, -40, 93, 113, -40, 93, 118, -40, 93, 138, -80, 93, 153, -30,
+	93, 154, -30, 93, 155, -30, 93, 156, -30, 93, 157, -30, 93, 158, -30, 93, 159, -30,
+	93, 162, -30, 93, 165, -30, 93, 190, -40, 93, 223, -30, 93, 224, -30, 93, 225, -30,
+	93, 226, -30, 93, 229, -30, 93, 230, -30, 93, 231, -30, 93, 238, -30, 93, 239, -30,
+	93, 246, -80, 93, 293, -30, 93, 294, -30, 93, 295, -30, 93, 296, -30, 93, 297, -30,
+	93, 298, -30, 93, 299, -30, 93, 301, -30, 93, 302, -30, 93, 306, -20, 93, 307, -20,
+	93, 308, -20, 95, 0, -110, 95, 2, -110, 95, 3, -110, 95, 4, -110, 95, 5, -110,
+	95, 6, -110, 95, 7, -110, 95, 8, -110, 95, 9, -110, 95, 10, -110, 95, 58, -85,
+	95, 60, -85, 95, 61, -85, 95, 62, -85, 95, 63, -85, 95, 64, -85, 95, 65, -85,
+	95, 66, -85, 95, 67, -85, 95, 102, -140, 95, 103, -140, 95, 104, -70, 95, 105, -140,
+	95, 107, -140, 95, 109, -140, 95, 110, -70, 95, 112, -140, 95, 113, -140, 95, 118, -140,
+	95, 137, -60, 95, 138, -140, 95, 153, -140, 95, 154, -140, 95, 155, -140, 95, 156, -140,
+	95, 157, -140, 95, 158, -140, 95, 159, -140, 95, 162, -70, 95, 165, -140, 95, 190, -140,
+	95, 191, -20, 95, 192, -20, 95, 197, -20, 95, 223, -140, 95, 224, -140, 95, 225, -140,
+	95, 226, -140, 95, 229, -140, 95, 230, -140, 95, 231, -140, 95, 238, -140, 95, 239, -140,
+	95, 246, -140, 95, 275, -60, 95, 293, -110, 95, 294, -110, 95, 295, -110, 95, 296, -110,
+	95, 297, -110, 95, 298, -110, 95, 299, -110, 95, 301, -110, 95, 302, -110, 96, 0, -110,
+	96, 2, -110, 96, 3, -110, 96, 4, -110, 96, 5, -110, 96, 6, -110, 96, 7, -110,
+	96, 8, -110, 96, 9, -110, 96, 10, -110, 96, 58, -85, 96, 60, -85, 96, 61, -85,
+	96, 62, -85, 96, 63, -85, 96, 64, -85, 96, 65, -85, 96, 66, -85, 96, 67, -85,
+	96, 102, -140, 96, 103, -140, 96, 104, -70, 96, 105, -140, 96, 107, -140, 96, 109, -140,
+	96, 110, -70, 96, 112, -140, 96, 113, -140, 96, 118, -70, 96, 137, -60, 96, 138, -140,
+	96, 153, -140, 96, 154, -140, 96, 155, -140, 96, 156, -140, 96, 157, -140, 96, 158, -140,
+	96, 159, -140, 96, 162, -70, 96, 165, -140, 96, 190, -140, 96, 191, -20, 96, 192, -20,
+	96, 197, -20, 96, 223, -140, 96, 224, -140, 96, 225, -140, 96, 226, -140, 96, 229, -140,
+	96, 230, -140, 96, 231, -70, 96, 238, -140, 96, 239, -140, 96, 246, -140, 96, 275, -60,
+	96, 293, -110, 96, 294, -110, 96, 295, -110, 96, 296, -110, 96, 297, -110, 96, 298, -110,
+	96, 299, -110, 96, 301, -110, 96, 302, -110, 97, 0, -110, 97, 2, -110, 97, 3, -110,
+	97, 4, -110, 97, 5, -110, 97, 6, -110, 97, 7, -110, 97, 8, -110, 97, 9, -110,
+	97, 10, -110, 97, 58, -85, 97, 60, -85, 97, 61, -85, 97, 62, -85, 97, 63, -85,
+	97, 64, -85, 97, 65, -85, 97, 66, -85, 97, 67, -85, 97, 102, -140, 97, 103, -140,
+	97, 104, -70, 97, 105, -140, 97, 107, -140, 97, 109, -140, 97, 110, -70, 97, 112, -140,
+	97, 113, -140, 97, 118, -70, 97, 137, -60, 97, 138, -140, 97, 153, -140, 97, 154, -140,
+	97, 155, -140, 97, 156, -140, 97, 157, -140, 97, 158, -140, 97, 159, -140, 97, 162, -70,
+	97, 165, -140, 97, 190, -140, 97, 191, -20, 97, 192, -20, 97, 197, -20, 97, 223, -140,
+	97, 224, -140, 97, 225, -140, 97, 226, -140, 97, 229, -140, 97, 230, -140, 97, 231, -140,
+	97, 238, -140, 97, 239, -140, 97, 246, -140, 97, 275, -60, 97, 293, -110, 97, 294, -110,
+	97, 295, -110, 97, 296, -110, 97, 297, -110, 97, 298, -110, 97, 299, -110, 97, 301, -110,
+	97, 302, -110, 102, 303, -20, 102, 304, -20, 102, 306, -30, 102, 307, -30, 102, 308, -30,
+	103, 303, -20, 103, 304, -20, 103, 306, -30, 103, 307, -30, 103, 308, -30, 104, 303, -20,
+	104, 304, -20, 104, 306, -30, 104, 307, -30, 104, 308, -30, 105, 303, -20, 105, 304, -20,
+	105, 306, -30, 105, 307, -30, 105, 308, -30, 107, 303, -20, 107, 304, -20, 107, 306, -30,
+	107, 307, -30, 107, 308, -30, 109, 303, -20, 109, 304, -20, 109, 306, -30, 109, 307, -30,
+	109, 308, -30, 110, 303, -20, 110, 304, -20, 110, 306, -30, 110, 307, -30, 110, 308, -30,
+	112, 303, -20, 112, 304, -20, 112, 306, -30, 112, 307, -30, 112, 308, -30, 113, 303, -20,
+	113, 304, -20, 113, 306, -30, 113, 307, -30, 113, 308, -30, 118, 303, -20, 118, 304, -20,
+	118, 306, -30, 118, 307, -30, 118, 308, -30, 119, 119, -10, 119, 138, -40, 119, 201, -20,
+	119, 202, -20, 119, 204, -20, 119, 209, -20, 119, 246, -40, 119, 293, -20, 119, 294, -20,
+	119, 295, -20, 119, 296, -20, 119, 297, -20, 119, 298, -20, 119, 299, -20, 119, 301, -20,
+	119, 302, -20, 119, 303, -20, 119, 306, -20, 119, 307, -20, 119, 308, -20, 129, 138, -15,
+	129, 199, -20, 129, 200, -20, 130, 138, -15, 130, 199, -20, 130, 200, -20, 132, 138, -15,
+	132, 199, -20, 132, 200, -20, 133, 138, -15, 133, 199, -20, 133, 200, -20, 137, 279, -50,
+	138, 257, -100, 138, 259, -100, 153, 138, -15, 153, 246, -15, 153, 303, -30, 153, 304, -20,
+	153, 305, -30, 153, 306, -20, 153, 307, -20, 153, 308, -20, 154, 138, -15, 154, 246, -15,
+	154, 303, -30, 154, 304, -20, 154, 305, -30, 154, 306, -20, 154, 307, -20, 154, 308, -20,
+	155, 138, -15, 155, 246, -15, 155, 303, -30, 155, 304, -20, 155, 305, -30, 155, 306, -20,
+	155, 307, -20, 155, 308, -20, 156, 138, -15, 156, 246, -15, 156, 303, -30, 156, 304, -20,
+	156, 305, -30, 156, 306, -20, 156, 307, -20, 156, 308, -20, 157, 138, -15, 157, 246, -15,
+	157, 303, -30, 157, 304, -20, 157, 305, -30, 157, 306, -20, 157, 307, -20, 157, 308, -20,
+	158, 138, -15, 158, 246, -15, 158, 303, -30, 158, 304, -20, 158, 305, -30, 158, 306, -20,
+	158, 307, -20, 158, 308, -20, 159, 138, -15, 159, 246, -15, 159, 303, -30, 159, 304, -20,
+	159, 305, -30, 159, 306, -20, 159, 307, -20, 159, 308, -20, 162, 138, -15, 162, 246, -15,
+	162, 303, -30, 162, 304, -20, 162, 305, -30, 162, 306, -20, 162, 307, -20, 162, 308, -20,
+	165, 138, -15, 165, 246, -15, 165, 303, -30, 165, 304, -20, 165, 305, -30, 165, 306, -20,
+	165, 307, -20, 165, 308, -20, 170, 102, -30, 170, 103, -30, 170, 104, -30, 170, 105, -30,
+	170, 107, -30, 170, 109, -30, 170, 110, -30, 170, 112, -30, 170, 113, -30, 170, 118, -30,
+	170, 138, -30, 170, 152, -28, 170, 153, -30, 170, 154, -30, 170, 155, -30, 170, 156, -30,
+	170, 157, -30, 170, 158, -30, 170, 159, -30, 170, 162, -30, 170, 165, -30, 170, 223, -30,
+	170, 224, -30, 170, 225, -30, 170, 226, -30, 170, 229, -30, 170, 230, -30, 170, 231, -30,
+	170, 238, -30, 170, 239, -30, 170, 246, -30, 170, 257, 60, 170, 259, 50, 177, 262, -10,
+	177, 263, -10, 177, 265, -10, 177, 266, -10, 178, 262, -10, 178, 263, -10, 178, 265, -10,
+	178, 266, -10, 179, 262, -10, 179, 263, -10, 179, 265, -10, 179, 266, -10, 188, 306, -30,
+	188, 307, -30, 188, 308, -30, 199, 153, -20, 199, 154, -20, 199, 155, -20, 199, 156, -20,
+	199, 157, -20, 199, 158, -20, 199, 159, -20, 199, 162, -20, 199, 165, -20, 199, 223, -20,
+	199, 224, -20, 199, 225, -20, 199, 226, -20, 199, 229, -20, 199, 230, -20, 199, 231, -20,
+	199, 238, -20, 199, 239, -20, 200, 153, -20, 200, 154, -20, 200, 155, -20, 200, 156, -20,
+	200, 157, -20, 200, 158, -20, 200, 159, -20, 200, 162, -20, 200, 165, -20, 200, 223, -20,
+	200, 224, -20, 200, 225, -20, 200, 226, -20, 200, 229, -20, 200, 230, -20, 200, 231, -20,
+	200, 238, -20, 200, 239, -20, 210, 293, -10, 210, 294, -10, 210, 295, -10, 210, 296, -10,
+	210, 297, -10, 210, 298, -10, 210, 299, -10, 210, 301, -10, 210, 302, -10, 210, 306, -15,
+	210, 307, -15, 210, 308, -15, 215, 293, -10, 215, 294, -10, 215, 295, -10, 215, 296, -10,
+	215, 297, -10, 215, 298, -10, 215, 299, -10, 215, 301, -10, 215, 302, -10, 215, 303, -20,
+	215, 306, -15, 215, 307, -15, 215, 308, -15, 216, 293, -10, 216, 294, -10, 216, 295, -10,
+	216, 296, -10, 216, 297, -10, 216, 298, -10, 216, 299, -10, 216, 301, -10, 216, 302, -10,
+	216, 303, -20, 216, 306, -15, 216, 307, -15, 216, 308, -15, 217, 293, -10, 217, 294, -10,
+	217, 295, -10, 217, 296, -10, 217, 297, -10, 217, 298, -10, 217, 299, -10, 217, 301, -10,
+	217, 302, -10, 217, 303, -20, 217, 306, -15, 217, 307, -15, 217, 308, -15, 218, 293, -10,
+	218, 294, -10, 218, 295, -10, 218, 296, -10, 218, 297, -10, 218, 298, -10, 218, 299, -10,
+	218, 301, -10, 218, 302, -10, 218, 303, -20, 218, 306, -15, 218, 307, -15, 218, 308, -15,
+	221, 293, -10, 221, 294, -10, 221, 295, -10, 221, 296, -10, 221, 297, -10, 221, 298, -10,
+	221, 299, -10, 221, 301, -10, 221, 302, -10, 221, 303, -20, 221, 306, -15, 221, 307, -15,
+	221, 308, -15, 223, 138, -40, 223, 246, -40, 223, 303, -15, 223, 304, -15, 223, 305, -30,
+	223, 306, -30, 223, 307, -30, 223, 308, -30, 224, 138, -40, 224, 246, -40, 224, 303, -15,
+	224, 304, -15, 224, 305, -30, 224, 306, -30, 224, 307, -30, 224, 308, -30, 225, 138, -40,
+	225, 246, -40, 225, 303, -15, 225, 304, -15, 225, 305, -30, 225, 306, -30, 225, 307, -30,
+	225, 308, -30, 226, 138, -40, 226, 246, -40, 226, 303, -15, 226, 304, -15, 226, 305, -30,
+	226, 306, -30, 226, 307, -30, 226, 308, -30, 229, 138, -40, 229, 246, -40, 229, 303, -15,
+	229, 304, -15, 229, 305, -30, 229, 306, -30, 229, 307, -30, 229, 308, -30, 230, 138, -40,
+	230, 246, -40, 230, 303, -15, 230, 304, -15, 230, 305, -30, 230, 306, -30, 230, 307, -30,
+	230, 308, -30, 231, 138, -40, 231, 246, -40, 231, 303, -15, 231, 304, -15, 231, 305, -30,
+	231, 306, -30, 231, 307, -30, 231, 308, -30, 238, 102, -55, 238, 103, -55, 238, 104, -55,
+	238, 105, -55, 238, 107, -55, 238, 109, -55, 238, 110, -55, 238, 112, -55, 238, 113, -55,
+	238, 118, -55, 238, 119, -55, 238, 129, -55, 238, 130, -55, 238, 132, -55, 238, 133, -55,
+	238, 138, -95, 238, 142, -55, 238, 146, -55, 238, 153, -55, 238, 154, -55, 238, 155, -55,
+	238, 156, -55, 238, 157, -55, 238, 158, -55, 238, 159, -55, 238, 162, -55, 238, 165, -55,
+	238, 170, -55, 238, 177, -55, 238, 178, -55, 238, 179, -55, 238, 188, -55, 238, 191, -55,
+	238, 192, -55, 238, 193, -55, 238, 194, -55, 238, 195, -55, 238, 196, -55, 238, 197, -55,
+	238, 198, -55, 238, 199, -55, 238, 200, -55, 238, 201, -55, 238, 202, -55, 238, 204, -55,
+	238, 209, -55, 238, 210, -55, 238, 215, -55, 238, 216, -55, 238, 217, -55, 238, 218, -55,
+	238, 221, -55, 238, 223, -55, 238, 224, -55, 238, 225, -55, 238, 226, -55, 238, 229, -55,
+	238, 230, -55, 238, 231, -55, 238, 238, -55, 238, 239, -55, 238, 240, -55, 238, 246, -95,
+	238, 251, -55, 238, 262, -55, 238, 263, -55, 238, 265, -55, 238, 266, -55, 238, 269, -55,
+	238, 270, -55, 238, 271, -55, 238, 272, -55, 238, 273, -55, 238, 282, -55, 238, 284, -55,
+	238, 293, -55, 238, 294, -55, 238, 295, -55, 238, 296, -55, 238, 297, -55, 238, 298, -55,
+	238, 299, -55, 238, 301, -55, 238, 302, -55, 238, 303, -70, 238, 304, -70, 238, 305, -85,
+	238, 306, -70, 238, 307, -70, 238, 308, -70, 238, 310, -55, 238, 311, -55, 238, 312, -55,
+	238, 313, -55, 239, 138, -40, 239, 246, -40, 239, 303, -15, 239, 304, -15, 239, 305, -30,
+	239, 306, -30, 239, 307, -30, 239, 308, -30, 240, 138, -35, 240, 246, -35, 240, 306, -30,
+	240, 307, -30, 240, 308, -30, 246, 257, -100, 246, 259, -100, 246, 279, -60, 257, 279, -40,
+	258, 258, -57, 259, 142, -50, 259, 146, -50, 259, 259, -57, 259, 262, -50, 259, 263, -50,
+	259, 265, -50, 259, 266, -50, 259, 269, -50, 259, 270, -50, 259, 271, -50, 259, 272, -50,
+	259, 273, -50, 259, 279, -70, 262, 102, -10, 262, 103, -10, 262, 104, -10, 262, 105, -10,
+	262, 107, -10, 262, 109, -10, 262, 110, -10, 262, 112, -10, 262, 113, -10, 262, 118, -10,
+	262, 137, 30, 262, 138, -50, 262, 191, 15, 262, 192, 15, 262, 193, 15, 262, 194, 15,
+	262, 195, 15, 262, 196, 15, 262, 197, 15, 262, 199, 15, 262, 200, 15, 262, 201, 15,
+	262, 202, 15, 262, 204, 15, 262, 209, 15, 262, 210, 25, 262, 215, 25, 262, 216, 25,
+	262, 217, 25, 262, 218, 25, 262, 221, 25, 262, 240, 30, 262, 246, -50, 262, 275, 30,
+	262, 282, 40, 262, 284, 40, 262, 293, 15, 262, 294, 15, 262, 295, 15, 262, 296, 15,
+	262, 297, 15, 262, 298, 15, 262, 299, 15, 262, 301, 15, 262, 302, 15, 262, 303, 30,
+	262, 306, 30, 262, 307, 30, 262, 308, 30, 263, 102, -10, 263, 103, -10, 263, 104, -10,
+	263, 105, -10, 263, 107, -10, 263, 109, -10, 263, 110, -10, 263, 112, -10, 263, 113, -10,
+	263, 118, -10, 263, 137, 30, 263, 138, -50, 263, 191, 15, 263, 192, 15, 263, 193, 15,
+	263, 194, 15, 263, 195, 15, 263, 196, 15, 263, 197, 15, 263, 199, 15, 263, 200, 15,
+	263, 201, 15, 263, 202, 15, 263, 204, 15, 263, 209, 15, 263, 210, 25, 263, 215, 25,
+	263, 216, 25, 263, 217, 25, 263, 218, 25, 263, 221, 25, 263, 240, 30, 263, 246, -50,
+	263, 275, 30, 263, 282, 40, 263, 284, 40, 263, 293, 15, 263, 294, 15, 263, 295, 15,
+	263, 296, 15, 263, 297, 15, 263, 298, 15, 263, 299, 15, 263, 301, 15, 263, 302, 15,
+	263, 303, 30, 263, 306, 30, 263, 307, 30, 263, 308, 30, 265, 102, -10, 265, 103, -10,
+	265, 104, -10, 265, 105, -10, 265, 107, -10, 265, 109, -10, 265, 110, -10, 265, 112, -10,
+	265, 113, -10, 265, 118, -10, 265, 137, 30, 265, 138, -50, 265, 191, 15, 265, 192, 15,
+	265, 193, 15, 265, 194, 15, 265, 195, 15, 265, 196, 15, 265, 197, 15, 265, 199, 15,
+	265, 200, 15, 265, 201, 15, 265, 202, 15, 265, 204, 15, 265, 209, 15, 265, 210, 25,
+	265, 215, 25, 265, 216, 25, 265, 217, 25, 265, 218, 25, 265, 221, 25, 265, 240, 30,
+	265, 246, -50, 265, 275, 30, 265, 282, 40, 265, 284, 40, 265, 293, 15, 265, 294, 15,
+	265, 295, 15, 265, 296, 15, 265, 297, 15, 265, 298, 15, 265, 299, 15, 265, 301, 15,
+	265, 302, 15, 265, 303, 30, 265, 306, 30, 265, 307, 30, 265, 308, 30, 266, 102, -10,
+	266, 103, -10, 266, 104, -10, 266, 105, -10, 266, 107, -10, 266, 109, -10, 266, 110, -10,
+	266, 112, -10, 266, 113, -10, 266, 118, -10, 266, 137, 30, 266, 138, -50, 266, 191, 15,
+	266, 192, 15, 266, 193, 15, 266, 194, 15, 266, 195, 15, 266, 196, 15, 266, 197, 15,
+	266, 199, 15, 266, 200, 15, 266, 201, 15, 266, 202, 15, 266, 204, 15, 266, 209, 15,
+	266, 210, 25, 266, 215, 25, 266, 216, 25, 266, 217, 25, 266, 218, 25, 266, 221, 25,
+	266, 240, 30, 266, 246, -50, 266, 275, 30, 266, 282, 40, 266, 284, 40, 266, 293, 15,
+	266, 294, 15, 266, 295, 15, 266, 296, 15, 266, 297, 15, 266, 298, 15, 266, 299, 15,
+	266, 301, 15, 266, 302, 15, 266, 303, 30, 266, 306, 30, 266, 307, 30, 266, 308, 30,
+	269, 138, -15, 269, 246, -15, 269, 304, -30, 270, 138, -15, 270, 246, -15, 270, 304, -30,
+	271, 138, -15, 271, 246, -15, 271, 304, -30, 272, 138, -15, 272, 246, -15, 272, 304, -30,
+	273, 138, -15, 273, 246, -15, 273, 304, -30, 275, 279, -50, 279, 79, -50, 279, 80, -50,
+	279, 81, -50, 279, 92, -50, 279, 93, -40, 279, 95, -90, 279, 96, -90, 279, 97, -90,
+	279, 256, -30, 279, 258, -60, 303, 102, -25, 303, 103, -25, 303, 104, -25, 303, 105, -25,
+	303, 107, -25, 303, 109, -25, 303, 110, -25, 303, 112, -25, 303, 113, -25, 303, 118, -25,
+	303, 138, -80, 303, 153, -25, 303, 154, -25, 303, 155, -25, 303, 156, -25, 303, 157, -25,
+	303, 158, -25, 303, 159, -25, 303, 162, -25, 303, 165, -25, 303, 223, -25, 303, 224, -25,
+	303, 225, -25, 303, 226, -25, 303, 229, -25, 303, 230, -25, 303, 231, -25, 303, 238, -25,
+	303, 239, -25, 303, 246, -80, 304, 102, -15, 304, 103, -15, 304, 104, -15, 304, 105, -15,
+	304, 107, -15, 304, 109, -15, 304, 110, -15, 304, 112, -15, 304, 113, -15, 304, 118, -15,
+	304, 138, -60, 304, 153, -10, 304, 154, -10, 304, 155, -10, 304, 156, -10, 304, 157, -10,
+	304, 158, -10, 304, 159, -10, 304, 162, -10, 304, 165, -10, 304, 223, -10, 304, 224, -10,
+	304, 225, -10, 304, 226, -10, 304, 229, -10, 304, 230, -10, 304, 231, -10, 304, 238, -10,
+	304, 239, -10, 304, 246, -60, 305, 153, -30, 305, 154, -30, 305, 155, -30, 305, 156, -30,
+	305, 157, -30, 305, 158, -30, 305, 159, -30, 305, 162, -30, 305, 165, -30, 306, 102, -20,
+	306, 103, -20, 306, 104, -20, 306, 105, -20, 306, 107, -20, 306, 109, -20, 306, 110, -20,
+	306, 112, -20, 306, 113, -20, 306, 118, -20, 306, 138, -100, 306, 153, -20, 306, 154, -20,
+	306, 155, -20, 306, 156, -20, 306, 157, -20, 306, 158, -20, 306, 159, -20, 306, 162, -20,
+	306, 165, -20, 306, 223, -20, 306, 224, -20, 306, 225, -20, 306, 226, -20, 306, 229, -20,
+	306, 230, -20, 306, 231, -20, 306, 238, -20, 306, 239, -20, 306, 246, -100, 307, 102, -20,
+	307, 103, -20, 307, 104, -20, 307, 105, -20, 307, 107, -20, 307, 109, -20, 307, 110, -20,
+	307, 112, -20, 307, 113, -20, 307, 118, -20, 307, 138, -100, 307, 153, -20, 307, 154, -20,
+	307, 155, -20, 307, 156, -20, 307, 157, -20, 307, 158, -20, 307, 159, -20, 307, 162, -20,
+	307, 165, -20, 307, 223, -20, 307, 224, -20, 307, 225, -20, 307, 226, -20, 307, 229, -20,
+	307, 230, -20, 307, 231, -20, 307, 238, -20, 307, 239, -20, 307, 246, -100, 308, 102, -20,
+	308, 103, -20, 308, 104, -20, 308, 105, -20, 308, 107, -20, 308, 109, -20, 308, 110, -20,
+	308, 112, -20, 308, 113, -20, 308, 118, -20, 308, 138, -100, 308, 153, -20, 308, 154, -20,
+	308, 155, -20, 308, 156, -20, 308, 157, -20, 308, 158, -20, 308, 159, -20, 308, 162, -20,
+	308, 165, -20, 308, 223, -20, 308, 224, -20, 308, 225, -20, 308, 226, -20, 308, 229, -20,
+	308, 230, -20, 308, 231, -20, 308, 238, -20, 308, 239, -20, 308, 246, -100, 310, 153, -15,
+	310, 154, -15, 310, 155, -15, 310, 156, -15, 310, 157, -15, 310, 158, -15, 310, 159, -15,
+	310, 162, -15, 310, 165, -15, 310, 223, -15, 310, 224, -15, 310, 225, -15, 310, 226, -15,
+	310, 229, -15, 310, 230, -15, 310, 231, -15, 310, 238, -15, 310, 239, -15, 311, 153, -15,
+	311, 154, -15, 311, 155, -15, 311, 156, -15, 311, 157, -15, 311, 158, -15, 311, 159, -15,
+	311, 162, -15, 311, 165, -15, 311, 223, -15, 311, 224, -15, 311, 225, -15, 311, 226, -15,
+	311, 229, -15, 311, 230, -15, 311, 231, -15, 311, 238, -15, 311, 239, -15, 312, 153, -15,
+	312, 154, -15, 312, 155, -15, 312, 156, -15, 312, 157, -15, 312, 158, -15, 312, 159, -15,
+	312, 162, -15, 312, 165, -15, 312, 223, -15, 312, 224, -15, 312, 225, -15, 312, 226, -15,
+	312, 229, -15, 312, 230, -15, 312, 231, -15, 312, 238, -15, 312, 239, -15, 313, 153, -15,
+	313, 154, -15, 313, 155, -15, 313, 156, -15, 313, 157, -15, 313, 158, -15, 313, 159, -15,
+	313, 162, -15, 313, 165, -15, 313, 223, -15, 313, 224, -15, 313, 225, -15, 313, 226, -15,
+	313, 229, -15, 313, 230, -15, 313, 231, -15, 313, 238, -15, 313, 239, -15,
+}
+
+// helveticaBoldKernPairs are the kerning pairs loaded from afms/Helvetica-Bold.afm, stored as
+// (left, right, kerning) triples where left and right are indices into
+// type1CommonRunes.
+// See afms/MustRead.html for license information.
+var helveticaBoldKernPairs = []int16{
+	0, 12, -40, 0, 13, -40, 0, 14, -40, 0, 15, -40, 0, 32, -50, 0, 33, -50,
+	0, 34, -50, 0, 58, -40, 0, 60, -40, 0, 61, -40, 0, 62, -40, 0, 63, -40,
+	0, 64, -40, 0, 65, -40, 0, 66, -40, 0, 67, -40, 0, 69, -40, 0, 79, -90,
+	0, 80, -90, 0, 81, -90, 0, 83, -50, 0, 84, -50, 0, 85, -50, 0, 86, -50,
+	0, 87, -50, 0, 88, -50, 0, 89, -50, 0, 90, -50, 0, 91, -50, 0, 92, -80,
+	0, 93, -60, 0, 95, -110, 0, 96, -110, 0, 97, -110, 0, 293, -30, 0, 294, -30,
+	0, 295, -30, 0, 296, -30, 0, 297, -30, 0, 298, -30, 0, 299, -30, 0, 301, -30,
+	0, 302, -30, 0, 303, -40, 0, 304, -30, 0, 306, -30, 0, 307, -30, 0, 308, -30,
+	2, 12, -40, 2, 13, -40, 2, 14, -40, 2, 15, -40, 2, 32, -50, 2, 33, -50,
+	2, 34, -50, 2, 58, -40, 2, 60, -40, 2, 61, -40, 2, 62, -40, 2, 63, -40,
+	2, 64, -40, 2, 65, -40, 2, 66, -40, 2, 67, -40, 2, 69, -40, 2, 79, -90,
+	2, 80, -90, 2, 81, -90, 2, 83, -50, 2, 84, -50, 2, 85, -50, 2, 86, -50,
+	2, 87, -50, 2, 88, -50, 2, 89, -50, 2, 90, -50, 2, 91, -50, 2, 92, -80,
+	2, 93, -60, 2, 95, -110, 2, 96, -110, 2, 97, -110, 2, 293, -30, 2, 294, -30,
+	2, 295, -30, 2, 296, -30, 2, 297, -30, 2, 298, -30, 2, 299, -30, 2, 301, -30,
+	2, 302, -30, 2, 303, -40, 2, 304, -30, 2, 306, -30, 2, 307, -30, 2, 308, -30,
+	3, 12, -40, 3, 13, -40, 3, 14, -40, 3, 15, -40, 3, 32, -50, 3, 33, -50,
+	3, 34, -50, 3, 58, -40, 3, 60, -40, 3, 61, -40, 3, 62, -40, 3, 63, -40,
+	3, 64, -40, 3, 65, -40, 3, 66, -40, 3, 67, -40, 3, 69, -40, 3, 79, -90,
+	3, 80, -90, 3, 81, -90, 3, 83, -50, 3, 84, -50, 3, 85, -50, 3, 86, -50,
+	3, 87, -50, 3, 88, -50, 3, 89, -50, 3, 90, -50, 3, 91, -50, 3, 92, -80,
+	3, 93, -60, 3, 95, -110, 3, 96, -110, 3, 97, -110, 3, 293, -30, 3, 294, -30,
+	3, 295, -30, 3, 296, -30, 3, 297, -30, 3, 298, -30, 3, 299, -30, 3, 301, -30,
+	3, 302, -30, 3, 303, -40, 3, 304, -30, 3, 306, -30, 3, 307, -30, 3, 308, -30,
+	4, 12, -40, 4, 13, -40, 4, 14, -40, 4, 15, -40, 4, 32, -50, 4, 33, -50,
+	4, 34, -50, 4, 58, -40, 4, 60, -40, 4, 61, -40, 4, 62, -40, 4, 63, -40,
+	4, 64, -40, 4, 65, -40, 4, 66, -40, 4, 67, -40, 4, 69, -40, 4, 79, -90,
+	4, 80, -90, 4, 81, -90, 4, 83, -50, 4, 84, -50, 4, 85, -50, 4, 86, -50,
+	4, 87, -50, 4, 88, -50, 4, 89, -50, 4, 90, -50, 4, 91, -50, 4, 92, -80,
+	4, 93, -60, 4, 95, -110, 4, 96, -110, 4, 97, -110, 4, 293, -30, 4, 294, -30,
+	4, 295, -30, 4, 296, -30, 4, 297, -30, 4, 298, -30, 4, 299, -30, 4, 301, -30,
+	4, 302, -30, 4, 303, -40, 4, 304, -30, 4, 306, -30, 4, 307, -30, 4, 308, -30,
+	5, 12, -40, 5, 13, -40, 5, 14, -40, 5, 15, -40, 5, 32, -50, 5, 33, -50,
+	5, 34, -50, 5, 58, -40, 5, 60, -40, 5, 61, -40, 5, 62, -40, 5, 63, -40,
+	5, 64, -40, 5, 65, -40, 5, 66, -40, 5, 67, -40, 5, 69, -40, 5, 79, -90,
+	5, 80, -90, 5, 81, -90, 5, 83, -50, 5, 84, -50, 5, 85, -50, 5, 86, -50,
+	5, 87, -50, 5, 88, -50, 5, 89, -50, 5, 90, -50, 5, 91, -50, 5, 92, -80,
+	5, 93, -60, 5, 95, -110, 5, 96, -110, 5, 97, -110, 5, 293, -30, 5, 294, -30,
+	5, 295, -30, 5, 296, -30, 5, 297, -30, 5, 298, -30, 5, 299, -30, 5, 301, -30,
+	5, 302, -30, 5, 303, -40, 5, 304, -30, 5, 306, -30, 5, 307, -30, 5, 308, -30,
+	6, 12, -40, 6, 13, -40, 6, 14, -40, 6, 15, -40, 6, 32, -50, 6, 33, -50,
+	6, 34, -50, 6, 58, -40, 6, 60, -40, 6, 61, -40, 6, 62, -40, 6, 63, -40,
+	6, 64, -40, 6, 65, -40, 6, 66, -40, 6, 67, -40, 6, 69, -40, 6, 79, -90,
+	6, 80, -90, 6, 81, -90, 6, 83, -50, 6, 84, -50, 6, 85, -50, 6, 86, -50,
+	6, 87, -50, 6, 88, -50, 6, 89, -50, 6, 90, -50, 6, 91, -50, 6, 92, -80,
+	6, 93, -60, 6, 95, -110, 6, 96, -110, 6, 97, -110, 6, 293, -30, 6, 294, -30,
+	6, 295, -30, 6, 296, -30, 6, 297, -30, 6, 298, -30, 6, 299, -30, 6, 301, -30,
+	6, 302, -30, 6, 303, -40, 6, 304, -30, 6, 306, -30, 6, 307, -30, 6, 308, -30,
+	7, 12, -40, 7, 13, -40, 7, 14, -40, 7, 15, -40, 7, 32, -50, 7, 33, -50,
+	7, 34, -50, 7, 58, -40, 7, 60, -40, 7, 61, -40, 7, 62, -40, 7, 63, -40,
+	7, 64, -40, 7, 65, -40, 7, 66, -40, 7, 67, -40, 7, 69, -40, 7, 79, -90,
+	7, 80, -90, 7, 81, -90, 7, 83, -50, 7, 84, -50, 7, 85, -50, 7, 86, -50,
+	7, 87, -50, 7, 88, -50, 7, 89, -50, 7, 90, -50, 7, 91, -50, 7, 92, -80,
+	7, 93, -60, 7, 95, -110, 7, 96, -110, 7, 97, -110, 7, 293, -30, 7, 294, -30,
+	7, 295, -30, 7, 296, -30, 7, 297, -30, 7, 298, -30, 7, 299, -30, 7, 301, -30,
+	7, 302, -30, 7, 303, -40, 7, 304, -30, 7, 306, -30, 7, 307, -30, 7, 308, -30,
+	8, 12, -40, 8, 13, -40, 8, 14, -40, 8, 15, -40, 8, 32, -50, 8, 33, -50,
+	8, 34, -50, 8, 58, -40, 8, 60, -40, 8, 61, -40, 8, 62, -40, 8, 63, -40,
+	8, 64, -40, 8, 65, -40, 8, 66, -40, 8, 67, -40, 8, 69, -40, 8, 79, -90,
+	8, 80, -90, 8, 81, -90, 8, 83, -50, 8, 84, -50, 8, 85, -50, 8, 86, -50,
+	8, 87, -50, 8, 88, -50, 8, 89, -50, 8, 90, -50, 8, 91, -50, 8, 92, -80,
+	8, 93, -60, 8, 95, -110, 8, 96, -110, 8, 97, -110, 8, 293, -30, 8, 294, -30,
+	8, 295, -30, 8, 296, -30, 8, 297, -30, 8, 298, -30, 8, 299, -30, 8, 301, -30,
+	8, 302, -30, 8, 303, -40, 8, 304, -30, 8, 306, -30, 8, 307, -30, 8, 308, -30,
+	9, 12, -40, 9, 13, -40, 9, 14, -40, 9, 15, -40, 9, 32, -50, 9, 33, -50,
+	9, 34, -50, 9, 58, -40, 9, 60, -40, 9, 61, -40, 9, 62, -40, 9, 63, -40,
+	9, 64, -40, 9, 65, -40, 9, 66, -40, 9, 67, -40, 9, 69, -40, 9, 79, -90,
+	9, 80, -90, 9, 81, -90, 9, 83, -50, 9, 84, -50, 9, 85, -50, 9, 86, -50,
+	9, 87, -50, 9, 88, -50, 9, 89, -50, 9, 90, -50, 9, 91, -50, 9, 92, -80,
+	9, 93, -60, 9, 95, -110, 9, 96, -110, 9, 97, -110, 9, 293, -30, 9, 294, -30,
+	9, 295, -30, 9, 296, -30, 9, 297, -30, 9, 298, -30, 9, 299, -30, 9, 301, -30,
+	9, 302, -30, 9, 303, -40, 9, 304, -30, 9, 306, -30, 9, 307, -30, 9, 308, -30,
+	10, 12, -40, 10, 13, -40, 10, 14, -40, 10, 15, -40, 10, 32, -50, 10, 33, -50,
+	10, 34, -50, 10, 58, -40, 10, 60, -40, 10, 61, -40, 10, 62, -40, 10, 63, -40,
+	10, 64, -40, 10, 65, -40, 10, 66, -40, 10, 67, -40, 10, 69, -40, 10, 79, -90,
+	10, 80, -90, 10, 81, -90, 10, 83, -50, 10, 84, -50, 10, 85, -50, 10, 86, -50,
+	10, 87, -50, 10, 88, -50, 10, 89, -50, 10, 90, -50, 10, 91, -50, 10, 92, -80,
+	10, 93, -60, 10, 95, -110, 10, 96, -110, 10, 97, -110, 10, 293, -30, 10, 294, -30,
+	10, 295, -30, 10, 296, -30, 10, 297, -30, 10, 298, -30, 10, 299, -30, 10, 301, -30,
+	10, 302, -30, 10, 303, -40, 10, 304, -30, 10, 306, -30, 10, 307, -30, 10, 308, -30,
+	11, 0, -30, 11, 2, -30, 11, 3, -30, 11, 4, -30, 11, 5, -30, 11, 6, -30,
+	11, 7, -30, 11, 8, -30, 11, 9, -30, 11, 10, -30, 11, 83, -10, 11, 84, -10,
+	11, 85, -10, 11, 86, -10, 11, 87, -10, 11, 88, -10, 11, 89, -10, 11, 90, -10,
+	11, 91, -10, 16, 0, -40, 16, 2, -40, 16, 3, -40, 16, 4, -40, 16, 5, -40,
+	16, 6, -40, 16, 7, -40, 16, 8, -40, 16, 9, -40, 16, 10, -40, 16, 92, -40,
+	16, 93, -40, 16, 95, -70, 16, 96, -70, 16, 97, -70, 16, 138, -30, 16, 246, -30,
+	17, 0, -40, 17, 2, -40, 17, 3, -40, 17, 4, -40, 17, 5, -40, 17, 6, -40,
+	17, 7, -40, 17, 8, -40, 17, 9, -40, 17, 10, -40, 17, 92, -40, 17, 93, -40,
+	17, 95, -70, 17, 96, -70, 17, 97, -70, 17, 138, -30, 17, 246, -30, 18, 0, -40,
+	18, 2, -40, 18, 3, -40, 18, 4, -40, 18, 5, -40, 18, 6, -40, 18, 7, -40,
+	18, 8, -40, 18, 9, -40, 18, 10, -40, 18, 92, -40, 18, 93, -40, 18, 95, -70,
+	18, 96, -70, 18, 97, -70, 18, 138, -30, 18, 246, -30, 31, 0, -80, 31, 2, -80,
+	31, 3, -80, 31, 4, -80, 31, 5, -80, 31, 6, -80, 31, 7, -80, 31, 8, -80,
+	31, 9, -80, 31, 10, -80, 31, 102, -20, 31, 103, -20, 31, 104, -20, 31, 105, -20,
+	31, 107, -20, 31, 109, -20, 31, 110, -20, 31, 112, -20, 31, 113, -20, 31, 118, -20,
+	31, 138, -100, 31, 246, -100, 44, 0, -20, 44, 2, -20, 44, 3, -20, 44, 4, -20,
+	44, 5, -20, 44, 6, -20, 44, 7, -20, 44, 8, -20, 44, 9, -20, 44, 10, -20,
+	44, 138, -20, 44, 246, -20, 44, 293, -20, 44, 294, -20, 44, 295, -20, 44, 296, -20,
+	44, 297, -20, 44, 298, -20, 44, 299, -20, 44, 301, -20, 44, 302, -20, 45, 58, -30,
+	45, 60, -30, 45, 61, -30, 45, 62, -30, 45, 63, -30, 45, 64, -30, 45, 65, -30,
+	45, 66, -30, 45, 67, -30, 45, 153, -15, 45, 154, -15, 45, 155, -15, 45, 156, -15,
+	45, 157, -15, 45, 158, -15, 45, 159, -15, 45, 162, -15, 45, 165, -15, 45, 223, -35,
+	45, 224, -35, 45, 225, -35, 45, 226, -35, 45, 229, -35, 45, 230, -35, 45, 231, -35,
+	45, 238, -35, 45, 239, -35, 45, 293, -30, 45, 294, -30, 45, 295, -30, 45, 296, -30,
+	45, 297, -30, 45, 298, -30, 45, 299, -30, 45, 301, -30, 45, 302, -30, 45, 306, -40,
+	45, 307, -40, 45, 308, -40, 46, 58, -30, 46, 60, -30, 46, 61, -30, 46, 62, -30,
+	46, 63, -30, 46, 64, -30, 46, 65, -30, 46, 66, -30, 46, 67, -30, 46, 153, -15,
+	46, 154, -15, 46, 155, -15, 46, 156, -15, 46, 157, -15, 46, 158, -15, 46, 159, -15,
+	46, 162, -15, 46, 165, -15, 46, 223, -35, 46, 224, -35, 46, 225, -35, 46, 226, -35,
+	46, 229, -35, 46, 230, -35, 46, 231, -35, 46, 238, -35, 46, 239, -35, 46, 293, -30,
+	46, 294, -30, 46, 295, -30, 46, 296, -30, 46, 297, -30, 46, 298, -30, 46, 299, -30,
+	46, 301, -30, 46, 302, -30, 46, 306, -40, 46, 307, -40, 46, 308, -40, 47, 79, -90,
+	47, 80, -90, 47, 81, -90, 47, 92, -110, 47, 93, -80, 47, 95, -120, 47, 96, -120,
+	47, 97, -120, 47, 257, -140, 47, 259, -140, 47, 306, -30, 47, 307, -30, 47, 308, -30,
+	48, 79, -90, 48, 80, -90, 48, 81, -90, 48, 92, -110, 48, 93, -80, 48, 95, -120,
+	48, 96, -120, 48, 97, -120, 48, 257, -140, 48, 259, -140, 48, 306, -30, 48, 307, -30,
+	48, 308, -30, 50, 79, -90, 50, 80, -90, 50, 81, -90, 50, 92, -110, 50, 93, -80,
+	50, 95, -120, 50, 96, -120, 50, 97, -120, 50, 257, -140, 50, 259, -140, 50, 306, -30,
+	50, 307, -30, 50, 308, -30, 51, 79, -90, 51, 80, -90, 51, 81, -90, 51, 92, -110,
+	51, 93, -80, 51, 95, -120, 51, 96, -120, 51, 97, -120, 51, 257, -140, 51, 259, -140,
+	51, 306, -30, 51, 307, -30, 51, 308, -30, 58, 0, -50, 58, 2, -50, 58, 3, -50,
+	58, 4, -50, 58, 5, -50, 58, 6, -50, 58, 7, -50, 58, 8, -50, 58, 9, -50,
+	58, 10, -50, 58, 79, -40, 58, 80, -40, 58, 81, -40, 58, 92, -50, 58, 93, -50,
+	58, 94, -50, 58, 95, -70, 58, 96, -70, 58, 97, -70, 58, 138, -40, 58, 246, -40,
+	60, 0, -50, 60, 2, -50, 60, 3, -50, 60, 4, -50, 60, 5, -50, 60, 6, -50,
+	60, 7, -50, 60, 8, -50, 60, 9, -50, 60, 10, -50, 60, 79, -40, 60, 80, -40,
+	60, 81, -40, 60, 92, -50, 60, 93, -50, 60, 94, -50, 60, 95, -70, 60, 96, -70,
+	60, 97, -70, 60, 138, -40, 60, 246, -40, 61, 0, -50, 61, 2, -50, 61, 3, -50,
+	61, 4, -50, 61, 5, -50, 61, 6, -50, 61, 7, -50, 61, 8, -50, 61, 9, -50,
+	61, 10, -50, 61, 79, -40, 61, 80, -40, 61, 81, -40, 61, 92, -50, 61, 93, -50,
+	61, 94, -50, 61, 95, -70, 61, 96, -70, 61, 97, -70, 61, 138, -40, 61, 246, -40,
+	62, 0, -50, 62, 2, -50, 62, 3, -50, 62, 4, -50, 62, 5, -50, 62, 6, -50,
+	62, 7, -50, 62, 8, -50, 62, 9, -50, 62, 10, -50, 62, 79, -40, 62, 80, -40,
+	62, 81, -40, 62, 92, -50, 62, 93, -50, 62, 94, -50, 62, 95, -70, 62, 96, -70,
+	62, 97, -70, 62, 138, -40, 62, 246, -40, 63, 0, -50, 63, 2, -50, 63, 3, -50,
+	63, 4, -50, 63, 5, -50, 63, 6, -50, 63, 7, -50, 63, 8, -50, 63, 9, -50,
+	63, 10, -50, 63, 79, -40, 63, 80, -40, 63, 81, -40, 63, 92, -50, 63, 93, -50,
+	63, 94, -50, 63, 95, -70, 63, 96, -70, 63, 97, -70, 63, 138, -40, 63, 246, -40,
+	64, 0, -50, 64, 2, -50, 64, 3, -50, 64, 4, -50, 64, 5, -50, 64, 6, -50,
+	64, 7, -50, 64, 8, -50, 64, 9, -50, 64, 10, -50, 64, 79, -40, 64, 80, -40,
+	64, 81, -40, 64, 92, -50, 64, 93, -50, 64, 94, -50, 64, 95, -70, 64, 96, -70,
+	64, 97, -70, 64, 138, -40, 64, 246, -40, 65, 0, -50, 65, 2, -50, 65, 3, -50,
+	65, 4, -50, 65, 5, -50, 65, 6, -50, 65, 7, -50, 65, 8, -50, 65, 9, -50,
+	65, 10, -50, 65, 79, -40, 65, 80, -40, 65, 81, -40, 65, 92, -50, 65, 93, -50,
+	65, 94, -50, 65, 95, -70, 65, 96, -70, 65, 97, -70, 65, 138, -40, 65, 246, -40,
+	66, 0, -50, 66, 2, -50, 66, 3, -50, 66, 4, -50, 66, 5, -50, 66, 6, -50,
+	66, 7, -50, 66, 8, -50, 66, 9, -50, 66, 10, -50, 66, 79, -40, 66, 80, -40,
+	66, 81, -40, 66, 92, -50, 66, 93, -50, 66, 94, -50, 66, 95, -70, 66, 96, -70,
+	66, 97, -70, 66, 138, -40, 66, 246, -40, 67, 0, -50, 67, 2, -50, 67, 3, -50,
+	67, 4, -50, 67, 5, -50, 67, 6, -50, 67, 7, -50, 67, 8, -50, 67, 9, -50,
+	67, 10, -50, 67, 79, -40, 67, 80, -40, 67, 81, -40, 67, 92, -50, 67, 93, -50,
+	67, 94, -50, 67, 95, -70, 67, 96, -70, 67, 97, -70, 67, 138, -40, 67, 246, -40,
+	68, 0, -100, 68, 2, -100, 68, 3, -100, 68, 4, -100, 68, 5, -100, 68, 6, -100,
+	68, 7, -100, 68, 8, -100, 68, 9, -100, 68, 10, -100, 68, 102, -30, 68, 103, -30,
+	68, 104, -30, 68, 105, -30, 68, 107, -30, 68, 109, -30, 68, 110, -30, 68, 112, -30,
+	68, 113, -30, 68, 118, -30, 68, 138, -120, 68, 153, -30, 68, 154, -30, 68, 155, -30,
+	68, 156, -30, 68, 157, -30, 68, 158, -30, 68, 159, -30, 68, 162, -30, 68, 165, -30,
+	68, 223, -40, 68, 224, -40, 68, 225, -40, 68, 226, -40, 68, 229, -40, 68, 230, -40,
+	68, 231, -40, 68, 238, -40, 68, 239, -40, 68, 246, -120, 69, 83, -10, 69, 84, -10,
+	69, 85, -10, 69, 86, -10, 69, 87, -10, 69, 88, -10, 69, 89, -10, 69, 90, -10,
+	69, 91, -10, 69, 138, 20, 69, 246, 20, 70, 58, -20, 70, 60, -20, 70, 61, -20,
+	70, 62, -20, 70, 63, -20, 70, 64, -20, 70, 65, -20, 70, 66, -20, 70, 67, -20,
+	70, 79, -20, 70, 80, -20, 70, 81, -20, 70, 83, -20, 70, 84, -20, 70, 85, -20,
+	70, 86, -20, 70, 87, -20, 70, 88, -20, 70, 89, -20, 70, 90, -20, 70, 91, -20,
+	70, 92, -50, 70, 93, -40, 70, 95, -50, 70, 96, -50, 70, 97, -50, 71, 58, -20,
+	71, 60, -20, 71, 61, -20, 71, 62, -20, 71, 63, -20, 71, 64, -20, 71, 65, -20,
+	71, 66, -20, 71, 67, -20, 71, 79, -20, 71, 80, -20, 71, 81, -20, 71, 83, -20,
+	71, 84, -20, 71, 85, -20, 71, 86, -20, 71, 87, -20, 71, 88, -20, 71, 89, -20,
+	71, 90, -20, 71, 91, -20, 71, 92, -50, 71, 93, -40, 71, 95, -50, 71, 96, -50,
+	71, 97, -50, 72, 58, -20, 72, 60, -20, 72, 61, -20, 72, 62, -20, 72, 63, -20,
+	72, 64, -20, 72, 65, -20, 72, 66, -20, 72, 67, -20, 72, 79, -20, 72, 80, -20,
+	72, 81, -20, 72, 83, -20, 72, 84, -20, 72, 85, -20, 72, 86, -20, 72, 87, -20,
+	72, 88, -20, 72, 89, -20, 72, 90, -20, 72, 91, -20, 72, 92, -50, 72, 93, -40,
+	72, 95, -50, 72, 96, -50, 72, 97, -50, 73, 58, -20, 73, 60, -20, 73, 61, -20,
+	73, 62, -20, 73, 63, -20, 73, 64, -20, 73, 65, -20, 73, 66, -20, 73, 67, -20,
+	73, 79, -20, 73, 80, -20, 73, 81, -20, 73, 83, -20, 73, 84, -20, 73, 85, -20,
+	73, 86, -20, 73, 87, -20, 73, 88, -20, 73, 89, -20, 73, 90, -20, 73, 91, -20,
+	73, 92, -50, 73, 93, -40, 73, 95, -50, 73, 96, -50, 73, 97, -50, 79, 0, -90,
+	79, 2, -90, 79, 3, -90, 79, 4, -90, 79, 5, -90, 79, 6, -90, 79, 7, -90,
+	79, 8, -90, 79, 9, -90, 79, 10, -90, 79, 58, -40, 79, 60, -40, 79, 61, -40,
+	79, 62, -40, 79, 63, -40, 79, 64, -40, 79, 65, -40, 79, 66, -40, 79, 67, -40,
+	79, 102, -80, 79, 103, -80, 79, 104, -80, 79, 105, -80, 79, 107, -80, 79, 109, -80,
+	79, 110, -80, 79, 112, -80, 79, 113, -80, 79, 118, -80, 79, 137, -40, 79, 138, -80,
+	79, 153, -60, 79, 154, -60, 79, 155, -60, 79, 156, -60, 79, 157, -60, 79, 158, -60,
+	79, 159, -60, 79, 162, -60, 79, 165, -60, 79, 190, -120, 79, 223, -80, 79, 224, -80,
+	79, 225, -80, 79, 226, -80, 79, 229, -80, 79, 230, -80, 79, 231, -80, 79, 238, -80,
+	79, 239, -80, 79, 246, -80, 79, 262, -80, 79, 263, -80, 79, 266, -80, 79, 275, -40,
+	79, 293, -90, 79, 294, -90, 79, 295, -90, 79, 296, -90, 79, 297, -90, 79, 298, -90,
+	79, 299, -90, 79, 301, -90, 79, 302, -90, 79, 304, -60, 79, 306, -60, 79, 307, -60,
+	79, 308, -60, 80, 0, -90, 80, 2, -90, 80, 3, -90, 80, 4, -90, 80, 5, -90,
+	80, 6, -90, 80, 7, -90, 80, 8, -90, 80, 9, -90, 80, 10, -90, 80, 58, -40,
+	80, 60, -40, 80, 61, -40, 80, 62, -40, 80, 63, -40, 80, 64, -40, 80, 65, -40,
+	80, 66, -40, 80, 67, -40, 80, 102, -80, 80, 103, -80, 80, 104, -80, 80, 105, -80,
+	80, 107, -80, 80, 109, -80, 80, 110, -80, 80, 112, -80, 80, 113, -80, 80, 118, -80,
+	80, 137, -40, 80, 138, -80, 80, 153, -60, 80, 154, -60, 80, 155, -60, 80, 156, -60,
+	80, 157, -60, 80, 158, -60, 80, 159, -60, 80, 162, -60, 80, 165, -60, 80, 190, -120,
+	80, 223, -80, 80, 224, -80, 80, 225, -80, 80, 226, -80, 80, 229, -80, 80, 230, -80,
+	80, 231, -80, 80, 238, -80, 80, 239, -80, 80, 246, -80, 80, 262, -80, 80, 263, -80,
+	80, 266, -80, 80, 275, -40, 80, 293, -90, 80, 294, -90, 80, 295, -90, 80, 296, -90,
+	80, 297, -90, 80, 298, -90, 80, 299, -90, 80, 301, -90, 80, 302, -90, 80, 304, -60,
+	80, 306, -60, 80, 307, -60, 80, 308, -60, 81, 0, -90, 81, 2, -90, 81, 3, -90,
+	81, 4, -90, 81, 5, -90, 81, 6, -90, 81, 7, -90, 81, 8, -90, 81, 9, -90,
+	81, 10, -90, 81, 58, -40, 81, 60, -40, 81, 61, -40, 81, 62, -40, 81, 63, -40,
+	81, 64, -40, 81, 65, -40, 81, 66, -40, 81, 67, -40, 81, 102, -80, 81, 103, -80,
+	81, 104, -80, 81, 105, -80, 81, 107, -80, 81, 109, -80, 81, 110, -80, 81, 112, -80,
+	81, 113, -80, 81, 118, -80, 81, 137, -40, 81, 138, -80, 81, 153, -60, 81, 154, -60,
+	81, 155, -60, 81, 156, -60, 81, 157, -60, 81, 158, -60, 81, 159, -60, 81, 162, -60,
+	81, 165, -60, 81, 190, -120, 81, 223, -80, 81, 224, -80, 81, 225, -80, 81, 226, -80,
+	81, 229, -80, 81, 230, -80, 81, 231, -80, 81, 238, -80, 81, 239, -80, 81, 246, -80,
+	81, 262, -80, 81, 263, -80, 81, 266, -80, 81, 275, -40, 81, 293, -90, 81, 294, -90,
+	81, 295, -90, 81, 296, -90, 81, 297, -90, 81, 298, -90, 81, 299, -90, 81, 301, -90,
+	81, 302, -90, 81, 304, -60, 81, 306, -60, 81, 307, -60, 81, 308, -60, 83, 0, -50,
+	83, 2, -50, 83, 3, -50, 83, 4, -50, 83, 5, -50, 83, 6, -50, 83, 7, -50,
+	83, 8, -50, 83, 9, -50, 83, 10, -50, 83, 138, -30, 83, 246, -30, 84, 0, -50,
+	84, 2, -50, 84, 3, -50, 84, 4, -50, 84, 5, -50, 84, 6, -50, 84, 7, -50,
+	84, 8, -50, 84, 9, -50, 84, 10, -50, 84, 138, -30, 84, 246, -30, 85, 0, -50,
+	85, 2, -50, 85, 3, -50, 85, 4, -50, 85, 5, -50, 85, 6, -50, 85, 7, -50,
+	85, 8, -50, 85, 9, -50, 85, 10, -50, 85, 138, -30, 85, 246, -30, 86, 0, -50,
+	86, 2, -50, 86, 3, -50, 86, 4, -50, 86, 5, -50, 86, 6, -50, 86, 7, -50,
+	86, 8, -50, 86, 9, -50, 86, 10, -50, 86, 138, -30, 86, 246, -30, 87, 0, -50,
+	87, 2, -50, 87, 3, -50, 87, 4, -50, 87, 5, -50, 87, 6, -50, 87, 7, -50,
+	87, 8, -50, 87, 9, -50, 87, 10, -50, 87, 138, -30, 87, 246, -30, 88, 0, -50,
+	88, 2, -50, 88, 3, -50, 88, 4, -50, 88, 5, -50, 88, 6, -50, 88, 7, -50,
+	88, 8, -50, 88, 9, -50, 88, 10, -50, 88, 138, -30, 88, 246, -30, 89, 0, -50,
+	89, 2, -50, 89, 3, -50, 89, 4, -50, 89, 5, -50, 89, 6, -50, 89, 7, -50,
+	89, 8, -50, 89, 9, -50, 89, 10, -50, 89, 138, -30, 89, 246, -30, 90, 0, -50,
+	90, 2, -50, 90, 3, -50, 90, 4, -50, 90, 5, -50, 90, 6, -50, 90, 7, -50,
+	90, 8, -50, 90, 9, -50, 90, 10, -50, 90, 138, -30, 90, 246, -30, 91, 0, -50,
+	91, 2, -50, 91, 3, -50, 91, 4, -50, 91, 5, -50, 91, 6, -50, 91, 7, -50,
+	91, 8, -50, 91, 9, -50, 91, 10, -50, 91, 138, -30, 91, 246, -30, 92, 0, -80,
+	92, 2, -80, 92, 3, -80, 92, 4, -80, 92, 5, -80, 92, 6, -80, 92, 7, -80,
+	92, 8, -80, 92, 9, -80, 92, 10, -80, 92, 32, -50, 92, 33, -50, 92, 34, -50,
+	92, 58, -50, 92, 60, -50, 92, 61, -50, 92, 62, -50, 92, 63, -50, 92, 64, -50,
+	92, 65, -50, 92, 66, -50, 92, 67, -50, 92, 102, -60, 92, 103, -60, 92, 104, -60,
+	92, 105, -60, 92, 107, -60, 92, 109, -60, 92, 110, -60, 92, 112, -60, 92, 113, -60,
+	92, 118, -60, 92, 137, -40, 92, 138, -120, 92, 153, -50, 92, 154, -50, 92, 155, -50,
+	92, 156, -50, 92, 157, -50, 92, 158, -50, 92, 159, -50, 92, 162, -50, 92, 165, -50,
+	92, 190, -80, 92, 223, -90, 92, 224, -90, 92, 225, -90, 92, 226, -90, 92, 229, -90,
+	92, 230, -90, 92, 231, -90, 92, 238, -90, 92, 239, -90, 92, 246, -120, 92, 275, -40,
+	92, 293, -60, 92, 294, -60, 92, 295, -60, 92, 296, -60, 92, 297, -60, 92, 298, -60,
+	92, 299, -60, 92, 301, -60, 92, 302, -60, 93, 0, -60, 93, 2, -60, 93, 3, -60,
+	93, 4, -60, 93, 5, -60, 93, 6, -60, 93, 7, -60, 93, 8, -60, 93, 9, -60,
+	93, 10, -60, 93, 58, -20, 93, 60, -20, 93, 61, -20, 93, 62, -20, 93, 63, -20,
+	93, 64, -20, 93, 65, -20, 93, 66, -20, 93, 67, -20, 93, 102, -40, 93, 103, -40,
+	93, 104, -40, 93, 105, -40, 93, 107, -40, 93, 109, -40, 93, 110, -40, 93, 112, -40,
+	93, 113, -40, 93, 118, -40, 93, 137, -10, 93, 138, -80, 93, 153, -35, 93, 154, -35,
+	93, 155, -35, 93, 156, -35, 93, 157, -35, 93, 158, -35, 93, 159, -35, 93, 162, -35,
+	93, 165, -35, 93, 190, -40, 93, 223, -60, 93, 224, -60, 93, 225, -60, 93, 226, -60,
+	93, 229, -60, 93, 230, -60, 93, 231, -60, 93, 238, -60, 93, 239, -60, 93, 246, -80,
+	93, 275, -10, 93, 293, -45, 93, 294, -45, 93, 295, -45, 93, 296, -45, 93, 297, -45,
+	93, 298, -45, 93, 299, -45, 93, 301, -45, 93, 302, -45, 93, 306, -20, 93, 307, -20,
+	93, 308, -20, 95, 0, -110, 95, 2, -110, 95, 3, -110, 95, 4, -110, 95, 5, -110,
+	95, 6, -110, 95, 7, -110, 95, 8, -110, 95, 9, -110, 95, 10, -110, 95, 58, -70,
+	95, 60, -70, 95, 61, -70, 95, 62, -70, 95, 63, -70, 95, 64, -70, 95, 65, -70,
+	95, 66, -70, 95, 67, -70, 95, 102, -90, 95, 103, -90, 95, 104, -90, 95, 105, -90,
+	95, 107, -90, 95, 109, -90, 95, 110, -90, 95, 112, -90, 95, 113, -90, 95, 118, -90,
+	95, 137, -50, 95, 138, -100, 95, 153, -80, 95, 154, -80, 95, 155, -80, 95, 156, -80,
+	95, 157, -80, 95, 158, -80, 95, 159, -80, 95, 162, -80, 95, 165, -80, 95, 223, -100,
+	95, 224, -100, 95, 225, -100, 95, 226, -100, 95, 229, -100, 95, 230, -100, 95, 231, -100,
+	95, 238, -100, 95, 239, -100, 95, 246, -100, 95, 275, -50, 95, 293, -100, 95, 294, -100,
+	95, 295, -100, 95, 296, -100, 95, 297, -100, 95, 298, -100, 95, 299, -100, 95, 301, -100,
+	95, 302, -100, 96, 0, -110, 96, 2, -110, 96, 3, -110, 96, 4, -110, 96, 5, -110,
+	96, 6, -110, 96, 7, -110, 96, 8, -110, 96, 9, -110, 96, 10, -110, 96, 58, -70,
+	96, 60, -70, 96, 61, -70, 96, 62, -70, 96, 63, -70, 96, 64, -70, 96, 65, -70,
+	96, 66, -70, 96, 67, -70, 96, 102, -90, 96, 103, -90, 96, 104, -90, 96, 105, -90,
+	96, 107, -90, 96, 109, -90, 96, 110, -90, 96, 112, -90, 96, 113, -90, 96, 118, -90,
+	96, 137, -50, 96, 138, -100, 96, 153, -80, 96, 154, -80, 96, 155, -80, 96, 156, -80,
+	96, 157, -80, 96, 158, -80, 96, 159, -80, 96, 162, -80, 96, 165, -80, 96, 223, -100,
+	96, 224, -100, 96, 225, -100, 96, 226, -100, 96, 229, -100, 96, 230, -100, 96, 231, -100,
+	96, 238, -100, 96, 239, -100, 96, 246, -100, 96, 275, -50, 96, 293, -100, 96, 294, -100,
+	96, 295, -100, 96, 296, -100, 96, 297, -100, 96, 298, -100, 96, 299, -100, 96, 301, -100,
+	96, 302, -100, 97, 0, -110, 97, 2, -110, 97, 3, -110, 97, 4, -110, 97, 5, -110,
+	97, 6, -110, 97, 7, -110, 97, 8, -110, 97, 9, -110, 97, 10, -110, 97, 58, -70,
+	97, 60, -70, 97, 61, -70, 97, 62, -70, 97, 63, -70, 97, 64, -70, 97, 65, -70,
+	97, 66, -70, 97, 67, -70, 97, 102, -90, 97, 103, -90, 97, 104, -90, 97, 105, -90,
+	97, 107, -90, 97, 109, -90, 97, 110, -90, 97, 112, -90, 97, 113, -90, 97, 118, -90,
+	97, 137, -50, 97, 138, -100, 97, 153, -80, 97, 154, -80, 97, 155, -80, 97, 156, -80,
+	97, 157, -80, 97, 158, -80, 97, 159, -80, 97, 162, -80, 97, 165, -80, 97, 223, -100,
+	97, 224, -100, 97, 225, -100, 97, 226, -100, 97, 229, -100, 97, 230, -100, 97, 231, -100,
+	97, 238, -100, 97, 239, -100, 97, 246, -100, 97, 275, -50, 97, 293, -100, 97, 294, -100,
+	97, 295, -100, 97, 296, -100, 97, 297, -100, 97, 298, -100, 97, 299, -100, 97, 301, -100,
+	97, 302, -100, 102, 177, -10, 102, 178, -10, 102, 179, -10, 102, 303, -15, 102, 304, -15,
+	102, 306, -20, 102, 307, -20, 102, 308, -20, 103, 177, -10, 103, 178, -10, 103, 179, -10,
+	103, 303, -15, 103, 304, -15, 103, 306, -20, 103, 307, -20, 103, 308, -20, 104, 177, -10,
+	104, 178, -10, 104, 179, -10, 104, 303, -15, 104, 304, -15, 104, 306, -20, 104, 307, -20,
+	104, 308, -20, 105, 177, -10, 105, 178, -10, 105, 179, -10, 105, 303, -15, 105, 304, -15,
+	105, 306, -20, 105, 307, -20, 105, 308, -20, 107, 177, -10, 107, 178, -10, 107, 179, -10,
+	107, 303, -15, 107, 304, -15, 107, 306, -20, 107, 307, -20, 107, 308, -20, 109, 177, -10,
+	109, 178, -10, 109, 179, -10, 109, 303, -15, 109, 304, -15, 109, 306, -20, 109, 307, -20,
+	109, 308, -20, 110, 177, -10, 110, 178, -10, 110, 179, -10, 110, 303, -15, 110, 304, -15,
+	110, 306, -20, 110, 307, -20, 110, 308, -20, 112, 177, -10, 112, 178, -10, 112, 179, -10,
+	112, 303, -15, 112, 304, -15, 112, 306, -20, 112, 307, -20, 112, 308, -20, 113, 177, -10,
+	113, 178, -10, 113, 179, -10, 113, 303, -15, 113, 304, -15, 113, 306, -20, 113, 307, -20,
+	113, 308, -20, 118, 177, -10, 118, 178, -10, 118, 179, -10, 118, 303, -15, 118, 304, -15,
+	118, 306, -20, 118, 307, -20, 118, 308, -20, 119, 201, -10, 119, 202, -10, 119, 204, -10,
+	119, 209, -10, 119, 293, -20, 119, 294, -20, 119, 295, -20, 119, 296, -20, 119, 297, -20,
+	119, 298, -20, 119, 299, -20, 119, 301, -20, 119, 302, -20, 119, 303, -20, 119, 306, -20,
+	119, 307, -20, 119, 308, -20, 129, 188, -10, 129, 199, -20, 129, 200, -20, 129, 201, -20,
+	129, 202, -20, 129, 204, -20, 129, 209, -20, 129, 306, -10, 129, 307, -10, 129, 308, -10,
+	130, 188, -10, 130, 199, -20, 130, 200, -20, 130, 201, -20, 130, 202, -20, 130, 204, -20,
+	130, 209, -20, 130, 306, -10, 130, 307, -10, 130, 308, -10, 132, 188, -10, 132, 199, -20,
+	132, 200, -20, 132, 201, -20, 132, 202, -20, 132, 204, -20, 132, 209, -20, 132, 306, -10,
+	132, 307, -10, 132, 308, -10, 133, 188, -10, 133, 199, -20, 133, 200, -20, 133, 201, -20,
+	133, 202, -20, 133, 204, -20, 133, 209, -20, 133, 306, -10, 133, 307, -10, 133, 308, -10,
+	137, 279, -40, 138, 257, -120, 138, 259, -120, 138, 279, -40, 142, 142, -10, 142, 146, -10,
+	142, 303, -15, 142, 304, -15, 142, 306, -15, 142, 307, -15, 142, 308, -15, 146, 142, -10,
+	146, 146, -10, 146, 303, -15, 146, 304, -15, 146, 306, -15, 146, 307, -15, 146, 308, -15,
+	153, 138, 10, 153, 246, 20, 153, 303, -15, 153, 304, -15, 153, 305, -15, 153, 306, -15,
+	153, 307, -15, 153, 308, -15, 154, 138, 10, 154, 246, 20, 154, 303, -15, 154, 304, -15,
+	154, 305, -15, 154, 306, -15, 154, 307, -15, 154, 308, -15, 155, 138, 10, 155, 246, 20,
+	155, 303, -15, 155, 304, -15, 155, 305, -15, 155, 306, -15, 155, 307, -15, 155, 308, -15,
+	156, 138, 10, 156, 246, 20, 156, 303, -15, 156, 304, -15, 156, 305, -15, 156, 306, -15,
+	156, 307, -15, 156, 308, -15, 157, 138, 10, 157, 246, 20, 157, 303, -15, 157, 304, -15,
+	157, 305, -15, 157, 306, -15, 157, 307, -15, 157, 308, -15, 158, 138, 10, 158, 246, 20,
+	158, 303, -15, 158, 304, -15, 158, 305, -15, 158, 306, -15, 158, 307, -15, 158, 308, -15,
+	159, 138, 10, 159, 246, 20, 159, 303, -15, 159, 304, -15, 159, 305, -15, 159, 306, -15,
+	159, 307, -15, 159, 308, -15, 162, 138, 10, 162, 246, 20, 162, 303, -15, 162, 304, -15,
+	162, 305, -15, 162, 306, -15, 162, 307, -15, 162, 308, -15, 165, 138, 10, 165, 246, 20,
+	165, 303, -15, 165, 304, -15, 165, 305, -15, 165, 306, -15, 165, 307, -15, 165, 308, -15,
+	170, 138, -10, 170, 153, -10, 170, 154, -10, 170, 155, -10, 170, 156, -10, 170, 157, -10,
+	170, 158, -10, 170, 159, -10, 170, 162, -10, 170, 165, -10, 170, 223, -20, 170, 224, -20,
+	170, 225, -20, 170, 226, -20, 170, 229, -20, 170, 230, -20, 170, 231, -20, 170, 238, -20,
+	170, 239, -20, 170, 246, -10, 170, 257, 30, 170, 259, 30, 177, 153, 10, 177, 154, 10,
+	177, 155, 10, 177, 156, 10, 177, 157, 10, 177, 158, 10, 177, 159, 10, 177, 162, 10,
+	177, 165, 10, 177, 177, -10, 177, 178, -10, 177, 179, -10, 178, 153, 10, 178, 154, 10,
+	178, 155, 10, 178, 156, 10, 178, 157, 10, 178, 158, 10, 178, 159, 10, 178, 162, 10,
+	178, 165, 10, 178, 177, -10, 178, 178, -10, 178, 179, -10, 179, 153, 10, 179, 154, 10,
+	179, 155, 10, 179, 156, 10, 179, 157, 10, 179, 158, 10, 179, 159, 10, 179, 162, 10,
+	179, 165, 10, 179, 177, -10, 179, 178, -10, 179, 179, -10, 188, 306, -20, 188, 307, -20,
+	188, 308, -20, 199, 223, -15, 199, 224, -15, 199, 225, -15, 199, 226, -15, 199, 229, -15,
+	199, 230, -15, 199, 231, -15, 199, 238, -15, 199, 239, -15, 200, 223, -15, 200, 224, -15,
+	200, 225, -15, 200, 226, -15, 200, 229, -15, 200, 230, -15, 200, 231, -15, 200, 238, -15,
+	200, 239, -15, 201, 304, -15, 201, 306, -15, 201, 307, -15, 201, 308, -15, 202, 304, -15,
+	202, 306, -15, 202, 307, -15, 202, 308, -15, 204, 304, -15, 204, 306, -15, 204, 307, -15,
+	204, 308, -15, 209, 304, -15, 209, 306, -15, 209, 307, -15, 209, 308, -15, 210, 293, -20,
+	210, 294, -20, 210, 295, -20, 210, 296, -20, 210, 297, -20, 210, 298, -20, 210, 299, -20,
+	210, 301, -20, 210, 302, -20, 210, 306, -30, 210, 307, -30, 210, 308, -30, 215, 293, -10,
+	215, 294, -10, 215, 295, -10, 215, 296, -10, 215, 297, -10, 215, 298, -10, 215, 299, -10,
+	215, 301, -10, 215, 302, -10, 215, 303, -40, 215, 306, -20, 215, 307, -20, 215, 308, -20,
+	216, 293, -10, 216, 294, -10, 216, 295, -10, 216, 296, -10, 216, 297, -10, 216, 298, -10,
+	216, 299, -10, 216, 301, -10, 216, 302, -10, 216, 303, -40, 216, 306, -20, 216, 307, -20,
+	216, 308, -20, 217, 293, -10, 217, 294, -10, 217, 295, -10, 217, 296, -10, 217, 297, -10,
+	217, 298, -10, 217, 299, -10, 217, 301, -10, 217, 302, -10, 217, 303, -40, 217, 306, -20,
+	217, 307, -20, 217, 308, -20, 218, 293, -10, 218, 294, -10, 218, 295, -10, 218, 296, -10,
+	218, 297, -10, 218, 298, -10, 218, 299, -10, 218, 301, -10, 218, 302, -10, 218, 303, -40,
+	218, 306, -20, 218, 307, -20, 218, 308, -20, 221, 293, -10, 221, 294, -10, 221, 295, -10,
+	221, 296, -10, 221, 297, -10, 221, 298, -10, 221, 299, -10, 221, 301, -10, 221, 302, -10,
+	221, 303, -40, 221, 306, -20, 221, 307, -20, 221, 308, -20, 223, 303, -20, 223, 304, -15,
+	223, 305, -30, 223, 306, -20, 223, 307, -20, 223, 308, -20, 224, 303, -20, 224, 304, -15,
+	224, 305, -30, 224, 306, -20, 224, 307, -20, 224, 308, -20, 225, 303, -20, 225, 304, -15,
+	225, 305, -30, 225, 306, -20, 225, 307, -20, 225, 308, -20, 226, 303, -20, 226, 304, -15,
+	226, 305, -30, 226, 306, -20, 226, 307, -20, 226, 308, -20, 229, 303, -20, 229, 304, -15,
+	229, 305, -30, 229, 306, -20, 229, 307, -20, 229, 308, -20, 230, 303, -20, 230, 304, -15,
+	230, 305, -30, 230, 306, -20, 230, 307, -20, 230, 308, -20, 231, 303, -20, 231, 304, -15,
+	231, 305, -30, 231, 306, -20, 231, 307, -20, 231, 308, -20, 238, 303, -20, 238, 304, -15,
+	238, 305, -30, 238, 306, -20, 238, 307, -20, 238, 308, -20, 239, 303, -20, 239, 304, -15,
+	239, 305, -30, 239, 306, -20, 239, 307, -20, 239, 308, -20, 240, 306, -15, 240, 307, -15,
+	240, 308, -15, 246, 257, -120, 246, 259, -120, 246, 279, -40, 257, 279, -80, 258, 258, -46,
+	259, 142, -80, 259, 146, -80, 259, 201, -20, 259, 202, -20, 259, 204, -20, 259, 209, -20,
+	259, 259, -46, 259, 262, -40, 259, 263, -40, 259, 265, -40, 259, 266, -40, 259, 269, -60,
+	259, 270, -60, 259, 271, -60, 259, 272, -60, 259, 273, -60, 259, 279, -80, 259, 303, -20,
+	262, 129, -20, 262, 130, -20, 262, 132, -20, 262, 133, -20, 262, 138, -60, 262, 142, -20,
+	262, 146, -20, 262, 177, -15, 262, 178, -15, 262, 179, -15, 262, 190, -20, 262, 223, -20,
+	262, 224, -20, 262, 225, -20, 262, 226, -20, 262, 229, -20, 262, 230, -20, 262, 231, -20,
+	262, 238, -20, 262, 239, -20, 262, 246, -60, 262, 251, -20, 262, 269, -15, 262, 270, -15,
+	262, 271, -15, 262, 272, -15, 262, 273, -15, 262, 282, 20, 262, 284, 20, 262, 303, 10,
+	262, 306, 10, 262, 307, 10, 262, 308, 10, 263, 129, -20, 263, 130, -20, 263, 132, -20,
+	263, 133, -20, 263, 138, -60, 263, 142, -20, 263, 146, -20, 263, 177, -15, 263, 178, -15,
+	263, 179, -15, 263, 190, -20, 263, 223, -20, 263, 224, -20, 263, 225, -20, 263, 226, -20,
+	263, 229, -20, 263, 230, -20, 263, 231, -20, 263, 238, -20, 263, 239, -20, 263, 246, -60,
+	263, 251, -20, 263, 269, -15, 263, 270, -15, 263, 271, -15, 263, 272, -15, 263, 273, -15,
+	263, 282, 20, 263, 284, 20, 263, 303, 10, 263, 306, 10, 263, 307, 10, 263, 308, 10,
+	265, 129, -20, 265, 130, -20, 265, 132, -20, 265, 133, -20, 265, 138, -60, 265, 142, -20,
+	265, 146, -20, 265, 177, -15, 265, 178, -15, 265, 179, -15, 265, 190, -20, 265, 223, -20,
+	265, 224, -20, 265, 225, -20, 265, 226, -20, 265, 229, -20, 265, 230, -20, 265, 231, -20,
+	265, 238, -20, 265, 239, -20, 265, 246, -60, 265, 251, -20, 265, 269, -15, 265, 270, -15,
+	265, 271, -15, 265, 272, -15, 265, 273, -15, 265, 282, 20, 265, 284, 20, 265, 303, 10,
+	265, 306, 10, 265, 307, 10, 265, 308, 10, 266, 129, -20, 266, 130, -20, 266, 132, -20,
+	266, 133, -20, 266, 138, -60, 266, 142, -20, 266, 146, -20, 266, 177, -15, 266, 178, -15,
+	266, 179, -15, 266, 190, -20, 266, 223, -20, 266, 224, -20, 266, 225, -20, 266, 226, -20,
+	266, 229, -20, 266, 230, -20, 266, 231, -20, 266, 238, -20, 266, 239, -20, 266, 246, -60,
+	266, 251, -20, 266, 269, -15, 266, 270, -15, 266, 271, -15, 266, 272, -15, 266, 273, -15,
+	266, 282, 20, 266, 284, 20, 266, 303, 10, 266, 306, 10, 266, 307, 10, 266, 308, 10,
+	269, 304, -15, 270, 304, -15, 271, 304, -15, 272, 304, -15, 273, 304, -15, 275, 279, -40,
+	279, 79, -100, 279, 80, -100, 279, 81, -100, 279, 92, -80, 279, 93, -80, 279, 95, -120,
+	279, 96, -120, 279, 97, -120, 279, 256, -80, 279, 258, -60, 303, 102, -20, 303, 103, -20,
+	303, 104, -20, 303, 105, -20, 303, 107, -20, 303, 109, -20, 303, 110, -20, 303, 112, -20,
+	303, 113, -20, 303, 118, -20, 303, 138, -80, 303, 223, -30, 303, 224, -30, 303, 225, -30,
+	303, 226, -30, 303, 229, -30, 303, 230, -30, 303, 231, -30, 303, 238, -30, 303, 239, -30,
+	303, 246, -80, 304, 138, -40, 304, 223, -20, 304, 224, -20, 304, 225, -20, 304, 226, -20,
+	304, 229, -20, 304, 230, -20, 304, 231, -20, 304, 238, -20, 304, 239, -20, 304, 246, -40,
+	305, 153, -10, 305, 154, -10, 305, 155, -10, 305, 156, -10, 305, 157, -10, 305, 158, -10,
+	305, 159, -10, 305, 162, -10, 305, 165, -10, 306, 102, -30, 306, 103, -30, 306, 104, -30,
+	306, 105, -30, 306, 107, -30, 306, 109, -30, 306, 110, -30, 306, 112, -30, 306, 113, -30,
+	306, 118, -30, 306, 138, -80, 306, 153, -10, 306, 154, -10, 306, 155, -10, 306, 156, -10,
+	306, 157, -10, 306, 158, -10, 306, 159, -10, 306, 162, -10, 306, 165, -10, 306, 223, -25,
+	306, 224, -25, 306, 225, -25, 306, 226, -25, 306, 229, -25, 306, 230, -25, 306, 231, -25,
+	306, 238, -25, 306, 239, -25, 306, 246, -80, 307, 102, -30, 307, 103, -30, 307, 104, -30,
+	307, 105, -30, 307, 107, -30, 307, 109, -30, 307, 110, -30, 307, 112, -30, 307, 113, -30,
+	307, 118, -30, 307, 138, -80, 307, 153, -10, 307, 154, -10, 307, 155, -10, 307, 156, -10,
+	307, 157, -10, 307, 158, -10, 307, 159, -10, 307, 162, -10, 307, 165, -10, 307, 223, -25,
+	307, 224, -25, 307, 225, -25, 307, 226, -25, 307, 229, -25, 307, 230, -25, 307, 231, -25,
+	307, 238, -25, 307, 239, -25, 307, 246, -80, 308, 102, -30, 308, 103, -30, 308, 104, -30,
+	308, 105, -30, 308, 107, -30, 308, 109, -30, 308, 110, -30, 308, 112, -30, 308, 113, -30,
+	308, 118, -30, 308, 138, -80, 308, 153, -10, 308, 154, -10, 308, 155, -10, 308, 156, -10,
+	308, 157, -10, 308, 158, -10, 308, 159, -10, 308, 162, -10, 308, 165, -10, 308, 223, -25,
+	308, 224, -25, 308, 225, -25, 308, 226, -25, 308, 229, -25, 308, 230, -25, 308, 231, -25,
+	308, 238, -25, 308, 239, -25, 308, 246, -80, 310, 153, 10, 310, 154, 10, 310, 155, 10,
+	310, 156, 10, 310, 157, 10, 310, 158, 10, 310, 159, 10, 310, 162, 10, 310, 165, 10,
+	311, 153, 10, 311, 154, 10, 311, 155, 10, 311, 156, 10, 311, 157, 10, 311, 158, 10,
+	311, 159, 10, 311, 162, 10, 311, 165, 10, 312, 153, 10, 312, 154, 10, 312, 155, 10,
+	312, 156, 10, 312, 157, 10, 312, 158, 10, 312, 159, 10, 312, 162, 10, 312, 165, 10,
+	313, 153, 10, 313, 154, 10, 313, 155, 10, 313, 156, 10, 313, 157, 10, 313, 158, 10,
+	313, 159, 10, 313, 162, 10, 313, 165, 10,
+}
+
+// timesRomanKernPairs are the kerning pairs loaded from afms/Times-Roman.afm, stored as
+// (left, right, kerning) triples where left and right are indices into
+// type1CommonRunes.
+// See afms/MustRead.html for license information.
+var timesRomanKernPairs = []int16{
+	0, 12, -40, 0, 13, -40, 0, 14, -40, 0, 15, -40, 0, 32, -40, 0, 33, -40,
+	0, 34, -40, 0, 58, -55, 0, 60, -55, 0, 61, -55, 0, 62, -55, 0, 63, -55,
+	0, 64, -55, 0, 65, -55, 0, 66, -55, 0, 67, -55, 0, 69, -55, 0, 79, -111,
+	0, 80, -111, 0, 81, -111, 0, 83, -55, 0, 84, -55, 0, 85, -55, 0, 86, -55,
+	0, 87, -55, 0, 88, -55, 0, 89, -55, 0, 90, -55, 0, 91, -55, 0, 92, -135,
+	0, 93, -90, 0, 95, -105, 0, 96, -105, 0, 97, -105, 0, 259, -111, 0, 303, -74,
+	0, 304, -92, 0, 306, -92, 0, 307, -92, 0, 308, -92, 2, 12, -40, 2, 13, -40,
+	2, 14, -40, 2, 15, -40, 2, 32, -40, 2, 33, -40, 2, 34, -40, 2, 58, -55,
+	2, 60, -55, 2, 61, -55, 2, 62, -55, 2, 63, -55, 2, 64, -55, 2, 65, -55,
+	2, 66, -55, 2, 67, -55, 2, 69, -55, 2, 79, -111, 2, 80, -111, 2, 81, -111,
+	2, 83, -55, 2, 84, -55, 2, 85, -55, 2, 86, -55, 2, 87, -55, 2, 88, -55,
+	2, 89, -55, 2, 90, -55, 2, 91, -55, 2, 92, -135, 2, 93, -90, 2, 95, -105,
+	2, 96, -105, 2, 97, -105, 2, 259, -111, 2, 303, -74, 2, 304, -92, 2, 306, -92,
+	2, 307, -92, 2, 308, -92, 3, 12, -40, 3, 13, -40, 3, 14, -40, 3, 15, -40,
+	3, 32, -40, 3, 33, -40, 3, 34, -40, 3, 58, -55, 3, 60, -55, 3, 61, -55,
+	3, 62, -55, 3, 63, -55, 3, 64, -55, 3, 65, -55, 3, 66, -55, 3, 67, -55,
+	3, 69, -55, 3, 79, -111, 3, 80, -111, 3, 81, -111, 3, 83, -55, 3, 84, -55,
+	3, 85, -55, 3, 86, -55, 3, 87, -55, 3, 88, -55, 3, 89, -55, 3, 90, -55,
+	3, 91, -55, 3, 92, -135, 3, 93, -90, 3, 95, -105, 3, 96, -105, 3, 97, -105,
+	3, 259, -111, 3, 303, -74, 3, 304, -92, 3, 306, -92, 3, 307, -92, 3, 308, -92,
+	4, 12, -40, 4, 13, -40, 4, 14, -40, 4, 15, -40, 4, 32, -40, 4, 33, -40,
+	4, 34, -40, 4, 58, -55, 4, 60, -55, 4, 61, -55, 4, 62, -55, 4, 63, -55,
+	4, 64, -55, 4, 65, -55, 4, 66, -55, 4, 67, -55, 4, 69, -55, 4, 79, -111,
+	4, 80, -111, 4, 81, -111, 4, 83, -55, 4, 84, -55, 4, 85, -55, 4, 86, -55,
+	4, 87, -55, 4, 88, -55, 4, 89, -55, 4, 90, -55, 4, 91, -55, 4, 92, -135,
+	4, 93, -90, 4, 95, -105, 4, 96, -105, 4, 97, -105, 4, 259, -111, 4, 303, -74,
+	4, 304, -92, 4, 306, -92, 4, 307, -92, 4, 308, -92, 5, 12, -40, 5, 13, -40,
+	5, 14, -40, 5, 15, -40, 5, 32, -40, 5, 33, -40, 5, 34, -40, 5, 58, -55,
+	5, 60, -55, 5, 61, -55, 5, 62, -55, 5, 63, -55, 5, 64, -55, 5, 65, -55,
+	5, 66, -55, 5, 67, -55, 5, 69, -55, 5, 79, -111, 5, 80, -111, 5, 81, -111,
+	5, 83, -55, 5, 84, -55, 5, 85, -55, 5, 86, -55, 5, 87, -55, 5, 88, -55,
+	5, 89, -55, 5, 90, -55, 5, 91, -55, 5, 92, -135, 5, 93, -90, 5, 95, -105,
+	5, 96, -105, 5, 97, -105, 5, 259, -111, 5, 303, -74, 5, 304, -92, 5, 306, -92,
+	5, 307, -92, 5, 308, -92, 6, 12, -40, 6, 13, -40, 6, 14, -40, 6, 15, -40,
+	6, 32, -40, 6, 33, -40, 6, 34, -40, 6, 58, -55, 6, 60, -55, 6, 61, -55,
+	6, 62, -55, 6, 63, -55, 6, 64, -55, 6, 65, -55, 6, 66, -55, 6, 67, -55,
+	6, 69, -55, 6, 79, -111, 6, 80, -111, 6, 81, -111, 6, 83, -55, 6, 84, -55,
+	6, 85, -55, 6, 86, -55, 6, 87, -55, 6, 88, -55, 6, 89, -55, 6, 90, -55,
+	6, 91, -55, 6, 92, -135, 6, 93, -90, 6, 95, -105, 6, 96, -105, 6, 97, -105,
+	6, 259, -111, 6, 303, -74, 6, 304, -92, 6, 306, -92, 6, 307, -92, 6, 308, -92,
+	7, 12, -40, 7, 13, -40, 7, 14, -40, 7, 15, -40, 7, 32, -40, 7, 33, -40,
+	7, 34, -40, 7, 58, -55, 7, 60, -55, 7, 61, -55, 7, 62, -55, 7, 63, -55,
+	7, 64, -55, 7, 65, -55, 7, 66, -55, 7, 67, -55, 7, 69, -55, 7, 79, -111,
+	7, 80, -111, 7, 81, -111, 7, 83, -55, 7, 84, -55, 7, 85, -55, 7, 86, -55,
+	7, 87, -55, 7, 88, -55, 7, 89, -55, 7, 90, -55, 7, 91, -55, 7, 92, -135,
+	7, 93, -90, 7, 95, -105, 7, 96, -105, 7, 97, -105, 7, 259, -111, 7, 303, -74,
+	7, 304, -92, 7, 306, -92, 7, 307, -92, 7, 308, -92, 8, 12, -40, 8, 13, -40,
+	8, 14, -40, 8, 15, -40, 8, 32, -40, 8, 33, -40, 8, 34, -40, 8, 58, -55,
+	8, 60, -55, 8, 61, -55, 8, 62, -55, 8, 63, -55, 8, 64, -55, 8, 65, -55,
+	8, 66, -55, 8, 67, -55, 8, 69, -55, 8, 79, -111, 8, 80, -111, 8, 81, -111,
+	8, 83, -55, 8, 84, -55, 8, 85, -55, 8, 86, -55, 8, 87, -55, 8, 88, -55,
+	8, 89, -55, 8, 90, -55, 8, 91, -55, 8, 92, -135, 8, 93, -90, 8, 95, -105,
+	8, 96, -105, 8, 97, -105, 8, 259, -111, 8, 303, -74, 8, 304, -52, 8, 306, -52,
+	8, 307, -52, 8, 308, -52, 9, 12, -40, 9, 13, -40, 9, 14, -40, 9, 15, -40,
+	9, 32, -40, 9, 33, -40, 9, 34, -40, 9, 58, -55, 9, 60, -55, 9, 61, -55,
+	9, 62, -55, 9, 63, -55, 9, 64, -55, 9, 65, -55, 9, 66, -55, 9, 67, -55,
+	9, 69, -55, 9, 79, -111, 9, 80, -111, 9, 81, -111, 9, 83, -55, 9, 84, -55,
+	9, 85, -55, 9, 86, -55, 9, 87, -55, 9, 88, -55, 9, 89, -55, 9, 90, -55,
+	9, 91, -55, 9, 92, -135, 9, 93, -90, 9, 95, -105, 9, 96, -105, 9, 97, -105,
+	9, 259, -111, 9, 303, -74, 9, 304, -92, 9, 306, -92, 9, 307, -92, 9, 308, -92,
+	10, 12, -40, 10, 13, -40, 10, 14, -40, 10, 15, -40, 10, 32, -40, 10, 33, -40,
+	10, 34, -40, 10, 58, -55, 10, 60, -55, 10, 61, -55, 10, 62, -55, 10, 63, -55,
+	10, 64, -55, 10, 65, -55, 10, 66, -55, 10, 67, -55, 10, 69, -55, 10, 79, -111,
+	10, 80, -111, 10, 81, -111, 10, 83, -55, 10, 84, -55, 10, 85, -55, 10, 86, -55,
+	10, 87, -55, 10, 88, -55, 10, 89, -55, 10, 90, -55, 10, 91, -55, 10, 92, -135,
+	10, 93, -90, 10, 95, -105, 10, 96, -105, 10, 97, -105, 10, 259, -111, 10, 303, -74,
+	10, 304, -92, 10, 306, -92, 10, 307, -92, 10, 308, -92, 11, 0, -35, 11, 2, -35,
+	11, 3, -35, 11, 4, -35, 11, 5, -35, 11, 6, -35, 11, 7, -35, 11, 8, -35,
+	11, 9, -35, 11, 10, -35, 11, 83, -10, 11, 84, -10, 11, 85, -10, 11, 86, -10,
+	11, 87, -10, 11, 88, -10, 11, 89, -10, 11, 90, -10, 11, 91, -10, 16, 0, -40,
+	16, 2, -40, 16, 3, -40, 16, 4, -40, 16, 5, -40, 16, 6, -40, 16, 7, -40,
+	16, 8, -40, 16, 9, -40, 16, 10, -40, 16, 92, -40, 16, 93, -30, 16, 95, -55,
+	16, 96, -55, 16, 97, -55, 17, 0, -40, 17, 2, -40, 17, 3, -40, 17, 4, -40,
+	17, 5, -40, 17, 6, -40, 17, 7, -40, 17, 8, -40, 17, 9, -40, 17, 10, -40,
+	17, 92, -40, 17, 93, -30, 17, 95, -55, 17, 96, -55, 17, 97, -55, 18, 0, -40,
+	18, 2, -40, 18, 3, -40, 18, 4, -40, 18, 5, -40, 18, 6, -40, 18, 7, -40,
+	18, 8, -40, 18, 9, -40, 18, 10, -40, 18, 92, -40, 18, 93, -30, 18, 95, -55,
+	18, 96, -55, 18, 97, -55, 31, 0, -74, 31, 2, -74, 31, 3, -74, 31, 4, -74,
+	31, 5, -74, 31, 6, -74, 31, 7, -74, 31, 8, -74, 31, 9, -74, 31, 10, -74,
+	31, 102, -15, 31, 103, -15, 31, 104, -15, 31, 105, -15, 31, 107, -15, 31, 109, -15,
+	31, 110, -15, 31, 112, -15, 31, 113, -15, 31, 118, -15, 31, 138, -80, 31, 223, -15,
+	31, 224, -15, 31, 225, -15, 31, 226, -15, 31, 229, -15, 31, 230, -15, 31, 231, -15,
+	31, 238, -15, 31, 239, -15, 31, 246, -80, 44, 0, -60, 44, 2, -60, 44, 3, -60,
+	44, 4, -60, 44, 5, -60, 44, 6, -60, 44, 7, -60, 44, 8, -60, 44, 9, -60,
+	44, 10, -60, 45, 58, -30, 45, 60, -30, 45, 61, -30, 45, 62, -30, 45, 63, -30,
+	45, 64, -30, 45, 65, -30, 45, 66, -30, 45, 67, -30, 45, 153, -25, 45, 154, -25,
+	45, 155, -25, 45, 156, -25, 45, 157, -25, 45, 158, -25, 45, 159, -25, 45, 162, -25,
+	45, 165, -25, 45, 223, -35, 45, 224, -35, 45, 225, -35, 45, 226, -35, 45, 229, -35,
+	45, 230, -35, 45, 231, -35, 45, 238, -35, 45, 239, -35, 45, 293, -15, 45, 294, -15,
+	45, 295, -15, 45, 296, -15, 45, 297, -15, 45, 298, -15, 45, 299, -15, 45, 301, -15,
+	45, 302, -15, 45, 306, -25, 45, 307, -25, 45, 308, -25, 46, 58, -30, 46, 60, -30,
+	46, 61, -30, 46, 62, -30, 46, 63, -30, 46, 64, -30, 46, 65, -30, 46, 66, -30,
+	46, 67, -30, 46, 153, -25, 46, 154, -25, 46, 155, -25, 46, 156, -25, 46, 157, -25,
+	46, 158, -25, 46, 159, -25, 46, 162, -25, 46, 165, -25, 46, 223, -35, 46, 224, -35,
+	46, 225, -35, 46, 226, -35, 46, 229, -35, 46, 230, -35, 46, 231, -35, 46, 238, -35,
+	46, 239, -35, 46, 293, -15, 46, 294, -15, 46, 295, -15, 46, 296, -15, 46, 297, -15,
+	46, 298, -15, 46, 299, -15, 46, 301, -15, 46, 302, -15, 46, 306, -25, 46, 307, -25,
+	46, 308, -25, 47, 79, -92, 47, 80, -92, 47, 81, -92, 47, 92, -100, 47, 93, -74,
+	47, 95, -100, 47, 96, -100, 47, 97, -100, 47, 259, -92, 47, 306, -55, 47, 307, -55,
+	47, 308, -55, 48, 79, -92, 48, 80, -92, 48, 81, -92, 48, 92, -100, 48, 93, -74,
+	48, 95, -100, 48, 96, -100, 48, 97, -100, 48, 259, -92, 48, 306, -55, 48, 307, -55,
+	48, 308, -55, 49, 259, -92, 49, 306, -55, 49, 307, -55, 49, 308, -55, 50, 79, -92,
+	50, 80, -92, 50, 81, -92, 50, 92, -100, 50, 93, -74, 50, 95, -100, 50, 96, -100,
+	50, 97, -100, 50, 259, -92, 50, 306, -55, 50, 307, -55, 50, 308, -55, 51, 79, -92,
+	51, 80, -92, 51, 81, -92, 51, 92, -100, 51, 93, -74, 51, 95, -100, 51, 96, -100,
+	51, 97, -100, 51, 259, -92, 51, 306, -55, 51, 307, -55, 51, 308, -55, 53, 0, -35,
+	53, 2, -35, 53, 3, -35, 53, 4, -35, 53, 5, -35, 53, 6, -35, 53, 7, -35,
+	53, 8, -35, 53, 9, -35, 53, 10, -35, 54, 0, -35, 54, 2, -35, 54, 3, -35,
+	54, 4, -35, 54, 5, -35, 54, 6, -35, 54, 7, -35, 54, 8, -35, 54, 9, -35,
+	54, 10, -35, 55, 0, -35, 55, 2, -35, 55, 3, -35, 55, 4, -35, 55, 5, -35,
+	55, 6, -35, 55, 7, -35, 55, 8, -35, 55, 9, -35, 55, 10, -35, 56, 0, -35,
+	56, 2, -35, 56, 3, -35, 56, 4, -35, 56, 5, -35, 56, 6, -35, 56, 7, -35,
+	56, 8, -35, 56, 9, -35, 56, 10, -35, 57, 0, -35, 57, 2, -35, 57, 3, -35,
+	57, 4, -35, 57, 5, -35, 57, 6, -35, 57, 7, -35, 57, 8, -35, 57, 9, -35,
+	57, 10, -35, 58, 0, -35, 58, 2, -35, 58, 3, -35, 58, 4, -35, 58, 5, -35,
+	58, 6, -35, 58, 7, -35, 58, 8, -35, 58, 9, -35, 58, 10, -35, 58, 79, -40,
+	58, 80, -40, 58, 81, -40, 58, 92, -50, 58, 93, -35, 58, 94, -40, 58, 95, -50,
+	58, 96, -50, 58, 97, -50, 60, 0, -35, 60, 2, -35, 60, 3, -35, 60, 4, -35,
+	60, 5, -35, 60, 6, -35, 60, 7, -35, 60, 8, -35, 60, 9, -35, 60, 10, -35,
+	60, 79, -40, 60, 80, -40, 60, 81, -40, 60, 92, -50, 60, 93, -35, 60, 94, -40,
+	60, 95, -50, 60, 96, -50, 60, 97, -50, 61, 0, -35, 61, 2, -35, 61, 3, -35,
+	61, 4, -35, 61, 5, -35, 61, 6, -35, 61, 7, -35, 61, 8, -35, 61, 9, -35,
+	61, 10, -35, 61, 79, -40, 61, 80, -40, 61, 81, -40, 61, 92, -50, 61, 93, -35,
+	61, 94, -40, 61, 95, -50, 61, 96, -50, 61, 97, -50, 62, 0, -35, 62, 2, -35,
+	62, 3, -35, 62, 4, -35, 62, 5, -35, 62, 6, -35, 62, 7, -35, 62, 8, -35,
+	62, 9, -35, 62, 10, -35, 62, 79, -40, 62, 80, -40, 62, 81, -40, 62, 92, -50,
+	62, 93, -35, 62, 94, -40, 62, 95, -50, 62, 96, -50, 62, 97, -50, 63, 0, -35,
+	63, 2, -35, 63, 3, -35, 63, 4, -35, 63, 5, -35, 63, 6, -35, 63, 7, -35,
+	63, 8, -35, 63, 9, -35, 63, 10, -35, 63, 79, -40, 63, 80, -40, 63, 81, -40,
+	63, 92, -50, 63, 93, -35, 63, 94, -40, 63, 95, -50, 63, 96, -50, 63, 97, -50,
+	64, 0, -35, 64, 2, -35, 64, 3, -35, 64, 4, -35, 64, 5, -35, 64, 6, -35,
+	64, 7, -35, 64, 8, -35, 64, 9, -35, 64, 10, -35, 64, 79, -40, 64, 80, -40,
+	64, 81, -40, 64, 92, -50, 64, 93, -35, 64, 94, -40, 64, 95, -50, 64, 96, -50,
+	64, 97, -50, 65, 0, -35, 65, 2, -35, 65, 3, -35, 65, 4, -35, 65, 5, -35,
+	65, 6, -35, 65, 7, -35, 65, 8, -35, 65, 9, -35, 65, 10, -35, 65, 79, -40,
+	65, 80, -40, 65, 81, -40, 65, 92, -50, 65, 93, -35, 65, 94, -40, 65, 95, -50,
+	65, 96, -50, 65, 97, -50, 66, 0, -35, 66, 2, -35, 66, 3, -35, 66, 4, -35,
+	66, 5, -35, 66, 6, -35, 66, 7, -35, 66, 8, -35, 66, 9, -35, 66, 10, -35,
+	66, 79, -40, 66, 80, -40, 66, 81, -40, 66, 92, -50, 66, 93, -35, 66, 94, -40,
+	66, 95, -50, 66, 96, -50, 66, 97, -50, 67, 0, -35, 67, 2, -35, 67, 3, -35,
+	67, 4, -35, 67, 5, -35, 67, 6, -35, 67, 7, -35, 67, 8, -35, 67, 9, -35,
+	67, 10, -35, 67, 79, -40, 67, 80, -40, 67, 81, -40, 67, 92, -50, 67, 93, -35,
+	67, 94, -40, 67, 95, -50, 67, 96, -50, 67, 97, -50, 68, 0, -92, 68, 2, -92,
+	68, 3, -92, 68, 4, -92, 68, 5, -92, 68, 6, -92, 68, 7, -92, 68, 8, -92,
+	68, 9, -92, 68, 10, -92, 68, 102, -15, 68, 103, -15, 68, 104, -15, 68, 105, -15,
+	68, 107, -15, 68, 109, -15, 68, 110, -15, 68, 112, -15, 68, 113, -15, 68, 118, -15,
+	68, 138, -111, 68, 246, -111, 69, 83, -10, 69, 84, -10, 69, 85, -10, 69, 86, -10,
+	69, 87, -10, 69, 88, -10, 69, 89, -10, 69, 90, -10, 69, 91, -10, 70, 58, -40,
+	70, 60, -40, 70, 61, -40, 70, 62, -40, 70, 63, -40, 70, 64, -40, 70, 65, -40,
+	70, 66, -40, 70, 67, -40, 70, 79, -60, 70, 80, -60, 70, 81, -60, 70, 83, -40,
+	70, 84, -40, 70, 85, -40, 70, 86, -40, 70, 87, -40, 70, 88, -40, 70, 89, -40,
+	70, 90, -40, 70, 91, -40, 70, 92, -80, 70, 93, -55, 70, 95, -65, 70, 96, -65,
+	70, 97, -65, 71, 58, -40, 71, 60, -40, 71, 61, -40, 71, 62, -40, 71, 63, -40,
+	71, 64, -40, 71, 65, -40, 71, 66, -40, 71, 67, -40, 71, 79, -60, 71, 80, -60,
+	71, 81, -60, 71, 83, -40, 71, 84, -40, 71, 85, -40, 71, 86, -40, 71, 87, -40,
+	71, 88, -40, 71, 89, -40, 71, 90, -40, 71, 91, -40, 71, 92, -80, 71, 93, -55,
+	71, 95, -65, 71, 96, -65, 71, 97, -65, 72, 58, -40, 72, 60, -40, 72, 61, -40,
+	72, 62, -40, 72, 63, -40, 72, 64, -40, 72, 65, -40, 72, 66, -40, 72, 67, -40,
+	72, 79, -60, 72, 80, -60, 72, 81, -60, 72, 83, -40, 72, 84, -40, 72, 85, -40,
+	72, 86, -40, 72, 87, -40, 72, 88, -40, 72, 89, -40, 72, 90, -40, 72, 91, -40,
+	72, 92, -80, 72, 93, -55, 72, 95, -65, 72, 96, -65, 72, 97, -65, 73, 58, -40,
+	73, 60, -40, 73, 61, -40, 73, 62, -40, 73, 63, -40, 73, 64, -40, 73, 65, -40,
+	73, 66, -40, 73, 67, -40, 73, 79, -60, 73, 80, -60, 73, 81, -60, 73, 83, -40,
+	73, 84, -40, 73, 85, -40, 73, 86, -40, 73, 87, -40, 73, 88, -40, 73, 89, -40,
+	73, 90, -40, 73, 91, -40, 73, 92, -80, 73, 93, -55, 73, 95, -65, 73, 96, -65,
+	73, 97, -65, 79, 0, -93, 79, 2, -93, 79, 3, -93, 79, 4, -93, 79, 5, -93,
+	79, 6, -93, 79, 7, -93, 79, 8, -93, 79, 9, -93, 79, 10, -93, 79, 58, -18,
+	79, 60, -18, 79, 61, -18, 79, 62, -18, 79, 63, -18, 79, 64, -18, 79, 65, -18,
+	79, 66, -18, 79, 67, -18, 79, 102, -80, 79, 103, -80, 79, 104, -80, 79, 105, -80,
+	79, 107, -40, 79, 109, -40, 79, 110, -40, 79, 112, -80, 79, 113, -80, 79, 118, -40,
+	79, 137, -50, 79, 138, -74, 79, 153, -70, 79, 154, -70, 79, 155, -70, 79, 156, -70,
+	79, 157, -30, 79, 158, -70, 79, 159, -70, 79, 162, -30, 79, 165, -70, 79, 190, -92,
+	79, 191, -35, 79, 192, -35, 79, 197, -35, 79, 223, -80, 79, 224, -80, 79, 225, -80,
+	79, 226, -80, 79, 229, -80, 79, 230, -80, 79, 231, -80, 79, 238, -80, 79, 239, -80,
+	79, 246, -74, 79, 262, -35, 79, 263, -35, 79, 265, -35, 79, 266, -35, 79, 275, -55,
+	79, 293, -45, 79, 294, -45, 79, 295, -45, 79, 296, -45, 79, 297, -45, 79, 298, -45,
+	79, 299, -45, 79, 301, -45, 79, 302, -45, 79, 304, -80, 79, 306, -80, 79, 307, -80,
+	79, 308, -80, 80, 0, -93, 80, 2, -93, 80, 3, -93, 80, 4, -93, 80, 5, -93,
+	80, 6, -93, 80, 7, -93, 80, 8, -93, 80, 9, -93, 80, 10, -93, 80, 58, -18,
+	80, 60, -18, 80, 61, -18, 80, 62, -18, 80, 63, -18, 80, 64, -18, 80, 65, -18,
+	80, 66, -18, 80, 67, -18, 80, 102, -80, 80, 103, -80, 80, 104, -80, 80, 105, -80,
+	80, 107, -40, 80, 109, -40, 80, 110, -40, 80, 112, -80, 80, 113, -80, 80, 118, -40,
+	80, 137, -50, 80, 138, -74, 80, 153, -70, 80, 154, -70, 80, 155, -70, 80, 156, -30,
+	80, 157, -30, 80, 158, -70, 80, 159, -70, 80, 162, -30, 80, 165, -70, 80, 190, -92,
+	80, 191, -35, 80, 192, -35, 80, 197, -35, 80, 223, -80, 80, 224, -80, 80, 225, -80,
+	80, 226, -80, 80, 229, -80, 80, 230, -80, 80, 231, -80, 80, 238, -80, 80, 239, -80,
+	80, 246, -74, 80, 262, -35, 80, 263, -35, 80, 265, -35, 80, 266, -35, 80, 275, -55,
+	80, 293, -45, 80, 294, -45, 80, 295, -45, 80, 296, -45, 80, 297, -45, 80, 298, -45,
+	80, 299, -45, 80, 301, -45, 80, 302, -45, 80, 304, -80, 80, 306, -80, 80, 307, -80,
+	80, 308, -80, 81, 0, -93, 81, 2, -93, 81, 3, -93, 81, 4, -93, 81, 5, -93,
+	81, 6, -93, 81, 7, -93, 81, 8, -93, 81, 9, -93, 81, 10, -93, 81, 58, -18,
+	81, 60, -18, 81, 61, -18, 81, 62, -18, 81, 63, -18, 81, 64, -18, 81, 65, -18,
+	81, 66, -18, 81, 67, -18, 81, 102, -80, 81, 103, -80, 81, 104, -80, 81, 105, -80,
+	81, 107, -40, 81, 109, -40, 81, 110, -40, 81, 112, -80, 81, 113, -80, 81, 118, -40,
+	81, 137, -50, 81, 138, -74, 81, 153, -70, 81, 154, -70, 81, 155, -70, 81, 156, -30,
+	81, 157, -30, 81, 158, -70, 81, 159, -30, 81, 162, -70, 81, 165, -70, 81, 190, -92,
+	81, 191, -35, 81, 192, -35, 81, 197, -35, 81, 223, -80, 81, 224, -80, 81, 225, -80,
+	81, 226, -80, 81, 229, -80, 81, 230, -80, 81, 231, -80, 81, 238, -80, 81, 239, -80,
+	81, 246, -74, 81, 262, -35, 81, 263, -35, 81, 265, -35, 81, 266, -35, 81, 275, -55,
+	81, 293, -45, 81, 294, -45, 81, 295, -45, 81, 296, -45, 81, 297, -45, 81, 298, -45,
+	81, 299, -45, 81, 301, -45, 81, 302, -45, 81, 304, -80, 81, 306, -80, 81, 307, -80,
+	81, 308, -80, 83, 0, -40, 83, 2, -40, 83, 3, -40, 83, 4, -40, 83, 5, -40,
+	83, 6, -40, 83, 7, -40, 83, 8, -40, 83, 9, -40, 83, 10, -40, 84, 0, -40,
+	84, 2, -40, 84, 3, -40, 84, 4, -40, 84, 5, -40, 84, 6, -40, 84, 7, -40,
+	84, 8, -40, 84, 9, -40, 84, 10, -40, 85, 0, -40, 85, 2, -40, 85, 3, -40,
+	85, 4, -40, 85, 5, -40, 85, 6, -40, 85, 7, -40, 85, 8, -40, 85, 9, -40,
+	85, 10, -40, 86, 0, -40, 86, 2, -40, 86, 3, -40, 86, 4, -40, 86, 5, -40,
+	86, 6, -40, 86, 7, -40, 86, 8, -40, 86, 9, -40, 86, 10, -40, 87, 0, -40,
+	87, 2, -40, 87, 3, -40, 87, 4, -40, 87, 5, -40, 87, 6, -40, 87, 7, -40,
+	87, 8, -40, 87, 9, -40, 87, 10, -40, 88, 0, -40, 88, 2, -40, 88, 3, -40,
+	88, 4, -40, 88, 5, -40, 88, 6, -40, 88, 7, -40, 88, 8, -40, 88, 9, -40,
+	88, 10, -40, 89, 0, -40, 89, 2, -40, 89, 3, -40, 89, 4, -40, 89, 5, -40,
+	89, 6, -40, 89, 7, -40, 89, 8, -40, 89, 9, -40, 89, 10, -40, 90, 0, -40,
+	90, 2, -40, 90, 3, -40, 90, 4, -40, 90, 5, -40, 90, 6, -40, 90, 7, -40,
+	90, 8, -40, 90, 9, -40, 90, 10, -40, 91, 0, -40, 91, 2, -40, 91, 3, -40,
+	91, 4, -40, 91, 5, -40, 91, 6, -40, 91, 7, -40, 91, 8, -40, 91, 9, -40,
+	91, 10, -40, 92, 0, -135, 92, 2, -135, 92, 3, -135, 92, 4, -135, 92, 5, -135,
+	92, 6, -135, 92, 7, -135, 92, 8, -135, 92, 9, -135, 92, 10, -135, 92, 32, -15,
+	92, 33, -15, 92, 34, -15, 92, 58, -40, 92, 60, -40, 92, 61, -40, 92, 62, -40,
+	92, 63, -40, 92, 64, -40, 92, 65, -40, 92, 66, -40, 92, 67, -40, 92, 102, -111,
+	92, 103, -111, 92, 104, -111, 92, 105, -71, 92, 107, -71, 92, 109, -71, 92, 110, -71,
+	92, 112, -111, 92, 113, -111, 92, 118, -71, 92, 137, -74, 92, 138, -129, 92, 153, -111,
+	92, 154, -111, 92, 155, -71, 92, 156, -71, 92, 157, -71, 92, 158, -111, 92, 159, -71,
+	92, 162, -71, 92, 165, -111, 92, 190, -100, 92, 191, -60, 92, 192, -60, 92, 193, -20,
+	92, 194, -20, 92, 195, -20, 92, 196, -20, 92, 197, -60, 92, 223, -129, 92, 224, -129,
+	92, 225, -129, 92, 226, -89, 92, 229, -89, 92, 230, -129, 92, 231, -89, 92, 238, -129,
+	92, 239, -89, 92, 246, -129, 92, 275, -74, 92, 293, -75, 92, 294, -75, 92, 295, -75,
+	92, 296, -75, 92, 297, -75, 92, 298, -75, 92, 299, -75, 92, 301, -75, 92, 302, -75,
+	93, 0, -120, 93, 2, -120, 93, 3, -120, 93, 4, -120, 93, 5, -120, 93, 6, -120,
+	93, 7, -120, 93, 8, -120, 93, 9, -120, 93, 10, -120, 93, 58, -10, 93, 60, -10,
+	93, 61, -10, 93, 62, -10, 93, 63, -10, 93, 64, -10, 93, 65, -10, 93, 66, -10,
+	93, 67, -10, 93, 102, -80, 93, 103, -80, 93, 104, -80, 93, 105, -80, 93, 107, -80,
+	93, 109, -80, 93, 110, -80, 93, 112, -80, 93, 113, -80, 93, 118, -80, 93, 137, -37,
+	93, 138, -92, 93, 153, -80, 93, 154, -80, 93, 155, -80, 93, 156, -80, 93, 157, -40,
+	93, 158, -80, 93, 159, -40, 93, 162, -40, 93, 165, -80, 93, 190, -65, 93, 191, -40,
+	93, 192, -40, 93, 197, -40, 93, 223, -80, 93, 224, -80, 93, 225, -80, 93, 226, -80,
+	93, 229, -80, 93, 230, -80, 93, 231, -80, 93, 238, -80, 93, 239, -80, 93, 246, -92,
+	93, 275, -37, 93, 293, -50, 93, 294, -50, 93, 295, -50, 93, 296, -50, 93, 297, -50,
+	93, 298, -50, 93, 299, -50, 93, 301, -50, 93, 302, -50, 93, 306, -73, 93, 307, -73,
+	93, 308, -73, 95, 0, -120, 95, 2, -120, 95, 3, -120, 95, 4, -120, 95, 5, -120,
+	95, 6, -120, 95, 7, -120, 95, 8, -120, 95, 9, -120, 95, 10, -120, 95, 58, -30,
+	95, 60, -30, 95, 61, -30, 95, 62, -30, 95, 63, -30, 95, 64, -30, 95, 65, -30,
+	95, 66, -30, 95, 67, -30, 95, 102, -100, 95, 103, -100, 95, 104, -100, 95, 105, -100,
+	95, 107, -60, 95, 109, -60, 95, 110, -60, 95, 112, -100, 95, 113, -100, 95, 118, -60,
+	95, 137, -92, 95, 138, -129, 95, 153, -100, 95, 154, -100, 95, 155, -100, 95, 156, -100,
+	95, 157, -60, 95, 158, -100, 95, 159, -60, 95, 162, -60, 95, 165, -100, 95, 190, -111,
+	95, 191, -55, 95, 192, -55, 95, 197, -55, 95, 223, -110, 95, 224, -110, 95, 225, -110,
+	95, 226, -70, 95, 229, -70, 95, 230, -110, 95, 231, -70, 95, 238, -110, 95, 239, -70,
+	95, 246, -129, 95, 275, -92, 95, 293, -111, 95, 294, -111, 95, 295, -111, 95, 296, -71,
+	95, 297, -71, 95, 298, -111, 95, 299, -71, 95, 301, -111, 95, 302, -111, 96, 0, -120,
+	96, 2, -120, 96, 3, -120, 96, 4, -120, 96, 5, -120, 96, 6, -120, 96, 7, -120,
+	96, 8, -120, 96, 9, -120, 96, 10, -120, 96, 58, -30, 96, 60, -30, 96, 61, -30,
+	96, 62, -30, 96, 63, -30, 96, 64, -30, 96, 65, -30, 96, 66, -30, 96, 67, -30,
+	96, 102, -100, 96, 103, -100, 96, 104, -100, 96, 105, -100, 96, 107, -60, 96, 109, -60,
+	96, 110, -60, 96, 112, -100, 96, 113, -100, 96, 118, -60, 96, 137, -92, 96, 138, -129,
+	96, 153, -100, 96, 154, -100, 96, 155, -100, 96, 156, -100, 96, 157, -60, 96, 158, -100,
+	96, 159, -60, 96, 162, -60, 96, 165, -100, 96, 190, -111, 96, 191, -55, 96, 192, -55,
+	96, 197, -55, 96, 223, -110, 96, 224, -110, 96, 225, -110, 96, 226, -70, 96, 229, -70,
+	96, 230, -110, 96, 231, -70, 96, 238, -110, 96, 239, -70, 96, 246, -129, 96, 275, -92,
+	96, 293, -111, 96, 294, -111, 96, 295, -111, 96, 296, -71, 96, 297, -71, 96, 298, -111,
+	96, 299, -71, 96, 301, -111, 96, 302, -111, 97, 0, -120, 97, 2, -120, 97, 3, -120,
+	97, 4, -120, 97, 5, -120, 97, 6, -120, 97, 7, -120, 97, 8, -120, 97, 9, -120,
+	97, 10, -120, 97, 58, -30, 97, 60, -30, 97, 61, -30, 97, 62, -30, 97, 63, -30,
+	97, 64, -30, 97, 65, -30, 97, 66, -30, 97, 67, -30, 97, 102, -100, 97, 103, -100,
+	97, 104, -100, 97, 105, -100, 97, 107, -60, 97, 109, -60, 97, 110, -60, 97, 112, -100,
+	97, 113, -100, 97, 118, -100, 97, 137, -92, 97, 138, -129, 97, 153, -100, 97, 154, -100,
+	97, 155, -100, 97, 156, -100, 97, 157, -60, 97, 158, -100, 97, 159, -60, 97, 162, -60,
+	97, 165, -100, 97, 190, -111, 97, 191, -55, 97, 192, -55, 97, 197, -55, 97, 223, -110,
+	97, 224, -110, 97, 225, -110, 97, 226, -70, 97, 229, -70, 97, 230, -110, 97, 231, -70,
+	97, 238, -110, 97, 239, -70, 97, 246, -129, 97, 275, -92, 97, 293, -111, 97, 294, -111,
+	97, 295, -111, 97, 296, -71, 97, 297, -71, 97, 298, -111, 97, 299, -71, 97, 301, -111,
+	97, 302, -111, 102, 303, -20, 102, 304, -15, 103, 303, -20, 103, 304, -15, 104, 303, -20,
+	104, 304, -15, 105, 303, -20, 105, 304, -15, 107, 303, -20, 107, 304, -15, 109, 303, -20,
+	109, 304, -15, 110, 303, -20, 110, 304, -15, 112, 303, -20, 112, 304, -15, 113, 303, -20,
+	113, 304, -15, 118, 303, -20, 118, 304, -15, 119, 246, -40, 119, 293, -20, 119, 294, -20,
+	119, 295, -20, 119, 296, -20, 119, 297, -20, 119, 298, -20, 119, 299, -20, 119, 301, -20,
+	119, 302, -20, 119, 303, -15, 129, 306, -15, 129, 307, -15, 129, 308, -15, 130, 306, -15,
+	130, 307, -15, 130, 308, -15, 132, 306, -15, 132, 307, -15, 132, 308, -15, 133, 306, -15,
+	133, 307, -15, 133, 308, -15, 138, 257, -70, 138, 259, -70, 153, 177, -15, 153, 178, -15,
+	153, 179, -15, 153, 303, -25, 153, 304, -25, 153, 305, -15, 153, 306, -15, 153, 307, -15,
+	153, 308, -15, 154, 177, -15, 154, 178, -15, 154, 179, -15, 154, 303, -25, 154, 304, -25,
+	154, 305, -15, 154, 306, -15, 154, 307, -15, 154, 308, -15, 155, 177, -15, 155, 178, -15,
+	155, 179, -15, 155, 303, -25, 155, 304, -25, 155, 305, -15, 155, 306, -15, 155, 307, -15,
+	155, 308, -15, 156, 177, -15, 156, 178, -15, 156, 179, -15, 156, 303, -25, 156, 304, -25,
+	156, 305, -15, 156, 306, -15, 156, 307, -15, 156, 308, -15, 157, 177, -15, 157, 178, -15,
+	157, 179, -15, 157, 303, -25, 157, 304, -25, 157, 305, -15, 157, 306, -15, 157, 307, -15,
+	157, 308, -15, 158, 177, -15, 158, 178, -15, 158, 179, -15, 158, 303, -25, 158, 304, -25,
+	158, 305, -15, 158, 306, -15, 158, 307, -15, 158, 308, -15, 159, 177, -15, 159, 178, -15,
+	159, 179, -15, 159, 303, -25, 159, 304, -25, 159, 305, -15, 159, 306, -15, 159, 307, -15,
+	159, 308, -15, 162, 177, -15, 162, 178, -15, 162, 179, -15, 162, 303, -25, 162, 304, -25,
+	162, 305, -15, 162, 306, -15, 162, 307, -15, 162, 308, -15, 165, 177, -15, 165, 178, -15,
+	165, 179, -15, 165, 303, -25, 165, 304, -25, 165, 305, -15, 165, 306, -15, 165, 307, -15,
+	165, 308, -15, 170, 102, -10, 170, 103, -10, 170, 104, -10, 170, 105, -10, 170, 107, -10,
+	170, 109, -10, 170, 110, -10, 170, 112, -10, 170, 113, -10, 170, 118, -10, 170, 152, -50,
+	170, 170, -25, 170, 191, -20, 170, 192, -20, 170, 259, 55, 177, 102, -5, 177, 103, -5,
+	177, 104, -5, 177, 105, -5, 177, 107, -5, 177, 109, -5, 177, 110, -5, 177, 112, -5,
+	177, 113, -5, 177, 118, -5, 178, 102, -5, 178, 103, -5, 178, 104, -5, 178, 105, -5,
+	178, 107, -5, 178, 109, -5, 178, 110, -5, 178, 112, -5, 178, 113, -5, 178, 118, -5,
+	179, 102, -5, 179, 103, -5, 179, 104, -5, 179, 105, -5, 179, 107, -5, 179, 109, -5,
+	179, 110, -5, 179, 112, -5, 179, 113, -5, 179, 118, -5, 188, 306, -5, 188, 307, -5,
+	188, 308, -5, 191, 303, -25, 192, 303, -25, 193, 303, -25, 194, 303, -25, 195, 303, -25,
+	196, 303, -25, 197, 303, -25, 199, 153, -10, 199, 154, -10, 199, 155, -10, 199, 156, -10,
+	199, 157, -10, 199, 158, -10, 199, 159, -10, 199, 162, -10, 199, 165, -10, 199, 223, -10,
+	199, 224, -10, 199, 225, -10, 199, 226, -10, 199, 229, -10, 199, 230, -10, 199, 231, -10,
+	199, 238, -10, 199, 239, -10, 199, 306, -15, 199, 307, -15, 199, 308, -15, 200, 153, -10,
+	200, 154, -10, 200, 155, -10, 200, 156, -10, 200, 157, -10, 200, 158, -10, 200, 159, -10,
+	200, 162, -10, 200, 165, -10, 200, 223, -10, 200, 224, -10, 200, 225, -10, 200, 226, -10,
+	200, 229, -10, 200, 230, -10, 200, 231, -10, 200, 238, -10, 200, 239, -10, 200, 306, -15,
+	200, 307, -15, 200, 308, -15, 201, 304, -10, 202, 304, -10, 204, 304, -10, 209, 304, -10,
+	215, 303, -40, 215, 306, -15, 215, 307, -15, 215, 308, -15, 216, 303, -40, 216, 306, -15,
+	216, 307, -15, 216, 308, -15, 217, 303, -40, 217, 306, -15, 217, 307, -15, 217, 308, -15,
+	218, 303, -40, 218, 306, -15, 218, 307, -15, 218, 308, -15, 221, 303, -40, 221, 306, -15,
+	221, 307, -15, 221, 308, -15, 223, 303, -15, 223, 304, -25, 223, 306, -10, 223, 307, -10,
+	223, 308, -10, 224, 303, -15, 224, 304, -25, 224, 306, -10, 224, 307, -10, 224, 308, -10,
+	225, 303, -15, 225, 304, -25, 225, 306, -10, 225, 307, -10, 225, 308, -10, 226, 303, -15,
+	226, 304, -25, 226, 306, -10, 226, 307, -10, 226, 308, -10, 229, 303, -15, 229, 304, -25,
+	229, 306, -10, 229, 307, -10, 229, 308, -10, 230, 303, -15, 230, 304, -25, 230, 306, -10,
+	230, 307, -10, 230, 308, -10, 231, 303, -15, 231, 304, -25, 231, 306, -10, 231, 307, -10,
+	231, 308, -10, 238, 303, -15, 238, 304, -25, 238, 306, -10, 238, 307, -10, 238, 308, -10,
+	239, 303, -15, 239, 304, -25, 239, 306, -10, 239, 307, -10, 239, 308, -10, 240, 306, -10,
+	240, 307, -10, 240, 308, -10, 246, 257, -70, 246, 259, -70, 256, 0, -80, 256, 2, -80,
+	256, 3, -80, 256, 4, -80, 256, 5, -80, 256, 6, -80, 256, 7, -80, 256, 8, -80,
+	256, 9, -80, 256, 10, -80, 258, 0, -80, 258, 2, -80, 258, 3, -80, 258, 4, -80,
+	258, 5, -80, 258, 6, -80, 258, 7, -80, 258, 8, -80, 258, 9, -80, 258, 10, -80,
+	258, 258, -74, 259, 142, -50, 259, 146, -50, 259, 201, -10, 259, 202, -10, 259, 204, -10,
+	259, 209, -10, 259, 259, -74, 259, 262, -50, 259, 263, -50, 259, 265, -50, 259, 266, -50,
+	259, 269, -55, 259, 270, -55, 259, 271, -55, 259, 272, -55, 259, 273, -55, 259, 279, -74,
+	259, 282, -18, 259, 284, -18, 259, 303, -50, 262, 138, -40, 262, 177, -18, 262, 178, -18,
+	262, 179, -18, 262, 190, -20, 262, 246, -55, 263, 138, -40, 263, 177, -18, 263, 178, -18,
+	263, 179, -18, 263, 190, -20, 263, 246, -55, 265, 138, -40, 265, 177, -18, 265, 178, -18,
+	265, 179, -18, 265, 190, -20, 265, 246, -55, 266, 138, -40, 266, 177, -18, 266, 178, -18,
+	266, 179, -18, 266, 190, -20, 266, 246, -55, 279, 0, -55, 279, 2, -55, 279, 3, -55,
+	279, 4, -55, 279, 5, -55, 279, 6, -55, 279, 7, -55, 279, 8, -55, 279, 9, -55,
+	279, 10, -55, 279, 79, -18, 279, 80, -18, 279, 81, -18, 279, 92, -50, 279, 93, -30,
+	279, 95, -90, 279, 96, -90, 279, 97, -90, 303, 102, -25, 303, 103, -25, 303, 104, -25,
+	303, 105, -25, 303, 107, -25, 303, 109, -25, 303, 110, -25, 303, 112, -25, 303, 113, -25,
+	303, 118, -25, 303, 138, -65, 303, 153, -15, 303, 154, -15, 303, 155, -15, 303, 156, -15,
+	303, 157, -15, 303, 158, -15, 303, 159, -15, 303, 162, -15, 303, 165, -15, 303, 223, -20,
+	303, 224, -20, 303, 225, -20, 303, 226, -20, 303, 229, -20, 303, 230, -20, 303, 231, -20,
+	303, 238, -20, 303, 239, -20, 303, 246, -65, 304, 102, -10, 304, 103, -10, 304, 104, -10,
+	304, 105, -10, 304, 107, -10, 304, 109, -10, 304, 110, -10, 304, 112, -10, 304, 113, -10,
+	304, 118, -10, 304, 138, -65, 304, 223, -10, 304, 224, -10, 304, 225, -10, 304, 226, -10,
+	304, 229, -10, 304, 230, -10, 304, 231, -10, 304, 238, -10, 304, 239, -10, 304, 246, -65,
+	305, 153, -15, 305, 154, -15, 305, 155, -15, 305, 156, -15, 305, 157, -15, 305, 158, -15,
+	305, 159, -15, 305, 162, -15, 305, 165, -15, 306, 138, -65, 306, 246, -65, 307, 138, -65,
+	307, 246, -65, 308, 138, -65, 308, 246, -65,
+}
+
+// timesBoldKernPairs are the kerning pairs loaded from afms/Times-Bold.afm, stored as
+// (left, right, kerning) triples where left and right are indices into
+// type1CommonRunes.
+// See afms/MustRead.html for license information.
+var timesBoldKernPairs = []int16{
+	0, 12, -55, 0, 13, -55, 0, 14, -55, 0, 15, -55, 0, 32, -55, 0, 33, -55,
+	0, 34, -55, 0, 58, -45, 0, 60, -45, 0, 61, -45, 0, 62, -45, 0, 63, -45,
+	0, 64, -45, 0, 65, -45, 0, 66, -45, 0, 67, -45, 0, 69, -45, 0, 79, -95,
+	0, 80, -95, 0, 81, -95, 0, 83, -50, 0, 84, -50, 0, 85, -50, 0, 86, -50,
+	0, 87, -50, 0, 88, -50, 0, 89, -50, 0, 90, -50, 0, 91, -50, 0, 92, -145,
+	0, 93, -130, 0, 95, -100, 0, 96, -100, 0, 97, -100, 0, 240, -25, 0, 259, -74,
+	0, 293, -50, 0, 294, -50, 0, 295, -50, 0, 296, -50, 0, 297, -50, 0, 298, -50,
+	0, 299, -50, 0, 301, -50, 0, 302, -50, 0, 303, -100, 0, 304, -90, 0, 306, -74,
+	0, 307, -74, 0, 308, -74, 2, 12, -55, 2, 13, -55, 2, 14, -55, 2, 15, -55,
+	2, 32, -55, 2, 33, -55, 2, 34, -55, 2, 58, -45, 2, 60, -45, 2, 61, -45,
+	2, 62, -45, 2, 63, -45, 2, 64, -45, 2, 65, -45, 2, 66, -45, 2, 67, -45,
+	2, 69, -45, 2, 79, -95, 2, 80, -95, 2, 81, -95, 2, 83, -50, 2, 84, -50,
+	2, 85, -50, 2, 86, -50, 2, 87, -50, 2, 88, -50, 2, 89, -50, 2, 90, -50,
+	2, 91, -50, 2, 92, -145, 2, 93, -130, 2, 95, -100, 2, 96, -100, 2, 97, -100,
+	2, 240, -25, 2, 259, -74, 2, 293, -50, 2, 294, -50, 2, 295, -50, 2, 296, -50,
+	2, 297, -50, 2, 298, -50, 2, 299, -50, 2, 301, -50, 2, 302, -50, 2, 303, -100,
+	2, 304, -90, 2, 306, -74, 2, 307, -74, 2, 308, -74, 3, 12, -55, 3, 13, -55,
+	3, 14, -55, 3, 15, -55, 3, 32, -55, 3, 33, -55, 3, 34, -55, 3, 58, -45,
+	3, 60, -45, 3, 61, -45, 3, 62, -45, 3, 63, -45, 3, 64, -45, 3, 65, -45,
+	3, 66, -45, 3, 67, -45, 3, 69, -45, 3, 79, -95, 3, 80, -95, 3, 81, -95,
+	3, 83, -50, 3, 84, -50, 3, 85, -50, 3, 86, -50, 3, 87, -50, 3, 88, -50,
+	3, 89, -50, 3, 90, -50, 3, 91, -50, 3, 92, -145, 3, 93, -130, 3, 95, -100,
+	3, 96, -100, 3, 97, -100, 3, 240, -25, 3, 259, -74, 3, 293, -50, 3, 294, -50,
+	3, 295, -50, 3, 296, -50, 3, 297, -50, 3, 298, -50, 3, 299, -50, 3, 301, -50,
+	3, 302, -50, 3, 303, -100, 3, 304, -90, 3, 306, -74, 3, 307, -74, 3, 308, -74,
+	4, 12, -55, 4, 13, -55, 4, 14, -55, 4, 15, -55, 4, 32, -55, 4, 33, -55,
+	4, 34, -55, 4, 58, -45, 4, 60, -45, 4, 61, -45, 4, 62, -45, 4, 63, -45,
+	4, 64, -45, 4, 65, -45, 4, 66, -45, 4, 67, -45, 4, 69, -45, 4, 79, -95,
+	4, 80, -95, 4, 81, -95, 4, 83, -50, 4, 84, -50, 4, 85, -50, 4, 86, -50,
+	4, 87, -50, 4, 88, -50, 4, 89, -50, 4, 90, -50, 4, 91, -50, 4, 92, -145,
+	4, 93, -130, 4, 95, -100, 4, 96, -100, 4, 97, -100, 4, 240, -25, 4, 259, -74,
+	4, 293, -50, 4, 294, -50, 4, 295, -50, 4, 296, -50, 4, 297, -50, 4, 298, -50,
+	4, 299, -50, 4, 301, -50, 4, 302, -50, 4, 303, -100, 4, 304, -90, 4, 306, -74,
+	4, 307, -74, 4, 308, -74, 5, 12, -55, 5, 13, -55, 5, 14, -55, 5, 15, -55,
+	5, 32, -55, 5, 33, -55, 5, 34, -55, 5, 58, -45, 5, 60, -45, 5, 61, -45,
+	5, 62, -45, 5, 63, -45, 5, 64, -45, 5, 65, -45, 5, 66, -45, 5, 67, -45,
+	5, 69, -45, 5, 79, -95, 5, 80, -95, 5, 81, -95, 5, 83, -50, 5, 84, -50,
+	5, 85, -50, 5, 86, -50, 5, 87, -50, 5, 88, -50, 5, 89, -50, 5, 90, -50,
+	5, 91, -50, 5, 92, -145, 5, 93, -130, 5, 95, -100, 5, 96, -100, 5, 97, -100,
+	5, 240, -25, 5, 259, -74, 5, 293, -50, 5, 294, -50, 5, 295, -50, 5, 296, -50,
+	5, 297, -50, 5, 298, -50, 5, 299, -50, 5, 301, -50, 5, 302, -50, 5, 303, -100,
+	5, 304, -90, 5, 306, -74, 5, 307, -74, 5, 308, -74, 6, 12, -55, 6, 13, -55,
+	6, 14, -55, 6, 15, -55, 6, 32, -55, 6, 33, -55, 6, 34, -55, 6, 58, -45,
+	6, 60, -45, 6, 61, -45, 6, 62, -45, 6, 63, -45, 6, 64, -45, 6, 65, -45,
+	6, 66, -45, 6, 67, -45, 6, 69, -45, 6, 79, -95, 6, 80, -95, 6, 81, -95,
+	6, 83, -50, 6, 84, -50, 6, 85, -50, 6, 86, -50, 6, 87, -50, 6, 88, -50,
+	6, 89, -50, 6, 90, -50, 6, 91, -50, 6, 92, -145, 6, 93, -130, 6, 95, -100,
+	6, 96, -100, 6, 97, -100, 6, 240, -25, 6, 259, -74, 6, 293, -50, 6, 294, -50,
+	6, 295, -50, 6, 296, -50, 6, 297, -50, 6, 298, -50, 6, 299, -50, 6, 301, -50,
+	6, 302, -50, 6, 303, -100, 6, 304, -90, 6, 306, -74, 6, 307, -74, 6, 308, -74,
+	7, 12, -55, 7, 13, -55, 7, 14, -55, 7, 15, -55, 7, 32, -55, 7, 33, -55,
+	7, 34, -55, 7, 58, -45, 7, 60, -45, 7, 61, -45, 7, 62, -45, 7, 63, -45,
+	7, 64, -45, 7, 65, -45, 7, 66, -45, 7, 67, -45, 7, 69, -45, 7, 79, -95,
+	7, 80, -95, 7, 81, -95, 7, 83, -50, 7, 84, -50, 7, 85, -50, 7, 86, -50,
+	7, 87, -50, 7, 88, -50, 7, 89, -50, 7, 90, -50, 7, 91, -50, 7, 92, -145,
+	7, 93, -130, 7, 95, -100, 7, 96, -100, 7, 97, -100, 7, 240, -25, 7, 259, -74,
+	7, 293, -50, 7, 294, -50, 7, 295, -50, 7, 296, -50, 7, 297, -50, 7, 298, -50,
+	7, 299, -50, 7, 301, -50, 7, 302, -50, 7, 303, -100, 7, 304, -90, 7, 306, -74,
+	7, 307, -74, 7, 308, -74, 8, 12, -55, 8, 13, -55, 8, 14, -55, 8, 15, -55,
+	8, 32, -55, 8, 33, -55, 8, 34, -55, 8, 58, -45, 8, 60, -45, 8, 61, -45,
+	8, 62, -45, 8, 63, -45, 8, 64, -45, 8, 65, -45, 8, 66, -45, 8, 67, -45,
+	8, 69, -45, 8, 79, -95, 8, 80, -95, 8, 81, -95, 8, 83, -50, 8, 84, -50,
+	8, 85, -50, 8, 86, -50, 8, 87, -50, 8, 88, -50, 8, 89, -50, 8, 90, -50,
+	8, 91, -50, 8, 92, -145, 8, 93, -130, 8, 95, -100, 8, 96, -100, 8, 97, -100,
+	8, 240, -25, 8, 259, -74, 8, 293, -50, 8, 294, -50, 8, 295, -50, 8, 296, -50,
+	8, 297, -50, 8, 298, -50, 8, 299, -50, 8, 301, -50, 8, 302, -50, 8, 303, -100,
+	8, 304, -90, 8, 306, -34, 8, 307, -34, 8, 308, -34, 9, 12, -55, 9, 13, -55,
+	9, 14, -55, 9, 15, -55, 9, 32, -55, 9, 33, -55, 9, 34, -55, 9, 58, -45,
+	9, 60, -45, 9, 61, -45, 9, 62, -45, 9, 63, -45, 9, 64, -45, 9, 65, -45,
+	9, 66, -45, 9, 67, -45, 9, 69, -45, 9, 79, -95, 9, 80, -95, 9, 81, -95,
+	9, 83, -50, 9, 84, -50, 9, 85, -50, 9, 86, -50, 9, 87, -50, 9, 88, -50,
+	9, 89, -50, 9, 90, -50, 9, 91, -50, 9, 92, -145, 9, 93, -130, 9, 95, -100,
+	9, 96, -100, 9, 97, -100, 9, 240, -25, 9, 259, -74, 9, 293, -50, 9, 294, -50,
+	9, 295, -50, 9, 296, -50, 9, 297, -50, 9, 298, -50, 9, 299, -50, 9, 301, -50,
+	9, 302, -50, 9, 303, -100, 9, 304, -90, 9, 306, -74, 9, 307, -74, 9, 308, -74,
+	10, 12, -55, 10, 13, -55, 10, 14, -55, 10, 15, -55, 10, 32, -55, 10, 33, -55,
+	10, 34, -55, 10, 58, -45, 10, 60, -45, 10, 61, -45, 10, 62, -45, 10, 63, -45,
+	10, 64, -45, 10, 65, -45, 10, 66, -45, 10, 67, -45, 10, 69, -45, 10, 79, -95,
+	10, 80, -95, 10, 81, -95, 10, 83, -50, 10, 84, -50, 10, 85, -50, 10, 86, -50,
+	10, 87, -50, 10, 88, -50, 10, 89, -50, 10, 90, -50, 10, 91, -50, 10, 92, -145,
+	10, 93, -130, 10, 95, -100, 10, 96, -100, 10, 97, -100, 10, 240, -25, 10, 259, -74,
+	10, 293, -50, 10, 294, -50, 10, 295, -50, 10, 296, -50, 10, 297, -50, 10, 298, -50,
+	10, 299, -50, 10, 301, -50, 10, 302, -50, 10, 303, -100, 10, 304, -90, 10, 306, -74,
+	10, 307, -74, 10, 308, -74, 11, 0, -30, 11, 2, -30, 11, 3, -30, 11, 4, -30,
+	11, 5, -30, 11, 6, -30, 11, 7, -30, 11, 8, -30, 11, 9, -30, 11, 10, -30,
+	11, 83, -10, 11, 84, -10, 11, 85, -10, 11, 86, -10, 11, 87, -10, 11, 88, -10,
+	11, 89, -10, 11, 90, -10, 11, 91, -10, 16, 0, -35, 16, 2, -35, 16, 3, -35,
+	16, 4, -35, 16, 5, -35, 16, 6, -35, 16, 7, -35, 16, 8, -35, 16, 9, -35,
+	16, 10, -35, 16, 92, -40, 16, 93, -40, 16, 95, -40, 16, 96, -40, 16, 97, -40,
+	16, 246, -20, 17, 0, -35, 17, 2, -35, 17, 3, -35, 17, 4, -35, 17, 5, -35,
+	17, 6, -35, 17, 7, -35, 17, 8, -35, 17, 9, -35, 17, 10, -35, 17, 92, -40,
+	17, 93, -40, 17, 95, -40, 17, 96, -40, 17, 97, -40, 17, 246, -20, 18, 0, -35,
+	18, 2, -35, 18, 3, -35, 18, 4, -35, 18, 5, -35, 18, 6, -35, 18, 7, -35,
+	18, 8, -35, 18, 9, -35, 18, 10, -35, 18, 92, -40, 18, 93, -40, 18, 95, -40,
+	18, 96, -40, 18, 97, -40, 18, 246, -20, 31, 0, -90, 31, 2, -90, 31, 3, -90,
+	31, 4, -90, 31, 5, -90, 31, 6, -90, 31, 7, -90, 31, 8, -90, 31, 9, -90,
+	31, 10, -90, 31, 102, -25, 31, 103, -25, 31, 104, -25, 31, 105, -25, 31, 107, -25,
+	31, 109, -25, 31, 110, -25, 31, 112, -25, 31, 113, -25, 31, 118, -25, 31, 138, -92,
+	31, 153, -25, 31, 154, -25, 31, 155, -25, 31, 156, -25, 31, 157, -25, 31, 158, -25,
+	31, 159, -25, 31, 162, -25, 31, 165, -25, 31, 223, -25, 31, 224, -25, 31, 225, -25,
+	31, 226, -25, 31, 229, -25, 31, 230, -25, 31, 231, -25, 31, 238, -25, 31, 239, -25,
+	31, 246, -110, 44, 0, -30, 44, 2, -30, 44, 3, -30, 44, 4, -30, 44, 5, -30,
+	44, 6, -30, 44, 7, -30, 44, 8, -30, 44, 9, -30, 44, 10, -30, 44, 102, -15,
+	44, 103, -15, 44, 104, -15, 44, 105, -15, 44, 107, -15, 44, 109, -15, 44, 110, -15,
+	44, 112, -15, 44, 113, -15, 44, 118, -15, 44, 153, -15, 44, 154, -15, 44, 155, -15,
+	44, 156, -15, 44, 157, -15, 44, 158, -15, 44, 159, -15, 44, 162, -15, 44, 165, -15,
+	44, 223, -15, 44, 224, -15, 44, 225, -15, 44, 226, -15, 44, 229, -15, 44, 230, -15,
+	44, 231, -15, 44, 238, -15, 44, 239, -15, 44, 246, -20, 44, 293, -15, 44, 294, -15,
+	44, 295, -15, 44, 296, -15, 44, 297, -15, 44, 298, -15, 44, 299, -15, 44, 301, -15,
+	44, 302, -15, 45, 58, -30, 45, 60, -30, 45, 61, -30, 45, 62, -30, 45, 63, -30,
+	45, 64, -30, 45, 65, -30, 45, 66, -30, 45, 67, -30, 45, 153, -25, 45, 154, -25,
+	45, 155, -25, 45, 156, -25, 45, 157, -25, 45, 158, -25, 45, 159, -25, 45, 162, -25,
+	45, 165, -25, 45, 223, -25, 45, 224, -25, 45, 225, -25, 45, 226, -25, 45, 229, -25,
+	45, 230, -25, 45, 231, -25, 45, 238, -25, 45, 239, -25, 45, 293, -15, 45, 294, -15,
+	45, 295, -15, 45, 296, -15, 45, 297, -15, 45, 298, -15, 45, 299, -15, 45, 301, -15,
+	45, 302, -15, 45, 306, -45, 45, 307, -45, 45, 308, -45, 46, 58, -30, 46, 60, -30,
+	46, 61, -30, 46, 62, -30, 46, 63, -30, 46, 64, -30, 46, 65, -30, 46, 66, -30,
+	46, 67, -30, 46, 153, -25, 46, 154, -25, 46, 155, -25, 46, 156, -25, 46, 157, -25,
+	46, 158, -25, 46, 159, -25, 46, 162, -25, 46, 165, -25, 46, 223, -25, 46, 224, -25,
+	46, 225, -25, 46, 226, -25, 46, 229, -25, 46, 230, -25, 46, 231, -25, 46, 238, -25,
+	46, 239, -25, 46, 293, -15, 46, 294, -15, 46, 295, -15, 46, 296, -15, 46, 297, -15,
+	46, 298, -15, 46, 299, -15, 46, 301, -15, 46, 302, -15, 46, 306, -45, 46, 307, -45,
+	46, 308, -45, 47, 79, -92, 47, 80, -92, 47, 81, -92, 47, 92, -92, 47, 93, -92,
+	47, 95, -92, 47, 96, -92, 47, 97, -92, 47, 257, -20, 47, 259, -110, 47, 306, -55,
+	47, 307, -55, 47, 308, -55, 48, 79, -92, 48, 80, -92, 48, 81, -92, 48, 92, -92,
+	48, 93, -92, 48, 95, -92, 48, 96, -92, 48, 97, -92, 48, 257, -20, 48, 259, -110,
+	48, 306, -55, 48, 307, -55, 48, 308, -55, 50, 79, -92, 50, 80, -92, 50, 81, -92,
+	50, 92, -92, 50, 93, -92, 50, 95, -92, 50, 96, -92, 50, 97, -92, 50, 257, -20,
+	50, 259, -110, 50, 306, -55, 50, 307, -55, 50, 308, -55, 51, 79, -92, 51, 80, -92,
+	51, 81, -92, 51, 92, -92, 51, 93, -92, 51, 95, -92, 51, 96, -92, 51, 97, -92,
+	51, 257, -20, 51, 259, -110, 51, 306, -55, 51, 307, -55, 51, 308, -55, 53, 0, -20,
+	53, 2, -20, 53, 3, -20, 53, 4, -20, 53, 5, -20, 53, 6, -20, 53, 7, -20,
+	53, 8, -20, 53, 9, -20, 53, 10, -20, 54, 0, -20, 54, 2, -20, 54, 3, -20,
+	54, 4, -20, 54, 5, -20, 54, 6, -20, 54, 7, -20, 54, 8, -20, 54, 9, -20,
+	54, 10, -20, 55, 0, -20, 55, 2, -20, 55, 3, -20, 55, 4, -20, 55, 5, -20,
+	55, 6, -20, 55, 7, -20, 55, 8, -20, 55, 9, -20, 55, 10, -20, 56, 0, -20,
+	56, 2, -20, 56, 3, -20, 56, 4, -20, 56, 5, -20, 56, 6, -20, 56, 7, -20,
+	56, 8, -20, 56, 9, -20, 56, 10, -20, 57, 0, -20, 57, 2, -20, 57, 3, -20,
+	57, 4, -20, 57, 5, -20, 57, 6, -20, 57, 7, -20, 57, 8, -20, 57, 9, -20,
+	57, 10, -20, 58, 0, -40, 58, 2, -40, 58, 3, -40, 58, 4, -40, 58, 5, -40,
+	58, 6, -40, 58, 7, -40, 58, 8, -40, 58, 9, -40, 58, 10, -40, 58, 79, -40,
+	58, 80, -40, 58, 81, -40, 58, 92, -50, 58, 93, -50, 58, 94, -40, 58, 95, -50,
+	58, 96, -50, 58, 97, -50, 60, 0, -40, 60, 2, -40, 60, 3, -40, 60, 4, -40,
+	60, 5, -40, 60, 6, -40, 60, 7, -40, 60, 8, -40, 60, 9, -40, 60, 10, -40,
+	60, 79, -40, 60, 80, -40, 60, 81, -40, 60, 92, -50, 60, 93, -50, 60, 94, -40,
+	60, 95, -50, 60, 96, -50, 60, 97, -50, 61, 0, -40, 61, 2, -40, 61, 3, -40,
+	61, 4, -40, 61, 5, -40, 61, 6, -40, 61, 7, -40, 61, 8, -40, 61, 9, -40,
+	61, 10, -40, 61, 79, -40, 61, 80, -40, 61, 81, -40, 61, 92, -50, 61, 93, -50,
+	61, 94, -40, 61, 95, -50, 61, 96, -50, 61, 97, -50, 62, 0, -40, 62, 2, -40,
+	62, 3, -40, 62, 4, -40, 62, 5, -40, 62, 6, -40, 62, 7, -40, 62, 8, -40,
+	62, 9, -40, 62, 10, -40, 62, 79, -40, 62, 80, -40, 62, 81, -40, 62, 92, -50,
+	62, 93, -50, 62, 94, -40, 62, 95, -50, 62, 96, -50, 62, 97, -50, 63, 0, -40,
+	63, 2, -40, 63, 3, -40, 63, 4, -40, 63, 5, -40, 63, 6, -40, 63, 7, -40,
+	63, 8, -40, 63, 9, -40, 63, 10, -40, 63, 79, -40, 63, 80, -40, 63, 81, -40,
+	63, 92, -50, 63, 93, -50, 63, 94, -40, 63, 95, -50, 63, 96, -50, 63, 97, -50,
+	64, 0, -40, 64, 2, -40, 64, 3, -40, 64, 4, -40, 64, 5, -40, 64, 6, -40,
+	64, 7, -40, 64, 8, -40, 64, 9, -40, 64, 10, -40, 64, 79, -40, 64, 80, -40,
+	64, 81, -40, 64, 92, -50, 64, 93, -50, 64, 94, -40, 64, 95, -50, 64, 96, -50,
+	64, 97, -50, 65, 0, -40, 65, 2, -40, 65, 3, -40, 65, 4, -40, 65, 5, -40,
+	65, 6, -40, 65, 7, -40, 65, 8, -40, 65, 9, -40, 65, 10, -40, 65, 79, -40,
+	65, 80, -40, 65, 81, -40, 65, 92, -50, 65, 93, -50, 65, 94, -40, 65, 95, -50,
+	65, 96, -50, 65, 97, -50, 66, 0, -40, 66, 2, -40, 66, 3, -40, 66, 4, -40,
+	66, 5, -40, 66, 6, -40, 66, 7, -40, 66, 8, -40, 66, 9, -40, 66, 10, -40,
+	66, 79, -40, 66, 80, -40, 66, 81, -40, 66, 92, -50, 66, 93, -50, 66, 94, -40,
+	66, 95, -50, 66, 96, -50, 66, 97, -50, 67, 0, -40, 67, 2, -40, 67, 3, -40,
+	67, 4, -40, 67, 5, -40, 67, 6, -40, 67, 7, -40, 67, 8, -40, 67, 9, -40,
+	67, 10, -40, 67, 79, -40, 67, 80, -40, 67, 81, -40, 67, 92, -50, 67, 93, -50,
+	67, 94, -40, 67, 95, -50, 67, 96, -50, 67, 97, -50, 68, 0, -74, 68, 2, -74,
+	68, 3, -74, 68, 4, -74, 68, 5, -74, 68, 6, -74, 68, 7, -74, 68, 8, -74,
+	68, 9, -74, 68, 10, -74, 68, 102, -10, 68, 103, -10, 68, 104, -10, 68, 105, -10,
+	68, 107, -10, 68, 109, -10, 68, 110, -10, 68, 112, -10, 68, 113, -10, 68, 118, -10,
+	68, 138, -92, 68, 153, -20, 68, 154, -20, 68, 155, -20, 68, 156, -20, 68, 157, -20,
+	68, 158, -20, 68, 159, -20, 68, 162, -20, 68, 165, -20, 68, 223, -20, 68, 224, -20,
+	68, 225, -20, 68, 226, -20, 68, 229, -20, 68, 230, -20, 68, 231, -20, 68, 238, -20,
+	68, 239, -20, 68, 246, -110, 69, 83, -10, 69, 84, -10, 69, 85, -10, 69, 86, -10,
+	69, 87, -10, 69, 88, -10, 69, 89, -10, 69, 90, -10, 69, 91, -10, 69, 246, -20,
+	70, 58, -30, 70, 60, -30, 70, 61, -30, 70, 62, -30, 70, 63, -30, 70, 64, -30,
+	70, 65, -30, 70, 66, -30, 70, 67, -30, 70, 79, -40, 70, 80, -40, 70, 81, -40,
+	70, 83, -30, 70, 84, -30, 70, 85, -30, 70, 86, -30, 70, 87, -30, 70, 88, -30,
+	70, 89, -30, 70, 90, -30, 70, 91, -30, 70, 92, -55, 70, 93, -35, 70, 95, -35,
+	70, 96, -35, 70, 97, -35, 71, 58, -30, 71, 60, -30, 71, 61, -30, 71, 62, -30,
+	71, 63, -30, 71, 64, -30, 71, 65, -30, 71, 66, -30, 71, 67, -30, 71, 79, -40,
+	71, 80, -40, 71, 81, -40, 71, 83, -30, 71, 84, -30, 71, 85, -30, 71, 86, -30,
+	71, 87, -30, 71, 88, -30, 71, 89, -30, 71, 90, -30, 71, 91, -30, 71, 92, -55,
+	71, 93, -35, 71, 95, -35, 71, 96, -35, 71, 97, -35, 72, 58, -30, 72, 60, -30,
+	72, 61, -30, 72, 62, -30, 72, 63, -30, 72, 64, -30, 72, 65, -30, 72, 66, -30,
+	72, 67, -30, 72, 79, -40, 72, 80, -40, 72, 81, -40, 72, 83, -30, 72, 84, -30,
+	72, 85, -30, 72, 86, -30, 72, 87, -30, 72, 88, -30, 72, 89, -30, 72, 90, -30,
+	72, 91, -30, 72, 92, -55, 72, 93, -35, 72, 95, -35, 72, 96, -35, 72, 97, -35,
+	73, 58, -30, 73, 60, -30, 73, 61, -30, 73, 62, -30, 73, 63, -30, 73, 64, -30,
+	73, 65, -30, 73, 66, -30, 73, 67, -30, 73, 79, -40, 73, 80, -40, 73, 81, -40,
+	73, 83, -30, 73, 84, -30, 73, 85, -30, 73, 86, -30, 73, 87, -30, 73, 88, -30,
+	73, 89, -30, 73, 90, -30, 73, 91, -30, 73, 92, -55, 73, 93, -35, 73, 95, -35,
+	73, 96, -35, 73, 97, -35, 79, 0, -90, 79, 2, -90, 79, 3, -90, 79, 4, -90,
+	79, 5, -90, 79, 6, -90, 79, 7, -90, 79, 8, -90, 79, 9, -90, 79, 10, -90,
+	79, 58, -18, 79, 60, -18, 79, 61, -18, 79, 62, -18, 79, 63, -18, 79, 64, -18,
+	79, 65, -18, 79, 66, -18, 79, 67, -18, 79, 102, -92, 79, 103, -92, 79, 104, -52,
+	79, 105, -52, 79, 107, -52, 79, 109, -52, 79, 110, -52, 79, 112, -92, 79, 113, -92,
+	79, 118, -52, 79, 137, -74, 79, 138, -74, 79, 153, -92, 79, 154, -92, 79, 155, -92,
+	79, 156, -92, 79, 157, -52, 79, 158, -92, 79, 159, -52, 79, 162, -52, 79, 165, -92,
+	79, 190, -92, 79, 191, -18, 79, 192, -18, 79, 197, -18, 79, 223, -92, 79, 224, -92,
+	79, 225, -92, 79, 226, -92, 79, 229, -92, 79, 230, -92, 79, 231, -92, 79, 238, -92,
+	79, 239, -92, 79, 246, -90, 79, 262, -74, 79, 263, -74, 79, 265, -74, 79, 266, -74,
+	79, 275, -74, 79, 293, -92, 79, 294, -92, 79, 295, -92, 79, 296, -92, 79, 297, -92,
+	79, 298, -92, 79, 299, -92, 79, 301, -92, 79, 302, -92, 79, 304, -74, 79, 306, -34,
+	79, 307, -34, 79, 308, -34, 80, 0, -90, 80, 2, -90, 80, 3, -90, 80, 4, -90,
+	80, 5, -90, 80, 6, -90, 80, 7, -90, 80, 8, -90, 80, 9, -90, 80, 10, -90,
+	80, 58, -18, 80, 60, -18, 80, 61, -18, 80, 62, -18, 80, 63, -18, 80, 64, -18,
+	80, 65, -18, 80, 66, -18, 80, 67, -18, 80, 102, -92, 80, 103, -92, 80, 104, -52,
+	80, 105, -52, 80, 107, -52, 80, 109, -52, 80, 110, -52, 80, 112, -92, 80, 113, -92,
+	80, 118, -52, 80, 137, -74, 80, 138, -74, 80, 153, -92, 80, 154, -92, 80, 155, -92,
+	80, 156, -92, 80, 157, -52, 80, 158, -92, 80, 159, -52, 80, 162, -52, 80, 165, -92,
+	80, 190, -92, 80, 191, -18, 80, 192, -18, 80, 197, -18, 80, 223, -92, 80, 224, -92,
+	80, 225, -92, 80, 226, -92, 80, 229, -92, 80, 230, -92, 80, 231, -92, 80, 238, -92,
+	80, 239, -92, 80, 246, -90, 80, 262, -74, 80, 263, -74, 80, 265, -74, 80, 266, -74,
+	80, 275, -74, 80, 293, -92, 80, 294, -92, 80, 295, -92, 80, 296, -92, 80, 297, -92,
+	80, 298, -92, 80, 299, -92, 80, 301, -92, 80, 302, -92, 80, 304, -74, 80, 306, -34,
+	80, 307, -34, 80, 308, -34, 81, 0, -90, 81, 2, -90, 81, 3, -90, 81, 4, -90,
+	81, 5, -90, 81, 6, -90, 81, 7, -90, 81, 8, -90, 81, 9, -90, 81, 10, -90,
+	81, 58, -18, 81, 60, -18, 81, 61, -18, 81, 62, -18, 81, 63, -18, 81, 64, -18,
+	81, 65, -18, 81, 66, -18, 81, 67, -18, 81, 102, -92, 81, 103, -92, 81, 104, -52,
+	81, 105, -52, 81, 107, -52, 81, 109, -52, 81, 110, -52, 81, 112, -92, 81, 113, -92,
+	81, 118, -52, 81, 137, -74, 81, 138, -74, 81, 153, -92, 81, 154, -92, 81, 155, -92,
+	81, 156, -92, 81, 157, -52, 81, 158, -92, 81, 159, -52, 81, 162, -52, 81, 165, -92,
+	81, 190, -92, 81, 191, -18, 81, 192, -18, 81, 197, -18, 81, 223, -92, 81, 224, -92,
+	81, 225, -92, 81, 226, -92, 81, 229, -92, 81, 230, -92, 81, 231, -92, 81, 238, -92,
+	81, 239, -92, 81, 246, -90, 81, 262, -74, 81, 263, -74, 81, 265, -74, 81, 266, -74,
+	81, 275, -74, 81, 293, -92, 81, 294, -92, 81, 295, -92, 81, 296, -92, 81, 297, -92,
+	81, 298, -92, 81, 299, -92, 81, 301, -92, 81, 302, -92, 81, 304, -74, 81, 306, -34,
+	81, 307, -34, 81, 308, -34, 83, 0, -60, 83, 2, -60, 83, 3, -60, 83, 4, -60,
+	83, 5, -60, 83, 6, -60, 83, 7, -60, 83, 8, -60, 83, 9, -60, 83, 10, -60,
+	83, 138, -50, 83, 246, -50, 84, 0, -60, 84, 2, -60, 84, 3, -60, 84, 4, -60,
+	84, 5, -60, 84, 6, -60, 84, 7, -60, 84, 8, -60, 84, 9, -60, 84, 10, -60,
+	84, 138, -50, 84, 246, -50, 85, 0, -60, 85, 2, -60, 85, 3, -60, 85, 4, -60,
+	85, 5, -60, 85, 6, -60, 85, 7, -60, 85, 8, -60, 85, 9, -60, 85, 10, -60,
+	85, 138, -50, 85, 246, -50, 86, 0, -60, 86, 2, -60, 86, 3, -60, 86, 4, -60,
+	86, 5, -60, 86, 6, -60, 86, 7, -60, 86, 8, -60, 86, 9, -60, 86, 10, -60,
+	86, 138, -50, 86, 246, -50, 87, 0, -60, 87, 2, -60, 87, 3, -60, 87, 4, -60,
+	87, 5, -60, 87, 6, -60, 87, 7, -60, 87, 8, -60, 87, 9, -60, 87, 10, -60,
+	87, 138, -50, 87, 246, -50, 88, 0, -60, 88, 2, -60, 88, 3, -60, 88, 4, -60,
+	88, 5, -60, 88, 6, -60, 88, 7, -60, 88, 8, -60, 88, 9, -60, 88, 10, -60,
+	88, 138, -50, 88, 246, -50, 89, 0, -60, 89, 2, -60, 89, 3, -60, 89, 4, -60,
+	89, 5, -60, 89, 6, -60, 89, 7, -60, 89, 8, -60, 89, 9, -60, 89, 10, -60,
+	89, 138, -50, 89, 246, -50, 90, 0, -60, 90, 2, -60, 90, 3, -60, 90, 4, -60,
+	90, 5, -60, 90, 6, -60, 90, 7, -60, 90, 8, -60, 90, 9, -60, 90, 10, -60,
+	90, 138, -50, 90, 246, -50, 91, 0, -60, 91, 2, -60, 91, 3, -60, 91, 4, -60,
+	91, 5, -60, 91, 6, -60, 91, 7, -60, 91, 8, -60, 91, 9, -60, 91, 10, -60,
+	91, 138, -50, 91, 246, -50, 92, 0, -135, 92, 2, -135, 92, 3, -135, 92, 4, -135,
+	92, 5, -135, 92, 6, -135, 92, 7, -135, 92, 8, -135, 92, 9, -135, 92, 10, -135,
+	92, 32, -30, 92, 33, -30, 92, 34, -30, 92, 58, -45, 92, 60, -45, 92, 61, -45,
+	92, 62, -45, 92, 63, -45, 92, 64, -45, 92, 65, -45, 92, 66, -45, 92, 67, -45,
+	92, 102, -92, 92, 103, -92, 92, 104, -92, 92, 105, -92, 92, 107, -92, 92, 109, -92,
+	92, 110, -92, 92, 112, -92, 92, 113, -92, 92, 118, -92, 92, 137, -92, 92, 138, -129,
+	92, 153, -100, 92, 154, -100, 92, 155, -100, 92, 156, -100, 92, 157, -100, 92, 158, -100,
+	92, 159, -100, 92, 162, -100, 92, 165, -100, 92, 190, -74, 92, 191, -37, 92, 192, -37,
+	92, 193, -37, 92, 194, -37, 92, 195, -37, 92, 196, -37, 92, 197, -37, 92, 223, -100,
+	92, 224, -100, 92, 225, -100, 92, 226, -100, 92, 229, -100, 92, 230, -100, 92, 231, -100,
+	92, 238, -100, 92, 239, -100, 92, 246, -145, 92, 275, -92, 92, 293, -92, 92, 294, -92,
+	92, 295, -92, 92, 296, -92, 92, 297, -92, 92, 298, -92, 92, 299, -92, 92, 301, -92,
+	92, 302, -92, 93, 0, -120, 93, 2, -120, 93, 3, -120, 93, 4, -120, 93, 5, -120,
+	93, 6, -120, 93, 7, -120, 93, 8, -120, 93, 9, -120, 93, 10, -120, 93, 58, -10,
+	93, 60, -10, 93, 61, -10, 93, 62, -10, 93, 63, -10, 93, 64, -10, 93, 65, -10,
+	93, 66, -10, 93, 67, -10, 93, 102, -65, 93, 103, -65, 93, 104, -65, 93, 105, -65,
+	93, 107, -65, 93, 109, -65, 93, 110, -65, 93, 112, -65, 93, 113, -65, 93, 118, -65,
+	93, 137, -55, 93, 138, -92, 93, 153, -65, 93, 154, -65, 93, 155, -65, 93, 156, -65,
+	93, 157, -65, 93, 158, -65, 93, 159, -65, 93, 162, -65, 93, 165, -65, 93, 190, -37,
+	93, 191, -18, 93, 192, -18, 93, 197, -18, 93, 223, -75, 93, 224, -75, 93, 225, -75,
+	93, 226, -75, 93, 229, -75, 93, 230, -75, 93, 231, -75, 93, 238, -75, 93, 239, -75,
+	93, 246, -92, 93, 275, -55, 93, 293, -50, 93, 294, -50, 93, 295, -50, 93, 296, -50,
+	93, 297, -50, 93, 298, -50, 93, 299, -50, 93, 301, -50, 93, 302, -50, 93, 306, -60,
+	93, 307, -60, 93, 308, -60, 95, 0, -110, 95, 2, -110, 95, 3, -110, 95, 4, -110,
+	95, 5, -110, 95, 6, -110, 95, 7, -110, 95, 8, -110, 95, 9, -110, 95, 10, -110,
+	95, 58, -35, 95, 60, -35, 95, 61, -35, 95, 62, -35, 95, 63, -35, 95, 64, -35,
+	95, 65, -35, 95, 66, -35, 95, 67, -35, 95, 102, -85, 95, 103, -85, 95, 104, -85,
+	95, 105, -85, 95, 107, -85, 95, 109, -85, 95, 110, -85, 95, 112, -85, 95, 113, -85,
+	95, 118, -85, 95, 137, -92, 95, 138, -92, 95, 153, -111, 95, 154, -111, 95, 155, -111,
+	95, 156, -111, 95, 157, -71, 95, 158, -111, 95, 159, -71, 95, 162, -71, 95, 165, -111,
+	95, 190, -92, 95, 191, -37, 95, 192, -37, 95, 197, -37, 95, 223, -111, 95, 224, -111,
+	95, 225, -111, 95, 226, -111, 95, 229, -111, 95, 230, -111, 95, 231, -111, 95, 238, -111,
+	95, 239, -111, 95, 246, -92, 95, 275, -92, 95, 293, -92, 95, 294, -92, 95, 295, -92,
+	95, 296, -92, 95, 297, -92, 95, 298, -92, 95, 299, -92, 95, 301, -92, 95, 302, -92,
+	96, 0, -110, 96, 2, -110, 96, 3, -110, 96, 4, -110, 96, 5, -110, 96, 6, -110,
+	96, 7, -110, 96, 8, -110, 96, 9, -110, 96, 10, -110, 96, 58, -35, 96, 60, -35,
+	96, 61, -35, 96, 62, -35, 96, 63, -35, 96, 64, -35, 96, 65, -35, 96, 66, -35,
+	96, 67, -35, 96, 102, -85, 96, 103, -85, 96, 104, -85, 96, 105, -85, 96, 107, -85,
+	96, 109, -85, 96, 110, -85, 96, 112, -85, 96, 113, -85, 96, 118, -85, 96, 137, -92,
+	96, 138, -92, 96, 153, -111, 96, 154, -111, 96, 155, -111, 96, 156, -111, 96, 157, -71,
+	96, 158, -111, 96, 159, -71, 96, 162, -71, 96, 165, -111, 96, 190, -92, 96, 191, -37,
+	96, 192, -37, 96, 197, -37, 96, 223, -111, 96, 224, -111, 96, 225, -111, 96, 226, -111,
+	96, 229, -111, 96, 230, -111, 96, 231, -111, 96, 238, -111, 96, 239, -111, 96, 246, -92,
+	96, 275, -92, 96, 293, -92, 96, 294, -92, 96, 295, -92, 96, 296, -92, 96, 297, -92,
+	96, 298, -92, 96, 299, -92, 96, 301, -92, 96, 302, -92, 97, 0, -110, 97, 2, -110,
+	97, 3, -110, 97, 4, -110, 97, 5, -110, 97, 6, -110, 97, 7, -110, 97, 8, -110,
+	97, 9, -110, 97, 10, -110, 97, 58, -35, 97, 60, -35, 97, 61, -35, 97, 62, -35,
+	97, 63, -35, 97, 64, -35, 97, 65, -35, 97, 66, -35, 97, 67, -35, 97, 102, -85,
+	97, 103, -85, 97, 104, -85, 97, 105, -85, 97, 107, -85, 97, 109, -85, 97, 110, -85,
+	97, 112, -85, 97, 113, -85, 97, 118, -85, 97, 137, -92, 97, 138, -92, 97, 153, -111,
+	97, 154, -111, 97, 155, -111, 97, 156, -111, 97, 157, -71, 97, 158, -111, 97, 159, -71,
+	97, 162, -71, 97, 165, -111, 97, 190, -92, 97, 191, -37, 97, 192, -37, 97, 197, -37,
+	97, 223, -111, 97, 224, -111, 97, 225, -111, 97, 226, -111, 97, 229, -111, 97, 230, -111,
+	97, 231, -111, 97, 238, -111, 97, 239, -111, 97, 246, -92, 97, 275, -92, 97, 293, -92,
+	97, 294, -92, 97, 295, -92, 97, 296, -92, 97, 297, -92, 97, 298, -92, 97, 299, -92,
+	97, 301, -92, 97, 302, -92, 102, 303, -25, 103, 303, -25, 104, 303, -25, 105, 303, -25,
+	107, 303, -25, 109, 303, -25, 110, 303, -25, 112, 303, -25, 113, 303, -25, 118, 303, -25,
+	119, 119, -10, 119, 246, -40, 119, 293, -20, 119, 294, -20, 119, 295, -20, 119, 296, -20,
+	119, 297, -20, 119, 298, -20, 119, 299, -20, 119, 301, -20, 119, 302, -20, 119, 303, -15,
+	138, 257, -45, 138, 259, -55, 142, 304, -15, 146, 304, -15, 153, 303, -15, 154, 303, -15,
+	155, 303, -15, 156, 303, -15, 157, 303, -15, 158, 303, -15, 159, 303, -15, 162, 303, -15,
+	165, 303, -15, 170, 138, -15, 170, 152, -35, 170, 191, -25, 170, 223, -25, 170, 224, -25,
+	170, 225, -25, 170, 226, -25, 170, 229, -25, 170, 230, -25, 170, 231, -25, 170, 238, -25,
+	170, 239, -25, 170, 246, -15, 170, 257, 50, 170, 259, 55, 177, 246, -15, 178, 246, -15,
+	179, 246, -15, 188, 306, -15, 188, 307, -15, 188, 308, -15, 191, 303, -10, 192, 303, -10,
+	193, 303, -10, 194, 303, -10, 195, 303, -10, 196, 303, -10, 197, 303, -10, 199, 153, -10,
+	199, 154, -10, 199, 155, -10, 199, 156, -10, 199, 157, -10, 199, 158, -10, 199, 159, -10,
+	199, 162, -10, 199, 165, -10, 199, 223, -15, 199, 224, -15, 199, 225, -15, 199, 226, -15,
+	199, 229, -15, 199, 230, -15, 199, 231, -15, 199, 238, -15, 199, 239, -15, 199, 306, -15,
+	199, 307, -15, 199, 308, -15, 200, 153, -10, 200, 154, -10, 200, 155, -10, 200, 156, -10,
+	200, 157, -10, 200, 158, -10, 200, 159, -10, 200, 162, -10, 200, 165, -10, 200, 223, -15,
+	200, 224, -15, 200, 225, -15, 200, 226, -15, 200, 229, -15, 200, 230, -15, 200, 231, -15,
+	200, 238, -15, 200, 239, -15, 200, 306, -15, 200, 307, -15, 200, 308, -15, 215, 303, -40,
+	216, 303, -40, 217, 303, -40, 218, 303, -40, 221, 303, -40, 223, 303, -10, 223, 304, -10,
+	224, 303, -10, 224, 304, -10, 225, 303, -10, 225, 304, -10, 226, 303, -10, 226, 304, -10,
+	229, 303, -10, 229, 304, -10, 230, 303, -10, 230, 304, -10, 231, 303, -10, 231, 304, -10,
+	238, 303, -10, 238, 304, -10, 239, 303, -10, 239, 304, -10, 246, 257, -55, 246, 259, -55,
+	256, 0, -10, 256, 2, -10, 256, 3, -10, 256, 4, -10, 256, 5, -10, 256, 6, -10,
+	256, 7, -10, 256, 8, -10, 256, 9, -10, 256, 10, -10, 258, 0, -10, 258, 2, -10,
+	258, 3, -10, 258, 4, -10, 258, 5, -10, 258, 6, -10, 258, 7, -10, 258, 8, -10,
+	258, 9, -10, 258, 10, -10, 258, 258, -63, 259, 142, -20, 259, 146, -20, 259, 259, -63,
+	259, 262, -20, 259, 263, -20, 259, 265, -20, 259, 266, -20, 259, 269, -37, 259, 270, -37,
+	259, 271, -37, 259, 272, -37, 259, 273, -37, 259, 279, -74, 259, 303, -20, 262, 129, -18,
+	262, 130, -18, 262, 132, -18, 262, 133, -18, 262, 138, -92, 262, 153, -18, 262, 154, -18,
+	262, 155, -18, 262, 156, -18, 262, 157, -18, 262, 158, -18, 262, 159, -18, 262, 162, -18,
+	262, 165, -18, 262, 177, -10, 262, 178, -10, 262, 179, -10, 262, 190, -37, 262, 215, -15,
+	262, 216, -15, 262, 217, -15, 262, 218, -15, 262, 221, -15, 262, 223, -18, 262, 224, -18,
+	262, 225, -18, 262, 226, -18, 262, 229, -18, 262, 230, -18, 262, 231, -18, 262, 238, -18,
+	262, 239, -18, 262, 240, -10, 262, 246, -100, 262, 251, -18, 262, 303, -10, 263, 129, -18,
+	263, 130, -18, 263, 132, -18, 263, 133, -18, 263, 138, -92, 263, 153, -18, 263, 154, -18,
+	263, 155, -18, 263, 156, -18, 263, 157, -18, 263, 158, -18, 263, 159, -18, 263, 162, -18,
+	263, 165, -18, 263, 177, -10, 263, 178, -10, 263, 179, -10, 263, 190, -37, 263, 215, -15,
+	263, 216, -15, 263, 217, -15, 263, 218, -15, 263, 221, -15, 263, 223, -18, 263, 224, -18,
+	263, 225, -18, 263, 226, -18, 263, 229, -18, 263, 230, -18, 263, 231, -18, 263, 238, -18,
+	263, 239, -18, 263, 240, -10, 263, 246, -100, 263, 251, -18, 263, 303, -10, 265, 129, -18,
+	265, 130, -18, 265, 132, -18, 265, 133, -18, 265, 138, -92, 265, 153, -18, 265, 154, -18,
+	265, 155, -18, 265, 156, -18, 265, 157, -18, 265, 158, -18, 265, 159, -18, 265, 162, -18,
+	265, 165, -18, 265, 177, -10, 265, 178, -10, 265, 179, -10, 265, 190, -37, 265, 215, -15,
+	265, 216, -15, 265, 217, -15, 265, 218, -15, 265, 221, -15, 265, 223, -18, 265, 224, -18,
+	265, 225, -18, 265, 226, -18, 265, 229, -18, 265, 230, -18, 265, 231, -18, 265, 238, -18,
+	265, 239, -18, 265, 240, -10, 265, 246, -100, 265, 251, -18, 265, 303, -10, 266, 129, -18,
+	266, 130, -18, 266, 132, -18, 266, 133, -18, 266, 138, -92, 266, 153, -18, 266, 154, -18,
+	266, 155, -18, 266, 156, -18, 266, 157, -18, 266, 158, -18, 266, 159, -18, 266, 162, -18,
+	266, 165, -18, 266, 177, -10, 266, 178, -10, 266, 179, -10, 266, 190, -37, 266, 215, -15,
+	266, 216, -15, 266, 217, -15, 266, 218, -15, 266, 221, -15, 266, 223, -18, 266, 224, -18,
+	266, 225, -18, 266, 226, -18, 266, 229, -18, 266, 230, -18, 266, 231, -18, 266, 238, -18,
+	266, 239, -18, 266, 240, -10, 266, 246, -100, 266, 251, -18, 266, 303, -10, 279, 0, -55,
+	279, 2, -55, 279, 3, -55, 279, 4, -55, 279, 5, -55, 279, 6, -55, 279, 7, -55,
+	279, 8, -55, 279, 9, -55, 279, 10, -55, 279, 79, -30, 279, 80, -30, 279, 81, -30,
+	279, 92, -45, 279, 93, -30, 279, 95, -55, 279, 96, -55, 279, 97, -55, 303, 102, -10,
+	303, 103, -10, 303, 104, -10, 303, 105, -10, 303, 107, -10, 303, 109, -10, 303, 110, -10,
+	303, 112, -10, 303, 113, -10, 303, 118, -10, 303, 138, -55, 303, 153, -10, 303, 154, -10,
+	303, 155, -10, 303, 156, -10, 303, 157, -10, 303, 158, -10, 303, 159, -10, 303, 162, -10,
+	303, 165, -10, 303, 223, -10, 303, 224, -10, 303, 225, -10, 303, 226, -10, 303, 229, -10,
+	303, 230, -10, 303, 231, -10, 303, 238, -10, 303, 239, -10, 303, 246, -70, 304, 138, -55,
+	304, 223, -10, 304, 224, -10, 304, 225, -10, 304, 226, -10, 304, 229, -10, 304, 230, -10,
+	304, 231, -10, 304, 238, -10, 304, 239, -10, 304, 246, -70, 306, 138, -55, 306, 153, -10,
+	306, 154, -10, 306, 155, -10, 306, 156, -10, 306, 157, -10, 306, 158, -10, 306, 159, -10,
+	306, 162, -10, 306, 165, -10, 306, 223, -25, 306, 224, -25, 306, 225, -25, 306, 226, -25,
+	306, 229, -25, 306, 230, -25, 306, 231, -25, 306, 238, -25, 306, 239, -25, 306, 246, -70,
+	307, 138, -55, 307, 153, -10, 307, 154, -10, 307, 155, -10, 307, 156, -10, 307, 157, -10,
+	307, 158, -10, 307, 159, -10, 307, 162, -10, 307, 165, -10, 307, 223, -25, 307, 224, -25,
+	307, 225, -25, 307, 226, -25, 307, 229, -25, 307, 230, -25, 307, 231, -25, 307, 238, -25,
+	307, 239, -25, 307, 246, -70, 308, 138, -55, 308, 153, -10, 308, 154, -10, 308, 155, -10,
+	308, 156, -10, 308, 157, -10, 308, 158, -10, 308, 159, -10, 308, 162, -10, 308, 165, -10,
+	308, 223, -25, 308, 224, -25, 308, 225, -25, 308, 226, -25, 308, 229, -25, 308, 230, -25,
+	308, 231, -25, 308, 238, -25, 308, 239, -25, 308, 246, -70,
+}
+
+// timesItalicKernPairs are the kerning pairs loaded from afms/Times-Italic.afm, stored as
+// (left, right, kerning) triples where left and right are indices into
+// type1CommonRunes.
+// See afms/MustRead.html for license information.
+var timesItalicKernPairs = []int16{
+	0, 12, -30, 0, 13, -30, 0, 14, -30, 0, 15, -30, 0, 32, -35, 0, 33, -35,
+	0, 34, -35, 0, 58, -40, 0, 60, -40, 0, 61, -40, 0, 62, -40, 0, 63, -40,
+	0, 64, -40, 0, 65, -40, 0, 66, -40, 0, 67, -40, 0, 69, -40, 0, 79, -37,
+	0, 80, -37, 0, 81, -37, 0, 83, -50, 0, 84, -50, 0, 85, -50, 0, 86, -50,
+	0, 87, -50, 0, 88, -50, 0, 89, -50, 0, 90, -50, 0, 91, -50, 0, 92, -105,
+	0, 93, -95, 0, 95, -55, 0, 96, -55, 0, 97, -55, 0, 259, -37, 0, 293, -20,
+	0, 294, -20, 0, 295, -20, 0, 296, -20, 0, 297, -20, 0, 298, -20, 0, 299, -20,
+	0, 301, -20, 0, 302, -20, 0, 303, -55, 0, 304, -55, 0, 306, -55, 0, 307, -55,
+	0, 308, -55, 2, 12, -30, 2, 13, -30, 2, 14, -30, 2, 15, -30, 2, 32, -35,
+	2, 33, -35, 2, 34, -35, 2, 58, -40, 2, 60, -40, 2, 61, -40, 2, 62, -40,
+	2, 63, -40, 2, 64, -40, 2, 65, -40, 2, 66, -40, 2, 67, -40, 2, 69, -40,
+	2, 79, -37, 2, 80, -37, 2, 81, -37, 2, 83, -50, 2, 84, -50, 2, 85, -50,
+	2, 86, -50, 2, 87, -50, 2, 88, -50, 2, 89, -50, 2, 90, -50, 2, 91, -50,
+	2, 92, -105, 2, 93, -95, 2, 95, -55, 2, 96, -55, 2, 97, -55, 2, 259, -37,
+	2, 293, -20, 2, 294, -20, 2, 295, -20, 2, 296, -20, 2, 297, -20, 2, 298, -20,
+	2, 299, -20, 2, 301, -20, 2, 302, -20, 2, 303, -55, 2, 304, -55, 2, 306, -55,
+	2, 307, -55, 2, 308, -55, 3, 12, -30, 3, 13, -30, 3, 14, -30, 3, 15, -30,
+	3, 32, -35, 3, 33, -35, 3, 34, -35, 3, 58, -40, 3, 60, -40, 3, 61, -40,
+	3, 62, -40, 3, 63, -40, 3, 64, -40, 3, 65, -40, 3, 66, -40, 3, 67, -40,
+	3, 69, -40, 3, 79, -37, 3, 80, -37, 3, 81, -37, 3, 83, -50, 3, 84, -50,
+	3, 85, -50, 3, 86, -50, 3, 87, -50, 3, 88, -50, 3, 89, -50, 3, 90, -50,
+	3, 91, -50, 3, 92, -105, 3, 93, -95, 3, 95, -55, 3, 96, -55, 3, 97, -55,
+	3, 259, -37, 3, 293, -20, 3, 294, -20, 3, 295, -20, 3, 296, -20, 3, 297, -20,
+	3, 298, -20, 3, 299, -20, 3, 301, -20, 3, 302, -20, 3, 303, -55, 3, 304, -55,
+	3, 306, -55, 3, 307, -55, 3, 308, -55, 4, 12, -30, 4, 13, -30, 4, 14, -30,
+	4, 15, -30, 4, 32, -35, 4, 33, -35, 4, 34, -35, 4, 58, -40, 4, 60, -40,
+	4, 61, -40, 4, 62, -40, 4, 63, -40, 4, 64, -40, 4, 65, -40, 4, 66, -40,
+	4, 67, -40, 4, 69, -40, 4, 79, -37, 4, 80, -37, 4, 81, -37, 4, 83, -50,
+	4, 84, -50, 4, 85, -50, 4, 86, -50, 4, 87, -50, 4, 88, -50, 4, 89, -50,
+	4, 90, -50, 4, 91, -50, 4, 92, -105, 4, 93, -95, 4, 95, -55, 4, 96, -55,
+	4, 97, -55, 4, 259, -37, 4, 293, -20, 4, 294, -20, 4, 295, -20, 4, 296, -20,
+	4, 297, -20, 4, 298, -20, 4, 299, -20, 4, 301, -20, 4, 302, -20, 4, 303, -55,
+	4, 304, -55, 4, 306, -55, 4, 307, -55, 4, 308, -55, 5, 12, -30, 5, 13, -30,
+	5, 14, -30, 5, 15, -30, 5, 32, -35, 5, 33, -35, 5, 34, -35, 5, 58, -40,
+	5, 60, -40, 5, 61, -40, 5, 62, -40, 5, 63, -40, 5, 64, -40, 5, 65, -40,
+	5, 66, -40, 5, 67, -40, 5, 69, -40, 5, 79, -37, 5, 80, -37, 5, 81, -37,
+	5, 83, -50, 5, 84, -50, 5, 85, -50, 5, 86, -50, 5, 87, -50, 5, 88, -50,
+	5, 89, -50, 5, 90, -50, 5, 91, -50, 5, 92, -105, 5, 93, -95, 5, 95, -55,
+	5, 96, -55, 5, 97, -55, 5, 259, -37, 5, 293, -20, 5, 294, -20, 5, 295, -20,
+	5, 296, -20, 5, 297, -20, 5, 298, -20, 5, 299, -20, 5, 301, -20, 5, 302, -20,
+	5, 303, -55, 5, 304, -55, 5, 306, -55, 5, 307, -55, 5, 308, -55, 6, 12, -30,
+	6, 13, -30, 6, 14, -30, 6, 15, -30, 6, 32, -35, 6, 33, -35, 6, 34, -35,
+	6, 58, -40, 6, 60, -40, 6, 61, -40, 6, 62, -40, 6, 63, -40, 6, 64, -40,
+	6, 65, -40, 6, 66, -40, 6, 67, -40, 6, 69, -40, 6, 79, -37, 6, 80, -37,
+	6, 81, -37, 6, 83, -50, 6, 84, -50, 6, 85, -50, 6, 86, -50, 6, 87, -50,
+	6, 88, -50, 6, 89, -50, 6, 90, -50, 6, 91, -50, 6, 92, -105, 6, 93, -95,
+	6, 95, -55, 6, 96, -55, 6, 97, -55, 6, 259, -37, 6, 293, -20, 6, 294, -20,
+	6, 295, -20, 6, 296, -20, 6, 297, -20, 6, 298, -20, 6, 299, -20, 6, 301, -20,
+	6, 302, -20, 6, 303, -55, 6, 304, -55, 6, 306, -55, 6, 307, -55, 6, 308, -55,
+	7, 12, -30, 7, 13, -30, 7, 14, -30, 7, 15, -30, 7, 32, -35, 7, 33, -35,
+	7, 34, -35, 7, 58, -40, 7, 60, -40, 7, 61, -40, 7, 62, -40, 7, 63, -40,
+	7, 64, -40, 7, 65, -40, 7, 66, -40, 7, 67, -40, 7, 69, -40, 7, 79, -37,
+	7, 80, -37, 7, 81, -37, 7, 83, -50, 7, 84, -50, 7, 85, -50, 7, 86, -50,
+	7, 87, -50, 7, 88, -50, 7, 89, -50, 7, 90, -50, 7, 91, -50, 7, 92, -105,
+	7, 93, -95, 7, 95, -55, 7, 96, -55, 7, 97, -55, 7, 259, -37, 7, 293, -20,
+	7, 294, -20, 7, 295, -20, 7, 296, -20, 7, 297, -20, 7, 298, -20, 7, 299, -20,
+	7, 301, -20, 7, 302, -20, 7, 303, -55, 7, 304, -55, 7, 306, -55, 7, 307, -55,
+	7, 308, -55, 8, 12, -30, 8, 13, -30, 8, 14, -30, 8, 15, -30, 8, 32, -35,
+	8, 33, -35, 8, 34, -35, 8, 58, -40, 8, 60, -40, 8, 61, -40, 8, 62, -40,
+	8, 63, -40, 8, 64, -40, 8, 65, -40, 8, 66, -40, 8, 67, -40, 8, 69, -40,
+	8, 79, -37, 8, 80, -37, 8, 81, -37, 8, 83, -50, 8, 84, -50, 8, 85, -50,
+	8, 86, -50, 8, 87, -50, 8, 88, -50, 8, 89, -50, 8, 90, -50, 8, 91, -50,
+	8, 92, -105, 8, 93, -95, 8, 95, -55, 8, 96, -55, 8, 97, -55, 8, 259, -37,
+	8, 293, -20, 8, 294, -20, 8, 295, -20, 8, 296, -20, 8, 297, -20, 8, 298, -20,
+	8, 299, -20, 8, 301, -20, 8, 302, -20, 8, 303, -55, 8, 304, -55, 8, 306, -55,
+	8, 307, -55, 8, 308, -55, 9, 12, -30, 9, 13, -30, 9, 14, -30, 9, 15, -30,
+	9, 32, -35, 9, 33, -35, 9, 34, -35, 9, 58, -40, 9, 60, -40, 9, 61, -40,
+	9, 62, -40, 9, 63, -40, 9, 64, -40, 9, 65, -40, 9, 66, -40, 9, 67, -40,
+	9, 69, -40, 9, 79, -37, 9, 80, -37, 9, 81, -37, 9, 83, -50, 9, 84, -50,
+	9, 85, -50, 9, 86, -50, 9, 87, -50, 9, 88, -50, 9, 89, -50, 9, 90, -50,
+	9, 91, -50, 9, 92, -105, 9, 93, -95, 9, 95, -55, 9, 96, -55, 9, 97, -55,
+	9, 259, -37, 9, 293, -20, 9, 294, -20, 9, 295, -20, 9, 296, -20, 9, 297, -20,
+	9, 298, -20, 9, 299, -20, 9, 301, -20, 9, 302, -20, 9, 303, -55, 9, 304, -55,
+	9, 306, -55, 9, 307, -55, 9, 308, -55, 10, 12, -30, 10, 13, -30, 10, 14, -30,
+	10, 15, -30, 10, 32, -35, 10, 33, -35, 10, 34, -35, 10, 58, -40, 10, 60, -40,
+	10, 61, -40, 10, 62, -40, 10, 63, -40, 10, 64, -40, 10, 65, -40, 10, 66, -40,
+	10, 67, -40, 10, 69, -40, 10, 79, -37, 10, 80, -37, 10, 81, -37, 10, 83, -50,
+	10, 84, -50, 10, 85, -50, 10, 86, -50, 10, 87, -50, 10, 88, -50, 10, 89, -50,
+	10, 90, -50, 10, 91, -50, 10, 92, -105, 10, 93, -95, 10, 95, -55, 10, 96, -55,
+	10, 97, -55, 10, 259, -37, 10, 293, -20, 10, 294, -20, 10, 295, -20, 10, 296, -20,
+	10, 297, -20, 10, 298, -20, 10, 299, -20, 10, 301, -20, 10, 302, -20, 10, 303, -55,
+	10, 304, -55, 10, 306, -55, 10, 307, -55, 10, 308, -55, 11, 0, -25, 11, 2, -25,
+	11, 3, -25, 11, 4, -25, 11, 5, -25, 11, 6, -25, 11, 7, -25, 11, 8, -25,
+	11, 9, -25, 11, 10, -25, 11, 83, -10, 11, 84, -10, 11, 85, -10, 11, 86, -10,
+	11, 87, -10, 11, 88, -10, 11, 89, -10, 11, 90, -10, 11, 91, -10, 16, 0, -35,
+	16, 2, -35, 16, 3, -35, 16, 4, -35, 16, 5, -35, 16, 6, -35, 16, 7, -35,
+	16, 8, -35, 16, 9, -35, 16, 10, -35, 16, 92, -40, 16, 93, -40, 16, 95, -40,
+	16, 96, -40, 16, 97, -40, 17, 0, -35, 17, 2, -35, 17, 3, -35, 17, 4, -35,
+	17, 5, -35, 17, 6, -35, 17, 7, -35, 17, 8, -35, 17, 9, -35, 17, 10, -35,
+	17, 92, -40, 17, 93, -40, 17, 95, -40, 17, 96, -40, 17, 97, -40, 18, 0, -35,
+	18, 2, -35, 18, 3, -35, 18, 4, -35, 18, 5, -35, 18, 6, -35, 18, 7, -35,
+	18, 8, -35, 18, 9, -35, 18, 10, -35, 18, 92, -40, 18, 93, -40, 18, 95, -40,
+	18, 96, -40, 18, 97, -40, 31, 0, -115, 31, 2, -115, 31, 3, -115, 31, 4, -115,
+	31, 5, -115, 31, 6, -115, 31, 7, -115, 31, 8, -115, 31, 9, -115, 31, 10, -115,
+	31, 102, -75, 31, 103, -75, 31, 104, -75, 31, 105, -75, 31, 107, -75, 31, 109, -75,
+	31, 110, -75, 31, 112, -75, 31, 113, -75, 31, 118, -75, 31, 138, -135, 31, 153, -75,
+	31, 154, -75, 31, 155, -75, 31, 156, -75, 31, 157, -75, 31, 158, -75, 31, 159, -75,
+	31, 162, -75, 31, 165, -75, 31, 191, -45, 31, 192, -45, 31, 193, -45, 31, 194, -45,
+	31, 195, -45, 31, 196, -45, 31, 197, -45, 31, 223, -105, 31, 224, -105, 31, 225, -105,
+	31, 226, -105, 31, 229, -105, 31, 230, -105, 31, 231, -105, 31, 238, -105, 31, 239, -105,
+	31, 246, -135, 31, 262, -55, 31, 263, -55, 31, 265, -55, 31, 266, -55, 44, 0, -40,
+	44, 2, -40, 44, 3, -40, 44, 4, -40, 44, 5, -40, 44, 6, -40, 44, 7, -40,
+	44, 8, -40, 44, 9, -40, 44, 10, -40, 44, 102, -35, 44, 103, -35, 44, 104, -35,
+	44, 105, -35, 44, 107, -35, 44, 109, -35, 44, 110, -35, 44, 112, -35, 44, 113, -35,
+	44, 118, -35, 44, 138, -25, 44, 153, -25, 44, 154, -25, 44, 155, -25, 44, 156, -25,
+	44, 157, -25, 44, 158, -25, 44, 159, -25, 44, 162, -25, 44, 165, -25, 44, 223, -25,
+	44, 224, -25, 44, 225, -25, 44, 226, -25, 44, 229, -25, 44, 230, -25, 44, 231, -25,
+	44, 238, -25, 44, 239, -25, 44, 246, -25, 44, 293, -35, 44, 294, -35, 44, 295, -35,
+	44, 296, -35, 44, 297, -35, 44, 298, -35, 44, 299, -35, 44, 301, -35, 44, 302, -35,
+	45, 58, -50, 45, 60, -50, 45, 61, -50, 45, 62, -50, 45, 63, -50, 45, 64, -50,
+	45, 65, -50, 45, 66, -50, 45, 67, -50, 45, 153, -35, 45, 154, -35, 45, 155, -35,
+	45, 156, -35, 45, 157, -35, 45, 158, -35, 45, 159, -35, 45, 162, -35, 45, 165, -35,
+	45, 223, -40, 45, 224, -40, 45, 225, -40, 45, 226, -40, 45, 229, -40, 45, 230, -40,
+	45, 231, -40, 45, 238, -40, 45, 239, -40, 45, 293, -40, 45, 294, -40, 45, 295, -40,
+	45, 296, -40, 45, 297, -40, 45, 298, -40, 45, 299, -40, 45, 301, -40, 45, 302, -40,
+	45, 306, -40, 45, 307, -40, 45, 308, -40, 46, 58, -50, 46, 60, -50, 46, 61, -50,
+	46, 62, -50, 46, 63, -50, 46, 64, -50, 46, 65, -50, 46, 66, -50, 46, 67, -50,
+	46, 153, -35, 46, 154, -35, 46, 155, -35, 46, 156, -35, 46, 157, -35, 46, 158, -35,
+	46, 159, -35, 46, 162, -35, 46, 165, -35, 46, 223, -40, 46, 224, -40, 46, 225, -40,
+	46, 226, -40, 46, 229, -40, 46, 230, -40, 46, 231, -40, 46, 238, -40, 46, 239, -40,
+	46, 293, -40, 46, 294, -40, 46, 295, -40, 46, 296, -40, 46, 297, -40, 46, 298, -40,
+	46, 299, -40, 46, 301, -40, 46, 302, -40, 46, 306, -40, 46, 307, -40, 46, 308, -40,
+	47, 79, -20, 47, 80, -20, 47, 81, -20, 47, 92, -55, 47, 93, -55, 47, 95, -20,
+	47, 96, -20, 47, 97, -20, 47, 259, -37, 47, 306, -30, 47, 307, -30, 47, 308, -30,
+	48, 79, -20, 48, 80, -20, 48, 81, -20, 48, 92, -55, 48, 93, -55, 48, 95, -20,
+	48, 96, -20, 48, 97, -20, 48, 259, -37, 48, 306, -30, 48, 307, -30, 48, 308, -30,
+	50, 79, -20, 50, 80, -20, 50, 81, -20, 50, 92, -55, 50, 93, -55, 50, 95, -20,
+	50, 96, -20, 50, 97, -20, 50, 259, -37, 50, 306, -30, 50, 307, -30, 50, 308, -30,
+	51, 79, -20, 51, 80, -20, 51, 81, -20, 51, 92, -55, 51, 93, -55, 51, 95, -20,
+	51, 96, -20, 51, 97, -20, 51, 259, -37, 51, 306, -30, 51, 307, -30, 51, 308, -30,
+	53, 0, -27, 53, 2, -27, 53, 3, -27, 53, 4, -27, 53, 5, -27, 53, 6, -27,
+	53, 7, -27, 53, 8, -27, 53, 9, -27, 53, 10, -27, 54, 0, -27, 54, 2, -27,
+	54, 3, -27, 54, 4, -27, 54, 5, -27, 54, 6, -27, 54, 7, -27, 54, 8, -27,
+	54, 9, -27, 54, 10, -27, 55, 0, -27, 55, 2, -27, 55, 3, -27, 55, 4, -27,
+	55, 5, -27, 55, 6, -27, 55, 7, -27, 55, 8, -27, 55, 9, -27, 55, 10, -27,
+	56, 0, -27, 56, 2, -27, 56, 3, -27, 56, 4, -27, 56, 5, -27, 56, 6, -27,
+	56, 7, -27, 56, 8, -27, 56, 9, -27, 56, 10, -27, 57, 0, -27, 57, 2, -27,
+	57, 3, -27, 57, 4, -27, 57, 5, -27, 57, 6, -27, 57, 7, -27, 57, 8, -27,
+	57, 9, -27, 57, 10, -27, 58, 0, -55, 58, 2, -55, 58, 3, -55, 58, 4, -55,
+	58, 5, -55, 58, 6, -55, 58, 7, -55, 58, 8, -55, 58, 9, -55, 58, 10, -55,
+	58, 79, -40, 58, 80, -40, 58, 81, -40, 58, 92, -50, 58, 93, -50, 58, 94, -40,
+	58, 95, -50, 58, 96, -50, 58, 97, -50, 60, 0, -55, 60, 2, -55, 60, 3, -55,
+	60, 4, -55, 60, 5, -55, 60, 6, -55, 60, 7, -55, 60, 8, -55, 60, 9, -55,
+	60, 10, -55, 60, 79, -40, 60, 80, -40, 60, 81, -40, 60, 92, -50, 60, 93, -50,
+	60, 94, -40, 60, 95, -50, 60, 96, -50, 60, 97, -50, 61, 0, -55, 61, 2, -55,
+	61, 3, -55, 61, 4, -55, 61, 5, -55, 61, 6, -55, 61, 7, -55, 61, 8, -55,
+	61, 9, -55, 61, 10, -55, 61, 79, -40, 61, 80, -40, 61, 81, -40, 61, 92, -50,
+	61, 93, -50, 61, 94, -40, 61, 95, -50, 61, 96, -50, 61, 97, -50, 62, 0, -55,
+	62, 2, -55, 62, 3, -55, 62, 4, -55, 62, 5, -55, 62, 6, -55, 62, 7, -55,
+	62, 8, -55, 62, 9, -55, 62, 10, -55, 62, 79, -40, 62, 80, -40, 62, 81, -40,
+	62, 92, -50, 62, 93, -50, 62, 94, -40, 62, 95, -50, 62, 96, -50, 62, 97, -50,
+	63, 0, -55, 63, 2, -55, 63, 3, -55, 63, 4, -55, 63, 5, -55, 63, 6, -55,
+	63, 7, -55, 63, 8, -55, 63, 9, -55, 63, 10, -55, 63, 79, -40, 63, 80, -40,
+	63, 81, -40, 63, 92, -50, 63, 93, -50, 63, 94, -40, 63, 95, -50, 63, 96, -50,
+	63, 97, -50, 64, 0, -55, 64, 2, -55, 64, 3, -55, 64, 4, -55, 64, 5, -55,
+	64, 6, -55, 64, 7, -55, 64, 8, -55, 64, 9, -55, 64, 10, -55, 64, 79, -40,
+	64, 80, -40, 64, 81, -40, 64, 92, -50, 64, 93, -50, 64, 94, -40, 64, 95, -50,
+	64, 96, -50, 64, 97, -50, 65, 0, -55, 65, 2, -55, 65, 3, -55, 65, 4, -55,
+	65, 5, -55, 65, 6, -55, 65, 7, -55, 65, 8, -55, 65, 9, -55, 65, 10, -55,
+	65, 79, -40, 65, 80, -40, 65, 81, -40, 65, 92, -50, 65, 93, -50, 65, 94, -40,
+	65, 95, -50, 65, 96, -50, 65, 97, -50, 66, 0, -55, 66, 2, -55, 66, 3, -55,
+	66, 4, -55, 66, 5, -55, 66, 6, -55, 66, 7, -55, 66, 8, -55, 66, 9, -55,
+	66, 10, -55, 66, 79, -40, 66, 80, -40, 66, 81, -40, 66, 92, -50, 66, 93, -50,
+	66, 94, -40, 66, 95, -50, 66, 96, -50, 66, 97, -50, 67, 0, -55, 67, 2, -55,
+	67, 3, -55, 67, 4, -55, 67, 5, -55, 67, 6, -55, 67, 7, -55, 67, 8, -55,
+	67, 9, -55, 67, 10, -55, 67, 79, -40, 67, 80, -40, 67, 81, -40, 67, 92, -50,
+	67, 93, -50, 67, 94, -40, 67, 95, -50, 67, 96, -50, 67, 97, -50, 68, 0, -90,
+	68, 2, -90, 68, 3, -90, 68, 4, -90, 68, 5, -90, 68, 6, -90, 68, 7, -90,
+	68, 8, -90, 68, 9, -90, 68, 10, -90, 68, 102, -80, 68, 103, -80, 68, 104, -80,
+	68, 105, -80, 68, 107, -80, 68, 109, -80, 68, 110, -80, 68, 112, -80, 68, 113, -80,
+	68, 118, -80, 68, 138, -135, 68, 153, -80, 68, 154, -80, 68, 155, -80, 68, 156, -80,
+	68, 157, -80, 68, 158, -80, 68, 159, -80, 68, 162, -80, 68, 165, -80, 68, 223, -80,
+	68, 224, -80, 68, 225, -80, 68, 226, -80, 68, 229, -80, 68, 230, -80, 68, 231, -80,
+	68, 238, -80, 68, 239, -80, 68, 246, -135, 69, 83, -10, 69, 84, -10, 69, 85, -10,
+	69, 86, -10, 69, 87, -10, 69, 88, -10, 69, 89, -10, 69, 90, -10, 69, 91, -10,
+	70, 58, -40, 70, 60, -40, 70, 61, -40, 70, 62, -40, 70, 63, -40, 70, 64, -40,
+	70, 65, -40, 70, 66, -40, 70, 67, -40, 70, 83, -40, 70, 84, -40, 70, 85, -40,
+	70, 86, -40, 70, 87, -40, 70, 88, -40, 70, 89, -40, 70, 90, -40, 70, 91, -40,
+	70, 92, -18, 70, 93, -18, 70, 95, -18, 70, 96, -18, 70, 97, -18, 71, 58, -40,
+	71, 60, -40, 71, 61, -40, 71, 62, -40, 71, 63, -40, 71, 64, -40, 71, 65, -40,
+	71, 66, -40, 71, 67, -40, 71, 83, -40, 71, 84, -40, 71, 85, -40, 71, 86, -40,
+	71, 87, -40, 71, 88, -40, 71, 89, -40, 71, 90, -40, 71, 91, -40, 71, 92, -18,
+	71, 93, -18, 71, 95, -18, 71, 96, -18, 71, 97, -18, 72, 58, -40, 72, 60, -40,
+	72, 61, -40, 72, 62, -40, 72, 63, -40, 72, 64, -40, 72, 65, -40, 72, 66, -40,
+	72, 67, -40, 72, 83, -40, 72, 84, -40, 72, 85, -40, 72, 86, -40, 72, 87, -40,
+	72, 88, -40, 72, 89, -40, 72, 90, -40, 72, 91, -40, 72, 92, -18, 72, 93, -18,
+	72, 95, -18, 72, 96, -18, 72, 97, -18, 73, 58, -40, 73, 60, -40, 73, 61, -40,
+	73, 62, -40, 73, 63, -40, 73, 64, -40, 73, 65, -40, 73, 66, -40, 73, 67, -40,
+	73, 83, -40, 73, 84, -40, 73, 85, -40, 73, 86, -40, 73, 87, -40, 73, 88, -40,
+	73, 89, -40, 73, 90, -40, 73, 91, -40, 73, 92, -18, 73, 93, -18, 73, 95, -18,
+	73, 96, -18, 73, 97, -18, 79, 0, -50, 79, 2, -50, 79, 3, -50, 79, 4, -50,
+	79, 5, -50, 79, 6, -50, 79, 7, -50, 79, 8, -50, 79, 9, -50, 79, 10, -50,
+	79, 58, -18, 79, 60, -18, 79, 61, -18, 79, 62, -18, 79, 63, -18, 79, 64, -18,
+	79, 65, -18, 79, 66, -18, 79, 67, -18, 79, 102, -92, 79, 103, -92, 79, 104, -92,
+	79, 105, -92, 79, 107, -92, 79, 109, -92, 79, 110, -92, 79, 112, -92, 79, 113, -92,
+	79, 118, -92, 79, 137, -55, 79, 138, -74, 79, 153, -92, 79, 154, -92, 79, 155, -92,
+	79, 156, -52, 79, 157, -52, 79, 158, -92, 79, 159, -52, 79, 162, -52, 79, 165, -92,
+	79, 190, -74, 79, 191, -55, 79, 192, -55, 79, 197, -55, 79, 223, -92, 79, 224, -92,
+	79, 225, -92, 79, 226, -92, 79, 229, -92, 79, 230, -92, 79, 231, -92, 79, 238, -92,
+	79, 239, -92, 79, 246, -74, 79, 262, -55, 79, 263, -55, 79, 265, -55, 79, 266, -55,
+	79, 275, -65, 79, 293, -55, 79, 294, -55, 79, 295, -55, 79, 296, -55, 79, 297, -55,
+	79, 298, -55, 79, 299, -55, 79, 301, -55, 79, 302, -55, 79, 304, -74, 79, 306, -74,
+	79, 307, -74, 79, 308, -34, 80, 0, -50, 80, 2, -50, 80, 3, -50, 80, 4, -50,
+	80, 5, -50, 80, 6, -50, 80, 7, -50, 80, 8, -50, 80, 9, -50, 80, 10, -50,
+	80, 58, -18, 80, 60, -18, 80, 61, -18, 80, 62, -18, 80, 63, -18, 80, 64, -18,
+	80, 65, -18, 80, 66, -18, 80, 67, -18, 80, 102, -92, 80, 103, -92, 80, 104, -92,
+	80, 105, -92, 80, 107, -92, 80, 109, -92, 80, 110, -92, 80, 112, -92, 80, 113, -92,
+	80, 118, -92, 80, 137, -55, 80, 138, -74, 80, 153, -92, 80, 154, -92, 80, 155, -92,
+	80, 156, -52, 80, 157, -52, 80, 158, -92, 80, 159, -52, 80, 162, -52, 80, 165, -92,
+	80, 190, -74, 80, 191, -55, 80, 192, -55, 80, 197, -55, 80, 223, -92, 80, 224, -92,
+	80, 225, -92, 80, 226, -92, 80, 229, -92, 80, 230, -92, 80, 231, -92, 80, 238, -92,
+	80, 239, -92, 80, 246, -74, 80, 262, -55, 80, 263, -55, 80, 265, -55, 80, 266, -55,
+	80, 275, -65, 80, 293, -55, 80, 294, -55, 80, 295, -55, 80, 296, -55, 80, 297, -55,
+	80, 298, -55, 80, 299, -55, 80, 301, -55, 80, 302, -55, 80, 304, -74, 80, 306, -74,
+	80, 307, -74, 80, 308, -34, 81, 0, -50, 81, 2, -50, 81, 3, -50, 81, 4, -50,
+	81, 5, -50, 81, 6, -50, 81, 7, -50, 81, 8, -50, 81, 9, -50, 81, 10, -50,
+	81, 58, -18, 81, 60, -18, 81, 61, -18, 81, 62, -18, 81, 63, -18, 81, 64, -18,
+	81, 65, -18, 81, 66, -18, 81, 67, -18, 81, 102, -92, 81, 103, -92, 81, 104, -92,
+	81, 105, -92, 81, 107, -92, 81, 109, -92, 81, 110, -92, 81, 112, -92, 81, 113, -92,
+	81, 118, -92, 81, 137, -55, 81, 138, -74, 81, 153, -92, 81, 154, -92, 81, 155, -92,
+	81, 156, -52, 81, 157, -52, 81, 158, -92, 81, 159, -52, 81, 162, -52, 81, 165, -92,
+	81, 190, -74, 81, 191, -55, 81, 192, -55, 81, 197, -55, 81, 223, -92, 81, 224, -92,
+	81, 225, -92, 81, 226, -92, 81, 229, -92, 81, 230, -92, 81, 231, -92, 81, 238, -92,
+	81, 239, -92, 81, 246, -74, 81, 262, -55, 81, 263, -55, 81, 265, -55, 81, 266, -55,
+	81, 275, -65, 81, 293, -55, 81, 294, -55, 81, 295, -55, 81, 296, -55, 81, 297, -55,
+	81, 298, -55, 81, 299, -55, 81, 301, -55, 81, 302, -55, 81, 304, -74, 81, 306, -74,
+	81, 307, -74, 81, 308, -34, 83, 0, -40, 83, 2, -40, 83, 3, -40, 83, 4, -40,
+	83, 5, -40, 83, 6, -40, 83, 7, -40, 83, 8, -40, 83, 9, -40, 83, 10, -40,
+	83, 138, -25, 83, 246, -25, 84, 0, -40, 84, 2, -40, 84, 3, -40, 84, 4, -40,
+	84, 5, -40, 84, 6, -40, 84, 7, -40, 84, 8, -40, 84, 9, -40, 84, 10, -40,
+	84, 138, -25, 84, 246, -25, 85, 0, -40, 85, 2, -40, 85, 3, -40, 85, 4, -40,
+	85, 5, -40, 85, 6, -40, 85, 7, -40, 85, 8, -40, 85, 9, -40, 85, 10, -40,
+	85, 138, -25, 85, 246, -25, 86, 0, -40, 86, 2, -40, 86, 3, -40, 86, 4, -40,
+	86, 5, -40, 86, 6, -40, 86, 7, -40, 86, 8, -40, 86, 9, -40, 86, 10, -40,
+	86, 138, -25, 86, 246, -25, 87, 0, -40, 87, 2, -40, 87, 3, -40, 87, 4, -40,
+	87, 5, -40, 87, 6, -40, 87, 7, -40, 87, 8, -40, 87, 9, -40, 87, 10, -40,
+	87, 138, -25, 87, 246, -25, 88, 0, -40, 88, 2, -40, 88, 3, -40, 88, 4, -40,
+	88, 5, -40, 88, 6, -40, 88, 7, -40, 88, 8, -40, 88, 9, -40, 88, 10, -40,
+	88, 138, -25, 88, 246, -25, 89, 0, -40, 89, 2, -40, 89, 3, -40, 89, 4, -40,
+	89, 5, -40, 89, 6, -40, 89, 7, -40, 89, 8, -40, 89, 9, -40, 89, 10, -40,
+	89, 138, -25, 89, 246, -25, 90, 0, -40, 90, 2, -40, 90, 3, -40, 90, 4, -40,
+	90, 5, -40, 90, 6, -40, 90, 7, -40, 90, 8, -40, 90, 9, -40, 90, 10, -40,
+	90, 138, -25, 90, 246, -25, 91, 0, -40, 91, 2, -40, 91, 3, -40, 91, 4, -40,
+	91, 5, -40, 91, 6, -40, 91, 7, -40, 91, 8, -40, 91, 9, -40, 91, 10, -40,
+	91, 138, -25, 91, 246, -25, 92, 0, -60, 92, 2, -60, 92, 3, -60, 92, 4, -60,
+	92, 5, -60, 92, 6, -60, 92, 7, -60, 92, 8, -60, 92, 9, -60, 92, 10, -60,
+	92, 58, -30, 92, 60, -30, 92, 61, -30, 92, 62, -30, 92, 63, -30, 92, 64, -30,
+	92, 65, -30, 92, 66, -30, 92, 67, -30, 92, 102, -111, 92, 103, -111, 92, 104, -111,
+	92, 105, -111, 92, 107, -111, 92, 109, -111, 92, 110, -111, 92, 112, -111, 92, 113, -111,
+	92, 118, -111, 92, 137, -65, 92, 138, -129, 92, 153, -111, 92, 154, -111, 92, 155, -111,
+	92, 156, -111, 92, 157, -71, 92, 158, -111, 92, 159, -71, 92, 162, -71, 92, 165, -111,
+	92, 190, -55, 92, 191, -74, 92, 192, -74, 92, 193, -34, 92, 194, -34, 92, 195, -34,
+	92, 196, -34, 92, 197, -74, 92, 223, -111, 92, 224, -111, 92, 225, -111, 92, 226, -111,
+	92, 229, -111, 92, 230, -111, 92, 231, -111, 92, 238, -111, 92, 239, -111, 92, 246, -129,
+	92, 275, -74, 92, 293, -74, 92, 294, -74, 92, 295, -74, 92, 296, -74, 92, 297, -74,
+	92, 298, -74, 92, 299, -74, 92, 301, -74, 92, 302, -74, 93, 0, -60, 93, 2, -60,
+	93, 3, -60, 93, 4, -60, 93, 5, -60, 93, 6, -60, 93, 7, -60, 93, 8, -60,
+	93, 9, -60, 93, 10, -60, 93, 58, -25, 93, 60, -25, 93, 61, -25, 93, 62, -25,
+	93, 63, -25, 93, 64, -25, 93, 65, -25, 93, 66, -25, 93, 67, -25, 93, 102, -92,
+	93, 103, -92, 93, 104, -92, 93, 105, -92, 93, 107, -92, 93, 109, -92, 93, 110, -92,
+	93, 112, -92, 93, 113, -92, 93, 118, -92, 93, 137, -65, 93, 138, -92, 93, 153, -92,
+	93, 154, -92, 93, 155, -92, 93, 156, -92, 93, 157, -52, 93, 158, -92, 93, 159, -52,
+	93, 162, -52, 93, 165, -92, 93, 190, -37, 93, 191, -55, 93, 192, -55, 93, 197, -55,
+	93, 223, -92, 93, 224, -92, 93, 225, -92, 93, 226, -92, 93, 229, -92, 93, 230, -92,
+	93, 231, -92, 93, 238, -92, 93, 239, -92, 93, 246, -92, 93, 275, -65, 93, 293, -55,
+	93, 294, -55, 93, 295, -55, 93, 296, -55, 93, 297, -55, 93, 298, -55, 93, 299, -55,
+	93, 301, -55, 93, 302, -55, 93, 306, -70, 93, 307, -70, 93, 308, -70, 95, 0, -50,
+	95, 2, -50, 95, 3, -50, 95, 4, -50, 95, 5, -50, 95, 6, -50, 95, 7, -50,
+	95, 8, -50, 95, 9, -50, 95, 10, -50, 95, 58, -15, 95, 60, -15, 95, 61, -15,
+	95, 62, -15, 95, 63, -15, 95, 64, -15, 95, 65, -15, 95, 66, -15, 95, 67, -15,
+	95, 102, -92, 95, 103, -92, 95, 104, -92, 95, 105, -92, 95, 107, -92, 95, 109, -92,
+	95, 110, -92, 95, 112, -92, 95, 113, -92, 95, 118, -92, 95, 137, -65, 95, 138, -92,
+	95, 153, -92, 95, 154, -92, 95, 155, -92, 95, 156, -92, 95, 157, -52, 95, 158, -92,
+	95, 159, -52, 95, 162, -52, 95, 165, -92, 95, 190, -74, 95, 191, -74, 95, 192, -74,
+	95, 193, -34, 95, 194, -34, 95, 195, -34, 95, 196, -34, 95, 197, -74, 95, 223, -92,
+	95, 224, -92, 95, 225, -92, 95, 226, -92, 95, 229, -92, 95, 230, -92, 95, 231, -92,
+	95, 238, -92, 95, 239, -92, 95, 246, -92, 95, 275, -65, 95, 293, -92, 95, 294, -92,
+	95, 295, -92, 95, 296, -92, 95, 297, -92, 95, 298, -92, 95, 299, -92, 95, 301, -92,
+	95, 302, -92, 96, 0, -50, 96, 2, -50, 96, 3, -50, 96, 4, -50, 96, 5, -50,
+	96, 6, -50, 96, 7, -50, 96, 8, -50, 96, 9, -50, 96, 10, -50, 96, 58, -15,
+	96, 60, -15, 96, 61, -15, 96, 62, -15, 96, 63, -15, 96, 64, -15, 96, 65, -15,
+	96, 66, -15, 96, 67, -15, 96, 102, -92, 96, 103, -92, 96, 104, -92, 96, 105, -92,
+	96, 107, -92, 96, 109, -92, 96, 110, -92, 96, 112, -92, 96, 113, -92, 96, 118, -92,
+	96, 137, -65, 96, 138, -92, 96, 153, -92, 96, 154, -92, 96, 155, -92, 96, 156, -92,
+	96, 157, -52, 96, 158, -92, 96, 159, -52, 96, 162, -52, 96, 165, -92, 96, 190, -74,
+	96, 191, -74, 96, 192, -74, 96, 193, -34, 96, 194, -34, 96, 195, -34, 96, 196, -34,
+	96, 197, -74, 96, 223, -92, 96, 224, -92, 96, 225, -92, 96, 226, -92, 96, 229, -92,
+	96, 230, -92, 96, 231, -92, 96, 238, -92, 96, 239, -92, 96, 246, -92, 96, 275, -65,
+	96, 293, -92, 96, 294, -92, 96, 295, -92, 96, 296, -92, 96, 297, -92, 96, 298, -92,
+	96, 299, -92, 96, 301, -92, 96, 302, -92, 97, 0, -50, 97, 2, -50, 97, 3, -50,
+	97, 4, -50, 97, 5, -50, 97, 6, -50, 97, 7, -50, 97, 8, -50, 97, 9, -50,
+	97, 10, -50, 97, 58, -15, 97, 60, -15, 97, 61, -15, 97, 62, -15, 97, 63, -15,
+	97, 64, -15, 97, 65, -15, 97, 66, -15, 97, 67, -15, 97, 102, -92, 97, 103, -92,
+	97, 104, -92, 97, 105, -92, 97, 107, -92, 97, 109, -92, 97, 110, -92, 97, 112, -92,
+	97, 113, -92, 97, 118, -92, 97, 137, -65, 97, 138, -92, 97, 153, -92, 97, 154, -92,
+	97, 155, -92, 97, 156, -92, 97, 157, -52, 97, 158, -92, 97, 159, -52, 97, 162, -52,
+	97, 165, -92, 97, 190, -74, 97, 191, -74, 97, 192, -74, 97, 193, -34, 97, 194, -34,
+	97, 195, -34, 97, 196, -34, 97, 197, -74, 97, 223, -92, 97, 224, -92, 97, 225, -92,
+	97, 226, -92, 97, 229, -92, 97, 230, -92, 97, 231, -92, 97, 238, -92, 97, 239, -92,
+	97, 246, -92, 97, 275, -65, 97, 293, -92, 97, 294, -92, 97, 295, -92, 97, 296, -92,
+	97, 297, -92, 97, 298, -92, 97, 299, -92, 97, 301, -92, 97, 302, -92, 102, 177, -10,
+	102, 178, -10, 102, 179, -10, 103, 177, -10, 103, 178, -10, 103, 179, -10, 104, 177, -10,
+	104, 178, -10, 104, 179, -10, 105, 177, -10, 105, 178, -10, 105, 179, -10, 107, 177, -10,
+	107, 178, -10, 107, 179, -10, 109, 177, -10, 109, 178, -10, 109, 179, -10, 110, 177, -10,
+	110, 178, -10, 110, 179, -10, 112, 177, -10, 112, 178, -10, 112, 179, -10, 113, 177, -10,
+	113, 178, -10, 113, 179, -10, 118, 177, -10, 118, 178, -10, 118, 179, -10, 119, 246, -40,
+	119, 293, -20, 119, 294, -20, 119, 295, -20, 119, 296, -20, 119, 297, -20, 119, 298, -20,
+	119, 299, -20, 119, 301, -20, 119, 302, -20, 129, 188, -15, 129, 199, -20, 129, 200, -20,
+	130, 188, -15, 130, 199, -20, 130, 200, -20, 132, 188, -15, 132, 199, -20, 132, 200, -20,
+	133, 188, -15, 133, 199, -20, 133, 200, -20, 138, 257, -140, 138, 259, -140, 153, 138, -10,
+	153, 177, -40, 153, 178, -40, 153, 179, -40, 153, 246, -15, 153, 303, -15, 153, 304, -15,
+	153, 305, -20, 153, 306, -30, 153, 307, -30, 153, 308, -30, 154, 138, -10, 154, 177, -40,
+	154, 178, -40, 154, 179, -40, 154, 246, -15, 154, 303, -15, 154, 304, -15, 154, 305, -20,
+	154, 306, -30, 154, 307, -30, 154, 308, -30, 155, 138, -10, 155, 177, -40, 155, 178, -40,
+	155, 179, -40, 155, 246, -15, 155, 303, -15, 155, 304, -15, 155, 305, -20, 155, 306, -30,
+	155, 307, -30, 155, 308, -30, 156, 138, -10, 156, 177, -40, 156, 178, -40, 156, 179, -40,
+	156, 246, -15, 156, 303, -15, 156, 304, -15, 156, 305, -20, 156, 306, -30, 156, 307, -30,
+	156, 308, -30, 157, 138, -10, 157, 177, -40, 157, 178, -40, 157, 179, -40, 157, 246, -15,
+	157, 303, -15, 157, 304, -15, 157, 305, -20, 157, 306, -30, 157, 307, -30, 157, 308, -30,
+	158, 138, -10, 158, 177, -40, 158, 178, -40, 158, 179, -40, 158, 246, -15, 158, 303, -15,
+	158, 304, -15, 158, 305, -20, 158, 306, -30, 158, 307, -30, 158, 308, -30, 159, 138, -10,
+	159, 177, -40, 159, 178, -40, 159, 179, -40, 159, 246, -15, 159, 303, -15, 159, 304, -15,
+	159, 305, -20, 159, 306, -30, 159, 307, -30, 159, 308, -30, 162, 138, -10, 162, 177, -40,
+	162, 178, -40, 162, 179, -40, 162, 246, -15, 162, 303, -15, 162, 304, -15, 162, 305, -20,
+	162, 306, -30, 162, 307, -30, 162, 308, -30, 165, 138, -10, 165, 177, -40, 165, 178, -40,
+	165, 179, -40, 165, 246, -15, 165, 303, -15, 165, 304, -15, 165, 305, -20, 165, 306, -30,
+	165, 307, -30, 165, 308, -30, 170, 138, -10, 170, 152, -60, 170, 170, -18, 170, 191, -20,
+	170, 197, -20, 170, 246, -15, 170, 259, 92, 177, 138, -10, 177, 153, -10, 177, 154, -10,
+	177, 155, -10, 177, 156, -10, 177, 157, -10, 177, 158, -10, 177, 159, -10, 177, 162, -10,
+	177, 165, -10, 177, 177, -10, 177, 178, -10, 177, 179, -10, 177, 246, -15, 178, 138, -10,
+	178, 153, -10, 178, 154, -10, 178, 155, -10, 178, 156, -10, 178, 157, -10, 178, 158, -10,
+	178, 159, -10, 178, 162, -10, 178, 165, -10, 178, 177, -10, 178, 178, -10, 178, 179, -10,
+	178, 246, -15, 179, 138, -10, 179, 153, -10, 179, 154, -10, 179, 155, -10, 179, 156, -10,
+	179, 157, -10, 179, 158, -10, 179, 159, -10, 179, 162, -10, 179, 165, -10, 179, 177, -10,
+	179, 178, -10, 179, 179, -10, 179, 246, -15, 199, 153, -10, 199, 154, -10, 199, 155, -10,
+	199, 156, -10, 199, 157, -10, 199, 158, -10, 199, 159, -10, 199, 162, -10, 199, 165, -10,
+	199, 223, -10, 199, 224, -10, 199, 225, -10, 199, 226, -10, 199, 229, -10, 199, 230, -10,
+	199, 231, -10, 199, 238, -10, 199, 239, -10, 199, 306, -10, 199, 307, -10, 199, 308, -10,
+	200, 153, -10, 200, 154, -10, 200, 155, -10, 200, 156, -10, 200, 157, -10, 200, 158, -10,
+	200, 159, -10, 200, 162, -10, 200, 165, -10, 200, 223, -10, 200, 224, -10, 200, 225, -10,
+	200, 226, -10, 200, 229, -10, 200, 230, -10, 200, 231, -10, 200, 238, -10, 200, 239, -10,
+	200, 306, -10, 200, 307, -10, 200, 308, -10, 215, 303, -40, 216, 303, -40, 217, 303, -40,
+	218, 303, -40, 221, 303, -40, 223, 177, -10, 223, 178, -10, 223, 179, -10, 223, 303, -10,
+	224, 177, -10, 224, 178, -10, 224, 179, -10, 224, 303, -10, 225, 177, -10, 225, 178, -10,
+	225, 179, -10, 225, 303, -10, 226, 177, -10, 226, 178, -10, 226, 179, -10, 226, 303, -10,
+	229, 177, -10, 229, 178, -10, 229, 179, -10, 229, 303, -10, 230, 177, -10, 230, 178, -10,
+	230, 179, -10, 230, 303, -10, 231, 177, -10, 231, 178, -10, 231, 179, -10, 231, 303, -10,
+	238, 177, -10, 238, 178, -10, 238, 179, -10, 238, 303, -10, 239, 177, -10, 239, 178, -10,
+	239, 179, -10, 239, 303, -10, 246, 257, -140, 246, 259, -140, 258, 258, -111, 259, 142, -25,
+	259, 146, -25, 259, 259, -111, 259, 262, -25, 259, 263, -25, 259, 265, -25, 259, 266, -25,
+	259, 269, -40, 259, 270, -40, 259, 271, -40, 259, 272, -40, 259, 273, -40, 259, 279, -111,
+	259, 282, -30, 259, 284, -30, 259, 303, -10, 262, 102, -15, 262, 103, -15, 262, 104, -15,
+	262, 105, -15, 262, 107, -15, 262, 109, -15, 262, 110, -15, 262, 112, -15, 262, 113, -15,
+	262, 118, -15, 262, 129, -37, 262, 130, -37, 262, 132, -37, 262, 133, -37, 262, 138, -111,
+	262, 142, -37, 262, 146, -37, 262, 153, -37, 262, 154, -37, 262, 155, -37, 262, 156, -37,
+	262, 157, -37, 262, 158, -37, 262, 159, -37, 262, 162, -37, 262, 165, -37, 262, 177, -37,
+	262, 178, -37, 262, 179, -37, 262, 190, -20, 262, 223, -45, 262, 224, -45, 262, 225, -45,
+	262, 226, -45, 262, 229, -45, 262, 230, -45, 262, 231, -45, 262, 238, -45, 262, 239, -45,
+	262, 246, -111, 262, 251, -37, 262, 269, -10, 262, 270, -10, 262, 271, -10, 262, 272, -10,
+	262, 273, -10, 263, 102, -15, 263, 103, -15, 263, 104, -15, 263, 105, -15, 263, 107, -15,
+	263, 109, -15, 263, 110, -15, 263, 112, -15, 263, 113, -15, 263, 118, -15, 263, 129, -37,
+	263, 130, -37, 263, 132, -37, 263, 133, -37, 263, 138, -111, 263, 142, -37, 263, 146, -37,
+	263, 153, -37, 263, 154, -37, 263, 155, -37, 263, 156, -37, 263, 157, -37, 263, 158, -37,
+	263, 159, -37, 263, 162, -37, 263, 165, -37, 263, 177, -37, 263, 178, -37, 263, 179, -37,
+	263, 190, -20, 263, 223, -45, 263, 224, -45, 263, 225, -45, 263, 226, -45, 263, 229, -45,
+	263, 230, -45, 263, 231, -45, 263, 238, -45, 263, 239, -45, 263, 246, -111, 263, 251, -37,
+	263, 269, -10, 263, 270, -10, 263, 271, -10, 263, 272, -10, 263, 273, -10, 265, 102, -15,
+	265, 103, -15, 265, 104, -15, 265, 105, -15, 265, 107, -15, 265, 109, -15, 265, 110, -15,
+	265, 112, -15, 265, 113, -15, 265, 118, -15, 265, 129, -37, 265, 130, -37, 265, 132, -37,
+	265, 133, -37, 265, 138, -111, 265, 142, -37, 265, 146, -37, 265, 153, -37, 265, 154, -37,
+	265, 155, -37, 265, 156, -37, 265, 157, -37, 265, 158, -37, 265, 159, -37, 265, 162, -37,
+	265, 165, -37, 265, 177, -37, 265, 178, -37, 265, 179, -37, 265, 190, -20, 265, 223, -45,
+	265, 224, -45, 265, 225, -45, 265, 226, -45, 265, 229, -45, 265, 230, -45, 265, 231, -45,
+	265, 238, -45, 265, 239, -45, 265, 246, -111, 265, 251, -37, 265, 269, -10, 265, 270, -10,
+	265, 271, -10, 265, 272, -10, 265, 273, -10, 266, 102, -15, 266, 103, -15, 266, 104, -15,
+	266, 105, -15, 266, 107, -15, 266, 109, -15, 266, 110, -15, 266, 112, -15, 266, 113, -15,
+	266, 118, -15, 266, 129, -37, 266, 130, -37, 266, 132, -37, 266, 133, -37, 266, 138, -111,
+	266, 142, -37, 266, 146, -37, 266, 153, -37, 266, 154, -37, 266, 155, -37, 266, 156, -37,
+	266, 157, -37, 266, 158, -37, 266, 159, -37, 266, 162, -37, 266, 165, -37, 266, 177, -37,
+	266, 178, -37, 266, 179, -37, 266, 190, -20, 266, 223, -45, 266, 224, -45, 266, 225, -45,
+	266, 226, -45, 266, 229, -45, 266, 230, -45, 266, 231, -45, 266, 238, -45, 266, 239, -45,
+	266, 246, -111, 266, 251, -37, 266, 269, -10, 266, 270, -10, 266, 271, -10, 266, 272, -10,
+	266, 273, -10, 279, 0, -18, 279, 2, -18, 279, 3, -18, 279, 4, -18, 279, 5, -18,
+	279, 6, -18, 279, 7, -18, 279, 8, -18, 279, 9, -18, 279, 10, -18, 279, 79, -18,
+	279, 80, -18, 279, 81, -18, 279, 92, -35, 279, 93, -40, 279, 95, -75, 279, 96, -75,
+	279, 97, -75, 303, 138, -74, 303, 246, -74, 304, 138, -74, 304, 246, -74, 306, 138, -55,
+	306, 246, -55, 307, 138, -55, 307, 246, -55, 308, 138, -55, 308, 246, -55,
+}
+
+// timesBoldItalicKernPairs are the kerning pairs loaded from afms/Times-BoldItalic.afm, stored as
+// (left, right, kerning) triples where left and right are indices into
+// type1CommonRunes.
+// See afms/MustRead.html for license information.
+var timesBoldItalicKernPairs = []int16{
+	0, 12, -65, 0, 13, -65, 0, 14, -65, 0, 15, -65, 0, 32, -60, 0, 33, -60,
+	0, 34, -60, 0, 58, -50, 0, 60, -50, 0, 61, -50, 0, 62, -50, 0, 63, -50,
+	0, 64, -50, 0, 65, -50, 0, 66, -50, 0, 67, -50, 0, 69, -55, 0, 79, -55,
+	0, 80, -55, 0, 81, -55, 0, 83, -50, 0, 84, -50, 0, 85, -50, 0, 86, -50,
+	0, 87, -50, 0, 88, -50, 0, 89, -50, 0, 90, -50, 0, 91, -50, 0, 92, -95,
+	0, 93, -100, 0, 95, -70, 0, 96, -70, 0, 97, -70, 0, 259, -74, 0, 293, -30,
+	0, 294, -30, 0, 295, -30, 0, 296, -30, 0, 297, -30, 0, 298, -30, 0, 299, -30,
+	0, 301, -30, 0, 302, -30, 0, 303, -74, 0, 304, -74, 0, 306, -74, 0, 307, -74,
+	0, 308, -74, 2, 12, -65, 2, 13, -65, 2, 14, -65, 2, 15, -65, 2, 32, -60,
+	2, 33, -60, 2, 34, -60, 2, 58, -50, 2, 60, -50, 2, 61, -50, 2, 62, -50,
+	2, 63, -50, 2, 64, -50, 2, 65, -50, 2, 66, -50, 2, 67, -50, 2, 69, -55,
+	2, 79, -55, 2, 80, -55, 2, 81, -55, 2, 83, -50, 2, 84, -50, 2, 85, -50,
+	2, 86, -50, 2, 87, -50, 2, 88, -50, 2, 89, -50, 2, 90, -50, 2, 91, -50,
+	2, 92, -95, 2, 93, -100, 2, 95, -70, 2, 96, -70, 2, 97, -70, 2, 259, -74,
+	2, 293, -30, 2, 294, -30, 2, 295, -30, 2, 296, -30, 2, 297, -30, 2, 298, -30,
+	2, 299, -30, 2, 301, -30, 2, 302, -30, 2, 303, -74, 2, 304, -74, 2, 306, -74,
+	2, 307, -74, 2, 308, -74, 3, 12, -65, 3, 13, -65, 3, 14, -65, 3, 15, -65,
+	3, 32, -60, 3, 33, -60, 3, 34, -60, 3, 58, -50, 3, 60, -50, 3, 61, -50,
+	3, 62, -50, 3, 63, -50, 3, 64, -50, 3, 65, -50, 3, 66, -50, 3, 67, -50,
+	3, 69, -55, 3, 79, -55, 3, 80, -55, 3, 81, -55, 3, 83, -50, 3, 84, -50,
+	3, 85, -50, 3, 86, -50, 3, 87, -50, 3, 88, -50, 3, 89, -50, 3, 90, -50,
+	3, 91, -50, 3, 92, -95, 3, 93, -100, 3, 95, -70, 3, 96, -70, 3, 97, -70,
+	3, 259, -74, 3, 293, -30, 3, 294, -30, 3, 295, -30, 3, 296, -30, 3, 297, -30,
+	3, 298, -30, 3, 299, -30, 3, 301, -30, 3, 302, -30, 3, 303, -74, 3, 304, -74,
+	3, 306, -74, 3, 307, -74, 3, 308, -74, 4, 12, -65, 4, 13, -65, 4, 14, -65,
+	4, 15, -65, 4, 32, -60, 4, 33, -60, 4, 34, -60, 4, 58, -50, 4, 60, -50,
+	4, 61, -50, 4, 62, -50, 4, 63, -50, 4, 64, -50, 4, 65, -50, 4, 66, -50,
+	4, 67, -50, 4, 69, -55, 4, 79, -55, 4, 80, -55, 4, 81, -55, 4, 83, -50,
+	4, 84, -50, 4, 85, -50, 4, 86, -50, 4, 87, -50, 4, 88, -50, 4, 89, -50,
+	4, 90, -50, 4, 91, -50, 4, 92, -95, 4, 93, -100, 4, 95, -70, 4, 96, -70,
+	4, 97, -70, 4, 259, -74, 4, 293, -30, 4, 294, -30, 4, 295, -30, 4, 296, -30,
+	4, 297, -30, 4, 298, -30, 4, 299, -30, 4, 301, -30, 4, 302, -30, 4, 303, -74,
+	4, 304, -74, 4, 306, -74, 4, 307, -74, 4, 308, -74, 5, 12, -65, 5, 13, -65,
+	5, 14, -65, 5, 15, -65, 5, 32, -60, 5, 33, -60, 5, 34, -60, 5, 58, -50,
+	5, 60, -50, 5, 61, -50, 5, 62, -50, 5, 63, -50, 5, 64, -50, 5, 65, -50,
+	5, 66, -50, 5, 67, -50, 5, 69, -55, 5, 79, -55, 5, 80, -55, 5, 81, -55,
+	5, 83, -50, 5, 84, -50, 5, 85, -50, 5, 86, -50, 5, 87, -50, 5, 88, -50,
+	5, 89, -50, 5, 90, -50, 5, 91, -50, 5, 92, -95, 5, 93, -100, 5, 95, -70,
+	5, 96, -70, 5, 97, -70, 5, 259, -74, 5, 293, -30, 5, 294, -30, 5, 295, -30,
+	5, 296, -30, 5, 297, -30, 5, 298, -30, 5, 299, -30, 5, 301, -30, 5, 302, -30,
+	5, 303, -74, 5, 304, -74, 5, 306, -74, 5, 307, -74, 5, 308, -74, 6, 12, -65,
+	6, 13, -65, 6, 14, -65, 6, 15, -65, 6, 32, -60, 6, 33, -60, 6, 34, -60,
+	6, 58, -50, 6, 60, -50, 6, 61, -50, 6, 62, -50, 6, 63, -50, 6, 64, -50,
+	6, 65, -50, 6, 66, -50, 6, 67, -50, 6, 69, -55, 6, 79, -55, 6, 80, -55,
+	6, 81, -55, 6, 83, -50, 6, 84, -50, 6, 85, -50, 6, 86, -50, 6, 87, -50,
+	6, 88, -50, 6, 89, -50, 6, 90, -50, 6, 91, -50, 6, 92, -95, 6, 93, -100,
+	6, 95, -70, 6, 96, -70, 6, 97, -70, 6, 259, -74, 6, 293, -30, 6, 294, -30,
+	6, 295, -30, 6, 296, -30, 6, 297, -30, 6, 298, -30, 6, 299, -30, 6, 301, -30,
+	6, 302, -30, 6, 303, -74, 6, 304, -74, 6, 306, -74, 6, 307, -74, 6, 308, -74,
+	7, 12, -65, 7, 13, -65, 7, 14, -65, 7, 15, -65, 7, 32, -60, 7, 33, -60,
+	7, 34, -60, 7, 58, -50, 7, 60, -50, 7, 61, -50, 7, 62, -50, 7, 63, -50,
+	7, 64, -50, 7, 65, -50, 7, 66, -50, 7, 67, -50, 7, 69, -55, 7, 79, -55,
+	7, 80, -55, 7, 81, -55, 7, 83, -50, 7, 84, -50, 7, 85, -50, 7, 86, -50,
+	7, 87, -50, 7, 88, -50, 7, 89, -50, 7, 90, -50, 7, 91, -50, 7, 92, -95,
+	7, 93, -100, 7, 95, -70, 7, 96, -70, 7, 97, -70, 7, 259, -74, 7, 293, -30,
+	7, 294, -30, 7, 295, -30, 7, 296, -30, 7, 297, -30, 7, 298, -30, 7, 299, -30,
+	7, 301, -30, 7, 302, -30, 7, 303, -74, 7, 304, -74, 7, 306, -74, 7, 307, -74,
+	7, 308, -74, 8, 12, -65, 8, 13, -65, 8, 14, -65, 8, 15, -65, 8, 32, -60,
+	8, 33, -60, 8, 34, -60, 8, 58, -50, 8, 60, -50, 8, 61, -50, 8, 62, -50,
+	8, 63, -50, 8, 64, -50, 8, 65, -50, 8, 66, -50, 8, 67, -50, 8, 69, -55,
+	8, 79, -55, 8, 80, -55, 8, 81, -55, 8, 83, -50, 8, 84, -50, 8, 85, -50,
+	8, 86, -50, 8, 87, -50, 8, 88, -50, 8, 89, -50, 8, 90, -50, 8, 91, -50,
+	8, 92, -95, 8, 93, -100, 8, 95, -70, 8, 96, -70, 8, 97, -70, 8, 259, -74,
+	8, 293, -30, 8, 294, -30, 8, 295, -30, 8, 296, -30, 8, 297, -30, 8, 298, -30,
+	8, 299, -30, 8, 301, -30, 8, 302, -30, 8, 303, -74, 8, 304, -74, 8, 306, -34,
+	8, 307, -34, 8, 308, -34, 9, 12, -65, 9, 13, -65, 9, 14, -65, 9, 15, -65,
+	9, 32, -60, 9, 33, -60, 9, 34, -60, 9, 58, -50, 9, 60, -50, 9, 61, -50,
+	9, 62, -50, 9, 63, -50, 9, 64, -50, 9, 65, -50, 9, 66, -50, 9, 67, -50,
+	9, 69, -55, 9, 79, -55, 9, 80, -55, 9, 81, -55, 9, 83, -50, 9, 84, -50,
+	9, 85, -50, 9, 86, -50, 9, 87, -50, 9, 88, -50, 9, 89, -50, 9, 90, -50,
+	9, 91, -50, 9, 92, -95, 9, 93, -100, 9, 95, -70, 9, 96, -70, 9, 97, -70,
+	9, 259, -74, 9, 293, -30, 9, 294, -30, 9, 295, -30, 9, 296, -30, 9, 297, -30,
+	9, 298, -30, 9, 299, -30, 9, 301, -30, 9, 302, -30, 9, 303, -74, 9, 304, -74,
+	9, 306, -74, 9, 307, -74, 9, 308, -74, 10, 12, -65, 10, 13, -65, 10, 14, -65,
+	10, 15, -65, 10, 32, -60, 10, 33, -60, 10, 34, -60, 10, 58, -50, 10, 60, -50,
+	10, 61, -50, 10, 62, -50, 10, 63, -50, 10, 64, -50, 10, 65, -50, 10, 66, -50,
+	10, 67, -50, 10, 69, -55, 10, 79, -55, 10, 80, -55, 10, 81, -55, 10, 83, -50,
+	10, 84, -50, 10, 85, -50, 10, 86, -50, 10, 87, -50, 10, 88, -50, 10, 89, -50,
+	10, 90, -50, 10, 91, -50, 10, 92, -95, 10, 93, -100, 10, 95, -70, 10, 96, -70,
+	10, 97, -70, 10, 259, -74, 10, 293, -30, 10, 294, -30, 10, 295, -30, 10, 296, -30,
+	10, 297, -30, 10, 298, -30, 10, 299, -30, 10, 301, -30, 10, 302, -30, 10, 303, -74,
+	10, 304, -74, 10, 306, -74, 10, 307, -74, 10, 308, -74, 11, 0, -25, 11, 2, -25,
+	11, 3, -25, 11, 4, -25, 11, 5, -25, 11, 6, -25, 11, 7, -25, 11, 8, -25,
+	11, 9, -25, 11, 10, -25, 11, 83, -10, 11, 84, -10, 11, 85, -10, 11, 86, -10,
+	11, 87, -10, 11, 88, -10, 11, 89, -10, 11, 90, -10, 11, 91, -10, 16, 0, -25,
+	16, 2, -25, 16, 3, -25, 16, 4, -25, 16, 5, -25, 16, 6, -25, 16, 7, -25,
+	16, 8, -25, 16, 9, -25, 16, 10, -25, 16, 92, -50, 16, 93, -40, 16, 95, -50,
+	16, 96, -50, 16, 97, -50, 17, 0, -25, 17, 2, -25, 17, 3, -25, 17, 4, -25,
+	17, 5, -25, 17, 6, -25, 17, 7, -25, 17, 8, -25, 17, 9, -25, 17, 10, -25,
+	17, 92, -50, 17, 93, -40, 17, 95, -50, 17, 96, -50, 17, 97, -50, 18, 0, -25,
+	18, 2, -25, 18, 3, -25, 18, 4, -25, 18, 5, -25, 18, 6, -25, 18, 7, -25,
+	18, 8, -25, 18, 9, -25, 18, 10, -25, 18, 92, -50, 18, 93, -40, 18, 95, -50,
+	18, 96, -50, 18, 97, -50, 31, 0, -100, 31, 2, -100, 31, 3, -100, 31, 4, -100,
+	31, 5, -100, 31, 6, -100, 31, 7, -100, 31, 8, -100, 31, 9, -100, 31, 10, -100,
+	31, 102, -95, 31, 103, -95, 31, 104, -95, 31, 105, -95, 31, 107, -95, 31, 109, -95,
+	31, 110, -95, 31, 112, -95, 31, 113, -95, 31, 118, -95, 31, 138, -129, 31, 153, -100,
+	31, 154, -100, 31, 155, -100, 31, 156, -100, 31, 157, -100, 31, 158, -100, 31, 159, -100,
+	31, 162, -100, 31, 165, -100, 31, 191, -40, 31, 192, -40, 31, 193, -40, 31, 194, -40,
+	31, 195, -40, 31, 196, -40, 31, 197, -40, 31, 223, -70, 31, 224, -70, 31, 225, -70,
+	31, 226, -70, 31, 229, -70, 31, 230, -70, 31, 231, -70, 31, 238, -70, 31, 239, -70,
+	31, 246, -129, 31, 262, -50, 31, 263, -50, 31, 265, -50, 31, 266, -50, 44, 0, -25,
+	44, 2, -25, 44, 3, -25, 44, 4, -25, 44, 5, -25, 44, 6, -25, 44, 7, -25,
+	44, 8, -25, 44, 9, -25, 44, 10, -25, 44, 102, -40, 44, 103, -40, 44, 104, -40,
+	44, 105, -40, 44, 107, -40, 44, 109, -40, 44, 110, -40, 44, 112, -40, 44, 113, -40,
+	44, 118, -40, 44, 138, -10, 44, 153, -40, 44, 154, -40, 44, 155, -40, 44, 156, -40,
+	44, 157, -40, 44, 158, -40, 44, 159, -40, 44, 162, -40, 44, 165, -40, 44, 223, -40,
+	44, 224, -40, 44, 225, -40, 44, 226, -40, 44, 229, -40, 44, 230, -40, 44, 231, -40,
+	44, 238, -40, 44, 239, -40, 44, 246, -10, 44, 293, -40, 44, 294, -40, 44, 295, -40,
+	44, 296, -40, 44, 297, -40, 44, 298, -40, 44, 299, -40, 44, 301, -40, 44, 302, -40,
+	45, 58, -30, 45, 60, -30, 45, 61, -30, 45, 62, -30, 45, 63, -30, 45, 64, -30,
+	45, 65, -30, 45, 66, -30, 45, 67, -30, 45, 153, -25, 45, 154, -25, 45, 155, -25,
+	45, 156, -25, 45, 157, -25, 45, 158, -25, 45, 159, -25, 45, 162, -25, 45, 165, -25,
+	45, 223, -25, 45, 224, -25, 45, 225, -25, 45, 226, -25, 45, 229, -25, 45, 230, -25,
+	45, 231, -25, 45, 238, -25, 45, 239, -25, 45, 293, -20, 45, 294, -20, 45, 295, -20,
+	45, 296, -20, 45, 297, -20, 45, 298, -20, 45, 299, -20, 45, 301, -20, 45, 302, -20,
+	45, 306, -20, 45, 307, -20, 45, 308, -20, 46, 58, -30, 46, 60, -30, 46, 61, -30,
+	46, 62, -30, 46, 63, -30, 46, 64, -30, 46, 65, -30, 46, 66, -30, 46, 67, -30,
+	46, 153, -25, 46, 154, -25, 46, 155, -25, 46, 156, -25, 46, 157, -25, 46, 158, -25,
+	46, 159, -25, 46, 162, -25, 46, 165, -25, 46, 223, -25, 46, 224, -25, 46, 225, -25,
+	46, 226, -25, 46, 229, -25, 46, 230, -25, 46, 231, -25, 46, 238, -25, 46, 239, -25,
+	46, 293, -20, 46, 294, -20, 46, 295, -20, 46, 296, -20, 46, 297, -20, 46, 298, -20,
+	46, 299, -20, 46, 301, -20, 46, 302, -20, 46, 306, -20, 46, 307, -20, 46, 308, -20,
+	47, 79, -18, 47, 80, -18, 47, 81, -18, 47, 92, -37, 47, 93, -37, 47, 95, -37,
+	47, 96, -37, 47, 97, -37, 47, 259, -55, 47, 306, -37, 47, 307, -37, 47, 308, -37,
+	48, 79, -18, 48, 80, -18, 48, 81, -18, 48, 92, -37, 48, 93, -37, 48, 95, -37,
+	48, 96, -37, 48, 97, -37, 48, 259, -55, 48, 306, -37, 48, 307, -37, 48, 308, -37,
+	50, 79, -18, 50, 80, -18, 50, 81, -18, 50, 92, -37, 50, 93, -37, 50, 95, -37,
+	50, 96, -37, 50, 97, -37, 50, 259, -55, 50, 306, -37, 50, 307, -37, 50, 308, -37,
+	51, 79, -18, 51, 80, -18, 51, 81, -18, 51, 92, -37, 51, 93, -37, 51, 95, -37,
+	51, 96, -37, 51, 97, -37, 51, 259, -55, 51, 306, -37, 51, 307, -37, 51, 308, -37,
+	53, 0, -30, 53, 2, -30, 53, 3, -30, 53, 4, -30, 53, 5, -30, 53, 6, -30,
+	53, 7, -30, 53, 8, -30, 53, 9, -30, 53, 10, -30, 54, 0, -30, 54, 2, -30,
+	54, 3, -30, 54, 4, -30, 54, 5, -30, 54, 6, -30, 54, 7, -30, 54, 8, -30,
+	54, 9, -30, 54, 10, -30, 55, 0, -30, 55, 2, -30, 55, 3, -30, 55, 4, -30,
+	55, 5, -30, 55, 6, -30, 55, 7, -30, 55, 8, -30, 55, 9, -30, 55, 10, -30,
+	56, 0, -30, 56, 2, -30, 56, 3, -30, 56, 4, -30, 56, 5, -30, 56, 6, -30,
+	56, 7, -30, 56, 8, -30, 56, 9, -30, 56, 10, -30, 57, 0, -30, 57, 2, -30,
+	57, 3, -30, 57, 4, -30, 57, 5, -30, 57, 6, -30, 57, 7, -30, 57, 8, -30,
+	57, 9, -30, 57, 10, -30, 58, 0, -40, 58, 2, -40, 58, 3, -40, 58, 4, -40,
+	58, 5, -40, 58, 6, -40, 58, 7, -40, 58, 8, -40, 58, 9, -40, 58, 10, -40,
+	58, 79, -40, 58, 80, -40, 58, 81, -40, 58, 92, -50, 58, 93, -50, 58, 94, -40,
+	58, 95, -50, 58, 96, -50, 58, 97, -50, 60, 0, -40, 60, 2, -40, 60, 3, -40,
+	60, 4, -40, 60, 5, -40, 60, 6, -40, 60, 7, -40, 60, 8, -40, 60, 9, -40,
+	60, 10, -40, 60, 79, -40, 60, 80, -40, 60, 81, -40, 60, 92, -50, 60, 93, -50,
+	60, 94, -40, 60, 95, -50, 60, 96, -50, 60, 97, -50, 61, 0, -40, 61, 2, -40,
+	61, 3, -40, 61, 4, -40, 61, 5, -40, 61, 6, -40, 61, 7, -40, 61, 8, -40,
+	61, 9, -40, 61, 10, -40, 61, 79, -40, 61, 80, -40, 61, 81, -40, 61, 92, -50,
+	61, 93, -50, 61, 94, -40, 61, 95, -50, 61, 96, -50, 61, 97, -50, 62, 0, -40,
+	62, 2, -40, 62, 3, -40, 62, 4, -40, 62, 5, -40, 62, 6, -40, 62, 7, -40,
+	62, 8, -40, 62, 9, -40, 62, 10, -40, 62, 79, -40, 62, 80, -40, 62, 81, -40,
+	62, 92, -50, 62, 93, -50, 62, 94, -40, 62, 95, -50, 62, 96, -50, 62, 97, -50,
+	63, 0, -40, 63, 2, -40, 63, 3, -40, 63, 4, -40, 63, 5, -40, 63, 6, -40,
+	63, 7, -40, 63, 8, -40, 63, 9, -40, 63, 10, -40, 63, 79, -40, 63, 80, -40,
+	63, 81, -40, 63, 92, -50, 63, 93, -50, 63, 94, -40, 63, 95, -50, 63, 96, -50,
+	63, 97, -50, 64, 0, -40, 64, 2, -40, 64, 3, -40, 64, 4, -40, 64, 5, -40,
+	64, 6, -40, 64, 7, -40, 64, 8, -40, 64, 9, -40, 64, 10, -40, 64, 79, -40,
+	64, 80, -40, 64, 81, -40, 64, 92, -50, 64, 93, -50, 64, 94, -40, 64, 95, -50,
+	64, 96, -50, 64, 97, -50, 65, 0, -40, 65, 2, -40, 65, 3, -40, 65, 4, -40,
+	65, 5, -40, 65, 6, -40, 65, 7, -40, 65, 8, -40, 65, 9, -40, 65, 10, -40,
+	65, 79, -40, 65, 80, -40, 65, 81, -40, 65, 92, -50, 65, 93, -50, 65, 94, -40,
+	65, 95, -50, 65, 96, -50, 65, 97, -50, 66, 0, -40, 66, 2, -40, 66, 3, -40,
+	66, 4, -40, 66, 5, -40, 66, 6, -40, 66, 7, -40, 66, 8, -40, 66, 9, -40,
+	66, 10, -40, 66, 79, -40, 66, 80, -40, 66, 81, -40, 66, 92, -50, 66, 93, -50,
+	66, 94, -40, 66, 95, -50, 66, 96, -50, 66, 97, -50, 67, 0, -40, 67, 2, -40,
+	67, 3, -40, 67, 4, -40, 67, 5, -40, 67, 6, -40, 67, 7, -40, 67, 8, -40,
+	67, 9, -40, 67, 10, -40, 67, 79, -40, 67, 80, -40, 67, 81, -40, 67, 92, -50,
+	67, 93, -50, 67, 94, -40, 67, 95, -50, 67, 96, -50, 67, 97, -50, 68, 0, -85,
+	68, 2, -85, 68, 3, -85, 68, 4, -85, 68, 5, -85, 68, 6, -85, 68, 7, -85,
+	68, 8, -85, 68, 9, -85, 68, 10, -85, 68, 102, -40, 68, 103, -40, 68, 104, -40,
+	68, 105, -40, 68, 107, -40, 68, 109, -40, 68, 110, -40, 68, 112, -40, 68, 113, -40,
+	68, 118, -40, 68, 138, -129, 68, 153, -50, 68, 154, -50, 68, 155, -50, 68, 156, -50,
+	68, 157, -50, 68, 158, -50, 68, 159, -50, 68, 162, -50, 68, 165, -50, 68, 223, -55,
+	68, 224, -55, 68, 225, -55, 68, 226, -55, 68, 229, -55, 68, 230, -55, 68, 231, -55,
+	68, 238, -55, 68, 239, -55, 68, 246, -129, 69, 83, -10, 69, 84, -10, 69, 85, -10,
+	69, 86, -10, 69, 87, -10, 69, 88, -10, 69, 89, -10, 69, 90, -10, 69, 91, -10,
+	70, 58, -40, 70, 60, -40, 70, 61, -40, 70, 62, -40, 70, 63, -40, 70, 64, -40,
+	70, 65, -40, 70, 66, -40, 70, 67, -40, 70, 79, -30, 70, 80, -30, 70, 81, -30,
+	70, 83, -40, 70, 84, -40, 70, 85, -40, 70, 86, -40, 70, 87, -40, 70, 88, -40,
+	70, 89, -40, 70, 90, -40, 70, 91, -40, 70, 92, -18, 70, 93, -18, 70, 95, -18,
+	70, 96, -18, 70, 97, -18, 71, 58, -40, 71, 60, -40, 71, 61, -40, 71, 62, -40,
+	71, 63, -40, 71, 64, -40, 71, 65, -40, 71, 66, -40, 71, 67, -40, 71, 79, -30,
+	71, 80, -30, 71, 81, -30, 71, 83, -40, 71, 84, -40, 71, 85, -40, 71, 86, -40,
+	71, 87, -40, 71, 88, -40, 71, 89, -40, 71, 90, -40, 71, 91, -40, 71, 92, -18,
+	71, 93, -18, 71, 95, -18, 71, 96, -18, 71, 97, -18, 72, 58, -40, 72, 60, -40,
+	72, 61, -40, 72, 62, -40, 72, 63, -40, 72, 64, -40, 72, 65, -40, 72, 66, -40,
+	72, 67, -40, 72, 79, -30, 72, 80, -30, 72, 81, -30, 72, 83, -40, 72, 84, -40,
+	72, 85, -40, 72, 86, -40, 72, 87, -40, 72, 88, -40, 72, 89, -40, 72, 90, -40,
+	72, 91, -40, 72, 92, -18, 72, 93, -18, 72, 95, -18, 72, 96, -18, 72, 97, -18,
+	73, 58, -40, 73, 60, -40, 73, 61, -40, 73, 62, -40, 73, 63, -40, 73, 64, -40,
+	73, 65, -40, 73, 66, -40, 73, 67, -40, 73, 79, -30, 73, 80, -30, 73, 81, -30,
+	73, 83, -40, 73, 84, -40, 73, 85, -40, 73, 86, -40, 73, 87, -40, 73, 88, -40,
+	73, 89, -40, 73, 90, -40, 73, 91, -40, 73, 92, -18, 73, 93, -18, 73, 95, -18,
+	73, 96, -18, 73, 97, -18, 79, 0, -55, 79, 2, -55, 79, 3, -55, 79, 4, -55,
+	79, 5, -55, 79, 6, -55, 79, 7, -55, 79, 8, -55, 79, 9, -55, 79, 10, -55,
+	79, 58, -18, 79, 60, -18, 79, 61, -18, 79, 62, -18, 79, 63, -18, 79, 64, -18,
+	79, 65, -18, 79, 66, -18, 79, 67, -18, 79, 102, -92, 79, 103, -92, 79, 104, -92,
+	79, 105, -92, 79, 107, -92, 79, 109, -92, 79, 110, -92, 79, 112, -92, 79, 113, -92,
+	79, 118, -92, 79, 137, -74, 79, 138, -92, 79, 153, -92, 79, 154, -92, 79, 155, -92,
+	79, 156, -92, 79, 157, -52, 79, 158, -92, 79, 159, -52, 79, 162, -52, 79, 165, -92,
+	79, 190, -92, 79, 191, -37, 79, 192, -37, 79, 197, -37, 79, 223, -95, 79, 224, -95,
+	79, 225, -95, 79, 226, -95, 79, 229, -95, 79, 230, -95, 79, 231, -95, 79, 238, -95,
+	79, 239, -95, 79, 246, -92, 79, 262, -37, 79, 263, -37, 79, 265, -37, 79, 266, -37,
+	79, 275, -74, 79, 293, -37, 79, 294, -37, 79, 295, -37, 79, 296, -37, 79, 297, -37,
+	79, 298, -37, 79, 299, -37, 79, 301, -37, 79, 302, -37, 79, 304, -37, 79, 306, -37,
+	79, 307, -37, 79, 308, -37, 80, 0, -55, 80, 2, -55, 80, 3, -55, 80, 4, -55,
+	80, 5, -55, 80, 6, -55, 80, 7, -55, 80, 8, -55, 80, 9, -55, 80, 10, -55,
+	80, 58, -18, 80, 60, -18, 80, 61, -18, 80, 62, -18, 80, 63, -18, 80, 64, -18,
+	80, 65, -18, 80, 66, -18, 80, 67, -18, 80, 102, -92, 80, 103, -92, 80, 104, -92,
+	80, 105, -92, 80, 107, -92, 80, 109, -92, 80, 110, -92, 80, 112, -92, 80, 113, -92,
+	80, 118, -92, 80, 137, -74, 80, 138, -92, 80, 153, -92, 80, 154, -92, 80, 155, -92,
+	80, 156, -92, 80, 157, -52, 80, 158, -92, 80, 159, -52, 80, 162, -52, 80, 165, -92,
+	80, 190, -92, 80, 191, -37, 80, 192, -37, 80, 197, -37, 80, 223, -95, 80, 224, -95,
+	80, 225, -95, 80, 226, -95, 80, 229, -95, 80, 230, -95, 80, 231, -95, 80, 238, -95,
+	80, 239, -95, 80, 246, -92, 80, 262, -37, 80, 263, -37, 80, 265, -37, 80, 266, -37,
+	80, 275, -74, 80, 293, -37, 80, 294, -37, 80, 295, -37, 80, 296, -37, 80, 297, -37,
+	80, 298, -37, 80, 299, -37, 80, 301, -37, 80, 302, -37, 80, 304, -37, 80, 306, -37,
+	80, 307, -37, 80, 308, -37, 81, 0, -55, 81, 2, -55, 81, 3, -55, 81, 4, -55,
+	81, 5, -55, 81, 6, -55, 81, 7, -55, 81, 8, -55, 81, 9, -55, 81, 10, -55,
+	81, 58, -18, 81, 60, -18, 81, 61, -18, 81, 62, -18, 81, 63, -18, 81, 64, -18,
+	81, 65, -18, 81, 66, -18, 81, 67, -18, 81, 102, -92, 81, 103, -92, 81, 104, -92,
+	81, 105, -92, 81, 107, -92, 81, 109, -92, 81, 110, -92, 81, 112, -92, 81, 113, -92,
+	81, 118, -92, 81, 137, -74, 81, 138, -92, 81, 153, -92, 81, 154, -92, 81, 155, -92,
+	81, 156, -92, 81, 157, -52, 81, 158, -92, 81, 159, -52, 81, 162, -52, 81, 165, -92,
+	81, 190, -92, 81, 191, -37, 81, 192, -37, 81, 197, -37, 81, 223, -95, 81, 224, -95,
+	81, 225, -95, 81, 226, -95, 81, 229, -95, 81, 230, -95, 81, 231, -95, 81, 238, -95,
+	81, 239, -95, 81, 246, -92, 81, 262, -37, 81, 263, -37, 81, 265, -37, 81, 266, -37,
+	81, 275, -74, 81, 293, -37, 81, 294, -37, 81, 295, -37, 81, 296, -37, 81, 297, -37,
+	81, 298, -37, 81, 299, -37, 81, 301, -37, 81, 302, -37, 81, 304, -37, 81, 306, -37,
+	81, 307, -37, 81, 308, -37, 83, 0, -45, 83, 2, -45, 83, 3, -45, 83, 4, -45,
+	83, 5, -45, 83, 6, -45, 83, 7, -45, 83, 8, -45, 83, 9, -45, 83, 10, -45,
+	84, 0, -45, 84, 2, -45, 84, 3, -45, 84, 4, -45, 84, 5, -45, 84, 6, -45,
+	84, 7, -45, 84, 8, -45, 84, 9, -45, 84, 10, -45, 85, 0, -45, 85, 2, -45,
+	85, 3, -45, 85, 4, -45, 85, 5, -45, 85, 6, -45, 85, 7, -45, 85, 8, -45,
+	85, 9, -45, 85, 10, -45, 86, 0, -45, 86, 2, -45, 86, 3, -45, 86, 4, -45,
+	86, 5, -45, 86, 6, -45, 86, 7, -45, 86, 8, -45, 86, 9, -45, 86, 10, -45,
+	87, 0, -45, 87, 2, -45, 87, 3, -45, 87, 4, -45, 87, 5, -45, 87, 6, -45,
+	87, 7, -45, 87, 8, -45, 87, 9, -45, 87, 10, -45, 88, 0, -45, 88, 2, -45,
+	88, 3, -45, 88, 4, -45, 88, 5, -45, 88, 6, -45, 88, 7, -45, 88, 8, -45,
+	88, 9, -45, 88, 10, -45, 89, 0, -45, 89, 2, -45, 89, 3, -45, 89, 4, -45,
+	89, 5, -45, 89, 6, -45, 89, 7, -45, 89, 8, -45, 89, 9, -45, 89, 10, -45,
+	90, 0, -45, 90, 2, -45, 90, 3, -45, 90, 4, -45, 90, 5, -45, 90, 6, -45,
+	90, 7, -45, 90, 8, -45, 90, 9, -45, 90, 10, -45, 91, 0, -45, 91, 2, -45,
+	91, 3, -45, 91, 4, -45, 91, 5, -45, 91, 6, -45, 91, 7, -45, 91, 8, -45,
+	91, 9, -45, 91, 10, -45, 92, 0, -85, 92, 2, -85, 92, 3, -85, 92, 4, -85,
+	92, 5, -85, 92, 6, -85, 92, 7, -85, 92, 8, -85, 92, 9, -85, 92, 10, -85,
+	92, 32, -10, 92, 33, -10, 92, 34, -10, 92, 58, -30, 92, 60, -30, 92, 61, -30,
+	92, 62, -30, 92, 63, -30, 92, 64, -30, 92, 65, -30, 92, 66, -30, 92, 67, -30,
+	92, 102, -111, 92, 103, -111, 92, 104, -111, 92, 105, -111, 92, 107, -111, 92, 109, -111,
+	92, 110, -111, 92, 112, -111, 92, 113, -111, 92, 118, -111, 92, 137, -74, 92, 138, -129,
+	92, 153, -111, 92, 154, -111, 92, 155, -111, 92, 156, -111, 92, 157, -71, 92, 158, -111,
+	92, 159, -71, 92, 162, -71, 92, 165, -111, 92, 190, -70, 92, 191, -55, 92, 192, -55,
+	92, 197, -55, 92, 223, -111, 92, 224, -111, 92, 225, -111, 92, 226, -111, 92, 229, -111,
+	92, 230, -111, 92, 231, -111, 92, 238, -111, 92, 239, -111, 92, 246, -129, 92, 275, -74,
+	92, 293, -55, 92, 294, -55, 92, 295, -55, 92, 296, -55, 92, 297, -55, 92, 298, -55,
+	92, 299, -55, 92, 301, -55, 92, 302, -55, 93, 0, -74, 93, 2, -74, 93, 3, -74,
+	93, 4, -74, 93, 5, -74, 93, 6, -74, 93, 7, -74, 93, 8, -74, 93, 9, -74,
+	93, 10, -74, 93, 58, -15, 93, 60, -15, 93, 61, -15, 93, 62, -15, 93, 63, -15,
+	93, 64, -15, 93, 65, -15, 93, 66, -15, 93, 67, -15, 93, 102, -85, 93, 103, -85,
+	93, 104, -85, 93, 105, -85, 93, 107, -85, 93, 109, -85, 93, 110, -85, 93, 112, -85,
+	93, 113, -85, 93, 118, -85, 93, 137, -55, 93, 138, -74, 93, 153, -90, 93, 154, -90,
+	93, 155, -90, 93, 156, -90, 93, 157, -50, 93, 158, -90, 93, 159, -50, 93, 162, -50,
+	93, 165, -90, 93, 190, -50, 93, 191, -37, 93, 192, -37, 93, 197, -37, 93, 223, -80,
+	93, 224, -80, 93, 225, -80, 93, 226, -80, 93, 229, -80, 93, 230, -80, 93, 231, -80,
+	93, 238, -80, 93, 239, -80, 93, 246, -74, 93, 275, -55, 93, 293, -55, 93, 294, -55,
+	93, 295, -55, 93, 296, -55, 93, 297, -55, 93, 298, -55, 93, 299, -55, 93, 301, -55,
+	93, 302, -55, 93, 306, -55, 93, 307, -55, 93, 308, -55, 95, 0, -74, 95, 2, -74,
+	95, 3, -74, 95, 4, -74, 95, 5, -74, 95, 6, -74, 95, 7, -74, 95, 8, -74,
+	95, 9, -74, 95, 10, -74, 95, 58, -25, 95, 60, -25, 95, 61, -25, 95, 62, -25,
+	95, 63, -25, 95, 64, -25, 95, 65, -25, 95, 66, -25, 95, 67, -25, 95, 102, -92,
+	95, 103, -92, 95, 104, -92, 95, 105, -92, 95, 107, -92, 95, 109, -92, 95, 110, -92,
+	95, 112, -92, 95, 113, -92, 95, 118, -92, 95, 137, -92, 95, 138, -92, 95, 153, -111,
+	95, 154, -111, 95, 155, -111, 95, 156, -71, 95, 157, -71, 95, 158, -111, 95, 159, -71,
+	95, 162, -71, 95, 165, -111, 95, 190, -92, 95, 191, -55, 95, 192, -55, 95, 197, -55,
+	95, 223, -111, 95, 224, -111, 95, 225, -111, 95, 226, -111, 95, 229, -111, 95, 230, -111,
+	95, 231, -111, 95, 238, -111, 95, 239, -111, 95, 246, -74, 95, 275, -92, 95, 293, -92,
+	95, 294, -92, 95, 295, -92, 95, 296, -92, 95, 297, -92, 95, 298, -92, 95, 299, -92,
+	95, 301, -92, 95, 302, -92, 96, 0, -74, 96, 2, -74, 96, 3, -74, 96, 4, -74,
+	96, 5, -74, 96, 6, -74, 96, 7, -74, 96, 8, -74, 96, 9, -74, 96, 10, -74,
+	96, 58, -25, 96, 60, -25, 96, 61, -25, 96, 62, -25, 96, 63, -25, 96, 64, -25,
+	96, 65, -25, 96, 66, -25, 96, 67, -25, 96, 102, -92, 96, 103, -92, 96, 104, -92,
+	96, 105, -92, 96, 107, -92, 96, 109, -92, 96, 110, -92, 96, 112, -92, 96, 113, -92,
+	96, 118, -92, 96, 137, -92, 96, 138, -92, 96, 153, -111, 96, 154, -111, 96, 155, -111,
+	96, 156, -71, 96, 157, -71, 96, 158, -111, 96, 159, -71, 96, 162, -71, 96, 165, -111,
+	96, 190, -92, 96, 191, -55, 96, 192, -55, 96, 197, -55, 96, 223, -111, 96, 224, -111,
+	96, 225, -111, 96, 226, -111, 96, 229, -111, 96, 230, -111, 96, 231, -111, 96, 238, -111,
+	96, 239, -111, 96, 246, -74, 96, 275, -92, 96, 293, -92, 96, 294, -92, 96, 295, -92,
+	96, 296, -92, 96, 297, -92, 96, 298, -92, 96, 299, -92, 96, 301, -92, 96, 302, -92,
+	97, 0, -74, 97, 2, -74, 97, 3, -74, 97, 4, -74, 97, 5, -74, 97, 6, -74,
+	97, 7, -74, 97, 8, -74, 97, 9, -74, 97, 10, -74, 97, 58, -25, 97, 60, -25,
+	97, 61, -25, 97, 62, -25, 97, 63, -25, 97, 64, -25, 97, 65, -25, 97, 66, -25,
+	97, 67, -25, 97, 102, -92, 97, 103, -92, 97, 104, -92, 97, 105, -92, 97, 107, -92,
+	97, 109, -92, 97, 110, -92, 97, 112, -92, 97, 113, -92, 97, 118, -92, 97, 137, -92,
+	97, 138, -92, 97, 153, -111, 97, 154, -111, 97, 155, -111, 97, 156, -71, 97, 157, -71,
+	97, 158, -111, 97, 159, -71, 97, 162, -71, 97, 165, -111, 97, 190, -92, 97, 191, -55,
+	97, 192, -55, 97, 197, -55, 97, 223, -111, 97, 224, -111, 97, 225, -111, 97, 226, -111,
+	97, 229, -111, 97, 230, -111, 97, 231, -111, 97, 238, -111, 97, 239, -111, 97, 246, -74,
+	97, 275, -92, 97, 293, -92, 97, 294, -92, 97, 295, -92, 97, 296, -92, 97, 297, -92,
+	97, 298, -92, 97, 299, -92, 97, 301, -92, 97, 302, -92, 119, 119, -10, 119, 246, -40,
+	119, 293, -20, 119, 294, -20, 119, 295, -20, 119, 296, -20, 119, 297, -20, 119, 298, -20,
+	119, 299, -20, 119, 301, -20, 119, 302, -20, 129, 188, -10, 129, 199, -10, 129, 200, -10,
+	130, 188, -10, 130, 199, -10, 130, 200, -10, 132, 188, -10, 132, 199, -10, 132, 200, -10,
+	133, 188, -10, 133, 199, -10, 133, 200, -10, 138, 257, -95, 138, 259, -95, 153, 119, -10,
+	154, 119, -10, 155, 119, -10, 156, 119, -10, 157, 119, -10, 158, 119, -10, 159, 119, -10,
+	162, 119, -10, 165, 119, -10, 170, 138, -10, 170, 152, -30, 170, 153, -10, 170, 154, -10,
+	170, 158, -10, 170, 165, -10, 170, 170, -18, 170, 223, -10, 170, 224, -10, 170, 225, -10,
+	170, 229, -10, 170, 230, -10, 170, 238, -10, 170, 239, -10, 170, 246, -10, 170, 259, 55,
+	199, 153, -30, 199, 154, -30, 199, 155, -30, 199, 156, -30, 199, 157, -30, 199, 158, -30,
+	199, 159, -30, 199, 162, -30, 199, 165, -30, 199, 223, -10, 199, 224, -10, 199, 225, -10,
+	199, 226, -10, 199, 229, -10, 199, 230, -10, 199, 231, -10, 199, 238, -10, 199, 239, -10,
+	200, 153, -30, 200, 154, -30, 200, 155, -30, 200, 156, -30, 200, 157, -30, 200, 158, -30,
+	200, 159, -30, 200, 162, -30, 200, 165, -30, 200, 223, -10, 200, 224, -10, 200, 225, -10,
+	200, 226, -10, 200, 229, -10, 200, 230, -10, 200, 231, -10, 200, 238, -10, 200, 239, -10,
+	215, 303, -40, 216, 303, -40, 217, 303, -40, 218, 303, -40, 221, 303, -40, 223, 303, -15,
+	223, 304, -25, 223, 305, -10, 223, 306, -10, 223, 307, -10, 223, 308, -10, 224, 303, -15,
+	224, 304, -25, 224, 305, -10, 224, 306, -10, 224, 307, -10, 224, 308, -10, 225, 303, -15,
+	225, 304, -25, 225, 305, -10, 225, 306, -10, 225, 307, -10, 225, 308, -10, 226, 303, -15,
+	226, 304, -25, 226, 305, -10, 226, 306, -10, 226, 307, -10, 226, 308, -10, 229, 303, -15,
+	229, 304, -25, 229, 305, -10, 229, 306, -10, 229, 307, -10, 229, 308, -10, 230, 303, -15,
+	230, 304, -25, 230, 305, -10, 230, 306, -10, 230, 307, -10, 230, 308, -10, 231, 303, -15,
+	231, 304, -25, 231, 305, -10, 231, 306, -10, 231, 307, -10, 231, 308, -10, 238, 303, -15,
+	238, 304, -25, 238, 305, -10, 238, 306, -10, 238, 307, -10, 238, 308, -10, 239, 303, -15,
+	239, 304, -25, 239, 305, -10, 239, 306, -10, 239, 307, -10, 239, 308, -10, 246, 257, -95,
+	246, 259, -95, 258, 258, -74, 259, 142, -15, 259, 146, -15, 259, 259, -74, 259, 262, -15,
+	259, 263, -15, 259, 265, -15, 259, 266, -15, 259, 269, -74, 259, 270, -74, 259, 271, -74,
+	259, 272, -74, 259, 273, -74, 259, 279, -74, 259, 282, -37, 259, 284, -37, 259, 303, -15,
+	262, 138, -65, 262, 246, -65, 263, 138, -65, 263, 246, -65, 265, 138, -65, 265, 246, -65,
+	266, 138, -65, 266, 246, -65, 279, 0, -37, 279, 2, -37, 279, 3, -37, 279, 4, -37,
+	279, 5, -37, 279, 6, -37, 279, 7, -37, 279, 8, -37, 279, 9, -37, 279, 10, -37,
+	279, 92, -70, 279, 93, -70, 279, 95, -70, 279, 96, -70, 279, 97, -70, 303, 138, -37,
+	303, 153, -15, 303, 154, -15, 303, 155, -15, 303, 156, -15, 303, 157, -15, 303, 158, -15,
+	303, 159, -15, 303, 162, -15, 303, 165, -15, 303, 223, -15, 303, 224, -15, 303, 225, -15,
+	303, 226, -15, 303, 229, -15, 303, 230, -15, 303, 231, -15, 303, 238, -15, 303, 239, -15,
+	303, 246, -37, 304, 102, -10, 304, 103, -10, 304, 104, -10, 304, 105, -10, 304, 107, -10,
+	304, 109, -10, 304, 110, -10, 304, 112, -10, 304, 113, -10, 304, 118, -10, 304, 138, -37,
+	304, 153, -10, 304, 154, -10, 304, 155, -10, 304, 156, -10, 304, 157, -10, 304, 158, -10,
+	304, 159, -10, 304, 162, -10, 304, 165, -10, 304, 223, -15, 304, 224, -15, 304, 225, -15,
+	304, 226, -15, 304, 229, -15, 304, 230, -15, 304, 231, -15, 304, 238, -15, 304, 239, -15,
+	304, 246, -37, 305, 153, -10, 305, 154, -10, 305, 155, -10, 305, 156, -10, 305, 157, -10,
+	305, 158, -10, 305, 159, -10, 305, 162, -10, 305, 165, -10, 306, 138, -37, 306, 246, -37,
+	307, 138, -37, 307, 246, -37, 308, 138, -37, 308, 246, -37,
+}
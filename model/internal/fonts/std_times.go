@@ -51,7 +51,9 @@ func newFontTimesRoman() StdFont {
 		StemV:       84,
 		StemH:       28,
 	}
-	return NewStdFont(desc, timesRomanCharMetrics)
+	font := NewStdFont(desc, timesRomanCharMetrics)
+	font.kerning = timesRomanKerning
+	return font
 }
 
 // newFontTimesBold returns a new instance of the font with a default encoder set (WinAnsiEncoding).
@@ -71,7 +73,9 @@ func newFontTimesBold() StdFont {
 		StemV:       139,
 		StemH:       44,
 	}
-	return NewStdFont(desc, timesBoldCharMetrics)
+	font := NewStdFont(desc, timesBoldCharMetrics)
+	font.kerning = timesBoldKerning
+	return font
 }
 
 // newFontTimesItalic returns a new instance of the font with a default encoder set (WinAnsiEncoding).
@@ -91,7 +95,9 @@ func newFontTimesItalic() StdFont {
 		StemV:       76,
 		StemH:       32,
 	}
-	return NewStdFont(desc, timesItalicCharMetrics)
+	font := NewStdFont(desc, timesItalicCharMetrics)
+	font.kerning = timesItalicKerning
+	return font
 }
 
 // newFontTimesBoldItalic returns a new instance of the font with a default encoder set (WinAnsiEncoding).
@@ -111,7 +117,9 @@ func newFontTimesBoldItalic() StdFont {
 		StemV:       121,
 		StemH:       42,
 	}
-	return NewStdFont(desc, timesBoldItalicCharMetrics)
+	font := NewStdFont(desc, timesBoldItalicCharMetrics)
+	font.kerning = timesBoldItalicKerning
+	return font
 }
 
 var timesOnce sync.Once
@@ -21,6 +21,17 @@ func (c *Chain) Append(optimizers ...model.Optimizer) {
 	c.optimizers = append(c.optimizers, optimizers...)
 }
 
+// SetTrailerObjects passes the objects referenced from the trailer dictionary
+// to the optimizers of the chain that need them.
+// It implements interface model.TrailerObjectsSetter.
+func (c *Chain) SetTrailerObjects(objects []core.PdfObject) {
+	for _, optimizer := range c.optimizers {
+		if setter, ok := optimizer.(model.TrailerObjectsSetter); ok {
+			setter.SetTrailerObjects(objects)
+		}
+	}
+}
+
 // Optimize optimizes PDF objects to decrease PDF size.
 func (c *Chain) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfObject, err error) {
 	optimizedObjects = objects
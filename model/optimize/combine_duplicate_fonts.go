@@ -0,0 +1,177 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package optimize
+
+import (
+	"crypto/md5"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// CombineDuplicateFonts combines duplicated font dictionaries, such as the
+// fonts embedded several times by documents assembled from multiple sources.
+// Fonts are considered duplicates if their dictionaries are identical,
+// including the content of the objects they reference: the font descriptors
+// and the embedded font programs (FontFile, FontFile2 and FontFile3 streams),
+// the descendant fonts, the encodings, the widths and the ToUnicode CMaps.
+// Streams are compared by their raw data and dictionaries.
+// The references to the duplicates, such as the ones of resource
+// dictionaries, are replaced with references to a single shared font, and
+// the objects of the duplicates are removed. The descendant fonts of Type0
+// fonts are combined along with their parent fonts.
+// The number of fonts combined by the last run of the optimizer is reported
+// in the Combined field.
+// It implements interface model.Optimizer.
+type CombineDuplicateFonts struct {
+	// Combined is the number of duplicate fonts which were replaced by a
+	// shared font by the last run of the optimizer.
+	Combined int
+}
+
+// Optimize optimizes PDF objects to decrease PDF size.
+func (dup *CombineDuplicateFonts) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfObject, err error) {
+	dup.Combined = 0
+	replaceTable := make(map[core.PdfObject]core.PdfObject)
+	fontsByKey := make(map[string]*core.PdfIndirectObject)
+	for _, obj := range objects {
+		ind, isIndirect := obj.(*core.PdfIndirectObject)
+		if !isIndirect {
+			continue
+		}
+		dict, isDict := ind.PdfObject.(*core.PdfObjectDictionary)
+		if !isDict {
+			continue
+		}
+		if kind, _ := core.GetNameVal(dict.Get("Type")); kind != "Font" {
+			continue
+		}
+		switch subtype, _ := core.GetNameVal(dict.Get("Subtype")); subtype {
+		case "CIDFontType0", "CIDFontType2":
+			// Combined along with their parent Type0 fonts.
+			continue
+		}
+
+		key := fontKey(ind, make(map[core.PdfObject]struct{}))
+		first, found := fontsByKey[key]
+		if !found {
+			fontsByKey[key] = ind
+			continue
+		}
+		mapDuplicateObjects(ind, first, replaceTable)
+		dup.Combined++
+	}
+	if len(replaceTable) == 0 {
+		return objects, nil
+	}
+
+	optimizedObjects = make([]core.PdfObject, 0, len(objects))
+	for _, obj := range objects {
+		if _, found := replaceTable[obj]; found {
+			continue
+		}
+		optimizedObjects = append(optimizedObjects, obj)
+	}
+	replaceObjectsInPlace(optimizedObjects, replaceTable)
+	return optimizedObjects, nil
+}
+
+// fontKey returns a string identifying the content of the font object `obj`,
+// including the content of the objects it references. The streams are
+// identified by the hash of their raw data. The objects of `visiting` are
+// being identified, and are identified by their address if they are
+// referenced recursively.
+func fontKey(obj core.PdfObject, visiting map[core.PdfObject]struct{}) string {
+	switch t := obj.(type) {
+	case nil:
+		return ""
+	case *core.PdfObjectReference:
+		return fontKey(t.Resolve(), visiting)
+	case *core.PdfIndirectObject:
+		if _, found := visiting[t]; found {
+			return fmt.Sprintf("%p", t)
+		}
+		visiting[t] = struct{}{}
+		defer delete(visiting, t)
+		return "obj(" + fontKey(t.PdfObject, visiting) + ")"
+	case *core.PdfObjectStream:
+		if _, found := visiting[t]; found {
+			return fmt.Sprintf("%p", t)
+		}
+		visiting[t] = struct{}{}
+		defer delete(visiting, t)
+		return fmt.Sprintf("stream(%x %s)", md5.Sum(t.Stream), fontKey(t.PdfObjectDictionary, visiting))
+	case *core.PdfObjectArray:
+		parts := make([]string, t.Len())
+		for i, elem := range t.Elements() {
+			parts[i] = fontKey(elem, visiting)
+		}
+		return "[" + strings.Join(parts, " ") + "]"
+	case *core.PdfObjectDictionary:
+		if t == nil {
+			return ""
+		}
+		keys := t.Keys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+		parts := make([]string, len(keys))
+		for i, key := range keys {
+			parts[i] = "/" + string(key) + " " + fontKey(t.Get(key), visiting)
+		}
+		return "<<" + strings.Join(parts, " ") + ">>"
+	}
+	return obj.WriteString()
+}
+
+// mapDuplicateObjects adds the indirect objects and streams of `dup` to
+// `replaceTable`, mapped to the corresponding objects of `first`. Both
+// objects are expected to have the same content, as identified by fontKey.
+func mapDuplicateObjects(dup, first core.PdfObject, replaceTable map[core.PdfObject]core.PdfObject) {
+	if dup == first {
+		return
+	}
+	switch t := dup.(type) {
+	case *core.PdfObjectReference:
+		mapDuplicateObjects(t.Resolve(), core.ResolveReference(first), replaceTable)
+	case *core.PdfIndirectObject:
+		f, ok := first.(*core.PdfIndirectObject)
+		if !ok {
+			return
+		}
+		if _, found := replaceTable[t]; found {
+			return
+		}
+		replaceTable[t] = f
+		mapDuplicateObjects(t.PdfObject, f.PdfObject, replaceTable)
+	case *core.PdfObjectStream:
+		f, ok := first.(*core.PdfObjectStream)
+		if !ok {
+			return
+		}
+		if _, found := replaceTable[t]; found {
+			return
+		}
+		replaceTable[t] = f
+		mapDuplicateObjects(t.PdfObjectDictionary, f.PdfObjectDictionary, replaceTable)
+	case *core.PdfObjectArray:
+		f, ok := first.(*core.PdfObjectArray)
+		if !ok || f.Len() != t.Len() {
+			return
+		}
+		for i, elem := range t.Elements() {
+			mapDuplicateObjects(elem, f.Get(i), replaceTable)
+		}
+	case *core.PdfObjectDictionary:
+		f, ok := first.(*core.PdfObjectDictionary)
+		if !ok || t == nil || f == nil {
+			return
+		}
+		for _, key := range t.Keys() {
+			mapDuplicateObjects(t.Get(key), f.Get(key), replaceTable)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package optimize
+
+import (
+	"crypto/md5"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// CombineDuplicateImages combines duplicated image XObjects.
+// It implements interface model.Optimizer.
+// Images are considered duplicates if their raw stream data and image
+// parameters (size, color space, bits per component, filters, decode
+// parameters and masks) are identical. The references to the duplicates,
+// such as the ones of resource dictionaries, are replaced with references
+// to a single shared image.
+type CombineDuplicateImages struct {
+}
+
+// Optimize optimizes PDF objects to decrease PDF size.
+func (dup *CombineDuplicateImages) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfObject, err error) {
+	optimizedObjects = objects
+	// Images referencing duplicate masks become duplicates once the masks
+	// are combined, so repeat until no duplicates are found.
+	for {
+		var combined bool
+		optimizedObjects, combined = dup.combine(optimizedObjects)
+		if !combined {
+			return optimizedObjects, nil
+		}
+	}
+}
+
+// combine replaces the duplicate images of `objects`. The returned flag is
+// false if no duplicates were found.
+func (dup *CombineDuplicateImages) combine(objects []core.PdfObject) ([]core.PdfObject, bool) {
+	replaceTable := make(map[core.PdfObject]core.PdfObject)
+	imagesByHash := make(map[string]*core.PdfObjectStream)
+	for _, obj := range objects {
+		stream, isStreamObj := obj.(*core.PdfObjectStream)
+		if !isStreamObj {
+			continue
+		}
+		if subtype, _ := core.GetNameVal(stream.Get("Subtype")); subtype != "Image" {
+			continue
+		}
+
+		hasher := md5.New()
+		hasher.Write(stream.Stream)
+		// All the image dictionary entries are compared, except for the
+		// stream length which is implied by the data, as entries such as
+		// OC, StructParent or Alt make otherwise identical images distinct.
+		for _, key := range sortedKeys(stream.PdfObjectDictionary) {
+			if key == "Length" {
+				continue
+			}
+			hasher.Write([]byte(fmt.Sprintf("/%s %s\n", key, imageParamKey(stream.Get(key)))))
+		}
+		hash := string(hasher.Sum(nil))
+		if first, found := imagesByHash[hash]; found {
+			replaceTable[stream] = first
+			continue
+		}
+		imagesByHash[hash] = stream
+	}
+	if len(replaceTable) == 0 {
+		return objects, false
+	}
+
+	optimizedObjects := make([]core.PdfObject, 0, len(objects)-len(replaceTable))
+	for _, obj := range objects {
+		if _, found := replaceTable[obj]; found {
+			continue
+		}
+		optimizedObjects = append(optimizedObjects, obj)
+	}
+	replaceObjectsInPlace(optimizedObjects, replaceTable)
+	return optimizedObjects, true
+}
+
+// imageParamKey returns a string identifying the image parameter `obj`.
+// Indirect objects and streams are identified by their address, as their
+// object numbers are not assigned prior to writing.
+func imageParamKey(obj core.PdfObject) string {
+	switch t := obj.(type) {
+	case nil:
+		return ""
+	case *core.PdfIndirectObject, *core.PdfObjectStream:
+		return fmt.Sprintf("%p", t)
+	case *core.PdfObjectArray:
+		parts := make([]string, t.Len())
+		for i, elem := range t.Elements() {
+			parts[i] = imageParamKey(elem)
+		}
+		return "[" + strings.Join(parts, " ") + "]"
+	case *core.PdfObjectDictionary:
+		var parts []string
+		for _, key := range sortedKeys(t) {
+			parts = append(parts, "/"+string(key)+" "+imageParamKey(t.Get(key)))
+		}
+		return "<<" + strings.Join(parts, " ") + ">>"
+	}
+	return obj.WriteString()
+}
+
+// sortedKeys returns the keys of `dict` sorted, so that dictionaries with the
+// same entries in different order produce the same key.
+func sortedKeys(dict *core.PdfObjectDictionary) []core.PdfObjectName {
+	keys := append([]core.PdfObjectName(nil), dict.Keys()...)
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
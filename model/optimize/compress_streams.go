@@ -6,47 +6,298 @@
 package optimize
 
 import (
+	"compress/flate"
+	"runtime"
+	"sync"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
 	"github.com/bcmmbaga/unipdf-agpl/v3/core"
 )
 
+// defaultCompressMinSize is the default minimum size, in bytes, of the
+// streams compressed by the CompressStreams optimizer.
+const defaultCompressMinSize = 64
+
 // CompressStreams compresses uncompressed streams.
 // It implements interface model.Optimizer.
+// Each stream is compressed using Flate, Flate with a PNG predictor (for
+// image streams) and LZW, and the smallest result is kept. Streams are left
+// uncompressed if none of the encoders reduces their size.
+// Optionally, streams already encoded with a single Flate filter are
+// recompressed at the highest compression level.
+// The streams are compressed in parallel, as each stream is processed
+// independently. The result does not depend on the number of workers.
 type CompressStreams struct {
+	// CompressionLevel is the zlib compression level used for compressing
+	// the streams. A value of 0 selects the default compression level.
+	CompressionLevel int
+
+	// ImagePredictor is the PNG predictor (10-15) applied to uncompressed
+	// image streams before Flate compression. The predictor parameters are
+	// taken from the image dictionary. Defaults to the optimum predictor
+	// (15). A negative value disables prediction, so that image streams are
+	// compressed using the other encoders only.
+	ImagePredictor int
+
+	// RecompressFlate enables decoding streams encoded with a single Flate
+	// filter and re-encoding them using the best compression level. The
+	// recompressed data is kept only if it is smaller than the original.
+	// Streams with multiple filters or other filters are left untouched.
+	RecompressFlate bool
+
+	// Concurrency is the number of workers compressing streams in parallel.
+	// If not set, GOMAXPROCS workers are used. A value of 1 compresses the
+	// streams sequentially.
+	Concurrency int
+
+	// MinSize is the minimum size, in bytes, of the compressed streams.
+	// Smaller streams, for which the compression overhead outweighs the
+	// gains, are left as-is without attempting to compress them. Defaults
+	// to 64. A negative value disables the threshold.
+	MinSize int
+
+	// CompressWithDecodeParms enables compressing unencoded streams which
+	// specify decode parameters (DecodeParms). As the parameters do not
+	// correspond to any filter, they cannot be reproduced by the encoders
+	// and they are removed from the compressed streams. By default, such
+	// streams are left unchanged.
+	CompressWithDecodeParms bool
 }
 
 // Optimize optimizes PDF objects to decrease PDF size.
 func (c *CompressStreams) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfObject, err error) {
 	optimizedObjects = make([]core.PdfObject, len(objects))
 	copy(optimizedObjects, objects)
+
+	minSize := c.MinSize
+	if minSize == 0 {
+		minSize = defaultCompressMinSize
+	}
+
+	// Collect the streams, each of them once, so that no stream is modified
+	// by multiple workers.
+	var streams []*core.PdfObjectStream
+	seen := map[*core.PdfObjectStream]struct{}{}
 	for _, obj := range objects {
 		stream, isStreamObj := core.GetStream(obj)
-		if !isStreamObj {
+		if !isStreamObj || len(stream.Stream) < minSize {
 			continue
 		}
-		// Skip objects that are already encoded.
-		// TODO: Try filter combinations, and ignoring inefficient filters.
-		if obj := stream.Get("Filter"); obj != nil {
-			if _, skip := core.GetName(obj); skip {
-				continue
-			}
-			if arr, ok := core.GetArray(obj); ok && arr.Len() > 0 {
-				continue
+		if _, ok := seen[stream]; ok {
+			continue
+		}
+		seen[stream] = struct{}{}
+		streams = append(streams, stream)
+	}
+
+	workers := c.Concurrency
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(streams) {
+		workers = len(streams)
+	}
+	if workers <= 1 {
+		for _, stream := range streams {
+			c.compressStream(stream)
+		}
+		return optimizedObjects, nil
+	}
+
+	queue := make(chan *core.PdfObjectStream)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for stream := range queue {
+				c.compressStream(stream)
 			}
+		}()
+	}
+	for _, stream := range streams {
+		queue <- stream
+	}
+	close(queue)
+	wg.Wait()
+
+	return optimizedObjects, nil
+}
+
+// compressStream compresses `stream` in place, if it is not encoded, using
+// the encoder producing the smallest result. Flate encoded streams are
+// recompressed if the RecompressFlate option is set.
+func (c *CompressStreams) compressStream(stream *core.PdfObjectStream) {
+	// Normalize empty filters and decode parameters (e.g. empty arrays),
+	// which are equivalent to no filter.
+	for _, key := range []core.PdfObjectName{"Filter", "DecodeParms"} {
+		if obj := stream.Get(key); obj != nil && isEmptyObject(obj) {
+			stream.Remove(key)
 		}
+	}
 
-		encoder := core.NewFlateEncoder() // Most mainstream compressor and probably most robust.
-		var data []byte
-		data, err = encoder.EncodeBytes(stream.Stream)
+	// Skip objects that are already encoded.
+	// TODO: Try filter combinations, and ignoring inefficient filters.
+	if obj := stream.Get("Filter"); obj != nil {
+		if c.RecompressFlate && isSingleFlate(obj) {
+			recompressFlate(stream)
+			return
+		}
+		if _, skip := core.GetName(obj); skip {
+			return
+		}
+		if arr, ok := core.GetArray(obj); ok && arr.Len() > 0 {
+			return
+		}
+	}
+	if stream.Get("DecodeParms") != nil && !c.CompressWithDecodeParms {
+		return
+	}
+
+	// Keep the smallest encoded data, if smaller than the original.
+	var bestData []byte
+	var bestDict *core.PdfObjectDictionary
+	bestSize := len(stream.Stream)
+	for _, encoder := range c.encoders(stream) {
+		data, err := encoder.EncodeBytes(stream.Stream)
 		if err != nil {
-			return optimizedObjects, err
+			common.Log.Debug("ERROR: unable to encode stream using %s: %v", encoder.GetFilterName(), err)
+			continue
 		}
 		dict := encoder.MakeStreamDict()
 		// compare compressed and uncompressed sizes
-		if len(data)+len(dict.WriteString()) < len(stream.Stream) {
-			stream.Stream = data
-			stream.PdfObjectDictionary.Merge(dict)
-			stream.PdfObjectDictionary.Set("Length", core.MakeInteger(int64(len(stream.Stream))))
+		if size := len(data) + len(dict.WriteString()); size < bestSize {
+			bestData, bestDict, bestSize = data, dict, size
 		}
 	}
-	return optimizedObjects, nil
+	if bestDict != nil {
+		stream.Stream = bestData
+		stream.PdfObjectDictionary.Remove("DecodeParms")
+		stream.PdfObjectDictionary.Merge(bestDict)
+		stream.PdfObjectDictionary.Set("Length", core.MakeInteger(int64(len(stream.Stream))))
+	}
+}
+
+// isEmptyObject returns true if `obj` is a null object, an empty array or an
+// empty dictionary.
+func isEmptyObject(obj core.PdfObject) bool {
+	if core.IsNullObject(obj) {
+		return true
+	}
+	if arr, ok := core.GetArray(obj); ok {
+		return arr.Len() == 0
+	}
+	if dict, ok := core.GetDict(obj); ok {
+		return len(dict.Keys()) == 0
+	}
+	return false
+}
+
+// isSingleFlate returns true if the stream filter `obj` consists of a single
+// Flate filter.
+func isSingleFlate(obj core.PdfObject) bool {
+	if arr, ok := core.GetArray(obj); ok {
+		if arr.Len() != 1 {
+			return false
+		}
+		obj = arr.Get(0)
+	}
+	name, ok := core.GetNameVal(obj)
+	return ok && name == core.StreamEncodingFilterNameFlate
+}
+
+// recompressFlate re-encodes the Flate encoded `stream` using the best
+// compression level, keeping the decode parameters of the stream. The stream
+// is left unchanged if recompressing fails or does not reduce its size.
+func recompressFlate(stream *core.PdfObjectStream) {
+	enc, err := core.NewEncoderFromStream(stream)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to create stream encoder: %v", err)
+		return
+	}
+	flateEnc, ok := enc.(*core.FlateEncoder)
+	if !ok {
+		return
+	}
+	decoded, err := flateEnc.DecodeStream(stream)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to decode Flate stream: %v", err)
+		return
+	}
+
+	flateEnc.CompressionLevel = flate.BestCompression
+	data, err := flateEnc.EncodeBytes(decoded)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to recompress Flate stream: %v", err)
+		return
+	}
+	if len(data) >= len(stream.Stream) {
+		return
+	}
+	stream.Stream = data
+	stream.PdfObjectDictionary.Set("Length", core.MakeInteger(int64(len(data))))
+}
+
+// encoders returns the encoders tried for compressing `stream`.
+func (c *CompressStreams) encoders(stream *core.PdfObjectStream) []core.StreamEncoder {
+	// Most mainstream compressor and probably most robust.
+	encoders := []core.StreamEncoder{
+		core.NewFlateEncoderWithOptions(&core.FlateEncoderOptions{CompressionLevel: c.CompressionLevel}),
+	}
+	if opts := c.predictorOptions(stream); opts != nil {
+		encoders = append(encoders, core.NewFlateEncoderWithOptions(opts))
+	}
+
+	lzw := core.NewLZWEncoder()
+	lzw.EarlyChange = 0
+	return append(encoders, lzw)
+}
+
+// predictorOptions returns the flate encoder options used for compressing
+// `stream` with a PNG predictor. The image predictor is used only for image
+// streams with 8 bits per component and a device color space. Returns nil
+// for other streams, or if prediction is disabled.
+func (c *CompressStreams) predictorOptions(stream *core.PdfObjectStream) *core.FlateEncoderOptions {
+	predictor := c.ImagePredictor
+	switch {
+	case predictor < 0:
+		return nil
+	case predictor == 0:
+		predictor = 15
+	case predictor < 10 || predictor > 15:
+		common.Log.Debug("WARN: invalid image predictor %d - prediction disabled", predictor)
+		return nil
+	}
+	if subtype, _ := core.GetNameVal(stream.Get("Subtype")); subtype != "Image" {
+		return nil
+	}
+
+	width, ok := core.GetIntVal(stream.Get("Width"))
+	if !ok || width <= 0 {
+		return nil
+	}
+	bpc, ok := core.GetIntVal(stream.Get("BitsPerComponent"))
+	if !ok || bpc != 8 {
+		return nil
+	}
+
+	var colors int
+	switch cs, _ := core.GetNameVal(stream.Get("ColorSpace")); cs {
+	case "DeviceGray":
+		colors = 1
+	case "DeviceRGB":
+		colors = 3
+	case "DeviceCMYK":
+		colors = 4
+	default:
+		return nil
+	}
+
+	return &core.FlateEncoderOptions{
+		CompressionLevel: c.CompressionLevel,
+		Predictor:        predictor,
+		Columns:          width,
+		Colors:           colors,
+		BitsPerComponent: bpc,
+	}
 }
@@ -0,0 +1,251 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package optimize
+
+import (
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// HoistResources reduces the size of the resource dictionaries of documents.
+// It merges the identical graphics state parameter dictionaries (ExtGState)
+// of the page and form resources into single shared objects. If all the pages
+// of the document have identical resources, the resources are moved to the
+// root page tree node, from which they are inherited by the pages, and removed
+// from the pages.
+// The resource reduction achieved by the last run of the optimizer is
+// reported in the statistics fields.
+// It implements interface model.Optimizer.
+type HoistResources struct {
+	// MergedExtGStates is the number of duplicate ExtGState dictionaries
+	// which were replaced by a shared identical dictionary.
+	MergedExtGStates int
+
+	// HoistedPages is the number of pages whose resources were removed, as
+	// they are inherited from the root page tree node.
+	HoistedPages int
+
+	// ResourceSizeBefore and ResourceSizeAfter are the serialized sizes, in
+	// bytes, of the resource dictionaries of all the pages, before and after
+	// the optimization. Inherited resources are counted once.
+	ResourceSizeBefore int
+	ResourceSizeAfter  int
+}
+
+// Optimize optimizes PDF objects to decrease PDF size.
+func (h *HoistResources) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfObject, err error) {
+	*h = HoistResources{}
+	updateObjectNumbers(objects)
+	objstr := getObjectStructure(objects)
+
+	h.ResourceSizeBefore = pageResourcesSize(objstr)
+
+	optimizedObjects = make([]core.PdfObject, len(objects))
+	copy(optimizedObjects, objects)
+	indirects := h.mergeExtGStates(objects)
+	if len(indirects) > 0 {
+		optimizedObjects = append(indirects, optimizedObjects...)
+		updateObjectNumbers(optimizedObjects)
+	}
+
+	h.hoistPageResources(objstr)
+	h.ResourceSizeAfter = pageResourcesSize(objstr)
+	return optimizedObjects, nil
+}
+
+// mergeExtGStates replaces the identical ExtGState dictionaries of the page
+// and form resources with a single shared indirect object. The new indirect
+// objects which need to be added to the document are returned.
+func (h *HoistResources) mergeExtGStates(objects []core.PdfObject) []core.PdfObject {
+	type entry struct {
+		extGStates *core.PdfObjectDictionary
+		key        core.PdfObjectName
+		obj        core.PdfObject
+	}
+
+	// Group the ExtGState entries by the content of their dictionaries.
+	var hashes []string
+	entriesByHash := map[string][]entry{}
+	for _, resources := range getResourceDicts(objects) {
+		extGStates, ok := core.GetDict(resources.Get("ExtGState"))
+		if !ok {
+			continue
+		}
+		for _, key := range extGStates.Keys() {
+			obj := extGStates.Get(key)
+			dict, ok := core.GetDict(obj)
+			if !ok {
+				continue
+			}
+			hash := dict.WriteString()
+			if _, ok := entriesByHash[hash]; !ok {
+				hashes = append(hashes, hash)
+			}
+			entriesByHash[hash] = append(entriesByHash[hash], entry{extGStates, key, obj})
+		}
+	}
+
+	var indirects []core.PdfObject
+	for _, hash := range hashes {
+		entries := entriesByHash[hash]
+		distinct := map[core.PdfObject]struct{}{}
+		var shared core.PdfObject
+		for _, e := range entries {
+			distinct[e.obj] = struct{}{}
+			if _, ok := e.obj.(*core.PdfIndirectObject); ok && shared == nil {
+				shared = e.obj
+			}
+		}
+		if len(distinct) < 2 {
+			continue
+		}
+
+		// Use the first indirect object as the shared object, or create one
+		// if all the dictionaries are direct objects.
+		if shared == nil {
+			dict, _ := core.GetDict(entries[0].obj)
+			shared = core.MakeIndirectObject(dict)
+			indirects = append(indirects, shared)
+		}
+		for _, e := range entries {
+			e.extGStates.Set(e.key, shared)
+		}
+		h.MergedExtGStates += len(distinct) - 1
+	}
+	return indirects
+}
+
+// hoistPageResources moves the resources of the pages to the root page tree
+// node, if all the pages have identical resources.
+func (h *HoistResources) hoistPageResources(objstr objectStructure) {
+	if objstr.pagesDict == nil || len(objstr.pages) < 2 {
+		return
+	}
+
+	var resources core.PdfObject
+	var hash string
+	for i, page := range objstr.pages {
+		dict, ok := core.GetDict(page)
+		if !ok {
+			return
+		}
+		// The resources can only be hoisted if all the pages are direct
+		// children of the root node.
+		if kind, _ := core.GetNameVal(dict.Get("Type")); kind != "Page" {
+			return
+		}
+		resDict, ok := core.GetDict(dict.Get("Resources"))
+		if !ok {
+			return
+		}
+		if i == 0 {
+			resources, hash = dict.Get("Resources"), resDict.WriteString()
+			continue
+		}
+		if resDict.WriteString() != hash {
+			return
+		}
+	}
+
+	// Do not override different inherited resources.
+	if parentRes, ok := core.GetDict(objstr.pagesDict.Get("Resources")); ok && parentRes.WriteString() != hash {
+		return
+	}
+
+	objstr.pagesDict.Set("Resources", resources)
+	for _, page := range objstr.pages {
+		dict, _ := core.GetDict(page)
+		dict.Remove("Resources")
+	}
+	h.HoistedPages = len(objstr.pages)
+}
+
+// getResourceDicts returns the resource dictionaries of the pages and the
+// form XObjects.
+func getResourceDicts(objects []core.PdfObject) []*core.PdfObjectDictionary {
+	var resDicts []*core.PdfObjectDictionary
+	for _, obj := range objects {
+		var dict *core.PdfObjectDictionary
+		switch t := obj.(type) {
+		case *core.PdfIndirectObject:
+			d, ok := core.GetDict(t)
+			if !ok {
+				continue
+			}
+			if kind, _ := core.GetNameVal(d.Get("Type")); kind != "Page" {
+				continue
+			}
+			dict = d
+		case *core.PdfObjectStream:
+			if subtype, _ := core.GetNameVal(t.Get("Subtype")); subtype != "Form" {
+				continue
+			}
+			dict = t.PdfObjectDictionary
+		default:
+			continue
+		}
+
+		if resDict, ok := core.GetDict(dict.Get("Resources")); ok {
+			resDicts = append(resDicts, resDict)
+		}
+	}
+	return resDicts
+}
+
+// pageResourcesSize returns the serialized size of the resource dictionaries
+// of the pages and of the root page tree node, including the indirect
+// resource category dictionaries and ExtGState dictionaries. Shared objects
+// are counted once.
+func pageResourcesSize(objstr objectStructure) int {
+	var size int
+	counted := map[core.PdfObject]struct{}{}
+
+	// addSize adds the size of `obj`, if it is an indirect object. The size
+	// of direct objects is included in the size of their container.
+	addSize := func(obj core.PdfObject) {
+		ind, ok := obj.(*core.PdfIndirectObject)
+		if !ok {
+			return
+		}
+		if _, ok := counted[ind]; ok {
+			return
+		}
+		counted[ind] = struct{}{}
+		size += len(ind.PdfObject.WriteString())
+	}
+
+	nodes := make([]core.PdfObject, 0, len(objstr.pages)+1)
+	if objstr.pagesDict != nil {
+		nodes = append(nodes, objstr.pagesDict)
+	}
+	for _, page := range objstr.pages {
+		nodes = append(nodes, page)
+	}
+	for _, node := range nodes {
+		dict, ok := core.GetDict(node)
+		if !ok {
+			continue
+		}
+		resDict, ok := core.GetDict(dict.Get("Resources"))
+		if !ok {
+			continue
+		}
+		if _, ok := dict.Get("Resources").(*core.PdfIndirectObject); ok {
+			addSize(dict.Get("Resources"))
+		} else {
+			size += len(resDict.WriteString())
+		}
+
+		for _, key := range resDict.Keys() {
+			addSize(resDict.Get(key))
+		}
+		if extGStates, ok := core.GetDict(resDict.Get("ExtGState")); ok {
+			for _, name := range extGStates.Keys() {
+				addSize(extGStates.Get(name))
+			}
+		}
+	}
+	return size
+}
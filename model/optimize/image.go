@@ -89,11 +89,7 @@ func (i *Image) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfO
 	}
 
 	replaceTable := make(map[core.PdfObject]core.PdfObject)
-	imageMasks := make(map[core.PdfObject]struct{})
-	for _, img := range images {
-		obj := img.Stream.PdfObjectDictionary.Get(core.PdfObjectName("SMask"))
-		imageMasks[obj] = struct{}{}
-	}
+	imageMasks := findImageMasks(images)
 
 	for index, img := range images {
 		stream := img.Stream
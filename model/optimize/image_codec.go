@@ -0,0 +1,397 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package optimize
+
+import (
+	"math"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// ImageCodec represents the encoding selected for an image by the
+// AutoImageCodec optimizer.
+type ImageCodec int
+
+const (
+	// ImageCodecKeep leaves the image unchanged.
+	ImageCodecKeep ImageCodec = iota
+
+	// ImageCodecJBIG2 converts the image to a bilevel image encoded with
+	// the JBIG2Decode filter.
+	ImageCodecJBIG2
+
+	// ImageCodecCCITT converts the image to a bilevel image encoded with
+	// the CCITTFaxDecode filter (Group 4).
+	ImageCodecCCITT
+
+	// ImageCodecDCT encodes the image with the DCTDecode filter (JPEG).
+	ImageCodecDCT
+
+	// ImageCodecIndexedFlate converts the image to an image with an Indexed
+	// color space, encoded with the FlateDecode filter.
+	ImageCodecIndexedFlate
+
+	// ImageCodecFlate encodes the image with the FlateDecode filter.
+	ImageCodecFlate
+)
+
+// ImageStats contains the statistics of an image, used for selecting the
+// codec of the image.
+type ImageStats struct {
+	Width            int
+	Height           int
+	ColorComponents  int
+	BitsPerComponent int
+
+	// Filter is the name of the current filter of the image, or an empty
+	// string if the image is not encoded or uses multiple filters.
+	Filter string
+
+	// NumColors is the number of distinct colors of the image, up to 257.
+	// A value of 257 indicates that the image has more than 256 colors.
+	NumColors int
+
+	// BilevelRatio is the fraction of pixels which are close to black or
+	// white.
+	BilevelRatio float64
+
+	// Entropy is the entropy of the luminance of the pixels, in bits.
+	Entropy float64
+}
+
+// maxIndexedColors is the maximum number of colors of Indexed images.
+const maxIndexedColors = 256
+
+// SuggestImageCodec returns the codec suggested for an image with the
+// specified statistics:
+//   - bilevel or near-bilevel images are encoded as JBIG2, or as CCITT Group 4
+//     if `preferCCITT` is true.
+//   - images with few colors are converted to Indexed images, encoded with
+//     Flate.
+//   - photographic images, with a high entropy, are encoded as DCT.
+//   - the other images are encoded with Flate.
+func SuggestImageCodec(stats ImageStats, preferCCITT bool) ImageCodec {
+	switch {
+	case stats.BilevelRatio >= 0.995:
+		if preferCCITT {
+			return ImageCodecCCITT
+		}
+		return ImageCodecJBIG2
+	case stats.NumColors <= maxIndexedColors:
+		return ImageCodecIndexedFlate
+	case stats.Entropy >= 5:
+		if stats.Filter == core.StreamEncodingFilterNameDCT {
+			// Avoid re-encoding JPEG images as it reduces their quality.
+			return ImageCodecKeep
+		}
+		return ImageCodecDCT
+	}
+	return ImageCodecFlate
+}
+
+// AutoImageCodec re-encodes the images of the document, using the codec
+// which is best suited for the content of each image, as selected by
+// SuggestImageCodec. The images are only replaced if the size of the
+// re-encoded image is smaller.
+// Only images with the DeviceGray and DeviceRGB color spaces and 8 bits per
+// component are processed.
+// It implements interface model.Optimizer.
+type AutoImageCodec struct {
+	// ImageQuality is the quality of the DCT encoded images, between 1 and
+	// 100. Defaults to 75.
+	ImageQuality int
+
+	// PreferCCITT selects CCITT Group 4 instead of JBIG2 for bilevel images.
+	PreferCCITT bool
+
+	// SelectCodec, if set, is called for each image with its statistics and
+	// the codec suggested by SuggestImageCodec. It returns the codec which is
+	// used for the image, allowing to override the suggestion.
+	SelectCodec func(stats ImageStats, suggested ImageCodec) ImageCodec
+}
+
+// Optimize optimizes PDF objects to decrease PDF size.
+func (a *AutoImageCodec) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfObject, err error) {
+	images := findImages(objects)
+	if len(images) == 0 {
+		return objects, nil
+	}
+
+	imageMasks := findImageMasks(images)
+
+	replaceTable := make(map[core.PdfObject]core.PdfObject)
+	for _, img := range images {
+		stream := img.Stream
+		if _, isMask := imageMasks[stream]; isMask {
+			continue
+		}
+		if img.BitsPerComponent != 8 || stream.Get("Decode") != nil {
+			continue
+		}
+		if isMask, _ := core.GetBoolVal(stream.Get("ImageMask")); isMask {
+			continue
+		}
+
+		data, err := core.DecodeStream(stream)
+		if err != nil {
+			common.Log.Debug("ERROR: unable to decode image: %v", err)
+			continue
+		}
+		if len(data) < img.Width*img.Height*img.ColorComponents || img.Width <= 0 || img.Height <= 0 {
+			common.Log.Debug("ERROR: invalid image data size")
+			continue
+		}
+
+		stats := computeImageStats(img, data)
+		codec := SuggestImageCodec(stats, a.PreferCCITT)
+		if a.SelectCodec != nil {
+			codec = a.SelectCodec(stats, codec)
+		}
+
+		newStream, err := a.encodeImage(img, data, codec)
+		if err != nil {
+			common.Log.Debug("ERROR: unable to encode image: %v", err)
+			continue
+		}
+		if newStream == nil || len(newStream.Stream) >= len(stream.Stream) {
+			continue
+		}
+		replaceTable[stream] = newStream
+	}
+
+	optimizedObjects = make([]core.PdfObject, len(objects))
+	copy(optimizedObjects, objects)
+	replaceObjectsInPlace(optimizedObjects, replaceTable)
+	return optimizedObjects, nil
+}
+
+// computeImageStats returns the statistics of the image with the specified
+// decoded 8 bit `data`.
+func computeImageStats(img *imageInfo, data []byte) ImageStats {
+	stats := ImageStats{
+		Width:            img.Width,
+		Height:           img.Height,
+		ColorComponents:  img.ColorComponents,
+		BitsPerComponent: img.BitsPerComponent,
+	}
+	if name, ok := core.GetNameVal(img.Stream.Get("Filter")); ok {
+		stats.Filter = name
+	}
+
+	var histogram [256]int
+	colors := map[uint32]struct{}{}
+	var bilevel int
+	numPixels := img.Width * img.Height
+	for i := 0; i < numPixels; i++ {
+		pixel := data[i*img.ColorComponents : (i+1)*img.ColorComponents]
+		lum, isBilevel := pixelLuminance(pixel)
+		histogram[lum]++
+		if isBilevel {
+			bilevel++
+		}
+		if len(colors) <= maxIndexedColors {
+			colors[packColor(pixel)] = struct{}{}
+		}
+	}
+
+	stats.NumColors = len(colors)
+	stats.BilevelRatio = float64(bilevel) / float64(numPixels)
+	for _, count := range histogram {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / float64(numPixels)
+		stats.Entropy -= p * math.Log2(p)
+	}
+	return stats
+}
+
+// pixelLuminance returns the luminance of the pixel and whether the pixel is
+// close to black or white.
+func pixelLuminance(pixel []byte) (uint8, bool) {
+	const tolerance = 32
+	if len(pixel) == 1 {
+		return pixel[0], pixel[0] < tolerance || pixel[0] > 255-tolerance
+	}
+
+	r, g, b := float64(pixel[0]), float64(pixel[1]), float64(pixel[2])
+	lum := uint8(math.Round(0.299*r + 0.587*g + 0.114*b))
+	min, max := math.Min(r, math.Min(g, b)), math.Max(r, math.Max(g, b))
+	isBilevel := max < tolerance || min > 255-tolerance
+	return lum, isBilevel
+}
+
+// packColor returns the components of the pixel packed in an integer.
+func packColor(pixel []byte) uint32 {
+	var c uint32
+	for _, v := range pixel {
+		c = c<<8 | uint32(v)
+	}
+	return c
+}
+
+// encodeImage returns a new image stream, containing the image data encoded
+// using `codec`, or nil if the image is to be kept unchanged.
+func (a *AutoImageCodec) encodeImage(img *imageInfo, data []byte, codec ImageCodec) (*core.PdfObjectStream, error) {
+	dict := core.MakeDict()
+	dict.Merge(img.Stream.PdfObjectDictionary)
+	dict.Remove("Filter")
+	dict.Remove("DecodeParms")
+
+	var encoded []byte
+	var encoder core.StreamEncoder
+	var err error
+	switch codec {
+	case ImageCodecJBIG2, ImageCodecCCITT:
+		bw := toBilevel(img, data)
+		dict.Set("ColorSpace", core.MakeName("DeviceGray"))
+		dict.Set("BitsPerComponent", core.MakeInteger(1))
+		if codec == ImageCodecJBIG2 {
+			encoded, encoder, err = encodeJBIG2(img, bw)
+		} else {
+			encoded, encoder, err = encodeCCITT(img, bw)
+		}
+	case ImageCodecDCT:
+		dctenc := core.NewDCTEncoder()
+		dctenc.ColorComponents = img.ColorComponents
+		dctenc.BitsPerComponent = img.BitsPerComponent
+		dctenc.Width = img.Width
+		dctenc.Height = img.Height
+		dctenc.Quality = a.ImageQuality
+		if dctenc.Quality <= 0 || dctenc.Quality > 100 {
+			dctenc.Quality = 75
+		}
+		encoder = dctenc
+		encoded, err = dctenc.EncodeBytes(data)
+	case ImageCodecIndexedFlate:
+		var indexed []byte
+		var colorspace core.PdfObject
+		var bpc int
+		indexed, colorspace, bpc, err = toIndexed(img, data)
+		if err != nil {
+			return nil, err
+		}
+		dict.Set("ColorSpace", colorspace)
+		dict.Set("BitsPerComponent", core.MakeInteger(int64(bpc)))
+		encoder = core.NewFlateEncoder()
+		encoded, err = encoder.EncodeBytes(indexed)
+	case ImageCodecFlate:
+		encoder = core.NewFlateEncoder()
+		encoded, err = encoder.EncodeBytes(data)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dict.Merge(encoder.MakeStreamDict())
+	dict.Set("Length", core.MakeInteger(int64(len(encoded))))
+	return &core.PdfObjectStream{
+		PdfObjectReference:  img.Stream.PdfObjectReference,
+		PdfObjectDictionary: dict,
+		Stream:              encoded,
+	}, nil
+}
+
+// toBilevel returns the pixels of the image converted to black (0) or white
+// (255), using one byte per pixel.
+func toBilevel(img *imageInfo, data []byte) []byte {
+	numPixels := img.Width * img.Height
+	bw := make([]byte, numPixels)
+	for i := range bw {
+		lum, _ := pixelLuminance(data[i*img.ColorComponents : (i+1)*img.ColorComponents])
+		if lum >= 128 {
+			bw[i] = 255
+		}
+	}
+	return bw
+}
+
+// encodeJBIG2 encodes the bilevel pixels `bw` using the JBIG2 generic region
+// encoding.
+func encodeJBIG2(img *imageInfo, bw []byte) ([]byte, core.StreamEncoder, error) {
+	// The JBIG2 encoder expects 1 bits for black pixels, without row padding.
+	packed := make([]byte, (len(bw)+7)/8)
+	for i, v := range bw {
+		if v == 0 {
+			packed[i/8] |= 0x80 >> uint(i%8)
+		}
+	}
+
+	enc := core.NewJBIG2Encoder()
+	enc.ColorComponents = 1
+	enc.BitsPerComponent = 1
+	enc.Width = img.Width
+	enc.Height = img.Height
+	encoded, err := enc.EncodeBytes(packed)
+	return encoded, enc, err
+}
+
+// encodeCCITT encodes the bilevel pixels `bw` using CCITT Group 4 encoding.
+func encodeCCITT(img *imageInfo, bw []byte) ([]byte, core.StreamEncoder, error) {
+	enc := core.NewCCITTFaxEncoder()
+	enc.K = -1
+	enc.Columns = img.Width
+	enc.Rows = img.Height
+	encoded, err := enc.EncodeBytes(bw)
+	return encoded, enc, err
+}
+
+// toIndexed converts the image to an image with an Indexed color space. It
+// returns the packed color indices, the color space and the number of bits
+// per index.
+func toIndexed(img *imageInfo, data []byte) ([]byte, core.PdfObject, int, error) {
+	numPixels := img.Width * img.Height
+	indices := map[uint32]int{}
+	var lookup []byte
+	pixelIndices := make([]int, numPixels)
+	for i := 0; i < numPixels; i++ {
+		pixel := data[i*img.ColorComponents : (i+1)*img.ColorComponents]
+		c := packColor(pixel)
+		idx, ok := indices[c]
+		if !ok {
+			if len(indices) == maxIndexedColors {
+				return nil, nil, 0, core.ErrRangeError
+			}
+			idx = len(indices)
+			indices[c] = idx
+			lookup = append(lookup, pixel...)
+		}
+		pixelIndices[i] = idx
+	}
+
+	bpc := 8
+	switch {
+	case len(indices) <= 2:
+		bpc = 1
+	case len(indices) <= 4:
+		bpc = 2
+	case len(indices) <= 16:
+		bpc = 4
+	}
+
+	// Pack the indices, padding the rows to whole bytes.
+	rowSize := (img.Width*bpc + 7) / 8
+	packed := make([]byte, rowSize*img.Height)
+	for y := 0; y < img.Height; y++ {
+		for x := 0; x < img.Width; x++ {
+			idx := pixelIndices[y*img.Width+x]
+			bit := x * bpc
+			shift := uint(8 - bpc - bit%8)
+			packed[y*rowSize+bit/8] |= byte(idx << shift)
+		}
+	}
+
+	colorspace := core.MakeArray(
+		core.MakeName("Indexed"),
+		core.MakeName(string(img.ColorSpace)),
+		core.MakeInteger(int64(len(indices)-1)),
+		core.MakeStringFromBytes(lookup),
+	)
+	return packed, colorspace, bpc, nil
+}
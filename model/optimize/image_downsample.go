@@ -0,0 +1,266 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package optimize
+
+import (
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/contentstream"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/transform"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
+	"golang.org/x/image/draw"
+)
+
+// defaultDownsampleMinSize is the default minimum size, in pixels, of the
+// images downsampled by the DownsampleImages optimizer.
+const defaultDownsampleMinSize = 16
+
+// DownsampleImages reduces the size of documents by downsampling the images
+// whose effective resolution exceeds TargetDPI. The effective resolution of
+// an image is computed from its dimensions, in pixels, and the size at which
+// it is drawn on the pages, based on the current transformation matrix (CTM)
+// of each Do operator drawing it, including those of the form XObjects drawn
+// by the pages. Images drawn several times are downsampled based on their
+// highest effective resolution, and images which are not drawn are left
+// unchanged.
+// The images are resampled using bilinear interpolation. DCT encoded images
+// are re-encoded with the DCT filter and all other images with the Flate
+// filter. Images are never upsampled. Soft masks (SMask) are not downsampled
+// on their own, but along with their images.
+// Only images with the DeviceGray and DeviceRGB color spaces are processed.
+// It implements interface model.Optimizer.
+type DownsampleImages struct {
+	// TargetDPI is the maximum effective resolution of the images, in dots
+	// per inch.
+	TargetDPI float64
+
+	// MinSize is the minimum width and height, in pixels, of the downsampled
+	// images. Smaller images are left unchanged. Defaults to 16.
+	MinSize int
+
+	// Downsampled is the number of images downsampled by the last run of
+	// the optimizer.
+	Downsampled int
+}
+
+// Optimize optimizes PDF objects to decrease PDF size.
+func (d *DownsampleImages) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfObject, err error) {
+	d.Downsampled = 0
+	if d.TargetDPI <= 0 {
+		return objects, nil
+	}
+	images := findImages(objects)
+	if len(images) == 0 {
+		return objects, nil
+	}
+	minSize := d.MinSize
+	if minSize <= 0 {
+		minSize = defaultDownsampleMinSize
+	}
+
+	resolutions := findImageResolutions(objects)
+	imageMasks := findImageMasks(images)
+	for _, img := range images {
+		if _, isMask := imageMasks[img.Stream]; isMask {
+			continue
+		}
+		if img.Width < minSize || img.Height < minSize {
+			continue
+		}
+		dpi := resolutions[img.Stream]
+		if dpi <= d.TargetDPI {
+			continue
+		}
+
+		scale := d.TargetDPI / dpi
+		newW := maxInt(scaledSize(img.Width, scale), 1)
+		newH := maxInt(scaledSize(img.Height, scale), 1)
+		if newW >= img.Width && newH >= img.Height {
+			continue
+		}
+		if err := resampleImageWithMask(img, newW, newH, draw.BiLinear, downsampleFilter); err != nil {
+			common.Log.Debug("Error downsample image keep original image: %v", err)
+			continue
+		}
+		d.Downsampled++
+	}
+	return objects, nil
+}
+
+// downsampleFilter returns the encoder of the downsampled image `xImg`.
+// DCT encoded images, which are usually photographic, remain DCT encoded.
+// All other images are encoded with the lossless Flate filter.
+func downsampleFilter(xImg *model.XObjectImage) core.StreamEncoder {
+	if _, isDCT := xImg.Filter.(*core.DCTEncoder); isDCT {
+		return core.NewDCTEncoder()
+	}
+	return core.NewFlateEncoder()
+}
+
+// findImageResolutions returns the highest effective resolution, in dots per
+// inch, at which each image XObject is drawn by the pages of the document
+// `objects`. The pages are found by traversing the page tree of the catalog.
+func findImageResolutions(objects []core.PdfObject) map[*core.PdfObjectStream]float64 {
+	resolutions := make(map[*core.PdfObjectStream]float64)
+
+	var catalog *core.PdfObjectDictionary
+	for _, obj := range objects {
+		if dict, ok := core.GetDict(obj); ok {
+			if kind, _ := core.GetNameVal(dict.Get("Type")); kind == "Catalog" {
+				catalog = dict
+				break
+			}
+		}
+	}
+	if catalog == nil {
+		return resolutions
+	}
+	pages, ok := core.GetDict(catalog.Get("Pages"))
+	if !ok {
+		return resolutions
+	}
+
+	visited := make(map[*core.PdfObjectDictionary]struct{})
+	var traverse func(node, resources *core.PdfObjectDictionary)
+	traverse = func(node, resources *core.PdfObjectDictionary) {
+		if _, ok := visited[node]; ok {
+			return
+		}
+		visited[node] = struct{}{}
+
+		// Resources are inherited from the ancestors of the pages.
+		if res, ok := core.GetDict(node.Get("Resources")); ok {
+			resources = res
+		}
+		if kids, ok := core.GetArray(node.Get("Kids")); ok {
+			for _, kid := range kids.Elements() {
+				if kidDict, ok := core.GetDict(kid); ok {
+					traverse(kidDict, resources)
+				}
+			}
+			return
+		}
+
+		contents, _ := getPageContents(node.Get("Contents"))
+		s := imageResolutionScanner{
+			resolutions: resolutions,
+			forms:       make(map[*core.PdfObjectStream]struct{}),
+		}
+		s.scan(contents, resources, transform.IdentityMatrix())
+	}
+	traverse(pages, nil)
+	return resolutions
+}
+
+// imageResolutionScanner records the effective resolutions of the images
+// drawn by content streams.
+type imageResolutionScanner struct {
+	resolutions map[*core.PdfObjectStream]float64
+
+	// forms contains the form XObjects being scanned, which are skipped if
+	// they draw themselves recursively.
+	forms map[*core.PdfObjectStream]struct{}
+}
+
+// scan records the effective resolutions of the images drawn by the content
+// stream `contents`, with the `resources` resource dictionary, starting with
+// the current transformation matrix `ctm`.
+func (s imageResolutionScanner) scan(contents string, resources *core.PdfObjectDictionary, ctm transform.Matrix) {
+	operations, err := contentstream.NewContentStreamParser(contents).Parse()
+	if err != nil {
+		common.Log.Debug("ERROR: unable to parse content stream: %v", err)
+		return
+	}
+	var xObjects *core.PdfObjectDictionary
+	if resources != nil {
+		xObjects, _ = core.GetDict(resources.Get("XObject"))
+	}
+
+	var stack []transform.Matrix
+	for _, op := range *operations {
+		switch op.Operand {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if len(stack) > 0 {
+				ctm = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+			}
+		case "cm":
+			vals, err := core.GetNumbersAsFloat(op.Params)
+			if err != nil || len(vals) != 6 {
+				continue
+			}
+			ctm.Concat(transform.NewMatrix(vals[0], vals[1], vals[2], vals[3], vals[4], vals[5]))
+		case "Do":
+			if xObjects == nil || len(op.Params) != 1 {
+				continue
+			}
+			name, ok := core.GetName(op.Params[0])
+			if !ok {
+				continue
+			}
+			stream, ok := core.GetStream(xObjects.Get(*name))
+			if !ok {
+				continue
+			}
+			switch subtype, _ := core.GetNameVal(stream.Get("Subtype")); subtype {
+			case "Image":
+				s.addImage(stream, ctm)
+			case "Form":
+				s.scanForm(stream, resources, ctm)
+			}
+		}
+	}
+}
+
+// addImage records the effective resolution of the image XObject `stream`
+// drawn with the current transformation matrix `ctm`, which maps the unit
+// square to the area of the page covered by the image.
+func (s imageResolutionScanner) addImage(stream *core.PdfObjectStream, ctm transform.Matrix) {
+	width, _ := core.GetIntVal(stream.Get("Width"))
+	height, _ := core.GetIntVal(stream.Get("Height"))
+	widthPts, heightPts := ctm.ScalingFactorX(), ctm.ScalingFactorY()
+	if width <= 0 || height <= 0 || widthPts == 0 || heightPts == 0 {
+		return
+	}
+
+	dpi := float64(width) * 72 / widthPts
+	if yDPI := float64(height) * 72 / heightPts; yDPI > dpi {
+		dpi = yDPI
+	}
+	if dpi > s.resolutions[stream] {
+		s.resolutions[stream] = dpi
+	}
+}
+
+// scanForm records the effective resolutions of the images drawn by the form
+// XObject `stream`, drawn with the current transformation matrix `ctm` by a
+// content stream with the `resources` resource dictionary, which are used by
+// the form if it has no resources of its own.
+func (s imageResolutionScanner) scanForm(stream *core.PdfObjectStream, resources *core.PdfObjectDictionary, ctm transform.Matrix) {
+	if _, ok := s.forms[stream]; ok {
+		return
+	}
+	data, err := core.DecodeStream(stream)
+	if err != nil {
+		common.Log.Debug("ERROR: unable to decode form XObject: %v", err)
+		return
+	}
+
+	if matrix, ok := core.GetArray(stream.Get("Matrix")); ok {
+		if vals, err := matrix.ToFloat64Array(); err == nil && len(vals) == 6 {
+			ctm.Concat(transform.NewMatrix(vals[0], vals[1], vals[2], vals[3], vals[4], vals[5]))
+		}
+	}
+	if res, ok := core.GetDict(stream.Get("Resources")); ok {
+		resources = res
+	}
+
+	s.forms[stream] = struct{}{}
+	s.scan(string(data), resources, ctm)
+	delete(s.forms, stream)
+}
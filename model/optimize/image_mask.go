@@ -0,0 +1,44 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package optimize
+
+import (
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// findImageMasks returns the soft mask (SMask) streams of `images`. The
+// masks are resolved, so that they are found whether the image dictionaries
+// reference them indirectly or contain them directly. The image optimizers
+// skip the masks, which are processed along with the images they belong to.
+func findImageMasks(images []*imageInfo) map[*core.PdfObjectStream]struct{} {
+	masks := make(map[*core.PdfObjectStream]struct{})
+	for _, img := range images {
+		if mask, ok := getImageMask(img.Stream); ok {
+			masks[mask] = struct{}{}
+		}
+	}
+	return masks
+}
+
+// getImageMask returns the soft mask (SMask) stream of the image `stream`.
+func getImageMask(stream *core.PdfObjectStream) (*core.PdfObjectStream, bool) {
+	return core.GetStream(stream.Get("SMask"))
+}
+
+// maskSize returns the dimensions a soft mask of `maskW`x`maskH` pixels must
+// be scaled to, along with an image of `imgW`x`imgH` pixels being scaled to
+// `newW`x`newH` pixels. A mask with the same dimensions as its image is
+// scaled to exactly the same dimensions, so that they stay aligned pixel by
+// pixel (which is required if the mask has a Matte entry). Other masks are
+// scaled proportionally to the image, to at least one pixel.
+func maskSize(maskW, maskH, imgW, imgH, newW, newH int) (int, int) {
+	if maskW == imgW && maskH == imgH {
+		return newW, newH
+	}
+	scaleX := float64(newW) / float64(imgW)
+	scaleY := float64(newH) / float64(imgH)
+	return maxInt(scaledSize(maskW, scaleX), 1), maxInt(scaledSize(maskH, scaleY), 1)
+}
@@ -24,23 +24,34 @@ type ImagePPI struct {
 	ImageUpperPPI float64
 }
 
-func scaleImage(stream *core.PdfObjectStream, scale float64) error {
+// resampledImage is an image XObject resampled to new dimensions, which is
+// not written to the image stream until it is applied.
+type resampledImage struct {
+	xImg *model.XObjectImage
+	img  *model.Image
+}
+
+// resampleImage resamples the image XObject `stream` to `width`x`height`
+// pixels using the `interp` interpolator. If `filter` is not nil, the image
+// is re-encoded with the encoder it returns for the image, otherwise with its
+// current filter. The stream is left unchanged until the returned image is
+// applied.
+func resampleImage(stream *core.PdfObjectStream, width, height int, interp draw.Interpolator,
+	filter func(*model.XObjectImage) core.StreamEncoder) (*resampledImage, error) {
 	xImg, err := model.NewXObjectImageFromStream(stream)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	i, err := xImg.ToImage()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	goimg, err := i.ToGoImage()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	newW := int(math.RoundToEven(float64(i.Width) * scale))
-	newH := int(math.RoundToEven(float64(i.Height) * scale))
-	rect := image.Rect(0, 0, newW, newH)
+	rect := image.Rect(0, 0, width, height)
 
 	var newImage draw.Image
 	var imageHandler func(image.Image) (*model.Image, error)
@@ -53,27 +64,81 @@ func scaleImage(stream *core.PdfObjectStream, scale float64) error {
 		newImage = image.NewGray(rect)
 		imageHandler = model.ImageHandling.NewGrayImageFromGoImage
 	default:
-		return fmt.Errorf("optimization is not supported for color space %s", xImg.ColorSpace.String())
+		return nil, fmt.Errorf("optimization is not supported for color space %s", xImg.ColorSpace.String())
 	}
 
-	draw.CatmullRom.Scale(newImage, newImage.Bounds(), goimg, goimg.Bounds(), draw.Over, &draw.Options{})
+	interp.Scale(newImage, newImage.Bounds(), goimg, goimg.Bounds(), draw.Over, &draw.Options{})
 	if i, err = imageHandler(newImage); err != nil {
-		return err
+		return nil, err
 	}
 
-	// Update quality and predictor parameters. All other image parameters would be updated
-	// in the SetImage method of the *XObjectImage.
-	encoderParams := core.MakeDict()
-	encoderParams.Set("Quality", core.MakeInteger(100))
-	encoderParams.Set("Predictor", core.MakeInteger(1))
+	if filter != nil {
+		xImg.Filter = filter(xImg)
+	} else {
+		// Update quality and predictor parameters. All other image parameters would be updated
+		// in the SetImage method of the *XObjectImage.
+		encoderParams := core.MakeDict()
+		encoderParams.Set("Quality", core.MakeInteger(100))
+		encoderParams.Set("Predictor", core.MakeInteger(1))
 
-	xImg.Filter.UpdateParams(encoderParams)
+		xImg.Filter.UpdateParams(encoderParams)
+	}
+	return &resampledImage{xImg: xImg, img: i}, nil
+}
 
+// apply writes the resampled image to its image stream.
+func (r *resampledImage) apply() error {
 	// Update image
-	if err = xImg.SetImage(i, nil); err != nil {
+	if err := r.xImg.SetImage(r.img, nil); err != nil {
 		return err
 	}
-	xImg.ToPdfObject()
+	r.xImg.ToPdfObject()
+	return nil
+}
+
+// scaledSize returns the image dimension `size` scaled by `scale`.
+func scaledSize(size int, scale float64) int {
+	return int(math.RoundToEven(float64(size) * scale))
+}
+
+// scaleImage scales the image `img` by `scale`, along with its soft mask
+// (SMask), if any.
+func scaleImage(img *imageInfo, scale float64) error {
+	newW, newH := scaledSize(img.Width, scale), scaledSize(img.Height, scale)
+	return resampleImageWithMask(img, newW, newH, draw.CatmullRom, nil)
+}
+
+// resampleImageWithMask resamples the image `img` to `newW`x`newH` pixels,
+// along with its soft mask (SMask), if any, as specified for resampleImage.
+// The image and its mask are processed together: both are resampled before
+// either of them is updated, so that an image is never left with a mask
+// whose dimensions do not match it anymore.
+func resampleImageWithMask(img *imageInfo, newW, newH int, interp draw.Interpolator,
+	filter func(*model.XObjectImage) core.StreamEncoder) error {
+	resampled, err := resampleImage(img.Stream, newW, newH, interp, filter)
+	if err != nil {
+		return err
+	}
+
+	var resampledMask *resampledImage
+	if mask, hasMask := getImageMask(img.Stream); hasMask {
+		maskW, _ := core.GetIntVal(mask.Get("Width"))
+		maskH, _ := core.GetIntVal(mask.Get("Height"))
+		if maskW <= 0 || maskH <= 0 || img.Width <= 0 || img.Height <= 0 {
+			return fmt.Errorf("invalid soft mask dimensions %dx%d", maskW, maskH)
+		}
+		maskW, maskH = maskSize(maskW, maskH, img.Width, img.Height, newW, newH)
+		if resampledMask, err = resampleImage(mask, maskW, maskH, interp, filter); err != nil {
+			return fmt.Errorf("soft mask: %v", err)
+		}
+	}
+
+	if err := resampled.apply(); err != nil {
+		return err
+	}
+	if resampledMask != nil {
+		return resampledMask.apply()
+	}
 	return nil
 }
 
@@ -86,11 +151,7 @@ func (i *ImagePPI) Optimize(objects []core.PdfObject) (optimizedObjects []core.P
 	if len(images) == 0 {
 		return objects, nil
 	}
-	imageMasks := make(map[core.PdfObject]struct{})
-	for _, img := range images {
-		obj := img.Stream.PdfObjectDictionary.Get(core.PdfObjectName("SMask"))
-		imageMasks[obj] = struct{}{}
-	}
+	imageMasks := findImageMasks(images)
 	imageByStream := make(map[*core.PdfObjectStream]*imageInfo)
 	for _, img := range images {
 		imageByStream[img.Stream] = img
@@ -205,14 +266,8 @@ func (i *ImagePPI) Optimize(objects []core.PdfObject) (optimizedObjects []core.P
 			continue
 		}
 		scale := i.ImageUpperPPI / img.PPI
-		if err := scaleImage(img.Stream, scale); err != nil {
+		if err := scaleImage(img, scale); err != nil {
 			common.Log.Debug("Error scale image keep original image: %s", err)
-		} else {
-			if mask, hasMask := core.GetStream(img.Stream.PdfObjectDictionary.Get(core.PdfObjectName("SMask"))); hasMask {
-				if err := scaleImage(mask, scale); err != nil {
-					return nil, err
-				}
-			}
 		}
 	}
 
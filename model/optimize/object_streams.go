@@ -11,30 +11,86 @@ import (
 
 // ObjectStreams groups PDF objects to object streams.
 // It implements interface model.Optimizer.
+// Only indirect objects with generation number 0 are packed into object
+// streams. The encryption dictionary and the objects referenced from the
+// trailer (see SetTrailerObjects) are kept outside of the object streams.
+// Documents containing object streams are written with a cross-reference
+// stream.
 type ObjectStreams struct {
+	// MaxObjectsPerStream is the maximum number of objects packed into a
+	// single object stream. A value of 0 packs all the objects into one
+	// object stream.
+	MaxObjectsPerStream int
+
+	trailerObjects map[core.PdfObject]struct{}
+}
+
+// SetTrailerObjects sets the objects referenced from the trailer dictionary,
+// which are not packed into object streams.
+// It implements interface model.TrailerObjectsSetter.
+func (o *ObjectStreams) SetTrailerObjects(objects []core.PdfObject) {
+	o.trailerObjects = make(map[core.PdfObject]struct{}, len(objects))
+	for _, obj := range objects {
+		o.trailerObjects[obj] = struct{}{}
+	}
 }
 
 // Optimize optimizes PDF objects to decrease PDF size.
 func (o *ObjectStreams) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfObject, err error) {
+	var objStreams []*core.PdfObjectStreams
 	objStream := &core.PdfObjectStreams{}
 	skippedObjects := make([]core.PdfObject, 0, len(objects))
 	for _, obj := range objects {
-		if io, isIndirectObj := obj.(*core.PdfIndirectObject); isIndirectObj && io.GenerationNumber == 0 {
-			objStream.Append(obj)
-		} else {
+		if !o.isPackable(obj) {
 			skippedObjects = append(skippedObjects, obj)
+			continue
+		}
+		if o.MaxObjectsPerStream > 0 && objStream.Len() >= o.MaxObjectsPerStream {
+			objStreams = append(objStreams, objStream)
+			objStream = &core.PdfObjectStreams{}
 		}
+		objStream.Append(obj)
 	}
-	if objStream.Len() == 0 {
+	if objStream.Len() > 0 {
+		objStreams = append(objStreams, objStream)
+	}
+	if len(objStreams) == 0 {
 		return skippedObjects, nil
 	}
 
-	optimizedObjects = make([]core.PdfObject, 0, len(skippedObjects)+objStream.Len()+1)
-	if objStream.Len() > 1 {
-		optimizedObjects = append(optimizedObjects, objStream)
+	optimizedObjects = make([]core.PdfObject, 0, len(objects)+len(objStreams))
+	for _, objStream := range objStreams {
+		// Object streams containing a single object do not reduce the size.
+		if objStream.Len() > 1 {
+			optimizedObjects = append(optimizedObjects, objStream)
+		}
+		optimizedObjects = append(optimizedObjects, objStream.Elements()...)
 	}
-	optimizedObjects = append(optimizedObjects, objStream.Elements()...)
 	optimizedObjects = append(optimizedObjects, skippedObjects...)
 
 	return optimizedObjects, nil
 }
+
+// isPackable returns true if `obj` can be packed into an object stream.
+func (o *ObjectStreams) isPackable(obj core.PdfObject) bool {
+	io, isIndirectObj := obj.(*core.PdfIndirectObject)
+	if !isIndirectObj || io.GenerationNumber != 0 {
+		return false
+	}
+	if _, isTrailerObj := o.trailerObjects[obj]; isTrailerObj {
+		return false
+	}
+	return !isEncryptDict(io.PdfObject)
+}
+
+// isEncryptDict returns true if `obj` is an encryption dictionary.
+func isEncryptDict(obj core.PdfObject) bool {
+	dict, ok := core.GetDict(obj)
+	if !ok {
+		return false
+	}
+	if _, ok := core.GetName(dict.Get("Filter")); !ok {
+		return false
+	}
+	return dict.Get("O") != nil && dict.Get("U") != nil && dict.Get("P") != nil
+}
@@ -9,9 +9,13 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math/rand"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/model"
 	"github.com/bcmmbaga/unipdf-agpl/v3/model/optimize"
 )
 
@@ -210,3 +214,1023 @@ endobj
 		t.Fatalf("len(optObjects) != 6 (%d)", len(optObjects))
 	}
 }
+
+// Test compressing uncompressed image streams with a PNG predictor.
+func TestCompressStreamsImagePredictor(t *testing.T) {
+	const width, height = 32, 16
+	var data []byte
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			data = append(data, byte(4*x), byte(8*y), byte(x+y))
+		}
+	}
+
+	image, err := core.MakeStream(data, nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	image.Set("Subtype", core.MakeName("Image"))
+	image.Set("Width", core.MakeInteger(width))
+	image.Set("Height", core.MakeInteger(height))
+	image.Set("BitsPerComponent", core.MakeInteger(8))
+	image.Set("ColorSpace", core.MakeName("DeviceRGB"))
+
+	content, err := core.MakeStream(bytes.Repeat([]byte("0 0 m 10 10 l S\n"), 20), nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	opt := optimize.CompressStreams{CompressionLevel: 9, ImagePredictor: 15}
+	if _, err := opt.Optimize([]core.PdfObject{image, content}); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	params, ok := core.GetDict(image.Get("DecodeParms"))
+	if !ok {
+		t.Fatalf("Image DecodeParms missing")
+	}
+	if predictor, _ := core.GetIntVal(params.Get("Predictor")); predictor != 15 {
+		t.Fatalf("Predictor != 15 (%d)", predictor)
+	}
+	decoded, err := core.DecodeStream(image)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !bytes.Equal(decoded, data) {
+		t.Fatalf("Decoded image data does not match")
+	}
+
+	// The predictor must not be applied to non-image streams.
+	if content.Get("DecodeParms") != nil {
+		t.Fatalf("Unexpected DecodeParms for content stream")
+	}
+	if name, _ := core.GetNameVal(content.Get("Filter")); name != core.StreamEncodingFilterNameFlate {
+		t.Fatalf("Content stream not compressed (%s)", name)
+	}
+
+	// A negative predictor disables prediction.
+	image, err = core.MakeStream(data, nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	image.Set("Subtype", core.MakeName("Image"))
+	image.Set("Width", core.MakeInteger(width))
+	image.Set("Height", core.MakeInteger(height))
+	image.Set("BitsPerComponent", core.MakeInteger(8))
+	image.Set("ColorSpace", core.MakeName("DeviceRGB"))
+
+	opt = optimize.CompressStreams{CompressionLevel: 9, ImagePredictor: -1}
+	if _, err := opt.Optimize([]core.PdfObject{image}); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if image.Get("DecodeParms") != nil {
+		t.Fatalf("Unexpected DecodeParms for image with prediction disabled")
+	}
+}
+
+// makeHoistTestObjects returns the objects of a document with two pages,
+// which use identical inline ExtGState dictionaries. The resources of the
+// second page contain the `extra` font, if set.
+func makeHoistTestObjects(extra bool) []core.PdfObject {
+	font := core.MakeIndirectObject(core.MakeDict())
+	font.PdfObject.(*core.PdfObjectDictionary).Set("BaseFont", core.MakeName("Helvetica"))
+
+	pages := core.MakeDict()
+	pages.Set("Type", core.MakeName("Pages"))
+	pagesObj := core.MakeIndirectObject(pages)
+	kids := core.MakeArray()
+	pages.Set("Kids", kids)
+
+	objects := []core.PdfObject{font, pagesObj}
+	for i := 0; i < 2; i++ {
+		gs := core.MakeDict()
+		gs.Set("CA", core.MakeFloat(0.5))
+		extGStates := core.MakeDict()
+		extGStates.Set("GS1", gs)
+		fonts := core.MakeDict()
+		fonts.Set("F1", font)
+		if extra && i == 1 {
+			fonts.Set("F2", font)
+		}
+		resources := core.MakeDict()
+		resources.Set("ExtGState", extGStates)
+		resources.Set("Font", fonts)
+
+		page := core.MakeDict()
+		page.Set("Type", core.MakeName("Page"))
+		page.Set("Parent", pagesObj)
+		page.Set("Resources", resources)
+		pageObj := core.MakeIndirectObject(page)
+		kids.Append(pageObj)
+		objects = append(objects, pageObj)
+	}
+
+	catalog := core.MakeDict()
+	catalog.Set("Type", core.MakeName("Catalog"))
+	catalog.Set("Pages", pagesObj)
+	return append([]core.PdfObject{core.MakeIndirectObject(catalog)}, objects...)
+}
+
+func TestHoistResources(t *testing.T) {
+	objects := makeHoistTestObjects(false)
+	opt := &optimize.HoistResources{}
+	optObjects, err := opt.Optimize(objects)
+	require.NoError(t, err)
+
+	// The shared ExtGState dictionary is added.
+	require.Len(t, optObjects, len(objects)+1)
+	require.Equal(t, 1, opt.MergedExtGStates)
+	require.Equal(t, 2, opt.HoistedPages)
+	require.Less(t, opt.ResourceSizeAfter, opt.ResourceSizeBefore)
+
+	pages, ok := core.GetDict(objects[2])
+	require.True(t, ok)
+	resources, ok := core.GetDict(pages.Get("Resources"))
+	require.True(t, ok)
+	require.NotNil(t, resources.Get("Font"))
+	for _, obj := range objects[3:] {
+		page, ok := core.GetDict(obj)
+		require.True(t, ok)
+		require.Nil(t, page.Get("Resources"))
+	}
+
+	// Different page resources are not hoisted.
+	objects = makeHoistTestObjects(true)
+	opt = &optimize.HoistResources{}
+	_, err = opt.Optimize(objects)
+	require.NoError(t, err)
+	require.Equal(t, 1, opt.MergedExtGStates)
+	require.Zero(t, opt.HoistedPages)
+
+	pages, ok = core.GetDict(objects[2])
+	require.True(t, ok)
+	require.Nil(t, pages.Get("Resources"))
+	for _, obj := range objects[3:] {
+		page, ok := core.GetDict(obj)
+		require.True(t, ok)
+		extGStates, ok := core.GetDict(page.Get("Resources").(*core.PdfObjectDictionary).Get("ExtGState"))
+		require.True(t, ok)
+		_, ok = extGStates.Get("GS1").(*core.PdfIndirectObject)
+		require.True(t, ok)
+	}
+}
+
+// makeImageStream returns an uncompressed image stream with 8 bits per
+// component.
+func makeImageStream(width, height int, colorspace string, data []byte) *core.PdfObjectStream {
+	stream, _ := core.MakeStream(data, nil)
+	stream.Set("Type", core.MakeName("XObject"))
+	stream.Set("Subtype", core.MakeName("Image"))
+	stream.Set("Width", core.MakeInteger(int64(width)))
+	stream.Set("Height", core.MakeInteger(int64(height)))
+	stream.Set("ColorSpace", core.MakeName(colorspace))
+	stream.Set("BitsPerComponent", core.MakeInteger(8))
+	return stream
+}
+
+func TestAutoImageCodec(t *testing.T) {
+	const size = 64
+
+	// Bilevel image, with a few near-black pixels.
+	bilevel := make([]byte, size*size)
+	expectedBits := make([]byte, size*size/8)
+	for i := range bilevel {
+		x, y := i%size, i/size
+		if (x/8+y/8)%2 == 0 {
+			bilevel[i] = 255
+			expectedBits[i/8] |= 0x80 >> uint(i%8)
+		} else if x == y {
+			bilevel[i] = 10
+		}
+	}
+
+	// Image with three colors.
+	palette := [][]byte{{255, 0, 0}, {0, 255, 0}, {0, 0, 255}}
+	var threeColors []byte
+	for i := 0; i < size*size; i++ {
+		threeColors = append(threeColors, palette[(i/7)%3]...)
+	}
+
+	// Smooth photographic-like image.
+	var photo []byte
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			photo = append(photo, byte(x*4), byte(y*4), byte((x+y)*2))
+		}
+	}
+
+	streams := []*core.PdfObjectStream{
+		makeImageStream(size, size, "DeviceGray", bilevel),
+		makeImageStream(size, size, "DeviceRGB", threeColors),
+		makeImageStream(size, size, "DeviceRGB", photo),
+	}
+	objects := []core.PdfObject{streams[0], streams[1], streams[2]}
+
+	var codecs []optimize.ImageCodec
+	opt := &optimize.AutoImageCodec{
+		SelectCodec: func(stats optimize.ImageStats, suggested optimize.ImageCodec) optimize.ImageCodec {
+			codecs = append(codecs, suggested)
+			return suggested
+		},
+	}
+	optObjects, err := opt.Optimize(objects)
+	require.NoError(t, err)
+	require.Equal(t, []optimize.ImageCodec{
+		optimize.ImageCodecJBIG2,
+		optimize.ImageCodecIndexedFlate,
+		optimize.ImageCodecDCT,
+	}, codecs)
+
+	filters := []string{"JBIG2Decode", "FlateDecode", "DCTDecode"}
+	for i, obj := range optObjects {
+		stream, ok := core.GetStream(obj)
+		require.True(t, ok)
+		require.True(t, stream != streams[i])
+		filter, _ := core.GetNameVal(stream.Get("Filter"))
+		require.Equal(t, filters[i], filter)
+	}
+
+	// The bilevel image is thresholded.
+	stream, _ := core.GetStream(optObjects[0])
+	bpc, _ := core.GetIntVal(stream.Get("BitsPerComponent"))
+	require.Equal(t, 1, bpc)
+	decoded, err := core.DecodeStream(stream)
+	require.NoError(t, err)
+	require.Equal(t, expectedBits, decoded)
+
+	// The three color image uses 2 bits per index.
+	stream, _ = core.GetStream(optObjects[1])
+	cs, ok := core.GetArray(stream.Get("ColorSpace"))
+	require.True(t, ok)
+	require.Equal(t, "/Indexed", cs.Get(0).WriteString())
+	hival, _ := core.GetIntVal(cs.Get(2))
+	require.Equal(t, 2, hival)
+	bpc, _ = core.GetIntVal(stream.Get("BitsPerComponent"))
+	require.Equal(t, 2, bpc)
+	decoded, err = core.DecodeStream(stream)
+	require.NoError(t, err)
+	require.Len(t, decoded, size*size/4)
+	require.Equal(t, byte(0x01), decoded[1]) // 00 00 00 01
+	require.Equal(t, byte(0x55), decoded[2]) // 01 01 01 01
+
+	// CCITT can be preferred and the suggestions can be overridden.
+	objects = []core.PdfObject{
+		makeImageStream(size, size, "DeviceGray", bilevel),
+		makeImageStream(size, size, "DeviceRGB", threeColors),
+	}
+	opt = &optimize.AutoImageCodec{
+		PreferCCITT: true,
+		SelectCodec: func(stats optimize.ImageStats, suggested optimize.ImageCodec) optimize.ImageCodec {
+			if stats.ColorComponents == 3 {
+				return optimize.ImageCodecKeep
+			}
+			return suggested
+		},
+	}
+	optObjects, err = opt.Optimize(objects)
+	require.NoError(t, err)
+	stream, _ = core.GetStream(optObjects[0])
+	filter, _ := core.GetNameVal(stream.Get("Filter"))
+	require.Equal(t, "CCITTFaxDecode", filter)
+	decoded, err = core.DecodeStream(stream)
+	require.NoError(t, err)
+	require.Equal(t, expectedBits, decoded)
+	require.True(t, optObjects[1] == objects[1])
+}
+
+// Test that compressed streams are never larger than the original streams.
+func TestCompressStreamsSmallest(t *testing.T) {
+	// Incompressible data.
+	random := make([]byte, 256)
+	rnd := rand.New(rand.NewSource(1))
+	rnd.Read(random)
+
+	data := [][]byte{
+		random,
+		bytes.Repeat([]byte("BT /F1 12 Tf (Hello) Tj ET\n"), 50),
+		[]byte("q Q"),
+	}
+	var streams []core.PdfObject
+	for _, d := range data {
+		stream, err := core.MakeStream(d, nil)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		streams = append(streams, stream)
+	}
+
+	opt := optimize.CompressStreams{}
+	if _, err := opt.Optimize(streams); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	for i, obj := range streams {
+		stream := obj.(*core.PdfObjectStream)
+		filter := stream.Get("Filter")
+		if i != 1 {
+			if filter != nil {
+				t.Fatalf("Stream %d should not be compressed (%v)", i, filter)
+			}
+			continue
+		}
+		if filter == nil {
+			t.Fatalf("Stream %d should be compressed", i)
+		}
+		decoded, err := core.DecodeStream(stream)
+		if err != nil {
+			t.Fatalf("Error: %v", err)
+		}
+		if !bytes.Equal(decoded, data[i]) {
+			t.Fatalf("Decoded stream %d does not match", i)
+		}
+	}
+
+	// LZW streams specify EarlyChange in the decode parameters.
+	lzw := core.NewLZWEncoder()
+	lzw.EarlyChange = 0
+	encoded, err := lzw.EncodeBytes(data[1])
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	stream, err := core.MakeStream(encoded, nil)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	stream.Merge(lzw.MakeStreamDict())
+	if stream.Get("EarlyChange") != nil {
+		t.Fatalf("Unexpected EarlyChange in stream dictionary")
+	}
+	decoded, err := core.DecodeStream(stream)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if !bytes.Equal(decoded, data[1]) {
+		t.Fatalf("Decoded LZW stream does not match")
+	}
+}
+
+// Test that streams smaller than the minimum size are not compressed.
+func TestCompressStreamsMinSize(t *testing.T) {
+	data := bytes.Repeat([]byte("0 0 m 10 10 l S\n"), 8)
+
+	compress := func(minSize int) bool {
+		stream, err := core.MakeStream(data, nil)
+		require.NoError(t, err)
+		opt := optimize.CompressStreams{MinSize: minSize}
+		_, err = opt.Optimize([]core.PdfObject{stream})
+		require.NoError(t, err)
+		return stream.Get("Filter") != nil
+	}
+
+	require.True(t, compress(0))
+	require.False(t, compress(len(data)+1))
+	require.True(t, compress(len(data)))
+	require.True(t, compress(-1))
+}
+
+// Test compressing unencoded streams with empty filters or decode parameters.
+func TestCompressStreamsEmptyFilter(t *testing.T) {
+	data := bytes.Repeat([]byte("0 0 m 10 10 l S\n"), 8)
+	makeStream := func(filter, decodeParms core.PdfObject) *core.PdfObjectStream {
+		stream, err := core.MakeStream(data, nil)
+		require.NoError(t, err)
+		if filter != nil {
+			stream.Set("Filter", filter)
+		}
+		if decodeParms != nil {
+			stream.Set("DecodeParms", decodeParms)
+		}
+		return stream
+	}
+	predictor := core.MakeDict()
+	predictor.Set("Predictor", core.MakeInteger(12))
+
+	emptyArray := makeStream(core.MakeArray(), core.MakeArray())
+	null := makeStream(core.MakeNull(), core.MakeDict())
+	params := makeStream(nil, predictor)
+
+	opt := optimize.CompressStreams{}
+	_, err := opt.Optimize([]core.PdfObject{emptyArray, null, params})
+	require.NoError(t, err)
+
+	// Empty filters are equivalent to no filter.
+	for _, stream := range []*core.PdfObjectStream{emptyArray, null} {
+		name, ok := core.GetNameVal(stream.Get("Filter"))
+		require.True(t, ok)
+		require.Equal(t, core.StreamEncodingFilterNameFlate, name)
+		require.Nil(t, stream.Get("DecodeParms"))
+		decoded, err := core.DecodeStream(stream)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+
+	// Streams with decode parameters are compressed only if enabled.
+	require.Nil(t, params.Get("Filter"))
+	require.Equal(t, data, params.Stream)
+
+	opt.CompressWithDecodeParms = true
+	_, err = opt.Optimize([]core.PdfObject{params})
+	require.NoError(t, err)
+	require.NotNil(t, params.Get("Filter"))
+	require.Nil(t, params.Get("DecodeParms"))
+	decoded, err := core.DecodeStream(params)
+	require.NoError(t, err)
+	require.Equal(t, data, decoded)
+}
+
+// Test recompressing Flate streams using the best compression level.
+func TestCompressStreamsRecompressFlate(t *testing.T) {
+	var data []byte
+	for i := 0; i < 500; i++ {
+		data = append(data, fmt.Sprintf("BT /F1 12 Tf %d %d Td (Line %d) Tj ET\n", i%7, i%13, i%11)...)
+	}
+	// Align the data to the predictor rows.
+	data = data[:len(data)-len(data)%40]
+
+	makeFlateStream := func(opts *core.FlateEncoderOptions) *core.PdfObjectStream {
+		enc := core.NewFlateEncoderWithOptions(opts)
+		encoded, err := enc.EncodeBytes(data)
+		require.NoError(t, err)
+		stream, err := core.MakeStream(encoded, nil)
+		require.NoError(t, err)
+		stream.Merge(enc.MakeStreamDict())
+		return stream
+	}
+
+	plain := makeFlateStream(&core.FlateEncoderOptions{CompressionLevel: 1})
+	predicted := makeFlateStream(&core.FlateEncoderOptions{
+		CompressionLevel: 1,
+		Predictor:        12,
+		Columns:          40,
+		Colors:           1,
+		BitsPerComponent: 8,
+	})
+	array := makeFlateStream(&core.FlateEncoderOptions{CompressionLevel: 1})
+	array.Set("Filter", core.MakeArray(core.MakeName(core.StreamEncodingFilterNameFlate)))
+
+	multi := makeFlateStream(&core.FlateEncoderOptions{CompressionLevel: 1})
+	multi.Set("Filter", core.MakeArray(
+		core.MakeName(core.StreamEncodingFilterNameFlate),
+		core.MakeName(core.StreamEncodingFilterNameFlate),
+	))
+	dct, err := core.MakeStream([]byte{0xff, 0xd8, 0xff, 0xd9}, nil)
+	require.NoError(t, err)
+	dct.Set("Filter", core.MakeName(core.StreamEncodingFilterNameDCT))
+
+	recompressed := []*core.PdfObjectStream{plain, predicted, array}
+	untouched := []*core.PdfObjectStream{multi, dct}
+	var objects []core.PdfObject
+	var sizes []int
+	for _, stream := range append(recompressed, untouched...) {
+		objects = append(objects, stream)
+		sizes = append(sizes, len(stream.Stream))
+	}
+
+	opt := optimize.CompressStreams{RecompressFlate: true}
+	_, err = opt.Optimize(objects)
+	require.NoError(t, err)
+
+	for i, stream := range recompressed {
+		require.Less(t, len(stream.Stream), sizes[i])
+		length, ok := core.GetIntVal(stream.Get("Length"))
+		require.True(t, ok)
+		require.Equal(t, len(stream.Stream), length)
+
+		decoded, err := core.DecodeStream(stream)
+		require.NoError(t, err)
+		require.Equal(t, data, decoded)
+	}
+	for i, stream := range untouched {
+		require.Equal(t, sizes[len(recompressed)+i], len(stream.Stream))
+	}
+}
+
+// makeCompressibleStreams returns `n` uncompressed content streams of about
+// `size` bytes each, with distinct contents, along with the indirect objects
+// referencing them.
+func makeCompressibleStreams(n, size int) ([]core.PdfObject, error) {
+	rnd := rand.New(rand.NewSource(int64(n)))
+	var objects []core.PdfObject
+	for i := 0; i < n; i++ {
+		var buf bytes.Buffer
+		for buf.Len() < size {
+			fmt.Fprintf(&buf, "BT /F1 %d Tf %d %d Td (Line %d) Tj ET\n", 8+rnd.Intn(8), rnd.Intn(600), rnd.Intn(800), rnd.Intn(100))
+		}
+		stream, err := core.MakeStream(buf.Bytes(), nil)
+		if err != nil {
+			return nil, err
+		}
+		objects = append(objects, stream, core.MakeIndirectObject(core.MakeArray(stream)))
+	}
+	return objects, nil
+}
+
+// Test that compressing streams in parallel gives the same result as
+// compressing them sequentially, preserving the order of the objects.
+func TestCompressStreamsConcurrency(t *testing.T) {
+	var results [][]core.PdfObject
+	for _, concurrency := range []int{1, 0, 8} {
+		objects, err := makeCompressibleStreams(20, 4096)
+		require.NoError(t, err)
+
+		opt := optimize.CompressStreams{Concurrency: concurrency}
+		optimized, err := opt.Optimize(objects)
+		require.NoError(t, err)
+		require.Equal(t, objects, optimized)
+		results = append(results, optimized)
+	}
+
+	for _, optimized := range results[1:] {
+		require.Len(t, optimized, len(results[0]))
+		for i, obj := range optimized {
+			expected, ok := results[0][i].(*core.PdfObjectStream)
+			if !ok {
+				continue
+			}
+			stream, ok := obj.(*core.PdfObjectStream)
+			require.True(t, ok)
+			require.NotNil(t, stream.Get("Filter"))
+			require.Equal(t, expected.Stream, stream.Stream)
+			require.Equal(t, expected.WriteString(), stream.WriteString())
+		}
+	}
+}
+
+// Benchmark compressing many large streams sequentially and in parallel.
+func BenchmarkCompressStreams(b *testing.B) {
+	for _, concurrency := range []int{1, 0} {
+		b.Run(fmt.Sprintf("Concurrency%d", concurrency), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				objects, err := makeCompressibleStreams(64, 256*1024)
+				require.NoError(b, err)
+				b.StartTimer()
+
+				opt := optimize.CompressStreams{Concurrency: concurrency}
+				_, err = opt.Optimize(objects)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+// Test packing objects into object streams with a limited number of objects
+// per stream, keeping the trailer objects outside the object streams.
+func TestObjectStreamsMaxObjects(t *testing.T) {
+	var objects []core.PdfObject
+	for i := 0; i < 7; i++ {
+		dict := core.MakeDict()
+		dict.Set("Index", core.MakeInteger(int64(i)))
+		objects = append(objects, core.MakeIndirectObject(dict))
+	}
+	infoDict := core.MakeDict()
+	infoDict.Set("Producer", core.MakeString("test"))
+	info := core.MakeIndirectObject(infoDict)
+	encryptDict := core.MakeDict()
+	encryptDict.Set("Filter", core.MakeName("Standard"))
+	encryptDict.Set("O", core.MakeString("o"))
+	encryptDict.Set("U", core.MakeString("u"))
+	encryptDict.Set("P", core.MakeInteger(-4))
+	encrypt := core.MakeIndirectObject(encryptDict)
+	stream, err := core.MakeStream([]byte("q Q"), nil)
+	require.NoError(t, err)
+	objects = append(objects, info, encrypt, stream)
+
+	opt := optimize.ObjectStreams{MaxObjectsPerStream: 3}
+	opt.SetTrailerObjects([]core.PdfObject{info})
+	optimized, err := opt.Optimize(objects)
+	require.NoError(t, err)
+
+	packed := map[core.PdfObject]bool{}
+	var counts []int
+	for _, obj := range optimized {
+		if objStream, ok := core.GetObjectStreams(obj); ok {
+			counts = append(counts, objStream.Len())
+			for _, elem := range objStream.Elements() {
+				packed[elem] = true
+			}
+		}
+	}
+	require.Equal(t, []int{3, 3}, counts)
+	for _, obj := range objects[:6] {
+		require.True(t, packed[obj])
+	}
+	for _, obj := range objects[6:] {
+		require.False(t, packed[obj])
+	}
+	require.Len(t, optimized, len(objects)+len(counts))
+}
+
+// Test writing and reading back a document using object streams.
+func TestObjectStreamsWrite(t *testing.T) {
+	w := model.NewPdfWriter()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, w.AddPage(model.NewPdfPage()))
+	}
+	w.SetOptimizer(optimize.New(optimize.Options{
+		UseObjectStreams:       true,
+		ObjectStreamMaxObjects: 2,
+	}))
+
+	var buf bytes.Buffer
+	require.NoError(t, w.Write(&buf))
+	data := buf.Bytes()
+
+	require.Greater(t, bytes.Count(data, []byte("/Type /ObjStm")), 1)
+	require.Contains(t, string(data), "/Type /XRef")
+	// The information dictionary is written outside the object streams.
+	require.Contains(t, string(data), "/Producer")
+
+	reader, err := model.NewPdfReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	numPages, err := reader.GetNumPages()
+	require.NoError(t, err)
+	require.Equal(t, 5, numPages)
+}
+
+// Test combining duplicate image XObjects.
+func TestCombineDuplicateImages(t *testing.T) {
+	makeImage := func(data []byte, smask core.PdfObject) *core.PdfObjectStream {
+		stream, err := core.MakeStream(data, nil)
+		require.NoError(t, err)
+		stream.Set("Type", core.MakeName("XObject"))
+		stream.Set("Subtype", core.MakeName("Image"))
+		stream.Set("Width", core.MakeInteger(2))
+		stream.Set("Height", core.MakeInteger(2))
+		stream.Set("ColorSpace", core.MakeName("DeviceGray"))
+		stream.Set("BitsPerComponent", core.MakeInteger(8))
+		if smask != nil {
+			stream.Set("SMask", smask)
+		}
+		return stream
+	}
+
+	pixels := []byte{0, 64, 128, 255}
+	smask1 := makeImage([]byte{255, 255, 0, 0}, nil)
+	smask2 := makeImage([]byte{255, 255, 0, 0}, nil)
+	smask3 := makeImage([]byte{0, 0, 255, 255}, nil)
+	img1 := makeImage(pixels, smask1)
+	img2 := makeImage(pixels, smask2)
+	img3 := makeImage(pixels, smask3)
+	img4 := makeImage(pixels, nil)
+
+	xobjects := core.MakeDict()
+	xobjects.Set("Im1", img1)
+	xobjects.Set("Im2", img2)
+	xobjects.Set("Im3", img3)
+	xobjects.Set("Im4", img4)
+	resources := core.MakeDict()
+	resources.Set("XObject", xobjects)
+	resourcesObj := core.MakeIndirectObject(resources)
+
+	objects := []core.PdfObject{resourcesObj, img1, smask1, img2, smask2, img3, smask3, img4}
+	opt := optimize.CombineDuplicateImages{}
+	optimized, err := opt.Optimize(objects)
+	require.NoError(t, err)
+
+	// The duplicate image and its duplicate mask are removed.
+	expected := []core.PdfObject{resourcesObj, img1, smask1, img3, smask3, img4}
+	require.Len(t, optimized, len(expected))
+	for i, obj := range expected {
+		require.Same(t, obj, optimized[i])
+	}
+	require.Same(t, img1, xobjects.Get("Im1"))
+	require.Same(t, img1, xobjects.Get("Im2"))
+	require.Same(t, img3, xobjects.Get("Im3"))
+	require.Same(t, img4, xobjects.Get("Im4"))
+	require.Same(t, smask1, img1.Get("SMask"))
+	require.Same(t, smask3, img3.Get("SMask"))
+}
+
+// Test that images differing only in entries other than the image parameters
+// are not combined.
+func TestCombineDuplicateImagesDistinctEntries(t *testing.T) {
+	makeImage := func(key string, value core.PdfObject) *core.PdfObjectStream {
+		stream := makeImageStream(2, 2, "DeviceGray", []byte{0, 64, 128, 255})
+		if value != nil {
+			stream.Set(core.PdfObjectName(key), value)
+		}
+		return stream
+	}
+
+	ocg := core.MakeIndirectObject(core.MakeDict())
+	metadata, err := core.MakeStream([]byte("<x:xmpmeta/>"), nil)
+	require.NoError(t, err)
+
+	objects := []core.PdfObject{
+		makeImage("", nil),
+		makeImage("OC", ocg),
+		makeImage("StructParent", core.MakeInteger(1)),
+		makeImage("Interpolate", core.MakeBool(true)),
+		makeImage("Alt", core.MakeString("alternate")),
+		makeImage("Metadata", metadata),
+	}
+	opt := optimize.CombineDuplicateImages{}
+	optimized, err := opt.Optimize(objects)
+	require.NoError(t, err)
+	require.Len(t, optimized, len(objects))
+}
+
+// Test scaling images along with their soft masks.
+func TestImagePPISoftMask(t *testing.T) {
+	makeImage := func(size int, colorspace string, smask core.PdfObject) *core.PdfObjectStream {
+		components := map[string]int{"DeviceGray": 1, "DeviceRGB": 3, "DeviceCMYK": 4}[colorspace]
+		data := make([]byte, size*size*components)
+		for i := range data {
+			data[i] = byte(i % 251)
+		}
+		stream := makeImageStream(size, size, colorspace, data)
+		if smask != nil {
+			stream.Set("SMask", smask)
+		}
+		return stream
+	}
+
+	// The first image has a mask with the same dimensions and the second one
+	// has a mask with half its dimensions. The mask of the third image cannot
+	// be resampled.
+	sameMask := makeImage(400, "DeviceGray", nil)
+	sameImg := makeImage(400, "DeviceRGB", sameMask)
+	halfMask := makeImage(200, "DeviceGray", nil)
+	halfImg := makeImage(400, "DeviceRGB", halfMask)
+	badMask := makeImage(400, "DeviceCMYK", nil)
+	badImg := makeImage(400, "DeviceRGB", badMask)
+
+	xobjects := core.MakeDict()
+	xobjects.Set("Im1", sameImg)
+	xobjects.Set("Im2", halfImg)
+	xobjects.Set("Im3", badImg)
+	resources := core.MakeDict()
+	resources.Set("XObject", xobjects)
+
+	// The images are drawn at 100x100 points, which is 288 PPI.
+	contents, err := core.MakeStream([]byte("q 100 0 0 100 0 0 cm /Im1 Do Q q 100 0 0 100 0 0 cm /Im2 Do Q q 100 0 0 100 0 0 cm /Im3 Do Q"), nil)
+	require.NoError(t, err)
+	page := core.MakeDict()
+	page.Set("Type", core.MakeName("Page"))
+	page.Set("Resources", resources)
+	page.Set("Contents", core.MakeArray(contents))
+	pages := core.MakeDict()
+	pages.Set("Type", core.MakeName("Pages"))
+	pages.Set("Kids", core.MakeArray(page))
+	catalog := core.MakeDict()
+	catalog.Set("Type", core.MakeName("Catalog"))
+	catalog.Set("Pages", pages)
+
+	objects := []core.PdfObject{catalog, pages, page, contents, sameImg, sameMask, halfImg, halfMask, badImg, badMask}
+	opt := optimize.ImagePPI{ImageUpperPPI: 144}
+	_, err = opt.Optimize(objects)
+	require.NoError(t, err)
+
+	getSize := func(stream *core.PdfObjectStream) (int, int) {
+		width, ok := core.GetIntVal(stream.Get("Width"))
+		require.True(t, ok)
+		height, ok := core.GetIntVal(stream.Get("Height"))
+		require.True(t, ok)
+		return width, height
+	}
+
+	// The masks are scaled along with their images and not on their own. The
+	// third image is left unchanged, as its mask could not be scaled with it.
+	for _, tc := range []struct {
+		img, mask                *core.PdfObjectStream
+		imgW, imgH, maskW, maskH int
+	}{
+		{sameImg, sameMask, 200, 200, 200, 200},
+		{halfImg, halfMask, 200, 200, 100, 100},
+		{badImg, badMask, 400, 400, 400, 400},
+	} {
+		w, h := getSize(tc.img)
+		require.Equal(t, tc.imgW, w)
+		require.Equal(t, tc.imgH, h)
+		w, h = getSize(tc.mask)
+		require.Equal(t, tc.maskW, w)
+		require.Equal(t, tc.maskH, h)
+
+		require.Same(t, tc.mask, tc.img.Get("SMask"))
+	}
+}
+
+// Test downsampling images based on their effective resolution.
+func TestDownsampleImages(t *testing.T) {
+	makeImage := func(size int, colorspace string) *core.PdfObjectStream {
+		components := map[string]int{"DeviceGray": 1, "DeviceRGB": 3}[colorspace]
+		data := make([]byte, size*size*components)
+		for i := range data {
+			data[i] = byte(i % 251)
+		}
+		return makeImageStream(size, size, colorspace, data)
+	}
+
+	// The RGB image is drawn rotated at 100x100 points, which is 288 DPI.
+	rgbMask := makeImage(400, "DeviceGray")
+	rgbImg := makeImage(400, "DeviceRGB")
+	rgbImg.Set("SMask", rgbMask)
+
+	// The DCT image is drawn at 100x100 points by a form XObject, which is
+	// 216 DPI.
+	dctenc := core.NewDCTEncoder()
+	dctenc.Width, dctenc.Height = 300, 300
+	dctenc.ColorComponents = 1
+	dctenc.BitsPerComponent = 8
+	dctImg, err := core.MakeStream(makeImage(300, "DeviceGray").Stream, dctenc)
+	require.NoError(t, err)
+	dctImg.Merge(makeImageStream(300, 300, "DeviceGray", nil).PdfObjectDictionary)
+	dctImg.Set("Length", core.MakeInteger(int64(len(dctImg.Stream))))
+	formXObjects := core.MakeDict()
+	formXObjects.Set("Im", dctImg)
+	formResources := core.MakeDict()
+	formResources.Set("XObject", formXObjects)
+	form, err := core.MakeStream([]byte("q 200 0 0 200 0 0 cm /Im Do Q"), nil)
+	require.NoError(t, err)
+	form.Set("Type", core.MakeName("XObject"))
+	form.Set("Subtype", core.MakeName("Form"))
+	form.Set("Matrix", core.MakeArrayFromFloats([]float64{0.5, 0, 0, 0.5, 0, 0}))
+	form.Set("Resources", formResources)
+
+	// The low resolution image is drawn at 72 DPI and the small image at
+	// 720 DPI.
+	lowImg := makeImage(100, "DeviceGray")
+	smallImg := makeImage(10, "DeviceGray")
+
+	xobjects := core.MakeDict()
+	xobjects.Set("Im1", rgbImg)
+	xobjects.Set("Fm1", form)
+	xobjects.Set("Im2", lowImg)
+	xobjects.Set("Im3", smallImg)
+	resources := core.MakeDict()
+	resources.Set("XObject", xobjects)
+
+	contents, err := core.MakeStream([]byte("q 0 100 -100 0 100 0 cm /Im1 Do Q /Fm1 Do "+
+		"q 100 0 0 100 0 0 cm /Im2 Do Q q 1 0 0 1 0 0 cm /Im3 Do Q"), nil)
+	require.NoError(t, err)
+
+	// The resources are inherited from the intermediate page tree node.
+	page := core.MakeDict()
+	page.Set("Type", core.MakeName("Page"))
+	page.Set("Contents", contents)
+	node := core.MakeDict()
+	node.Set("Type", core.MakeName("Pages"))
+	node.Set("Kids", core.MakeArray(page))
+	node.Set("Resources", resources)
+	pages := core.MakeDict()
+	pages.Set("Type", core.MakeName("Pages"))
+	pages.Set("Kids", core.MakeArray(node))
+	catalog := core.MakeDict()
+	catalog.Set("Type", core.MakeName("Catalog"))
+	catalog.Set("Pages", pages)
+
+	objects := []core.PdfObject{catalog, pages, node, page, contents, form,
+		rgbImg, rgbMask, dctImg, lowImg, smallImg}
+	opt := optimize.DownsampleImages{TargetDPI: 144}
+	_, err = opt.Optimize(objects)
+	require.NoError(t, err)
+	require.Equal(t, 2, opt.Downsampled)
+
+	for _, tc := range []struct {
+		stream *core.PdfObjectStream
+		size   int
+		filter string
+	}{
+		{rgbImg, 200, core.StreamEncodingFilterNameFlate},
+		{rgbMask, 200, core.StreamEncodingFilterNameFlate},
+		{dctImg, 200, core.StreamEncodingFilterNameDCT},
+		{lowImg, 100, ""},
+		{smallImg, 10, ""},
+	} {
+		width, _ := core.GetIntVal(tc.stream.Get("Width"))
+		height, _ := core.GetIntVal(tc.stream.Get("Height"))
+		require.Equal(t, tc.size, width)
+		require.Equal(t, tc.size, height)
+		filter, _ := core.GetNameVal(tc.stream.Get("Filter"))
+		require.Equal(t, tc.filter, filter)
+	}
+	require.Same(t, rgbMask, rgbImg.Get("SMask"))
+}
+
+// Test removing the objects which are not reachable from the trailer.
+func TestPruneUnusedObjects(t *testing.T) {
+	makeIndirect := func(entries map[string]core.PdfObject) *core.PdfIndirectObject {
+		dict := core.MakeDict()
+		for key, val := range entries {
+			dict.Set(core.PdfObjectName(key), val)
+		}
+		return core.MakeIndirectObject(dict)
+	}
+
+	contents, err := core.MakeStream([]byte("BT ET"), nil)
+	require.NoError(t, err)
+	font := makeIndirect(map[string]core.PdfObject{"Type": core.MakeName("Font")})
+	fonts := core.MakeDict()
+	fonts.Set("F1", font)
+	resources := core.MakeDict()
+	resources.Set("Font", fonts)
+	page := makeIndirect(map[string]core.PdfObject{"Type": core.MakeName("Page"), "Contents": contents, "Resources": resources})
+	pages := makeIndirect(map[string]core.PdfObject{"Type": core.MakeName("Pages"), "Kids": core.MakeArray(page)})
+	page.PdfObject.(*core.PdfObjectDictionary).Set("Parent", pages)
+
+	// Objects reached through the interactive form and the name trees.
+	field := makeIndirect(map[string]core.PdfObject{"FT": core.MakeName("Tx"), "P": page})
+	acroForm := makeIndirect(map[string]core.PdfObject{"Fields": core.MakeArray(field)})
+	dest := core.MakeIndirectObject(core.MakeArray(page, core.MakeName("Fit")))
+	dests := makeIndirect(map[string]core.PdfObject{"Names": core.MakeArray(core.MakeString("d1"), dest)})
+	names := core.MakeDict()
+	names.Set("Dests", dests)
+
+	catalog := makeIndirect(map[string]core.PdfObject{
+		"Type": core.MakeName("Catalog"), "Pages": pages, "AcroForm": acroForm, "Names": names})
+	info := makeIndirect(map[string]core.PdfObject{"Producer": core.MakeString("test")})
+
+	// The orphaned page references live objects, which are kept, and an
+	// orphaned stream, which is removed along with the page.
+	orphanStream, err := core.MakeStream([]byte("q Q"), nil)
+	require.NoError(t, err)
+	orphanPage := makeIndirect(map[string]core.PdfObject{
+		"Type": core.MakeName("Page"), "Parent": pages, "Contents": orphanStream, "Resources": resources})
+	orphanDict := makeIndirect(map[string]core.PdfObject{"Type": core.MakeName("Font")})
+
+	objects := []core.PdfObject{catalog, info, pages, page, contents, font, orphanPage,
+		orphanStream, acroForm, field, orphanDict, dests, dest}
+
+	opt := optimize.PruneUnusedObjects{}
+	opt.SetTrailerObjects([]core.PdfObject{catalog, info})
+	optimized, err := opt.Optimize(objects)
+	require.NoError(t, err)
+	require.Equal(t, 3, opt.Pruned)
+	require.Equal(t, []core.PdfObject{catalog, info, pages, page, contents, font, acroForm, field, dests, dest}, optimized)
+
+	// Without trailer objects, the objects are reached from the catalog.
+	opt = optimize.PruneUnusedObjects{}
+	optimized, err = opt.Optimize(objects)
+	require.NoError(t, err)
+	require.Equal(t, 4, opt.Pruned)
+	require.NotContains(t, optimized, info)
+
+	// Documents written with the optimizer keep all their pages.
+	w := model.NewPdfWriter()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, w.AddPage(model.NewPdfPage()))
+	}
+	w.SetOptimizer(optimize.New(optimize.Options{PruneUnusedObjects: true}))
+	var buf bytes.Buffer
+	require.NoError(t, w.Write(&buf))
+
+	reader, err := model.NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	numPages, err := reader.GetNumPages()
+	require.NoError(t, err)
+	require.Equal(t, 3, numPages)
+	require.Contains(t, buf.String(), "/Producer")
+}
+
+// Test combining duplicate fonts.
+func TestCombineDuplicateFonts(t *testing.T) {
+	// makeFont returns a TrueType font embedding the font program `program`.
+	makeFont := func(program []byte) (font, descriptor *core.PdfIndirectObject, fontFile *core.PdfObjectStream) {
+		fontFile, err := core.MakeStream(program, nil)
+		require.NoError(t, err)
+		descDict := core.MakeDict()
+		descDict.Set("Type", core.MakeName("FontDescriptor"))
+		descDict.Set("FontName", core.MakeName("ABCDEF+Test"))
+		descDict.Set("FontFile2", fontFile)
+		descriptor = core.MakeIndirectObject(descDict)
+
+		fontDict := core.MakeDict()
+		fontDict.Set("Type", core.MakeName("Font"))
+		fontDict.Set("Subtype", core.MakeName("TrueType"))
+		fontDict.Set("BaseFont", core.MakeName("ABCDEF+Test"))
+		fontDict.Set("FontDescriptor", descriptor)
+		return core.MakeIndirectObject(fontDict), descriptor, fontFile
+	}
+
+	font1, desc1, file1 := makeFont([]byte("font program"))
+	font2, desc2, file2 := makeFont([]byte("font program"))
+	font3, desc3, file3 := makeFont([]byte("other program"))
+
+	fonts1 := core.MakeDict()
+	fonts1.Set("F1", font1)
+	fonts1.Set("F3", font3)
+	fonts2 := core.MakeDict()
+	fonts2.Set("F1", font2)
+	resources1 := core.MakeIndirectObject(core.MakeDict())
+	resources1.PdfObject.(*core.PdfObjectDictionary).Set("Font", fonts1)
+	resources2 := core.MakeIndirectObject(core.MakeDict())
+	resources2.PdfObject.(*core.PdfObjectDictionary).Set("Font", fonts2)
+
+	objects := []core.PdfObject{resources1, resources2, font1, desc1, file1, font2, desc2, file2, font3, desc3, file3}
+	opt := optimize.CombineDuplicateFonts{}
+	optimized, err := opt.Optimize(objects)
+	require.NoError(t, err)
+	require.Equal(t, 1, opt.Combined)
+
+	// The duplicate font, along with its descriptor and font program, is
+	// removed and replaced by the first font.
+	expected := []core.PdfObject{resources1, resources2, font1, desc1, file1, font3, desc3, file3}
+	require.Len(t, optimized, len(expected))
+	for i, obj := range expected {
+		require.Same(t, obj, optimized[i])
+	}
+	require.Same(t, font1, fonts1.Get("F1"))
+	require.Same(t, font1, fonts2.Get("F1"))
+	require.Same(t, font3, fonts1.Get("F3"))
+}
@@ -23,25 +23,49 @@ func New(options Options) *Chain {
 		imageOptimizer.ImageUpperPPI = options.ImageUpperPPI
 		chain.Append(imageOptimizer)
 	}
-	if options.ImageQuality > 0 {
+	if options.ImageTargetDPI > 0 {
+		chain.Append(&DownsampleImages{TargetDPI: options.ImageTargetDPI})
+	}
+	if options.AutoImageCodec {
+		chain.Append(&AutoImageCodec{ImageQuality: options.ImageQuality})
+	} else if options.ImageQuality > 0 {
 		imageOptimizer := new(Image)
 		imageOptimizer.ImageQuality = options.ImageQuality
 		chain.Append(imageOptimizer)
 	}
+	if options.HoistResources {
+		chain.Append(new(HoistResources))
+	}
 	if options.CombineDuplicateDirectObjects {
 		chain.Append(new(CombineDuplicateDirectObjects))
 	}
+	if options.CombineDuplicateImages {
+		chain.Append(new(CombineDuplicateImages))
+	}
+	if options.CombineDuplicateFonts {
+		chain.Append(new(CombineDuplicateFonts))
+	}
 	if options.CombineDuplicateStreams {
 		chain.Append(new(CombineDuplicateStreams))
 	}
 	if options.CombineIdenticalIndirectObjects {
 		chain.Append(new(CombineIdenticalIndirectObjects))
 	}
+	if options.PruneUnusedObjects {
+		chain.Append(new(PruneUnusedObjects))
+	}
 	if options.UseObjectStreams {
-		chain.Append(new(ObjectStreams))
+		chain.Append(&ObjectStreams{MaxObjectsPerStream: options.ObjectStreamMaxObjects})
 	}
 	if options.CompressStreams {
-		chain.Append(new(CompressStreams))
+		chain.Append(&CompressStreams{
+			CompressionLevel:        options.CompressionLevel,
+			ImagePredictor:          options.CompressionImagePredictor,
+			RecompressFlate:         options.RecompressFlateStreams,
+			Concurrency:             options.CompressionConcurrency,
+			MinSize:                 options.CompressionMinSize,
+			CompressWithDecodeParms: options.CompressWithDecodeParms,
+		})
 	}
 	return chain
 }
@@ -8,13 +8,26 @@ package optimize
 // Options describes PDF optimization parameters.
 type Options struct {
 	CombineDuplicateStreams         bool
+	CombineDuplicateImages          bool
 	CombineDuplicateDirectObjects   bool
 	ImageUpperPPI                   float64
 	ImageQuality                    int
 	UseObjectStreams                bool
+	ObjectStreamMaxObjects          int
 	CombineIdenticalIndirectObjects bool
 	CompressStreams                 bool
+	CompressionLevel                int
+	CompressionImagePredictor       int
+	RecompressFlateStreams          bool
+	CompressionConcurrency          int
+	CompressionMinSize              int
+	CompressWithDecodeParms         bool
 	CleanFonts                      bool
 	SubsetFonts                     bool
 	CleanContentstream              bool
+	HoistResources                  bool
+	AutoImageCodec                  bool
+	ImageTargetDPI                  float64
+	PruneUnusedObjects              bool
+	CombineDuplicateFonts           bool
 }
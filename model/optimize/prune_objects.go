@@ -0,0 +1,110 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package optimize
+
+import (
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// PruneUnusedObjects removes the indirect objects which cannot be reached
+// from the trailer dictionary, such as the objects orphaned by the edition
+// of a document. The objects are reached by following all the references of
+// the dictionaries, arrays and streams, starting from the trailer objects
+// (see SetTrailerObjects), which include the document catalog, and through
+// it the page tree, the interactive form (AcroForm), the name trees (Names)
+// and all the other objects of the document. If the trailer objects are not
+// set, the objects are reached from the document catalog.
+// Object streams are left unchanged, which is why the optimizer should run
+// before the objects are packed into object streams.
+// The number of objects removed by the last run of the optimizer is reported
+// in the Pruned field.
+// It implements interfaces model.Optimizer and model.TrailerObjectsSetter.
+type PruneUnusedObjects struct {
+	// Pruned is the number of objects removed by the last run of the
+	// optimizer.
+	Pruned int
+
+	trailerObjects []core.PdfObject
+}
+
+// SetTrailerObjects sets the objects referenced from the trailer dictionary,
+// from which the used objects are reached.
+// It implements interface model.TrailerObjectsSetter.
+func (p *PruneUnusedObjects) SetTrailerObjects(objects []core.PdfObject) {
+	p.trailerObjects = objects
+}
+
+// Optimize optimizes PDF objects to decrease PDF size.
+func (p *PruneUnusedObjects) Optimize(objects []core.PdfObject) (optimizedObjects []core.PdfObject, err error) {
+	p.Pruned = 0
+	roots := p.trailerObjects
+	if len(roots) == 0 {
+		for _, obj := range objects {
+			if dict, ok := core.GetDict(obj); ok {
+				if kind, _ := core.GetNameVal(dict.Get("Type")); kind == "Catalog" {
+					roots = append(roots, obj)
+					break
+				}
+			}
+		}
+	}
+	if len(roots) == 0 {
+		// Without roots, all the objects would be removed.
+		return objects, nil
+	}
+
+	reachable := findReachableObjects(roots)
+	optimizedObjects = make([]core.PdfObject, 0, len(objects))
+	for _, obj := range objects {
+		switch obj.(type) {
+		case *core.PdfIndirectObject, *core.PdfObjectStream:
+			if _, ok := reachable[obj]; !ok {
+				p.Pruned++
+				continue
+			}
+		}
+		optimizedObjects = append(optimizedObjects, obj)
+	}
+	return optimizedObjects, nil
+}
+
+// findReachableObjects returns the objects which can be reached from the
+// `roots` objects by following the entries of the dictionaries, the elements
+// of the arrays, the dictionaries of the streams and the references.
+func findReachableObjects(roots []core.PdfObject) map[core.PdfObject]struct{} {
+	reachable := make(map[core.PdfObject]struct{})
+	stack := make([]core.PdfObject, len(roots))
+	copy(stack, roots)
+	for len(stack) > 0 {
+		obj := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if obj == nil {
+			continue
+		}
+		if _, ok := reachable[obj]; ok {
+			continue
+		}
+		reachable[obj] = struct{}{}
+
+		switch t := obj.(type) {
+		case *core.PdfObjectReference:
+			stack = append(stack, t.Resolve())
+		case *core.PdfIndirectObject:
+			stack = append(stack, t.PdfObject)
+		case *core.PdfObjectStream:
+			if t.PdfObjectDictionary != nil {
+				stack = append(stack, t.PdfObjectDictionary)
+			}
+		case *core.PdfObjectDictionary:
+			for _, key := range t.Keys() {
+				stack = append(stack, t.Get(key))
+			}
+		case *core.PdfObjectArray:
+			stack = append(stack, t.Elements()...)
+		}
+	}
+	return reachable
+}
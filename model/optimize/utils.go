@@ -100,3 +100,11 @@ func getPageContents(contentsObj core.PdfObject) (contents string, objs []core.P
 	}
 	return buf.String(), objs
 }
+
+// maxInt returns the greater of `a` and `b`.
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
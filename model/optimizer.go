@@ -16,3 +16,11 @@ import (
 type Optimizer interface {
 	Optimize(objects []core.PdfObject) ([]core.PdfObject, error)
 }
+
+// TrailerObjectsSetter is implemented by optimizers which need to know the objects referenced from the trailer
+// dictionary (the document catalog, information and encryption dictionaries).
+//
+// SetTrailerObjects is called by the writer with the trailer `objects` prior to calling Optimize.
+type TrailerObjectsSetter interface {
+	SetTrailerObjects(objects []core.PdfObject)
+}
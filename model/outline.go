@@ -35,6 +35,15 @@ func NewOutlineDest(page int64, x, y float64) OutlineDest {
 	}
 }
 
+// NewOutlineDestFit returns a new destination which displays the page with
+// the specified index, magnified to fit the entire page in the window.
+func NewOutlineDestFit(page int64) OutlineDest {
+	return OutlineDest{
+		Page: page,
+		Mode: "Fit",
+	}
+}
+
 // newOutlineDestFromPdfObject creates a new outline destination from the
 // specified PDF object.
 func newOutlineDestFromPdfObject(o core.PdfObject, r *PdfReader) (*OutlineDest, error) {
@@ -0,0 +1,193 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/transform"
+)
+
+// getVisibleBox returns the region of the page which is displayed, which is
+// the CropBox of the page, or the MediaBox if the CropBox is not set.
+func (p *PdfPage) getVisibleBox() (*PdfRectangle, error) {
+	if p.CropBox != nil {
+		return p.CropBox, nil
+	}
+	return p.GetMediaBox()
+}
+
+// getRotation returns the page rotation, normalized to 0, 90, 180 or 270.
+func (p *PdfPage) getRotation() int64 {
+	if p.Rotate == nil {
+		return 0
+	}
+	rotate := (*p.Rotate%360 + 360) % 360
+	return rotate - rotate%90
+}
+
+// getDisplayMatrix returns the matrix mapping the visible box of the page to
+// the coordinate system of the displayed page, which has its origin at the
+// lower left corner of the displayed page, taking the page rotation into
+// account. The width and height of the displayed page are also returned.
+func (p *PdfPage) getDisplayMatrix() (transform.Matrix, float64, float64, error) {
+	box, err := p.getVisibleBox()
+	if err != nil {
+		return transform.Matrix{}, 0, 0, err
+	}
+
+	w, h := box.Width(), box.Height()
+	switch p.getRotation() {
+	case 90:
+		return transform.NewMatrix(0, -1, 1, 0, -box.Lly, box.Llx+w), h, w, nil
+	case 180:
+		return transform.NewMatrix(-1, 0, 0, -1, box.Llx+w, box.Lly+h), w, h, nil
+	case 270:
+		return transform.NewMatrix(0, 1, -1, 0, box.Lly+h, -box.Llx), h, w, nil
+	}
+	return transform.NewMatrix(1, 0, 0, 1, -box.Llx, -box.Lly), w, h, nil
+}
+
+// getPageMatrix returns the inverse of the display matrix of the page, which
+// maps the coordinate system of the displayed page to the page coordinates.
+func (p *PdfPage) getPageMatrix() (transform.Matrix, float64, float64, error) {
+	box, err := p.getVisibleBox()
+	if err != nil {
+		return transform.Matrix{}, 0, 0, err
+	}
+
+	w, h := box.Width(), box.Height()
+	switch p.getRotation() {
+	case 90:
+		return transform.NewMatrix(0, 1, -1, 0, box.Llx+w, box.Lly), h, w, nil
+	case 180:
+		return transform.NewMatrix(-1, 0, 0, -1, box.Llx+w, box.Lly+h), w, h, nil
+	case 270:
+		return transform.NewMatrix(0, -1, 1, 0, box.Llx, box.Lly+h), h, w, nil
+	}
+	return transform.NewMatrix(1, 0, 0, 1, box.Llx, box.Lly), w, h, nil
+}
+
+// ToXObjectForm returns a form XObject containing the contents of the page.
+// The bounding box of the form is the visible box of the page. The form
+// matrix maps the page contents upright, as the page is displayed, with the
+// lower left corner of the displayed page at the origin.
+// The resources of the form are a deep copy of the resources of the page, so
+// that the form can be modified or added to other documents without affecting
+// the page.
+func (p *PdfPage) ToXObjectForm() (*XObjectForm, error) {
+	content, err := p.GetAllContentStreams()
+	if err != nil {
+		return nil, err
+	}
+
+	resources := NewPdfPageResources()
+	if p.Resources != nil {
+		copier := core.NewObjectCopier(0)
+		if dict, ok := core.GetDict(copier.Copy(p.Resources.ToPdfObject())); ok {
+			if resources, err = NewPdfPageResourcesFromDict(dict); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return p.newXObjectForm(content, resources)
+}
+
+// newXObjectForm returns a form XObject with the specified content and
+// resources, which has the bounding box and matrix of the page, as described
+// by ToXObjectForm.
+func (p *PdfPage) newXObjectForm(content string, resources *PdfPageResources) (*XObjectForm, error) {
+	box, err := p.getVisibleBox()
+	if err != nil {
+		return nil, err
+	}
+	matrix, _, _, err := p.getDisplayMatrix()
+	if err != nil {
+		return nil, err
+	}
+
+	xform := NewXObjectForm()
+	xform.BBox = box.ToPdfObject()
+	if matrix != transform.IdentityMatrix() {
+		xform.Matrix = core.MakeArrayFromFloats([]float64{
+			matrix[0], matrix[1], matrix[3], matrix[4], matrix[6], matrix[7],
+		})
+	}
+	xform.Resources = resources
+	if err := xform.SetContentStream([]byte(content), core.NewFlateEncoder()); err != nil {
+		return nil, err
+	}
+
+	return xform, nil
+}
+
+// Overlay draws the contents of the `stamp` page on the `base` page, either
+// behind or in front of the existing contents. The stamp page is added to the
+// resources of the base page as a form XObject, using an unused resource
+// name. The stamp is scaled to fit the rectangle `rect`, preserving its
+// aspect ratio, and centered in it. The rectangle is specified in the
+// coordinate system of the displayed base page, with the origin at its lower
+// left corner. If `rect` is nil, the stamp is fitted to the whole page.
+// The rotations of both pages are taken into account, so that the stamp is
+// displayed upright on the base page.
+func Overlay(base, stamp *PdfPage, rect *PdfRectangle, behind bool) error {
+	if base == nil || stamp == nil {
+		return errors.New("base and stamp pages must be specified")
+	}
+
+	xform, err := stamp.ToXObjectForm()
+	if err != nil {
+		return err
+	}
+	_, stampWidth, stampHeight, err := stamp.getDisplayMatrix()
+	if err != nil {
+		return err
+	}
+	if stampWidth <= 0 || stampHeight <= 0 {
+		return errors.New("invalid stamp page size")
+	}
+
+	pageMatrix, width, height, err := base.getPageMatrix()
+	if err != nil {
+		return err
+	}
+	if rect == nil {
+		rect = &PdfRectangle{Urx: width, Ury: height}
+	}
+
+	// Scale the stamp to fit the rectangle and center it.
+	scale := math.Min(rect.Width()/stampWidth, rect.Height()/stampHeight)
+	if scale <= 0 {
+		return errors.New("invalid overlay rectangle")
+	}
+	tx := rect.Llx + (rect.Width()-scale*stampWidth)/2
+	ty := rect.Lly + (rect.Height()-scale*stampHeight)/2
+
+	if base.Resources == nil {
+		base.Resources = NewPdfPageResources()
+	}
+	name := base.Resources.GenerateXObjectName()
+	if err := base.Resources.SetXObjectFormByName(name, xform); err != nil {
+		return err
+	}
+
+	m := pageMatrix
+	content := fmt.Sprintf("q\n%.4f %.4f %.4f %.4f %.4f %.4f cm\n%.4f 0 0 %.4f %.4f %.4f cm\n/%s Do\nQ",
+		m[0], m[1], m[3], m[4], m[6], m[7], scale, scale, tx, ty, name)
+	if behind {
+		return base.PrependContentStreamByString(content)
+	}
+
+	// Isolate the existing contents, so that the stamp is not affected by
+	// their graphics state changes.
+	if err := base.PrependContentStreamByString("q"); err != nil {
+		return err
+	}
+	return base.AddContentStreamByString("Q\n" + content)
+}
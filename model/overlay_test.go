@@ -0,0 +1,99 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+func TestPageToXObjectForm(t *testing.T) {
+	page := NewPdfPage()
+	page.MediaBox = &PdfRectangle{Llx: 10, Lly: 20, Urx: 210, Ury: 120}
+	require.NoError(t, page.AddContentStreamByString("0 0 m 10 10 l S"))
+
+	xform, err := page.ToXObjectForm()
+	require.NoError(t, err)
+
+	bbox, ok := core.GetArray(xform.BBox)
+	require.True(t, ok)
+	vals, err := bbox.ToFloat64Array()
+	require.NoError(t, err)
+	require.Equal(t, []float64{10, 20, 210, 120}, vals)
+
+	matrix, ok := core.GetArray(xform.Matrix)
+	require.True(t, ok)
+	vals, err = matrix.ToFloat64Array()
+	require.NoError(t, err)
+	require.Equal(t, []float64{1, 0, 0, 1, -10, -20}, vals)
+
+	xform.ToPdfObject()
+	content, err := xform.GetContentStream()
+	require.NoError(t, err)
+	require.Contains(t, string(content), "0 0 m 10 10 l S")
+
+	// Rotated pages are mapped upright.
+	rotate := int64(90)
+	page.Rotate = &rotate
+	xform, err = page.ToXObjectForm()
+	require.NoError(t, err)
+	matrix, ok = core.GetArray(xform.Matrix)
+	require.True(t, ok)
+	vals, err = matrix.ToFloat64Array()
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, -1, 1, 0, -20, 210}, vals)
+
+	// The resources of the page are copied.
+	font := core.MakeIndirectObject(core.MakeDict())
+	page.Resources = NewPdfPageResources()
+	require.NoError(t, page.Resources.SetFontByName("F1", font))
+	xform, err = page.ToXObjectForm()
+	require.NoError(t, err)
+	require.NotSame(t, page.Resources, xform.Resources)
+	fontObj, ok := xform.Resources.GetFontByName("F1")
+	require.True(t, ok)
+	require.NotSame(t, font, fontObj)
+	require.NoError(t, xform.Resources.SetFontByName("F2", font))
+	require.False(t, page.Resources.HasFontByName("F2"))
+}
+
+func TestOverlay(t *testing.T) {
+	newBase := func() *PdfPage {
+		base := NewPdfPage()
+		base.MediaBox = &PdfRectangle{Urx: 400, Ury: 400}
+		require.NoError(t, base.AddContentStreamByString("1 0 0 rg"))
+		return base
+	}
+	stamp := NewPdfPage()
+	stamp.MediaBox = &PdfRectangle{Urx: 200, Ury: 100}
+	require.NoError(t, stamp.AddContentStreamByString("0 0 50 50 re f"))
+
+	// Stamp in front, fitted to the whole page.
+	base := newBase()
+	require.NoError(t, Overlay(base, stamp, nil, false))
+	require.True(t, base.Resources.HasXObjectByName("XObj1"))
+
+	content, err := base.GetAllContentStreams()
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(content, "q"))
+	require.Contains(t, content, "2.0000 0 0 2.0000 0.0000 100.0000 cm\n/XObj1 Do")
+	require.True(t, strings.Index(content, "1 0 0 rg") < strings.Index(content, "/XObj1 Do"))
+
+	// Stamp behind, fitted to a rectangle. Resource names do not collide.
+	require.NoError(t, Overlay(base, stamp, &PdfRectangle{Llx: 100, Lly: 100, Urx: 200, Ury: 300}, true))
+	require.True(t, base.Resources.HasXObjectByName("XObj2"))
+
+	content, err = base.GetAllContentStreams()
+	require.NoError(t, err)
+	require.Contains(t, content, "0.5000 0 0 0.5000 100.0000 175.0000 cm\n/XObj2 Do")
+	require.True(t, strings.Index(content, "/XObj2 Do") < strings.Index(content, "1 0 0 rg"))
+
+	require.Error(t, Overlay(base, nil, nil, false))
+}
@@ -748,25 +748,63 @@ func (p *PdfPage) AddContentStreamByString(contentStr string) error {
 	return nil
 }
 
-// AppendContentStream adds content stream by string.  Appends to the last
-// contentstream instance if many.
-func (p *PdfPage) AppendContentStream(contentStr string) error {
-	cstreams, err := p.GetContentStreams()
+// PrependContentStreamByString adds content stream by string before the
+// existing content streams of the page. Puts the content string into a stream
+// object and inserts it at the beginning of the page Contents.
+func (p *PdfPage) PrependContentStreamByString(contentStr string) error {
+	stream, err := core.MakeStream([]byte(contentStr), core.NewFlateEncoder())
 	if err != nil {
 		return err
 	}
-	if len(cstreams) == 0 {
-		cstreams = []string{contentStr}
+
+	if p.Contents == nil {
+		// If not set, place it directly.
+		p.Contents = stream
+	} else if contArray, isArray := core.GetArray(p.Contents); isArray {
+		// If an array of content streams, prepend it.
+		p.Contents = core.MakeArray(append([]core.PdfObject{stream}, contArray.Elements()...)...)
+	} else {
+		// Only 1 element in place. Wrap inside a new array and add the new one.
+		p.Contents = core.MakeArray(stream, p.Contents)
+	}
+
+	return nil
+}
+
+// AppendContentStream adds content stream by string.  Appends to the last
+// contentstream instance if many. Only the last content stream is rewritten,
+// the other content streams of the page are left unchanged.
+func (p *PdfPage) AppendContentStream(contentStr string) error {
+	contArray, isArray := core.GetArray(p.Contents)
+	if !isArray || contArray.Len() == 0 {
+		cstreams, err := p.GetContentStreams()
+		if err != nil {
+			return err
+		}
+		if len(cstreams) == 0 {
+			cstreams = []string{contentStr}
+			return p.SetContentStreams(cstreams, core.NewFlateEncoder())
+		}
+		cstreams[0] = cstreams[0] + "\n" + contentStr
 		return p.SetContentStreams(cstreams, core.NewFlateEncoder())
 	}
 
+	last := contArray.Len() - 1
+	lastStr, err := getContentStreamAsString(contArray.Get(last))
+	if err != nil {
+		return err
+	}
+
 	var buf bytes.Buffer
-	buf.WriteString(cstreams[len(cstreams)-1])
+	buf.WriteString(lastStr)
 	buf.WriteString("\n")
 	buf.WriteString(contentStr)
-	cstreams[len(cstreams)-1] = buf.String()
 
-	return p.SetContentStreams(cstreams, core.NewFlateEncoder())
+	stream, err := core.MakeStream(buf.Bytes(), core.NewFlateEncoder())
+	if err != nil {
+		return err
+	}
+	return contArray.Set(last, stream)
 }
 
 // SetContentStreams sets the content streams based on a string array. Will make
@@ -863,12 +901,14 @@ func (p *PdfPage) GetContentStreams() ([]string, error) {
 }
 
 // GetAllContentStreams gets all the content streams for a page as one string.
+// The content streams are separated by newlines, so that tokens at the end of
+// a content stream cannot merge with tokens at the beginning of the next one.
 func (p *PdfPage) GetAllContentStreams() (string, error) {
 	cstreams, err := p.GetContentStreams()
 	if err != nil {
 		return "", err
 	}
-	return strings.Join(cstreams, " "), nil
+	return strings.Join(cstreams, "\n"), nil
 }
 
 // PdfPageResourcesColorspaces contains the colorspace in the PdfPageResources.
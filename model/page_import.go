@@ -0,0 +1,215 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+	"github.com/bcmmbaga/unipdf-agpl/v3/internal/transform"
+)
+
+// ImportPageAsForm returns a form XObject containing the contents of the
+// page with number `pageNum` (starting from 1) of the document loaded by
+// `reader`. The form can be used in other documents, for example for drawing
+// the page as a template or for placing several pages on a single sheet.
+// The bounding box and the matrix of the form are set as described by
+// PdfPage.ToXObjectForm. The page attributes inherited from the page tree
+// (resources, MediaBox, CropBox and Rotate) are taken into account.
+// The resources of the page are deep copied, so that the form does not share
+// any objects with the source document. When the form is written, the copied
+// objects are numbered in the object numbering space of the destination.
+// If `includeAnnotations` is true, the normal appearances of the visible page
+// annotations are drawn on top of the page contents.
+func ImportPageAsForm(reader *PdfReader, pageNum int, includeAnnotations bool) (*XObjectForm, error) {
+	if reader == nil {
+		return nil, errors.New("reader not specified")
+	}
+	page, err := reader.GetPage(pageNum)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the effective page attributes.
+	imported := NewPdfPage()
+	if imported.MediaBox, err = page.GetMediaBox(); err != nil {
+		return nil, err
+	}
+	imported.CropBox = page.CropBox
+	if imported.CropBox == nil {
+		if arr, ok := core.GetArray(page.getInheritedAttribute("CropBox")); ok {
+			if imported.CropBox, err = NewPdfRectangle(*arr); err != nil {
+				return nil, err
+			}
+		}
+	}
+	imported.Rotate = page.Rotate
+	if imported.Rotate == nil {
+		if rotate, ok := core.GetIntVal(page.getInheritedAttribute("Rotate")); ok {
+			r := int64(rotate)
+			imported.Rotate = &r
+		}
+	}
+
+	copier := core.NewObjectCopier(0)
+	resDict := core.MakeDict()
+	if page.Resources != nil {
+		if dict, ok := core.GetDict(copier.Copy(page.Resources.ToPdfObject())); ok {
+			resDict = dict
+		}
+	}
+	if imported.Resources, err = NewPdfPageResourcesFromDict(resDict); err != nil {
+		return nil, err
+	}
+
+	content, err := page.GetAllContentStreams()
+	if err != nil {
+		return nil, err
+	}
+	if includeAnnotations {
+		annotContent, err := importAnnotationAppearances(page, imported.Resources, copier)
+		if err != nil {
+			return nil, err
+		}
+		if annotContent != "" {
+			content = "q\n" + content + "\nQ\n" + annotContent
+		}
+	}
+
+	return imported.newXObjectForm(content, imported.Resources)
+}
+
+// getInheritedAttribute returns the value of the inheritable attribute `key`
+// from the closest ancestor of the page which defines it, or nil if none does.
+func (p *PdfPage) getInheritedAttribute(key core.PdfObjectName) core.PdfObject {
+	node := p.Parent
+	for depth := 0; node != nil && depth < 100; depth++ {
+		dict, ok := core.GetDict(node)
+		if !ok {
+			return nil
+		}
+		if obj := dict.Get(key); obj != nil {
+			return core.ResolveReference(obj)
+		}
+		node = dict.Get("Parent")
+	}
+	return nil
+}
+
+// importAnnotationAppearances adds copies of the normal appearances of the
+// visible annotations of `page` to `resources` and returns the content stream
+// operations drawing them, as specified in section 12.5.5 "Appearance
+// Streams" (p. 395 PDF32000_2008).
+func importAnnotationAppearances(page *PdfPage, resources *PdfPageResources,
+	copier *core.ObjectCopier) (string, error) {
+	annotations, err := page.GetAnnotations()
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	for _, annot := range annotations {
+		// Skip annotations with the Hidden (bit 2) or NoView (bit 6) flags set.
+		if flags, ok := core.GetIntVal(annot.F); ok && flags&(1<<1|1<<5) != 0 {
+			continue
+		}
+
+		stream := getNormalAppearance(annot)
+		if stream == nil {
+			continue
+		}
+		rectArr, ok := core.GetArray(annot.Rect)
+		if !ok {
+			continue
+		}
+		rect, err := NewPdfRectangle(*rectArr)
+		if err != nil {
+			common.Log.Debug("ERROR: invalid annotation rectangle: %v", err)
+			continue
+		}
+
+		// Compute the matrix mapping the transformed appearance bounding box
+		// to the annotation rectangle.
+		bboxArr, ok := core.GetArray(stream.Get("BBox"))
+		if !ok {
+			continue
+		}
+		bbox, err := NewPdfRectangle(*bboxArr)
+		if err != nil {
+			common.Log.Debug("ERROR: invalid appearance bounding box: %v", err)
+			continue
+		}
+		matrix := transform.IdentityMatrix()
+		if arr, ok := core.GetArray(stream.Get("Matrix")); ok {
+			vals, err := arr.ToFloat64Array()
+			if err != nil || len(vals) != 6 {
+				common.Log.Debug("ERROR: invalid appearance matrix")
+				continue
+			}
+			matrix = transform.NewMatrix(vals[0], vals[1], vals[2], vals[3], vals[4], vals[5])
+		}
+		box := transformRectangle(matrix, bbox)
+		if box.Width() == 0 || box.Height() == 0 {
+			continue
+		}
+		sx, sy := rect.Width()/box.Width(), rect.Height()/box.Height()
+
+		appearance, ok := copier.Copy(stream).(*core.PdfObjectStream)
+		if !ok {
+			continue
+		}
+		name := resources.GenerateXObjectName()
+		if err := resources.SetXObjectByName(name, appearance); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&b, "q\n%.4f 0 0 %.4f %.4f %.4f cm\n/%s Do\nQ\n",
+			sx, sy, rect.Llx-box.Llx*sx, rect.Lly-box.Lly*sy, name)
+	}
+
+	return b.String(), nil
+}
+
+// getNormalAppearance returns the normal appearance stream of the
+// annotation, selected using the appearance state for annotations with
+// multiple appearance states, or nil if the annotation has none.
+func getNormalAppearance(annot *PdfAnnotation) *core.PdfObjectStream {
+	apDict, ok := core.GetDict(annot.AP)
+	if !ok {
+		return nil
+	}
+	obj := core.ResolveReference(apDict.Get("N"))
+	if stateDict, ok := core.GetDict(obj); ok {
+		state, ok := core.GetName(annot.AS)
+		if !ok {
+			return nil
+		}
+		obj = core.ResolveReference(stateDict.Get(*state))
+	}
+	stream, _ := core.GetStream(obj)
+	return stream
+}
+
+// transformRectangle returns the smallest rectangle containing the corners of
+// `rect`, transformed by the matrix `m`.
+func transformRectangle(m transform.Matrix, rect *PdfRectangle) *PdfRectangle {
+	res := &PdfRectangle{
+		Llx: math.Inf(1), Lly: math.Inf(1),
+		Urx: math.Inf(-1), Ury: math.Inf(-1),
+	}
+	for _, corner := range [][2]float64{
+		{rect.Llx, rect.Lly}, {rect.Llx, rect.Ury},
+		{rect.Urx, rect.Lly}, {rect.Urx, rect.Ury},
+	} {
+		x, y := m.Transform(corner[0], corner[1])
+		res.Llx, res.Urx = math.Min(res.Llx, x), math.Max(res.Urx, x)
+		res.Lly, res.Ury = math.Min(res.Lly, y), math.Max(res.Ury, y)
+	}
+	return res
+}
@@ -0,0 +1,129 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// newImportTestReader returns a reader for a single page document, which
+// contains text and two square annotations, one of them hidden.
+func newImportTestReader(t *testing.T) *PdfReader {
+	page := NewPdfPage()
+	page.MediaBox = &PdfRectangle{Urx: 300, Ury: 200}
+	font, err := NewStandard14Font(HelveticaName)
+	require.NoError(t, err)
+	require.NoError(t, page.AddFont("F1", font.ToPdfObject()))
+	require.NoError(t, page.AddContentStreamByString("BT /F1 12 Tf (Hello) Tj ET"))
+
+	for _, flags := range []int64{0, 2} {
+		appearance, err := core.MakeStream([]byte("0 0 10 10 re f"), nil)
+		require.NoError(t, err)
+		appearance.Set("Type", core.MakeName("XObject"))
+		appearance.Set("Subtype", core.MakeName("Form"))
+		appearance.Set("BBox", core.MakeArrayFromFloats([]float64{0, 0, 10, 10}))
+
+		annot := NewPdfAnnotationSquare()
+		annot.Rect = core.MakeArrayFromFloats([]float64{100, 100, 120, 120})
+		annot.F = core.MakeInteger(flags)
+		apDict := core.MakeDict()
+		apDict.Set("N", appearance)
+		annot.AP = apDict
+		page.AddAnnotation(annot.PdfAnnotation)
+	}
+
+	w := NewPdfWriter()
+	require.NoError(t, w.AddPage(page))
+	return writeAndReload(t, &w)
+}
+
+func TestImportPageAsForm(t *testing.T) {
+	reader := newImportTestReader(t)
+
+	xform, err := ImportPageAsForm(reader, 1, false)
+	require.NoError(t, err)
+
+	// The resources are copied.
+	srcPage, err := reader.GetPage(1)
+	require.NoError(t, err)
+	srcFont, ok := srcPage.Resources.GetFontByName("F1")
+	require.True(t, ok)
+	font, ok := xform.Resources.GetFontByName("F1")
+	require.True(t, ok)
+	require.NotEqual(t, core.ResolveReference(srcFont), font)
+	fontDict, ok := core.GetDict(font)
+	require.True(t, ok)
+	baseFont, _ := core.GetNameVal(fontDict.Get("BaseFont"))
+	require.Equal(t, "Helvetica", baseFont)
+
+	xform.ToPdfObject()
+	content, err := xform.GetContentStream()
+	require.NoError(t, err)
+	require.Contains(t, string(content), "(Hello) Tj")
+	require.NotContains(t, string(content), "Do")
+
+	// Only the visible annotations are drawn.
+	xform, err = ImportPageAsForm(reader, 1, true)
+	require.NoError(t, err)
+	require.True(t, xform.Resources.HasXObjectByName("XObj1"))
+	require.False(t, xform.Resources.HasXObjectByName("XObj2"))
+
+	xform.ToPdfObject()
+	content, err = xform.GetContentStream()
+	require.NoError(t, err)
+	require.Contains(t, string(content), "2.0000 0 0 2.0000 100.0000 100.0000 cm\n/XObj1 Do")
+
+	// The form can be used in another document.
+	page := NewPdfPage()
+	page.MediaBox = &PdfRectangle{Urx: 600, Ury: 400}
+	require.NoError(t, page.Resources.SetXObjectFormByName("Page1", xform))
+	require.NoError(t, page.AddContentStreamByString("q 2 0 0 2 0 0 cm /Page1 Do Q"))
+	w := NewPdfWriter()
+	require.NoError(t, w.AddPage(page))
+	dst := writeAndReload(t, &w)
+	dstPage, err := dst.GetPage(1)
+	require.NoError(t, err)
+	dstForm, err := dstPage.Resources.GetXObjectFormByName("Page1")
+	require.NoError(t, err)
+	require.NotNil(t, dstForm)
+	require.True(t, dstForm.Resources.HasFontByName("F1"))
+
+	_, err = ImportPageAsForm(reader, 2, false)
+	require.Error(t, err)
+}
+
+func TestImportPageAsFormInherited(t *testing.T) {
+	reader := newImportTestReader(t)
+	page, err := reader.GetPage(1)
+	require.NoError(t, err)
+
+	// Inherit the CropBox and the rotation from the parent node.
+	parent := core.MakeDict()
+	parent.Set("CropBox", core.MakeArrayFromFloats([]float64{10, 20, 110, 220}))
+	parent.Set("Rotate", core.MakeInteger(90))
+	page.Parent = core.MakeIndirectObject(parent)
+	page.MediaBox = nil
+	parent.Set("MediaBox", core.MakeArrayFromFloats([]float64{0, 0, 300, 200}))
+
+	xform, err := ImportPageAsForm(reader, 1, false)
+	require.NoError(t, err)
+
+	bbox, ok := core.GetArray(xform.BBox)
+	require.True(t, ok)
+	vals, err := bbox.ToFloat64Array()
+	require.NoError(t, err)
+	require.Equal(t, []float64{10, 20, 110, 220}, vals)
+
+	matrix, ok := core.GetArray(xform.Matrix)
+	require.True(t, ok)
+	vals, err = matrix.ToFloat64Array()
+	require.NoError(t, err)
+	require.Equal(t, []float64{0, -1, 1, 0, -20, 110}, vals)
+}
@@ -0,0 +1,106 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// TabOrder specifies the tab order used for the annotations of a page
+// (section 12.5 "Annotations" p. 381 PDF32000_2008).
+type TabOrder string
+
+// Tab orders.
+const (
+	TabOrderRow         TabOrder = "R" // Row order.
+	TabOrderColumn      TabOrder = "C" // Column order.
+	TabOrderStructure   TabOrder = "S" // Structure order.
+	TabOrderAnnotations TabOrder = "A" // Annotations array order (PDF 2.0).
+	TabOrderWidgets     TabOrder = "W" // Widget order (PDF 2.0).
+)
+
+// IsValid checks if the tab order is one of the values defined by the PDF
+// specification.
+func (o TabOrder) IsValid() bool {
+	switch o {
+	case TabOrderRow, TabOrderColumn, TabOrderStructure,
+		TabOrderAnnotations, TabOrderWidgets:
+		return true
+	}
+	return false
+}
+
+// GetTabOrder returns the tab order of the annotations of the page, or an
+// empty string if the page does not specify one.
+func (p *PdfPage) GetTabOrder() TabOrder {
+	name, _ := core.GetNameVal(p.Tabs)
+	return TabOrder(name)
+}
+
+// SetTabOrder sets the tab order of the annotations of the page. An empty
+// tab order removes the Tabs entry of the page.
+func (p *PdfPage) SetTabOrder(order TabOrder) error {
+	if order == "" {
+		p.Tabs = nil
+		return nil
+	}
+	if !order.IsValid() {
+		common.Log.Debug("ERROR: invalid tab order: %s", order)
+		return errors.New("invalid tab order")
+	}
+	p.Tabs = core.MakeName(string(order))
+	return nil
+}
+
+// SetWidgetTabOrder reorders the widget annotations of the page so that the
+// specified `widgets` are visited in the given order when the page uses the
+// annotations array order (TabOrderAnnotations), or the widget order
+// (TabOrderWidgets). The widgets take the positions of the annotations array
+// previously occupied by them, in the specified order, while the positions of
+// the other annotations are kept.
+// All the widgets must be annotations of the page.
+func (p *PdfPage) SetWidgetTabOrder(widgets []*PdfAnnotationWidget) error {
+	annotations, err := p.GetAnnotations()
+	if err != nil {
+		return err
+	}
+
+	positions := map[*PdfAnnotation]int{}
+	for i, annot := range annotations {
+		positions[annot] = i
+	}
+
+	var slots []int
+	seen := map[*PdfAnnotation]struct{}{}
+	for _, widget := range widgets {
+		if widget == nil || widget.PdfAnnotation == nil {
+			return errors.New("invalid widget annotation")
+		}
+		pos, ok := positions[widget.PdfAnnotation]
+		if !ok {
+			common.Log.Debug("ERROR: widget annotation not found on page")
+			return errors.New("widget annotation not found on page")
+		}
+		if _, ok := seen[widget.PdfAnnotation]; ok {
+			return errors.New("duplicate widget annotation")
+		}
+		seen[widget.PdfAnnotation] = struct{}{}
+		slots = append(slots, pos)
+	}
+	sort.Ints(slots)
+
+	reordered := make([]*PdfAnnotation, len(annotations))
+	copy(reordered, annotations)
+	for i, widget := range widgets {
+		reordered[slots[i]] = widget.PdfAnnotation
+	}
+	p.annotations = reordered
+	return nil
+}
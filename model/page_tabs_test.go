@@ -0,0 +1,143 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// newTabOrderTestReader returns a reader for a single page form, which
+// contains the text fields `names` and a square annotation. The widgets of
+// the fields are placed in reverse order in the annotations of the page.
+func newTabOrderTestReader(t *testing.T, order TabOrder, names ...string) *PdfReader {
+	page := NewPdfPage()
+	page.MediaBox = &PdfRectangle{Urx: 200, Ury: 400}
+	require.NoError(t, page.SetTabOrder(order))
+
+	var fields []*PdfField
+	var widgets []*PdfAnnotationWidget
+	for i, name := range names {
+		field := NewPdfField()
+		field.SetContext(&PdfFieldText{PdfField: field})
+		field.T = core.MakeString(name)
+
+		widget := NewPdfAnnotationWidget()
+		widget.Rect = core.MakeArrayFromFloats([]float64{10, float64(100 * i), 100, float64(100*i + 20)})
+		widget.Parent = field.ToPdfObject()
+		field.Annotations = append(field.Annotations, widget)
+		fields = append(fields, field)
+		widgets = append([]*PdfAnnotationWidget{widget}, widgets...)
+
+		page.AddAnnotation(widget.PdfAnnotation)
+		if i == 0 {
+			square := NewPdfAnnotationSquare()
+			square.Rect = core.MakeArrayFromFloats([]float64{0, 0, 10, 10})
+			page.AddAnnotation(square.PdfAnnotation)
+		}
+	}
+	require.NoError(t, page.SetWidgetTabOrder(widgets))
+
+	form := NewPdfAcroForm()
+	form.Fields = &fields
+
+	w := NewPdfWriter()
+	require.NoError(t, w.AddPage(page))
+	require.NoError(t, w.SetForms(form))
+	return writeAndReload(t, &w)
+}
+
+// getWidgetNames returns the names of the fields of the widget annotations
+// of `page`, in the order of the annotations.
+func getWidgetNames(t *testing.T, page *PdfPage) []string {
+	annotations, err := page.GetAnnotations()
+	require.NoError(t, err)
+
+	var names []string
+	for _, annot := range annotations {
+		widget, ok := annot.GetContext().(*PdfAnnotationWidget)
+		if !ok {
+			continue
+		}
+		parent, ok := core.GetDict(widget.Parent)
+		require.True(t, ok)
+		name, ok := core.GetString(parent.Get("T"))
+		require.True(t, ok)
+		names = append(names, name.Decoded())
+	}
+	return names
+}
+
+func TestSetWidgetTabOrder(t *testing.T) {
+	reader := newTabOrderTestReader(t, TabOrderAnnotations, "a", "b", "c")
+	page, err := reader.GetPage(1)
+	require.NoError(t, err)
+	require.Equal(t, TabOrderAnnotations, page.GetTabOrder())
+	require.Equal(t, []string{"c", "b", "a"}, getWidgetNames(t, page))
+
+	// The position of the square annotation is kept.
+	annotations, err := page.GetAnnotations()
+	require.NoError(t, err)
+	require.Len(t, annotations, 4)
+	_, ok := annotations[1].GetContext().(*PdfAnnotationSquare)
+	require.True(t, ok)
+
+	require.Error(t, page.SetWidgetTabOrder([]*PdfAnnotationWidget{NewPdfAnnotationWidget()}))
+	require.Error(t, page.SetTabOrder("X"))
+	require.NoError(t, page.SetTabOrder(""))
+	require.Nil(t, page.Tabs)
+}
+
+func TestMergeFormsTabOrder(t *testing.T) {
+	reader1 := newTabOrderTestReader(t, TabOrderStructure, "a", "b")
+	reader2 := newTabOrderTestReader(t, TabOrderRow, "c", "d")
+
+	// Combine the pages and the fields of both forms.
+	w := NewPdfWriter()
+	form := NewPdfAcroForm()
+	var fields []*PdfField
+	for _, reader := range []*PdfReader{reader1, reader2} {
+		page, err := reader.GetPage(1)
+		require.NoError(t, err)
+		require.NoError(t, w.AddPage(page))
+		fields = append(fields, reader.AcroForm.AllFields()...)
+	}
+	form.Fields = &fields
+	require.NoError(t, w.SetForms(form))
+
+	reader := writeAndReload(t, &w)
+	page1, err := reader.GetPage(1)
+	require.NoError(t, err)
+	require.Equal(t, TabOrderStructure, page1.GetTabOrder())
+	require.Equal(t, []string{"b", "a"}, getWidgetNames(t, page1))
+
+	page2, err := reader.GetPage(2)
+	require.NoError(t, err)
+	require.Equal(t, TabOrderRow, page2.GetTabOrder())
+	require.Equal(t, []string{"d", "c"}, getWidgetNames(t, page2))
+
+	// Merge the page of the second form into the page of a form without
+	// tab order.
+	reader3 := newTabOrderTestReader(t, "", "e")
+	appender, err := NewPdfAppender(reader3)
+	require.NoError(t, err)
+	srcPage, err := reader2.GetPage(1)
+	require.NoError(t, err)
+	require.NoError(t, appender.MergePageWith(1, srcPage))
+
+	var buf bytes.Buffer
+	require.NoError(t, appender.Write(&buf))
+	merged, err := NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	page, err := merged.GetPage(1)
+	require.NoError(t, err)
+	require.Equal(t, TabOrderRow, page.GetTabOrder())
+	require.Equal(t, []string{"e", "d", "c"}, getWidgetNames(t, page))
+}
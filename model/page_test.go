@@ -9,6 +9,8 @@ import (
 	"io"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+
 	"github.com/bcmmbaga/unipdf-agpl/v3/common"
 	"github.com/bcmmbaga/unipdf-agpl/v3/core"
 )
@@ -382,3 +384,32 @@ func TestRect(t *testing.T) {
 		return
 	}
 }
+
+func TestPageContentStreamArray(t *testing.T) {
+	// The path painting operator of the rectangle and the parameters of the
+	// color operator are split across content stream boundaries.
+	page := NewPdfPage()
+	require.NoError(t, page.SetContentStreams([]string{"q 0 0 10 10 re", "f 0.5", "g Q"}, nil))
+
+	contArray, ok := core.GetArray(page.Contents)
+	require.True(t, ok)
+	require.Equal(t, 3, contArray.Len())
+
+	content, err := page.GetAllContentStreams()
+	require.NoError(t, err)
+	require.Equal(t, "q 0 0 10 10 re\nf 0.5\ng Q", content)
+
+	// Appending must preserve the array and the other content streams.
+	first, second := contArray.Get(0), contArray.Get(1)
+	require.NoError(t, page.AppendContentStream("0 0 m 10 10 l S"))
+
+	contArray, ok = core.GetArray(page.Contents)
+	require.True(t, ok)
+	require.Equal(t, 3, contArray.Len())
+	require.True(t, first == contArray.Get(0))
+	require.True(t, second == contArray.Get(1))
+
+	cstreams, err := page.GetContentStreams()
+	require.NoError(t, err)
+	require.Equal(t, []string{"q 0 0 10 10 re", "f 0.5", "g Q\n0 0 m 10 10 l S"}, cstreams)
+}
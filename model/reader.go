@@ -41,40 +41,27 @@ type PdfReader struct {
 	rs        io.ReadSeeker
 }
 
+// ReaderOpts defines the options for creating PdfReader instances.
+type ReaderOpts struct {
+	// LazyLoad enables lazy-loading mode, as described by NewPdfReaderLazy.
+	LazyLoad bool
+
+	// PassthroughUnsupportedFilters enables passing through the streams
+	// encoded with unsupported filters. Such streams are left undecoded
+	// (the decoded data is the raw stream data) instead of failing, so that
+	// the rest of the document can still be loaded, and they are written
+	// unchanged. The streams which were left undecoded are returned by
+	// GetUndecodedStreams.
+	PassthroughUnsupportedFilters bool
+}
+
 // NewPdfReader returns a new PdfReader for an input io.ReadSeeker interface. Can be used to read PDF from
 // memory or file. Immediately loads and traverses the PDF structure including pages and page contents (if
 // not encrypted). Loads entire document structure into memory.
 // Alternatively a lazy-loading reader can be created with NewPdfReaderLazy which loads only references,
 // and references are loaded from disk into memory on an as-needed basis.
 func NewPdfReader(rs io.ReadSeeker) (*PdfReader, error) {
-	pdfReader := &PdfReader{
-		rs:           rs,
-		traversed:    map[core.PdfObject]struct{}{},
-		modelManager: newModelManager(),
-		isLazy:       false,
-	}
-
-	// Create the parser, loads the cross reference table and trailer.
-	parser, err := core.NewParser(rs)
-	if err != nil {
-		return nil, err
-	}
-	pdfReader.parser = parser
-
-	isEncrypted, err := pdfReader.IsEncrypted()
-	if err != nil {
-		return nil, err
-	}
-
-	// Load pdf doc structure if not encrypted.
-	if !isEncrypted {
-		err = pdfReader.loadStructure()
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	return pdfReader, nil
+	return NewPdfReaderWithOpts(rs, nil)
 }
 
 // NewPdfReaderLazy creates a new PdfReader for `rs` in lazy-loading mode. The difference
@@ -83,11 +70,21 @@ func NewPdfReader(rs io.ReadSeeker) (*PdfReader, error) {
 // Note that it may make sense to use the lazy-load reader when processing only parts of files,
 // rather than loading entire file into memory. Example: splitting a few pages from a large PDF file.
 func NewPdfReaderLazy(rs io.ReadSeeker) (*PdfReader, error) {
+	return NewPdfReaderWithOpts(rs, &ReaderOpts{LazyLoad: true})
+}
+
+// NewPdfReaderWithOpts creates a new PdfReader for `rs` using the specified
+// options. If `opts` is nil, the default options are used, which is
+// equivalent to NewPdfReader.
+func NewPdfReaderWithOpts(rs io.ReadSeeker, opts *ReaderOpts) (*PdfReader, error) {
+	if opts == nil {
+		opts = &ReaderOpts{}
+	}
 	pdfReader := &PdfReader{
 		rs:           rs,
 		traversed:    map[core.PdfObject]struct{}{},
 		modelManager: newModelManager(),
-		isLazy:       true,
+		isLazy:       opts.LazyLoad,
 	}
 
 	// Create the parser, loads the cross reference table and trailer.
@@ -95,6 +92,7 @@ func NewPdfReaderLazy(rs io.ReadSeeker) (*PdfReader, error) {
 	if err != nil {
 		return nil, err
 	}
+	parser.SetPassthroughUnsupportedFilters(opts.PassthroughUnsupportedFilters)
 	pdfReader.parser = parser
 
 	isEncrypted, err := pdfReader.IsEncrypted()
@@ -113,6 +111,14 @@ func NewPdfReaderLazy(rs io.ReadSeeker) (*PdfReader, error) {
 	return pdfReader, nil
 }
 
+// GetUndecodedStreams returns the streams of the document which were left
+// undecoded as they are encoded with unsupported filters. The streams are
+// only passed through if enabled by the PassthroughUnsupportedFilters reader
+// option. The object numbers of the streams identify them in the document.
+func (r *PdfReader) GetUndecodedStreams() []*core.PdfObjectStream {
+	return r.parser.GetUndecodedStreams()
+}
+
 // PdfVersion returns version of the PDF file.
 func (r *PdfReader) PdfVersion() core.Version {
 	return r.parser.PdfVersion()
@@ -7,6 +7,7 @@ package model
 
 import (
 	"bytes"
+	"errors"
 	"os"
 	"testing"
 
@@ -47,3 +48,58 @@ func TestReaderLazy(t *testing.T) {
 	err = writer.Write(&buf)
 	require.NoError(t, err)
 }
+
+func TestReaderPassthroughUnsupportedFilters(t *testing.T) {
+	// Create a document with a content stream encoded with an unknown filter.
+	stream, err := core.MakeStream([]byte("encoded data"), nil)
+	require.NoError(t, err)
+	stream.Set("Filter", core.MakeName("UnknownDecode"))
+	page := NewPdfPage()
+	page.Contents = core.MakeArray(stream)
+
+	w := NewPdfWriter()
+	require.NoError(t, w.AddPage(page))
+	var buf bytes.Buffer
+	require.NoError(t, w.Write(&buf))
+
+	// Decoding fails by default.
+	reader, err := NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	page, err = reader.GetPage(1)
+	require.NoError(t, err)
+	_, err = page.GetAllContentStreams()
+	require.True(t, errors.Is(err, core.ErrUnsupportedFilter))
+	require.Empty(t, reader.GetUndecodedStreams())
+
+	// The raw data is returned in passthrough mode.
+	reader, err = NewPdfReaderWithOpts(bytes.NewReader(buf.Bytes()), &ReaderOpts{
+		PassthroughUnsupportedFilters: true,
+	})
+	require.NoError(t, err)
+	page, err = reader.GetPage(1)
+	require.NoError(t, err)
+	content, err := page.GetAllContentStreams()
+	require.NoError(t, err)
+	require.Equal(t, "encoded data", content)
+	_, err = page.GetAllContentStreams()
+	require.NoError(t, err)
+
+	undecoded := reader.GetUndecodedStreams()
+	require.Len(t, undecoded, 1)
+	filter, _ := core.GetNameVal(undecoded[0].Get("Filter"))
+	require.Equal(t, "UnknownDecode", filter)
+
+	// The stream is written unchanged.
+	w = NewPdfWriter()
+	require.NoError(t, w.AddPage(page))
+	reader = writeAndReload(t, &w)
+	page, err = reader.GetPage(1)
+	require.NoError(t, err)
+	streams, ok := core.GetArray(page.Contents)
+	require.True(t, ok)
+	written, ok := core.GetStream(streams.Get(0))
+	require.True(t, ok)
+	require.Equal(t, "encoded data", string(written.Stream))
+	filter, _ = core.GetNameVal(written.Get("Filter"))
+	require.Equal(t, "UnknownDecode", filter)
+}
@@ -0,0 +1,157 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// PageStats represents size related statistics of a page. It is useful for
+// diagnosing large documents and for quantifying the effect of optimizations.
+type PageStats struct {
+	// ContentStreamSize is the size in bytes of the encoded content streams.
+	ContentStreamSize int
+
+	// DecodedContentStreamSize is the size in bytes of the decoded content streams.
+	DecodedContentStreamSize int
+
+	// ImageCount is the number of distinct image XObjects used by the page,
+	// including the images used by form XObjects.
+	ImageCount int
+
+	// ImageSize is the size in bytes of the encoded image XObject streams.
+	ImageSize int
+
+	// FontCount is the number of distinct fonts used by the page, including
+	// the fonts used by form XObjects.
+	FontCount int
+
+	// AnnotationCount is the number of annotations of the page.
+	AnnotationCount int
+}
+
+// DocumentStats represents size related statistics of a document.
+type DocumentStats struct {
+	// Pages contains the statistics of each page of the document.
+	Pages []*PageStats
+
+	// ObjectCount is the number of objects in the document.
+	ObjectCount int
+}
+
+// Analyze walks the pages of the document and returns statistics about their
+// content streams, images, fonts and annotations.
+func (r *PdfReader) Analyze() (*DocumentStats, error) {
+	stats := &DocumentStats{
+		ObjectCount: len(r.GetObjectNums()),
+	}
+
+	for _, page := range r.PageList {
+		pageStats, err := page.Stats()
+		if err != nil {
+			return nil, err
+		}
+		stats.Pages = append(stats.Pages, pageStats)
+	}
+
+	return stats, nil
+}
+
+// Stats returns statistics about the content streams, images, fonts and
+// annotations of the page.
+func (p *PdfPage) Stats() (*PageStats, error) {
+	stats := &PageStats{}
+
+	// Content streams.
+	if p.Contents != nil {
+		var cstreams []core.PdfObject
+		if arr, ok := core.GetArray(p.Contents); ok {
+			cstreams = arr.Elements()
+		} else {
+			cstreams = []core.PdfObject{p.Contents}
+		}
+
+		for _, obj := range cstreams {
+			stream, ok := core.GetStream(obj)
+			if !ok {
+				continue
+			}
+			stats.ContentStreamSize += len(stream.Stream)
+
+			decoded, err := core.DecodeStream(stream)
+			if err != nil {
+				common.Log.Debug("ERROR: unable to decode content stream: %v", err)
+				continue
+			}
+			stats.DecodedContentStreamSize += len(decoded)
+		}
+	}
+
+	// Resources.
+	if p.Resources != nil {
+		images := map[core.PdfObject]struct{}{}
+		fonts := map[core.PdfObject]struct{}{}
+		collectResourceStats(p.Resources.ToPdfObject(), images, fonts, map[core.PdfObject]struct{}{})
+
+		stats.ImageCount = len(images)
+		stats.FontCount = len(fonts)
+		for obj := range images {
+			if stream, ok := core.GetStream(obj); ok {
+				stats.ImageSize += len(stream.Stream)
+			}
+		}
+	}
+
+	// Annotations.
+	annotations, err := p.GetAnnotations()
+	if err != nil {
+		return nil, err
+	}
+	stats.AnnotationCount = len(annotations)
+
+	return stats, nil
+}
+
+// collectResourceStats collects the images and fonts found in the resource
+// dictionary `resources`. The resources of form XObjects are traversed
+// recursively. The `visited` map prevents processing the same form twice.
+func collectResourceStats(resources core.PdfObject, images, fonts, visited map[core.PdfObject]struct{}) {
+	resDict, ok := core.GetDict(resources)
+	if !ok {
+		return
+	}
+
+	if fontDict, ok := core.GetDict(resDict.Get("Font")); ok {
+		for _, key := range fontDict.Keys() {
+			if font := core.ResolveReference(fontDict.Get(key)); font != nil {
+				fonts[font] = struct{}{}
+			}
+		}
+	}
+
+	xobjDict, ok := core.GetDict(resDict.Get("XObject"))
+	if !ok {
+		return
+	}
+	for _, key := range xobjDict.Keys() {
+		stream, ok := core.GetStream(xobjDict.Get(key))
+		if !ok {
+			continue
+		}
+
+		switch subtype, _ := core.GetNameVal(stream.Get("Subtype")); subtype {
+		case "Image":
+			images[stream] = struct{}{}
+		case "Form":
+			if _, ok := visited[stream]; ok {
+				continue
+			}
+			visited[stream] = struct{}{}
+			collectResourceStats(stream.Get("Resources"), images, fonts, visited)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+func TestReaderAnalyze(t *testing.T) {
+	f, err := os.Open("./testdata/img1-1.pdf")
+	require.NoError(t, err)
+	defer f.Close()
+
+	reader, err := NewPdfReader(f)
+	require.NoError(t, err)
+
+	stats, err := reader.Analyze()
+	require.NoError(t, err)
+	require.Len(t, stats.Pages, len(reader.PageList))
+	require.Equal(t, len(reader.GetObjectNums()), stats.ObjectCount)
+
+	page := stats.Pages[0]
+	require.Greater(t, page.ContentStreamSize, 0)
+	require.GreaterOrEqual(t, page.DecodedContentStreamSize, page.ContentStreamSize)
+	require.Equal(t, 1, page.ImageCount)
+	require.Greater(t, page.ImageSize, 0)
+	require.Equal(t, 1, page.FontCount)
+	require.Equal(t, 0, page.AnnotationCount)
+}
+
+func TestPageStatsFontsAnnotations(t *testing.T) {
+	helvetica, err := NewStandard14Font(HelveticaName)
+	require.NoError(t, err)
+	courier, err := NewStandard14Font(CourierName)
+	require.NoError(t, err)
+	helvObj := helvetica.ToPdfObject()
+
+	// The page uses Helvetica and a form XObject using Helvetica and
+	// Courier, so it uses 2 distinct fonts.
+	xform := NewXObjectForm()
+	xform.Resources = NewPdfPageResources()
+	require.NoError(t, xform.Resources.SetFontByName("F1", helvObj))
+	require.NoError(t, xform.Resources.SetFontByName("F2", courier.ToPdfObject()))
+	require.NoError(t, xform.SetContentStream([]byte("BT /F1 10 Tf (a) Tj /F2 10 Tf (b) Tj ET"), nil))
+
+	page := NewPdfPage()
+	page.MediaBox = &PdfRectangle{Urx: 200, Ury: 200}
+	require.NoError(t, page.Resources.SetFontByName("F1", helvObj))
+	require.NoError(t, page.Resources.SetXObjectFormByName("Fm1", xform))
+	require.NoError(t, page.SetContentStreams([]string{"BT /F1 10 Tf (c) Tj ET /Fm1 Do"}, nil))
+
+	text := NewPdfAnnotationText()
+	text.Rect = core.MakeArrayFromFloats([]float64{10, 10, 30, 30})
+	link := NewPdfAnnotationLink()
+	link.Rect = core.MakeArrayFromFloats([]float64{40, 10, 80, 30})
+	page.AddAnnotation(text.PdfAnnotation)
+	page.AddAnnotation(link.PdfAnnotation)
+
+	writer := NewPdfWriter()
+	require.NoError(t, writer.AddPage(page))
+	var buf bytes.Buffer
+	require.NoError(t, writer.Write(&buf))
+
+	reader, err := NewPdfReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	stats, err := reader.Analyze()
+	require.NoError(t, err)
+	require.Len(t, stats.Pages, 1)
+	require.Equal(t, 2, stats.Pages[0].FontCount)
+	require.Equal(t, 2, stats.Pages[0].AnnotationCount)
+	require.Equal(t, 0, stats.Pages[0].ImageCount)
+}
@@ -0,0 +1,325 @@
+/*
+ * This file is subject to the terms and conditions defined in
+ * file 'LICENSE.md', which is part of this source code package.
+ */
+
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/bcmmbaga/unipdf-agpl/v3/common"
+	"github.com/bcmmbaga/unipdf-agpl/v3/core"
+)
+
+// Direction represents the predominant reading order of text.
+type Direction string
+
+// Reading directions.
+const (
+	DirectionL2R Direction = "L2R" // Left to right.
+	DirectionR2L Direction = "R2L" // Right to left (including vertical writing systems).
+)
+
+// PrintScaling represents the page scaling option selected in the print
+// dialog when the document is printed.
+type PrintScaling string
+
+// Print scaling options.
+const (
+	PrintScalingNone       PrintScaling = "None"       // No page scaling.
+	PrintScalingAppDefault PrintScaling = "AppDefault" // Default scaling of the conforming reader.
+)
+
+// Duplex represents the paper handling option used when printing.
+type Duplex string
+
+// Duplex options.
+const (
+	DuplexSimplex       Duplex = "Simplex"             // Print single-sided.
+	DuplexFlipShortEdge Duplex = "DuplexFlipShortEdge" // Duplex and flip on the short edge of the sheet.
+	DuplexFlipLongEdge  Duplex = "DuplexFlipLongEdge"  // Duplex and flip on the long edge of the sheet.
+)
+
+// ViewerPreferences represents the viewer preferences of a document, which
+// control the way the document is presented on the screen or in print
+// (section 12.2 "Viewer Preferences" p. 360 PDF32000_2008).
+// Unset (zero value) fields are not written.
+type ViewerPreferences struct {
+	HideToolbar     bool // Hide the toolbar of the viewer application.
+	HideMenubar     bool // Hide the menu bar of the viewer application.
+	HideWindowUI    bool // Hide the user interface elements in the document's window.
+	FitWindow       bool // Resize the document's window to fit the first displayed page.
+	CenterWindow    bool // Position the document's window in the center of the screen.
+	DisplayDocTitle bool // Display the document title instead of the file name in the title bar.
+
+	// NonFullScreenPageMode specifies how to display the document on exiting
+	// full-screen mode. Valid values are PageModeUseNone, PageModeUseOutlines,
+	// PageModeUseThumbs and PageModeUseOC.
+	NonFullScreenPageMode PageMode
+
+	// Direction is the predominant reading order of text.
+	Direction Direction
+
+	// ViewArea, ViewClip, PrintArea and PrintClip are the names of the page
+	// boundaries (MediaBox, CropBox, BleedBox, TrimBox or ArtBox) used when
+	// viewing or printing the document.
+	ViewArea  string
+	ViewClip  string
+	PrintArea string
+	PrintClip string
+
+	// PrintScaling is the page scaling option of the print dialog.
+	PrintScaling PrintScaling
+
+	// Duplex is the paper handling option used when printing.
+	Duplex Duplex
+
+	// PickTrayByPDFSize specifies whether the page size is used to select
+	// the input paper tray. If nil, the choice is left to the viewer.
+	PickTrayByPDFSize *bool
+
+	// PrintPageRange contains pairs of page numbers (starting at 1) which
+	// specify the page ranges selected in the print dialog.
+	PrintPageRange []int
+
+	// NumCopies is the number of copies selected in the print dialog.
+	NumCopies int
+}
+
+// isValidPageBoundary checks if `name` is the name of a page boundary.
+func isValidPageBoundary(name string) bool {
+	switch name {
+	case "MediaBox", "CropBox", "BleedBox", "TrimBox", "ArtBox":
+		return true
+	}
+	return false
+}
+
+// isValidNonFullScreenPageMode checks if `mode` can be used as the
+// NonFullScreenPageMode viewer preference.
+func isValidNonFullScreenPageMode(mode PageMode) bool {
+	switch mode {
+	case PageModeUseNone, PageModeUseOutlines, PageModeUseThumbs, PageModeUseOC:
+		return true
+	}
+	return false
+}
+
+// isValidDirection checks if `dir` is a valid reading direction.
+func isValidDirection(dir Direction) bool {
+	return dir == DirectionL2R || dir == DirectionR2L
+}
+
+// isValidPrintScaling checks if `ps` is a valid print scaling option.
+func isValidPrintScaling(ps PrintScaling) bool {
+	return ps == PrintScalingNone || ps == PrintScalingAppDefault
+}
+
+// isValidDuplex checks if `duplex` is a valid paper handling option.
+func isValidDuplex(duplex Duplex) bool {
+	switch duplex {
+	case DuplexSimplex, DuplexFlipShortEdge, DuplexFlipLongEdge:
+		return true
+	}
+	return false
+}
+
+// isValidPrintPageRange checks if `pages` contains valid pairs of page numbers.
+func isValidPrintPageRange(pages []int) bool {
+	if len(pages)%2 != 0 {
+		return false
+	}
+	for i := 0; i < len(pages); i += 2 {
+		if pages[i] < 1 || pages[i+1] < pages[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate checks that the enumeration entries of the viewer preferences
+// have valid values. Unset entries are not checked.
+func (vp *ViewerPreferences) Validate() error {
+	if vp.NonFullScreenPageMode != "" && !isValidNonFullScreenPageMode(vp.NonFullScreenPageMode) {
+		return fmt.Errorf("invalid NonFullScreenPageMode: %s", vp.NonFullScreenPageMode)
+	}
+	if vp.Direction != "" && !isValidDirection(vp.Direction) {
+		return fmt.Errorf("invalid Direction: %s", vp.Direction)
+	}
+	if vp.PrintScaling != "" && !isValidPrintScaling(vp.PrintScaling) {
+		return fmt.Errorf("invalid PrintScaling: %s (must be AppDefault or None)", vp.PrintScaling)
+	}
+	if vp.Duplex != "" && !isValidDuplex(vp.Duplex) {
+		return fmt.Errorf("invalid Duplex: %s", vp.Duplex)
+	}
+
+	boxes := []struct {
+		key string
+		val string
+	}{
+		{"ViewArea", vp.ViewArea},
+		{"ViewClip", vp.ViewClip},
+		{"PrintArea", vp.PrintArea},
+		{"PrintClip", vp.PrintClip},
+	}
+	for _, box := range boxes {
+		if box.val != "" && !isValidPageBoundary(box.val) {
+			return fmt.Errorf("invalid %s: %s", box.key, box.val)
+		}
+	}
+
+	if !isValidPrintPageRange(vp.PrintPageRange) {
+		return errors.New("invalid PrintPageRange")
+	}
+	if vp.NumCopies < 0 {
+		return fmt.Errorf("invalid NumCopies: %d", vp.NumCopies)
+	}
+
+	return nil
+}
+
+// ToPdfObject returns the viewer preferences dictionary.
+func (vp *ViewerPreferences) ToPdfObject() core.PdfObject {
+	d := core.MakeDict()
+
+	bools := []struct {
+		key core.PdfObjectName
+		val bool
+	}{
+		{"HideToolbar", vp.HideToolbar},
+		{"HideMenubar", vp.HideMenubar},
+		{"HideWindowUI", vp.HideWindowUI},
+		{"FitWindow", vp.FitWindow},
+		{"CenterWindow", vp.CenterWindow},
+		{"DisplayDocTitle", vp.DisplayDocTitle},
+	}
+	for _, b := range bools {
+		if b.val {
+			d.Set(b.key, core.MakeBool(true))
+		}
+	}
+
+	names := []struct {
+		key core.PdfObjectName
+		val string
+	}{
+		{"NonFullScreenPageMode", string(vp.NonFullScreenPageMode)},
+		{"Direction", string(vp.Direction)},
+		{"ViewArea", vp.ViewArea},
+		{"ViewClip", vp.ViewClip},
+		{"PrintArea", vp.PrintArea},
+		{"PrintClip", vp.PrintClip},
+		{"PrintScaling", string(vp.PrintScaling)},
+		{"Duplex", string(vp.Duplex)},
+	}
+	for _, n := range names {
+		if n.val != "" {
+			d.Set(n.key, core.MakeName(n.val))
+		}
+	}
+
+	if vp.PickTrayByPDFSize != nil {
+		d.Set("PickTrayByPDFSize", core.MakeBool(*vp.PickTrayByPDFSize))
+	}
+	if len(vp.PrintPageRange) > 0 {
+		d.Set("PrintPageRange", core.MakeArrayFromIntegers(vp.PrintPageRange))
+	}
+	if vp.NumCopies > 0 {
+		d.Set("NumCopies", core.MakeInteger(int64(vp.NumCopies)))
+	}
+
+	return d
+}
+
+// newViewerPreferencesFromPdfObject loads the viewer preferences from the
+// specified dictionary object. Invalid entries are ignored.
+func newViewerPreferencesFromPdfObject(obj core.PdfObject) (*ViewerPreferences, error) {
+	d, ok := core.GetDict(obj)
+	if !ok {
+		return nil, fmt.Errorf("invalid ViewerPreferences type (%T)", obj)
+	}
+
+	vp := &ViewerPreferences{}
+	vp.HideToolbar, _ = core.GetBoolVal(d.Get("HideToolbar"))
+	vp.HideMenubar, _ = core.GetBoolVal(d.Get("HideMenubar"))
+	vp.HideWindowUI, _ = core.GetBoolVal(d.Get("HideWindowUI"))
+	vp.FitWindow, _ = core.GetBoolVal(d.Get("FitWindow"))
+	vp.CenterWindow, _ = core.GetBoolVal(d.Get("CenterWindow"))
+	vp.DisplayDocTitle, _ = core.GetBoolVal(d.Get("DisplayDocTitle"))
+
+	// getName returns the name value of the entry with the specified key,
+	// if valid according to the `isValid` function.
+	getName := func(key core.PdfObjectName, isValid func(string) bool) string {
+		name, ok := core.GetNameVal(d.Get(key))
+		if !ok {
+			return ""
+		}
+		if !isValid(name) {
+			common.Log.Debug("ERROR: invalid ViewerPreferences %s (%s) - ignoring", key, name)
+			return ""
+		}
+		return name
+	}
+
+	vp.NonFullScreenPageMode = PageMode(getName("NonFullScreenPageMode", func(name string) bool {
+		return isValidNonFullScreenPageMode(PageMode(name))
+	}))
+	vp.Direction = Direction(getName("Direction", func(name string) bool {
+		return isValidDirection(Direction(name))
+	}))
+	vp.PrintScaling = PrintScaling(getName("PrintScaling", func(name string) bool {
+		return isValidPrintScaling(PrintScaling(name))
+	}))
+	vp.Duplex = Duplex(getName("Duplex", func(name string) bool {
+		return isValidDuplex(Duplex(name))
+	}))
+	vp.ViewArea = getName("ViewArea", isValidPageBoundary)
+	vp.ViewClip = getName("ViewClip", isValidPageBoundary)
+	vp.PrintArea = getName("PrintArea", isValidPageBoundary)
+	vp.PrintClip = getName("PrintClip", isValidPageBoundary)
+
+	if val, ok := core.GetBoolVal(d.Get("PickTrayByPDFSize")); ok {
+		vp.PickTrayByPDFSize = &val
+	}
+	if arr, ok := core.GetArray(d.Get("PrintPageRange")); ok {
+		pages, err := arr.ToIntegerArray()
+		if err != nil || !isValidPrintPageRange(pages) {
+			common.Log.Debug("ERROR: invalid ViewerPreferences PrintPageRange - ignoring")
+		} else {
+			vp.PrintPageRange = pages
+		}
+	}
+	if val, ok := core.GetIntVal(d.Get("NumCopies")); ok && val > 0 {
+		vp.NumCopies = val
+	}
+
+	return vp, nil
+}
+
+// GetViewerPreferences returns the viewer preferences of the document.
+// Returns nil if the document does not specify viewer preferences.
+func (r *PdfReader) GetViewerPreferences() (*ViewerPreferences, error) {
+	obj := core.ResolveReference(r.catalog.Get("ViewerPreferences"))
+	if obj == nil {
+		return nil, nil
+	}
+
+	return newViewerPreferencesFromPdfObject(obj)
+}
+
+// SetViewerPreferences sets the viewer preferences of the output document.
+// A nil value removes the viewer preferences.
+func (w *PdfWriter) SetViewerPreferences(vp *ViewerPreferences) error {
+	if vp == nil {
+		w.catalog.Remove("ViewerPreferences")
+		return nil
+	}
+	if err := vp.Validate(); err != nil {
+		return err
+	}
+
+	w.catalog.Set("ViewerPreferences", vp.ToPdfObject())
+	return nil
+}
@@ -998,6 +998,14 @@ func (w *PdfWriter) Write(writer io.Writer) error {
 	w.copyObjects()
 
 	if w.optimizer != nil {
+		if setter, ok := w.optimizer.(TrailerObjectsSetter); ok {
+			trailerObjects := []core.PdfObject{w.root, w.infoObj}
+			if w.encryptObj != nil {
+				trailerObjects = append(trailerObjects, w.encryptObj)
+			}
+			setter.SetTrailerObjects(trailerObjects)
+		}
+
 		var err error
 		w.objects, err = w.optimizer.Optimize(w.objects)
 		if err != nil {